@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+
+	"github.com/keptn/keptn/api/identity"
+	custommiddleware "github.com/keptn/keptn/api/middleware"
+	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/restapi/operations/auth"
+	"github.com/keptn/keptn/cp-common/audit"
+)
+
+const (
+	// rolesHeader and projectsHeader carry the roles and projects of the API token, so that the API gateway can forward them to the control plane
+	rolesHeader    = "X-Keptn-Roles"
+	projectsHeader = "X-Keptn-Projects"
+	// originalMethodHeader and originalURIHeader are set by the API gateway for its auth subrequests
+	originalMethodHeader = "X-Original-Method"
+	originalURIHeader    = "X-Original-URI"
+)
+
+type tokenAccessProvider interface {
+	GetTokenAccess(token string) (*custommiddleware.TokenAccess, error)
+}
+
+var tokenAccess tokenAccessProvider
+
+// SetTokenAccessProvider sets the provider for the roles and projects of the API tokens
+func SetTokenAccessProvider(provider tokenAccessProvider) {
+	tokenAccess = provider
+}
+
+// AuthHandlerFunc confirms that the caller has been authenticated and is allowed to send the original request of the API gateway.
+// The principal, roles and projects of the caller are returned in headers, so that the API gateway can forward them to the other services
+func AuthHandlerFunc(params auth.AuthParams, principal *identity.Principal) middleware.Responder {
+	access := getTokenAccess(principal)
+	if access == nil {
+		return sendForbidden()
+	}
+	if params.HTTPRequest != nil {
+		if uri := params.HTTPRequest.Header.Get(originalURIHeader); uri != "" && !access.AllowsRequest(params.HTTPRequest.Header.Get(originalMethodHeader), uri) {
+			return sendForbidden()
+		}
+	}
+	return middleware.ResponderFunc(func(rw http.ResponseWriter, producer runtime.Producer) {
+		rw.Header().Set(audit.PrincipalHeader, access.Principal)
+		rw.Header().Set(rolesHeader, strings.Join(access.Roles, ","))
+		rw.Header().Set(projectsHeader, strings.Join(access.Projects, ","))
+		auth.NewAuthOK().WriteResponse(rw, producer)
+	})
+}
+
+// getTokenAccess returns the roles and projects of the token the caller has been authenticated with.
+// If no provider has been set, or there is no principal, the caller is granted full access
+func getTokenAccess(principal *identity.Principal) *custommiddleware.TokenAccess {
+	if tokenAccess == nil || principal == nil {
+		return &custommiddleware.TokenAccess{Principal: getAuditPrincipal(principal), Roles: []string{custommiddleware.RoleAdmin}}
+	}
+	access, err := tokenAccess.GetTokenAccess(principal.Token)
+	if err != nil {
+		return nil
+	}
+	return access
+}
+
+func getAuditPrincipal(principal *identity.Principal) string {
+	if principal == nil {
+		return audit.PrincipalInternal
+	}
+	if tokenAccess != nil {
+		if access, err := tokenAccess.GetTokenAccess(principal.Token); err == nil {
+			return access.Principal
+		}
+	}
+	return audit.PrincipalFromToken(principal.Token)
+}
+
+func sendForbidden() middleware.Responder {
+	return middleware.ResponderFunc(func(rw http.ResponseWriter, producer runtime.Producer) {
+		rw.WriteHeader(http.StatusForbidden)
+		_ = producer.Produce(rw, &models.Error{Code: http.StatusForbidden, Message: swag.String("insufficient permissions")})
+	})
+}
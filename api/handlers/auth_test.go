@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/keptn/keptn/api/identity"
+	custommiddleware "github.com/keptn/keptn/api/middleware"
+	"github.com/keptn/keptn/api/restapi/operations/auth"
+)
+
+type tokenAccessProviderMock struct {
+	access map[string]*custommiddleware.TokenAccess
+}
+
+func (p *tokenAccessProviderMock) GetTokenAccess(token string) (*custommiddleware.TokenAccess, error) {
+	if access, ok := p.access[token]; ok {
+		return access, nil
+	}
+	return nil, custommiddleware.ErrTokenNotFound
+}
+
+func TestAuthHandlerFunc(t *testing.T) {
+	principal := identity.Principal{Token: "my-token"}
+
+	w := httptest.NewRecorder()
+	AuthHandlerFunc(auth.AuthParams{}, &principal).WriteResponse(w, &mockProducer{})
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Regexp(t, "^api-token:[0-9a-f]{8}$", w.Header().Get("X-Keptn-Principal"))
+	require.NotContains(t, w.Header().Get("X-Keptn-Principal"), "my-token")
+}
+
+func TestAuthHandlerFunc_NamedToken(t *testing.T) {
+	SetTokenAccessProvider(&tokenAccessProviderMock{access: map[string]*custommiddleware.TokenAccess{
+		"viewer-token": {Principal: "token:viewer", Roles: []string{custommiddleware.RoleViewer}, Projects: []string{"sockshop", "podtato-head"}},
+	}})
+	defer SetTokenAccessProvider(nil)
+	principal := identity.Principal{Token: "viewer-token"}
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/auth", nil)
+	request.Header.Set("X-Original-Method", http.MethodGet)
+	request.Header.Set("X-Original-URI", "/api/configuration-service/v1/project/sockshop/resource")
+	w := httptest.NewRecorder()
+	AuthHandlerFunc(auth.AuthParams{HTTPRequest: request}, &principal).WriteResponse(w, &mockProducer{})
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "token:viewer", w.Header().Get("X-Keptn-Principal"))
+	require.Equal(t, "viewer", w.Header().Get("X-Keptn-Roles"))
+	require.Equal(t, "sockshop,podtato-head", w.Header().Get("X-Keptn-Projects"))
+
+	request.Header.Set("X-Original-Method", http.MethodPut)
+	w = httptest.NewRecorder()
+	AuthHandlerFunc(auth.AuthParams{HTTPRequest: request}, &principal).WriteResponse(w, &mockProducer{})
+
+	require.Equal(t, http.StatusForbidden, w.Code)
+}
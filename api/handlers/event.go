@@ -1,7 +1,11 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net"
+	"net/http"
 	"net/url"
 	"strings"
 	"sync"
@@ -18,8 +22,12 @@ import (
 	"github.com/google/uuid"
 
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/api/identity"
 	"github.com/keptn/keptn/api/models"
 	"github.com/keptn/keptn/api/restapi/operations/event"
+	"github.com/keptn/keptn/api/tracing"
+	"github.com/keptn/keptn/cp-common/audit"
+	commontracing "github.com/keptn/keptn/cp-common/tracing"
 )
 
 //go:generate moq -pkg handlers_mock --skip-ensure -out ./fake/eventpublisher_mock.go . eventPublisher:EventPublisherMock
@@ -32,6 +40,13 @@ const defaultEventSource = "https://github.com/keptn/keptn/api"
 var eventHandlerInstance *EventHandler
 var instanceOnce = sync.Once{}
 
+var auditRecorder audit.Recorder
+
+// SetAuditRecorder sets the recorder for the audit records of the events that are sent via the API
+func SetAuditRecorder(recorder audit.Recorder) {
+	auditRecorder = recorder
+}
+
 type EventHandler struct {
 	EventPublisher eventPublisher
 }
@@ -47,7 +62,9 @@ func GetEventHandlerInstance() (*EventHandler, error) {
 	return eventHandlerInstance, nil
 }
 
-func (eh *EventHandler) PostEvent(event models.KeptnContextExtendedCE) (*models.EventContext, error) {
+// PostEvent publishes the given event to the event broker. The published event continues the trace of the given context,
+// unless the trace context is already carried in the extensions of the event
+func (eh *EventHandler) PostEvent(ctx context.Context, event models.KeptnContextExtendedCE) (*models.EventContext, error) {
 	keptnContext := createOrApplyKeptnContext(event.Shkeptncontext)
 
 	logger.Info("API received a keptn event")
@@ -73,7 +90,11 @@ func (eh *EventHandler) PostEvent(event models.KeptnContextExtendedCE) (*models.
 	outEvent.Contenttype = cloudevents.ApplicationJSON
 	outEvent.Shkeptncontext = keptnContext
 
-	if err := eh.EventPublisher.Publish(*outEvent); err != nil {
+	ctx, span := tracing.StartEventSpan(ctx, *outEvent, "api.PostEvent")
+	commontracing.InjectIntoEvent(ctx, outEvent)
+	err := eh.EventPublisher.Publish(*outEvent)
+	tracing.EndSpan(span, err)
+	if err != nil {
 		return nil, err
 	}
 
@@ -82,18 +103,89 @@ func (eh *EventHandler) PostEvent(event models.KeptnContextExtendedCE) (*models.
 }
 
 // PostEventHandlerFunc forwards an event to the event broker
-func PostEventHandlerFunc(params event.PostEventParams, principal *models.Principal) middleware.Responder {
+func PostEventHandlerFunc(params event.PostEventParams, principal *identity.Principal) middleware.Responder {
+	if !isEventAllowed(params, principal) {
+		recordEventAudit(params, principal, nil, http.StatusForbidden)
+		return sendForbidden()
+	}
 	eh, err := GetEventHandlerInstance()
 	if err != nil {
+		recordEventAudit(params, principal, nil, http.StatusInternalServerError)
 		return sendInternalErrorForPost(err)
 	}
-	keptnContext, err := eh.PostEvent(*params.Body)
+	keptnContext, err := eh.PostEvent(tracing.ContextFromRequest(params.HTTPRequest), *params.Body)
 	if err != nil {
+		recordEventAudit(params, principal, nil, http.StatusInternalServerError)
 		return sendInternalErrorForPost(err)
 	}
+	recordEventAudit(params, principal, keptnContext, http.StatusOK)
 	return event.NewPostEventOK().WithPayload(keptnContext)
 }
 
+// isEventAllowed checks whether the roles and projects of the caller's API token permit sending the event
+func isEventAllowed(params event.PostEventParams, principal *identity.Principal) bool {
+	access := getTokenAccess(principal)
+	if access == nil {
+		return false
+	}
+	if params.Body == nil {
+		return true
+	}
+	eventType := ""
+	if params.Body.Type != nil {
+		eventType = *params.Body.Type
+	}
+	eventData := keptnv2.EventData{}
+	_ = keptnv2.Decode(params.Body.Data, &eventData)
+	return access.AllowsEvent(eventType, eventData.Project)
+}
+
+// recordEventAudit records that an event has been sent via the API. Only the type and the scope of the event are included, not its data
+func recordEventAudit(params event.PostEventParams, principal *identity.Principal, eventContext *models.EventContext, statusCode int) {
+	if auditRecorder == nil || params.Body == nil {
+		return
+	}
+
+	record := audit.Record{
+		Service:    "api-service",
+		Principal:  getAuditPrincipal(principal),
+		Action:     "event.send",
+		TargetType: "event",
+		StatusCode: statusCode,
+	}
+	if params.Body.Type != nil {
+		record.Target = *params.Body.Type
+	}
+	eventData := keptnv2.EventData{}
+	if err := keptnv2.Decode(params.Body.Data, &eventData); err == nil {
+		record.Project = eventData.Project
+		after := map[string]string{"stage": eventData.Stage, "service": eventData.Service}
+		if eventContext != nil && eventContext.KeptnContext != nil {
+			after["keptnContext"] = *eventContext.KeptnContext
+		}
+		if summary, err := json.Marshal(after); err == nil {
+			record.After = string(summary)
+		}
+	}
+	if params.HTTPRequest != nil {
+		record.RequestID = params.HTTPRequest.Header.Get(audit.RequestIDHeader)
+		record.ClientIP = getClientIP(params.HTTPRequest)
+	}
+	auditRecorder.Record(record)
+}
+
+// getClientIP returns the address of the caller. Requests that have been forwarded by the API gateway carry it in the X-Real-IP header
+func getClientIP(request *http.Request) string {
+	if realIP := request.Header.Get("X-Real-IP"); realIP != "" {
+		return realIP
+	}
+	host, _, err := net.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		return request.RemoteAddr
+	}
+	return host
+}
+
 func createOrApplyKeptnContext(eventKeptnContext string) string {
 	uuid.SetRand(nil)
 	keptnContext := uuid.New().String()
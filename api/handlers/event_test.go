@@ -1,24 +1,35 @@
 package handlers
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"github.com/go-openapi/strfmt"
 	"github.com/google/uuid"
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
 	handlers_mock "github.com/keptn/keptn/api/handlers/fake"
+	custommiddleware "github.com/keptn/keptn/api/middleware"
+	"github.com/keptn/keptn/cp-common/audit"
+	audit_mock "github.com/keptn/keptn/cp-common/audit/fake"
 	"github.com/nats-io/nats-server/v2/server"
 	natstest "github.com/nats-io/nats-server/v2/test"
 	nats2 "github.com/nats-io/nats.go"
 	"github.com/stretchr/testify/require"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/keptn/keptn/api/identity"
 	"github.com/keptn/keptn/api/models"
 	"github.com/keptn/keptn/api/restapi/operations/event"
+	"github.com/keptn/keptn/api/tracing"
 	"github.com/keptn/keptn/api/utils"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func Test_createOrApplyKeptnContext(t *testing.T) {
@@ -233,7 +244,7 @@ func TestEventHandler_PostEvent(t *testing.T) {
 		Type:           &topicName,
 	}
 
-	got, err := eh.PostEvent(testEvent)
+	got, err := eh.PostEvent(context.Background(), testEvent)
 
 	require.Nil(t, err)
 	require.NotNil(t, got)
@@ -272,7 +283,7 @@ func TestEventHandler_PostEvent_UseAvailableKeptnContext(t *testing.T) {
 		Type:           &topicName,
 	}
 
-	got, err := eh.PostEvent(testEvent)
+	got, err := eh.PostEvent(context.Background(), testEvent)
 
 	require.Nil(t, err)
 	require.NotNil(t, got)
@@ -311,7 +322,7 @@ func TestEventHandler_PostEvent_SetDefaultSource(t *testing.T) {
 		Type:           &topicName,
 	}
 
-	got, err := eh.PostEvent(testEvent)
+	got, err := eh.PostEvent(context.Background(), testEvent)
 
 	require.Nil(t, err)
 	require.NotNil(t, got)
@@ -348,7 +359,7 @@ func TestEventHandler_PostEvent_ReplaceInvalidWithDefaultSource(t *testing.T) {
 		Type:           &topicName,
 	}
 
-	got, err := eh.PostEvent(testEvent)
+	got, err := eh.PostEvent(context.Background(), testEvent)
 
 	require.Nil(t, err)
 	require.NotNil(t, got)
@@ -385,10 +396,145 @@ func TestEventHandler_PostEvent_SendFails(t *testing.T) {
 		Type:           &topicName,
 	}
 
-	got, err := eh.PostEvent(testEvent)
+	got, err := eh.PostEvent(context.Background(), testEvent)
 
 	require.NotNil(t, err)
 	require.Nil(t, got)
 
 	require.Len(t, mockPublisher.PublishCalls(), 1)
 }
+
+func TestEventHandler_PostEvent_TraceContextIsPropagated(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previousProvider)
+
+	mockPublisher := &handlers_mock.EventPublisherMock{
+		PublishFunc: func(event apimodels.KeptnContextExtendedCE) error {
+			return nil
+		},
+	}
+	eh := &EventHandler{
+		EventPublisher: mockPublisher,
+	}
+
+	topicName := "my-topic"
+	testEvent := models.KeptnContextExtendedCE{
+		Data:        map[string]interface{}{},
+		Source:      stringp("test-source"),
+		Specversion: "1.0",
+		Type:        &topicName,
+	}
+
+	request, _ := http.NewRequest(http.MethodPost, "/v1/event", nil)
+	request.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	_, err := eh.PostEvent(tracing.ContextFromRequest(request), testEvent)
+	require.Nil(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	require.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+
+	require.Len(t, mockPublisher.PublishCalls(), 1)
+	require.Equal(t,
+		map[string]interface{}{"traceparent": fmt.Sprintf("00-%s-%s-01", spans[0].SpanContext().TraceID(), spans[0].SpanContext().SpanID())},
+		mockPublisher.PublishCalls()[0].Event.Extensions,
+	)
+}
+
+func TestEventHandler_PostEvent_TraceContextOfEventIsPreferred(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previousProvider)
+
+	mockPublisher := &handlers_mock.EventPublisherMock{
+		PublishFunc: func(event apimodels.KeptnContextExtendedCE) error {
+			return nil
+		},
+	}
+	eh := &EventHandler{
+		EventPublisher: mockPublisher,
+	}
+
+	topicName := "my-topic"
+	testEvent := models.KeptnContextExtendedCE{
+		Data:        map[string]interface{}{},
+		Extensions:  map[string]interface{}{"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"},
+		Source:      stringp("test-source"),
+		Specversion: "1.0",
+		Type:        &topicName,
+	}
+
+	request, _ := http.NewRequest(http.MethodPost, "/v1/event", nil)
+	request.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	_, err := eh.PostEvent(tracing.ContextFromRequest(request), testEvent)
+	require.Nil(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "0af7651916cd43dd8448eb211c80319c", spans[0].SpanContext().TraceID().String())
+	require.Equal(t, "b7ad6b7169203331", spans[0].Parent().SpanID().String())
+}
+
+func Test_recordEventAudit(t *testing.T) {
+	recorder := &audit_mock.RecorderMock{RecordFunc: func(record audit.Record) {}}
+	SetAuditRecorder(recorder)
+	defer SetAuditRecorder(nil)
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/event", nil)
+	request.Header.Set("X-Request-ID", "my-request")
+	request.Header.Set("X-Real-IP", "10.0.0.1")
+	principal := identity.Principal{Token: "my-token"}
+	params := event.PostEventParams{
+		HTTPRequest: request,
+		Body: &models.KeptnContextExtendedCE{
+			Data: map[string]interface{}{"project": "my-project", "stage": "dev", "service": "my-service", "secret": "value"},
+			Type: stringp("sh.keptn.event.dev.delivery.triggered"),
+		},
+	}
+
+	recordEventAudit(params, &principal, &models.EventContext{KeptnContext: stringp("my-context")}, http.StatusOK)
+
+	require.Len(t, recorder.RecordCalls(), 1)
+	record := recorder.RecordCalls()[0].Record
+	require.Equal(t, audit.PrincipalFromToken("my-token"), record.Principal)
+	require.Equal(t, "event.send", record.Action)
+	require.Equal(t, "sh.keptn.event.dev.delivery.triggered", record.Target)
+	require.Equal(t, "my-project", record.Project)
+	require.Equal(t, "my-request", record.RequestID)
+	require.Equal(t, "10.0.0.1", record.ClientIP)
+	require.Equal(t, `{"keptnContext":"my-context","service":"my-service","stage":"dev"}`, record.After)
+	require.Equal(t, http.StatusOK, record.StatusCode)
+}
+
+func TestPostEventHandlerFunc_Forbidden(t *testing.T) {
+	SetTokenAccessProvider(&tokenAccessProviderMock{access: map[string]*custommiddleware.TokenAccess{
+		"approver-token": {Principal: "token:approver", Roles: []string{custommiddleware.RoleApprover}, Projects: []string{"my-project"}},
+	}})
+	defer SetTokenAccessProvider(nil)
+
+	principal := identity.Principal{Token: "approver-token"}
+	params := event.PostEventParams{
+		HTTPRequest: httptest.NewRequest(http.MethodPost, "/v1/event", nil),
+		Body: &models.KeptnContextExtendedCE{
+			Data: map[string]interface{}{"project": "my-project", "stage": "dev", "service": "my-service"},
+			Type: stringp("sh.keptn.event.dev.delivery.triggered"),
+		},
+	}
+	require.False(t, isEventAllowed(params, &principal))
+
+	w := httptest.NewRecorder()
+	PostEventHandlerFunc(params, &principal).WriteResponse(w, &mockProducer{})
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	params.Body.Type = stringp("sh.keptn.event.approval.finished")
+	require.True(t, isEventAllowed(params, &principal))
+
+	params.Body.Data = map[string]interface{}{"project": "other-project"}
+	require.False(t, isEventAllowed(params, &principal))
+}
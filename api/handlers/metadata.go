@@ -15,6 +15,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
+	"github.com/keptn/keptn/api/identity"
 	"github.com/keptn/keptn/api/models"
 	"github.com/keptn/keptn/api/restapi/operations/metadata"
 )
@@ -24,7 +25,7 @@ const defaultVersion = "N/A"
 // Swagger Structure
 
 // GetMetadataHandlerFunc returns metadata of the keptn installation
-func GetMetadataHandlerFunc(params metadata.MetadataParams, principal *models.Principal) middleware.Responder {
+func GetMetadataHandlerFunc(params metadata.MetadataParams, principal *identity.Principal) middleware.Responder {
 
 	handler := newMetadataHandler()
 
@@ -20,6 +20,7 @@ import (
 	fakeappsv1 "k8s.io/client-go/kubernetes/typed/apps/v1/fake"
 	test "k8s.io/client-go/testing"
 
+	"github.com/keptn/keptn/api/identity"
 	"github.com/keptn/keptn/api/models"
 	"github.com/keptn/keptn/api/restapi/operations/metadata"
 )
@@ -27,7 +28,7 @@ import (
 func TestGetMetadataHandlerFunc(t *testing.T) {
 	type args struct {
 		params metadata.MetadataParams
-		p      *models.Principal
+		p      *identity.Principal
 	}
 	tests := []struct {
 		name       string
@@ -0,0 +1,11 @@
+package identity
+
+// Principal is a caller that has been authenticated by a TokenValidator
+type Principal struct {
+	// Token is the API token or bearer token the caller has been authenticated with
+	Token string `json:"-"`
+	// Subject identifies a caller that has been authenticated with a bearer token, e.g. via the 'sub' claim of a JWT
+	Subject string `json:"subject,omitempty"`
+	// Groups are the groups of a caller that has been authenticated with a bearer token
+	Groups []string `json:"groups,omitempty"`
+}
@@ -4,7 +4,7 @@
 package middleware_mock
 
 import (
-	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/identity"
 	"sync"
 )
 
@@ -14,7 +14,7 @@ import (
 //
 // 		// make and configure a mocked middleware.TokenValidator
 // 		mockedTokenValidator := &TokenValidatorMock{
-// 			ValidateTokenFunc: func(token string) (*models.Principal, error) {
+// 			ValidateTokenFunc: func(token string) (*identity.Principal, error) {
 // 				panic("mock out the ValidateToken method")
 // 			},
 // 		}
@@ -25,7 +25,7 @@ import (
 // 	}
 type TokenValidatorMock struct {
 	// ValidateTokenFunc mocks the ValidateToken method.
-	ValidateTokenFunc func(token string) (*models.Principal, error)
+	ValidateTokenFunc func(token string) (*identity.Principal, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -39,7 +39,7 @@ type TokenValidatorMock struct {
 }
 
 // ValidateToken calls ValidateTokenFunc.
-func (mock *TokenValidatorMock) ValidateToken(token string) (*models.Principal, error) {
+func (mock *TokenValidatorMock) ValidateToken(token string) (*identity.Principal, error) {
 	if mock.ValidateTokenFunc == nil {
 		panic("TokenValidatorMock.ValidateTokenFunc: method is nil but TokenValidator.ValidateToken was just called")
 	}
@@ -0,0 +1,217 @@
+package middleware
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	jwksFetchTimeout = 10 * time.Second
+	// jwksMinRefreshInterval limits how often the key set is fetched because a token refers to an unknown key
+	jwksMinRefreshInterval = 1 * time.Minute
+)
+
+// ErrKeyNotFound is returned by a JWKSKeySet if the key set does not contain a key with the given ID
+var ErrKeyNotFound = errors.New("signing key not found")
+
+type jsonWebKey struct {
+	KeyID string `json:"kid"`
+	Type  string `json:"kty"`
+	Use   string `json:"use"`
+	N     string `json:"n"`
+	E     string `json:"e"`
+	Curve string `json:"crv"`
+	X     string `json:"x"`
+	Y     string `json:"y"`
+}
+
+// JWKSKeySet provides the public keys of a JSON Web Key Set (JWKS) that is published by an identity provider.
+// The keys are cached for the given duration. If a token refers to a key that is not cached, e.g. because the
+// identity provider has rotated its keys, the key set is fetched again, at most once per jwksMinRefreshInterval
+type JWKSKeySet struct {
+	url        string
+	httpClient *http.Client
+	cacheTTL   time.Duration
+	theClock   clock.Clock
+	keys       map[string]interface{}
+	fetchedAt  time.Time
+	// refreshedAt is the time of the last attempt to fetch the key set, regardless of whether it succeeded
+	refreshedAt time.Time
+	// refreshing is closed once the refresh that is currently in progress has been completed
+	refreshing chan struct{}
+	refreshErr error
+	mutex      sync.Mutex
+}
+
+func NewJWKSKeySet(url string, cacheTTL time.Duration, httpClient *http.Client, theClock clock.Clock) *JWKSKeySet {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: jwksFetchTimeout}
+	}
+	return &JWKSKeySet{
+		url:        url,
+		httpClient: httpClient,
+		cacheTTL:   cacheTTL,
+		theClock:   theClock,
+		keys:       map[string]interface{}{},
+	}
+}
+
+// GetKey returns the public key with the given ID
+func (k *JWKSKeySet) GetKey(keyID string) (interface{}, error) {
+	k.mutex.Lock()
+	now := k.theClock.Now()
+	expired := k.fetchedAt.IsZero() || now.Sub(k.fetchedAt) >= k.cacheTTL
+	key, ok := k.keys[keyID]
+	if ok && !expired {
+		k.mutex.Unlock()
+		return key, nil
+	}
+	// tokens referring to unknown keys must not make the key set be fetched for each request
+	if !k.refreshedAt.IsZero() && now.Sub(k.refreshedAt) < jwksMinRefreshInterval && k.refreshing == nil {
+		k.mutex.Unlock()
+		if ok {
+			return key, nil
+		}
+		return nil, ErrKeyNotFound
+	}
+	refreshing := k.refreshing
+	if refreshing == nil {
+		refreshing = make(chan struct{})
+		k.refreshing = refreshing
+		k.refreshedAt = now
+		k.mutex.Unlock()
+		// the key set is fetched without holding the lock, so that known keys can still be looked up in the meantime
+		k.refresh(now)
+	} else {
+		k.mutex.Unlock()
+	}
+	<-refreshing
+
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	if key, ok := k.keys[keyID]; ok {
+		return key, nil
+	}
+	if k.refreshErr != nil {
+		return nil, k.refreshErr
+	}
+	return nil, ErrKeyNotFound
+}
+
+// refresh fetches the key set and notifies the callers that are waiting for it
+func (k *JWKSKeySet) refresh(now time.Time) {
+	keys, err := k.fetch()
+
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	k.refreshErr = err
+	if err != nil {
+		// keep using the cached keys if the identity provider is not available
+		log.WithError(err).Error("Could not fetch JSON Web Key Set")
+	} else {
+		k.keys = keys
+		k.fetchedAt = now
+	}
+	close(k.refreshing)
+	k.refreshing = nil
+}
+
+func (k *JWKSKeySet) fetch() (map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), jwksFetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := k.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	keySet := &struct {
+		Keys []jsonWebKey `json:"keys"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(keySet); err != nil {
+		return nil, fmt.Errorf("could not decode JSON Web Key Set: %w", err)
+	}
+
+	keys := map[string]interface{}{}
+	for _, jwk := range keySet.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			log.WithError(err).Warnf("Ignoring JSON Web Key %s", jwk.KeyID)
+			continue
+		}
+		keys[jwk.KeyID] = key
+	}
+	return keys, nil
+}
+
+func (jwk jsonWebKey) publicKey() (interface{}, error) {
+	switch jwk.Type {
+	case "RSA":
+		n, err := decodeBase64URLInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBase64URLInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", jwk.Curve)
+		}
+		x, err := decodeBase64URLInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBase64URLInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", jwk.Type)
+	}
+}
+
+func decodeBase64URLInt(value string) (*big.Int, error) {
+	if value == "" {
+		return nil, errors.New("missing key parameter")
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key parameter: %w", err)
+	}
+	return new(big.Int).SetBytes(decoded), nil
+}
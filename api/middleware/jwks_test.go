@@ -0,0 +1,203 @@
+package middleware
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/require"
+)
+
+type jwksServer struct {
+	server *httptest.Server
+	keys   []jsonWebKey
+	calls  int
+	mutex  sync.Mutex
+}
+
+func newJWKSServer(t *testing.T) *jwksServer {
+	s := &jwksServer{}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		s.calls++
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": s.keys})
+	}))
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+func (s *jwksServer) setKeys(keys ...jsonWebKey) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.keys = keys
+}
+
+func (s *jwksServer) getCalls() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.calls
+}
+
+func encodeBase64URLInt(value *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(value.Bytes())
+}
+
+func rsaJSONWebKey(keyID string, key *rsa.PublicKey) jsonWebKey {
+	return jsonWebKey{
+		KeyID: keyID,
+		Type:  "RSA",
+		Use:   "sig",
+		N:     encodeBase64URLInt(key.N),
+		E:     encodeBase64URLInt(big.NewInt(int64(key.E))),
+	}
+}
+
+func generateRSAKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+	return key
+}
+
+func TestJWKSKeySet_GetKey(t *testing.T) {
+	rsaKey := generateRSAKey(t)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+
+	server := newJWKSServer(t)
+	server.setKeys(
+		rsaJSONWebKey("rsa-key", &rsaKey.PublicKey),
+		jsonWebKey{KeyID: "ec-key", Type: "EC", Curve: "P-256", X: encodeBase64URLInt(ecKey.X), Y: encodeBase64URLInt(ecKey.Y)},
+		jsonWebKey{KeyID: "enc-key", Type: "RSA", Use: "enc", N: encodeBase64URLInt(rsaKey.N), E: "AQAB"},
+		jsonWebKey{KeyID: "invalid-key", Type: "oct"},
+	)
+
+	keySet := NewJWKSKeySet(server.server.URL, 15*time.Minute, nil, clock.NewMock())
+
+	key, err := keySet.GetKey("rsa-key")
+	require.Nil(t, err)
+	require.Equal(t, &rsaKey.PublicKey, key)
+
+	key, err = keySet.GetKey("ec-key")
+	require.Nil(t, err)
+	require.True(t, ecKey.PublicKey.Equal(key))
+
+	_, err = keySet.GetKey("enc-key")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	_, err = keySet.GetKey("invalid-key")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	// the key set has only been fetched once
+	require.Equal(t, 1, server.getCalls())
+}
+
+func TestJWKSKeySet_KeyRotation(t *testing.T) {
+	oldKey := generateRSAKey(t)
+	newKey := generateRSAKey(t)
+
+	server := newJWKSServer(t)
+	server.setKeys(rsaJSONWebKey("old-key", &oldKey.PublicKey))
+
+	mockClock := clock.NewMock()
+	keySet := NewJWKSKeySet(server.server.URL, 15*time.Minute, nil, mockClock)
+
+	_, err := keySet.GetKey("old-key")
+	require.Nil(t, err)
+	require.Equal(t, 1, server.getCalls())
+
+	// the identity provider rotates its keys
+	server.setKeys(rsaJSONWebKey("new-key", &newKey.PublicKey))
+
+	// unknown keys do not cause the key set to be fetched again within a minute
+	_, err = keySet.GetKey("new-key")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.Equal(t, 1, server.getCalls())
+
+	// the cached key can still be used until the cache expires
+	_, err = keySet.GetKey("old-key")
+	require.Nil(t, err)
+
+	mockClock.Add(2 * time.Minute)
+	key, err := keySet.GetKey("new-key")
+	require.Nil(t, err)
+	require.Equal(t, &newKey.PublicKey, key)
+	require.Equal(t, 2, server.getCalls())
+
+	_, err = keySet.GetKey("old-key")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestJWKSKeySet_CacheExpiry(t *testing.T) {
+	rsaKey := generateRSAKey(t)
+
+	server := newJWKSServer(t)
+	server.setKeys(rsaJSONWebKey("rsa-key", &rsaKey.PublicKey))
+
+	mockClock := clock.NewMock()
+	keySet := NewJWKSKeySet(server.server.URL, 15*time.Minute, nil, mockClock)
+
+	_, err := keySet.GetKey("rsa-key")
+	require.Nil(t, err)
+
+	mockClock.Add(10 * time.Minute)
+	_, err = keySet.GetKey("rsa-key")
+	require.Nil(t, err)
+	require.Equal(t, 1, server.getCalls())
+
+	mockClock.Add(10 * time.Minute)
+	_, err = keySet.GetKey("rsa-key")
+	require.Nil(t, err)
+	require.Equal(t, 2, server.getCalls())
+
+	// cached keys are still used if the identity provider is not available
+	server.server.Close()
+	mockClock.Add(20 * time.Minute)
+	_, err = keySet.GetKey("rsa-key")
+	require.Nil(t, err)
+}
+
+func TestJWKSKeySet_UnknownKeys(t *testing.T) {
+	rsaKey := generateRSAKey(t)
+
+	server := newJWKSServer(t)
+	server.setKeys(rsaJSONWebKey("rsa-key", &rsaKey.PublicKey))
+
+	mockClock := clock.NewMock()
+	keySet := NewJWKSKeySet(server.server.URL, 15*time.Minute, nil, mockClock)
+
+	// concurrent lookups wait for the same refresh of the key set
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := keySet.GetKey(fmt.Sprintf("unknown-key-%d", i))
+			require.ErrorIs(t, err, ErrKeyNotFound)
+		}(i)
+	}
+	wg.Wait()
+	require.Equal(t, 1, server.getCalls())
+
+	// unknown keys do not cause further refreshes within a minute
+	for i := 0; i < 10; i++ {
+		_, err := keySet.GetKey(fmt.Sprintf("other-key-%d", i))
+		require.ErrorIs(t, err, ErrKeyNotFound)
+	}
+	require.Equal(t, 1, server.getCalls())
+
+	key, err := keySet.GetKey("rsa-key")
+	require.Nil(t, err)
+	require.Equal(t, &rsaKey.PublicKey, key)
+}
@@ -0,0 +1,210 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/benbjohnson/clock"
+	openapierrors "github.com/go-openapi/errors"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/keptn/keptn/api/identity"
+	"github.com/keptn/keptn/cp-common/audit"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	jwtPrincipalPrefix     = "jwt:"
+	bearerPrefix           = "Bearer "
+	defaultJWTGroupsClaim  = "groups"
+	jwtKeyIDHeader         = "kid"
+	jwtSubjectClaim        = "sub"
+	jwtExpirationTimeClaim = "exp"
+)
+
+var jwtSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// JWTKeySet provides the public keys that are used to verify the signatures of bearer tokens
+type JWTKeySet interface {
+	GetKey(keyID string) (interface{}, error)
+}
+
+// JWTTokenValidatorConfig configures which bearer tokens are accepted by a JWTTokenValidator
+type JWTTokenValidatorConfig struct {
+	// Issuer must match the 'iss' claim of the token
+	Issuer string
+	// Audience must be contained in the 'aud' claim of the token, if set
+	Audience string
+	// GroupsClaim is the claim that contains the groups of the caller. Defaults to 'groups'
+	GroupsClaim string
+	// GroupRoles maps the groups of the caller to the roles they are granted
+	GroupRoles map[string][]string
+}
+
+// JWTTokenValidator accepts JSON Web Tokens that are issued by an OIDC identity provider. The signature of a token is verified
+// with the keys of the identity provider, and its issuer, audience and expiration time are checked.
+// Tokens that are not JWTs, e.g. the token configured via the SECRET_TOKEN env var, are passed on to the fallback validator
+type JWTTokenValidator struct {
+	config   JWTTokenValidatorConfig
+	keySet   JWTKeySet
+	fallback TokenAccessValidator
+	theClock clock.Clock
+	parser   *jwt.Parser
+}
+
+// TokenAccessValidator is a TokenValidator that also provides the roles and projects of the tokens it accepts
+type TokenAccessValidator interface {
+	TokenValidator
+	GetTokenAccess(token string) (*TokenAccess, error)
+}
+
+func NewJWTTokenValidator(config JWTTokenValidatorConfig, keySet JWTKeySet, fallback TokenAccessValidator, theClock clock.Clock) *JWTTokenValidator {
+	if config.GroupsClaim == "" {
+		config.GroupsClaim = defaultJWTGroupsClaim
+	}
+	return &JWTTokenValidator{
+		config:   config,
+		keySet:   keySet,
+		fallback: fallback,
+		theClock: theClock,
+		parser:   jwt.NewParser(jwt.WithValidMethods(jwtSigningMethods), jwt.WithoutClaimsValidation()),
+	}
+}
+
+func (j *JWTTokenValidator) ValidateToken(token string) (*identity.Principal, error) {
+	token = strings.TrimPrefix(token, bearerPrefix)
+	if !isJWT(token) {
+		if j.fallback == nil {
+			return nil, openapierrors.New(http.StatusUnauthorized, "incorrect api key auth")
+		}
+		return j.fallback.ValidateToken(token)
+	}
+	claims, err := j.parseToken(token)
+	if err != nil {
+		log.WithError(err).Errorf("Access attempt with invalid bearer token: %s", audit.PrincipalFromToken(token))
+		return nil, openapierrors.New(http.StatusUnauthorized, "invalid bearer token")
+	}
+	return &identity.Principal{
+		Token:   token,
+		Subject: getStringClaim(claims, jwtSubjectClaim),
+		Groups:  getStringSliceClaim(claims, j.config.GroupsClaim),
+	}, nil
+}
+
+// GetTokenAccess returns the roles of the given bearer token, which are derived from the groups of the caller.
+// Bearer tokens are not scoped to projects
+func (j *JWTTokenValidator) GetTokenAccess(token string) (*TokenAccess, error) {
+	token = strings.TrimPrefix(token, bearerPrefix)
+	if !isJWT(token) {
+		if j.fallback == nil {
+			return nil, ErrTokenNotFound
+		}
+		return j.fallback.GetTokenAccess(token)
+	}
+	claims, err := j.parseToken(token)
+	if err != nil {
+		return nil, ErrTokenNotFound
+	}
+	roles := []string{}
+	seen := map[string]bool{}
+	for _, group := range getStringSliceClaim(claims, j.config.GroupsClaim) {
+		for _, role := range j.config.GroupRoles[group] {
+			if !seen[role] {
+				seen[role] = true
+				roles = append(roles, role)
+			}
+		}
+	}
+	return &TokenAccess{
+		Principal: jwtPrincipalPrefix + getStringClaim(claims, jwtSubjectClaim),
+		Roles:     roles,
+	}, nil
+}
+
+func (j *JWTTokenValidator) parseToken(token string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	if _, err := j.parser.ParseWithClaims(token, claims, j.getKey); err != nil {
+		return nil, err
+	}
+
+	now := j.theClock.Now().Unix()
+	if _, ok := claims[jwtExpirationTimeClaim]; !ok || !claims.VerifyExpiresAt(now, true) {
+		return nil, errors.New("token is expired")
+	}
+	if !claims.VerifyNotBefore(now, false) {
+		return nil, errors.New("token is not valid yet")
+	}
+	if !claims.VerifyIssuer(j.config.Issuer, true) {
+		return nil, errors.New("unexpected issuer")
+	}
+	if j.config.Audience != "" && !claims.VerifyAudience(j.config.Audience, true) {
+		return nil, errors.New("unexpected audience")
+	}
+	if getStringClaim(claims, jwtSubjectClaim) == "" {
+		return nil, errors.New("missing subject")
+	}
+	return claims, nil
+}
+
+func (j *JWTTokenValidator) getKey(token *jwt.Token) (interface{}, error) {
+	keyID, ok := token.Header[jwtKeyIDHeader].(string)
+	if !ok || keyID == "" {
+		return nil, errors.New("missing key id")
+	}
+	key, err := j.keySet.GetKey(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get key %s: %w", keyID, err)
+	}
+	return key, nil
+}
+
+// isJWT checks whether the token consists of the three segments of a signed JWT.
+// API tokens are hex encoded and therefore never contain dots
+func isJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+func getStringClaim(claims jwt.MapClaims, name string) string {
+	value, _ := claims[name].(string)
+	return value
+}
+
+func getStringSliceClaim(claims jwt.MapClaims, name string) []string {
+	switch value := claims[name].(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		result := []string{}
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
+
+// ParseGroupRoles parses a mapping of groups to roles in the format 'group=role,group=role'
+func ParseGroupRoles(value string) (map[string][]string, error) {
+	groupRoles := map[string][]string{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		split := strings.SplitN(entry, "=", 2)
+		if len(split) != 2 || strings.TrimSpace(split[0]) == "" {
+			return nil, fmt.Errorf("invalid group role mapping '%s'", entry)
+		}
+		role := strings.TrimSpace(split[1])
+		if _, ok := rolePermissions[role]; !ok {
+			return nil, fmt.Errorf("unknown role '%s'", role)
+		}
+		group := strings.TrimSpace(split[0])
+		groupRoles[group] = append(groupRoles[group], role)
+	}
+	return groupRoles, nil
+}
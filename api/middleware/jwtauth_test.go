@@ -0,0 +1,280 @@
+package middleware
+
+import (
+	"crypto/rsa"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/keptn/keptn/api/identity"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testIssuer   = "https://idp.example.com"
+	testAudience = "keptn"
+)
+
+func signToken(t *testing.T, key *rsa.PrivateKey, keyID string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header[jwtKeyIDHeader] = keyID
+	signed, err := token.SignedString(key)
+	require.Nil(t, err)
+	return signed
+}
+
+func validClaims(now time.Time) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":    testIssuer,
+		"aud":    []string{testAudience},
+		"sub":    "jane",
+		"exp":    now.Add(time.Hour).Unix(),
+		"nbf":    now.Add(-time.Minute).Unix(),
+		"groups": []string{"keptn-admins", "developers"},
+	}
+}
+
+func newTestJWTTokenValidator(t *testing.T, mockClock clock.Clock, fallback TokenAccessValidator) (*JWTTokenValidator, *rsa.PrivateKey, *jwksServer) {
+	key := generateRSAKey(t)
+	server := newJWKSServer(t)
+	server.setKeys(rsaJSONWebKey("key-1", &key.PublicKey))
+
+	validator := NewJWTTokenValidator(JWTTokenValidatorConfig{
+		Issuer:   testIssuer,
+		Audience: testAudience,
+		GroupRoles: map[string][]string{
+			"keptn-admins": {RoleAdmin},
+			"developers":   {RoleOperator},
+			"viewers":      {RoleViewer},
+		},
+	}, NewJWKSKeySet(server.server.URL, 15*time.Minute, nil, mockClock), fallback, mockClock)
+	return validator, key, server
+}
+
+func TestJWTTokenValidator_ValidateToken(t *testing.T) {
+	mockClock := clock.NewMock()
+	mockClock.Set(time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC))
+	validator, key, _ := newTestJWTTokenValidator(t, mockClock, nil)
+	otherKey := generateRSAKey(t)
+	now := mockClock.Now()
+
+	withClaim := func(name string, value interface{}) jwt.MapClaims {
+		claims := validClaims(now)
+		if value == nil {
+			delete(claims, name)
+		} else {
+			claims[name] = value
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    *identity.Principal
+		wantErr bool
+	}{
+		{
+			name:  "valid token",
+			token: signToken(t, key, "key-1", validClaims(now)),
+			want:  &identity.Principal{Subject: "jane", Groups: []string{"keptn-admins", "developers"}},
+		},
+		{
+			name:  "valid token with bearer prefix",
+			token: "Bearer " + signToken(t, key, "key-1", validClaims(now)),
+			want:  &identity.Principal{Subject: "jane", Groups: []string{"keptn-admins", "developers"}},
+		},
+		{
+			name:  "audience as string",
+			token: signToken(t, key, "key-1", withClaim("aud", testAudience)),
+			want:  &identity.Principal{Subject: "jane", Groups: []string{"keptn-admins", "developers"}},
+		},
+		{
+			name:  "without groups",
+			token: signToken(t, key, "key-1", withClaim("groups", nil)),
+			want:  &identity.Principal{Subject: "jane"},
+		},
+		{
+			name:    "wrong issuer",
+			token:   signToken(t, key, "key-1", withClaim("iss", "https://other-idp.example.com")),
+			wantErr: true,
+		},
+		{
+			name:    "missing issuer",
+			token:   signToken(t, key, "key-1", withClaim("iss", nil)),
+			wantErr: true,
+		},
+		{
+			name:    "wrong audience",
+			token:   signToken(t, key, "key-1", withClaim("aud", []string{"other-app"})),
+			wantErr: true,
+		},
+		{
+			name:    "expired",
+			token:   signToken(t, key, "key-1", withClaim("exp", now.Add(-time.Minute).Unix())),
+			wantErr: true,
+		},
+		{
+			name:    "missing expiration time",
+			token:   signToken(t, key, "key-1", withClaim("exp", nil)),
+			wantErr: true,
+		},
+		{
+			name:    "not valid yet",
+			token:   signToken(t, key, "key-1", withClaim("nbf", now.Add(time.Minute).Unix())),
+			wantErr: true,
+		},
+		{
+			name:    "missing subject",
+			token:   signToken(t, key, "key-1", withClaim("sub", nil)),
+			wantErr: true,
+		},
+		{
+			name:    "signed with other key",
+			token:   signToken(t, otherKey, "key-1", validClaims(now)),
+			wantErr: true,
+		},
+		{
+			name:    "unknown key",
+			token:   signToken(t, otherKey, "key-2", validClaims(now)),
+			wantErr: true,
+		},
+		{
+			name: "unsigned token",
+			token: func() string {
+				s, _ := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims(now)).SignedString(jwt.UnsafeAllowNoneSignatureType)
+				return s
+			}(),
+			wantErr: true,
+		},
+		{
+			name:    "no jwt and no fallback",
+			token:   "my-token",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validator.ValidateToken(tt.token)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				require.Nil(t, got)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want.Subject, got.Subject)
+			require.Equal(t, tt.want.Groups, got.Groups)
+			require.NotEmpty(t, got.Token)
+		})
+	}
+}
+
+func TestJWTTokenValidator_KeyRotation(t *testing.T) {
+	mockClock := clock.NewMock()
+	mockClock.Set(time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC))
+	validator, key, server := newTestJWTTokenValidator(t, mockClock, nil)
+
+	_, err := validator.ValidateToken(signToken(t, key, "key-1", validClaims(mockClock.Now())))
+	require.Nil(t, err)
+
+	// the identity provider publishes a new key
+	newKey := generateRSAKey(t)
+	server.setKeys(rsaJSONWebKey("key-1", &key.PublicKey), rsaJSONWebKey("key-2", &newKey.PublicKey))
+	mockClock.Add(2 * time.Minute)
+
+	principal, err := validator.ValidateToken(signToken(t, newKey, "key-2", validClaims(mockClock.Now())))
+	require.Nil(t, err)
+	require.Equal(t, "jane", principal.Subject)
+	require.Equal(t, 2, server.getCalls())
+}
+
+func TestJWTTokenValidator_GetTokenAccess(t *testing.T) {
+	_ = os.Setenv("SECRET_TOKEN", "my-token")
+	defer os.Unsetenv("SECRET_TOKEN")
+
+	mockClock := clock.NewMock()
+	mockClock.Set(time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC))
+	validator, key, _ := newTestJWTTokenValidator(t, mockClock, NewBasicTokenValidator(nil, time.Minute, mockClock))
+	now := mockClock.Now()
+
+	access, err := validator.GetTokenAccess(signToken(t, key, "key-1", validClaims(now)))
+	require.Nil(t, err)
+	require.Equal(t, &TokenAccess{Principal: "jwt:jane", Roles: []string{RoleAdmin, RoleOperator}}, access)
+
+	claims := validClaims(now)
+	claims["groups"] = []string{"viewers", "unknown"}
+	access, err = validator.GetTokenAccess(signToken(t, key, "key-1", claims))
+	require.Nil(t, err)
+	require.Equal(t, []string{RoleViewer}, access.Roles)
+	require.Empty(t, access.Projects)
+
+	claims["groups"] = "unknown"
+	access, err = validator.GetTokenAccess(signToken(t, key, "key-1", claims))
+	require.Nil(t, err)
+	require.Empty(t, access.Roles)
+
+	claims["iss"] = "https://other-idp.example.com"
+	_, err = validator.GetTokenAccess(signToken(t, key, "key-1", claims))
+	require.ErrorIs(t, err, ErrTokenNotFound)
+
+	// tokens that are not JWTs are passed on to the fallback validator
+	access, err = validator.GetTokenAccess("my-token")
+	require.Nil(t, err)
+	require.Equal(t, []string{RoleAdmin}, access.Roles)
+
+	principal, err := validator.ValidateToken("my-token")
+	require.Nil(t, err)
+	require.Equal(t, "my-token", principal.Token)
+
+	_, err = validator.GetTokenAccess("my-invalid-token")
+	require.ErrorIs(t, err, ErrTokenNotFound)
+
+	_, err = validator.ValidateToken("my-invalid-token")
+	require.NotNil(t, err)
+}
+
+func TestParseGroupRoles(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string][]string
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			value: "",
+			want:  map[string][]string{},
+		},
+		{
+			name:  "multiple groups",
+			value: "keptn-admins=admin, developers=operator,developers=approver",
+			want: map[string][]string{
+				"keptn-admins": {RoleAdmin},
+				"developers":   {RoleOperator, RoleApprover},
+			},
+		},
+		{
+			name:    "unknown role",
+			value:   "developers=owner",
+			wantErr: true,
+		},
+		{
+			name:    "missing role",
+			value:   "developers",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseGroupRoles(tt.value)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
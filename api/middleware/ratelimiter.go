@@ -11,6 +11,8 @@ import (
 	"golang.org/x/time/rate"
 )
 
+const principalBucketPrefix = "principal:"
+
 type visitor struct {
 	limiter  *rate.Limiter
 	lastSeen time.Time
@@ -53,21 +55,34 @@ func (r *RateLimiter) Handle(handler http.Handler) http.Handler {
 }
 
 func (r *RateLimiter) Apply(w http.ResponseWriter, req *http.Request, handler http.Handler) {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
 	ipAddress := getRemoteIP(req)
-	limiter := r.getIPBucket(ipAddress)
-	if !limiter.Allow() {
+	if !r.allow(ipAddress) {
 		http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
 		return
 	}
-	_, err := r.tokenValidator.ValidateToken(req.Header.Get("x-token"))
+	// the token is validated without holding the lock, since this may involve requests to other services
+	principal, err := r.tokenValidator.ValidateToken(req.Header.Get("x-token"))
 	if err == nil {
+		// callers that have been authenticated with a bearer token are additionally limited by a bucket of their own,
+		// so that they cannot bypass the limit by sending their requests from several IP addresses
+		if principal != nil && principal.Subject != "" && !r.allow(principalBucketPrefix+principal.Subject) {
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return
+		}
+		r.mutex.Lock()
 		r.clearIPBucket(ipAddress)
+		r.mutex.Unlock()
 	}
 	handler.ServeHTTP(w, req)
 }
 
+// allow takes a token from the bucket with the given key
+func (r *RateLimiter) allow(bucketKey string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.getIPBucket(bucketKey).Allow()
+}
+
 func (r *RateLimiter) getIPBucket(ip string) *rate.Limiter {
 	v, exists := r.visitors[ip]
 	if !exists {
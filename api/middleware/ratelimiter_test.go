@@ -9,8 +9,8 @@ import (
 	"time"
 
 	"github.com/benbjohnson/clock"
+	"github.com/keptn/keptn/api/identity"
 	middleware_mock "github.com/keptn/keptn/api/middleware/fake"
-	"github.com/keptn/keptn/api/models"
 	"github.com/stretchr/testify/require"
 )
 
@@ -93,7 +93,7 @@ func Test_getRemoteIPWithRemoteXRealIP(t *testing.T) {
 
 func TestRateLimiter(t *testing.T) {
 	mockClock := clock.NewMock()
-	tokenValidator := &middleware_mock.TokenValidatorMock{ValidateTokenFunc: func(token string) (*models.Principal, error) {
+	tokenValidator := &middleware_mock.TokenValidatorMock{ValidateTokenFunc: func(token string) (*identity.Principal, error) {
 		return nil, errors.New("oops")
 	}}
 	rl := NewRateLimiter(1.0, 1, tokenValidator, mockClock)
@@ -123,7 +123,7 @@ func TestRateLimiter(t *testing.T) {
 	require.Equal(t, 1, mh.calls)
 
 	// now simulate a request with a valid token - this should however still be throttled due to previous burst of invalid requests
-	tokenValidator.ValidateTokenFunc = func(token string) (*models.Principal, error) {
+	tokenValidator.ValidateTokenFunc = func(token string) (*identity.Principal, error) {
 		return nil, nil
 	}
 	req, err = http.NewRequest(http.MethodGet, "", nil)
@@ -145,6 +145,71 @@ func TestRateLimiter(t *testing.T) {
 	require.Empty(t, rl.visitors)
 }
 
+func TestRateLimiter_BearerTokens(t *testing.T) {
+	mockClock := clock.NewMock()
+	tokenValidator := &middleware_mock.TokenValidatorMock{ValidateTokenFunc: func(token string) (*identity.Principal, error) {
+		if token == "header.payload.signature" {
+			return &identity.Principal{Token: token, Subject: "jane"}, nil
+		}
+		return nil, errors.New("oops")
+	}}
+	rl := NewRateLimiter(1.0, 1, tokenValidator, mockClock)
+
+	mh := &MockHttpHandler{}
+	newRequest := func(token string) *http.Request {
+		req, err := http.NewRequest(http.MethodGet, "", nil)
+		require.Nil(t, err)
+		req.RemoteAddr = "127.0.0.1:8000"
+		req.Header.Set("x-token", token)
+		return req
+	}
+
+	// invalid bearer tokens exhaust the bucket of the IP address
+	rl.Apply(&httptest.ResponseRecorder{}, newRequest("invalid.bearer.token"), mh)
+	rl.Apply(&httptest.ResponseRecorder{}, newRequest("invalid.bearer.token"), mh)
+	require.Equal(t, 1, mh.calls)
+
+	// throttled requests are rejected before their token is validated, even if it is a valid bearer token
+	rl.Apply(&httptest.ResponseRecorder{}, newRequest("header.payload.signature"), mh)
+	require.Equal(t, 1, mh.calls)
+	require.Len(t, tokenValidator.ValidateTokenCalls(), 1)
+
+	require.Len(t, rl.visitors, 1)
+	require.Contains(t, rl.visitors, "127.0.0.1")
+}
+
+func TestRateLimiter_PrincipalBuckets(t *testing.T) {
+	mockClock := clock.NewMock()
+	tokenValidator := &middleware_mock.TokenValidatorMock{ValidateTokenFunc: func(token string) (*identity.Principal, error) {
+		if token == "header.payload.signature" {
+			return &identity.Principal{Token: token, Subject: "jane"}, nil
+		}
+		return nil, errors.New("oops")
+	}}
+	rl := NewRateLimiter(1.0, 1, tokenValidator, mockClock)
+
+	mh := &MockHttpHandler{}
+	newRequest := func(remoteAddr string) *http.Request {
+		req, err := http.NewRequest(http.MethodGet, "", nil)
+		require.Nil(t, err)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("x-token", "header.payload.signature")
+		return req
+	}
+
+	rl.Apply(&httptest.ResponseRecorder{}, newRequest("127.0.0.1:8000"), mh)
+	require.Equal(t, 1, mh.calls)
+
+	// the principal is throttled, even if it sends its requests from another IP address
+	w := httptest.NewRecorder()
+	rl.Apply(w, newRequest("127.0.0.2:8000"), mh)
+	require.Equal(t, 1, mh.calls)
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+
+	require.Len(t, rl.visitors, 3)
+	require.Contains(t, rl.visitors, "principal:jane")
+}
+
 type MockHttpHandler struct {
 	calls int
 	lock  sync.Mutex
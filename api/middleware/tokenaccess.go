@@ -0,0 +1,167 @@
+package middleware
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+const (
+	// RoleViewer allows read access
+	RoleViewer = "viewer"
+	// RoleApprover allows read access and sending the results of approvals
+	RoleApprover = "approver"
+	// RoleOperator allows read and write access, except for the creation and deletion of projects and the management of API tokens
+	RoleOperator = "operator"
+	// RoleAdmin allows full access
+	RoleAdmin = "admin"
+
+	// controlPlaneService is the prefix of the routes of the shipyard-controller, which checks the roles and projects of a token itself
+	controlPlaneService = "controlPlane"
+
+	approvalFinishedEventSuffix = ".approval.finished"
+)
+
+type permission int
+
+const (
+	permissionRead permission = iota
+	permissionApprove
+	permissionOperate
+	permissionAdmin
+)
+
+var rolePermissions = map[string]permission{
+	RoleViewer:   permissionRead,
+	RoleApprover: permissionApprove,
+	RoleOperator: permissionOperate,
+	RoleAdmin:    permissionAdmin,
+}
+
+// TokenAccess describes what a caller that has been authenticated with an API token is allowed to do
+type TokenAccess struct {
+	// Principal is the name under which the caller is recorded in the audit log
+	Principal string
+	// Roles are the roles that have been granted to the token
+	Roles []string
+	// Projects limit the access to the given projects. If empty, the access is not limited
+	Projects []string
+}
+
+// AllowsProject returns whether the token grants access to the given project
+func (a TokenAccess) AllowsProject(project string) bool {
+	if len(a.Projects) == 0 {
+		return true
+	}
+	for _, p := range a.Projects {
+		if p == project {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsEvent returns whether the token permits sending an event of the given type for the given project.
+// Approvers may only send the results of approvals
+func (a TokenAccess) AllowsEvent(eventType, project string) bool {
+	required := permissionOperate
+	if strings.HasSuffix(eventType, approvalFinishedEventSuffix) {
+		required = permissionApprove
+	}
+	if a.permission() < required {
+		return false
+	}
+	if len(a.Projects) > 0 && project == "" {
+		return false
+	}
+	return a.AllowsProject(project)
+}
+
+// AllowsRequest returns whether the token permits a request that is forwarded by the API gateway, given its method and original URI.
+// Requests to the control plane are only checked for a valid role here, since the shipyard-controller checks them in detail.
+// For the other services, writes require the operator role, and tokens that are limited to a set of projects may only access
+// routes that refer to one of these projects. Requests whose target service cannot be determined are denied
+func (a TokenAccess) AllowsRequest(method, uri string) bool {
+	if a.permission() < permissionRead {
+		return false
+	}
+
+	service, path, query, ok := splitGatewayURI(uri)
+	if !ok {
+		return false
+	}
+	if service == controlPlaneService {
+		return true
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+	default:
+		if a.permission() < permissionOperate {
+			return false
+		}
+	}
+
+	if len(a.Projects) == 0 {
+		return true
+	}
+	project := getProjectFromPath(path)
+	if project == "" {
+		project = query.Get("project")
+	}
+	return project != "" && a.AllowsProject(project)
+}
+
+func (a TokenAccess) permission() permission {
+	granted := permission(-1)
+	for _, role := range a.Roles {
+		if p, ok := rolePermissions[role]; ok && p > granted {
+			granted = p
+		}
+	}
+	return granted
+}
+
+// splitGatewayURI returns the service a request of the API gateway is forwarded to, the remaining path and the query parameters,
+// e.g. 'configuration-service', '/v1/project/sockshop/resource' for '/api/configuration-service/v1/project/sockshop/resource'.
+// The original URI is not normalized by the API gateway, but the gateway resolves dot segments and encoded characters before routing
+// the request. URIs containing them are therefore rejected, as well as URIs that do not refer to a service
+func splitGatewayURI(uri string) (string, string, url.Values, bool) {
+	parsed, err := url.ParseRequestURI(uri)
+	if err != nil {
+		return "", "", nil, false
+	}
+	escapedPath := strings.ToLower(parsed.EscapedPath())
+	for _, encoded := range []string{"%2e", "%2f", "%5c", "\\"} {
+		if strings.Contains(escapedPath, encoded) {
+			return "", "", nil, false
+		}
+	}
+	for _, segment := range strings.Split(parsed.Path, "/") {
+		if segment == "." || segment == ".." {
+			return "", "", nil, false
+		}
+	}
+
+	cleanedPath := path.Clean(parsed.Path)
+	index := strings.Index(cleanedPath, "/api/")
+	if index < 0 {
+		return "", "", nil, false
+	}
+	service, rest, _ := strings.Cut(cleanedPath[index+len("/api/"):], "/")
+	if service == "" {
+		return "", "", nil, false
+	}
+	return service, "/" + rest, parsed.Query(), true
+}
+
+func getProjectFromPath(path string) string {
+	segments := strings.Split(path, "/")
+	for index := 0; index < len(segments)-1; index++ {
+		if segments[index] == "project" {
+			return segments[index+1]
+		}
+	}
+	return ""
+}
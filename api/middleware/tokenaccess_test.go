@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenAccess_AllowsRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		access TokenAccess
+		method string
+		uri    string
+		want   bool
+	}{
+		{
+			name:   "viewer reads resource",
+			access: TokenAccess{Roles: []string{RoleViewer}},
+			method: http.MethodGet,
+			uri:    "/api/configuration-service/v1/project/sockshop/stage/dev/service/carts/resource",
+			want:   true,
+		},
+		{
+			name:   "viewer updates resource",
+			access: TokenAccess{Roles: []string{RoleViewer}},
+			method: http.MethodPut,
+			uri:    "/api/configuration-service/v1/project/sockshop/stage/dev/service/carts/resource",
+			want:   false,
+		},
+		{
+			name:   "token without valid role",
+			access: TokenAccess{Roles: []string{"root"}},
+			method: http.MethodGet,
+			uri:    "/api/controlPlane/v1/project",
+			want:   false,
+		},
+		{
+			name:   "control plane is checked by the shipyard-controller",
+			access: TokenAccess{Roles: []string{RoleViewer}, Projects: []string{"sockshop"}},
+			method: http.MethodDelete,
+			uri:    "/my-prefix/api/controlPlane/v1/project/podtato-head",
+			want:   true,
+		},
+		{
+			name:   "scoped token updates resource of its project",
+			access: TokenAccess{Roles: []string{RoleOperator}, Projects: []string{"sockshop"}},
+			method: http.MethodPut,
+			uri:    "/api/configuration-service/v1/project/sockshop/stage/dev/service/carts/resource",
+			want:   true,
+		},
+		{
+			name:   "scoped token updates resource of other project",
+			access: TokenAccess{Roles: []string{RoleOperator}, Projects: []string{"sockshop"}},
+			method: http.MethodPut,
+			uri:    "/api/configuration-service/v1/project/podtato-head/stage/dev/service/carts/resource",
+			want:   false,
+		},
+		{
+			name:   "scoped token reads events of its project",
+			access: TokenAccess{Roles: []string{RoleViewer}, Projects: []string{"sockshop"}},
+			method: http.MethodGet,
+			uri:    "/api/mongodb-datastore/event?project=sockshop&pageSize=10",
+			want:   true,
+		},
+		{
+			name:   "scoped token creates secret",
+			access: TokenAccess{Roles: []string{RoleAdmin}, Projects: []string{"sockshop"}},
+			method: http.MethodPost,
+			uri:    "/api/secrets/v1/secret",
+			want:   false,
+		},
+		{
+			name:   "dot segments are not resolved into the control plane",
+			access: TokenAccess{Roles: []string{RoleViewer}},
+			method: http.MethodPost,
+			uri:    "/api/controlPlane/../secrets/v1/secret",
+			want:   false,
+		},
+		{
+			name:   "dot segments do not bypass the project scope",
+			access: TokenAccess{Roles: []string{RoleOperator}, Projects: []string{"sockshop"}},
+			method: http.MethodPut,
+			uri:    "/api/configuration-service/v1/project/sockshop/../../project/podtato-head/resource",
+			want:   false,
+		},
+		{
+			name:   "encoded dot segments",
+			access: TokenAccess{Roles: []string{RoleViewer}},
+			method: http.MethodPost,
+			uri:    "/api/controlPlane/%2E%2e/secrets/v1/secret",
+			want:   false,
+		},
+		{
+			name:   "encoded slash",
+			access: TokenAccess{Roles: []string{RoleViewer}},
+			method: http.MethodPost,
+			uri:    "/api/controlPlane%2F..%2Fsecrets/v1/secret",
+			want:   false,
+		},
+		{
+			name:   "duplicate slashes are cleaned",
+			access: TokenAccess{Roles: []string{RoleOperator}, Projects: []string{"sockshop"}},
+			method: http.MethodPut,
+			uri:    "/api//configuration-service//v1/project/sockshop/resource",
+			want:   true,
+		},
+		{
+			name:   "uri without service",
+			access: TokenAccess{Roles: []string{RoleAdmin}},
+			method: http.MethodGet,
+			uri:    "/bridge/project",
+			want:   false,
+		},
+		{
+			name:   "invalid uri",
+			access: TokenAccess{Roles: []string{RoleAdmin}},
+			method: http.MethodGet,
+			uri:    "not a uri",
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.access.AllowsRequest(tt.method, tt.uri))
+		})
+	}
+}
+
+func TestTokenAccess_AllowsEvent(t *testing.T) {
+	approver := TokenAccess{Roles: []string{RoleApprover}, Projects: []string{"sockshop"}}
+	require.True(t, approver.AllowsEvent("sh.keptn.event.approval.finished", "sockshop"))
+	require.False(t, approver.AllowsEvent("sh.keptn.event.approval.finished", "podtato-head"))
+	require.False(t, approver.AllowsEvent("sh.keptn.event.dev.delivery.triggered", "sockshop"))
+
+	operator := TokenAccess{Roles: []string{RoleOperator}}
+	require.True(t, operator.AllowsEvent("sh.keptn.event.dev.delivery.triggered", "sockshop"))
+
+	viewer := TokenAccess{Roles: []string{RoleViewer}}
+	require.False(t, viewer.AllowsEvent("sh.keptn.event.approval.finished", "sockshop"))
+}
@@ -1,25 +1,109 @@
 package middleware
 
 import (
-	openapierrors "github.com/go-openapi/errors"
-	"github.com/keptn/keptn/api/models"
-	log "github.com/sirupsen/logrus"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
 	"net/http"
 	"os"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	openapierrors "github.com/go-openapi/errors"
+	"github.com/keptn/keptn/api/identity"
+	"github.com/keptn/keptn/cp-common/audit"
+	log "github.com/sirupsen/logrus"
 )
 
 //go:generate moq -pkg middleware_mock --skip-ensure -out ./fake/tokenvalidator_mock.go . TokenValidator
 type TokenValidator interface {
-	ValidateToken(token string) (*models.Principal, error)
+	ValidateToken(token string) (*identity.Principal, error)
+}
+
+type cachedTokenAccess struct {
+	access    *TokenAccess
+	expiresAt time.Time
+}
+
+// BasicTokenValidator accepts the token that is configured via the SECRET_TOKEN env var, which grants full access,
+// as well as the named API tokens of the given TokenStore. The results of the TokenStore are cached for the given duration,
+// so that a revoked or rotated token may be accepted until its cache entry expires
+type BasicTokenValidator struct {
+	store    TokenStore
+	cacheTTL time.Duration
+	theClock clock.Clock
+	cache    map[string]cachedTokenAccess
+	mutex    sync.Mutex
+}
+
+func NewBasicTokenValidator(store TokenStore, cacheTTL time.Duration, theClock clock.Clock) *BasicTokenValidator {
+	return &BasicTokenValidator{
+		store:    store,
+		cacheTTL: cacheTTL,
+		theClock: theClock,
+		cache:    map[string]cachedTokenAccess{},
+	}
+}
+
+func (b *BasicTokenValidator) ValidateToken(token string) (*identity.Principal, error) {
+	if _, err := b.GetTokenAccess(token); err != nil {
+		if !errors.Is(err, ErrTokenNotFound) {
+			log.WithError(err).Error("Could not validate api token")
+		}
+		log.Errorf("Access attempt with incorrect api key auth: %s", audit.PrincipalFromToken(token))
+		return nil, openapierrors.New(http.StatusUnauthorized, "incorrect api key auth")
+	}
+	return &identity.Principal{Token: token}, nil
+}
+
+// GetTokenAccess returns the roles and projects of the given token. If the token is unknown, ErrTokenNotFound is returned
+func (b *BasicTokenValidator) GetTokenAccess(token string) (*TokenAccess, error) {
+	if token == "" {
+		return nil, ErrTokenNotFound
+	}
+	if secretToken := os.Getenv("SECRET_TOKEN"); secretToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(secretToken)) == 1 {
+		return &TokenAccess{Principal: audit.PrincipalFromToken(token), Roles: []string{RoleAdmin}}, nil
+	}
+	if b.store == nil {
+		return nil, ErrTokenNotFound
+	}
+
+	hash := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(hash[:])
+
+	b.mutex.Lock()
+	cached, ok := b.cache[key]
+	b.mutex.Unlock()
+	if ok && b.now().Before(cached.expiresAt) {
+		return cached.access, nil
+	}
+
+	access, err := b.store.GetTokenAccess(token)
+	if err != nil {
+		return nil, err
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.removeExpiredEntries()
+	b.cache[key] = cachedTokenAccess{access: access, expiresAt: b.now().Add(b.cacheTTL)}
+	return access, nil
 }
 
-type BasicTokenValidator struct{}
+func (b *BasicTokenValidator) removeExpiredEntries() {
+	now := b.now()
+	for key, entry := range b.cache {
+		if !now.Before(entry.expiresAt) {
+			delete(b.cache, key)
+		}
+	}
+}
 
-func (b *BasicTokenValidator) ValidateToken(token string) (*models.Principal, error) {
-	if token == os.Getenv("SECRET_TOKEN") {
-		prin := models.Principal(token)
-		return &prin, nil
+func (b *BasicTokenValidator) now() time.Time {
+	if b.theClock == nil {
+		return time.Now()
 	}
-	log.Errorf("Access attempt with incorrect api key auth: %s", token)
-	return nil, openapierrors.New(http.StatusUnauthorized, "incorrect api key auth")
+	return b.theClock.Now()
 }
@@ -1,10 +1,14 @@
 package middleware
 
 import (
-	"github.com/keptn/keptn/api/models"
 	"os"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/keptn/keptn/api/identity"
+	"github.com/stretchr/testify/require"
 )
 
 func TestValidateToken(t *testing.T) {
@@ -14,7 +18,7 @@ func TestValidateToken(t *testing.T) {
 	tests := []struct {
 		name            string
 		args            args
-		want            models.Principal
+		want            identity.Principal
 		configuredToken string
 		wantErr         bool
 	}{
@@ -24,7 +28,7 @@ func TestValidateToken(t *testing.T) {
 				token: "my-token",
 			},
 			configuredToken: "my-token",
-			want:            models.Principal("my-token"),
+			want:            identity.Principal{Token: "my-token"},
 			wantErr:         false,
 		},
 		{
@@ -45,7 +49,7 @@ func TestValidateToken(t *testing.T) {
 				t.Errorf("ValidateToken() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if tt.want != "" {
+			if tt.want.Token != "" {
 				if !reflect.DeepEqual(*got, tt.want) {
 					t.Errorf("ValidateToken() got = %v, want %v", got, tt.want)
 				}
@@ -54,3 +58,55 @@ func TestValidateToken(t *testing.T) {
 		})
 	}
 }
+
+type tokenStoreMock struct {
+	tokens map[string]*TokenAccess
+	calls  int
+}
+
+func (s *tokenStoreMock) GetTokenAccess(token string) (*TokenAccess, error) {
+	s.calls++
+	if access, ok := s.tokens[token]; ok {
+		return access, nil
+	}
+	return nil, ErrTokenNotFound
+}
+
+func TestBasicTokenValidator_NamedTokens(t *testing.T) {
+	_ = os.Setenv("SECRET_TOKEN", "my-token")
+	defer os.Unsetenv("SECRET_TOKEN")
+
+	store := &tokenStoreMock{tokens: map[string]*TokenAccess{
+		"ci-token": {Principal: "token:ci", Roles: []string{RoleOperator}, Projects: []string{"sockshop"}},
+	}}
+	theClock := clock.NewMock()
+	tv := NewBasicTokenValidator(store, 30*time.Second, theClock)
+
+	principal, err := tv.ValidateToken("ci-token")
+	require.Nil(t, err)
+	require.Equal(t, identity.Principal{Token: "ci-token"}, *principal)
+
+	access, err := tv.GetTokenAccess("ci-token")
+	require.Nil(t, err)
+	require.Equal(t, "token:ci", access.Principal)
+	// the result of the first lookup is cached
+	require.Equal(t, 1, store.calls)
+
+	// a revoked token is accepted until its cache entry expires
+	delete(store.tokens, "ci-token")
+	_, err = tv.ValidateToken("ci-token")
+	require.Nil(t, err)
+	theClock.Add(31 * time.Second)
+	_, err = tv.ValidateToken("ci-token")
+	require.NotNil(t, err)
+
+	// the token configured via SECRET_TOKEN grants full access
+	access, err = tv.GetTokenAccess("my-token")
+	require.Nil(t, err)
+	require.Equal(t, []string{RoleAdmin}, access.Roles)
+	require.Empty(t, access.Projects)
+	require.NotContains(t, access.Principal, "my-token")
+
+	_, err = tv.ValidateToken("")
+	require.NotNil(t, err)
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	validateTokenPath    = "/v1/token/validate"
+	internalTokenHeader  = "x-token"
+	validateTokenTimeout = 5 * time.Second
+	namedTokenPrefix     = "token:"
+)
+
+// ErrTokenNotFound is returned by a TokenStore if the given token is unknown
+var ErrTokenNotFound = errors.New("token not found")
+
+// TokenStore returns the roles and projects of named API tokens
+type TokenStore interface {
+	GetTokenAccess(token string) (*TokenAccess, error)
+}
+
+// ShipyardTokenStore looks up named API tokens via the shipyard-controller, which stores the hashes of the tokens.
+// Since the shipyard-controller is called directly, the store authenticates with the internal API token of the cluster
+type ShipyardTokenStore struct {
+	endpoint         string
+	internalAPIToken string
+	httpClient       *http.Client
+}
+
+func NewShipyardTokenStore(shipyardControllerURL, internalAPIToken string, httpClient *http.Client) *ShipyardTokenStore {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: validateTokenTimeout}
+	}
+	return &ShipyardTokenStore{
+		endpoint:         strings.TrimSuffix(shipyardControllerURL, "/") + validateTokenPath,
+		internalAPIToken: internalAPIToken,
+		httpClient:       httpClient,
+	}
+}
+
+type apiToken struct {
+	Name     string   `json:"name"`
+	Roles    []string `json:"roles"`
+	Projects []string `json:"projects"`
+}
+
+func (s *ShipyardTokenStore) GetTokenAccess(token string) (*TokenAccess, error) {
+	payload, err := json.Marshal(map[string]string{"token": token})
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), validateTokenTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.internalAPIToken != "" {
+		req.Header.Set(internalTokenHeader, s.internalAPIToken)
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusNotFound:
+		return nil, ErrTokenNotFound
+	default:
+		return nil, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	result := &apiToken{}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, fmt.Errorf("could not decode api token: %w", err)
+	}
+	return &TokenAccess{
+		Principal: namedTokenPrefix + result.Name,
+		Roles:     result.Roles,
+		Projects:  result.Projects,
+	}, nil
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShipyardTokenStore_GetTokenAccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/token/validate", r.URL.Path)
+		require.Equal(t, "internal-token", r.Header.Get("x-token"))
+		payload := map[string]string{}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&payload))
+		if payload["token"] != "ci-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"name":"ci","roles":["operator"],"projects":["sockshop"],"createdAt":"2022-06-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	store := NewShipyardTokenStore(server.URL+"/", "internal-token", nil)
+
+	access, err := store.GetTokenAccess("ci-token")
+	require.Nil(t, err)
+	require.Equal(t, &TokenAccess{Principal: "token:ci", Roles: []string{RoleOperator}, Projects: []string{"sockshop"}}, access)
+
+	_, err = store.GetTokenAccess("other-token")
+	require.ErrorIs(t, err, ErrTokenNotFound)
+}
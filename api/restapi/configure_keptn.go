@@ -3,12 +3,14 @@
 package restapi
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/benbjohnson/clock"
 	"github.com/kelseyhightower/envconfig"
@@ -16,25 +18,36 @@ import (
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
-	"github.com/go-openapi/runtime/middleware"
 
+	"github.com/keptn/keptn/cp-common/audit"
 	"github.com/keptn/keptn/api/handlers"
 	custommiddleware "github.com/keptn/keptn/api/middleware"
-	"github.com/keptn/keptn/api/models"
 	"github.com/keptn/keptn/api/restapi/operations"
 	"github.com/keptn/keptn/api/restapi/operations/auth"
 	"github.com/keptn/keptn/api/restapi/operations/event"
 	"github.com/keptn/keptn/api/restapi/operations/metadata"
+	"github.com/keptn/keptn/api/tracing"
+	"go.opentelemetry.io/otel"
 )
 
-//go:generate swagger generate server --target ../../api --name Keptn --spec ../swagger.yaml --principal models.Principal
+//go:generate swagger generate server --target ../../api --name Keptn --spec ../swagger.yaml --principal github.com/keptn/keptn/api/identity.Principal
 
 const envVarLogLevel = "LOG_LEVEL"
 
 type EnvConfig struct {
-	MaxAuthEnabled           bool    `envconfig:"MAX_AUTH_ENABLED" default:"true"`
-	MaxAuthRequestsPerSecond float64 `envconfig:"MAX_AUTH_REQUESTS_PER_SECOND" default:"1"`
-	MaxAuthRequestBurst      int     `envconfig:"MAX_AUTH_REQUESTS_BURST" default:"2"`
+	MaxAuthEnabled           bool          `envconfig:"MAX_AUTH_ENABLED" default:"true"`
+	MaxAuthRequestsPerSecond float64       `envconfig:"MAX_AUTH_REQUESTS_PER_SECOND" default:"1"`
+	MaxAuthRequestBurst      int           `envconfig:"MAX_AUTH_REQUESTS_BURST" default:"2"`
+	OTLPEndpoint             string        `envconfig:"OTEL_EXPORTER_OTLP_ENDPOINT" default:""`
+	ShipyardControllerURL    string        `envconfig:"SHIPYARD_CONTROLLER_URL" default:"http://shipyard-controller:8080"`
+	TokenCacheTTL            time.Duration `envconfig:"TOKEN_CACHE_TTL" default:"30s"`
+	InternalAPIToken         string        `envconfig:"INTERNAL_API_TOKEN" default:""`
+	JWTJWKSURL               string        `envconfig:"JWT_JWKS_URL" default:""`
+	JWTJWKSCacheTTL          time.Duration `envconfig:"JWT_JWKS_CACHE_TTL" default:"15m"`
+	JWTIssuer                string        `envconfig:"JWT_ISSUER" default:""`
+	JWTAudience              string        `envconfig:"JWT_AUDIENCE" default:""`
+	JWTGroupsClaim           string        `envconfig:"JWT_GROUPS_CLAIM" default:"groups"`
+	JWTGroupRoles            string        `envconfig:"JWT_GROUP_ROLES" default:""`
 }
 
 func configureFlags(api *operations.KeptnAPI) {
@@ -70,17 +83,29 @@ func configureAPI(api *operations.KeptnAPI) http.Handler {
 	api.JSONProducer = runtime.JSONProducer()
 
 	// Applies when the "x-token" header is set
-	tokenValidator := &custommiddleware.BasicTokenValidator{}
+	tokenValidator, err := getTokenValidator(env)
+	if err != nil {
+		log.WithError(err).Error("Failed to configure token validation")
+		os.Exit(1)
+	}
 	api.KeyAuth = tokenValidator.ValidateToken
+	handlers.SetTokenAccessProvider(tokenValidator)
 
 	// Set your custom authorizer if needed. Default one is security.Authorized()
 	// Expected interface runtime.Authorizer
 	//
 	// Example:
 	// api.APIAuthorizer = security.Authorized()
-	api.AuthAuthHandler = auth.AuthHandlerFunc(func(params auth.AuthParams, principal *models.Principal) middleware.Responder {
-		return auth.NewAuthOK()
-	})
+	api.AuthAuthHandler = auth.AuthHandlerFunc(handlers.AuthHandlerFunc)
+
+	auditClient := audit.NewClient(env.ShipyardControllerURL, env.InternalAPIToken, nil)
+	auditCtx, cancelAudit := context.WithCancel(context.Background())
+	auditDone := make(chan struct{})
+	go func() {
+		auditClient.Run(auditCtx)
+		close(auditDone)
+	}()
+	handlers.SetAuditRecorder(auditClient)
 
 	api.EventPostEventHandler = event.PostEventHandlerFunc(handlers.PostEventHandlerFunc)
 	//api.EventGetEventHandler = event.GetEventHandlerFunc(handlers.GetEventHandlerFunc)
@@ -95,11 +120,49 @@ func configureAPI(api *operations.KeptnAPI) http.Handler {
 		api.AddMiddlewareFor(http.MethodPost, "/auth", rateLimiter.Handle)
 	}
 
-	api.ServerShutdown = func() {}
+	tracerProvider, err := tracing.NewTracerProvider(context.Background(), "api-service", env.OTLPEndpoint)
+	if err != nil {
+		log.WithError(err).Error("Failed to create tracer provider")
+		os.Exit(1)
+	}
+	otel.SetTracerProvider(tracerProvider)
+
+	api.ServerShutdown = func() {
+		// send the remaining audit records
+		cancelAudit()
+		<-auditDone
+		if err := tracerProvider.Shutdown(context.Background()); err != nil {
+			log.WithError(err).Error("Failed to shut down tracer provider")
+		}
+	}
 
 	return setupGlobalMiddleware(api.Serve(setupMiddlewares))
 }
 
+// getTokenValidator returns the validator for the API tokens. If a JWKS URL is configured, bearer tokens of the
+// identity provider are accepted in addition to the API tokens
+func getTokenValidator(env *EnvConfig) (custommiddleware.TokenAccessValidator, error) {
+	basicTokenValidator := custommiddleware.NewBasicTokenValidator(custommiddleware.NewShipyardTokenStore(env.ShipyardControllerURL, env.InternalAPIToken, nil), env.TokenCacheTTL, clock.New())
+	if env.JWTJWKSURL == "" {
+		return basicTokenValidator, nil
+	}
+	if env.JWTIssuer == "" {
+		return nil, fmt.Errorf("JWT_ISSUER must be set if JWT_JWKS_URL is set")
+	}
+	groupRoles, err := custommiddleware.ParseGroupRoles(env.JWTGroupRoles)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse JWT_GROUP_ROLES: %w", err)
+	}
+	config := custommiddleware.JWTTokenValidatorConfig{
+		Issuer:      env.JWTIssuer,
+		Audience:    env.JWTAudience,
+		GroupsClaim: env.JWTGroupsClaim,
+		GroupRoles:  groupRoles,
+	}
+	keySet := custommiddleware.NewJWKSKeySet(env.JWTJWKSURL, env.JWTJWKSCacheTTL, nil, clock.New())
+	return custommiddleware.NewJWTTokenValidator(config, keySet, basicTokenValidator, clock.New()), nil
+}
+
 // The TLS configuration before HTTPS server starts.
 func configureTLS(tlsConfig *tls.Config) {
 	// Make all necessary changes to the TLS configuration here.
@@ -166,6 +229,10 @@ func setupGlobalMiddleware(handler http.Handler) http.Handler {
 			http.StripPrefix("/swagger-ui/", http.FileServer(http.Dir("swagger-ui"))).ServeHTTP(w, r)
 			return
 		}
+		// bearer tokens may also be passed via the Authorization header
+		if r.Header.Get("x-token") == "" && strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			r.Header.Set("x-token", strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		}
 		if strings.Index(r.URL.Path, "/health") == 0 {
 			w.WriteHeader(http.StatusOK)
 			return
@@ -3,7 +3,9 @@ package restapi
 import (
 	"os"
 	"testing"
+	"time"
 
+	custommiddleware "github.com/keptn/keptn/api/middleware"
 	"github.com/stretchr/testify/require"
 )
 
@@ -28,4 +30,30 @@ func Test_getEnvConfigUseDefaults(t *testing.T) {
 	require.Equal(t, true, config.MaxAuthEnabled)
 	require.Equal(t, float64(1), config.MaxAuthRequestsPerSecond)
 	require.Equal(t, 2, config.MaxAuthRequestBurst)
+	require.Equal(t, "http://shipyard-controller:8080", config.ShipyardControllerURL)
+	require.Equal(t, 30*time.Second, config.TokenCacheTTL)
+	require.Equal(t, "", config.JWTJWKSURL)
+	require.Equal(t, 15*time.Minute, config.JWTJWKSCacheTTL)
+	require.Equal(t, "groups", config.JWTGroupsClaim)
+}
+
+func Test_getTokenValidator(t *testing.T) {
+	env := &EnvConfig{ShipyardControllerURL: "http://shipyard-controller:8080", TokenCacheTTL: 30 * time.Second}
+	validator, err := getTokenValidator(env)
+	require.Nil(t, err)
+	require.IsType(t, &custommiddleware.BasicTokenValidator{}, validator)
+
+	env.JWTJWKSURL = "https://idp.example.com/keys"
+	_, err = getTokenValidator(env)
+	require.NotNil(t, err)
+
+	env.JWTIssuer = "https://idp.example.com"
+	env.JWTGroupRoles = "keptn-admins=owner"
+	_, err = getTokenValidator(env)
+	require.NotNil(t, err)
+
+	env.JWTGroupRoles = "keptn-admins=admin"
+	validator, err = getTokenValidator(env)
+	require.Nil(t, err)
+	require.IsType(t, &custommiddleware.JWTTokenValidator{}, validator)
 }
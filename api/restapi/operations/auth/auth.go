@@ -10,20 +10,20 @@ import (
 
 	"github.com/go-openapi/runtime/middleware"
 
-	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/identity"
 )
 
 // AuthHandlerFunc turns a function with the right signature into a auth handler
-type AuthHandlerFunc func(AuthParams, *models.Principal) middleware.Responder
+type AuthHandlerFunc func(AuthParams, *identity.Principal) middleware.Responder
 
 // Handle executing the request and returning a response
-func (fn AuthHandlerFunc) Handle(params AuthParams, principal *models.Principal) middleware.Responder {
+func (fn AuthHandlerFunc) Handle(params AuthParams, principal *identity.Principal) middleware.Responder {
 	return fn(params, principal)
 }
 
 // AuthHandler interface for that can handle valid auth params
 type AuthHandler interface {
-	Handle(AuthParams, *models.Principal) middleware.Responder
+	Handle(AuthParams, *identity.Principal) middleware.Responder
 }
 
 // NewAuth creates a new http.Handler for the auth operation
@@ -55,9 +55,9 @@ func (o *Auth) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	if aCtx != nil {
 		*r = *aCtx
 	}
-	var principal *models.Principal
+	var principal *identity.Principal
 	if uprinc != nil {
-		principal = uprinc.(*models.Principal) // this is really a models.Principal, I promise
+		principal = uprinc.(*identity.Principal) // this is really a identity.Principal, I promise
 	}
 
 	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
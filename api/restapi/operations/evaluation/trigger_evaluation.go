@@ -10,20 +10,20 @@ import (
 
 	"github.com/go-openapi/runtime/middleware"
 
-	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/identity"
 )
 
 // TriggerEvaluationHandlerFunc turns a function with the right signature into a trigger evaluation handler
-type TriggerEvaluationHandlerFunc func(TriggerEvaluationParams, *models.Principal) middleware.Responder
+type TriggerEvaluationHandlerFunc func(TriggerEvaluationParams, *identity.Principal) middleware.Responder
 
 // Handle executing the request and returning a response
-func (fn TriggerEvaluationHandlerFunc) Handle(params TriggerEvaluationParams, principal *models.Principal) middleware.Responder {
+func (fn TriggerEvaluationHandlerFunc) Handle(params TriggerEvaluationParams, principal *identity.Principal) middleware.Responder {
 	return fn(params, principal)
 }
 
 // TriggerEvaluationHandler interface for that can handle valid trigger evaluation params
 type TriggerEvaluationHandler interface {
-	Handle(TriggerEvaluationParams, *models.Principal) middleware.Responder
+	Handle(TriggerEvaluationParams, *identity.Principal) middleware.Responder
 }
 
 // NewTriggerEvaluation creates a new http.Handler for the trigger evaluation operation
@@ -56,9 +56,9 @@ func (o *TriggerEvaluation) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	if aCtx != nil {
 		r = aCtx
 	}
-	var principal *models.Principal
+	var principal *identity.Principal
 	if uprinc != nil {
-		principal = uprinc.(*models.Principal) // this is really a models.Principal, I promise
+		principal = uprinc.(*identity.Principal) // this is really a identity.Principal, I promise
 	}
 
 	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
@@ -10,20 +10,20 @@ import (
 
 	"github.com/go-openapi/runtime/middleware"
 
-	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/identity"
 )
 
 // GetEventHandlerFunc turns a function with the right signature into a get event handler
-type GetEventHandlerFunc func(GetEventParams, *models.Principal) middleware.Responder
+type GetEventHandlerFunc func(GetEventParams, *identity.Principal) middleware.Responder
 
 // Handle executing the request and returning a response
-func (fn GetEventHandlerFunc) Handle(params GetEventParams, principal *models.Principal) middleware.Responder {
+func (fn GetEventHandlerFunc) Handle(params GetEventParams, principal *identity.Principal) middleware.Responder {
 	return fn(params, principal)
 }
 
 // GetEventHandler interface for that can handle valid get event params
 type GetEventHandler interface {
-	Handle(GetEventParams, *models.Principal) middleware.Responder
+	Handle(GetEventParams, *identity.Principal) middleware.Responder
 }
 
 // NewGetEvent creates a new http.Handler for the get event operation
@@ -56,9 +56,9 @@ func (o *GetEvent) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	if aCtx != nil {
 		r = aCtx
 	}
-	var principal *models.Principal
+	var principal *identity.Principal
 	if uprinc != nil {
-		principal = uprinc.(*models.Principal) // this is really a models.Principal, I promise
+		principal = uprinc.(*identity.Principal) // this is really a identity.Principal, I promise
 	}
 
 	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
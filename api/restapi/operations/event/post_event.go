@@ -10,20 +10,20 @@ import (
 
 	"github.com/go-openapi/runtime/middleware"
 
-	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/identity"
 )
 
 // PostEventHandlerFunc turns a function with the right signature into a post event handler
-type PostEventHandlerFunc func(PostEventParams, *models.Principal) middleware.Responder
+type PostEventHandlerFunc func(PostEventParams, *identity.Principal) middleware.Responder
 
 // Handle executing the request and returning a response
-func (fn PostEventHandlerFunc) Handle(params PostEventParams, principal *models.Principal) middleware.Responder {
+func (fn PostEventHandlerFunc) Handle(params PostEventParams, principal *identity.Principal) middleware.Responder {
 	return fn(params, principal)
 }
 
 // PostEventHandler interface for that can handle valid post event params
 type PostEventHandler interface {
-	Handle(PostEventParams, *models.Principal) middleware.Responder
+	Handle(PostEventParams, *identity.Principal) middleware.Responder
 }
 
 // NewPostEvent creates a new http.Handler for the post event operation
@@ -55,9 +55,9 @@ func (o *PostEvent) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	if aCtx != nil {
 		*r = *aCtx
 	}
-	var principal *models.Principal
+	var principal *identity.Principal
 	if uprinc != nil {
-		principal = uprinc.(*models.Principal) // this is really a models.Principal, I promise
+		principal = uprinc.(*identity.Principal) // this is really a identity.Principal, I promise
 	}
 
 	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
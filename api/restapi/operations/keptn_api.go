@@ -19,7 +19,7 @@ import (
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 
-	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/identity"
 	"github.com/keptn/keptn/api/restapi/operations/auth"
 	"github.com/keptn/keptn/api/restapi/operations/event"
 	"github.com/keptn/keptn/api/restapi/operations/metadata"
@@ -47,18 +47,18 @@ func NewKeptnAPI(spec *loads.Document) *KeptnAPI {
 
 		JSONProducer: runtime.JSONProducer(),
 
-		EventPostEventHandler: event.PostEventHandlerFunc(func(params event.PostEventParams, principal *models.Principal) middleware.Responder {
+		EventPostEventHandler: event.PostEventHandlerFunc(func(params event.PostEventParams, principal *identity.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation event.PostEvent has not yet been implemented")
 		}),
-		AuthAuthHandler: auth.AuthHandlerFunc(func(params auth.AuthParams, principal *models.Principal) middleware.Responder {
+		AuthAuthHandler: auth.AuthHandlerFunc(func(params auth.AuthParams, principal *identity.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation auth.Auth has not yet been implemented")
 		}),
-		MetadataMetadataHandler: metadata.MetadataHandlerFunc(func(params metadata.MetadataParams, principal *models.Principal) middleware.Responder {
+		MetadataMetadataHandler: metadata.MetadataHandlerFunc(func(params metadata.MetadataParams, principal *identity.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation metadata.Metadata has not yet been implemented")
 		}),
 
 		// Applies when the "x-token" header is set
-		KeyAuth: func(token string) (*models.Principal, error) {
+		KeyAuth: func(token string) (*identity.Principal, error) {
 			return nil, errors.NotImplemented("api key auth (key) x-token from header param [x-token] has not yet been implemented")
 		},
 		// default authorizer is authorized meaning no requests are blocked
@@ -102,7 +102,7 @@ type KeptnAPI struct {
 
 	// KeyAuth registers a function that takes a token and returns a principal
 	// it performs authentication based on an api key x-token provided in the header
-	KeyAuth func(string) (*models.Principal, error)
+	KeyAuth func(string) (*identity.Principal, error)
 
 	// APIAuthorizer provides access control (ACL/RBAC/ABAC) by providing access to the request and authenticated principal
 	APIAuthorizer runtime.Authorizer
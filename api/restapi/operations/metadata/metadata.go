@@ -10,20 +10,20 @@ import (
 
 	"github.com/go-openapi/runtime/middleware"
 
-	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/identity"
 )
 
 // MetadataHandlerFunc turns a function with the right signature into a metadata handler
-type MetadataHandlerFunc func(MetadataParams, *models.Principal) middleware.Responder
+type MetadataHandlerFunc func(MetadataParams, *identity.Principal) middleware.Responder
 
 // Handle executing the request and returning a response
-func (fn MetadataHandlerFunc) Handle(params MetadataParams, principal *models.Principal) middleware.Responder {
+func (fn MetadataHandlerFunc) Handle(params MetadataParams, principal *identity.Principal) middleware.Responder {
 	return fn(params, principal)
 }
 
 // MetadataHandler interface for that can handle valid metadata params
 type MetadataHandler interface {
-	Handle(MetadataParams, *models.Principal) middleware.Responder
+	Handle(MetadataParams, *identity.Principal) middleware.Responder
 }
 
 // NewMetadata creates a new http.Handler for the metadata operation
@@ -55,9 +55,9 @@ func (o *Metadata) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	if aCtx != nil {
 		*r = *aCtx
 	}
-	var principal *models.Principal
+	var principal *identity.Principal
 	if uprinc != nil {
-		principal = uprinc.(*models.Principal) // this is really a models.Principal, I promise
+		principal = uprinc.(*identity.Principal) // this is really a identity.Principal, I promise
 	}
 
 	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
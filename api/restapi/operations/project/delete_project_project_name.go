@@ -10,20 +10,20 @@ import (
 
 	"github.com/go-openapi/runtime/middleware"
 
-	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/identity"
 )
 
 // DeleteProjectProjectNameHandlerFunc turns a function with the right signature into a delete project project name handler
-type DeleteProjectProjectNameHandlerFunc func(DeleteProjectProjectNameParams, *models.Principal) middleware.Responder
+type DeleteProjectProjectNameHandlerFunc func(DeleteProjectProjectNameParams, *identity.Principal) middleware.Responder
 
 // Handle executing the request and returning a response
-func (fn DeleteProjectProjectNameHandlerFunc) Handle(params DeleteProjectProjectNameParams, principal *models.Principal) middleware.Responder {
+func (fn DeleteProjectProjectNameHandlerFunc) Handle(params DeleteProjectProjectNameParams, principal *identity.Principal) middleware.Responder {
 	return fn(params, principal)
 }
 
 // DeleteProjectProjectNameHandler interface for that can handle valid delete project project name params
 type DeleteProjectProjectNameHandler interface {
-	Handle(DeleteProjectProjectNameParams, *models.Principal) middleware.Responder
+	Handle(DeleteProjectProjectNameParams, *identity.Principal) middleware.Responder
 }
 
 // NewDeleteProjectProjectName creates a new http.Handler for the delete project project name operation
@@ -56,9 +56,9 @@ func (o *DeleteProjectProjectName) ServeHTTP(rw http.ResponseWriter, r *http.Req
 	if aCtx != nil {
 		r = aCtx
 	}
-	var principal *models.Principal
+	var principal *identity.Principal
 	if uprinc != nil {
-		principal = uprinc.(*models.Principal) // this is really a models.Principal, I promise
+		principal = uprinc.(*identity.Principal) // this is really a identity.Principal, I promise
 	}
 
 	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
@@ -10,20 +10,20 @@ import (
 
 	"github.com/go-openapi/runtime/middleware"
 
-	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/identity"
 )
 
 // PostProjectHandlerFunc turns a function with the right signature into a post project handler
-type PostProjectHandlerFunc func(PostProjectParams, *models.Principal) middleware.Responder
+type PostProjectHandlerFunc func(PostProjectParams, *identity.Principal) middleware.Responder
 
 // Handle executing the request and returning a response
-func (fn PostProjectHandlerFunc) Handle(params PostProjectParams, principal *models.Principal) middleware.Responder {
+func (fn PostProjectHandlerFunc) Handle(params PostProjectParams, principal *identity.Principal) middleware.Responder {
 	return fn(params, principal)
 }
 
 // PostProjectHandler interface for that can handle valid post project params
 type PostProjectHandler interface {
-	Handle(PostProjectParams, *models.Principal) middleware.Responder
+	Handle(PostProjectParams, *identity.Principal) middleware.Responder
 }
 
 // NewPostProject creates a new http.Handler for the post project operation
@@ -56,9 +56,9 @@ func (o *PostProject) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	if aCtx != nil {
 		r = aCtx
 	}
-	var principal *models.Principal
+	var principal *identity.Principal
 	if uprinc != nil {
-		principal = uprinc.(*models.Principal) // this is really a models.Principal, I promise
+		principal = uprinc.(*identity.Principal) // this is really a identity.Principal, I promise
 	}
 
 	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
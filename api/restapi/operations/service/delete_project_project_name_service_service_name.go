@@ -10,20 +10,20 @@ import (
 
 	"github.com/go-openapi/runtime/middleware"
 
-	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/identity"
 )
 
 // DeleteProjectProjectNameServiceServiceNameHandlerFunc turns a function with the right signature into a delete project project name service service name handler
-type DeleteProjectProjectNameServiceServiceNameHandlerFunc func(DeleteProjectProjectNameServiceServiceNameParams, *models.Principal) middleware.Responder
+type DeleteProjectProjectNameServiceServiceNameHandlerFunc func(DeleteProjectProjectNameServiceServiceNameParams, *identity.Principal) middleware.Responder
 
 // Handle executing the request and returning a response
-func (fn DeleteProjectProjectNameServiceServiceNameHandlerFunc) Handle(params DeleteProjectProjectNameServiceServiceNameParams, principal *models.Principal) middleware.Responder {
+func (fn DeleteProjectProjectNameServiceServiceNameHandlerFunc) Handle(params DeleteProjectProjectNameServiceServiceNameParams, principal *identity.Principal) middleware.Responder {
 	return fn(params, principal)
 }
 
 // DeleteProjectProjectNameServiceServiceNameHandler interface for that can handle valid delete project project name service service name params
 type DeleteProjectProjectNameServiceServiceNameHandler interface {
-	Handle(DeleteProjectProjectNameServiceServiceNameParams, *models.Principal) middleware.Responder
+	Handle(DeleteProjectProjectNameServiceServiceNameParams, *identity.Principal) middleware.Responder
 }
 
 // NewDeleteProjectProjectNameServiceServiceName creates a new http.Handler for the delete project project name service service name operation
@@ -56,9 +56,9 @@ func (o *DeleteProjectProjectNameServiceServiceName) ServeHTTP(rw http.ResponseW
 	if aCtx != nil {
 		r = aCtx
 	}
-	var principal *models.Principal
+	var principal *identity.Principal
 	if uprinc != nil {
-		principal = uprinc.(*models.Principal) // this is really a models.Principal, I promise
+		principal = uprinc.(*identity.Principal) // this is really a identity.Principal, I promise
 	}
 
 	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
@@ -10,20 +10,20 @@ import (
 
 	"github.com/go-openapi/runtime/middleware"
 
-	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/identity"
 )
 
 // PostProjectProjectNameServiceHandlerFunc turns a function with the right signature into a post project project name service handler
-type PostProjectProjectNameServiceHandlerFunc func(PostProjectProjectNameServiceParams, *models.Principal) middleware.Responder
+type PostProjectProjectNameServiceHandlerFunc func(PostProjectProjectNameServiceParams, *identity.Principal) middleware.Responder
 
 // Handle executing the request and returning a response
-func (fn PostProjectProjectNameServiceHandlerFunc) Handle(params PostProjectProjectNameServiceParams, principal *models.Principal) middleware.Responder {
+func (fn PostProjectProjectNameServiceHandlerFunc) Handle(params PostProjectProjectNameServiceParams, principal *identity.Principal) middleware.Responder {
 	return fn(params, principal)
 }
 
 // PostProjectProjectNameServiceHandler interface for that can handle valid post project project name service params
 type PostProjectProjectNameServiceHandler interface {
-	Handle(PostProjectProjectNameServiceParams, *models.Principal) middleware.Responder
+	Handle(PostProjectProjectNameServiceParams, *identity.Principal) middleware.Responder
 }
 
 // NewPostProjectProjectNameService creates a new http.Handler for the post project project name service operation
@@ -56,9 +56,9 @@ func (o *PostProjectProjectNameService) ServeHTTP(rw http.ResponseWriter, r *htt
 	if aCtx != nil {
 		r = aCtx
 	}
-	var principal *models.Principal
+	var principal *identity.Principal
 	if uprinc != nil {
-		principal = uprinc.(*models.Principal) // this is really a models.Principal, I promise
+		principal = uprinc.(*identity.Principal) // this is really a identity.Principal, I promise
 	}
 
 	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
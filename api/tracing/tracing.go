@@ -0,0 +1,70 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	commontracing "github.com/keptn/keptn/cp-common/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/keptn/keptn/api"
+
+var propagator = propagation.TraceContext{}
+
+// NewTracerProvider creates a TracerProvider that exports spans to an OTLP collector via HTTP. The exporter is configured via the OTEL_EXPORTER_OTLP_* environment variables,
+// and is only created if the given endpoint, i.e. the value of OTEL_EXPORTER_OTLP_ENDPOINT, is not empty.
+// Without an exporter, no spans are exported, but the trace context of the events is still propagated
+func NewTracerProvider(ctx context.Context, serviceName, endpoint string) (*sdktrace.TracerProvider, error) {
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName))),
+	}
+	if endpoint != "" {
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+	return sdktrace.NewTracerProvider(options...), nil
+}
+
+// ContextFromRequest returns a copy of the context of the given request that contains the trace context passed via the traceparent header of the request
+func ContextFromRequest(request *http.Request) context.Context {
+	if request == nil {
+		return context.Background()
+	}
+	return propagator.Extract(request.Context(), propagation.HeaderCarrier(request.Header))
+}
+
+// StartEventSpan starts a span for the given event. If the event carries a trace context in its extensions, the span continues that trace,
+// otherwise the trace of the given context is continued
+func StartEventSpan(ctx context.Context, event apimodels.KeptnContextExtendedCE, spanName string) (context.Context, trace.Span) {
+	eventType := ""
+	if event.Type != nil {
+		eventType = *event.Type
+	}
+	return otel.Tracer(tracerName).Start(commontracing.ContextFromEvent(ctx, event), spanName, trace.WithAttributes(
+		attribute.String("keptn.context", event.Shkeptncontext),
+		attribute.String("keptn.event.id", event.ID),
+		attribute.String("keptn.event.type", eventType),
+	))
+}
+
+// EndSpan ends the given span and marks it as failed if an error is passed
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+const schedulePath = "/v1/schedule"
+
+type createScheduleStruct struct {
+	project       *string
+	stage         *string
+	service       *string
+	sequence      *string
+	cron          *string
+	at            *string
+	overlapPolicy *string
+	labels        *map[string]string
+}
+
+type scheduleParams struct {
+	Project       string            `json:"project"`
+	Stage         string            `json:"stage"`
+	Service       string            `json:"service"`
+	Sequence      string            `json:"sequence"`
+	Cron          string            `json:"cron,omitempty"`
+	RunAt         *time.Time        `json:"runAt,omitempty"`
+	OverlapPolicy string            `json:"overlapPolicy,omitempty"`
+	Labels        map[string]string `json:"labels,omitempty"`
+}
+
+type createScheduleResponse struct {
+	ID string `json:"id"`
+}
+
+var createScheduleParams createScheduleStruct
+
+var createScheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Creates a schedule that triggers a sequence periodically or at a given point in time",
+	Long: `Creates a schedule that triggers a sequence for a service in a stage.
+The sequence is either triggered periodically based on a cron expression (e.g. "0 2 * * 1-5"), or once at the time given by the --at flag (RFC3339 format).
+
+The --overlap-policy flag defines what happens if the sequence triggered by a previous run of the schedule is still active:
+- skip: the run is skipped (default)
+- queue: the sequence is triggered and queued until the active sequence has been finished
+- cancel: the active sequence is aborted before the sequence is triggered again
+`,
+	Example: `keptn create schedule --project=sockshop --stage=dev --service=carts --sequence=evaluation --cron="0 2 * * 1-5"
+keptn create schedule --project=sockshop --stage=dev --service=carts --sequence=delivery --at=2022-05-10T22:00:00Z --overlap-policy=cancel`,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params := scheduleParams{
+			Project:       *createScheduleParams.project,
+			Stage:         *createScheduleParams.stage,
+			Service:       *createScheduleParams.service,
+			Sequence:      *createScheduleParams.sequence,
+			Cron:          *createScheduleParams.cron,
+			OverlapPolicy: *createScheduleParams.overlapPolicy,
+		}
+		if createScheduleParams.labels != nil {
+			params.Labels = *createScheduleParams.labels
+		}
+
+		if (params.Cron == "") == (*createScheduleParams.at == "") {
+			cmd.SilenceUsage = false
+			return errors.New("either --cron or --at must be specified")
+		}
+		if *createScheduleParams.at != "" {
+			runAt, err := time.Parse(time.RFC3339, *createScheduleParams.at)
+			if err != nil {
+				return fmt.Errorf("Invalid value for --at, expected a timestamp in RFC3339 format: %v", err)
+			}
+			params.RunAt = &runAt
+		}
+
+		endPoint, apiToken, err := credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+		if mocking {
+			return nil
+		}
+
+		client := internal.NewControlPlaneClient(endPoint, apiToken)
+
+		response := &createScheduleResponse{}
+		if err := client.Post(schedulePath, params, response); err != nil {
+			return fmt.Errorf("Failed to create schedule for sequence %s: %v", params.Sequence, err)
+		}
+
+		logging.PrintLog(fmt.Sprintf("Schedule %s created successfully", response.ID), logging.InfoLevel)
+		return nil
+	},
+}
+
+func init() {
+	createCmd.AddCommand(createScheduleCmd)
+	createScheduleParams.project = createScheduleCmd.Flags().StringP("project", "p", "",
+		"The Keptn project of the service")
+	createScheduleParams.stage = createScheduleCmd.Flags().StringP("stage", "s", "",
+		"The stage in which the sequence should be triggered")
+	createScheduleParams.service = createScheduleCmd.Flags().StringP("service", "", "",
+		"The service for which the sequence should be triggered")
+	createScheduleParams.sequence = createScheduleCmd.Flags().StringP("sequence", "", "",
+		"The name of the sequence that should be triggered")
+	createScheduleParams.cron = createScheduleCmd.Flags().StringP("cron", "", "",
+		"A cron expression defining when the sequence should be triggered")
+	createScheduleParams.at = createScheduleCmd.Flags().StringP("at", "", "",
+		"A point in time (RFC3339 format) at which the sequence should be triggered once")
+	createScheduleParams.overlapPolicy = createScheduleCmd.Flags().StringP("overlap-policy", "", "skip",
+		"What to do if the sequence is still active from a previous run: skip, queue or cancel")
+	createScheduleParams.labels = createScheduleCmd.Flags().StringToStringP("labels", "l", nil,
+		"Additional labels to be included in the triggered event")
+	createScheduleCmd.MarkFlagRequired("project")
+	createScheduleCmd.MarkFlagRequired("stage")
+	createScheduleCmd.MarkFlagRequired("service")
+	createScheduleCmd.MarkFlagRequired("sequence")
+}
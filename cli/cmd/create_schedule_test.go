@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+)
+
+// TestCreateSchedule tests the create schedule command
+func TestCreateSchedule(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	cmd := fmt.Sprintf(`create schedule --project=sockshop --stage=dev --service=carts --sequence=evaluation --cron=@daily --mock`)
+	_, err := executeActionCommandC(cmd)
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+}
+
+// TestCreateScheduleWithCronAndTime tests that either a cron expression or a point in time has to be specified
+func TestCreateScheduleWithCronAndTime(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+	testInvalidInputHelper("create schedule --project=sockshop --stage=dev --service=carts --sequence=evaluation --cron=@daily --at=2022-05-10T22:00:00Z --mock",
+		"either --cron or --at must be specified", t)
+}
+
+// TestCreateScheduleInvalidTime tests that the point in time has to be in RFC3339 format
+func TestCreateScheduleInvalidTime(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+	testInvalidInputHelper("create schedule --project=sockshop --stage=dev --service=carts --sequence=evaluation --cron= --at=tomorrow --mock",
+		"Invalid value for --at, expected a timestamp in RFC3339 format: parsing time \"tomorrow\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"tomorrow\" as \"2006\"", t)
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+const tokenPath = "/v1/token"
+
+type createTokenStruct struct {
+	roles    *string
+	projects *string
+	rotate   *bool
+}
+
+type createTokenParams struct {
+	Name     string   `json:"name"`
+	Roles    []string `json:"roles"`
+	Projects []string `json:"projects,omitempty"`
+}
+
+type createTokenResponse struct {
+	Name     string   `json:"name"`
+	Roles    []string `json:"roles"`
+	Projects []string `json:"projects"`
+	Token    string   `json:"token"`
+}
+
+var createTokenCmdParams createTokenStruct
+
+var createTokenCmd = &cobra.Command{
+	Use:   "token TOKEN_NAME --roles=ROLES",
+	Short: "Creates a named API token with the given roles",
+	Long: `Creates a named API token that grants the given roles, optionally limited to a set of projects.
+The available roles are:
+- viewer: read access
+- approver: read access and sending the results of approvals
+- operator: read and write access, except for the creation and deletion of projects and the management of API tokens
+- admin: full access
+
+The token is only printed once and cannot be retrieved afterwards. With the --rotate flag, the existing token with the given name is replaced
+by a new one with the same roles and projects, and the previous token becomes invalid.
+`,
+	Example: `keptn create token ci-pipeline --roles=operator --projects=sockshop
+keptn create token ci-pipeline --rotate`,
+	SilenceUsage: true,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			cmd.SilenceUsage = false
+			return errors.New("required argument TOKEN_NAME not set")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		roles := splitCommaSeparated(*createTokenCmdParams.roles)
+		projects := splitCommaSeparated(*createTokenCmdParams.projects)
+		if !*createTokenCmdParams.rotate && len(roles) == 0 {
+			cmd.SilenceUsage = false
+			return errors.New("at least one role must be specified with --roles")
+		}
+		if *createTokenCmdParams.rotate && (len(roles) > 0 || len(projects) > 0) {
+			cmd.SilenceUsage = false
+			return errors.New("the roles and projects of a token cannot be changed when rotating it")
+		}
+
+		endPoint, apiToken, err := credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+		if mocking {
+			return nil
+		}
+
+		client := internal.NewControlPlaneClient(endPoint, apiToken)
+
+		response := &createTokenResponse{}
+		if *createTokenCmdParams.rotate {
+			if err := client.Post(tokenPath+"/"+url.PathEscape(args[0])+"/rotate", nil, response); err != nil {
+				return fmt.Errorf("Failed to rotate token %s: %v", args[0], err)
+			}
+		} else {
+			params := createTokenParams{
+				Name:     args[0],
+				Roles:    roles,
+				Projects: projects,
+			}
+			if err := client.Post(tokenPath, params, response); err != nil {
+				return fmt.Errorf("Failed to create token %s: %v", args[0], err)
+			}
+		}
+
+		fmt.Printf("API token %s: %s\n", response.Name, response.Token)
+		fmt.Println("Make sure to store the token, it cannot be retrieved again.")
+		return nil
+	},
+}
+
+func splitCommaSeparated(value string) []string {
+	values := []string{}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func init() {
+	createCmd.AddCommand(createTokenCmd)
+	createTokenCmdParams.roles = createTokenCmd.Flags().String("roles", "",
+		"The comma separated roles granted by the token: viewer, approver, operator or admin")
+	createTokenCmdParams.projects = createTokenCmd.Flags().String("projects", "",
+		"The comma separated projects the token is limited to. If not set, the token grants access to all projects")
+	createTokenCmdParams.rotate = createTokenCmd.Flags().Bool("rotate", false,
+		"Replace the existing token with the given name by a new one")
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+)
+
+// TestCreateToken tests the create token command
+func TestCreateToken(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	cmd := "create token ci-pipeline --roles=operator,approver --projects=sockshop --rotate=false --mock"
+	_, err := executeActionCommandC(cmd)
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+}
+
+// TestRotateToken tests the create token command with the rotate flag
+func TestRotateToken(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	cmd := "create token ci-pipeline --rotate --roles= --projects= --mock"
+	_, err := executeActionCommandC(cmd)
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+}
+
+// TestCreateTokenWithoutRoles tests that at least one role has to be specified
+func TestCreateTokenWithoutRoles(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+	testInvalidInputHelper("create token ci-pipeline --roles= --projects= --rotate=false --mock", "at least one role must be specified with --roles", t)
+}
+
+// TestRotateTokenWithRoles tests that the roles of a token cannot be changed when rotating it
+func TestRotateTokenWithRoles(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+	testInvalidInputHelper("create token ci-pipeline --rotate --roles=admin --mock", "the roles and projects of a token cannot be changed when rotating it", t)
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+var deleteTokenCmd = &cobra.Command{
+	Use:          "token TOKEN_NAME",
+	Short:        "Revokes a named API token",
+	Long:         "Revokes a named API token. Requests using the token are rejected afterwards.",
+	Example:      `keptn delete token ci-pipeline`,
+	SilenceUsage: true,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			cmd.SilenceUsage = false
+			return errors.New("required argument TOKEN_NAME not set")
+		} else if len(args) >= 2 {
+			cmd.SilenceUsage = false
+			return errors.New("too many arguments set")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		endPoint, apiToken, err := credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+		if mocking {
+			return nil
+		}
+
+		client := internal.NewControlPlaneClient(endPoint, apiToken)
+		if err := client.Delete(tokenPath+"/"+url.PathEscape(args[0]), nil); err != nil {
+			return fmt.Errorf("Failed to revoke token %s: %v", args[0], err)
+		}
+
+		logging.PrintLog(fmt.Sprintf("Token %s has been revoked", args[0]), logging.InfoLevel)
+		return nil
+	},
+}
+
+func init() {
+	deleteCmd.AddCommand(deleteTokenCmd)
+}
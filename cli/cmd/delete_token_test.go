@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+)
+
+// TestDeleteToken tests the delete token command
+func TestDeleteToken(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	_, err := executeActionCommandC("delete token ci-pipeline --mock")
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+}
+
+// TestDeleteTokenUnknownCommand
+func TestDeleteTokenUnknownCommand(t *testing.T) {
+	testInvalidInputHelper("delete token ci-pipeline someUnknownCommand", "too many arguments set", t)
+}
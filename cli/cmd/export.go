@@ -0,0 +1,12 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var exportCmd = &cobra.Command{
+	Use:   "export [ project ]",
+	Short: "Exports a project as a portable archive",
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+const exportProjectPath = "/v1/project/%s/export"
+
+type exportProjectStruct struct {
+	output           *string
+	includeSequences *bool
+}
+
+var exportProjectParams exportProjectStruct
+
+var exportProjectCmd = &cobra.Command{
+	Use:   "project PROJECTNAME",
+	Short: "Exports a project as a tar.gz archive",
+	Long: `Exports the shipyard, the stage and service resources, the subscriptions and the names of the secrets of a project as a tar.gz archive.
+The values of the secrets are not exported. The archive can be imported into another Keptn installation using keptn import project.`,
+	Example: `keptn export project sockshop
+keptn export project sockshop --output=./backup/sockshop.tar.gz --include-sequences`,
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName := args[0]
+		endPoint, apiToken, err := credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+		if mocking {
+			return nil
+		}
+
+		client := internal.NewControlPlaneClient(endPoint, apiToken)
+
+		path := fmt.Sprintf(exportProjectPath, url.PathEscape(projectName))
+		if *exportProjectParams.includeSequences {
+			path += "?includeSequences=true"
+		}
+
+		var archive []byte
+		if err := client.Get(path, &archive); err != nil {
+			return fmt.Errorf("Failed to export project %s: %v", projectName, err)
+		}
+
+		output := *exportProjectParams.output
+		if output == "" {
+			output = projectName + ".tar.gz"
+		}
+		if err := ioutil.WriteFile(output, archive, 0644); err != nil {
+			return fmt.Errorf("Failed to write archive: %v", err)
+		}
+
+		fmt.Printf("Exported project %s to %s\n", projectName, output)
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportProjectCmd)
+	exportProjectParams.output = exportProjectCmd.Flags().StringP("output", "o", "",
+		"The file the archive is written to. Defaults to PROJECTNAME.tar.gz")
+	exportProjectParams.includeSequences = exportProjectCmd.Flags().BoolP("include-sequences", "", false,
+		"Include the execution history of the sequences of the project")
+}
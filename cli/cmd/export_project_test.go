@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+)
+
+// TestExportProject tests the export project command
+func TestExportProject(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	_, err := executeActionCommandC("export project sockshop --output=./sockshop.tar.gz --include-sequences --mock")
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+}
+
+// TestExportProjectMissingName
+func TestExportProjectMissingName(t *testing.T) {
+	testInvalidInputHelper("export project --mock", "accepts 1 arg(s), received 0", t)
+}
+
+// TestExportProjectUnknownParameter
+func TestExportProjectUnknownParmeter(t *testing.T) {
+	testInvalidInputHelper("export project sockshop --outputt=./sockshop.tar.gz", "unknown flag: --outputt", t)
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+const taskAttemptsPath = "/v1/sequence/%s/%s/attempts"
+
+type getAttemptsStruct struct {
+	project      *string
+	keptnContext *string
+	stage        *string
+}
+
+type taskExecutionAttempt struct {
+	TriggeredID string `json:"triggeredID"`
+	Result      string `json:"result"`
+	Status      string `json:"status"`
+	FinishedAt  string `json:"finishedAt"`
+}
+
+type taskAttemptHistory struct {
+	Stage       string                 `json:"stage"`
+	Task        string                 `json:"task"`
+	TriggeredID string                 `json:"triggeredID"`
+	Result      string                 `json:"result"`
+	Status      string                 `json:"status"`
+	Attempts    []taskExecutionAttempt `json:"attempts"`
+}
+
+type getTaskAttemptsResponse struct {
+	Tasks []taskAttemptHistory `json:"tasks"`
+}
+
+var getAttemptsParams getAttemptsStruct
+
+var getAttemptsCmd = &cobra.Command{
+	Use:     "attempts",
+	Aliases: []string{"attempt"},
+	Short:   "Get the attempt history of the tasks of a sequence",
+	Long: `Get the outcome of all tasks of a sequence. For tasks that have been retried according to their retry policy,
+the previous attempts are listed as well.`,
+	Example: `keptn get attempts --project=sockshop --keptn-context=8929e5e5-3826-488f-9257-708bfa974909
+STAGE     TASK          ATTEMPT   TRIGGERED ID                           RESULT   STATUS      FINISHED AT
+dev       deployment    1         1a2b3c4d-3826-488f-9257-708bfa974909   fail     errored     2022-05-10T09:51:00.000Z
+dev       deployment    2         5e6f7a8b-3826-488f-9257-708bfa974909   pass     succeeded
+dev       test          1         9c0d1e2f-3826-488f-9257-708bfa974909
+`,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		endPoint, apiToken, err := credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+		if mocking {
+			return nil
+		}
+
+		client := internal.NewControlPlaneClient(endPoint, apiToken)
+
+		path := fmt.Sprintf(taskAttemptsPath, url.PathEscape(*getAttemptsParams.project), url.PathEscape(*getAttemptsParams.keptnContext))
+		if *getAttemptsParams.stage != "" {
+			path += "?stage=" + url.QueryEscape(*getAttemptsParams.stage)
+		}
+
+		response := &getTaskAttemptsResponse{}
+		if err := client.Get(path, response); err != nil {
+			return fmt.Errorf("Failed to retrieve task attempts of sequence %s: %v", *getAttemptsParams.keptnContext, err)
+		}
+
+		if len(response.Tasks) == 0 {
+			fmt.Println("No tasks found")
+			return nil
+		}
+
+		w := new(tabwriter.Writer)
+		w.Init(os.Stdout, 10, 8, 0, '\t', 0)
+		fmt.Fprintln(w, "STAGE\tTASK\tATTEMPT\tTRIGGERED ID\tRESULT\tSTATUS\tFINISHED AT")
+		for _, task := range response.Tasks {
+			for index, attempt := range task.Attempts {
+				fmt.Fprintln(w, task.Stage+"\t"+task.Task+"\t"+strconv.Itoa(index+1)+"\t"+attempt.TriggeredID+"\t"+attempt.Result+"\t"+attempt.Status+"\t"+attempt.FinishedAt)
+			}
+			fmt.Fprintln(w, task.Stage+"\t"+task.Task+"\t"+strconv.Itoa(len(task.Attempts)+1)+"\t"+task.TriggeredID+"\t"+task.Result+"\t"+task.Status+"\t")
+		}
+		return w.Flush()
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getAttemptsCmd)
+	getAttemptsParams.project = getAttemptsCmd.Flags().StringP("project", "p", "",
+		"The Keptn project the sequence belongs to")
+	getAttemptsParams.keptnContext = getAttemptsCmd.Flags().StringP("keptn-context", "c", "",
+		"The Keptn context of the sequence")
+	getAttemptsParams.stage = getAttemptsCmd.Flags().StringP("stage", "s", "",
+		"The Keptn stage of the sequence. If not set, the tasks of all stages are listed")
+	getAttemptsCmd.MarkFlagRequired("project")
+	getAttemptsCmd.MarkFlagRequired("keptn-context")
+}
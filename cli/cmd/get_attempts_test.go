@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+)
+
+// TestGetAttempts tests the get attempts command
+func TestGetAttempts(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	cmd := fmt.Sprintf("get attempts --project=sockshop --keptn-context=8929e5e5-3826-488f-9257-708bfa974909 --stage=dev --mock")
+	_, err := executeActionCommandC(cmd)
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+}
+
+// TestGetAttemptsUnknownParameter
+func TestGetAttemptsUnknownParameter(t *testing.T) {
+	testInvalidInputHelper("get attempts --projectt=sockshop --keptn-context=djsfjdfdsjjcs", "unknown flag: --projectt", t)
+}
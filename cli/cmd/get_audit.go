@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+const auditRecordsPath = "/v1/audit"
+
+type getAuditStruct struct {
+	project    *string
+	principal  *string
+	action     *string
+	targetType *string
+	target     *string
+	from       *string
+	to         *string
+	limit      *int
+}
+
+type auditRecord struct {
+	Time       string `json:"time"`
+	Service    string `json:"service"`
+	Principal  string `json:"principal"`
+	Action     string `json:"action"`
+	TargetType string `json:"targetType"`
+	Target     string `json:"target"`
+	Project    string `json:"project"`
+	StatusCode int    `json:"statusCode"`
+}
+
+type getAuditRecordsResponse struct {
+	TotalCount int64         `json:"totalCount"`
+	Records    []auditRecord `json:"records"`
+}
+
+var getAuditParams getAuditStruct
+
+var getAuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Get the audit log of the state-changing API calls",
+	Long: `Get the audit records of the state-changing API calls that have been handled by the Keptn control plane, e.g. the creation of a project or the update of a secret.
+The records are listed from newest to oldest and can be filtered by project, principal, action, target and time range.`,
+	Example: `keptn get audit --project=sockshop --from=2022-06-01T00:00:00Z
+TIME                       SERVICE               PRINCIPAL            ACTION             TARGET                                 PROJECT    STATUS
+2022-06-02T09:51:00.000Z   shipyard-controller   api-token:1a2b3c4d   sequence.control   8929e5e5-3826-488f-9257-708bfa974909   sockshop   200
+2022-06-01T12:00:00.000Z   shipyard-controller   internal             service.create     carts                                  sockshop   200
+`,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		endPoint, apiToken, err := credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+		if mocking {
+			return nil
+		}
+
+		client := internal.NewControlPlaneClient(endPoint, apiToken)
+
+		response := &getAuditRecordsResponse{}
+		if err := client.Get(auditRecordsPath+"?"+getAuditQuery(getAuditParams).Encode(), response); err != nil {
+			return fmt.Errorf("Failed to retrieve audit records: %v", err)
+		}
+
+		if len(response.Records) == 0 {
+			fmt.Println("No audit records found")
+			return nil
+		}
+
+		w := new(tabwriter.Writer)
+		w.Init(os.Stdout, 10, 8, 0, '\t', 0)
+		fmt.Fprintln(w, "TIME\tSERVICE\tPRINCIPAL\tACTION\tTARGET\tPROJECT\tSTATUS")
+		for _, record := range response.Records {
+			fmt.Fprintln(w, record.Time+"\t"+record.Service+"\t"+record.Principal+"\t"+record.Action+"\t"+record.Target+"\t"+record.Project+"\t"+strconv.Itoa(record.StatusCode))
+		}
+		return w.Flush()
+	},
+}
+
+func getAuditQuery(params getAuditStruct) url.Values {
+	query := url.Values{}
+	addQueryParam := func(key string, value *string) {
+		if value != nil && *value != "" {
+			query.Set(key, *value)
+		}
+	}
+	addQueryParam("project", params.project)
+	addQueryParam("principal", params.principal)
+	addQueryParam("action", params.action)
+	addQueryParam("targetType", params.targetType)
+	addQueryParam("target", params.target)
+	addQueryParam("from", params.from)
+	addQueryParam("to", params.to)
+	if params.limit != nil && *params.limit > 0 {
+		query.Set("pageSize", strconv.Itoa(*params.limit))
+	}
+	return query
+}
+
+func init() {
+	getCmd.AddCommand(getAuditCmd)
+	getAuditParams.project = getAuditCmd.Flags().StringP("project", "p", "",
+		"Only list the records of the given Keptn project")
+	getAuditParams.principal = getAuditCmd.Flags().String("principal", "",
+		"Only list the records of the given principal, e.g. 'api-token:1a2b3c4d'")
+	getAuditParams.action = getAuditCmd.Flags().String("action", "",
+		"Only list the records of the given action, e.g. 'project.delete'")
+	getAuditParams.targetType = getAuditCmd.Flags().String("target-type", "",
+		"Only list the records of actions on the given type of target, e.g. 'secret'")
+	getAuditParams.target = getAuditCmd.Flags().String("target", "",
+		"Only list the records of actions on the given target")
+	getAuditParams.from = getAuditCmd.Flags().String("from", "",
+		"Only list the records created at or after the given point in time (RFC3339)")
+	getAuditParams.to = getAuditCmd.Flags().String("to", "",
+		"Only list the records created before the given point in time (RFC3339)")
+	getAuditParams.limit = getAuditCmd.Flags().Int("limit", 50,
+		"The maximum number of records to list")
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetAudit tests the get audit command
+func TestGetAudit(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	cmd := "get audit --project=sockshop --action=project.delete --from=2022-06-01T00:00:00Z --mock"
+	_, err := executeActionCommandC(cmd)
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+}
+
+// TestGetAuditUnknownParameter
+func TestGetAuditUnknownParameter(t *testing.T) {
+	testInvalidInputHelper("get audit --projectt=sockshop", "unknown flag: --projectt", t)
+}
+
+func Test_getAuditQuery(t *testing.T) {
+	project := "sockshop"
+	principal := ""
+	targetType := "secret"
+	limit := 10
+
+	query := getAuditQuery(getAuditStruct{project: &project, principal: &principal, targetType: &targetType, limit: &limit})
+
+	require.Equal(t, "pageSize=10&project=sockshop&targetType=secret", query.Encode())
+}
@@ -17,12 +17,15 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/keptn/keptn/cli/internal"
 
 	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
 	"github.com/spf13/cobra"
 )
 
@@ -32,6 +35,18 @@ type getStageStruct struct {
 
 var stageParameter getStageStruct
 
+// stageFreezeWindow contains the properties of a freeze window of a stage that are shown by the get stage command
+type stageFreezeWindow struct {
+	Stage  string `json:"stage"`
+	Name   string `json:"name"`
+	Action string `json:"action"`
+	Active bool   `json:"active"`
+}
+
+type getStageFreezeWindowsResponse struct {
+	FreezeWindows []stageFreezeWindow `json:"freezeWindows"`
+}
+
 // getStageCmd represents the get stage command
 var getStageCmd = &cobra.Command{
 	Use:     "stage",
@@ -39,13 +54,13 @@ var getStageCmd = &cobra.Command{
 	Short:   "Get details of a stage",
 	Long:    `Get all stages or details of a stage from a given Keptn project`,
 	Example: `keptn get stages --project=sockshop
-NAME           CREATION DATE                 
-staging        2020-04-06T14:37:45.210Z
-production     2020-04-06T14:37:45.210Z
+NAME           CREATION DATE                 FREEZE WINDOWS
+staging        2020-04-06T14:37:45.210Z      -
+production     2020-04-06T14:37:45.210Z      weekend (active)
 
 keptn get stage staging --project sockshop
-NAME           CREATION DATE                 
-staging        2020-04-06T14:37:45.210Z
+NAME           CREATION DATE                 FREEZE WINDOWS
+staging        2020-04-06T14:37:45.210Z      -
 `,
 	SilenceUsage: true,
 	Args: func(cmd *cobra.Command, args []string) error {
@@ -79,13 +94,19 @@ staging        2020-04-06T14:37:45.210Z
 				return nil
 			}
 
+			// freeze windows are an optional addition to the output, so the stages are still shown if they cannot be retrieved
+			freezeWindows, err := getStageFreezeWindows(endPoint, apiToken, *stageParameter.project)
+			if err != nil {
+				logging.PrintLog(fmt.Sprintf("Could not retrieve freeze windows of project %s: %v", *stageParameter.project, err), logging.VerboseLevel)
+			}
+
 			w := new(tabwriter.Writer)
 			w.Init(os.Stdout, 10, 8, 0, '\t', 0)
-			fmt.Fprintln(w, "NAME\tCREATION DATE")
+			fmt.Fprintln(w, "NAME\tCREATION DATE\tFREEZE WINDOWS")
 
 			for _, stage := range stages {
 				if len(args) == 1 && stage.StageName == args[0] || len(args) == 0 {
-					fmt.Fprintln(w, stage.StageName+"\tn/a")
+					fmt.Fprintln(w, stage.StageName+"\tn/a\t"+formatStageFreezeWindows(freezeWindows[stage.StageName]))
 				}
 			}
 			err = w.Flush()
@@ -97,6 +118,36 @@ staging        2020-04-06T14:37:45.210Z
 	},
 }
 
+// getStageFreezeWindows retrieves the freeze windows of the stages of a project, grouped by stage name
+func getStageFreezeWindows(endPoint url.URL, apiToken string, project string) (map[string][]stageFreezeWindow, error) {
+	response := &getStageFreezeWindowsResponse{}
+	client := internal.NewControlPlaneClient(endPoint, apiToken)
+	if err := client.Get("/v1/sequence/"+url.PathEscape(project)+"?pageSize=1", response); err != nil {
+		return nil, err
+	}
+
+	result := map[string][]stageFreezeWindow{}
+	for _, freezeWindow := range response.FreezeWindows {
+		result[freezeWindow.Stage] = append(result[freezeWindow.Stage], freezeWindow)
+	}
+	return result, nil
+}
+
+func formatStageFreezeWindows(freezeWindows []stageFreezeWindow) string {
+	if len(freezeWindows) == 0 {
+		return "-"
+	}
+	names := []string{}
+	for _, freezeWindow := range freezeWindows {
+		name := freezeWindow.Name
+		if freezeWindow.Active {
+			name += " (active)"
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ", ")
+}
+
 func init() {
 	getCmd.AddCommand(getStageCmd)
 
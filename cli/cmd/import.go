@@ -0,0 +1,12 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var importCmd = &cobra.Command{
+	Use:   "import [ project ]",
+	Short: "Imports a project from an archive created by keptn export project",
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+const importProjectPath = "/v1/project/%s/import"
+
+type importProjectStruct struct {
+	archive           *string
+	gitUser           *string
+	gitRemoteURL      *string
+	gitToken          *string
+	gitPrivateKey     *string
+	gitPrivateKeyPass *string
+	insecureSkipTLS   *bool
+}
+
+type importProjectRequest struct {
+	Archive           string `json:"archive"`
+	GitRemoteURL      string `json:"gitRemoteURL,omitempty"`
+	GitToken          string `json:"gitToken,omitempty"`
+	GitUser           string `json:"gitUser,omitempty"`
+	GitPrivateKey     string `json:"gitPrivateKey,omitempty"`
+	GitPrivateKeyPass string `json:"gitPrivateKeyPass,omitempty"`
+	InsecureSkipTLS   bool   `json:"insecureSkipTLS"`
+}
+
+type archivedSecret struct {
+	Name  string `json:"name"`
+	Scope string `json:"scope"`
+}
+
+type importProjectResponse struct {
+	Warnings       []string         `json:"warnings"`
+	MissingSecrets []archivedSecret `json:"missingSecrets"`
+}
+
+var importProjectParams importProjectStruct
+
+var importProjectCmd = &cobra.Command{
+	Use:   "project PROJECTNAME --archive=FILEPATH",
+	Short: "Creates a project from an archive created by keptn export project",
+	Long: `Creates a project from an archive created by keptn export project. The stages, services, resources and subscriptions of the archive are recreated.
+The project can be given a different name than the exported one. Subscriptions of integrations that are not registered in this Keptn installation are skipped,
+and secrets that do not exist need to be created manually, since their values are not part of the archive.`,
+	Example: `keptn import project sockshop --archive=./sockshop.tar.gz
+keptn import project sockshop --archive=./sockshop.tar.gz --git-user=GIT_USER --git-remote-url=GIT_REMOTE_URL --git-token=GIT_TOKEN`,
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName := args[0]
+		endPoint, apiToken, err := credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		if *importProjectParams.gitToken != "" && *importProjectParams.gitPrivateKey != "" {
+			return errors.New("Access token or private key cannot be set together")
+		}
+
+		archive, err := ioutil.ReadFile(*importProjectParams.archive)
+		if err != nil {
+			return fmt.Errorf("Failed to read archive: %v", err)
+		}
+
+		request := importProjectRequest{
+			Archive:           base64.StdEncoding.EncodeToString(archive),
+			GitRemoteURL:      *importProjectParams.gitRemoteURL,
+			GitToken:          *importProjectParams.gitToken,
+			GitUser:           *importProjectParams.gitUser,
+			GitPrivateKeyPass: *importProjectParams.gitPrivateKeyPass,
+			InsecureSkipTLS:   *importProjectParams.insecureSkipTLS,
+		}
+		if *importProjectParams.gitPrivateKey != "" {
+			privateKey, err := ioutil.ReadFile(*importProjectParams.gitPrivateKey)
+			if err != nil {
+				return fmt.Errorf("unable to read privateKey file: %v", err)
+			}
+			request.GitPrivateKey = base64.StdEncoding.EncodeToString(privateKey)
+		}
+
+		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+		if mocking {
+			return nil
+		}
+
+		client := internal.NewControlPlaneClient(endPoint, apiToken)
+
+		response := &importProjectResponse{}
+		if err := client.Post(fmt.Sprintf(importProjectPath, url.PathEscape(projectName)), request, response); err != nil {
+			return fmt.Errorf("Failed to import project %s: %v", projectName, err)
+		}
+
+		for _, warning := range response.Warnings {
+			fmt.Println("WARNING: " + warning)
+		}
+		for _, secret := range response.MissingSecrets {
+			fmt.Printf("Secret %s with scope %s does not exist and needs to be created\n", secret.Name, secret.Scope)
+		}
+		fmt.Printf("Imported project %s\n", projectName)
+		return nil
+	},
+}
+
+func init() {
+	importCmd.AddCommand(importProjectCmd)
+	importProjectParams.archive = importProjectCmd.Flags().StringP("archive", "a", "",
+		"The path to the archive created by keptn export project")
+	importProjectParams.gitUser = importProjectCmd.Flags().StringP("git-user", "u", "", "The git user of the upstream target")
+	importProjectParams.gitRemoteURL = importProjectCmd.Flags().StringP("git-remote-url", "r", "", "The remote url of the upstream target")
+	importProjectParams.gitToken = importProjectCmd.Flags().StringP("git-token", "t", "", "The git token of the git user")
+	importProjectParams.gitPrivateKey = importProjectCmd.Flags().StringP("git-private-key", "k", "", "The SSH git private key of the git user")
+	importProjectParams.gitPrivateKeyPass = importProjectCmd.Flags().StringP("git-private-key-pass", "l", "", "The passphrase of git private key")
+	importProjectParams.insecureSkipTLS = importProjectCmd.Flags().BoolP("insecure-skip-tls", "", false, "Skip tls verification for communication with upstream repository")
+	importProjectCmd.MarkFlagRequired("archive")
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+)
+
+// TestImportProject tests the import project command
+func TestImportProject(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	archive, err := ioutil.TempFile("", "sockshop-*.tar.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(archive.Name())
+
+	_, err = executeActionCommandC("import project sockshop --archive=" + archive.Name() + " --git-user=user --git-remote-url=https://my-repo --git-token=token --mock")
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+}
+
+// TestImportProjectMissingArchive
+func TestImportProjectMissingArchive(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	_, err := executeActionCommandC("import project sockshop --archive=./does-not-exist.tar.gz --mock")
+	if err == nil {
+		t.Errorf("expected an error for a missing archive")
+	}
+}
+
+// TestImportProjectUnknownParameter
+func TestImportProjectUnknownParmeter(t *testing.T) {
+	testInvalidInputHelper("import project sockshop --archivee=./sockshop.tar.gz", "unknown flag: --archivee", t)
+}
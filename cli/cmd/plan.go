@@ -0,0 +1,12 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var planCmd = &cobra.Command{
+	Use:   "plan [ sequence ]",
+	Short: "Previews the execution of a sequence without triggering it",
+}
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+}
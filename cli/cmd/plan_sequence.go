@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+const planSequencePath = "/v1/project/%s/sequence/plan"
+
+type planSequenceStruct struct {
+	project  *string
+	stage    *string
+	sequence *string
+	result   *string
+	shipyard *string
+}
+
+type planSequenceRequest struct {
+	Stage    string `json:"stage"`
+	Sequence string `json:"sequence"`
+	Result   string `json:"result,omitempty"`
+	Shipyard string `json:"shipyard,omitempty"`
+}
+
+type plannedTask struct {
+	Name         string   `json:"name"`
+	Parallel     string   `json:"parallel"`
+	When         string   `json:"when"`
+	Timeout      string   `json:"timeout"`
+	Integrations []string `json:"integrations"`
+}
+
+type sequencePlanStep struct {
+	Stage       string        `json:"stage"`
+	Sequence    string        `json:"sequence"`
+	TriggeredBy string        `json:"triggeredBy"`
+	Timeout     string        `json:"timeout"`
+	Tasks       []plannedTask `json:"tasks"`
+}
+
+type sequencePlan struct {
+	Steps []sequencePlanStep `json:"steps"`
+}
+
+var planSequenceParams planSequenceStruct
+
+var planSequenceCmd = &cobra.Command{
+	Use:   "sequence",
+	Short: "Shows the stages and tasks that are executed when a sequence is triggered",
+	Long: `Shows the sequences and tasks that are executed when a sequence is triggered in a stage, including the sequences of other stages
+that are triggered subsequently. No events are sent. The --result flag determines the assumed result of each sequence,
+which decides the subsequent sequences. If the --shipyard flag is not set, the current shipyard of the project is used.`,
+	Example: `keptn plan sequence --project=sockshop --stage=dev --sequence=delivery
+STAGE     SEQUENCE   TRIGGERED BY              TASK         PARALLEL   TIMEOUT   INTEGRATIONS
+dev       delivery                             deployment                        helm-service
+dev       delivery                             test                    30m0s     jmeter-service
+staging   delivery   dev.delivery.finished     deployment                        helm-service
+`,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		endPoint, apiToken, err := credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		request := planSequenceRequest{
+			Stage:    *planSequenceParams.stage,
+			Sequence: *planSequenceParams.sequence,
+			Result:   *planSequenceParams.result,
+		}
+		if *planSequenceParams.shipyard != "" {
+			shipyard, err := retrieveShipyard(*planSequenceParams.shipyard)
+			if err != nil {
+				return fmt.Errorf("Failed to read shipyard file - %s", err.Error())
+			}
+			request.Shipyard = base64.StdEncoding.EncodeToString(shipyard)
+		}
+
+		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+		if mocking {
+			return nil
+		}
+
+		client := internal.NewControlPlaneClient(endPoint, apiToken)
+
+		plan := &sequencePlan{}
+		if err := client.Post(fmt.Sprintf(planSequencePath, url.PathEscape(*planSequenceParams.project)), request, plan); err != nil {
+			return fmt.Errorf("Failed to plan sequence: %v", err)
+		}
+
+		w := new(tabwriter.Writer)
+		w.Init(os.Stdout, 10, 8, 0, '\t', 0)
+		fmt.Fprintln(w, "STAGE\tSEQUENCE\tTRIGGERED BY\tTASK\tPARALLEL\tTIMEOUT\tINTEGRATIONS")
+		for _, step := range plan.Steps {
+			for _, task := range step.Tasks {
+				fmt.Fprintln(w, step.Stage+"\t"+step.Sequence+"\t"+step.TriggeredBy+"\t"+task.Name+"\t"+task.Parallel+"\t"+task.Timeout+"\t"+strings.Join(task.Integrations, ","))
+			}
+		}
+		return w.Flush()
+	},
+}
+
+func init() {
+	planCmd.AddCommand(planSequenceCmd)
+	planSequenceParams.project = planSequenceCmd.Flags().StringP("project", "p", "",
+		"The Keptn project the sequence belongs to")
+	planSequenceParams.stage = planSequenceCmd.Flags().StringP("stage", "s", "",
+		"The Keptn stage in which the sequence is triggered")
+	planSequenceParams.sequence = planSequenceCmd.Flags().StringP("sequence", "", "",
+		"The name of the sequence that is triggered")
+	planSequenceParams.result = planSequenceCmd.Flags().StringP("result", "r", "",
+		"The assumed result of each sequence (pass, warning or fail). Defaults to pass")
+	planSequenceParams.shipyard = planSequenceCmd.Flags().StringP("shipyard", "", "",
+		"The path or URL to the shipyard file. If not set, the current shipyard of the project is used")
+	planSequenceCmd.MarkFlagRequired("project")
+	planSequenceCmd.MarkFlagRequired("stage")
+	planSequenceCmd.MarkFlagRequired("sequence")
+}
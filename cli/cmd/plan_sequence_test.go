@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+)
+
+// TestPlanSequence tests the plan sequence command
+func TestPlanSequence(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	cmd := fmt.Sprintf("plan sequence --project=sockshop --stage=dev --sequence=delivery --result=fail --mock")
+	_, err := executeActionCommandC(cmd)
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+}
+
+// TestPlanSequenceUnknownCommand
+func TestPlanSequenceUnknownCommand(t *testing.T) {
+	testInvalidInputHelper("plan sequence someUnknownCommand --project=sockshop --stage=dev --sequence=delivery", "unknown command \"someUnknownCommand\" for \"keptn plan sequence\"", t)
+}
+
+// TestPlanSequenceUnknownParameter
+func TestPlanSequenceUnknownParmeter(t *testing.T) {
+	testInvalidInputHelper("plan sequence --projectt=sockshop --stage=dev --sequence=delivery", "unknown flag: --projectt", t)
+}
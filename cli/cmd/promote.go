@@ -0,0 +1,12 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var promoteCmd = &cobra.Command{
+	Use:   "promote [ service ]",
+	Short: "Promotes the configuration of a service from one stage to another",
+}
+
+func init() {
+	rootCmd.AddCommand(promoteCmd)
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+const promoteServicePath = "/v1/project/%s/stage/%s/service/%s/promote?from=%s"
+
+type promoteServiceStruct struct {
+	project   *string
+	stage     *string
+	from      *string
+	strategy  *string
+	include   *string
+	exclude   *string
+	overwrite *bool
+	push      *bool
+}
+
+type promoteServicePayload struct {
+	Strategy  string   `json:"strategy,omitempty"`
+	Include   []string `json:"include,omitempty"`
+	Exclude   []string `json:"exclude,omitempty"`
+	Overwrite bool     `json:"overwrite,omitempty"`
+	Push      *bool    `json:"push,omitempty"`
+}
+
+type promoteServiceResponse struct {
+	CommitID string   `json:"commitID"`
+	Added    []string `json:"added"`
+	Updated  []string `json:"updated"`
+	Deleted  []string `json:"deleted"`
+}
+
+var promoteServiceParams promoteServiceStruct
+
+var promoteServiceCmd = &cobra.Command{
+	Use:   "service SERVICENAME --project=PROJECTNAME --stage=STAGE --from=STAGE",
+	Short: "Promotes the resources of a service from one stage to another",
+	Long: `Promotes the resources of a service from the stage given by --from to the stage given by --stage with a single commit.
+With the copy strategy (default), the resources of the target stage are replaced by the ones of the source stage, and resources that only exist in the target stage are deleted.
+With the merge strategy, resources of the source stage are added to the target stage. Resources that differ between both stages are reported as conflicts
+and nothing is changed, unless the --overwrite flag is set.
+
+The --include and --exclude flags accept comma separated glob patterns, which are matched against the path of a resource and of each of its parent directories.
+Patterns without a "/" are matched against the names of the resource and of its parent directories, e.g., --include=helm promotes the helm charts of the service.
+`,
+	Example: `keptn promote service carts --project=sockshop --stage=production --from=hardening
+keptn promote service carts --project=sockshop --stage=production --from=hardening --strategy=merge --exclude=*.md --push=false`,
+	SilenceUsage: true,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			cmd.SilenceUsage = false
+			return errors.New("required argument SERVICENAME not set")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		strategy := *promoteServiceParams.strategy
+		if strategy != "copy" && strategy != "merge" {
+			cmd.SilenceUsage = false
+			return errors.New("the strategy must be either copy or merge")
+		}
+		if *promoteServiceParams.stage == *promoteServiceParams.from {
+			cmd.SilenceUsage = false
+			return errors.New("the source and target stage must be different")
+		}
+
+		endPoint, apiToken, err := credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+		if mocking {
+			return nil
+		}
+
+		client := internal.NewConfigurationServiceClient(endPoint, apiToken)
+
+		path := fmt.Sprintf(promoteServicePath, url.PathEscape(*promoteServiceParams.project), url.PathEscape(*promoteServiceParams.stage),
+			url.PathEscape(args[0]), url.QueryEscape(*promoteServiceParams.from))
+		payload := promoteServicePayload{
+			Strategy:  strategy,
+			Include:   splitCommaSeparated(*promoteServiceParams.include),
+			Exclude:   splitCommaSeparated(*promoteServiceParams.exclude),
+			Overwrite: *promoteServiceParams.overwrite,
+			Push:      promoteServiceParams.push,
+		}
+		response := &promoteServiceResponse{}
+		if err := client.Post(path, payload, response); err != nil {
+			return fmt.Errorf("Failed to promote service %s: %v", args[0], err)
+		}
+
+		if len(response.Added)+len(response.Updated)+len(response.Deleted) == 0 {
+			fmt.Printf("Service %s in stage %s is already up to date with stage %s\n", args[0], *promoteServiceParams.stage, *promoteServiceParams.from)
+			return nil
+		}
+		fmt.Printf("Successfully promoted service %s from stage %s to stage %s in commit %s\n", args[0], *promoteServiceParams.from, *promoteServiceParams.stage, response.CommitID)
+		printPromotedResources("Added", response.Added)
+		printPromotedResources("Updated", response.Updated)
+		printPromotedResources("Deleted", response.Deleted)
+		return nil
+	},
+}
+
+func printPromotedResources(title string, resources []string) {
+	if len(resources) == 0 {
+		return
+	}
+	fmt.Printf("%s:\n  %s\n", title, strings.Join(resources, "\n  "))
+}
+
+func init() {
+	promoteCmd.AddCommand(promoteServiceCmd)
+	promoteServiceParams.project = promoteServiceCmd.Flags().StringP("project", "p", "",
+		"The project the service belongs to")
+	promoteServiceParams.stage = promoteServiceCmd.Flags().StringP("stage", "s", "",
+		"The stage the resources are promoted to")
+	promoteServiceParams.from = promoteServiceCmd.Flags().StringP("from", "", "",
+		"The stage the resources are promoted from")
+	promoteServiceParams.strategy = promoteServiceCmd.Flags().StringP("strategy", "", "copy",
+		"The promotion strategy: copy replaces the resources of the target stage, merge only adds resources to it")
+	promoteServiceParams.include = promoteServiceCmd.Flags().String("include", "",
+		"The comma separated glob patterns of the resources to be promoted. If not set, all resources are promoted")
+	promoteServiceParams.exclude = promoteServiceCmd.Flags().String("exclude", "",
+		"The comma separated glob patterns of the resources that shall not be promoted")
+	promoteServiceParams.overwrite = promoteServiceCmd.Flags().Bool("overwrite", false,
+		"Overwrite resources that differ between both stages when using the merge strategy")
+	promoteServiceParams.push = promoteServiceCmd.Flags().Bool("push", true,
+		"Push the commit to the upstream repository of the project")
+	promoteServiceCmd.MarkFlagRequired("project")
+	promoteServiceCmd.MarkFlagRequired("stage")
+	promoteServiceCmd.MarkFlagRequired("from")
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+)
+
+// TestPromoteService tests the promote service command
+func TestPromoteService(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	cmd := "promote service carts --project=sockshop --stage=production --from=hardening --strategy=merge --include=helm --exclude=*.md --overwrite --push=false --mock"
+	_, err := executeActionCommandC(cmd)
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+}
+
+// TestPromoteServiceInvalidStrategy tests that only the copy and merge strategies are accepted
+func TestPromoteServiceInvalidStrategy(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+	testInvalidInputHelper("promote service carts --project=sockshop --stage=production --from=hardening --strategy=rebase --mock", "the strategy must be either copy or merge", t)
+}
+
+// TestPromoteServiceSameStage tests that the source and target stage have to be different
+func TestPromoteServiceSameStage(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+	testInvalidInputHelper("promote service carts --project=sockshop --stage=production --from=production --strategy=copy --mock", "the source and target stage must be different", t)
+}
+
+// TestPromoteServiceWithoutServiceName tests that the name of the service is required
+func TestPromoteServiceWithoutServiceName(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+	testInvalidInputHelper("promote service --project=sockshop --stage=production --from=hardening --mock", "required argument SERVICENAME not set", t)
+}
@@ -0,0 +1,12 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var retryCmd = &cobra.Command{
+	Use:   "retry [ sequence ]",
+	Short: "Retries the execution of a failed sequence",
+}
+
+func init() {
+	rootCmd.AddCommand(retryCmd)
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+const sequenceControlPath = "/v1/sequence/%s/%s/control"
+
+type retrySequenceStruct struct {
+	sequenceControlStruct
+	fromTask *string
+}
+
+type retrySequenceCommand struct {
+	State    string `json:"state"`
+	Stage    string `json:"stage,omitempty"`
+	FromTask string `json:"fromTask,omitempty"`
+}
+
+var retrySequenceParams retrySequenceStruct
+
+var retrySequenceCmd = &cobra.Command{
+	Use:   "sequence",
+	Short: "Retries the execution of a failed sequence",
+	Long: `Triggers a failed sequence again under the same Keptn context. The results of the tasks that have been completed before the task
+given by the --from-task flag are kept, and the sequence continues with that task. If --from-task is not set, the sequence continues with its first failed task.
+If the task is part of a group of parallel tasks, the whole group is executed again.`,
+	Example: `keptn retry sequence --project=sockshop --keptn-context=8929e5e5-3826-488f-9257-708bfa974909
+keptn retry sequence --project=sockshop --keptn-context=8929e5e5-3826-488f-9257-708bfa974909 --stage=staging --from-task=test`,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		endPoint, apiToken, err := credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+		if mocking {
+			return nil
+		}
+
+		client := internal.NewControlPlaneClient(endPoint, apiToken)
+
+		path := fmt.Sprintf(sequenceControlPath, url.PathEscape(*retrySequenceParams.project), url.PathEscape(*retrySequenceParams.keptnContext))
+		command := retrySequenceCommand{
+			State:    string(retrySequence),
+			Stage:    *retrySequenceParams.stage,
+			FromTask: *retrySequenceParams.fromTask,
+		}
+		if err := client.Post(path, command, nil); err != nil {
+			return fmt.Errorf("Failed to retry sequence: %v", err)
+		}
+
+		fmt.Println("Successfully retried sequence")
+		return nil
+	},
+}
+
+func init() {
+	retryCmd.AddCommand(retrySequenceCmd)
+	retrySequenceParams.keptnContext = retrySequenceCmd.Flags().StringP("keptn-context", "c", "",
+		"The Keptn context the sequence execution is bound to")
+	retrySequenceParams.project = retrySequenceCmd.Flags().StringP("project", "p", "",
+		"The Keptn project the sequence belongs to")
+	retrySequenceParams.stage = retrySequenceCmd.Flags().StringP("stage", "s", "",
+		"The Keptn stage in which the sequence shall be retried")
+	retrySequenceParams.fromTask = retrySequenceCmd.Flags().StringP("from-task", "", "",
+		"The task from which the sequence shall be retried. Defaults to the first failed task")
+	retrySequenceCmd.MarkFlagRequired("keptn-context")
+	retrySequenceCmd.MarkFlagRequired("project")
+}
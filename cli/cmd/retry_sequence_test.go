@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+)
+
+// TestRetrySequence tests the retry sequence command
+func TestRetrySequence(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	cmd := fmt.Sprintf("retry sequence --project=sockshop --keptn-context=8929e5e5-3826-488f-9257-708bfa974909 --stage=dev --from-task=test --mock")
+	_, err := executeActionCommandC(cmd)
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+}
+
+// TestRetrySequenceUnknownCommand
+func TestRetrySequenceUnknownCommand(t *testing.T) {
+	testInvalidInputHelper("retry sequence someUnknownCommand --project=sockshop --keptn-context=djsfjdfdsjjcs", "unknown command \"someUnknownCommand\" for \"keptn retry sequence\"", t)
+}
+
+// TestRetrySequenceUnknownParameter
+func TestRetrySequenceUnknownParmeter(t *testing.T) {
+	testInvalidInputHelper("retry sequence --projectt=sockshop --keptn-context=djsfjdfdsjjcs", "unknown flag: --projectt", t)
+}
@@ -19,6 +19,7 @@ const (
 	pauseSequence  SequenceState = "pause"
 	resumeSequence SequenceState = "resume"
 	abortSequence  SequenceState = "abort"
+	retrySequence  SequenceState = "retry"
 )
 
 func AbortSequence(params sequenceControlStruct) error {
@@ -0,0 +1,12 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var validateCmd = &cobra.Command{
+	Use:   "validate [ shipyard ]",
+	Short: "Validates the configuration of a Keptn project",
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
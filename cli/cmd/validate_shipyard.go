@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+const validateShipyardPath = "/v1/project/%s/shipyard/validate"
+
+type validateShipyardStruct struct {
+	project  *string
+	shipyard *string
+}
+
+type validateShipyardRequest struct {
+	Shipyard string `json:"shipyard,omitempty"`
+}
+
+type shipyardValidationResult struct {
+	Valid    bool     `json:"valid"`
+	Errors   []string `json:"errors"`
+	Warnings []string `json:"warnings"`
+}
+
+var validateShipyardParams validateShipyardStruct
+
+var validateShipyardCmd = &cobra.Command{
+	Use:   "shipyard",
+	Short: "Validates the shipyard of a project",
+	Long: `Validates a shipyard against a Keptn project without applying it. The validation checks the structure of the shipyard, the names of its stages,
+the triggeredOn references and cycles between sequences. Tasks that no integration is subscribed to are reported as warnings.
+If the --shipyard flag is not set, the current shipyard of the project is validated.`,
+	Example: `keptn validate shipyard --project=sockshop
+keptn validate shipyard --project=sockshop --shipyard=./shipyard.yaml`,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		endPoint, apiToken, err := credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		request := validateShipyardRequest{}
+		if *validateShipyardParams.shipyard != "" {
+			shipyard, err := retrieveShipyard(*validateShipyardParams.shipyard)
+			if err != nil {
+				return fmt.Errorf("Failed to read shipyard file - %s", err.Error())
+			}
+			request.Shipyard = base64.StdEncoding.EncodeToString(shipyard)
+		}
+
+		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+		if mocking {
+			return nil
+		}
+
+		client := internal.NewControlPlaneClient(endPoint, apiToken)
+
+		result := &shipyardValidationResult{}
+		if err := client.Post(fmt.Sprintf(validateShipyardPath, url.PathEscape(*validateShipyardParams.project)), request, result); err != nil {
+			return fmt.Errorf("Failed to validate shipyard: %v", err)
+		}
+
+		for _, validationError := range result.Errors {
+			fmt.Println("ERROR: " + validationError)
+		}
+		for _, warning := range result.Warnings {
+			fmt.Println("WARNING: " + warning)
+		}
+		if !result.Valid {
+			return errors.New("Shipyard is invalid")
+		}
+
+		fmt.Println("Shipyard is valid")
+		return nil
+	},
+}
+
+func init() {
+	validateCmd.AddCommand(validateShipyardCmd)
+	validateShipyardParams.project = validateShipyardCmd.Flags().StringP("project", "p", "",
+		"The Keptn project the shipyard is validated against")
+	validateShipyardParams.shipyard = validateShipyardCmd.Flags().StringP("shipyard", "s", "",
+		"The path or URL to the shipyard file. If not set, the current shipyard of the project is validated")
+	validateShipyardCmd.MarkFlagRequired("project")
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+)
+
+// TestValidateShipyard tests the validate shipyard command
+func TestValidateShipyard(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	cmd := fmt.Sprintf("validate shipyard --project=sockshop --mock")
+	_, err := executeActionCommandC(cmd)
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+}
+
+// TestValidateShipyardUnknownCommand
+func TestValidateShipyardUnknownCommand(t *testing.T) {
+	testInvalidInputHelper("validate shipyard someUnknownCommand --project=sockshop", "unknown command \"someUnknownCommand\" for \"keptn validate shipyard\"", t)
+}
+
+// TestValidateShipyardUnknownParameter
+func TestValidateShipyardUnknownParmeter(t *testing.T) {
+	testInvalidInputHelper("validate shipyard --projectt=sockshop", "unknown flag: --projectt", t)
+}
@@ -0,0 +1,115 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+)
+
+const controlPlaneBasePath = "controlPlane"
+const configurationServiceBasePath = "configuration-service"
+
+// ControlPlaneClient is used to access the endpoints of the control plane API that are not covered by the API clients of go-utils.
+// It can also be created for the configuration service, which is exposed by the API gateway in the same way
+type ControlPlaneClient struct {
+	baseURL    string
+	apiToken   string
+	httpClient *http.Client
+}
+
+// NewControlPlaneClient creates a new ControlPlaneClient for the given Keptn API endpoint
+func NewControlPlaneClient(endpoint url.URL, apiToken string) *ControlPlaneClient {
+	return newClient(endpoint, apiToken, controlPlaneBasePath)
+}
+
+// NewConfigurationServiceClient creates a new ControlPlaneClient for the configuration service behind the given Keptn API endpoint
+func NewConfigurationServiceClient(endpoint url.URL, apiToken string) *ControlPlaneClient {
+	return newClient(endpoint, apiToken, configurationServiceBasePath)
+}
+
+func newClient(endpoint url.URL, apiToken string, basePath string) *ControlPlaneClient {
+	baseURL := strings.TrimRight(endpoint.String(), "/")
+	if !strings.HasSuffix(baseURL, "/"+basePath) {
+		baseURL += "/" + basePath
+	}
+	return &ControlPlaneClient{
+		baseURL:    baseURL,
+		apiToken:   apiToken,
+		httpClient: &http.Client{},
+	}
+}
+
+// Get sends a GET request to the given path and decodes the response into result.
+// If result is a *[]byte, the response body is returned as it is
+func (c *ControlPlaneClient) Get(path string, result interface{}) error {
+	return c.do(http.MethodGet, path, nil, result)
+}
+
+// Post sends a POST request with the given body to the given path and decodes the response into result
+func (c *ControlPlaneClient) Post(path string, body interface{}, result interface{}) error {
+	return c.do(http.MethodPost, path, body, result)
+}
+
+// Put sends a PUT request with the given body to the given path and decodes the response into result
+func (c *ControlPlaneClient) Put(path string, body interface{}, result interface{}) error {
+	return c.do(http.MethodPut, path, body, result)
+}
+
+// Delete sends a DELETE request to the given path and decodes the response into result
+func (c *ControlPlaneClient) Delete(path string, result interface{}) error {
+	return c.do(http.MethodDelete, path, nil, result)
+}
+
+func (c *ControlPlaneClient) do(method, path string, body interface{}, result interface{}) error {
+	var requestBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("could not encode request: %w", err)
+		}
+		requestBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, requestBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiToken != "" {
+		req.Header.Set("x-token", c.apiToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &apimodels.Error{}
+		if err := json.Unmarshal(responseBody, apiErr); err == nil && apiErr.Message != nil {
+			return fmt.Errorf("%s", *apiErr.Message)
+		}
+		return fmt.Errorf(ErrWithStatusCode, resp.StatusCode)
+	}
+
+	if raw, ok := result.(*[]byte); ok {
+		*raw = responseBody
+		return nil
+	}
+	if result == nil || len(responseBody) == 0 {
+		return nil
+	}
+	return json.Unmarshal(responseBody, result)
+}
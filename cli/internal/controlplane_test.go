@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestControlPlaneClient_Get(t *testing.T) {
+	var receivedPath, receivedToken string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedPath = r.URL.Path
+		receivedToken = r.Header.Get("x-token")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"name":"my-project"}`))
+	}))
+	defer ts.Close()
+
+	endpoint, err := url.Parse(ts.URL + "/api")
+	require.Nil(t, err)
+
+	result := &struct {
+		Name string `json:"name"`
+	}{}
+	err = NewControlPlaneClient(*endpoint, "my-token").Get("/v1/project/my-project", result)
+	require.Nil(t, err)
+	require.Equal(t, "/api/controlPlane/v1/project/my-project", receivedPath)
+	require.Equal(t, "my-token", receivedToken)
+	require.Equal(t, "my-project", result.Name)
+}
+
+func TestControlPlaneClient_GetRaw(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/gzip")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte{0x1f, 0x8b, 0x08})
+	}))
+	defer ts.Close()
+
+	endpoint, err := url.Parse(ts.URL)
+	require.Nil(t, err)
+
+	var result []byte
+	err = NewControlPlaneClient(*endpoint, "").Get("/v1/project/my-project/export", &result)
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x1f, 0x8b, 0x08}, result)
+}
+
+func TestControlPlaneClient_ErrorResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code":404,"message":"sequence not found"}`))
+	}))
+	defer ts.Close()
+
+	endpoint, err := url.Parse(ts.URL)
+	require.Nil(t, err)
+
+	err = NewControlPlaneClient(*endpoint, "").Delete("/v1/sequence/my-project/my-context", nil)
+	require.EqualError(t, err, "sequence not found")
+}
+
+func TestConfigurationServiceClient_Post(t *testing.T) {
+	var receivedPath, receivedQuery, receivedBody string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedPath = r.URL.Path
+		receivedQuery = r.URL.RawQuery
+		body, _ := io.ReadAll(r.Body)
+		receivedBody = string(body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"commitID":"my-commit"}`))
+	}))
+	defer ts.Close()
+
+	endpoint, err := url.Parse(ts.URL + "/api")
+	require.Nil(t, err)
+
+	result := &struct {
+		CommitID string `json:"commitID"`
+	}{}
+	err = NewConfigurationServiceClient(*endpoint, "my-token").Post("/v1/project/my-project/stage/prod/service/my-service/promote?from=dev", map[string]string{"strategy": "copy"}, result)
+	require.Nil(t, err)
+	require.Equal(t, "/api/configuration-service/v1/project/my-project/stage/prod/service/my-service/promote", receivedPath)
+	require.Equal(t, "from=dev", receivedQuery)
+	require.Equal(t, `{"strategy":"copy"}`, receivedBody)
+	require.Equal(t, "my-commit", result.CommitID)
+}
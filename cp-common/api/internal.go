@@ -7,9 +7,16 @@ import (
 	api "github.com/keptn/go-utils/pkg/api/utils"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"net/http"
+	"os"
 	"time"
 )
 
+// InternalAPITokenEnvVar is the environment variable containing the token the shipyard-controller expects from Keptn services within the cluster
+const InternalAPITokenEnvVar = "INTERNAL_API_TOKEN"
+
+// internalAPITokenHeader is the header the internal API token is sent in
+const internalAPITokenHeader = "x-token"
+
 // InternalAPISet is an implementation of APISet
 // which can be used from within the Keptn control plane
 type InternalAPISet struct {
@@ -68,11 +75,16 @@ func NewInternal(client *http.Client, apiMappings ...InClusterAPIMappings) (*Int
 	as := &InternalAPISet{}
 	as.httpClient = client
 
+	// the shipyard-controller only accepts direct calls from within the cluster that are authenticated with the internal API token
+	internalAPIToken := os.Getenv(InternalAPITokenEnvVar)
+
 	as.apiHandler = &InternalAPIHandler{
 		shipyardControllerApiHandler: &api.APIHandler{
 			BaseURL:    apimap[ShipyardController],
 			HTTPClient: &http.Client{Transport: wrapOtelTransport(getClientTransport(as.httpClient.Transport))},
 			Scheme:     "http",
+			AuthHeader: internalAPITokenHeader,
+			AuthToken:  internalAPIToken,
 		},
 	}
 
@@ -85,6 +97,8 @@ func NewInternal(client *http.Client, apiMappings ...InClusterAPIMappings) (*Int
 		BaseURL:      apimap[ShipyardController],
 		HTTPClient:   &http.Client{Transport: getClientTransport(as.httpClient.Transport)},
 		Scheme:       "http",
+		AuthHeader:   internalAPITokenHeader,
+		AuthToken:    internalAPIToken,
 		LogCache:     []models.LogEntry{},
 		TheClock:     clock.New(),
 		SyncInterval: 1 * time.Minute,
@@ -100,6 +114,8 @@ func NewInternal(client *http.Client, apiMappings ...InClusterAPIMappings) (*Int
 		BaseURL:    apimap[ShipyardController],
 		HTTPClient: &http.Client{Transport: wrapOtelTransport(getClientTransport(as.httpClient.Transport))},
 		Scheme:     "http",
+		AuthHeader: internalAPITokenHeader,
+		AuthToken:  internalAPIToken,
 	}
 
 	as.resourceHandler = &api.ResourceHandler{
@@ -116,26 +132,36 @@ func NewInternal(client *http.Client, apiMappings ...InClusterAPIMappings) (*Int
 		BaseURL:    apimap[ShipyardController],
 		HTTPClient: &http.Client{Transport: wrapOtelTransport(getClientTransport(as.httpClient.Transport))},
 		Scheme:     "http",
+		AuthHeader: internalAPITokenHeader,
+		AuthToken:  internalAPIToken,
 	}
 	as.serviceHandler = &api.ServiceHandler{
 		BaseURL:    apimap[ShipyardController],
 		HTTPClient: &http.Client{Transport: wrapOtelTransport(getClientTransport(as.httpClient.Transport))},
 		Scheme:     "http",
+		AuthHeader: internalAPITokenHeader,
+		AuthToken:  internalAPIToken,
 	}
 	as.shipyardControlHandler = &api.ShipyardControllerHandler{
 		BaseURL:    apimap[ShipyardController],
 		HTTPClient: &http.Client{Transport: wrapOtelTransport(getClientTransport(as.httpClient.Transport))},
 		Scheme:     "http",
+		AuthHeader: internalAPITokenHeader,
+		AuthToken:  internalAPIToken,
 	}
 	as.stageHandler = &api.StageHandler{
 		BaseURL:    apimap[ShipyardController],
 		HTTPClient: &http.Client{Transport: otelhttp.NewTransport(as.httpClient.Transport)},
 		Scheme:     "http",
+		AuthHeader: internalAPITokenHeader,
+		AuthToken:  internalAPIToken,
 	}
 	as.uniformHandler = &api.UniformHandler{
 		BaseURL:    apimap[ShipyardController],
 		HTTPClient: &http.Client{Transport: getClientTransport(as.httpClient.Transport)},
 		Scheme:     "http",
+		AuthHeader: internalAPITokenHeader,
+		AuthToken:  internalAPIToken,
 	}
 	return as, nil
 }
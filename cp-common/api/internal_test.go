@@ -25,6 +25,17 @@ func TestApiSetInternalMappings(t *testing.T) {
 		assert.Equal(t, DefaultInClusterAPIMappings[ShipyardController], internal.ProjectsV1().(*api.ProjectHandler).BaseURL)
 	})
 
+	t.Run("TestInternalAPISet - Internal API Token", func(t *testing.T) {
+		t.Setenv(InternalAPITokenEnvVar, "internal-token")
+		internal, err := NewInternal(nil)
+		require.Nil(t, err)
+		assert.Equal(t, "internal-token", internal.ProjectsV1().(*api.ProjectHandler).AuthToken)
+		assert.Equal(t, "x-token", internal.ProjectsV1().(*api.ProjectHandler).AuthHeader)
+		assert.Equal(t, "internal-token", internal.UniformV1().(*api.UniformHandler).AuthToken)
+		assert.Equal(t, "internal-token", internal.APIV1().(*InternalAPIHandler).shipyardControllerApiHandler.AuthToken)
+		assert.Empty(t, internal.ResourcesV1().(*api.ResourceHandler).AuthToken)
+	})
+
 	t.Run("TestInternalAPISet - Override Mappings", func(t *testing.T) {
 		overrideMappings := InClusterAPIMappings{
 			ConfigurationService: "special-configuration-service:8080",
@@ -0,0 +1,141 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// PrincipalHeader carries the principal that has been authenticated by the API gateway
+	PrincipalHeader = "X-Keptn-Principal"
+	// RequestIDHeader carries the ID of the request that has been assigned by the API gateway
+	RequestIDHeader = "X-Request-ID"
+	// PrincipalInternal is used for calls that have not been made via the API gateway
+	PrincipalInternal = "internal"
+
+	tokenPrincipalPrefix = "api-token:"
+	// internalTokenHeader carries the token the shipyard-controller expects from Keptn services within the cluster
+	internalTokenHeader = "x-token"
+	auditRecordsPath    = "/v1/audit"
+	queueSize           = 100
+	sendTimeout         = 5 * time.Second
+)
+
+// Record is a structured audit record of a state-changing API call. Records are stored by the shipyard-controller
+type Record struct {
+	Service    string `json:"service"`
+	Principal  string `json:"principal"`
+	ClientIP   string `json:"clientIP,omitempty"`
+	Action     string `json:"action"`
+	TargetType string `json:"targetType,omitempty"`
+	Target     string `json:"target,omitempty"`
+	Project    string `json:"project,omitempty"`
+	Before     string `json:"before,omitempty"`
+	After      string `json:"after,omitempty"`
+	RequestID  string `json:"requestID,omitempty"`
+	StatusCode int    `json:"statusCode,omitempty"`
+}
+
+//go:generate moq -pkg audit_mock --skip-ensure -out ./fake/recorder_mock.go . Recorder
+type Recorder interface {
+	// Record queues the given record. It does not block the caller
+	Record(record Record)
+}
+
+// PrincipalFromToken returns the name under which a caller that has been authenticated with the given API token is recorded.
+// It is derived from a hash of the token, so that the token itself never ends up in the audit log
+func PrincipalFromToken(token string) string {
+	if token == "" {
+		return PrincipalInternal
+	}
+	sum := sha256.Sum256([]byte(token))
+	return tokenPrincipalPrefix + hex.EncodeToString(sum[:])[:8]
+}
+
+// Client sends audit records to the shipyard-controller in the background, so that API calls are not delayed
+// by the audit log. Records are dropped if the queue is full
+type Client struct {
+	endpoint         string
+	internalAPIToken string
+	httpClient       *http.Client
+	records          chan Record
+}
+
+// NewClient creates a client that authenticates at the shipyard-controller with the given internal API token
+func NewClient(shipyardControllerURL, internalAPIToken string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: sendTimeout}
+	}
+	return &Client{
+		endpoint:         strings.TrimSuffix(shipyardControllerURL, "/") + auditRecordsPath,
+		internalAPIToken: internalAPIToken,
+		httpClient:       httpClient,
+		records:          make(chan Record, queueSize),
+	}
+}
+
+func (c *Client) Record(record Record) {
+	select {
+	case c.records <- record:
+	default:
+		log.Errorf("Audit queue is full, dropping record of action %s on %s %s", record.Action, record.TargetType, record.Target)
+	}
+}
+
+// Run sends the queued records until the given context is cancelled. Records that are still queued at that point are sent before Run returns
+func (c *Client) Run(ctx context.Context) {
+	for {
+		select {
+		case record := <-c.records:
+			c.send(record)
+		case <-ctx.Done():
+			for {
+				select {
+				case record := <-c.records:
+					c.send(record)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (c *Client) send(record Record) {
+	if err := c.post(record); err != nil {
+		log.WithError(err).Errorf("Could not send audit record of action %s on %s %s", record.Action, record.TargetType, record.Target)
+	}
+}
+
+func (c *Client) post(record Record) error {
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(internalTokenHeader, c.internalAPIToken)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return nil
+}
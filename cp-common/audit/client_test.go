@@ -0,0 +1,55 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Run(t *testing.T) {
+	received := make(chan Record, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/audit", r.URL.Path)
+		require.Equal(t, "internal-token", r.Header.Get("x-token"))
+		record := Record{}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&record))
+		received <- record
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", "internal-token", nil)
+	client.Record(Record{Service: "api-service", Action: "event.send", Target: "sh.keptn.event.dev.delivery.triggered"})
+	client.Record(Record{Service: "api-service", Action: "event.send", Target: "sh.keptn.event.evaluation.triggered"})
+
+	// all queued records are sent before Run returns
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client.Run(ctx)
+
+	require.Len(t, received, 2)
+	require.Equal(t, "sh.keptn.event.dev.delivery.triggered", (<-received).Target)
+	require.Equal(t, "sh.keptn.event.evaluation.triggered", (<-received).Target)
+}
+
+func TestClient_Record_DropsRecordsIfQueueIsFull(t *testing.T) {
+	client := NewClient("http://shipyard-controller:8080", "internal-token", nil)
+	for i := 0; i < queueSize+1; i++ {
+		client.Record(Record{Action: "event.send"})
+	}
+	require.Len(t, client.records, queueSize)
+}
+
+func TestPrincipalFromToken(t *testing.T) {
+	principal := PrincipalFromToken("my-token")
+	require.Equal(t, "api-token:", principal[:len("api-token:")])
+	require.Len(t, principal, len("api-token:")+8)
+	require.NotContains(t, principal, "my-token")
+	require.Equal(t, principal, PrincipalFromToken("my-token"))
+	require.NotEqual(t, principal, PrincipalFromToken("my-other-token"))
+	require.Equal(t, PrincipalInternal, PrincipalFromToken(""))
+}
@@ -0,0 +1,120 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/types"
+	"github.com/keptn/go-utils/pkg/api/models"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// TraceParentExtension is the name of the CloudEvents extension that carries the W3C trace context of an event
+const TraceParentExtension = "traceparent"
+
+// TraceStateExtension is the name of the CloudEvents extension that carries the vendor specific trace state of an event
+const TraceStateExtension = "tracestate"
+
+var propagator = propagation.TraceContext{}
+
+// ContextFromEvent returns a copy of the given context that contains the trace context carried in the extensions of the given event
+func ContextFromEvent(ctx context.Context, event models.KeptnContextExtendedCE) context.Context {
+	return propagator.Extract(ctx, eventCarrier{event: &event})
+}
+
+// InjectIntoEvent stores the trace context of the given context in the extensions of the given event
+func InjectIntoEvent(ctx context.Context, event *models.KeptnContextExtendedCE) {
+	propagator.Inject(ctx, eventCarrier{event: event})
+}
+
+// HasTraceContext checks whether the given event carries a trace context in its extensions
+func HasTraceContext(event models.KeptnContextExtendedCE) bool {
+	return eventCarrier{event: &event}.Get(TraceParentExtension) != ""
+}
+
+// ContextFromCloudEvent returns a copy of the given context that contains the trace context carried in the extensions of the given CloudEvent
+func ContextFromCloudEvent(ctx context.Context, event cloudevents.Event) context.Context {
+	return propagator.Extract(ctx, cloudEventCarrier{event: &event})
+}
+
+// InjectIntoCloudEvent stores the trace context of the given context in the extensions of the given CloudEvent
+func InjectIntoCloudEvent(ctx context.Context, event *cloudevents.Event) {
+	propagator.Inject(ctx, cloudEventCarrier{event: event})
+}
+
+// ApplyCloudEventAttributes copies the trace context that is carried as top-level attributes of a serialized CloudEvent into the extensions of the given event.
+// This is needed because the events sent by the shipyard-controller and the distributor carry the trace context as top-level attributes, which are dropped when
+// unmarshalling them into a KeptnContextExtendedCE. A trace context that is already carried in the extensions of the event is kept
+func ApplyCloudEventAttributes(data []byte, event *models.KeptnContextExtendedCE) {
+	if HasTraceContext(*event) {
+		return
+	}
+	attributes := map[string]interface{}{}
+	if err := json.Unmarshal(data, &attributes); err != nil {
+		return
+	}
+	carrier := eventCarrier{event: event}
+	for _, key := range []string{TraceParentExtension, TraceStateExtension} {
+		if value, ok := attributes[key].(string); ok && value != "" {
+			carrier.Set(key, value)
+		}
+	}
+}
+
+// eventCarrier adapts the extensions of a KeptnContextExtendedCE to a propagation.TextMapCarrier
+type eventCarrier struct {
+	event *models.KeptnContextExtendedCE
+}
+
+func (c eventCarrier) Get(key string) string {
+	extensions, ok := c.event.Extensions.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	value, _ := extensions[key].(string)
+	return value
+}
+
+func (c eventCarrier) Set(key, value string) {
+	extensions, ok := c.event.Extensions.(map[string]interface{})
+	if !ok {
+		extensions = map[string]interface{}{}
+		c.event.Extensions = extensions
+	}
+	extensions[key] = value
+}
+
+func (c eventCarrier) Keys() []string {
+	extensions, ok := c.event.Extensions.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	keys := make([]string, 0, len(extensions))
+	for key := range extensions {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// cloudEventCarrier adapts the extensions of a CloudEvent to a propagation.TextMapCarrier
+type cloudEventCarrier struct {
+	event *cloudevents.Event
+}
+
+func (c cloudEventCarrier) Get(key string) string {
+	value, _ := types.ToString(c.event.Extensions()[key])
+	return value
+}
+
+func (c cloudEventCarrier) Set(key, value string) {
+	c.event.SetExtension(key, value)
+}
+
+func (c cloudEventCarrier) Keys() []string {
+	keys := make([]string, 0, len(c.event.Extensions()))
+	for key := range c.event.Extensions() {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,88 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/keptn/go-utils/pkg/api/models"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestContextFromEvent(t *testing.T) {
+	event := models.KeptnContextExtendedCE{Extensions: map[string]interface{}{TraceParentExtension: testTraceParent}}
+
+	spanContext := trace.SpanContextFromContext(ContextFromEvent(context.Background(), event))
+
+	require.True(t, spanContext.IsRemote())
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spanContext.TraceID().String())
+	require.Equal(t, "00f067aa0ba902b7", spanContext.SpanID().String())
+}
+
+func TestContextFromEvent_NoTraceContext(t *testing.T) {
+	for _, extensions := range []interface{}{nil, "invalid", map[string]interface{}{TraceParentExtension: 1}} {
+		event := models.KeptnContextExtendedCE{Extensions: extensions}
+
+		spanContext := trace.SpanContextFromContext(ContextFromEvent(context.Background(), event))
+
+		require.False(t, spanContext.IsValid())
+	}
+}
+
+func TestInjectIntoEvent(t *testing.T) {
+	ctx := ContextFromEvent(context.Background(), models.KeptnContextExtendedCE{Extensions: map[string]interface{}{TraceParentExtension: testTraceParent}})
+	event := &models.KeptnContextExtendedCE{}
+
+	require.False(t, HasTraceContext(*event))
+	InjectIntoEvent(ctx, event)
+
+	require.True(t, HasTraceContext(*event))
+	require.Equal(t, map[string]interface{}{TraceParentExtension: testTraceParent}, event.Extensions)
+}
+
+func TestInjectIntoEvent_KeepsOtherExtensions(t *testing.T) {
+	ctx := ContextFromEvent(context.Background(), models.KeptnContextExtendedCE{Extensions: map[string]interface{}{TraceParentExtension: testTraceParent}})
+	event := &models.KeptnContextExtendedCE{Extensions: map[string]interface{}{"foo": "bar"}}
+
+	InjectIntoEvent(ctx, event)
+
+	require.Equal(t, map[string]interface{}{"foo": "bar", TraceParentExtension: testTraceParent}, event.Extensions)
+}
+
+func TestInjectIntoCloudEvent(t *testing.T) {
+	ctx := ContextFromEvent(context.Background(), models.KeptnContextExtendedCE{Extensions: map[string]interface{}{TraceParentExtension: testTraceParent}})
+	event := cloudevents.NewEvent()
+
+	InjectIntoCloudEvent(ctx, &event)
+
+	require.Equal(t, testTraceParent, event.Extensions()[TraceParentExtension])
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", trace.SpanContextFromContext(ContextFromCloudEvent(context.Background(), event)).TraceID().String())
+}
+
+func TestApplyCloudEventAttributes(t *testing.T) {
+	event := &models.KeptnContextExtendedCE{}
+
+	ApplyCloudEventAttributes([]byte(`{"id":"my-id","traceparent":"`+testTraceParent+`","tracestate":"foo=bar"}`), event)
+
+	require.Equal(t, map[string]interface{}{TraceParentExtension: testTraceParent, TraceStateExtension: "foo=bar"}, event.Extensions)
+}
+
+func TestApplyCloudEventAttributes_NoTraceContext(t *testing.T) {
+	event := &models.KeptnContextExtendedCE{}
+
+	ApplyCloudEventAttributes([]byte(`{"id":"my-id"}`), event)
+
+	require.Nil(t, event.Extensions)
+}
+
+func TestApplyCloudEventAttributes_ExtensionsArePreferred(t *testing.T) {
+	otherTraceParent := "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+	event := &models.KeptnContextExtendedCE{Extensions: map[string]interface{}{TraceParentExtension: otherTraceParent}}
+
+	ApplyCloudEventAttributes([]byte(`{"id":"my-id","traceparent":"`+testTraceParent+`"}`), event)
+
+	require.Equal(t, map[string]interface{}{TraceParentExtension: otherTraceParent}, event.Extensions)
+}
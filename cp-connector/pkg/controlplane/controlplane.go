@@ -5,12 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"github.com/keptn/go-utils/pkg/api/models"
+	commontracing "github.com/keptn/keptn/cp-common/tracing"
 	"github.com/keptn/keptn/cp-connector/pkg/eventmatcher"
 	"github.com/keptn/keptn/cp-connector/pkg/eventsource"
 	"github.com/keptn/keptn/cp-connector/pkg/logforwarder"
 	"github.com/keptn/keptn/cp-connector/pkg/logger"
 	"github.com/keptn/keptn/cp-connector/pkg/subscriptionsource"
+	"github.com/keptn/keptn/cp-connector/pkg/tracing"
 	"github.com/keptn/keptn/cp-connector/pkg/types"
+	"go.opentelemetry.io/otel/attribute"
 	"sync"
 )
 
@@ -170,7 +173,12 @@ func (cp *ControlPlane) forwardMatchedEvent(ctx context.Context, eventUpdate typ
 	if err != nil {
 		cp.logger.Warnf("Could not append subscription data to event: %v", err)
 	}
-	if err := integration.OnEvent(context.WithValue(ctx, types.EventSenderKey, cp.getSender(cp.eventSource.Sender())), eventUpdate.KeptnEvent); err != nil {
+
+	ctx, span := tracing.StartEventSpan(ctx, eventUpdate.KeptnEvent, "cp-connector.forwardMatchedEvent")
+	span.SetAttributes(attribute.String("keptn.subscription.id", subscription.ID))
+	err = integration.OnEvent(context.WithValue(ctx, types.EventSenderKey, cp.getTracingSender(ctx, cp.getSender(cp.eventSource.Sender()))), eventUpdate.KeptnEvent)
+	tracing.EndSpan(span, err)
+	if err != nil {
 		if errors.Is(err, ErrEventHandleFatal) {
 			cp.logger.Errorf("Fatal error during handling of event: %v", err)
 			return err
@@ -180,6 +188,17 @@ func (cp *ControlPlane) forwardMatchedEvent(ctx context.Context, eventUpdate typ
 	return nil
 }
 
+// getTracingSender returns an EventSender that adds the trace context of the given context to the events sent by the integration,
+// unless the integration has already set the trace context of the event
+func (cp *ControlPlane) getTracingSender(ctx context.Context, sender types.EventSender) types.EventSender {
+	return func(ce models.KeptnContextExtendedCE) error {
+		if !commontracing.HasTraceContext(ce) {
+			commontracing.InjectIntoEvent(ctx, &ce)
+		}
+		return sender(ce)
+	}
+}
+
 func subjects(subscriptions []models.EventSubscription) []string {
 	var ret []string
 	for _, s := range subscriptions {
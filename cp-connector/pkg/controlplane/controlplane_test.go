@@ -13,6 +13,9 @@ import (
 	"github.com/keptn/go-utils/pkg/api/models"
 	"github.com/keptn/go-utils/pkg/common/strutils"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 type ExampleIntegration struct {
@@ -462,3 +465,74 @@ func TestControlPlane_IsRegistered(t *testing.T) {
 		return !controlPlane.IsRegistered()
 	}, time.Second, 100*time.Millisecond)
 }
+
+func TestControlPlaneTraceContextIsPropagated(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previousProvider)
+
+	var eventChan chan types.EventUpdate
+	var subsChan chan []models.EventSubscription
+	eventUpdate := types.EventUpdate{
+		KeptnEvent: models.KeptnContextExtendedCE{
+			ID:         "some-id",
+			Type:       strutils.Stringp("sh.keptn.event.echo.triggered"),
+			Extensions: map[string]interface{}{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		},
+		MetaData: types.EventUpdateMetaData{Subject: "sh.keptn.event.echo.triggered"},
+	}
+
+	sentEvents := make(chan models.KeptnContextExtendedCE, 1)
+	callBackSender := func(ce models.KeptnContextExtendedCE) error {
+		sentEvents <- ce
+		return nil
+	}
+
+	ssm := &fake2.SubscriptionSourceMock{
+		StartFn: func(ctx context.Context, data types.RegistrationData, c chan []models.EventSubscription, wg *sync.WaitGroup) error {
+			subsChan = c
+			return nil
+		},
+		RegisterFn: func(integration models.Integration) (string, error) {
+			return "some-id", nil
+		},
+	}
+	esm := &fake2.EventSourceMock{
+		StartFn: func(ctx context.Context, data types.RegistrationData, ces chan types.EventUpdate, wg *sync.WaitGroup) error {
+			eventChan = ces
+			return nil
+		},
+		OnSubscriptionUpdateFn: func(strings []string) {},
+		SenderFn:               func() types.EventSender { return callBackSender },
+	}
+
+	controlPlane := New(ssm, esm, nil)
+
+	integration := ExampleIntegration{
+		RegistrationDataFn: func() types.RegistrationData { return types.RegistrationData{} },
+		OnEventFn: func(ctx context.Context, ce models.KeptnContextExtendedCE) error {
+			sender := ctx.Value(types.EventSenderKey).(types.EventSender)
+			return sender(models.KeptnContextExtendedCE{ID: "some-other-id", Type: strutils.Stringp("sh.keptn.event.echo.started")})
+		},
+	}
+	go controlPlane.Register(context.TODO(), integration)
+	require.Eventually(t, func() bool { return subsChan != nil }, time.Second, time.Millisecond*100)
+	require.Eventually(t, func() bool { return eventChan != nil }, time.Second, time.Millisecond*100)
+
+	subsChan <- []models.EventSubscription{{ID: "some-id", Event: "sh.keptn.event.echo.triggered", Filter: models.EventSubscriptionFilter{}}}
+	eventChan <- eventUpdate
+
+	var sentEvent models.KeptnContextExtendedCE
+	select {
+	case sentEvent = <-sentEvents:
+	case <-time.After(time.Second):
+		t.Fatal("integration did not send an event")
+	}
+
+	require.Eventually(t, func() bool { return len(recorder.Ended()) == 1 }, time.Second, time.Millisecond*100)
+	span := recorder.Ended()[0]
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+	require.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+	require.Equal(t, fmt.Sprintf("00-%s-%s-01", span.SpanContext().TraceID(), span.SpanContext().SpanID()), sentEvent.Extensions.(map[string]interface{})["traceparent"])
+}
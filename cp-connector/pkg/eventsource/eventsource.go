@@ -10,6 +10,7 @@ import (
 	"sync"
 
 	"github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/cp-common/tracing"
 	"github.com/keptn/keptn/cp-connector/pkg/logger"
 	natseventsource "github.com/keptn/keptn/cp-connector/pkg/nats"
 	"github.com/nats-io/nats.go"
@@ -68,6 +69,7 @@ func (n *NATSEventSource) Start(ctx context.Context, registrationData types.Regi
 		if err := json.Unmarshal(event.Data, &keptnEvent); err != nil {
 			return fmt.Errorf("could not unmarshal message: %w", err)
 		}
+		tracing.ApplyCloudEventAttributes(event.Data, &keptnEvent)
 		eventChannel <- types.EventUpdate{
 			KeptnEvent: keptnEvent,
 			MetaData:   types.EventUpdateMetaData{event.Sub.Subject},
@@ -0,0 +1,37 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	commontracing "github.com/keptn/keptn/cp-common/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/keptn/keptn/cp-connector"
+
+// StartEventSpan starts a span that continues the trace of the given event.
+// The span is created using the global TracerProvider, so no spans are recorded unless the integration has configured one
+func StartEventSpan(ctx context.Context, event models.KeptnContextExtendedCE, spanName string) (context.Context, trace.Span) {
+	eventType := ""
+	if event.Type != nil {
+		eventType = *event.Type
+	}
+	return otel.Tracer(tracerName).Start(commontracing.ContextFromEvent(ctx, event), spanName, trace.WithAttributes(
+		attribute.String("keptn.context", event.Shkeptncontext),
+		attribute.String("keptn.event.id", event.ID),
+		attribute.String("keptn.event.type", eventType),
+	))
+}
+
+// EndSpan ends the given span and marks it as failed if an error is passed
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -16,6 +16,7 @@ import (
 type EnvConfig struct {
 	KeptnAPIEndpoint       string        `envconfig:"KEPTN_API_ENDPOINT" default:""`
 	KeptnAPIToken          string        `envconfig:"KEPTN_API_TOKEN" default:""`
+	InternalAPIToken       string        `envconfig:"INTERNAL_API_TOKEN" default:""`
 	APIProxyPort           int           `envconfig:"API_PROXY_PORT" default:"8081"`
 	APIProxyPath           string        `envconfig:"API_PROXY_PATH" default:"/"`
 	APIProxyHTTPTimeout    string        `envconfig:"API_PROXY_HTTP_TIMEOUT" default:"30"`
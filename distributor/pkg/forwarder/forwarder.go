@@ -199,7 +199,11 @@ func (f *Forwarder) apiProxyHandler(rw http.ResponseWriter, req *http.Request) {
 	forwardReq.URL.RawQuery = req.URL.RawQuery
 	logger.Debugf("Forwarding request to host=%s, path=%s, URL=%s", proxyHost, proxyPath, forwardReq.URL.String())
 
-	if f.env.KeptnAPIToken != "" {
+	if f.env.KeptnAPIEndpoint == "" && f.env.InternalAPIToken != "" {
+		// the services within the cluster are called directly, and therefore expect the internal API token
+		logger.Debug("Adding internal x-token header to HTTP request")
+		forwardReq.Header.Set("x-token", f.env.InternalAPIToken)
+	} else if f.env.KeptnAPIToken != "" {
 		logger.Debug("Adding x-token header to HTTP request")
 		forwardReq.Header.Add("x-token", f.env.KeptnAPIToken)
 	}
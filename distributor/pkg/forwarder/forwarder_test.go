@@ -204,14 +204,17 @@ func Test_ForwardEventsToKeptnAPI(t *testing.T) {
 
 func Test_APIProxy(t *testing.T) {
 	proxyEndpointCalled := 0
+	receivedToken := ""
 	ts := httptest.NewServer(
 		http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+			receivedToken = request.Header.Get("x-token")
 			proxyEndpointCalled++
 		}))
 
 	cfg := config.EnvConfig{}
 	envconfig.Process("", &cfg)
 	cfg.KeptnAPIEndpoint = ""
+	cfg.InternalAPIToken = "internal-token"
 	config.InClusterAPIProxyMappings["/testpath"] = strings.TrimPrefix(ts.URL, "http://")
 
 	apiset, _ := keptnapi.New(ts.URL)
@@ -234,6 +237,7 @@ func Test_APIProxy(t *testing.T) {
 	assert.Eventually(t, func() bool {
 		return proxyEndpointCalled == 1
 	}, time.Second*time.Duration(10), time.Second)
+	assert.Equal(t, "internal-token", receivedToken)
 
 	cancel()
 	executionContext.Wg.Wait()
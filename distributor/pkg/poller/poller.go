@@ -148,6 +148,7 @@ func getEventFilterForSubscription(subscription apimodels.EventSubscription) api
 
 func (p *Poller) sendEvent(e apimodels.KeptnContextExtendedCE, subscription apimodels.EventSubscription) error {
 	event := v0_2_0.ToCloudEvent(e)
+	utils.CopyTraceContextToCloudEvent(e, &event)
 	matcher := utils.NewEventMatcherFromSubscription(subscription)
 	if !matcher.Matches(event) {
 		return nil
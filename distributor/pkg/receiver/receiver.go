@@ -109,6 +109,7 @@ func (n *NATSEventReceiver) handleMessage(m *nats.Msg) {
 		if err != nil {
 			return
 		}
+		utils.CopyTraceContextToKeptnEvent(*cloudEvent, &keptnEvent)
 
 		// determine subscription for the received message
 		subscriptions := n.getSubscriptionsFromReceivedMessage(m, *cloudEvent)
@@ -171,6 +172,7 @@ func (n *NATSEventReceiver) getSubscriptionsFromReceivedMessage(m *nats.Msg, eve
 
 func (n *NATSEventReceiver) sendEvent(e models.KeptnContextExtendedCE, subscription *models.EventSubscription) error {
 	event := v0_2_0.ToCloudEvent(e)
+	utils.CopyTraceContextToCloudEvent(e, &event)
 	if subscription != nil {
 		matcher := utils.NewEventMatcherFromSubscription(*subscription)
 		if !matcher.Matches(event) {
@@ -88,13 +88,75 @@ func DecodeNATSMessage(data []byte) (*cloudevents.Event, error) {
 
 	event := cloudevents.NewEvent(cv.SpecVersion)
 
-	if err := json.Unmarshal(data, &event); err != nil {
+	if err := json.Unmarshal(liftTraceContext(data), &event); err != nil {
 		return nil, err
 	}
 
 	return &event, nil
 }
 
+// traceContextExtensions are the CloudEvents extensions that carry the W3C trace context of an event
+var traceContextExtensions = []string{"traceparent", "tracestate"}
+
+// liftTraceContext replaces the extensions object of a serialized KeptnContextExtendedCE with top-level attributes containing the trace context of the event,
+// since the extensions object itself is not a valid CloudEvents attribute
+func liftTraceContext(data []byte) []byte {
+	attributes := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &attributes); err != nil {
+		return data
+	}
+	rawExtensions, ok := attributes["extensions"]
+	if !ok {
+		return data
+	}
+	delete(attributes, "extensions")
+
+	extensions := map[string]interface{}{}
+	_ = json.Unmarshal(rawExtensions, &extensions)
+	for _, key := range traceContextExtensions {
+		if _, ok := attributes[key]; ok {
+			continue
+		}
+		if value, ok := extensions[key].(string); ok && value != "" {
+			attributes[key], _ = json.Marshal(value)
+		}
+	}
+
+	result, err := json.Marshal(attributes)
+	if err != nil {
+		return data
+	}
+	return result
+}
+
+// CopyTraceContextToKeptnEvent copies the trace context of the given CloudEvent to the extensions of the given KeptnContextExtendedCE,
+// since it is not retained when converting a CloudEvent via v0_2_0.ToKeptnEvent
+func CopyTraceContextToKeptnEvent(event cloudevents.Event, keptnEvent *apimodels.KeptnContextExtendedCE) {
+	extensions := map[string]interface{}{}
+	for _, key := range traceContextExtensions {
+		if value, ok := event.Extensions()[key].(string); ok && value != "" {
+			extensions[key] = value
+		}
+	}
+	if len(extensions) > 0 {
+		keptnEvent.Extensions = extensions
+	}
+}
+
+// CopyTraceContextToCloudEvent copies the trace context carried in the extensions of the given KeptnContextExtendedCE to the given CloudEvent,
+// since it is not retained when converting a KeptnContextExtendedCE via v0_2_0.ToCloudEvent
+func CopyTraceContextToCloudEvent(keptnEvent apimodels.KeptnContextExtendedCE, event *cloudevents.Event) {
+	extensions, ok := keptnEvent.Extensions.(map[string]interface{})
+	if !ok {
+		return
+	}
+	for _, key := range traceContextExtensions {
+		if value, ok := extensions[key].(string); ok && value != "" {
+			event.SetExtension(key, value)
+		}
+	}
+}
+
 // Cache is used to store key value data
 type Cache struct {
 	sync.RWMutex
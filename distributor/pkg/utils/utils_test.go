@@ -450,3 +450,36 @@ func Test_dedup(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeNATSMessage_WithExtensions(t *testing.T) {
+	data := []byte(`{
+		"data": {"project": "my-project", "value": 12345678901234567890},
+		"id": "6de83495-4f83-481c-8dbe-fcceb2e0243b",
+		"source": "api",
+		"specversion": "1.0",
+		"type": "sh.keptn.event.deployment.finished",
+		"shkeptncontext": "3c9ffbbb-6e1d-4789-9fee-6e63b4bcc1fb",
+		"extensions": {"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "foo": {"bar": "baz"}}
+	}`)
+
+	event, err := DecodeNATSMessage(data)
+
+	require.Nil(t, err)
+	require.Equal(t, "sh.keptn.event.deployment.finished", event.Type())
+	require.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", event.Extensions()["traceparent"])
+	require.Nil(t, event.Extensions()["foo"])
+	require.JSONEq(t, `{"project": "my-project", "value": 12345678901234567890}`, string(event.Data()))
+}
+
+func TestCopyTraceContext(t *testing.T) {
+	event := cloudevents.NewEvent()
+	event.SetExtension("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	keptnEvent := apimodels.KeptnContextExtendedCE{}
+	CopyTraceContextToKeptnEvent(event, &keptnEvent)
+	require.Equal(t, map[string]interface{}{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}, keptnEvent.Extensions)
+
+	copiedEvent := cloudevents.NewEvent()
+	CopyTraceContextToCloudEvent(keptnEvent, &copiedEvent)
+	require.Equal(t, event.Extensions(), copiedEvent.Extensions())
+}
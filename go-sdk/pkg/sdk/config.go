@@ -18,6 +18,7 @@ type envConfig struct {
 	K8sNamespace            string `envconfig:"K8S_NAMESPACE" default:""`
 	K8sPodName              string `envconfig:"K8S_POD_NAME" default:""`
 	K8sNodeName             string `envconfig:"K8S_NODE_NAME" default:""`
+	OTLPEndpoint            string `envconfig:"OTEL_EXPORTER_OTLP_ENDPOINT" default:""`
 }
 
 func newEnvConfig() envConfig {
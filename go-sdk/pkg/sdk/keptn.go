@@ -19,8 +19,11 @@ import (
 	api "github.com/keptn/go-utils/pkg/api/utils"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	api2 "github.com/keptn/keptn/cp-common/api"
+	"github.com/keptn/keptn/cp-common/tracing"
 	"github.com/keptn/keptn/cp-connector/pkg/controlplane"
 	"github.com/keptn/keptn/cp-connector/pkg/nats"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 const (
@@ -143,6 +146,7 @@ type Keptn struct {
 	logger                 Logger
 	env                    envConfig
 	healthEndpointRunner   healthEndpointRunner
+	tracerProvider         *sdktrace.TracerProvider
 }
 
 // NewKeptn creates a new Keptn
@@ -152,6 +156,13 @@ func NewKeptn(source string, opts ...KeptnOption) *Keptn {
 	resourceHandler := newResourceHandlerFromEnv()
 	taskRegistry := newTaskMap()
 	logger := newDefaultLogger()
+	tracerProvider, err := newTracerProvider(source, env.OTLPEndpoint)
+	if err != nil {
+		log.Fatalf("failed to create tracer provider: %s", err)
+	}
+	if tracerProvider != nil {
+		otel.SetTracerProvider(tracerProvider)
+	}
 	keptn := &Keptn{
 		controlPlane:           controlPlane,
 		eventSender:            eventSender,
@@ -164,6 +175,7 @@ func NewKeptn(source string, opts ...KeptnOption) *Keptn {
 		logger:                 logger,
 		env:                    env,
 		healthEndpointRunner:   newHealthEndpointRunner,
+		tracerProvider:         tracerProvider,
 	}
 	for _, opt := range opts {
 		opt(keptn)
@@ -198,6 +210,17 @@ func (k *Keptn) OnEvent(ctx context.Context, event models.KeptnContextExtendedCE
 		{
 			defer wg.Done()
 			if handler, ok := k.taskRegistry.Contains(*event.Type); ok {
+				taskCtx, span := startTaskSpan(ctx, k.source, event)
+				var taskErr *Error
+				defer func() {
+					endTaskSpan(span, taskErr)
+				}()
+				// the events sent in response to the incoming event continue its trace
+				eventSender := func(ce models.KeptnContextExtendedCE) error {
+					tracing.InjectIntoEvent(taskCtx, &ce)
+					return eventSender(ce)
+				}
+
 				keptnEvent := &KeptnEvent{}
 				if err := keptnv2.Decode(&event, keptnEvent); err != nil {
 					errorLogEvent, err := createErrorLogEvent(k.source, event, nil, &Error{Err: err, StatusType: keptnv2.StatusErrored, ResultType: keptnv2.ResultFailed})
@@ -235,6 +258,7 @@ func (k *Keptn) OnEvent(ctx context.Context, event models.KeptnContextExtendedCE
 				}
 
 				result, err := handler.taskHandler.Execute(k, *keptnEvent)
+				taskErr = err
 				if err != nil {
 					k.logger.Errorf("Error during task execution %v", err.Err)
 					if k.automaticEventResponse {
@@ -305,6 +329,11 @@ func (k *Keptn) Start() error {
 	// add additional waiting time to ensure the waitGroup has been increased for all events that have been received between receiving SIGTERM and this point
 	<-time.After(5 * time.Second)
 	wg.Wait()
+	if k.tracerProvider != nil {
+		if err := k.tracerProvider.Shutdown(context.Background()); err != nil {
+			k.logger.Errorf("Unable to shut down tracer provider: %v", err)
+		}
+	}
 	return err
 }
 
@@ -317,6 +346,7 @@ func (k *Keptn) SendStartedEvent(event KeptnEvent) error {
 	if err != nil {
 		return err
 	}
+	tracing.InjectIntoEvent(tracing.ContextFromEvent(context.Background(), models.KeptnContextExtendedCE(event)), finishedEvent)
 	return k.eventSender(*finishedEvent)
 }
 
@@ -325,6 +355,7 @@ func (k *Keptn) SendFinishedEvent(event KeptnEvent, result interface{}) error {
 	if err != nil {
 		return err
 	}
+	tracing.InjectIntoEvent(tracing.ContextFromEvent(context.Background(), models.KeptnContextExtendedCE(event)), finishedEvent)
 	return k.eventSender(*finishedEvent)
 }
 
@@ -0,0 +1,56 @@
+package sdk
+
+import (
+	"context"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/cp-common/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/keptn/keptn/go-sdk"
+
+// newTracerProvider creates a TracerProvider that exports spans to the OTLP collector configured via the OTEL_EXPORTER_OTLP_* environment variables.
+// If no endpoint is configured, nil is returned and the global TracerProvider is used
+func newTracerProvider(serviceName, endpoint string) (*sdktrace.TracerProvider, error) {
+	if endpoint == "" {
+		return nil, nil
+	}
+	exporter, err := otlptracehttp.New(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName))),
+	), nil
+}
+
+// startTaskSpan starts the span covering the execution of a task. The span continues the trace of the given context if it contains one,
+// otherwise the trace context carried in the extensions of the given event is used
+func startTaskSpan(ctx context.Context, source string, event models.KeptnContextExtendedCE) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = tracing.ContextFromEvent(ctx, event)
+	}
+	return otel.Tracer(tracerName).Start(ctx, "go-sdk.Execute", trace.WithAttributes(
+		attribute.String("keptn.integration", source),
+		attribute.String("keptn.context", event.Shkeptncontext),
+		attribute.String("keptn.event.id", event.ID),
+		attribute.String("keptn.event.type", *event.Type),
+	))
+}
+
+func endTaskSpan(span trace.Span, err *Error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
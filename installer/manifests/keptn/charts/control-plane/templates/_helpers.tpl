@@ -83,6 +83,14 @@ lifecycle:
       command: ["/bin/sleep", {{ . }} ]
 {{- end }}
 
+{{- define "control-plane.internal-api-token.env.var" -}}
+- name: INTERNAL_API_TOKEN
+  valueFrom:
+    secretKeyRef:
+      name: {{ default "keptn-api-token" .Values.apiService.tokenSecretName }}
+      key: keptn-api-token
+{{- end }}
+
 {{- define "control-plane.common.env.vars" -}}
 - name: K8S_DEPLOYMENT_NAME
   valueFrom:
@@ -114,11 +122,13 @@ lifecycle:
     fieldRef:
       apiVersion: v1
       fieldPath: metadata.name
+{{ include "control-plane.internal-api-token.env.var" . }}
 {{- end }}
 
 {{- define "control-plane.dist.common.env.vars" -}}
 - name: PUBSUB_URL
   value: 'nats://keptn-nats'
+{{ include "control-plane.internal-api-token.env.var" . }}
 - name: VERSION
   valueFrom:
     fieldRef:
@@ -0,0 +1,530 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	fdiff "github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+const storeCommitRetries = 5
+
+// binaryDetectionLength is the number of bytes that are checked for a NUL byte to detect binary files, as done by git
+const binaryDetectionLength = 8000
+
+// IConfigurationStore keeps the resources of projects without a local git repository.
+// Like with the branch based git structure, the resources of each stage are kept in a separate line of commits,
+// which starts at the commit of the project that was current when the stage was created
+type IConfigurationStore interface {
+	ProjectExists(project string) bool
+	StageExists(project string, stage string) bool
+	CreateProject(project string, files map[string][]byte, message string) (string, error)
+	DeleteProject(project string) error
+	CreateStage(project string, stage string) (string, error)
+	DeleteStage(project string, stage string) error
+	GetTree(project string, stage string, revision string) (*StoreTree, error)
+	Commit(project string, stage string, message string, update func(tree *StoreTree) error) (string, error)
+	GetFileHistory(project string, stage string, file string) ([]common_models.GitCommit, error)
+	GetFileDiff(project string, fromRevision string, toRevision string, file string) (string, error)
+}
+
+// storeCommit is a snapshot of all files of a project or stage. Its ID is the hash of its JSON representation
+type storeCommit struct {
+	Parent    string            `json:"parent,omitempty"`
+	Message   string            `json:"message"`
+	Author    string            `json:"author"`
+	Timestamp time.Time         `json:"timestamp"`
+	Files     map[string]string `json:"files"`
+}
+
+// ConfigurationStore implements IConfigurationStore on top of an IObjectStore.
+// The content of each file is stored as an object addressed by its git blob hash, and each commit is stored as an object
+// that maps the paths of the files to these hashes. The current commit of a project or stage is kept in a reference,
+// which is only updated if no other commit has been made in the meantime
+type ConfigurationStore struct {
+	objects IObjectStore
+	clock   func() time.Time
+}
+
+func NewConfigurationStore(objects IObjectStore) *ConfigurationStore {
+	return &ConfigurationStore{
+		objects: objects,
+		clock:   time.Now,
+	}
+}
+
+func (s *ConfigurationStore) ProjectExists(project string) bool {
+	_, err := s.objects.GetReference(projectReferenceKey(project))
+	return err == nil
+}
+
+func (s *ConfigurationStore) StageExists(project string, stage string) bool {
+	_, err := s.objects.GetReference(stageReferenceKey(project, stage))
+	return err == nil
+}
+
+// CreateProject creates a project with an initial commit containing the given files
+func (s *ConfigurationStore) CreateProject(project string, files map[string][]byte, message string) (string, error) {
+	if s.ProjectExists(project) {
+		return "", kerrors.ErrProjectAlreadyExists
+	}
+	tree := &StoreTree{store: s, project: project, files: map[string]string{}, pending: map[string][]byte{}}
+	for path, content := range files {
+		tree.Write(path, content)
+	}
+	commitID, err := s.writeCommit(tree, "", message)
+	if err != nil {
+		return "", err
+	}
+	if err := s.objects.UpdateReference(projectReferenceKey(project), "", commitID); err != nil {
+		if errors.Is(err, kerrors.ErrObjectModified) {
+			return "", kerrors.ErrProjectAlreadyExists
+		}
+		return "", err
+	}
+	return commitID, nil
+}
+
+func (s *ConfigurationStore) DeleteProject(project string) error {
+	if err := s.objects.DeleteAll(project + "/"); err != nil {
+		return fmt.Errorf("could not delete project %s: %w", project, err)
+	}
+	return nil
+}
+
+// CreateStage creates a stage that starts with the current commit of the project
+func (s *ConfigurationStore) CreateStage(project string, stage string) (string, error) {
+	commitID, err := s.objects.GetReference(projectReferenceKey(project))
+	if err != nil {
+		return "", s.onReferenceError(err, project, "")
+	}
+	if err := s.objects.UpdateReference(stageReferenceKey(project, stage), "", commitID); err != nil {
+		if errors.Is(err, kerrors.ErrObjectModified) {
+			return "", kerrors.ErrStageAlreadyExists
+		}
+		return "", err
+	}
+	return commitID, nil
+}
+
+func (s *ConfigurationStore) DeleteStage(project string, stage string) error {
+	key := stageReferenceKey(project, stage)
+	commitID, err := s.objects.GetReference(key)
+	if err != nil {
+		return s.onReferenceError(err, project, stage)
+	}
+	return s.objects.UpdateReference(key, commitID, "")
+}
+
+// GetTree returns the files of the given revision, or of the current commit of the project or stage if the revision is empty
+func (s *ConfigurationStore) GetTree(project string, stage string, revision string) (*StoreTree, error) {
+	if revision == "" {
+		commitID, err := s.objects.GetReference(referenceKey(project, stage))
+		if err != nil {
+			return nil, s.onReferenceError(err, project, stage)
+		}
+		revision = commitID
+	} else if !s.ProjectExists(project) {
+		return nil, kerrors.ErrProjectNotFound
+	}
+	commit, err := s.getCommit(project, revision)
+	if err != nil {
+		return nil, err
+	}
+	return &StoreTree{store: s, project: project, revision: revision, files: commit.Files, pending: map[string][]byte{}}, nil
+}
+
+// Commit applies the changes made by the update function to the current files of the project or stage and stores them as a new commit.
+// If another commit has been made in the meantime, the update function is called again with the new files.
+// If the update function does not change any file, no commit is made and the current revision is returned
+func (s *ConfigurationStore) Commit(project string, stage string, message string, update func(tree *StoreTree) error) (string, error) {
+	key := referenceKey(project, stage)
+	for i := 0; i < storeCommitRetries; i++ {
+		tree, err := s.GetTree(project, stage, "")
+		if err != nil {
+			return "", err
+		}
+		if err := update(tree); err != nil {
+			return "", err
+		}
+		if !tree.changed {
+			return tree.revision, nil
+		}
+		commitID, err := s.writeCommit(tree, tree.revision, message)
+		if err != nil {
+			return "", err
+		}
+		err = s.objects.UpdateReference(key, tree.revision, commitID)
+		if err == nil {
+			return commitID, nil
+		}
+		if !errors.Is(err, kerrors.ErrObjectModified) {
+			return "", fmt.Errorf(kerrors.ErrMsgCouldNotCommit, project, err)
+		}
+	}
+	return "", fmt.Errorf(kerrors.ErrMsgCouldNotCommit, project, kerrors.ErrObjectModified)
+}
+
+// GetFileHistory returns the commits of the project or stage that changed the given file, starting with the latest one
+func (s *ConfigurationStore) GetFileHistory(project string, stage string, file string) ([]common_models.GitCommit, error) {
+	commitID, err := s.objects.GetReference(referenceKey(project, stage))
+	if err != nil {
+		return nil, s.onReferenceError(err, project, stage)
+	}
+
+	history := []common_models.GitCommit{}
+	for commitID != "" {
+		commit, err := s.getCommit(project, commitID)
+		if err != nil {
+			return nil, err
+		}
+		parentHash := ""
+		if commit.Parent != "" {
+			parent, err := s.getCommit(project, commit.Parent)
+			if err != nil {
+				return nil, err
+			}
+			parentHash = parent.Files[file]
+		}
+		if commit.Files[file] != parentHash {
+			history = append(history, common_models.GitCommit{
+				ID:        commitID,
+				Author:    commit.Author,
+				Message:   commit.Message,
+				Timestamp: commit.Timestamp,
+			})
+		}
+		commitID = commit.Parent
+	}
+	if len(history) == 0 {
+		return nil, kerrors.ErrResourceNotFound
+	}
+	return history, nil
+}
+
+// GetFileDiff returns the changes of the given file between two revisions in unified diff format
+func (s *ConfigurationStore) GetFileDiff(project string, fromRevision string, toRevision string, file string) (string, error) {
+	fromTree, err := s.GetTree(project, "", fromRevision)
+	if err != nil {
+		return "", err
+	}
+	toTree, err := s.GetTree(project, "", toRevision)
+	if err != nil {
+		return "", err
+	}
+	fromHash, fromExists := fromTree.files[file]
+	toHash, toExists := toTree.files[file]
+	if !fromExists && !toExists {
+		return "", kerrors.ErrResourceNotFound
+	}
+	if fromHash == toHash {
+		return "", nil
+	}
+
+	patch := &storeFilePatch{}
+	var fromContent, toContent []byte
+	if fromExists {
+		patch.from = &storeFile{hash: plumbing.NewHash(fromHash), path: file}
+		if fromContent, err = fromTree.Read(file); err != nil {
+			return "", err
+		}
+	}
+	if toExists {
+		patch.to = &storeFile{hash: plumbing.NewHash(toHash), path: file}
+		if toContent, err = toTree.Read(file); err != nil {
+			return "", err
+		}
+	}
+	if !isBinary(fromContent) && !isBinary(toContent) {
+		for _, d := range diff.Do(string(fromContent), string(toContent)) {
+			patch.chunks = append(patch.chunks, &storeChunk{content: d.Text, operation: chunkOperation(d.Type)})
+		}
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := fdiff.NewUnifiedEncoder(buf, fdiff.DefaultContextLines).Encode(&storePatch{filePatches: []fdiff.FilePatch{patch}}); err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "diff", project, err)
+	}
+	return buf.String(), nil
+}
+
+func (s *ConfigurationStore) writeCommit(tree *StoreTree, parent string, message string) (string, error) {
+	usedHashes := tree.usedHashes()
+	for hash, content := range tree.pending {
+		if _, stillUsed := usedHashes[hash]; !stillUsed {
+			continue
+		}
+		if err := s.objects.PutObject(objectKey(tree.project, hash), content); err != nil {
+			return "", fmt.Errorf(kerrors.ErrMsgCouldNotCommit, tree.project, err)
+		}
+	}
+	commit := storeCommit{
+		Parent:    parent,
+		Message:   message,
+		Author:    getGitKeptnUser(),
+		Timestamp: s.clock().UTC(),
+		Files:     tree.files,
+	}
+	// the files are encoded in the order of their paths, which makes the ID of the commit deterministic
+	data, err := json.Marshal(commit)
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotCommit, tree.project, err)
+	}
+	commitID := plumbing.ComputeHash(plumbing.CommitObject, data).String()
+	if err := s.objects.PutObject(commitKey(tree.project, commitID), data); err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotCommit, tree.project, err)
+	}
+	return commitID, nil
+}
+
+func (s *ConfigurationStore) getCommit(project string, commitID string) (*storeCommit, error) {
+	if !plumbing.IsHash(commitID) {
+		return nil, kerrors.ErrResolveRevision
+	}
+	data, err := s.objects.GetObject(commitKey(project, commitID))
+	if err != nil {
+		if errors.Is(err, kerrors.ErrObjectNotFound) {
+			return nil, kerrors.ErrResolveRevision
+		}
+		return nil, err
+	}
+	commit := &storeCommit{}
+	if err := json.Unmarshal(data, commit); err != nil {
+		return nil, fmt.Errorf("could not decode commit %s of project %s: %w", commitID, project, err)
+	}
+	if commit.Files == nil {
+		commit.Files = map[string]string{}
+	}
+	return commit, nil
+}
+
+func (s *ConfigurationStore) onReferenceError(err error, project string, stage string) error {
+	if !errors.Is(err, kerrors.ErrObjectNotFound) {
+		return err
+	}
+	if stage != "" && s.ProjectExists(project) {
+		return kerrors.ErrStageNotFound
+	}
+	return kerrors.ErrProjectNotFound
+}
+
+// StoreTree contains the files of a commit of a ConfigurationStore.
+// Changes made to a tree are only stored by ConfigurationStore.Commit
+type StoreTree struct {
+	store    *ConfigurationStore
+	project  string
+	revision string
+	files    map[string]string
+	pending  map[string][]byte
+	changed  bool
+}
+
+// Revision returns the ID of the commit the tree has been read from
+func (t *StoreTree) Revision() string {
+	return t.revision
+}
+
+// Files returns the sorted paths of all files within the given directory, or of all files if the directory is empty
+func (t *StoreTree) Files(directory string) []string {
+	prefix := strings.Trim(directory, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	files := []string{}
+	for path := range t.files {
+		if strings.HasPrefix(path, prefix) {
+			files = append(files, path)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+func (t *StoreTree) Exists(path string) bool {
+	_, exists := t.files[path]
+	return exists
+}
+
+// Read returns the content of the file with the given path, or errors.ErrResourceNotFound
+func (t *StoreTree) Read(path string) ([]byte, error) {
+	hash, exists := t.files[path]
+	if !exists {
+		return nil, kerrors.ErrResourceNotFound
+	}
+	if content, ok := t.pending[hash]; ok {
+		return content, nil
+	}
+	content, err := t.store.objects.GetObject(objectKey(t.project, hash))
+	if err != nil {
+		return nil, fmt.Errorf("could not read file %s of project %s: %w", path, t.project, err)
+	}
+	return content, nil
+}
+
+func (t *StoreTree) Write(path string, content []byte) {
+	hash := plumbing.ComputeHash(plumbing.BlobObject, content).String()
+	if t.files[path] == hash {
+		return
+	}
+	t.pending[hash] = content
+	t.files = t.copyFiles()
+	t.files[path] = hash
+	t.changed = true
+}
+
+// Delete removes the file with the given path, as well as all files within the directory with this path.
+// It returns the number of removed files
+func (t *StoreTree) Delete(path string) int {
+	path = strings.Trim(path, "/")
+	removed := 0
+	files := t.copyFiles()
+	for file := range t.files {
+		if file == path || strings.HasPrefix(file, path+"/") {
+			delete(files, file)
+			removed++
+		}
+	}
+	if removed > 0 {
+		t.files = files
+		t.changed = true
+	}
+	return removed
+}
+
+// copyFiles returns a copy of the files of the tree, since the original map may be shared with the commit it has been read from
+func (t *StoreTree) copyFiles() map[string]string {
+	files := make(map[string]string, len(t.files))
+	for path, hash := range t.files {
+		files[path] = hash
+	}
+	return files
+}
+
+func (t *StoreTree) usedHashes() map[string]struct{} {
+	hashes := map[string]struct{}{}
+	for _, hash := range t.files {
+		hashes[hash] = struct{}{}
+	}
+	return hashes
+}
+
+func referenceKey(project string, stage string) string {
+	if stage == "" {
+		return projectReferenceKey(project)
+	}
+	return stageReferenceKey(project, stage)
+}
+
+func projectReferenceKey(project string) string {
+	return project + "/refs/project"
+}
+
+func stageReferenceKey(project string, stage string) string {
+	return project + "/refs/stages/" + stage
+}
+
+func objectKey(project string, hash string) string {
+	return project + "/objects/" + hash
+}
+
+func commitKey(project string, commitID string) string {
+	return project + "/commits/" + commitID
+}
+
+func isBinary(content []byte) bool {
+	if len(content) > binaryDetectionLength {
+		content = content[:binaryDetectionLength]
+	}
+	return bytes.IndexByte(content, 0) >= 0
+}
+
+func chunkOperation(diffType diffmatchpatch.Operation) fdiff.Operation {
+	switch diffType {
+	case diffmatchpatch.DiffDelete:
+		return fdiff.Delete
+	case diffmatchpatch.DiffInsert:
+		return fdiff.Add
+	default:
+		return fdiff.Equal
+	}
+}
+
+// storePatch, storeFilePatch, storeFile and storeChunk implement the interfaces required to encode a diff in the same format as git does
+
+type storePatch struct {
+	filePatches []fdiff.FilePatch
+}
+
+func (p *storePatch) FilePatches() []fdiff.FilePatch {
+	return p.filePatches
+}
+
+func (p *storePatch) Message() string {
+	return ""
+}
+
+type storeFilePatch struct {
+	from   *storeFile
+	to     *storeFile
+	chunks []fdiff.Chunk
+}
+
+func (p *storeFilePatch) IsBinary() bool {
+	return len(p.chunks) == 0
+}
+
+func (p *storeFilePatch) Files() (fdiff.File, fdiff.File) {
+	var from, to fdiff.File
+	if p.from != nil {
+		from = p.from
+	}
+	if p.to != nil {
+		to = p.to
+	}
+	return from, to
+}
+
+func (p *storeFilePatch) Chunks() []fdiff.Chunk {
+	return p.chunks
+}
+
+type storeFile struct {
+	hash plumbing.Hash
+	path string
+}
+
+func (f *storeFile) Hash() plumbing.Hash {
+	return f.hash
+}
+
+func (f *storeFile) Mode() filemode.FileMode {
+	return filemode.Regular
+}
+
+func (f *storeFile) Path() string {
+	return f.path
+}
+
+type storeChunk struct {
+	content   string
+	operation fdiff.Operation
+}
+
+func (c *storeChunk) Content() string {
+	return c.content
+}
+
+func (c *storeChunk) Type() fdiff.Operation {
+	return c.operation
+}
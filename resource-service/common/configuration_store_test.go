@@ -0,0 +1,226 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	common_mock "github.com/keptn/keptn/resource-service/common/fake"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConfigurationStore() (*ConfigurationStore, *common_mock.MemoryObjectStore) {
+	objects := common_mock.NewMemoryObjectStore()
+	store := NewConfigurationStore(objects)
+	store.clock = func() time.Time {
+		return time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return store, objects
+}
+
+func TestConfigurationStore_CreateProject(t *testing.T) {
+	store, _ := newTestConfigurationStore()
+
+	commitID, err := store.CreateProject("my-project", map[string][]byte{"metadata.yaml": []byte("projectName: my-project")}, "initialized project")
+	require.Nil(t, err)
+	require.Len(t, commitID, 40)
+	require.True(t, store.ProjectExists("my-project"))
+	require.False(t, store.ProjectExists("other-project"))
+
+	tree, err := store.GetTree("my-project", "", "")
+	require.Nil(t, err)
+	require.Equal(t, commitID, tree.Revision())
+	require.Equal(t, []string{"metadata.yaml"}, tree.Files(""))
+	content, err := tree.Read("metadata.yaml")
+	require.Nil(t, err)
+	require.Equal(t, "projectName: my-project", string(content))
+
+	_, err = store.CreateProject("my-project", nil, "initialized project")
+	require.ErrorIs(t, err, kerrors.ErrProjectAlreadyExists)
+}
+
+func TestConfigurationStore_Stages(t *testing.T) {
+	store, _ := newTestConfigurationStore()
+
+	projectCommit, err := store.CreateProject("my-project", map[string][]byte{"shipyard.yaml": []byte("stages")}, "initialized project")
+	require.Nil(t, err)
+
+	stageCommit, err := store.CreateStage("my-project", "dev")
+	require.Nil(t, err)
+	require.Equal(t, projectCommit, stageCommit)
+	require.True(t, store.StageExists("my-project", "dev"))
+
+	_, err = store.CreateStage("my-project", "dev")
+	require.ErrorIs(t, err, kerrors.ErrStageAlreadyExists)
+	_, err = store.CreateStage("other-project", "dev")
+	require.ErrorIs(t, err, kerrors.ErrProjectNotFound)
+
+	// commits to a stage do not change the project
+	_, err = store.Commit("my-project", "dev", "Added resource", func(tree *StoreTree) error {
+		tree.Write("my-service/values.yaml", []byte("replicas: 1"))
+		return nil
+	})
+	require.Nil(t, err)
+	projectTree, err := store.GetTree("my-project", "", "")
+	require.Nil(t, err)
+	require.Equal(t, []string{"shipyard.yaml"}, projectTree.Files(""))
+
+	require.Nil(t, store.DeleteStage("my-project", "dev"))
+	require.False(t, store.StageExists("my-project", "dev"))
+	require.ErrorIs(t, store.DeleteStage("my-project", "dev"), kerrors.ErrStageNotFound)
+	_, err = store.GetTree("my-project", "dev", "")
+	require.ErrorIs(t, err, kerrors.ErrStageNotFound)
+}
+
+func TestConfigurationStore_Commit(t *testing.T) {
+	store, _ := newTestConfigurationStore()
+
+	initialCommit, err := store.CreateProject("my-project", nil, "initialized project")
+	require.Nil(t, err)
+
+	firstCommit, err := store.Commit("my-project", "", "Added resources", func(tree *StoreTree) error {
+		tree.Write("helm/values.yaml", []byte("replicas: 1"))
+		tree.Write("helm/Chart.yaml", []byte("name: chart"))
+		tree.Write("slo.yaml", []byte("objectives"))
+		return nil
+	})
+	require.Nil(t, err)
+	require.NotEqual(t, initialCommit, firstCommit)
+
+	// a commit without any change keeps the current revision
+	unchangedCommit, err := store.Commit("my-project", "", "Updated resource", func(tree *StoreTree) error {
+		tree.Write("slo.yaml", []byte("objectives"))
+		return nil
+	})
+	require.Nil(t, err)
+	require.Equal(t, firstCommit, unchangedCommit)
+
+	secondCommit, err := store.Commit("my-project", "", "Deleted resources", func(tree *StoreTree) error {
+		require.Equal(t, 2, tree.Delete("helm"))
+		return nil
+	})
+	require.Nil(t, err)
+
+	tree, err := store.GetTree("my-project", "", "")
+	require.Nil(t, err)
+	require.Equal(t, secondCommit, tree.Revision())
+	require.Equal(t, []string{"slo.yaml"}, tree.Files(""))
+
+	// previous revisions can still be read
+	tree, err = store.GetTree("my-project", "", firstCommit)
+	require.Nil(t, err)
+	require.Equal(t, []string{"helm/Chart.yaml", "helm/values.yaml"}, tree.Files("helm"))
+	content, err := tree.Read("helm/values.yaml")
+	require.Nil(t, err)
+	require.Equal(t, "replicas: 1", string(content))
+
+	_, err = store.GetTree("my-project", "", "0123456789012345678901234567890123456789")
+	require.ErrorIs(t, err, kerrors.ErrResolveRevision)
+	_, err = store.GetTree("my-project", "", "unknown")
+	require.ErrorIs(t, err, kerrors.ErrResolveRevision)
+}
+
+func TestConfigurationStore_Commit_ConcurrentUpdate(t *testing.T) {
+	store, objects := newTestConfigurationStore()
+
+	_, err := store.CreateProject("my-project", nil, "initialized project")
+	require.Nil(t, err)
+
+	attempts := 0
+	commitID, err := store.Commit("my-project", "", "Added resource", func(tree *StoreTree) error {
+		attempts++
+		if attempts == 1 {
+			// simulate another replica committing a change after the files have been read
+			_, err := store.Commit("my-project", "", "Added other resource", func(tree *StoreTree) error {
+				tree.Write("other.yaml", []byte("other"))
+				return nil
+			})
+			require.Nil(t, err)
+		}
+		tree.Write("my.yaml", []byte("mine"))
+		return nil
+	})
+	require.Nil(t, err)
+	require.Equal(t, 2, attempts)
+	require.Equal(t, commitID, objects.References["my-project/refs/project"])
+
+	tree, err := store.GetTree("my-project", "", "")
+	require.Nil(t, err)
+	require.Equal(t, []string{"my.yaml", "other.yaml"}, tree.Files(""))
+}
+
+func TestConfigurationStore_GetFileHistory(t *testing.T) {
+	store, _ := newTestConfigurationStore()
+
+	_, err := store.CreateProject("my-project", nil, "initialized project")
+	require.Nil(t, err)
+
+	write := func(file, content, message string) string {
+		commitID, err := store.Commit("my-project", "", message, func(tree *StoreTree) error {
+			tree.Write(file, []byte(content))
+			return nil
+		})
+		require.Nil(t, err)
+		return commitID
+	}
+	first := write("slo.yaml", "first", "Added resource")
+	write("other.yaml", "other", "Added resource")
+	second := write("slo.yaml", "second", "Updated resource")
+
+	history, err := store.GetFileHistory("my-project", "", "slo.yaml")
+	require.Nil(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, second, history[0].ID)
+	require.Equal(t, "Updated resource", history[0].Message)
+	require.Equal(t, "keptn", history[0].Author)
+	require.Equal(t, first, history[1].ID)
+
+	_, err = store.GetFileHistory("my-project", "", "unknown.yaml")
+	require.ErrorIs(t, err, kerrors.ErrResourceNotFound)
+}
+
+func TestConfigurationStore_GetFileDiff(t *testing.T) {
+	store, _ := newTestConfigurationStore()
+
+	first, err := store.CreateProject("my-project", map[string][]byte{"slo.yaml": []byte("a\nb\n")}, "initialized project")
+	require.Nil(t, err)
+	second, err := store.Commit("my-project", "", "Updated resource", func(tree *StoreTree) error {
+		tree.Write("slo.yaml", []byte("a\nc\n"))
+		return nil
+	})
+	require.Nil(t, err)
+
+	diff, err := store.GetFileDiff("my-project", first, second, "slo.yaml")
+	require.Nil(t, err)
+	require.Equal(t, "diff --git a/slo.yaml b/slo.yaml\n"+
+		"index 422c2b7ab3b3c668038da977e4e93a5fc623169c..0f7bc766052a5a0ee28a393d51d2370f96d8ceb8 100644\n"+
+		"--- a/slo.yaml\n"+
+		"+++ b/slo.yaml\n"+
+		"@@ -1,2 +1,2 @@\n"+
+		" a\n"+
+		"-b\n"+
+		"+c\n", diff)
+
+	diff, err = store.GetFileDiff("my-project", second, second, "slo.yaml")
+	require.Nil(t, err)
+	require.Equal(t, "", diff)
+
+	_, err = store.GetFileDiff("my-project", first, second, "unknown.yaml")
+	require.ErrorIs(t, err, kerrors.ErrResourceNotFound)
+}
+
+func TestConfigurationStore_DeleteProject(t *testing.T) {
+	store, objects := newTestConfigurationStore()
+
+	_, err := store.CreateProject("my-project", map[string][]byte{"metadata.yaml": []byte("my-project")}, "initialized project")
+	require.Nil(t, err)
+	_, err = store.CreateProject("my-project-2", map[string][]byte{"metadata.yaml": []byte("my-project-2")}, "initialized project")
+	require.Nil(t, err)
+
+	require.Nil(t, store.DeleteProject("my-project"))
+	require.False(t, store.ProjectExists("my-project"))
+	require.True(t, store.ProjectExists("my-project-2"))
+	for key := range objects.Objects {
+		require.NotContains(t, key, "my-project/")
+	}
+}
@@ -18,6 +18,12 @@ type CredentialReader interface {
 	GetCredentials(project string) (*common_models.GitCredentials, error)
 }
 
+//go:generate moq -pkg common_mock -skip-ensure -out ./fake/webhook_secret_reader_mock.go . WebhookSecretReader
+type WebhookSecretReader interface {
+	// GetWebhookSecret returns the secret that is used to verify webhook calls of the upstream repository of the project
+	GetWebhookSecret(project string) (string, error)
+}
+
 type K8sCredentialReader struct {
 	k8sClient kubernetes.Interface
 }
@@ -51,6 +57,26 @@ func (kr K8sCredentialReader) GetCredentials(project string) (*common_models.Git
 	return credentials, nil
 }
 
+// GetWebhookSecret reads the webhook secret of the project from the secret git-webhook-<project>. The secret is kept
+// apart from the git credentials, since these are replaced whenever the upstream of the project is updated
+func (kr K8sCredentialReader) GetWebhookSecret(project string) (string, error) {
+	secretName := fmt.Sprintf("git-webhook-%s", project)
+
+	secret, err := kr.k8sClient.CoreV1().Secrets(GetKeptnNamespace()).Get(context.TODO(), secretName, metav1.GetOptions{})
+	if err != nil && k8serrors.IsNotFound(err) {
+		logger.Debug("Could not retrieve webhook secret named: ", secretName)
+		return "", errors2.ErrWebhookSecretNotFound
+	}
+	if err != nil {
+		logger.Debug("Could not retrieve webhook secret named: ", secretName)
+		return "", err
+	}
+	if len(secret.Data["webhook-secret"]) == 0 {
+		return "", errors2.ErrWebhookSecretNotFound
+	}
+	return string(secret.Data["webhook-secret"]), nil
+}
+
 func GetKeptnNamespace() string {
 	return os.Getenv("POD_NAMESPACE")
 }
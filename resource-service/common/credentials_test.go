@@ -117,6 +117,29 @@ func TestK8sCredentialReader_ReadSecretError(t *testing.T) {
 	require.Nil(t, secret)
 }
 
+func TestK8sCredentialReader_GetWebhookSecret(t *testing.T) {
+	_ = os.Setenv("POD_NAMESPACE", "keptn")
+	secretReader := NewK8sCredentialReader(fake.NewSimpleClientset(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "git-webhook-my-project",
+				Namespace: "keptn",
+			},
+			Data: map[string][]byte{
+				"webhook-secret": []byte("my-secret")},
+			Type: corev1.SecretTypeOpaque,
+		},
+	))
+
+	secret, err := secretReader.GetWebhookSecret("my-project")
+	require.Nil(t, err)
+	require.Equal(t, "my-secret", secret)
+
+	secret, err = secretReader.GetWebhookSecret("my-other-project")
+	require.ErrorIs(t, err, errors.ErrWebhookSecretNotFound)
+	require.Empty(t, secret)
+}
+
 func getK8sSecret() *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
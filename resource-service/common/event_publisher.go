@@ -0,0 +1,48 @@
+package common
+
+import (
+	"fmt"
+	"sync"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/cp-connector/pkg/nats"
+)
+
+//go:generate moq -pkg common_mock -skip-ensure -out ./fake/event_publisher_mock.go . EventPublisher
+type EventPublisher interface {
+	Publish(event apimodels.KeptnContextExtendedCE) error
+}
+
+// NatsEventPublisher publishes events to the NATS server configured via the NATS_URL env var.
+// The connection is established with the first event, so that the service does not depend on NATS being available at startup
+type NatsEventPublisher struct {
+	mutex     sync.Mutex
+	connector *nats.NatsConnector
+}
+
+func NewNatsEventPublisher() *NatsEventPublisher {
+	return &NatsEventPublisher{}
+}
+
+func (p *NatsEventPublisher) Publish(event apimodels.KeptnContextExtendedCE) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.connector == nil {
+		connector, err := nats.ConnectFromEnv()
+		if err != nil {
+			return fmt.Errorf("could not publish event: %w", err)
+		}
+		p.connector = connector
+	}
+	return p.connector.Publish(event)
+}
+
+// Close closes the connection to NATS, if it has been established
+func (p *NatsEventPublisher) Close() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.connector != nil {
+		_ = p.connector.Disconnect()
+		p.connector = nil
+	}
+}
@@ -20,15 +20,33 @@ import (
 // 			CloneRepoFunc: func(gitContext common_models.GitContext) (bool, error) {
 // 				panic("mock out the CloneRepo method")
 // 			},
+// 			CommitAllFunc: func(gitContext common_models.GitContext, message string) (string, error) {
+// 				panic("mock out the CommitAll method")
+// 			},
 // 			CreateBranchFunc: func(gitContext common_models.GitContext, branch string, sourceBranch string) error {
 // 				panic("mock out the CreateBranch method")
 // 			},
+// 			DeleteBranchFunc: func(gitContext common_models.GitContext, branch string) error {
+// 				panic("mock out the DeleteBranch method")
+// 			},
+// 			GetChangedFilesFunc: func(gitContext common_models.GitContext, fromRevision string, toRevision string) ([]string, error) {
+// 				panic("mock out the GetChangedFiles method")
+// 			},
+// 			GetCurrentBranchFunc: func(gitContext common_models.GitContext) (string, error) {
+// 				panic("mock out the GetCurrentBranch method")
+// 			},
 // 			GetCurrentRevisionFunc: func(gitContext common_models.GitContext) (string, error) {
 // 				panic("mock out the GetCurrentRevision method")
 // 			},
 // 			GetDefaultBranchFunc: func(gitContext common_models.GitContext) (string, error) {
 // 				panic("mock out the GetDefaultBranch method")
 // 			},
+// 			GetFileDiffFunc: func(gitContext common_models.GitContext, fromRevision string, toRevision string, file string) (string, error) {
+// 				panic("mock out the GetFileDiff method")
+// 			},
+// 			GetFileHistoryFunc: func(gitContext common_models.GitContext, file string) ([]common_models.GitCommit, error) {
+// 				panic("mock out the GetFileHistory method")
+// 			},
 // 			GetFileRevisionFunc: func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
 // 				panic("mock out the GetFileRevision method")
 // 			},
@@ -47,7 +65,10 @@ import (
 // 			PushFunc: func(gitContext common_models.GitContext) error {
 // 				panic("mock out the Push method")
 // 			},
-// 			ResetHardFunc: func(gitContext common_models.GitContext) error {
+// 			PushBranchFunc: func(gitContext common_models.GitContext, branch string) error {
+// 				panic("mock out the PushBranch method")
+// 			},
+// 			ResetHardFunc: func(gitContext common_models.GitContext, revision string) error {
 // 				panic("mock out the ResetHard method")
 // 			},
 // 			StageAndCommitAllFunc: func(gitContext common_models.GitContext, message string) (string, error) {
@@ -66,15 +87,33 @@ type IGitMock struct {
 	// CloneRepoFunc mocks the CloneRepo method.
 	CloneRepoFunc func(gitContext common_models.GitContext) (bool, error)
 
+	// CommitAllFunc mocks the CommitAll method.
+	CommitAllFunc func(gitContext common_models.GitContext, message string) (string, error)
+
 	// CreateBranchFunc mocks the CreateBranch method.
 	CreateBranchFunc func(gitContext common_models.GitContext, branch string, sourceBranch string) error
 
+	// DeleteBranchFunc mocks the DeleteBranch method.
+	DeleteBranchFunc func(gitContext common_models.GitContext, branch string) error
+
+	// GetChangedFilesFunc mocks the GetChangedFiles method.
+	GetChangedFilesFunc func(gitContext common_models.GitContext, fromRevision string, toRevision string) ([]string, error)
+
+	// GetCurrentBranchFunc mocks the GetCurrentBranch method.
+	GetCurrentBranchFunc func(gitContext common_models.GitContext) (string, error)
+
 	// GetCurrentRevisionFunc mocks the GetCurrentRevision method.
 	GetCurrentRevisionFunc func(gitContext common_models.GitContext) (string, error)
 
 	// GetDefaultBranchFunc mocks the GetDefaultBranch method.
 	GetDefaultBranchFunc func(gitContext common_models.GitContext) (string, error)
 
+	// GetFileDiffFunc mocks the GetFileDiff method.
+	GetFileDiffFunc func(gitContext common_models.GitContext, fromRevision string, toRevision string, file string) (string, error)
+
+	// GetFileHistoryFunc mocks the GetFileHistory method.
+	GetFileHistoryFunc func(gitContext common_models.GitContext, file string) ([]common_models.GitCommit, error)
+
 	// GetFileRevisionFunc mocks the GetFileRevision method.
 	GetFileRevisionFunc func(gitContext common_models.GitContext, revision string, file string) ([]byte, error)
 
@@ -93,8 +132,11 @@ type IGitMock struct {
 	// PushFunc mocks the Push method.
 	PushFunc func(gitContext common_models.GitContext) error
 
+	// PushBranchFunc mocks the PushBranch method.
+	PushBranchFunc func(gitContext common_models.GitContext, branch string) error
+
 	// ResetHardFunc mocks the ResetHard method.
-	ResetHardFunc func(gitContext common_models.GitContext) error
+	ResetHardFunc func(gitContext common_models.GitContext, revision string) error
 
 	// StageAndCommitAllFunc mocks the StageAndCommitAll method.
 	StageAndCommitAllFunc func(gitContext common_models.GitContext, message string) (string, error)
@@ -113,6 +155,13 @@ type IGitMock struct {
 			// GitContext is the gitContext argument value.
 			GitContext common_models.GitContext
 		}
+		// CommitAll holds details about calls to the CommitAll method.
+		CommitAll []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Message is the message argument value.
+			Message string
+		}
 		// CreateBranch holds details about calls to the CreateBranch method.
 		CreateBranch []struct {
 			// GitContext is the gitContext argument value.
@@ -122,6 +171,27 @@ type IGitMock struct {
 			// SourceBranch is the sourceBranch argument value.
 			SourceBranch string
 		}
+		// DeleteBranch holds details about calls to the DeleteBranch method.
+		DeleteBranch []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Branch is the branch argument value.
+			Branch string
+		}
+		// GetChangedFiles holds details about calls to the GetChangedFiles method.
+		GetChangedFiles []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// FromRevision is the fromRevision argument value.
+			FromRevision string
+			// ToRevision is the toRevision argument value.
+			ToRevision string
+		}
+		// GetCurrentBranch holds details about calls to the GetCurrentBranch method.
+		GetCurrentBranch []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+		}
 		// GetCurrentRevision holds details about calls to the GetCurrentRevision method.
 		GetCurrentRevision []struct {
 			// GitContext is the gitContext argument value.
@@ -132,6 +202,24 @@ type IGitMock struct {
 			// GitContext is the gitContext argument value.
 			GitContext common_models.GitContext
 		}
+		// GetFileDiff holds details about calls to the GetFileDiff method.
+		GetFileDiff []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// FromRevision is the fromRevision argument value.
+			FromRevision string
+			// ToRevision is the toRevision argument value.
+			ToRevision string
+			// File is the file argument value.
+			File string
+		}
+		// GetFileHistory holds details about calls to the GetFileHistory method.
+		GetFileHistory []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// File is the file argument value.
+			File string
+		}
 		// GetFileRevision holds details about calls to the GetFileRevision method.
 		GetFileRevision []struct {
 			// GitContext is the gitContext argument value.
@@ -168,10 +256,19 @@ type IGitMock struct {
 			// GitContext is the gitContext argument value.
 			GitContext common_models.GitContext
 		}
+		// PushBranch holds details about calls to the PushBranch method.
+		PushBranch []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Branch is the branch argument value.
+			Branch string
+		}
 		// ResetHard holds details about calls to the ResetHard method.
 		ResetHard []struct {
 			// GitContext is the gitContext argument value.
 			GitContext common_models.GitContext
+			// Revision is the revision argument value.
+			Revision string
 		}
 		// StageAndCommitAll holds details about calls to the StageAndCommitAll method.
 		StageAndCommitAll []struct {
@@ -183,15 +280,22 @@ type IGitMock struct {
 	}
 	lockCheckoutBranch     sync.RWMutex
 	lockCloneRepo          sync.RWMutex
+	lockCommitAll          sync.RWMutex
 	lockCreateBranch       sync.RWMutex
+	lockDeleteBranch       sync.RWMutex
+	lockGetChangedFiles    sync.RWMutex
+	lockGetCurrentBranch   sync.RWMutex
 	lockGetCurrentRevision sync.RWMutex
 	lockGetDefaultBranch   sync.RWMutex
+	lockGetFileDiff        sync.RWMutex
+	lockGetFileHistory     sync.RWMutex
 	lockGetFileRevision    sync.RWMutex
 	lockMigrateProject     sync.RWMutex
 	lockProjectExists      sync.RWMutex
 	lockProjectRepoExists  sync.RWMutex
 	lockPull               sync.RWMutex
 	lockPush               sync.RWMutex
+	lockPushBranch         sync.RWMutex
 	lockResetHard          sync.RWMutex
 	lockStageAndCommitAll  sync.RWMutex
 }
@@ -216,7 +320,8 @@ func (mock *IGitMock) CheckoutBranch(gitContext common_models.GitContext, branch
 
 // CheckoutBranchCalls gets all the calls that were made to CheckoutBranch.
 // Check the length with:
-//     len(mockedIGit.CheckoutBranchCalls())
+//
+// 	len(mockedIGit.CheckoutBranchCalls())
 func (mock *IGitMock) CheckoutBranchCalls() []struct {
 	GitContext common_models.GitContext
 	Branch     string
@@ -249,7 +354,8 @@ func (mock *IGitMock) CloneRepo(gitContext common_models.GitContext) (bool, erro
 
 // CloneRepoCalls gets all the calls that were made to CloneRepo.
 // Check the length with:
-//     len(mockedIGit.CloneRepoCalls())
+//
+// 	len(mockedIGit.CloneRepoCalls())
 func (mock *IGitMock) CloneRepoCalls() []struct {
 	GitContext common_models.GitContext
 } {
@@ -262,6 +368,42 @@ func (mock *IGitMock) CloneRepoCalls() []struct {
 	return calls
 }
 
+// CommitAll calls CommitAllFunc.
+func (mock *IGitMock) CommitAll(gitContext common_models.GitContext, message string) (string, error) {
+	if mock.CommitAllFunc == nil {
+		panic("IGitMock.CommitAllFunc: method is nil but IGit.CommitAll was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Message    string
+	}{
+		GitContext: gitContext,
+		Message:    message,
+	}
+	mock.lockCommitAll.Lock()
+	mock.calls.CommitAll = append(mock.calls.CommitAll, callInfo)
+	mock.lockCommitAll.Unlock()
+	return mock.CommitAllFunc(gitContext, message)
+}
+
+// CommitAllCalls gets all the calls that were made to CommitAll.
+// Check the length with:
+//
+// 	len(mockedIGit.CommitAllCalls())
+func (mock *IGitMock) CommitAllCalls() []struct {
+	GitContext common_models.GitContext
+	Message    string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Message    string
+	}
+	mock.lockCommitAll.RLock()
+	calls = mock.calls.CommitAll
+	mock.lockCommitAll.RUnlock()
+	return calls
+}
+
 // CreateBranch calls CreateBranchFunc.
 func (mock *IGitMock) CreateBranch(gitContext common_models.GitContext, branch string, sourceBranch string) error {
 	if mock.CreateBranchFunc == nil {
@@ -284,7 +426,8 @@ func (mock *IGitMock) CreateBranch(gitContext common_models.GitContext, branch s
 
 // CreateBranchCalls gets all the calls that were made to CreateBranch.
 // Check the length with:
-//     len(mockedIGit.CreateBranchCalls())
+//
+// 	len(mockedIGit.CreateBranchCalls())
 func (mock *IGitMock) CreateBranchCalls() []struct {
 	GitContext   common_models.GitContext
 	Branch       string
@@ -301,6 +444,114 @@ func (mock *IGitMock) CreateBranchCalls() []struct {
 	return calls
 }
 
+// DeleteBranch calls DeleteBranchFunc.
+func (mock *IGitMock) DeleteBranch(gitContext common_models.GitContext, branch string) error {
+	if mock.DeleteBranchFunc == nil {
+		panic("IGitMock.DeleteBranchFunc: method is nil but IGit.DeleteBranch was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Branch     string
+	}{
+		GitContext: gitContext,
+		Branch:     branch,
+	}
+	mock.lockDeleteBranch.Lock()
+	mock.calls.DeleteBranch = append(mock.calls.DeleteBranch, callInfo)
+	mock.lockDeleteBranch.Unlock()
+	return mock.DeleteBranchFunc(gitContext, branch)
+}
+
+// DeleteBranchCalls gets all the calls that were made to DeleteBranch.
+// Check the length with:
+//
+// 	len(mockedIGit.DeleteBranchCalls())
+func (mock *IGitMock) DeleteBranchCalls() []struct {
+	GitContext common_models.GitContext
+	Branch     string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Branch     string
+	}
+	mock.lockDeleteBranch.RLock()
+	calls = mock.calls.DeleteBranch
+	mock.lockDeleteBranch.RUnlock()
+	return calls
+}
+
+// GetChangedFiles calls GetChangedFilesFunc.
+func (mock *IGitMock) GetChangedFiles(gitContext common_models.GitContext, fromRevision string, toRevision string) ([]string, error) {
+	if mock.GetChangedFilesFunc == nil {
+		panic("IGitMock.GetChangedFilesFunc: method is nil but IGit.GetChangedFiles was just called")
+	}
+	callInfo := struct {
+		GitContext   common_models.GitContext
+		FromRevision string
+		ToRevision   string
+	}{
+		GitContext:   gitContext,
+		FromRevision: fromRevision,
+		ToRevision:   toRevision,
+	}
+	mock.lockGetChangedFiles.Lock()
+	mock.calls.GetChangedFiles = append(mock.calls.GetChangedFiles, callInfo)
+	mock.lockGetChangedFiles.Unlock()
+	return mock.GetChangedFilesFunc(gitContext, fromRevision, toRevision)
+}
+
+// GetChangedFilesCalls gets all the calls that were made to GetChangedFiles.
+// Check the length with:
+//
+// 	len(mockedIGit.GetChangedFilesCalls())
+func (mock *IGitMock) GetChangedFilesCalls() []struct {
+	GitContext   common_models.GitContext
+	FromRevision string
+	ToRevision   string
+} {
+	var calls []struct {
+		GitContext   common_models.GitContext
+		FromRevision string
+		ToRevision   string
+	}
+	mock.lockGetChangedFiles.RLock()
+	calls = mock.calls.GetChangedFiles
+	mock.lockGetChangedFiles.RUnlock()
+	return calls
+}
+
+// GetCurrentBranch calls GetCurrentBranchFunc.
+func (mock *IGitMock) GetCurrentBranch(gitContext common_models.GitContext) (string, error) {
+	if mock.GetCurrentBranchFunc == nil {
+		panic("IGitMock.GetCurrentBranchFunc: method is nil but IGit.GetCurrentBranch was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+	}{
+		GitContext: gitContext,
+	}
+	mock.lockGetCurrentBranch.Lock()
+	mock.calls.GetCurrentBranch = append(mock.calls.GetCurrentBranch, callInfo)
+	mock.lockGetCurrentBranch.Unlock()
+	return mock.GetCurrentBranchFunc(gitContext)
+}
+
+// GetCurrentBranchCalls gets all the calls that were made to GetCurrentBranch.
+// Check the length with:
+//
+// 	len(mockedIGit.GetCurrentBranchCalls())
+func (mock *IGitMock) GetCurrentBranchCalls() []struct {
+	GitContext common_models.GitContext
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+	}
+	mock.lockGetCurrentBranch.RLock()
+	calls = mock.calls.GetCurrentBranch
+	mock.lockGetCurrentBranch.RUnlock()
+	return calls
+}
+
 // GetCurrentRevision calls GetCurrentRevisionFunc.
 func (mock *IGitMock) GetCurrentRevision(gitContext common_models.GitContext) (string, error) {
 	if mock.GetCurrentRevisionFunc == nil {
@@ -319,7 +570,8 @@ func (mock *IGitMock) GetCurrentRevision(gitContext common_models.GitContext) (s
 
 // GetCurrentRevisionCalls gets all the calls that were made to GetCurrentRevision.
 // Check the length with:
-//     len(mockedIGit.GetCurrentRevisionCalls())
+//
+// 	len(mockedIGit.GetCurrentRevisionCalls())
 func (mock *IGitMock) GetCurrentRevisionCalls() []struct {
 	GitContext common_models.GitContext
 } {
@@ -350,7 +602,8 @@ func (mock *IGitMock) GetDefaultBranch(gitContext common_models.GitContext) (str
 
 // GetDefaultBranchCalls gets all the calls that were made to GetDefaultBranch.
 // Check the length with:
-//     len(mockedIGit.GetDefaultBranchCalls())
+//
+// 	len(mockedIGit.GetDefaultBranchCalls())
 func (mock *IGitMock) GetDefaultBranchCalls() []struct {
 	GitContext common_models.GitContext
 } {
@@ -363,6 +616,86 @@ func (mock *IGitMock) GetDefaultBranchCalls() []struct {
 	return calls
 }
 
+// GetFileDiff calls GetFileDiffFunc.
+func (mock *IGitMock) GetFileDiff(gitContext common_models.GitContext, fromRevision string, toRevision string, file string) (string, error) {
+	if mock.GetFileDiffFunc == nil {
+		panic("IGitMock.GetFileDiffFunc: method is nil but IGit.GetFileDiff was just called")
+	}
+	callInfo := struct {
+		GitContext   common_models.GitContext
+		FromRevision string
+		ToRevision   string
+		File         string
+	}{
+		GitContext:   gitContext,
+		FromRevision: fromRevision,
+		ToRevision:   toRevision,
+		File:         file,
+	}
+	mock.lockGetFileDiff.Lock()
+	mock.calls.GetFileDiff = append(mock.calls.GetFileDiff, callInfo)
+	mock.lockGetFileDiff.Unlock()
+	return mock.GetFileDiffFunc(gitContext, fromRevision, toRevision, file)
+}
+
+// GetFileDiffCalls gets all the calls that were made to GetFileDiff.
+// Check the length with:
+//
+// 	len(mockedIGit.GetFileDiffCalls())
+func (mock *IGitMock) GetFileDiffCalls() []struct {
+	GitContext   common_models.GitContext
+	FromRevision string
+	ToRevision   string
+	File         string
+} {
+	var calls []struct {
+		GitContext   common_models.GitContext
+		FromRevision string
+		ToRevision   string
+		File         string
+	}
+	mock.lockGetFileDiff.RLock()
+	calls = mock.calls.GetFileDiff
+	mock.lockGetFileDiff.RUnlock()
+	return calls
+}
+
+// GetFileHistory calls GetFileHistoryFunc.
+func (mock *IGitMock) GetFileHistory(gitContext common_models.GitContext, file string) ([]common_models.GitCommit, error) {
+	if mock.GetFileHistoryFunc == nil {
+		panic("IGitMock.GetFileHistoryFunc: method is nil but IGit.GetFileHistory was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		File       string
+	}{
+		GitContext: gitContext,
+		File:       file,
+	}
+	mock.lockGetFileHistory.Lock()
+	mock.calls.GetFileHistory = append(mock.calls.GetFileHistory, callInfo)
+	mock.lockGetFileHistory.Unlock()
+	return mock.GetFileHistoryFunc(gitContext, file)
+}
+
+// GetFileHistoryCalls gets all the calls that were made to GetFileHistory.
+// Check the length with:
+//
+// 	len(mockedIGit.GetFileHistoryCalls())
+func (mock *IGitMock) GetFileHistoryCalls() []struct {
+	GitContext common_models.GitContext
+	File       string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		File       string
+	}
+	mock.lockGetFileHistory.RLock()
+	calls = mock.calls.GetFileHistory
+	mock.lockGetFileHistory.RUnlock()
+	return calls
+}
+
 // GetFileRevision calls GetFileRevisionFunc.
 func (mock *IGitMock) GetFileRevision(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
 	if mock.GetFileRevisionFunc == nil {
@@ -385,7 +718,8 @@ func (mock *IGitMock) GetFileRevision(gitContext common_models.GitContext, revis
 
 // GetFileRevisionCalls gets all the calls that were made to GetFileRevision.
 // Check the length with:
-//     len(mockedIGit.GetFileRevisionCalls())
+//
+// 	len(mockedIGit.GetFileRevisionCalls())
 func (mock *IGitMock) GetFileRevisionCalls() []struct {
 	GitContext common_models.GitContext
 	Revision   string
@@ -422,7 +756,8 @@ func (mock *IGitMock) MigrateProject(gitContext common_models.GitContext, newMet
 
 // MigrateProjectCalls gets all the calls that were made to MigrateProject.
 // Check the length with:
-//     len(mockedIGit.MigrateProjectCalls())
+//
+// 	len(mockedIGit.MigrateProjectCalls())
 func (mock *IGitMock) MigrateProjectCalls() []struct {
 	GitContext         common_models.GitContext
 	NewMetadatacontent []byte
@@ -455,7 +790,8 @@ func (mock *IGitMock) ProjectExists(gitContext common_models.GitContext) bool {
 
 // ProjectExistsCalls gets all the calls that were made to ProjectExists.
 // Check the length with:
-//     len(mockedIGit.ProjectExistsCalls())
+//
+// 	len(mockedIGit.ProjectExistsCalls())
 func (mock *IGitMock) ProjectExistsCalls() []struct {
 	GitContext common_models.GitContext
 } {
@@ -486,7 +822,8 @@ func (mock *IGitMock) ProjectRepoExists(projectName string) bool {
 
 // ProjectRepoExistsCalls gets all the calls that were made to ProjectRepoExists.
 // Check the length with:
-//     len(mockedIGit.ProjectRepoExistsCalls())
+//
+// 	len(mockedIGit.ProjectRepoExistsCalls())
 func (mock *IGitMock) ProjectRepoExistsCalls() []struct {
 	ProjectName string
 } {
@@ -517,7 +854,8 @@ func (mock *IGitMock) Pull(gitContext common_models.GitContext) error {
 
 // PullCalls gets all the calls that were made to Pull.
 // Check the length with:
-//     len(mockedIGit.PullCalls())
+//
+// 	len(mockedIGit.PullCalls())
 func (mock *IGitMock) PullCalls() []struct {
 	GitContext common_models.GitContext
 } {
@@ -548,7 +886,8 @@ func (mock *IGitMock) Push(gitContext common_models.GitContext) error {
 
 // PushCalls gets all the calls that were made to Push.
 // Check the length with:
-//     len(mockedIGit.PushCalls())
+//
+// 	len(mockedIGit.PushCalls())
 func (mock *IGitMock) PushCalls() []struct {
 	GitContext common_models.GitContext
 } {
@@ -561,6 +900,42 @@ func (mock *IGitMock) PushCalls() []struct {
 	return calls
 }
 
+// PushBranch calls PushBranchFunc.
+func (mock *IGitMock) PushBranch(gitContext common_models.GitContext, branch string) error {
+	if mock.PushBranchFunc == nil {
+		panic("IGitMock.PushBranchFunc: method is nil but IGit.PushBranch was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Branch     string
+	}{
+		GitContext: gitContext,
+		Branch:     branch,
+	}
+	mock.lockPushBranch.Lock()
+	mock.calls.PushBranch = append(mock.calls.PushBranch, callInfo)
+	mock.lockPushBranch.Unlock()
+	return mock.PushBranchFunc(gitContext, branch)
+}
+
+// PushBranchCalls gets all the calls that were made to PushBranch.
+// Check the length with:
+//
+// 	len(mockedIGit.PushBranchCalls())
+func (mock *IGitMock) PushBranchCalls() []struct {
+	GitContext common_models.GitContext
+	Branch     string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Branch     string
+	}
+	mock.lockPushBranch.RLock()
+	calls = mock.calls.PushBranch
+	mock.lockPushBranch.RUnlock()
+	return calls
+}
+
 // ResetHard calls ResetHardFunc.
 func (mock *IGitMock) ResetHard(gitContext common_models.GitContext, revision string) error {
 	if mock.ResetHardFunc == nil {
@@ -568,23 +943,28 @@ func (mock *IGitMock) ResetHard(gitContext common_models.GitContext, revision st
 	}
 	callInfo := struct {
 		GitContext common_models.GitContext
+		Revision   string
 	}{
 		GitContext: gitContext,
+		Revision:   revision,
 	}
 	mock.lockResetHard.Lock()
 	mock.calls.ResetHard = append(mock.calls.ResetHard, callInfo)
 	mock.lockResetHard.Unlock()
-	return mock.ResetHardFunc(gitContext)
+	return mock.ResetHardFunc(gitContext, revision)
 }
 
 // ResetHardCalls gets all the calls that were made to ResetHard.
 // Check the length with:
-//     len(mockedIGit.ResetHardCalls())
+//
+// 	len(mockedIGit.ResetHardCalls())
 func (mock *IGitMock) ResetHardCalls() []struct {
 	GitContext common_models.GitContext
+	Revision   string
 } {
 	var calls []struct {
 		GitContext common_models.GitContext
+		Revision   string
 	}
 	mock.lockResetHard.RLock()
 	calls = mock.calls.ResetHard
@@ -612,7 +992,8 @@ func (mock *IGitMock) StageAndCommitAll(gitContext common_models.GitContext, mes
 
 // StageAndCommitAllCalls gets all the calls that were made to StageAndCommitAll.
 // Check the length with:
-//     len(mockedIGit.StageAndCommitAllCalls())
+//
+// 	len(mockedIGit.StageAndCommitAllCalls())
 func (mock *IGitMock) StageAndCommitAllCalls() []struct {
 	GitContext common_models.GitContext
 	Message    string
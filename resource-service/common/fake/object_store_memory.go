@@ -0,0 +1,79 @@
+package common_mock
+
+import (
+	"strings"
+	"sync"
+
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+)
+
+// MemoryObjectStore is an in-memory implementation of common.IObjectStore
+type MemoryObjectStore struct {
+	mutex      sync.Mutex
+	Objects    map[string][]byte
+	References map[string]string
+}
+
+func NewMemoryObjectStore() *MemoryObjectStore {
+	return &MemoryObjectStore{
+		Objects:    map[string][]byte{},
+		References: map[string]string{},
+	}
+}
+
+func (m *MemoryObjectStore) GetObject(key string) ([]byte, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	content, ok := m.Objects[key]
+	if !ok {
+		return nil, kerrors.ErrObjectNotFound
+	}
+	return content, nil
+}
+
+func (m *MemoryObjectStore) PutObject(key string, content []byte) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.Objects[key] = content
+	return nil
+}
+
+func (m *MemoryObjectStore) GetReference(key string) (string, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	value, ok := m.References[key]
+	if !ok {
+		return "", kerrors.ErrObjectNotFound
+	}
+	return value, nil
+}
+
+func (m *MemoryObjectStore) UpdateReference(key string, previous string, value string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.References[key] != previous {
+		return kerrors.ErrObjectModified
+	}
+	if value == "" {
+		delete(m.References, key)
+	} else {
+		m.References[key] = value
+	}
+	return nil
+}
+
+func (m *MemoryObjectStore) DeleteAll(prefix string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for key := range m.Objects {
+		if strings.HasPrefix(key, prefix) {
+			delete(m.Objects, key)
+		}
+	}
+	for key := range m.References {
+		if strings.HasPrefix(key, prefix) {
+			delete(m.References, key)
+		}
+	}
+	return nil
+}
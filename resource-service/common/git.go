@@ -33,12 +33,19 @@ type IGit interface {
 	ProjectRepoExists(projectName string) bool
 	CloneRepo(gitContext common_models.GitContext) (bool, error)
 	StageAndCommitAll(gitContext common_models.GitContext, message string) (string, error)
+	CommitAll(gitContext common_models.GitContext, message string) (string, error)
 	Push(gitContext common_models.GitContext) error
+	PushBranch(gitContext common_models.GitContext, branch string) error
 	Pull(gitContext common_models.GitContext) error
 	CreateBranch(gitContext common_models.GitContext, branch string, sourceBranch string) error
 	CheckoutBranch(gitContext common_models.GitContext, branch string) error
+	DeleteBranch(gitContext common_models.GitContext, branch string) error
 	GetFileRevision(gitContext common_models.GitContext, revision string, file string) ([]byte, error)
+	GetFileHistory(gitContext common_models.GitContext, file string) ([]common_models.GitCommit, error)
+	GetFileDiff(gitContext common_models.GitContext, fromRevision string, toRevision string, file string) (string, error)
+	GetChangedFiles(gitContext common_models.GitContext, fromRevision string, toRevision string) ([]string, error)
 	GetCurrentRevision(gitContext common_models.GitContext) (string, error)
+	GetCurrentBranch(gitContext common_models.GitContext) (string, error)
 	GetDefaultBranch(gitContext common_models.GitContext) (string, error)
 	MigrateProject(gitContext common_models.GitContext, newMetadatacontent []byte) error
 	ResetHard(gitContext common_models.GitContext, revision string) error
@@ -250,6 +257,15 @@ func (g Git) commitAll(gitContext common_models.GitContext, message string) (str
 	return id.String(), err
 }
 
+// CommitAll stages and commits all changes of the working tree without pushing them to the upstream repository
+func (g Git) CommitAll(gitContext common_models.GitContext, message string) (string, error) {
+	id, err := g.commitAll(gitContext, message)
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotCommit, gitContext.Project, err)
+	}
+	return id, nil
+}
+
 func (g Git) StageAndCommitAll(gitContext common_models.GitContext, message string) (string, error) {
 
 	id, err := g.commitAll(gitContext, message)
@@ -312,6 +328,38 @@ func (g Git) Push(gitContext common_models.GitContext) error {
 	return nil
 }
 
+// PushBranch pushes the current HEAD to the given branch of the upstream repository, without changing the checked out branch
+func (g Git) PushBranch(gitContext common_models.GitContext, branch string) error {
+	if gitContext.Credentials == nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "push", gitContext.Project, kerrors.ErrCredentialsNotFound)
+	}
+	repo, _, err := g.getWorkTree(gitContext)
+	if err != nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "push", gitContext.Project, err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "push", gitContext.Project, err)
+	}
+	auth, err := getAuthMethod(gitContext)
+	if err != nil {
+		return err
+	}
+	err = repo.Push(&git.PushOptions{
+		RemoteName:      "origin",
+		RefSpecs:        []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:%s", head.Name(), plumbing.NewBranchReferenceName(branch)))},
+		Auth:            auth,
+		InsecureSkipTLS: gitContext.Credentials.InsecureSkipTLS,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		if errors.Is(err, git.ErrForceNeeded) {
+			return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "push", gitContext.Project, kerrors.ErrForceNeeded)
+		}
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "push", gitContext.Project, err)
+	}
+	return nil
+}
+
 func (g *Git) Pull(gitContext common_models.GitContext) error {
 	if g.ProjectExists(gitContext) {
 		r, w, err := g.getWorkTree(gitContext)
@@ -358,6 +406,19 @@ func (g *Git) GetCurrentRevision(gitContext common_models.GitContext) (string, e
 	return hash.String(), nil
 }
 
+// GetCurrentBranch returns the name of the checked out branch
+func (g *Git) GetCurrentBranch(gitContext common_models.GitContext) (string, error) {
+	r, _, err := g.getWorkTree(gitContext)
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "get current branch of", gitContext.Project, err)
+	}
+	ref, err := r.Head()
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "get current branch of", gitContext.Project, err)
+	}
+	return ref.Name().Short(), nil
+}
+
 // returns what is the current commit id of remote and if the remote is up-to-date with the local branch
 func (g *Git) getCurrentRemoteRevision(gitContext common_models.GitContext) (string, bool, error) {
 	repo, _, err := g.getWorkTree(gitContext)
@@ -437,6 +498,62 @@ func (g *Git) CreateBranch(gitContext common_models.GitContext, branch string, s
 	return nil
 }
 
+// DeleteBranch removes a branch from the local repository and from the upstream of the project.
+// If the branch is currently checked out, the default branch is checked out before
+func (g *Git) DeleteBranch(gitContext common_models.GitContext, branch string) error {
+	if gitContext.Credentials == nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotDelete, branch, gitContext.Project, kerrors.ErrCredentialsNotFound)
+	}
+	r, _, err := g.getWorkTree(gitContext)
+	if err != nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotDelete, branch, gitContext.Project, err)
+	}
+	if err := g.fetch(gitContext, r); err != nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotDelete, branch, gitContext.Project, err)
+	}
+
+	b := plumbing.NewBranchReferenceName(branch)
+	if _, err := r.Reference(b, false); err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return fmt.Errorf(kerrors.ErrMsgCouldNotDelete, branch, gitContext.Project, kerrors.ErrBranchNotFound)
+		}
+		return fmt.Errorf(kerrors.ErrMsgCouldNotDelete, branch, gitContext.Project, err)
+	}
+
+	head, err := r.Head()
+	if err == nil && head.Name() == b {
+		defaultBranch, err := g.GetDefaultBranch(gitContext)
+		if err != nil {
+			return fmt.Errorf(kerrors.ErrMsgCouldNotDelete, branch, gitContext.Project, err)
+		}
+		if err := g.CheckoutBranch(gitContext, defaultBranch); err != nil {
+			return fmt.Errorf(kerrors.ErrMsgCouldNotDelete, branch, gitContext.Project, err)
+		}
+	}
+
+	auth, err := getAuthMethod(gitContext)
+	if err != nil {
+		return err
+	}
+	err = r.Push(&git.PushOptions{
+		RemoteName:      "origin",
+		RefSpecs:        []config.RefSpec{config.RefSpec(":" + b.String())},
+		Auth:            auth,
+		InsecureSkipTLS: gitContext.Credentials.InsecureSkipTLS,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotDelete, branch, gitContext.Project, err)
+	}
+
+	if err := r.Storer.RemoveReference(b); err != nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotDelete, branch, gitContext.Project, err)
+	}
+	if err := r.DeleteBranch(branch); err != nil && !errors.Is(err, git.ErrBranchNotFound) {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotDelete, branch, gitContext.Project, err)
+	}
+	return nil
+}
+
 func (g *Git) CheckoutBranch(gitContext common_models.GitContext, branch string) error {
 	//  short path
 	b := plumbing.NewBranchReferenceName(branch)
@@ -542,6 +659,123 @@ func (g *Git) GetFileRevision(gitContext common_models.GitContext, revision stri
 	return ioutil.ReadAll(re)
 }
 
+// GetFileHistory returns the commits of the checked out branch that changed the given file, starting with the latest one
+func (g *Git) GetFileHistory(gitContext common_models.GitContext, file string) ([]common_models.GitCommit, error) {
+	r, err := g.git.PlainOpen(GetProjectConfigPath(gitContext.Project))
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "open", gitContext.Project, err)
+	}
+	head, err := r.Head()
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGetRevision, gitContext.Project, err)
+	}
+	commits, err := r.Log(&git.LogOptions{From: head.Hash(), FileName: &file})
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "read history of", gitContext.Project, err)
+	}
+	defer commits.Close()
+
+	history := []common_models.GitCommit{}
+	err = commits.ForEach(func(commit *object.Commit) error {
+		history = append(history, common_models.GitCommit{
+			ID:        commit.Hash.String(),
+			Author:    commit.Author.Name,
+			Message:   strings.TrimSpace(commit.Message),
+			Timestamp: commit.Author.When,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "read history of", gitContext.Project, err)
+	}
+	if len(history) == 0 {
+		return nil, kerrors.ErrResourceNotFound
+	}
+	return history, nil
+}
+
+// GetFileDiff returns the changes of the given file between two revisions as unified diff.
+// If the file has not been changed, an empty string is returned
+func (g *Git) GetFileDiff(gitContext common_models.GitContext, fromRevision string, toRevision string, file string) (string, error) {
+	r, err := g.git.PlainOpen(GetProjectConfigPath(gitContext.Project))
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "open", gitContext.Project, err)
+	}
+	fromTree, err := getRevisionTree(r, gitContext, fromRevision)
+	if err != nil {
+		return "", err
+	}
+	toTree, err := getRevisionTree(r, gitContext, toRevision)
+	if err != nil {
+		return "", err
+	}
+	_, fromErr := fromTree.File(file)
+	_, toErr := toTree.File(file)
+	if fromErr != nil && toErr != nil {
+		return "", kerrors.ErrResourceNotFound
+	}
+
+	changes, err := object.DiffTree(fromTree, toTree)
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "diff", gitContext.Project, err)
+	}
+	for _, change := range changes {
+		if change.From.Name != file && change.To.Name != file {
+			continue
+		}
+		patch, err := change.Patch()
+		if err != nil {
+			return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "diff", gitContext.Project, err)
+		}
+		return patch.String(), nil
+	}
+	return "", nil
+}
+
+// GetChangedFiles returns the files that have been added, modified or removed between the given revisions
+func (g *Git) GetChangedFiles(gitContext common_models.GitContext, fromRevision string, toRevision string) ([]string, error) {
+	r, err := g.git.PlainOpen(GetProjectConfigPath(gitContext.Project))
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "open", gitContext.Project, err)
+	}
+	fromTree, err := getRevisionTree(r, gitContext, fromRevision)
+	if err != nil {
+		return nil, err
+	}
+	toTree, err := getRevisionTree(r, gitContext, toRevision)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := object.DiffTree(fromTree, toTree)
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "diff", gitContext.Project, err)
+	}
+	files := []string{}
+	for _, change := range changes {
+		// renamed files are reported with both names
+		if change.From.Name != "" {
+			files = append(files, change.From.Name)
+		}
+		if change.To.Name != "" && change.To.Name != change.From.Name {
+			files = append(files, change.To.Name)
+		}
+	}
+	return files, nil
+}
+
+func getRevisionTree(r *git.Repository, gitContext common_models.GitContext, revision string) (*object.Tree, error) {
+	h, err := r.ResolveRevision(plumbing.Revision(revision))
+	if err != nil || h == nil {
+		logger.Debugf("Could not resolve revision %s: %v", revision, err)
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in", gitContext.Project, kerrors.ErrResolveRevision)
+	}
+	commit, err := r.CommitObject(*h)
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in", gitContext.Project, err)
+	}
+	return commit.Tree()
+}
+
 func (g *Git) GetDefaultBranch(gitContext common_models.GitContext) (string, error) {
 	r, _, err := g.getWorkTree(gitContext)
 	if err != nil {
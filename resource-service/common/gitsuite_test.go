@@ -355,6 +355,31 @@ func (s *BaseSuite) TestGit_StageAndCommitAll(c *C) {
 	}
 }
 
+func (s *BaseSuite) TestGit_CommitAll(c *C) {
+	g := NewGit(s.NewTestGit())
+	gitContext := s.NewGitContext()
+	r := s.Repository
+
+	w, err := r.Worktree()
+	c.Assert(err, IsNil)
+	err = write("foo/local.txt", "local content", c, w)
+	c.Assert(err, IsNil)
+
+	id, err := g.CommitAll(gitContext, "local commit")
+	c.Assert(err, IsNil)
+
+	head, err := r.Head()
+	c.Assert(err, IsNil)
+	c.Assert(head.Hash().String(), Equals, id)
+
+	// the commit must not be pushed to the remote repository
+	remoteRepo, err := git.Clone(memory.NewStorage(), memfs.New(), &git.CloneOptions{URL: s.url})
+	c.Assert(err, IsNil)
+	remoteHead, err := remoteRepo.Head()
+	c.Assert(err, IsNil)
+	c.Assert(remoteHead.Hash().String(), Not(Equals), id)
+}
+
 func (s *BaseSuite) checkCommit(c *C, r *git.Repository, id string) {
 	head, err := r.Head()
 	c.Assert(err, IsNil)
@@ -736,6 +761,35 @@ func (s *BaseSuite) TestGit_CreateBranch(c *C) {
 	}
 }
 
+func (s *BaseSuite) TestGit_DeleteBranch(c *C) {
+	g := NewGit(s.NewTestGit())
+	gitContext := s.NewGitContext()
+
+	err := g.CreateBranch(gitContext, "dev", "master")
+	c.Assert(err, IsNil)
+	_, err = g.StageAndCommitAll(gitContext, "created stage")
+	c.Assert(err, IsNil)
+
+	err = g.DeleteBranch(gitContext, "dev")
+	c.Assert(err, IsNil)
+
+	// the default branch is checked out instead of the deleted branch
+	head, err := s.Repository.Head()
+	c.Assert(err, IsNil)
+	c.Assert(head.Name(), Equals, plumbing.NewBranchReferenceName("master"))
+
+	_, err = s.Repository.Reference(plumbing.NewBranchReferenceName("dev"), false)
+	c.Assert(err, Equals, plumbing.ErrReferenceNotFound)
+
+	remote, err := git.PlainOpen(s.url)
+	c.Assert(err, IsNil)
+	_, err = remote.Reference(plumbing.NewBranchReferenceName("dev"), false)
+	c.Assert(err, Equals, plumbing.ErrReferenceNotFound)
+
+	err = g.DeleteBranch(gitContext, "dev")
+	c.Assert(errors.Is(err, kerrors.ErrBranchNotFound), Equals, true)
+}
+
 func (s *BaseSuite) TestGit_CheckoutBranch(c *C) {
 
 	tests := []struct {
@@ -838,6 +892,150 @@ func (s *BaseSuite) TestGit_GetFileRevision(c *C) {
 	}
 }
 
+func (s *BaseSuite) TestGit_GetFileHistory(c *C) {
+	g := NewGit(s.NewTestGit())
+	gitContext := s.NewGitContext()
+
+	first := s.commitAndPush("foo/history.yaml", "first", c)
+	s.commitAndPush("foo/other.yaml", "other", c)
+	second := s.commitAndPush("foo/history.yaml", "second", c)
+
+	got, err := g.GetFileHistory(gitContext, "foo/history.yaml")
+	c.Assert(err, IsNil)
+	c.Assert(got, HasLen, 2)
+	c.Assert(got[0].ID, Equals, second.String())
+	c.Assert(got[0].Author, Equals, "Test Create Branch")
+	c.Assert(got[0].Message, Equals, "added a file")
+	c.Assert(got[1].ID, Equals, first.String())
+
+	_, err = g.GetFileHistory(gitContext, "foo/unknown.yaml")
+	c.Assert(errors.Is(err, kerrors.ErrResourceNotFound), Equals, true)
+}
+
+func (s *BaseSuite) TestGit_GetFileDiff(c *C) {
+	g := NewGit(s.NewTestGit())
+	gitContext := s.NewGitContext()
+
+	first := s.commitAndPush("foo/diff.yaml", "a\nb\n", c)
+	s.commitAndPush("foo/other.yaml", "other", c)
+	second := s.commitAndPush("foo/diff.yaml", "a\nc\n", c)
+
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		file    string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "changed file",
+			from: first.String(),
+			to:   second.String(),
+			file: "foo/diff.yaml",
+			want: "--- a/foo/diff.yaml\n+++ b/foo/diff.yaml\n@@ -1,2 +1,2 @@\n a\n-b\n+c\n",
+		},
+		{
+			name: "unchanged file",
+			from: first.String(),
+			to:   first.String(),
+			file: "foo/diff.yaml",
+			want: "",
+		},
+		{
+			name:    "unknown file",
+			from:    first.String(),
+			to:      second.String(),
+			file:    "foo/unknown.yaml",
+			wantErr: kerrors.ErrResourceNotFound,
+		},
+		{
+			name:    "unknown revision",
+			from:    "ciaoWrongId",
+			to:      second.String(),
+			file:    "foo/diff.yaml",
+			wantErr: kerrors.ErrResolveRevision,
+		},
+	}
+	for _, tt := range tests {
+		c.Log("Test : " + tt.name)
+		got, err := g.GetFileDiff(gitContext, tt.from, tt.to, tt.file)
+		if tt.wantErr != nil {
+			c.Assert(errors.Is(err, tt.wantErr), Equals, true)
+			continue
+		}
+		c.Assert(err, IsNil)
+		c.Assert(strings.Contains(got, tt.want), Equals, true)
+	}
+}
+
+func (s *BaseSuite) TestGit_GetChangedFiles(c *C) {
+	g := NewGit(s.NewTestGit())
+	gitContext := s.NewGitContext()
+
+	first := s.commitAndPush("foo/changed.yaml", "a", c)
+	s.commitAndPush("foo/added.yaml", "b", c)
+	second := s.commitAndPush("foo/changed.yaml", "c", c)
+
+	got, err := g.GetChangedFiles(gitContext, first.String(), second.String())
+	c.Assert(err, IsNil)
+	c.Assert(got, DeepEquals, []string{"foo/added.yaml", "foo/changed.yaml"})
+
+	got, err = g.GetChangedFiles(gitContext, second.String(), second.String())
+	c.Assert(err, IsNil)
+	c.Assert(got, HasLen, 0)
+
+	_, err = g.GetChangedFiles(gitContext, "ciaoWrongId", second.String())
+	c.Assert(errors.Is(err, kerrors.ErrResolveRevision), Equals, true)
+}
+
+func (s *BaseSuite) TestGit_GetCurrentBranch(c *C) {
+	g := NewGit(GogitReal{})
+	gitContext := s.NewGitContext()
+
+	got, err := g.GetCurrentBranch(gitContext)
+	c.Assert(err, IsNil)
+	c.Assert(got, Equals, "master")
+
+	err = g.CreateBranch(gitContext, "dev", "master")
+	c.Assert(err, IsNil)
+
+	got, err = g.GetCurrentBranch(gitContext)
+	c.Assert(err, IsNil)
+	c.Assert(got, Equals, "dev")
+}
+
+func (s *BaseSuite) TestGit_PushBranch(c *C) {
+	g := NewGit(GogitReal{})
+	gitContext := s.NewGitContext()
+
+	w, err := s.Repository.Worktree()
+	c.Assert(err, IsNil)
+	err = write("foo/file.txt", "a content", c, w)
+	c.Assert(err, IsNil)
+	h := commit("foo/file.txt", c, w)
+
+	err = g.PushBranch(gitContext, "keptn/master-change")
+	c.Assert(err, IsNil)
+
+	remote, err := git.PlainOpen(s.url)
+	c.Assert(err, IsNil)
+	ref, err := remote.Reference(plumbing.NewBranchReferenceName("keptn/master-change"), true)
+	c.Assert(err, IsNil)
+	c.Assert(ref.Hash(), Equals, h)
+
+	// the pushed branch is not checked out, and the stage branch remains unchanged
+	branch, err := g.GetCurrentBranch(gitContext)
+	c.Assert(err, IsNil)
+	c.Assert(branch, Equals, "master")
+	ref, err = remote.Reference(plumbing.NewBranchReferenceName("master"), true)
+	c.Assert(err, IsNil)
+	c.Assert(ref.Hash(), Not(Equals), h)
+
+	err = g.PushBranch(common_models.GitContext{Project: "sockshop"}, "keptn/master-change")
+	c.Assert(errors.Is(err, kerrors.ErrCredentialsNotFound), Equals, true)
+}
+
 func (s *BaseSuite) TestGit_MigrateProject(c *C) {
 	g := NewGit(GogitReal{})
 
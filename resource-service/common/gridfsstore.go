@@ -0,0 +1,160 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"regexp"
+	"time"
+
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const gridFSRequestTimeout = 30 * time.Second
+
+// gridFSReference is a reference of a GridFSObjectStore, stored in a separate collection next to the GridFS bucket
+type gridFSReference struct {
+	Key   string `bson:"_id"`
+	Value string `bson:"value"`
+}
+
+// GridFSObjectStore implements IObjectStore on top of a GridFS bucket of MongoDB.
+// Objects are stored as files whose ID is their key, while references are stored as documents of the collection <bucket>.refs,
+// which are only updated if they still contain the expected value
+type GridFSObjectStore struct {
+	database   *mongo.Database
+	bucketName string
+	references *mongo.Collection
+}
+
+func NewGridFSObjectStore(database *mongo.Database, bucketName string) *GridFSObjectStore {
+	return &GridFSObjectStore{
+		database:   database,
+		bucketName: bucketName,
+		references: database.Collection(bucketName + ".refs"),
+	}
+}
+
+func (s *GridFSObjectStore) GetObject(key string) ([]byte, error) {
+	bucket, err := s.getBucket()
+	if err != nil {
+		return nil, err
+	}
+	content := &bytes.Buffer{}
+	if _, err := bucket.DownloadToStream(key, content); err != nil {
+		if errors.Is(err, gridfs.ErrFileNotFound) {
+			return nil, kerrors.ErrObjectNotFound
+		}
+		return nil, err
+	}
+	return content.Bytes(), nil
+}
+
+func (s *GridFSObjectStore) PutObject(key string, content []byte) error {
+	bucket, err := s.getBucket()
+	if err != nil {
+		return err
+	}
+	err = bucket.UploadFromStreamWithID(key, key, bytes.NewReader(content))
+	if mongo.IsDuplicateKeyError(err) {
+		// objects are addressed by their content, so an existing object already has the same content
+		return nil
+	}
+	return err
+}
+
+func (s *GridFSObjectStore) GetReference(key string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gridFSRequestTimeout)
+	defer cancel()
+
+	reference := &gridFSReference{}
+	if err := s.references.FindOne(ctx, bson.M{"_id": key}).Decode(reference); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return "", kerrors.ErrObjectNotFound
+		}
+		return "", err
+	}
+	return reference.Value, nil
+}
+
+func (s *GridFSObjectStore) UpdateReference(key string, previous string, value string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), gridFSRequestTimeout)
+	defer cancel()
+
+	switch {
+	case previous == "" && value == "":
+		return nil
+	case previous == "":
+		_, err := s.references.InsertOne(ctx, gridFSReference{Key: key, Value: value})
+		if mongo.IsDuplicateKeyError(err) {
+			return kerrors.ErrObjectModified
+		}
+		return err
+	case value == "":
+		result, err := s.references.DeleteOne(ctx, bson.M{"_id": key, "value": previous})
+		if err != nil {
+			return err
+		}
+		if result.DeletedCount == 0 {
+			return kerrors.ErrObjectModified
+		}
+		return nil
+	default:
+		result, err := s.references.UpdateOne(ctx, bson.M{"_id": key, "value": previous}, bson.M{"$set": bson.M{"value": value}})
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			return kerrors.ErrObjectModified
+		}
+		return nil
+	}
+}
+
+func (s *GridFSObjectStore) DeleteAll(prefix string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), gridFSRequestTimeout)
+	defer cancel()
+
+	filter := bson.M{"_id": bson.M{"$regex": "^" + regexp.QuoteMeta(prefix)}}
+	bucket, err := s.getBucket()
+	if err != nil {
+		return err
+	}
+	cursor, err := bucket.Find(filter)
+	if err != nil {
+		return err
+	}
+	files := []gridfs.File{}
+	if err := cursor.All(ctx, &files); err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := bucket.Delete(file.ID); err != nil && !errors.Is(err, gridfs.ErrFileNotFound) {
+			return err
+		}
+	}
+
+	_, err = s.references.DeleteMany(ctx, filter)
+	return err
+}
+
+// getBucket returns a bucket whose operations are limited by gridFSRequestTimeout. Since the deadlines are kept by the bucket,
+// a new one is used for each operation
+func (s *GridFSObjectStore) getBucket() (*gridfs.Bucket, error) {
+	bucket, err := gridfs.NewBucket(s.database, options.GridFSBucket().SetName(s.bucketName))
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(gridFSRequestTimeout)
+	if err := bucket.SetReadDeadline(deadline); err != nil {
+		return nil, err
+	}
+	if err := bucket.SetWriteDeadline(deadline); err != nil {
+		return nil, err
+	}
+	return bucket, nil
+}
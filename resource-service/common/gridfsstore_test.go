@@ -0,0 +1,74 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// newTestGridFSObjectStore connects to the MongoDB instance given by MONGODB_TEST_URI, e.g. a local one started with:
+// docker run -p 27017:27017 mongo
+func newTestGridFSObjectStore(t *testing.T) *GridFSObjectStore {
+	uri := os.Getenv("MONGODB_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGODB_TEST_URI is not set")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		_ = client.Disconnect(context.Background())
+	})
+	return NewGridFSObjectStore(client.Database("keptn-test"), fmt.Sprintf("resources-%d", time.Now().UnixNano()))
+}
+
+func TestGridFSObjectStore_Objects(t *testing.T) {
+	store := newTestGridFSObjectStore(t)
+
+	_, err := store.GetObject("my-project/objects/abc")
+	require.ErrorIs(t, err, kerrors.ErrObjectNotFound)
+
+	require.Nil(t, store.PutObject("my-project/objects/abc", []byte("content")))
+	require.Nil(t, store.PutObject("my-project/objects/abc", []byte("content")))
+
+	content, err := store.GetObject("my-project/objects/abc")
+	require.Nil(t, err)
+	require.Equal(t, []byte("content"), content)
+
+	require.Nil(t, store.DeleteAll("my-project/"))
+	_, err = store.GetObject("my-project/objects/abc")
+	require.ErrorIs(t, err, kerrors.ErrObjectNotFound)
+}
+
+func TestGridFSObjectStore_UpdateReference(t *testing.T) {
+	store := newTestGridFSObjectStore(t)
+
+	_, err := store.GetReference("my-project/refs/project")
+	require.ErrorIs(t, err, kerrors.ErrObjectNotFound)
+
+	require.Nil(t, store.UpdateReference("my-project/refs/project", "", "first"))
+	require.ErrorIs(t, store.UpdateReference("my-project/refs/project", "", "second"), kerrors.ErrObjectModified)
+	require.ErrorIs(t, store.UpdateReference("my-project/refs/project", "unknown", "second"), kerrors.ErrObjectModified)
+	require.Nil(t, store.UpdateReference("my-project/refs/project", "first", "second"))
+
+	value, err := store.GetReference("my-project/refs/project")
+	require.Nil(t, err)
+	require.Equal(t, "second", value)
+
+	require.ErrorIs(t, store.UpdateReference("my-project/refs/project", "first", ""), kerrors.ErrObjectModified)
+	require.Nil(t, store.UpdateReference("my-project/refs/project", "second", ""))
+	_, err = store.GetReference("my-project/refs/project")
+	require.ErrorIs(t, err, kerrors.ErrObjectNotFound)
+}
+
+func TestGridFSObjectStore_ConfigurationStore(t *testing.T) {
+	testConfigurationStoreWithObjectStore(t, newTestGridFSObjectStore(t))
+}
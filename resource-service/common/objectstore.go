@@ -0,0 +1,29 @@
+package common
+
+// StorageBackendGit keeps the resources of a project in a local clone of its upstream git repository
+const StorageBackendGit = "git"
+
+// StorageBackendS3 keeps the resources of a project in an S3 compatible object store
+const StorageBackendS3 = "s3"
+
+// StorageBackendGridFS keeps the resources of a project in a GridFS bucket of MongoDB
+const StorageBackendGridFS = "gridfs"
+
+// IObjectStore is used by a ConfigurationStore to keep the objects and references of its projects.
+// Objects are addressed by their content and never change once they have been written, whereas references point to the current commit
+// of a project or stage and can only be changed if they still have the expected value. This allows multiple replicas of the
+// resource-service to share the same object store without any local state.
+type IObjectStore interface {
+	// GetObject returns the content of the object with the given key, or errors.ErrObjectNotFound
+	GetObject(key string) ([]byte, error)
+	// PutObject stores the object with the given key. As objects are addressed by their content, an existing object does not have to be replaced
+	PutObject(key string, content []byte) error
+	// GetReference returns the value of the reference with the given key, or errors.ErrObjectNotFound
+	GetReference(key string) (string, error)
+	// UpdateReference sets the reference with the given key to value, if its current value is still equal to previous.
+	// An empty previous value requires that the reference does not exist yet, and an empty value deletes the reference.
+	// If the reference has been changed in the meantime, errors.ErrObjectModified is returned
+	UpdateReference(key string, previous string, value string) error
+	// DeleteAll removes all objects and references whose key starts with the given prefix
+	DeleteAll(prefix string) error
+}
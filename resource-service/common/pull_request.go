@@ -0,0 +1,131 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+)
+
+const (
+	GitProviderGitHub    = "github"
+	GitProviderGitLab    = "gitlab"
+	GitProviderGitea     = "gitea"
+	GitProviderBitbucket = "bitbucket"
+
+	pullRequestTimeout = 30 * time.Second
+	githubHost         = "github.com"
+	githubAPIURL       = "https://api.github.com"
+)
+
+// PullRequestClient opens pull requests (merge requests in GitLab) in the upstream repository of a project and returns their URL
+//
+//go:generate moq -pkg common_mock -skip-ensure -out ./fake/pull_request_client_mock.go . PullRequestClient
+type PullRequestClient interface {
+	CreatePullRequest(provider string, credentials common_models.GitCredentials, pullRequest common_models.PullRequest) (string, error)
+}
+
+// ForgeClient implements PullRequestClient with the REST APIs of GitHub, GitLab and Gitea.
+// The API is derived from the remote URI of the repository and authenticated with the token of the git credentials,
+// e.g. https://github.com/owner/repo.git is accessed via https://api.github.com/repos/owner/repo
+type ForgeClient struct {
+	httpClient *http.Client
+}
+
+func NewForgeClient(httpClient *http.Client) *ForgeClient {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: pullRequestTimeout}
+	}
+	return &ForgeClient{httpClient: httpClient}
+}
+
+func (f *ForgeClient) CreatePullRequest(provider string, credentials common_models.GitCredentials, pullRequest common_models.PullRequest) (string, error) {
+	if credentials.Token == "" {
+		return "", kerrors.ErrPullRequestNotSupported
+	}
+	remote, err := url.Parse(credentials.RemoteURI)
+	if err != nil || (remote.Scheme != "https" && remote.Scheme != "http") {
+		return "", kerrors.ErrPullRequestNotSupported
+	}
+	repository := strings.TrimSuffix(strings.Trim(remote.Path, "/"), ".git")
+	baseURL := remote.Scheme + "://" + remote.Host
+
+	switch provider {
+	case GitProviderGitHub:
+		apiURL := baseURL + "/api/v3"
+		if remote.Host == githubHost {
+			apiURL = githubAPIURL
+		}
+		payload := map[string]string{
+			"title": pullRequest.Title,
+			"body":  pullRequest.Description,
+			"head":  pullRequest.SourceBranch,
+			"base":  pullRequest.TargetBranch,
+		}
+		header := http.Header{"Authorization": []string{"token " + credentials.Token}, "Accept": []string{"application/vnd.github+json"}}
+		return f.post(apiURL+"/repos/"+repository+"/pulls", header, payload, "html_url")
+	case GitProviderGitLab:
+		payload := map[string]string{
+			"title":         pullRequest.Title,
+			"description":   pullRequest.Description,
+			"source_branch": pullRequest.SourceBranch,
+			"target_branch": pullRequest.TargetBranch,
+		}
+		header := http.Header{"Private-Token": []string{credentials.Token}}
+		return f.post(baseURL+"/api/v4/projects/"+url.PathEscape(repository)+"/merge_requests", header, payload, "web_url")
+	case GitProviderGitea:
+		payload := map[string]string{
+			"title": pullRequest.Title,
+			"body":  pullRequest.Description,
+			"head":  pullRequest.SourceBranch,
+			"base":  pullRequest.TargetBranch,
+		}
+		header := http.Header{"Authorization": []string{"token " + credentials.Token}}
+		return f.post(baseURL+"/api/v1/repos/"+repository+"/pulls", header, payload, "html_url")
+	}
+	return "", fmt.Errorf("%w: %s", kerrors.ErrUnsupportedPullRequestProvider, provider)
+}
+
+// post sends the payload to the API of the provider and returns the URL contained in the given field of the response
+func (f *ForgeClient) post(apiURL string, header http.Header, payload map[string]string, urlField string) (string, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequest(http.MethodPost, apiURL, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", kerrors.ErrCouldNotCreatePullRequest, err)
+	}
+	req.Header = header
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", kerrors.ErrCouldNotCreatePullRequest, err)
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", kerrors.ErrCouldNotCreatePullRequest, err)
+	}
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: %s returned %d: %s", kerrors.ErrCouldNotCreatePullRequest, apiURL, resp.StatusCode, string(content))
+	}
+	result := map[string]interface{}{}
+	if err := json.Unmarshal(content, &result); err != nil {
+		return "", fmt.Errorf("%w: %v", kerrors.ErrCouldNotCreatePullRequest, err)
+	}
+	pullRequestURL, ok := result[urlField].(string)
+	if !ok || pullRequestURL == "" {
+		return "", fmt.Errorf("%w: response of %s does not contain %s", kerrors.ErrCouldNotCreatePullRequest, apiURL, urlField)
+	}
+	return pullRequestURL, nil
+}
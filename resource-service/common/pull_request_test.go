@@ -0,0 +1,146 @@
+package common
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeForgeServer implements the endpoints of GitHub, GitLab and Gitea that are used to open pull requests
+type fakeForgeServer struct {
+	*httptest.Server
+	requests []fakeForgeRequest
+}
+
+type fakeForgeRequest struct {
+	Path    string
+	Header  http.Header
+	Payload map[string]string
+}
+
+func newFakeForgeServer(t *testing.T) *fakeForgeServer {
+	f := &fakeForgeServer{}
+	mux := http.NewServeMux()
+	handle := func(path string, response string) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			payload := map[string]string{}
+			require.Nil(t, json.NewDecoder(r.Body).Decode(&payload))
+			f.requests = append(f.requests, fakeForgeRequest{Path: r.URL.EscapedPath(), Header: r.Header, Payload: payload})
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(response))
+		})
+	}
+	handle("/api/v3/repos/keptn/sockshop/pulls", `{"number": 1, "html_url": "https://github.example.com/keptn/sockshop/pull/1"}`)
+	// the mux matches the decoded path, while GitLab requires the project path to be encoded as a single segment
+	handle("/api/v4/projects/keptn/sockshop/merge_requests", `{"iid": 1, "web_url": "https://gitlab.example.com/keptn/sockshop/-/merge_requests/1"}`)
+	handle("/api/v1/repos/keptn/sockshop/pulls", `{"number": 1, "html_url": "https://gitea.example.com/keptn/sockshop/pulls/1"}`)
+	mux.HandleFunc("/api/v1/repos/keptn/protected/pulls", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "forbidden"}`))
+	})
+	f.Server = httptest.NewServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+func TestForgeClient_CreatePullRequest(t *testing.T) {
+	pullRequest := common_models.PullRequest{
+		Title:        "Updated resource",
+		Description:  "Changes of stage dev",
+		SourceBranch: "keptn/dev/0123abcd",
+		TargetBranch: "dev",
+	}
+	tests := []struct {
+		name        string
+		provider    string
+		wantURL     string
+		wantPath    string
+		wantHeader  map[string]string
+		wantPayload map[string]string
+	}{
+		{
+			name:       "github",
+			provider:   GitProviderGitHub,
+			wantURL:    "https://github.example.com/keptn/sockshop/pull/1",
+			wantPath:   "/api/v3/repos/keptn/sockshop/pulls",
+			wantHeader: map[string]string{"Authorization": "token my-token"},
+			wantPayload: map[string]string{
+				"title": "Updated resource",
+				"body":  "Changes of stage dev",
+				"head":  "keptn/dev/0123abcd",
+				"base":  "dev",
+			},
+		},
+		{
+			name:       "gitlab",
+			provider:   GitProviderGitLab,
+			wantURL:    "https://gitlab.example.com/keptn/sockshop/-/merge_requests/1",
+			wantPath:   "/api/v4/projects/keptn%2Fsockshop/merge_requests",
+			wantHeader: map[string]string{"PRIVATE-TOKEN": "my-token"},
+			wantPayload: map[string]string{
+				"title":         "Updated resource",
+				"description":   "Changes of stage dev",
+				"source_branch": "keptn/dev/0123abcd",
+				"target_branch": "dev",
+			},
+		},
+		{
+			name:       "gitea",
+			provider:   GitProviderGitea,
+			wantURL:    "https://gitea.example.com/keptn/sockshop/pulls/1",
+			wantPath:   "/api/v1/repos/keptn/sockshop/pulls",
+			wantHeader: map[string]string{"Authorization": "token my-token"},
+			wantPayload: map[string]string{
+				"title": "Updated resource",
+				"body":  "Changes of stage dev",
+				"head":  "keptn/dev/0123abcd",
+				"base":  "dev",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeForgeServer(t)
+			client := NewForgeClient(server.Client())
+			credentials := common_models.GitCredentials{User: "keptn", Token: "my-token", RemoteURI: server.URL + "/keptn/sockshop.git"}
+
+			got, err := client.CreatePullRequest(tt.provider, credentials, pullRequest)
+			require.Nil(t, err)
+			require.Equal(t, tt.wantURL, got)
+
+			require.Len(t, server.requests, 1)
+			require.Equal(t, tt.wantPath, server.requests[0].Path)
+			for key, value := range tt.wantHeader {
+				require.Equal(t, value, server.requests[0].Header.Get(key))
+			}
+			require.Equal(t, tt.wantPayload, server.requests[0].Payload)
+		})
+	}
+}
+
+func TestForgeClient_CreatePullRequest_Errors(t *testing.T) {
+	server := newFakeForgeServer(t)
+	client := NewForgeClient(server.Client())
+	pullRequest := common_models.PullRequest{Title: "Updated resource", SourceBranch: "keptn/dev/0123abcd", TargetBranch: "dev"}
+
+	_, err := client.CreatePullRequest(GitProviderGitea, common_models.GitCredentials{Token: "my-token", RemoteURI: server.URL + "/keptn/protected.git"}, pullRequest)
+	require.ErrorIs(t, err, kerrors.ErrCouldNotCreatePullRequest)
+
+	_, err = client.CreatePullRequest(GitProviderBitbucket, common_models.GitCredentials{Token: "my-token", RemoteURI: server.URL + "/keptn/sockshop.git"}, pullRequest)
+	require.ErrorIs(t, err, kerrors.ErrUnsupportedPullRequestProvider)
+
+	_, err = client.CreatePullRequest(GitProviderGitHub, common_models.GitCredentials{RemoteURI: server.URL + "/keptn/sockshop.git"}, pullRequest)
+	require.ErrorIs(t, err, kerrors.ErrPullRequestNotSupported)
+
+	_, err = client.CreatePullRequest(GitProviderGitHub, common_models.GitCredentials{Token: "my-token", RemoteURI: "ssh://git@github.com/keptn/sockshop.git"}, pullRequest)
+	require.ErrorIs(t, err, kerrors.ErrPullRequestNotSupported)
+
+	require.Empty(t, server.requests)
+}
@@ -0,0 +1,342 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+)
+
+const (
+	s3RequestTimeout   = 30 * time.Second
+	s3SigningAlgorithm = "AWS4-HMAC-SHA256"
+	s3DateFormat       = "20060102T150405Z"
+	s3ContentHeader    = "X-Amz-Content-Sha256"
+	s3DateHeader       = "X-Amz-Date"
+)
+
+// S3Config contains the connection parameters of an S3 compatible object store
+type S3Config struct {
+	// Endpoint is the base URL of the object store, e.g. https://s3.eu-central-1.amazonaws.com or http://minio:9000
+	Endpoint        string
+	Bucket          string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// S3ObjectStore implements IObjectStore on top of an S3 compatible object store, such as AWS S3 or MinIO.
+// Since objects are never overwritten and every commit keeps a reference to its parent, all previous revisions of the resources
+// remain available without enabling versioning on the bucket.
+// References are updated with conditional requests (If-Match/If-None-Match), which have to be supported by the object store
+type S3ObjectStore struct {
+	config     S3Config
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+func NewS3ObjectStore(config S3Config, httpClient *http.Client) *S3ObjectStore {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: s3RequestTimeout}
+	}
+	config.Endpoint = strings.TrimSuffix(config.Endpoint, "/")
+	return &S3ObjectStore{
+		config:     config,
+		httpClient: httpClient,
+		now:        time.Now,
+	}
+}
+
+func (s *S3ObjectStore) GetObject(key string) ([]byte, error) {
+	content, _, err := s.get(key)
+	return content, err
+}
+
+func (s *S3ObjectStore) PutObject(key string, content []byte) error {
+	resp, err := s.do(http.MethodPut, key, nil, content, nil)
+	if err != nil {
+		return err
+	}
+	return s.checkResponse(resp, http.MethodPut, key)
+}
+
+func (s *S3ObjectStore) GetReference(key string) (string, error) {
+	content, _, err := s.get(key)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+func (s *S3ObjectStore) UpdateReference(key string, previous string, value string) error {
+	content, etag, err := s.get(key)
+	if err != nil && !errors.Is(err, kerrors.ErrObjectNotFound) {
+		return err
+	}
+	if string(content) != previous {
+		return kerrors.ErrObjectModified
+	}
+
+	header := http.Header{}
+	if value == "" {
+		if etag == "" {
+			return nil
+		}
+		// conditional deletes are not supported by all object stores, so references are deleted unconditionally
+		resp, err := s.do(http.MethodDelete, key, nil, nil, nil)
+		if err != nil {
+			return err
+		}
+		return s.checkResponse(resp, http.MethodDelete, key)
+	}
+	if etag == "" {
+		header.Set("If-None-Match", "*")
+	} else {
+		header.Set("If-Match", etag)
+	}
+	resp, err := s.do(http.MethodPut, key, nil, []byte(value), header)
+	if err != nil {
+		return err
+	}
+	return s.checkResponse(resp, http.MethodPut, key)
+}
+
+func (s *S3ObjectStore) DeleteAll(prefix string) error {
+	continuationToken := ""
+	for {
+		result, err := s.list(prefix, continuationToken)
+		if err != nil {
+			return err
+		}
+		for _, object := range result.Contents {
+			resp, err := s.do(http.MethodDelete, object.Key, nil, nil, nil)
+			if err != nil {
+				return err
+			}
+			if err := s.checkResponse(resp, http.MethodDelete, object.Key); err != nil && !errors.Is(err, kerrors.ErrObjectNotFound) {
+				return err
+			}
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return nil
+		}
+		continuationToken = result.NextContinuationToken
+	}
+}
+
+// s3ListResult is the part of the ListObjectsV2 response that is used by the S3ObjectStore
+type s3ListResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (s *S3ObjectStore) list(prefix string, continuationToken string) (*s3ListResult, error) {
+	query := url.Values{}
+	query.Set("list-type", "2")
+	query.Set("prefix", prefix)
+	if continuationToken != "" {
+		query.Set("continuation-token", continuationToken)
+	}
+	resp, err := s.do(http.MethodGet, "", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, s.responseError(resp, http.MethodGet, prefix)
+	}
+	result := &s3ListResult{}
+	if err := xml.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, fmt.Errorf("could not decode list of objects with prefix %s: %w", prefix, err)
+	}
+	return result, nil
+}
+
+func (s *S3ObjectStore) get(key string) ([]byte, string, error) {
+	resp, err := s.do(http.MethodGet, key, nil, nil, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", s.responseError(resp, http.MethodGet, key)
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	return content, resp.Header.Get("ETag"), nil
+}
+
+func (s *S3ObjectStore) do(method string, key string, query url.Values, body []byte, header http.Header) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s3RequestTimeout)
+	requestURL, err := url.Parse(s.config.Endpoint)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	requestURL.Path = strings.TrimSuffix(requestURL.Path, "/") + "/" + s.config.Bucket + "/" + key
+	requestURL.RawPath = s3EncodePath(requestURL.Path)
+	requestURL.RawQuery = s3EncodeQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL.String(), bytes.NewReader(body))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	s.sign(req, body)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+func (s *S3ObjectStore) checkResponse(resp *http.Response, method string, key string) error {
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	return s.responseError(resp, method, key)
+}
+
+func (s *S3ObjectStore) responseError(resp *http.Response, method string, key string) error {
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return kerrors.ErrObjectNotFound
+	case http.StatusPreconditionFailed, http.StatusConflict:
+		return kerrors.ErrObjectModified
+	}
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("unexpected response status %d for %s of object %s: %s", resp.StatusCode, method, key, strings.TrimSpace(string(message)))
+}
+
+// sign adds the headers of the AWS signature version 4 to the request
+func (s *S3ObjectStore) sign(req *http.Request, body []byte) {
+	now := s.now().UTC()
+	payloadHash := sha256Hex(body)
+	req.Header.Set(s3DateHeader, now.Format(s3DateFormat))
+	req.Header.Set(s3ContentHeader, payloadHash)
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	canonicalHeaders := strings.Builder{}
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3EncodePath(req.URL.Path),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	date := now.Format("20060102")
+	scope := date + "/" + s.config.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		s3SigningAlgorithm,
+		now.Format(s3DateFormat),
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.config.SecretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, s.config.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3SigningAlgorithm, s.config.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// cancelOnClose releases the context of a request once its response body has been closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
+// s3Encode encodes all characters except the unreserved characters of RFC 3986, as required by the AWS signature
+func s3Encode(value string, encodeSlash bool) string {
+	encoded := strings.Builder{}
+	for _, b := range []byte(value) {
+		if (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') ||
+			b == '-' || b == '_' || b == '.' || b == '~' || (b == '/' && !encodeSlash) {
+			encoded.WriteByte(b)
+		} else {
+			encoded.WriteString(fmt.Sprintf("%%%02X", b))
+		}
+	}
+	return encoded.String()
+}
+
+func s3EncodePath(path string) string {
+	return s3Encode(path, false)
+}
+
+func s3EncodeQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parameters := []string{}
+	for _, key := range keys {
+		for _, value := range query[key] {
+			parameters = append(parameters, s3Encode(key, true)+"="+s3Encode(value, true))
+		}
+	}
+	return strings.Join(parameters, "&")
+}
+
+func sha256Hex(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, value string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
@@ -3,6 +3,7 @@ package common_models
 import (
 	"net/url"
 	"strings"
+	"time"
 
 	kerrors "github.com/keptn/keptn/resource-service/errors"
 )
@@ -19,6 +20,9 @@ type GitCredentials struct {
 	GitProxyUser      string `json:"gitProxyUser,omitempty"`
 	GitProxyPassword  string `json:"gitProxyPassword,omitempty"`
 	GitPemCertificate string `json:"gitPemCertificate,omitempty"`
+	// PullRequestProvider is the git provider of the upstream (github, gitlab or gitea). If it is set,
+	// changes are proposed via pull requests instead of being pushed to the upstream directly
+	PullRequestProvider string `json:"pullRequestProvider,omitempty"`
 	// omitempty property is missing due to fallback of this
 	// parameter to "undefined" when marshalling/unmarshalling data
 	// when "false" value is present
@@ -30,6 +34,22 @@ type GitContext struct {
 	Credentials *GitCredentials
 }
 
+// GitCommit contains the metadata of a commit
+type GitCommit struct {
+	ID        string
+	Author    string
+	Message   string
+	Timestamp time.Time
+}
+
+// PullRequest describes a change of the source branch that is proposed for the target branch
+type PullRequest struct {
+	Title        string
+	Description  string
+	SourceBranch string
+	TargetBranch string
+}
+
 func (g GitCredentials) Validate() error {
 	if strings.HasPrefix(g.RemoteURI, "https://") || strings.HasPrefix(g.RemoteURI, "http://") {
 		if err := g.validateRemoteURIAndToken(); err != nil {
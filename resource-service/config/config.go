@@ -5,4 +5,18 @@ var Global EnvConfig
 type EnvConfig struct {
 	LogLevel                string `envconfig:"LOG_LEVEL" default:"info"`
 	DirectoryStageStructure bool   `envconfig:"DIRECTORY_STAGE_STRUCTURE" default:"false"`
+	ShipyardControllerURL   string `envconfig:"SHIPYARD_CONTROLLER_URL" default:"http://shipyard-controller:8080"`
+	InternalAPIToken        string `envconfig:"INTERNAL_API_TOKEN" default:""`
+	// StorageBackend is the backend of projects that are not listed in ProjectStorageBackends: git, s3 or gridfs
+	StorageBackend string `envconfig:"STORAGE_BACKEND" default:"git"`
+	// ProjectStorageBackends assigns projects to other backends, e.g. "podtato-head=s3,sockshop=gridfs"
+	ProjectStorageBackends string `envconfig:"STORAGE_PROJECT_BACKENDS" default:""`
+	S3Endpoint             string `envconfig:"S3_ENDPOINT" default:""`
+	S3Bucket               string `envconfig:"S3_BUCKET" default:""`
+	S3Region               string `envconfig:"S3_REGION" default:"us-east-1"`
+	S3AccessKeyID          string `envconfig:"S3_ACCESS_KEY_ID" default:""`
+	S3SecretAccessKey      string `envconfig:"S3_SECRET_ACCESS_KEY" default:""`
+	GridFSBucket           string `envconfig:"GRIDFS_BUCKET" default:"resources"`
+	// WebhookSecret verifies the git webhooks of projects that do not have a git-webhook-<project> secret
+	WebhookSecret string `envconfig:"WEBHOOK_SECRET" default:""`
 }
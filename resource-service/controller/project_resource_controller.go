@@ -20,4 +20,6 @@ func (controller ProjectResourceController) Inject(apiGroup *gin.RouterGroup) {
 	apiGroup.GET("/project/:projectName/resource/:resourceURI", controller.ProjectResourceHandler.GetProjectResource)
 	apiGroup.PUT("/project/:projectName/resource/:resourceURI", controller.ProjectResourceHandler.UpdateProjectResource)
 	apiGroup.DELETE("/project/:projectName/resource/:resourceURI", controller.ProjectResourceHandler.DeleteProjectResource)
+	apiGroup.GET("/project/:projectName/resource/:resourceURI/history", controller.ProjectResourceHandler.GetProjectResourceHistory)
+	apiGroup.GET("/project/:projectName/resource/:resourceURI/diff", controller.ProjectResourceHandler.GetProjectResourceDiff)
 }
@@ -0,0 +1,18 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/resource-service/handler"
+)
+
+type WebhookController struct {
+	WebhookHandler handler.IWebhookHandler
+}
+
+func NewWebhookController(webhookHandler handler.IWebhookHandler) Controller {
+	return &WebhookController{WebhookHandler: webhookHandler}
+}
+
+func (controller WebhookController) Inject(apiGroup *gin.RouterGroup) {
+	apiGroup.POST("/project/:projectName/webhook", controller.WebhookHandler.ReceiveGitWebhook)
+}
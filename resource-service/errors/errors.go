@@ -40,6 +40,35 @@ var ErrResourceNotFound = New("resource not found")
 var ErrResourceAlreadyExists = New("resource already exists")
 var ErrResourceNotBase64Encoded = New("resource content is not base64 encoded")
 var ErrResourceInvalidResourceURI = New("invalid resource uri")
+var ErrRevisionMustNotBeEmpty = New("revision must not be empty")
+var ErrInvalidPageSize = New("page size must be greater than zero")
+
+// Promotion specific errors
+
+var ErrPromotionSameStage = New("source and target stage must be different")
+var ErrInvalidPromotionStrategy = New("promotion strategy must be either copy or merge")
+var ErrInvalidGlobPattern = New("invalid glob pattern")
+var ErrPromotionConflict = New("resources differ between source and target stage")
+
+// Storage specific errors
+
+var ErrObjectNotFound = New("object not found")
+var ErrObjectModified = New("object has been modified concurrently")
+var ErrUnknownStorageBackend = New("unknown storage backend")
+
+// Webhook specific errors
+
+var ErrWebhookSecretNotFound = New("could not find webhook secret")
+var ErrWebhookNotSupported = New("webhooks are not supported by the storage backend of the project")
+var ErrUnsupportedWebhookProvider = New("unsupported webhook provider")
+var ErrInvalidWebhookSignature = New("invalid webhook signature")
+var ErrInvalidWebhookPayload = New("invalid webhook payload")
+
+// Pull request specific errors
+
+var ErrUnsupportedPullRequestProvider = New("unsupported pull request provider")
+var ErrPullRequestNotSupported = New("pull requests can only be opened for upstream repositories accessed via HTTP(S) with a token")
+var ErrCouldNotCreatePullRequest = New("could not create pull request")
 
 // Git specific errors
 
@@ -106,3 +135,4 @@ const ErrMsgCouldNotGetRevision = "could not get current revision for project %s
 const ErrMsgCouldNotGetDefBranch = "could not get default branch for project %s: %w"
 const ErrMsgCouldNotCheckout = "could not checkout branch %s: %w"
 const ErrMsgCouldNotCreate = "could not create branch %s for project %s: %w"
+const ErrMsgCouldNotDelete = "could not delete branch %s for project %s: %w"
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/cp-common/audit"
+	"github.com/keptn/keptn/resource-service/models"
+)
+
+const auditServiceName = "resource-service"
+
+//go:generate moq -pkg handler_mock -skip-ensure -out ./fake/audit_recorder_mock.go . IAuditRecorder
+type IAuditRecorder interface {
+	// Record queues the given record. It does not block the caller
+	Record(record audit.Record)
+}
+
+type resourceAuditAction struct {
+	name         string
+	targetParams []string
+}
+
+// resourceAuditActions contains the routes that change resources. Changes to projects, stages and services are
+// made by the shipyard-controller, which records them in the audit log itself
+var resourceAuditActions = map[string]resourceAuditAction{
+	"POST /v1/project/:projectName/resource":                                       {name: "resource.create"},
+	"PUT /v1/project/:projectName/resource":                                        {name: "resource.update"},
+	"PUT /v1/project/:projectName/resource/:resourceURI":                           {name: "resource.update", targetParams: []string{"resourceURI"}},
+	"DELETE /v1/project/:projectName/resource/:resourceURI":                        {name: "resource.delete", targetParams: []string{"resourceURI"}},
+	"POST /v1/project/:projectName/stage/:stageName/resource":                      {name: "resource.create", targetParams: []string{"stageName"}},
+	"PUT /v1/project/:projectName/stage/:stageName/resource":                       {name: "resource.update", targetParams: []string{"stageName"}},
+	"PUT /v1/project/:projectName/stage/:stageName/resource/:resourceURI":          {name: "resource.update", targetParams: []string{"stageName", "resourceURI"}},
+	"DELETE /v1/project/:projectName/stage/:stageName/resource/:resourceURI":       {name: "resource.delete", targetParams: []string{"stageName", "resourceURI"}},
+	"POST /v1/project/:projectName/stage/:stageName/service/:serviceName/resource": {name: "resource.create", targetParams: []string{"stageName", "serviceName"}},
+	"PUT /v1/project/:projectName/stage/:stageName/service/:serviceName/resource":  {name: "resource.update", targetParams: []string{"stageName", "serviceName"}},
+	"PUT /v1/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI": {
+		name: "resource.update", targetParams: []string{"stageName", "serviceName", "resourceURI"},
+	},
+	"DELETE /v1/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI": {
+		name: "resource.delete", targetParams: []string{"stageName", "serviceName", "resourceURI"},
+	},
+}
+
+// resourceAuditSummary describes a changed resource in an audit record. The content of the resource is never included
+type resourceAuditSummary struct {
+	ResourceURI string `json:"resourceURI,omitempty"`
+	Size        int    `json:"size"`
+}
+
+// AuditMiddleware records an audit record for each call that changes resources, once the call has been handled
+func AuditMiddleware(recorder IAuditRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		action, ok := resourceAuditActions[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			c.Next()
+			return
+		}
+
+		record := audit.Record{
+			Service:    auditServiceName,
+			Principal:  c.GetHeader(audit.PrincipalHeader),
+			ClientIP:   c.ClientIP(),
+			Action:     action.name,
+			TargetType: "resource",
+			Target:     getResourceAuditTarget(c, action),
+			Project:    c.Param("projectName"),
+			RequestID:  c.GetHeader(audit.RequestIDHeader),
+		}
+		if record.Principal == "" {
+			record.Principal = audit.PrincipalInternal
+		}
+		record.After = summarizeResourcesForAudit(readAuditResources(c))
+
+		c.Next()
+
+		record.StatusCode = c.Writer.Status()
+		recorder.Record(record)
+	}
+}
+
+func getResourceAuditTarget(c *gin.Context, action resourceAuditAction) string {
+	values := []string{}
+	for _, param := range action.targetParams {
+		// path values are not unescaped by the engine, to support resource URIs containing slashes
+		value, err := url.PathUnescape(c.Param(param))
+		if err != nil {
+			value = c.Param(param)
+		}
+		values = append(values, value)
+	}
+	return strings.Join(values, "/")
+}
+
+// readAuditResources returns the resources contained in the payload of the request. The body of the request is restored, so that it can still be read by the handler
+func readAuditResources(c *gin.Context) []models.Resource {
+	if c.Request.Body == nil {
+		return nil
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil || len(body) == 0 {
+		return nil
+	}
+
+	if c.Param("resourceURI") != "" {
+		payload := models.UpdateResourcePayload{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil
+		}
+		return []models.Resource{{ResourceContent: payload.ResourceContent}}
+	}
+	payload := models.CreateResourcesPayload{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil
+	}
+	return payload.Resources
+}
+
+func summarizeResourcesForAudit(resources []models.Resource) string {
+	if len(resources) == 0 {
+		return ""
+	}
+	summaries := make([]resourceAuditSummary, 0, len(resources))
+	for _, resource := range resources {
+		size := len(resource.ResourceContent)
+		if decoded, err := base64.StdEncoding.DecodeString(string(resource.ResourceContent)); err == nil {
+			size = len(decoded)
+		}
+		summaries = append(summaries, resourceAuditSummary{ResourceURI: resource.ResourceURI, Size: size})
+	}
+	summary, err := json.Marshal(summaries)
+	if err != nil {
+		return ""
+	}
+	return string(summary)
+}
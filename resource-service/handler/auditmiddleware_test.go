@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/cp-common/audit"
+	handler_mock "github.com/keptn/keptn/resource-service/handler/fake"
+	"github.com/stretchr/testify/require"
+)
+
+func newAuditTestRouter(recorder IAuditRecorder) *gin.Engine {
+	router := gin.New()
+	router.UnescapePathValues = false
+	router.UseRawPath = true
+	router.Use(AuditMiddleware(recorder))
+	apiV1 := router.Group("/v1")
+	apiV1.POST("/project/:projectName/stage/:stageName/service/:serviceName/resource", func(c *gin.Context) {
+		payload := map[string]interface{}{}
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.Status(http.StatusCreated)
+	})
+	apiV1.DELETE("/project/:projectName/stage/:stageName/resource/:resourceURI", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	apiV1.GET("/project/:projectName/resource", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func TestAuditMiddleware_CreateServiceResources(t *testing.T) {
+	recorder := &handler_mock.IAuditRecorderMock{RecordFunc: func(record audit.Record) {}}
+	router := newAuditTestRouter(recorder)
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/project/my-project/stage/dev/service/my-service/resource", bytes.NewBufferString(createResourcesTestPayload))
+	request.Header.Set(audit.PrincipalHeader, "api-token:1a2b3c4d")
+	request.Header.Set(audit.RequestIDHeader, "my-request")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, request)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Len(t, recorder.RecordCalls(), 1)
+	require.Equal(t, audit.Record{
+		Service:    "resource-service",
+		Principal:  "api-token:1a2b3c4d",
+		ClientIP:   "192.0.2.1",
+		Action:     "resource.create",
+		TargetType: "resource",
+		Target:     "dev/my-service",
+		Project:    "my-project",
+		After:      `[{"resourceURI":"resource.yaml","size":6}]`,
+		RequestID:  "my-request",
+		StatusCode: http.StatusCreated,
+	}, recorder.RecordCalls()[0].Record)
+}
+
+func TestAuditMiddleware_DeleteStageResource(t *testing.T) {
+	recorder := &handler_mock.IAuditRecorderMock{RecordFunc: func(record audit.Record) {}}
+	router := newAuditTestRouter(recorder)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/v1/project/my-project/stage/dev/resource/helm%2Fvalues.yaml", nil))
+
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.Len(t, recorder.RecordCalls(), 1)
+	record := recorder.RecordCalls()[0].Record
+	require.Equal(t, "resource.delete", record.Action)
+	require.Equal(t, "dev/helm/values.yaml", record.Target)
+	require.Equal(t, audit.PrincipalInternal, record.Principal)
+	require.Empty(t, record.After)
+}
+
+func TestAuditMiddleware_SkipsReadOnlyCalls(t *testing.T) {
+	recorder := &handler_mock.IAuditRecorderMock{}
+	router := newAuditTestRouter(recorder)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/project/my-project/resource", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, recorder.RecordCalls())
+}
@@ -19,6 +19,15 @@ func OnAPIError(c *gin.Context, err error) {
 
 	if check, resourceType := alreadyExists(err); check {
 		SetConflictErrorResponse(c, resourceType+" already exists")
+	} else if errors.Is(err, errors2.ErrInvalidWebhookSignature) || errors.Is(err, errors2.ErrWebhookSecretNotFound) {
+		// the same response is used for missing secrets, so that the existence of projects is not revealed
+		SetUnauthorizedErrorResponse(c, "Invalid webhook signature")
+	} else if errors.Is(err, errors2.ErrUnsupportedWebhookProvider) || errors.Is(err, errors2.ErrInvalidWebhookPayload) || errors.Is(err, errors2.ErrWebhookNotSupported) {
+		SetBadRequestErrorResponse(c, err.Error())
+	} else if errors.Is(err, errors2.ErrPromotionConflict) {
+		SetConflictErrorResponse(c, err.Error())
+	} else if errors.Is(err, errors2.ErrCouldNotCreatePullRequest) || errors.Is(err, errors2.ErrPullRequestNotSupported) || errors.Is(err, errors2.ErrUnsupportedPullRequestProvider) {
+		SetFailedDependencyErrorResponse(c, "Could not create pull request: "+err.Error())
 	} else if errors.Is(err, errors2.ErrInvalidGitToken) {
 		SetFailedDependencyErrorResponse(c, "Invalid git token")
 	} else if errors.Is(err, errors2.ErrCredentialsNotFound) {
@@ -57,6 +66,8 @@ func resourceNotFound(err error) (bool, string) {
 		return true, "Service"
 	} else if errors.Is(err, errors2.ErrResourceNotFound) {
 		return true, "Resource"
+	} else if errors.Is(err, errors2.ErrResolveRevision) {
+		return true, "Revision"
 	}
 	return false, ""
 }
@@ -89,6 +100,20 @@ func SetBadRequestErrorResponse(c *gin.Context, msg string) {
 	})
 }
 
+func SetUnauthorizedErrorResponse(c *gin.Context, msg string) {
+	c.JSON(http.StatusUnauthorized, models.Error{
+		Code:    http.StatusUnauthorized,
+		Message: msg,
+	})
+}
+
+func SetRequestEntityTooLargeErrorResponse(c *gin.Context, msg string) {
+	c.JSON(http.StatusRequestEntityTooLarge, models.Error{
+		Code:    http.StatusRequestEntityTooLarge,
+		Message: msg,
+	})
+}
+
 func SetConflictErrorResponse(c *gin.Context, msg string) {
 	c.JSON(http.StatusConflict, models.Error{
 		Code:    http.StatusConflict,
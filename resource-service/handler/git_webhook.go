@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/keptn/keptn/resource-service/common"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+)
+
+const (
+	branchRefPrefix = "refs/heads/"
+	// zeroRevision is sent as the previous revision of a created branch and as the new revision of a deleted branch
+	zeroRevision = "0000000000000000000000000000000000000000"
+)
+
+// pushPayload is the payload of push events sent by GitHub, GitLab and Gitea
+type pushPayload struct {
+	Ref     string `json:"ref"`
+	Before  string `json:"before"`
+	After   string `json:"after"`
+	Commits []struct {
+		Added    []string `json:"added"`
+		Modified []string `json:"modified"`
+		Removed  []string `json:"removed"`
+	} `json:"commits"`
+	// TotalCommitsCount is only sent by GitLab, which limits the number of commits contained in the payload
+	TotalCommitsCount *int `json:"total_commits_count"`
+}
+
+// bitbucketCloudPushPayload is the payload of repo:push events sent by Bitbucket Cloud
+type bitbucketCloudPushPayload struct {
+	Push struct {
+		Changes []struct {
+			New *bitbucketCloudRef `json:"new"`
+			Old *bitbucketCloudRef `json:"old"`
+		} `json:"changes"`
+	} `json:"push"`
+}
+
+type bitbucketCloudRef struct {
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Target struct {
+		Hash string `json:"hash"`
+	} `json:"target"`
+}
+
+// bitbucketServerPushPayload is the payload of repo:refs_changed events sent by Bitbucket Server
+type bitbucketServerPushPayload struct {
+	Changes []struct {
+		Ref struct {
+			ID   string `json:"id"`
+			Type string `json:"type"`
+		} `json:"ref"`
+		FromHash string `json:"fromHash"`
+		ToHash   string `json:"toHash"`
+	} `json:"changes"`
+}
+
+// ParseGitPush verifies that a webhook request has been sent by the upstream repository, using the given secret,
+// and returns the branches updated by the push it describes. Requests of GitHub, GitLab, Gitea and Bitbucket are supported.
+// If the request is valid, but does not describe a push, e.g. a ping, no push is returned
+func ParseGitPush(header http.Header, body []byte, secret string) (*models.GitPush, error) {
+	switch {
+	// Gitea also sends the headers of GitHub, so it has to be checked first
+	case header.Get("X-Gitea-Event") != "":
+		if !verifyHMACSignature(header.Get("X-Gitea-Signature"), body, secret) {
+			return nil, kerrors.ErrInvalidWebhookSignature
+		}
+		if header.Get("X-Gitea-Event") != "push" {
+			return nil, nil
+		}
+		return parsePushPayload(common.GitProviderGitea, body)
+	case header.Get("X-GitHub-Event") != "":
+		if !verifyHMACSignature(strings.TrimPrefix(header.Get("X-Hub-Signature-256"), "sha256="), body, secret) {
+			return nil, kerrors.ErrInvalidWebhookSignature
+		}
+		if header.Get("X-GitHub-Event") != "push" {
+			return nil, nil
+		}
+		return parsePushPayload(common.GitProviderGitHub, body)
+	case header.Get("X-Gitlab-Event") != "":
+		// GitLab does not sign the payload, but sends the secret token itself
+		token := header.Get("X-Gitlab-Token")
+		if secret == "" || subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+			return nil, kerrors.ErrInvalidWebhookSignature
+		}
+		if header.Get("X-Gitlab-Event") != "Push Hook" {
+			return nil, nil
+		}
+		return parsePushPayload(common.GitProviderGitLab, body)
+	case header.Get("X-Event-Key") != "":
+		if !verifyHMACSignature(strings.TrimPrefix(header.Get("X-Hub-Signature"), "sha256="), body, secret) {
+			return nil, kerrors.ErrInvalidWebhookSignature
+		}
+		switch header.Get("X-Event-Key") {
+		case "repo:push":
+			return parseBitbucketCloudPushPayload(body)
+		case "repo:refs_changed":
+			return parseBitbucketServerPushPayload(body)
+		}
+		return nil, nil
+	}
+	return nil, kerrors.ErrUnsupportedWebhookProvider
+}
+
+// verifyHMACSignature checks the hex encoded HMAC-SHA256 signature of the payload
+func verifyHMACSignature(signature string, body []byte, secret string) bool {
+	if secret == "" || signature == "" {
+		return false
+	}
+	received, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(received, mac.Sum(nil))
+}
+
+func parsePushPayload(provider string, body []byte) (*models.GitPush, error) {
+	payload := pushPayload{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, kerrors.ErrInvalidWebhookPayload
+	}
+	push := &models.GitPush{Provider: provider, Branches: []models.GitBranchUpdate{}}
+	update, ok := newBranchUpdate(payload.Ref, payload.Before, payload.After)
+	if !ok {
+		return push, nil
+	}
+	if payload.TotalCommitsCount == nil || *payload.TotalCommitsCount <= len(payload.Commits) {
+		update.ChangedFiles = []string{}
+		for _, commit := range payload.Commits {
+			update.ChangedFiles = appendFiles(update.ChangedFiles, commit.Added, commit.Modified, commit.Removed)
+		}
+	}
+	push.Branches = append(push.Branches, *update)
+	return push, nil
+}
+
+func parseBitbucketCloudPushPayload(body []byte) (*models.GitPush, error) {
+	payload := bitbucketCloudPushPayload{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, kerrors.ErrInvalidWebhookPayload
+	}
+	push := &models.GitPush{Provider: common.GitProviderBitbucket, Branches: []models.GitBranchUpdate{}}
+	for _, change := range payload.Push.Changes {
+		// deleted branches and tags are not of interest
+		if change.New == nil || change.New.Type != "branch" {
+			continue
+		}
+		before := ""
+		if change.Old != nil {
+			before = change.Old.Target.Hash
+		}
+		if update, ok := newBranchUpdate(branchRefPrefix+change.New.Name, before, change.New.Target.Hash); ok {
+			push.Branches = append(push.Branches, *update)
+		}
+	}
+	return push, nil
+}
+
+func parseBitbucketServerPushPayload(body []byte) (*models.GitPush, error) {
+	payload := bitbucketServerPushPayload{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, kerrors.ErrInvalidWebhookPayload
+	}
+	push := &models.GitPush{Provider: common.GitProviderBitbucket, Branches: []models.GitBranchUpdate{}}
+	for _, change := range payload.Changes {
+		if change.Ref.Type != "BRANCH" {
+			continue
+		}
+		if update, ok := newBranchUpdate(change.Ref.ID, change.FromHash, change.ToHash); ok {
+			push.Branches = append(push.Branches, *update)
+		}
+	}
+	return push, nil
+}
+
+// newBranchUpdate returns the update of the branch with the given ref. Pushes of tags and deleted branches are ignored
+func newBranchUpdate(ref string, before string, after string) (*models.GitBranchUpdate, bool) {
+	if !strings.HasPrefix(ref, branchRefPrefix) || after == "" || after == zeroRevision {
+		return nil, false
+	}
+	if before == zeroRevision {
+		before = ""
+	}
+	return &models.GitBranchUpdate{
+		Branch: strings.TrimPrefix(ref, branchRefPrefix),
+		Before: before,
+		After:  after,
+	}, true
+}
+
+func appendFiles(files []string, fileLists ...[]string) []string {
+	for _, fileList := range fileLists {
+		for _, file := range fileList {
+			if !containsString(files, file) {
+				files = append(files, file)
+			}
+		}
+	}
+	return files
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
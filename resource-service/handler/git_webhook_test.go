@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/keptn/keptn/resource-service/common"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+)
+
+const testWebhookSecret = "my-secret"
+
+const testPushPayload = `{
+  "ref": "refs/heads/dev",
+  "before": "1111111111111111111111111111111111111111",
+  "after": "2222222222222222222222222222222222222222",
+  "commits": [
+    {"added": ["my-service/new.yaml"], "modified": ["my-service/slo.yaml"], "removed": []},
+    {"added": [], "modified": ["my-service/slo.yaml"], "removed": ["my-service/old.yaml"]}
+  ]
+}`
+
+const testGitLabTruncatedPushPayload = `{
+  "ref": "refs/heads/dev",
+  "before": "1111111111111111111111111111111111111111",
+  "after": "2222222222222222222222222222222222222222",
+  "commits": [{"added": [], "modified": ["my-service/slo.yaml"], "removed": []}],
+  "total_commits_count": 25
+}`
+
+const testBitbucketCloudPushPayload = `{
+  "push": {
+    "changes": [
+      {"new": {"type": "branch", "name": "dev", "target": {"hash": "2222222222222222222222222222222222222222"}}, "old": {"type": "branch", "name": "dev", "target": {"hash": "1111111111111111111111111111111111111111"}}},
+      {"new": {"type": "tag", "name": "v1", "target": {"hash": "2222222222222222222222222222222222222222"}}, "old": null},
+      {"new": null, "old": {"type": "branch", "name": "removed", "target": {"hash": "1111111111111111111111111111111111111111"}}}
+    ]
+  }
+}`
+
+const testBitbucketServerPushPayload = `{
+  "changes": [
+    {"ref": {"id": "refs/heads/production", "type": "BRANCH"}, "fromHash": "0000000000000000000000000000000000000000", "toHash": "2222222222222222222222222222222222222222", "type": "ADD"}
+  ]
+}`
+
+func signTestPayload(payload string) string {
+	mac := hmac.New(sha256.New, []byte(testWebhookSecret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestParseGitPush(t *testing.T) {
+	devUpdate := models.GitBranchUpdate{
+		Branch:       "dev",
+		Before:       "1111111111111111111111111111111111111111",
+		After:        "2222222222222222222222222222222222222222",
+		ChangedFiles: []string{"my-service/new.yaml", "my-service/slo.yaml", "my-service/old.yaml"},
+	}
+	tests := []struct {
+		name    string
+		header  http.Header
+		payload string
+		want    *models.GitPush
+		wantErr error
+	}{
+		{
+			name: "github push",
+			header: http.Header{
+				"X-Github-Event":      []string{"push"},
+				"X-Hub-Signature-256": []string{"sha256=" + signTestPayload(testPushPayload)},
+			},
+			payload: testPushPayload,
+			want:    &models.GitPush{Provider: common.GitProviderGitHub, Branches: []models.GitBranchUpdate{devUpdate}},
+		},
+		{
+			name: "github ping",
+			header: http.Header{
+				"X-Github-Event":      []string{"ping"},
+				"X-Hub-Signature-256": []string{"sha256=" + signTestPayload(`{}`)},
+			},
+			payload: `{}`,
+			want:    nil,
+		},
+		{
+			name: "github invalid signature",
+			header: http.Header{
+				"X-Github-Event":      []string{"push"},
+				"X-Hub-Signature-256": []string{"sha256=" + signTestPayload(`{}`)},
+			},
+			payload: testPushPayload,
+			wantErr: kerrors.ErrInvalidWebhookSignature,
+		},
+		{
+			name: "github missing signature",
+			header: http.Header{
+				"X-Github-Event": []string{"push"},
+			},
+			payload: testPushPayload,
+			wantErr: kerrors.ErrInvalidWebhookSignature,
+		},
+		{
+			name: "gitea push",
+			header: http.Header{
+				"X-Gitea-Event":     []string{"push"},
+				"X-Github-Event":    []string{"push"},
+				"X-Gitea-Signature": []string{signTestPayload(testPushPayload)},
+			},
+			payload: testPushPayload,
+			want:    &models.GitPush{Provider: common.GitProviderGitea, Branches: []models.GitBranchUpdate{devUpdate}},
+		},
+		{
+			name: "gitlab push",
+			header: http.Header{
+				"X-Gitlab-Event": []string{"Push Hook"},
+				"X-Gitlab-Token": []string{testWebhookSecret},
+			},
+			payload: testPushPayload,
+			want:    &models.GitPush{Provider: common.GitProviderGitLab, Branches: []models.GitBranchUpdate{devUpdate}},
+		},
+		{
+			name: "gitlab push with more commits than contained in the payload",
+			header: http.Header{
+				"X-Gitlab-Event": []string{"Push Hook"},
+				"X-Gitlab-Token": []string{testWebhookSecret},
+			},
+			payload: testGitLabTruncatedPushPayload,
+			want: &models.GitPush{Provider: common.GitProviderGitLab, Branches: []models.GitBranchUpdate{{
+				Branch: "dev",
+				Before: "1111111111111111111111111111111111111111",
+				After:  "2222222222222222222222222222222222222222",
+			}}},
+		},
+		{
+			name: "gitlab invalid token",
+			header: http.Header{
+				"X-Gitlab-Event": []string{"Push Hook"},
+				"X-Gitlab-Token": []string{"other-secret"},
+			},
+			payload: testPushPayload,
+			wantErr: kerrors.ErrInvalidWebhookSignature,
+		},
+		{
+			name: "bitbucket cloud push",
+			header: http.Header{
+				"X-Event-Key":     []string{"repo:push"},
+				"X-Hub-Signature": []string{"sha256=" + signTestPayload(testBitbucketCloudPushPayload)},
+			},
+			payload: testBitbucketCloudPushPayload,
+			want: &models.GitPush{Provider: common.GitProviderBitbucket, Branches: []models.GitBranchUpdate{{
+				Branch: "dev",
+				Before: "1111111111111111111111111111111111111111",
+				After:  "2222222222222222222222222222222222222222",
+			}}},
+		},
+		{
+			name: "bitbucket server push of a new branch",
+			header: http.Header{
+				"X-Event-Key":     []string{"repo:refs_changed"},
+				"X-Hub-Signature": []string{"sha256=" + signTestPayload(testBitbucketServerPushPayload)},
+			},
+			payload: testBitbucketServerPushPayload,
+			want: &models.GitPush{Provider: common.GitProviderBitbucket, Branches: []models.GitBranchUpdate{{
+				Branch: "production",
+				After:  "2222222222222222222222222222222222222222",
+			}}},
+		},
+		{
+			name: "github push of a tag",
+			header: http.Header{
+				"X-Github-Event":      []string{"push"},
+				"X-Hub-Signature-256": []string{"sha256=" + signTestPayload(`{"ref": "refs/tags/v1", "after": "2222222222222222222222222222222222222222"}`)},
+			},
+			payload: `{"ref": "refs/tags/v1", "after": "2222222222222222222222222222222222222222"}`,
+			want:    &models.GitPush{Provider: common.GitProviderGitHub, Branches: []models.GitBranchUpdate{}},
+		},
+		{
+			name: "invalid payload",
+			header: http.Header{
+				"X-Github-Event":      []string{"push"},
+				"X-Hub-Signature-256": []string{"sha256=" + signTestPayload("invalid")},
+			},
+			payload: "invalid",
+			wantErr: kerrors.ErrInvalidWebhookPayload,
+		},
+		{
+			name:    "unknown provider",
+			header:  http.Header{},
+			payload: testPushPayload,
+			wantErr: kerrors.ErrUnsupportedWebhookProvider,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseGitPush(tt.header, []byte(tt.payload), testWebhookSecret)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseGitPush_NoSecret(t *testing.T) {
+	header := http.Header{
+		"X-Github-Event":      []string{"push"},
+		"X-Hub-Signature-256": []string{"sha256=" + signTestPayload(testPushPayload)},
+	}
+	_, err := ParseGitPush(header, []byte(testPushPayload), "")
+	require.ErrorIs(t, err, kerrors.ErrInvalidWebhookSignature)
+
+	header = http.Header{
+		"X-Gitlab-Event": []string{"Push Hook"},
+		"X-Gitlab-Token": []string{""},
+	}
+	_, err = ParseGitPush(header, []byte(testPushPayload), "")
+	require.ErrorIs(t, err, kerrors.ErrInvalidWebhookSignature)
+}
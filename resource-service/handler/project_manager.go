@@ -22,9 +22,10 @@ type IProjectManager interface {
 }
 
 type ProjectManager struct {
-	git              common.IGit
-	credentialReader common.CredentialReader
-	fileSystem       common.IFileSystem
+	git                common.IGit
+	credentialReader   common.CredentialReader
+	fileSystem         common.IFileSystem
+	pullRequestManager IPullRequestManager
 }
 
 func NewProjectManager(git common.IGit, credentialReader common.CredentialReader, fileWriter common.IFileSystem) *ProjectManager {
@@ -36,6 +37,13 @@ func NewProjectManager(git common.IGit, credentialReader common.CredentialReader
 	return projectManager
 }
 
+// WithPullRequests lets the ProjectManager propose the initialization of projects via pull requests for the projects that have pull
+// requests enabled
+func (p *ProjectManager) WithPullRequests(pullRequestManager IPullRequestManager) *ProjectManager {
+	p.pullRequestManager = pullRequestManager
+	return p
+}
+
 func (p ProjectManager) CreateProject(project models.CreateProjectParams) error {
 	common.LockProject(project.ProjectName)
 	defer common.UnlockProject(project.ProjectName)
@@ -81,7 +89,7 @@ func (p ProjectManager) CreateProject(project models.CreateProjectParams) error
 		return fmt.Errorf("could not write metadata.yaml during creating project %s: %w", project, err)
 	}
 
-	_, err = p.git.StageAndCommitAll(gitContext, "initialized project")
+	_, err = p.commitInitialization(gitContext)
 	if err != nil {
 		rollbackFunc()
 		return fmt.Errorf("could not complete initial commit for project %s: %w", project.ProjectName, err)
@@ -89,6 +97,17 @@ func (p ProjectManager) CreateProject(project models.CreateProjectParams) error
 	return nil
 }
 
+// commitInitialization commits the metadata of a new project. The initial commit to an empty upstream is pushed directly, since there
+// is no branch yet a pull request could be opened for
+func (p ProjectManager) commitInitialization(gitContext common_models.GitContext) (string, error) {
+	if pullRequestsEnabled(p.pullRequestManager, gitContext) {
+		if _, err := p.git.GetCurrentRevision(gitContext); err != nil {
+			return p.git.StageAndCommitAll(gitContext, "initialized project")
+		}
+	}
+	return commitChanges(p.git, p.pullRequestManager, gitContext, "initialized project")
+}
+
 func (p ProjectManager) isProjectInitialized(project string) bool {
 	metadataPath := common.GetProjectMetadataFilePath(project)
 	if !p.fileSystem.FileExists(metadataPath) {
@@ -63,6 +63,48 @@ func TestProjectManager_CreateProject(t *testing.T) {
 	require.Equal(t, pmd.ProjectName, project.ProjectName)
 }
 
+func TestProjectManager_CreateProject_WithPullRequests(t *testing.T) {
+	project := models.CreateProjectParams{
+		Project: models.Project{ProjectName: "my-project"},
+	}
+
+	fields := getTestProjectManagerFields()
+	fields.git.GetCurrentRevisionFunc = func(gitContext common_models.GitContext) (string, error) {
+		return "0123abcd", nil
+	}
+	pullRequestManager := getTestPullRequestManager("my-project")
+
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter).WithPullRequests(pullRequestManager)
+	err := p.CreateProject(project)
+
+	require.Nil(t, err)
+
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+	require.Len(t, pullRequestManager.CommitAndOpenPullRequestCalls(), 1)
+	require.Equal(t, "initialized project", pullRequestManager.CommitAndOpenPullRequestCalls()[0].Message)
+}
+
+func TestProjectManager_CreateProject_WithPullRequestsEmptyUpstream(t *testing.T) {
+	project := models.CreateProjectParams{
+		Project: models.Project{ProjectName: "my-project"},
+	}
+
+	fields := getTestProjectManagerFields()
+	fields.git.GetCurrentRevisionFunc = func(gitContext common_models.GitContext) (string, error) {
+		return "", errors.New("reference not found")
+	}
+	pullRequestManager := getTestPullRequestManager("my-project")
+
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter).WithPullRequests(pullRequestManager)
+	err := p.CreateProject(project)
+
+	require.Nil(t, err)
+
+	// there is no branch a pull request could be opened for, so the initial commit is pushed directly
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+	require.Empty(t, pullRequestManager.CommitAndOpenPullRequestCalls())
+}
+
 func TestProjectManager_CreateProject_ProjectAlreadyExists(t *testing.T) {
 	project := models.CreateProjectParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -931,7 +973,7 @@ func TestProjectManager_DeleteProject_CannotDeleteDirectory(t *testing.T) {
 func getTestProjectManagerFields() projectManagerTestFields {
 	return projectManagerTestFields{
 		git: &common_mock.IGitMock{
-			ResetHardFunc:         func(gitContext common_models.GitContext) error { return nil },
+			ResetHardFunc:         func(gitContext common_models.GitContext, revision string) error { return nil },
 			ProjectExistsFunc:     func(gitContext common_models.GitContext) bool { return true },
 			ProjectRepoExistsFunc: func(projectName string) bool { return true },
 			CloneRepoFunc:         func(gitContext common_models.GitContext) (bool, error) { return true, nil },
@@ -14,6 +14,8 @@ type IProjectResourceHandler interface {
 	GetProjectResource(context *gin.Context)
 	UpdateProjectResource(context *gin.Context)
 	DeleteProjectResource(context *gin.Context)
+	GetProjectResourceHistory(context *gin.Context)
+	GetProjectResourceDiff(context *gin.Context)
 }
 
 type ProjectResourceHandler struct {
@@ -273,3 +275,93 @@ func (ph *ProjectResourceHandler) DeleteProjectResource(c *gin.Context) {
 
 	c.JSON(http.StatusOK, result)
 }
+
+// GetProjectResourceHistory godoc
+// @Summary      Get the history of a project resource
+// @Description  Get the commits that changed a resource of the project, starting with the latest one
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName                                 path    string  true  "The name of the project"
+// @Param        resourceURI                           path  string  true    "The path of the resource file"
+// @Param        pageSize     query     int     false  "The number of items to return"
+// @Param        nextPageKey  query     string  false  "Pointer to the next set of items"
+// @Success      200          {object}  models.GetResourceHistoryResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/resource/{resourceURI}/history [get]
+func (ph *ProjectResourceHandler) GetProjectResourceHistory(c *gin.Context) {
+	params := &models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getHistory := &models.GetResourceHistoryQuery{PageSize: 20}
+	if err := c.ShouldBindQuery(getHistory); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceHistoryQuery = *getHistory
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	history, err := ph.ProjectResourceManager.GetResourceHistory(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// GetProjectResourceDiff godoc
+// @Summary      Get the changes of a project resource
+// @Description  Get the changes of a resource of the project between two revisions as unified diff
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName                                 path    string  true  "The name of the project"
+// @Param        resourceURI                           path  string  true    "The path of the resource file"
+// @Param        from         query     string  true   "The revision the changes are compared to"
+// @Param        to           query     string  false  "The revision that contains the changes. Defaults to the current revision"
+// @Success      200          {object}  models.GetResourceDiffResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/resource/{resourceURI}/diff [get]
+func (ph *ProjectResourceHandler) GetProjectResourceDiff(c *gin.Context) {
+	params := &models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getDiff := &models.GetResourceDiffQuery{}
+	if err := c.ShouldBindQuery(getDiff); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceDiffQuery = *getDiff
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	diff, err := ph.ProjectResourceManager.GetResourceDiff(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
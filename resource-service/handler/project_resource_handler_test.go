@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 const createResourcesTestPayload = `{
@@ -531,6 +532,31 @@ func TestProjectResourceHandler_GetProjectResources(t *testing.T) {
 	}
 }
 
+var testGetResourceHistoryResponse = models.GetResourceHistoryResponse{
+	NextPageKey: "0",
+	PageSize:    1,
+	Commits: []models.ResourceCommit{
+		{
+			CommitID:  "commit-id",
+			Author:    "keptn",
+			Message:   "Updated resource",
+			Timestamp: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	},
+	TotalCount: 1,
+}
+
+var testGetResourceDiffResponse = models.GetResourceDiffResponse{
+	ResourceURI: "my-resource.yaml",
+	From:        "commit-id",
+	To:          "my-amazing-commit-id",
+	Diff:        "--- a/my-resource.yaml\n+++ b/my-resource.yaml\n",
+	Metadata: models.Version{
+		UpstreamURL: "http://upstream-url.git",
+		Version:     "my-amazing-commit-id",
+	},
+}
+
 func TestProjectResourceHandler_GetProjectResource(t *testing.T) {
 	type fields struct {
 		ProjectResourceManager *handler_mock.IResourceManagerMock
@@ -827,3 +853,194 @@ func TestProjectResourceHandler_DeleteProjectResource(t *testing.T) {
 		})
 	}
 }
+
+func TestProjectResourceHandler_GetProjectResourceHistory(t *testing.T) {
+	type fields struct {
+		ProjectResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.GetResourceHistoryParams
+		wantResult *models.GetResourceHistoryResponse
+		wantStatus int
+	}{
+		{
+			name: "get resource history",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceHistoryFunc: func(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+						return &testGetResourceHistoryResponse, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/resource/my-resource.yaml/history?pageSize=1", nil),
+			wantParams: &models.GetResourceHistoryParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceHistoryQuery: models.GetResourceHistoryQuery{
+					PageSize: 1,
+				},
+			},
+			wantResult: &testGetResourceHistoryResponse,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "invalid page size",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/project/my-project/resource/my-resource.yaml/history?pageSize=0", nil),
+			wantParams: nil,
+			wantResult: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "resource not found",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceHistoryFunc: func(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+						return nil, errors2.ErrResourceNotFound
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/resource/my-resource.yaml/history", nil),
+			wantParams: &models.GetResourceHistoryParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceHistoryQuery: models.GetResourceHistoryQuery{
+					PageSize: 20,
+				},
+			},
+			wantResult: nil,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewProjectResourceHandler(tt.fields.ProjectResourceManager)
+
+			router := gin.Default()
+			router.GET("/project/:projectName/resource/:resourceURI/history", ph.GetProjectResourceHistory)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ProjectResourceManager.GetResourceHistoryCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ProjectResourceManager.GetResourceHistoryCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ProjectResourceManager.GetResourceHistoryCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantResult != nil {
+				result := &models.GetResourceHistoryResponse{}
+				err := json.Unmarshal(resp.Body.Bytes(), result)
+				require.Nil(t, err)
+				require.Equal(t, tt.wantResult, result)
+			}
+		})
+	}
+}
+
+func TestProjectResourceHandler_GetProjectResourceDiff(t *testing.T) {
+	type fields struct {
+		ProjectResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.GetResourceDiffParams
+		wantResult *models.GetResourceDiffResponse
+		wantStatus int
+	}{
+		{
+			name: "get resource diff",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceDiffFunc: func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+						return &testGetResourceDiffResponse, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/resource/my-resource.yaml/diff?from=commit-id&to=my-amazing-commit-id", nil),
+			wantParams: &models.GetResourceDiffParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceDiffQuery: models.GetResourceDiffQuery{
+					From: "commit-id",
+					To:   "my-amazing-commit-id",
+				},
+			},
+			wantResult: &testGetResourceDiffResponse,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "missing from revision",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/project/my-project/resource/my-resource.yaml/diff", nil),
+			wantParams: nil,
+			wantResult: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "revision not found",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceDiffFunc: func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+						return nil, errors2.ErrResolveRevision
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/resource/my-resource.yaml/diff?from=unknown", nil),
+			wantParams: &models.GetResourceDiffParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceDiffQuery: models.GetResourceDiffQuery{
+					From: "unknown",
+				},
+			},
+			wantResult: nil,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewProjectResourceHandler(tt.fields.ProjectResourceManager)
+
+			router := gin.Default()
+			router.GET("/project/:projectName/resource/:resourceURI/diff", ph.GetProjectResourceDiff)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ProjectResourceManager.GetResourceDiffCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ProjectResourceManager.GetResourceDiffCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ProjectResourceManager.GetResourceDiffCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantResult != nil {
+				result := &models.GetResourceDiffResponse{}
+				err := json.Unmarshal(resp.Body.Bytes(), result)
+				require.Nil(t, err)
+				require.Equal(t, tt.wantResult, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/resource-service/common"
+	"github.com/keptn/keptn/resource-service/common_models"
+	"github.com/keptn/keptn/resource-service/models"
+	logger "github.com/sirupsen/logrus"
+)
+
+const (
+	pullRequestBranchPrefix = "keptn/"
+	shortRevisionLength     = 8
+)
+
+// IPullRequestManager provides an interface for proposing changes to the upstream repository of a project via pull requests,
+// instead of pushing them to the branch of the stage directly
+//
+//go:generate moq -pkg handler_mock -skip-ensure -out ./fake/pull_request_manager_mock.go . IPullRequestManager
+type IPullRequestManager interface {
+	IsEnabled(gitContext common_models.GitContext) bool
+	CommitAndOpenPullRequest(gitContext common_models.GitContext, message string) (*models.OpenedPullRequest, error)
+}
+
+type GitPullRequestManager struct {
+	git            common.IGit
+	client         common.PullRequestClient
+	eventPublisher common.EventPublisher
+}
+
+// NewGitPullRequestManager creates a manager that opens pull requests for the projects whose git credentials contain a pull request provider
+func NewGitPullRequestManager(git common.IGit, client common.PullRequestClient, eventPublisher common.EventPublisher) *GitPullRequestManager {
+	return &GitPullRequestManager{
+		git:            git,
+		client:         client,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// IsEnabled returns true if a pull request provider has been set in the git credentials of the project
+func (m GitPullRequestManager) IsEnabled(gitContext common_models.GitContext) bool {
+	return gitContext.Credentials != nil && gitContext.Credentials.PullRequestProvider != ""
+}
+
+// CommitAndOpenPullRequest commits all changes of the working tree and pushes them to a new branch, which is proposed to be merged into the
+// checked out branch. Afterwards, the checked out branch is reset to its previous revision, so that it keeps matching the upstream repository
+// until the pull request has been merged
+func (m GitPullRequestManager) CommitAndOpenPullRequest(gitContext common_models.GitContext, message string) (*models.OpenedPullRequest, error) {
+	targetBranch, err := m.git.GetCurrentBranch(gitContext)
+	if err != nil {
+		return nil, err
+	}
+	baseRevision, err := m.git.GetCurrentRevision(gitContext)
+	if err != nil {
+		return nil, err
+	}
+	commitID, err := m.git.CommitAll(gitContext, message)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := m.git.ResetHard(gitContext, baseRevision); err != nil {
+			logger.WithError(err).Warnf("Could not reset branch %s of project %s", targetBranch, gitContext.Project)
+		}
+	}()
+
+	sourceBranch := pullRequestBranchPrefix + targetBranch + "/" + shortRevision(commitID)
+	if err := m.git.PushBranch(gitContext, sourceBranch); err != nil {
+		return nil, err
+	}
+
+	provider := gitContext.Credentials.PullRequestProvider
+	url, err := m.client.CreatePullRequest(provider, *gitContext.Credentials, common_models.PullRequest{
+		Title:        fmt.Sprintf("%s in %s", message, targetBranch),
+		Description:  m.getDescription(gitContext, baseRevision, commitID),
+		SourceBranch: sourceBranch,
+		TargetBranch: targetBranch,
+	})
+	if err != nil {
+		return nil, err
+	}
+	pullRequest := &models.OpenedPullRequest{
+		URL:          url,
+		SourceBranch: sourceBranch,
+		TargetBranch: targetBranch,
+		CommitID:     commitID,
+	}
+	m.publishPullRequestOpenedEvent(gitContext.Project, provider, pullRequest)
+	return pullRequest, nil
+}
+
+// commitChanges commits all changes of the working tree of a project. If pull requests are enabled for the project, the changes are proposed
+// via a pull request for the checked out branch, otherwise they are pushed to the upstream repository directly
+func commitChanges(git common.IGit, pullRequestManager IPullRequestManager, gitContext common_models.GitContext, message string) (string, error) {
+	if pullRequestsEnabled(pullRequestManager, gitContext) {
+		pullRequest, err := pullRequestManager.CommitAndOpenPullRequest(gitContext, message)
+		if err != nil {
+			return "", err
+		}
+		return pullRequest.CommitID, nil
+	}
+	return git.StageAndCommitAll(gitContext, message)
+}
+
+func pullRequestsEnabled(pullRequestManager IPullRequestManager, gitContext common_models.GitContext) bool {
+	return pullRequestManager != nil && pullRequestManager.IsEnabled(gitContext)
+}
+
+// shortRevision abbreviates the given revision to the length used in the names of the pull request branches
+func shortRevision(revision string) string {
+	if len(revision) > shortRevisionLength {
+		return revision[:shortRevisionLength]
+	}
+	return revision
+}
+
+// getDescription lists the files that have been changed by the commit
+func (m GitPullRequestManager) getDescription(gitContext common_models.GitContext, baseRevision string, commitID string) string {
+	description := fmt.Sprintf("Changes to the resources of the Keptn project %s.", gitContext.Project)
+	changedFiles, err := m.git.GetChangedFiles(gitContext, baseRevision, commitID)
+	if err != nil {
+		logger.Debugf("Could not determine the changed files of project %s: %v", gitContext.Project, err)
+		return description
+	}
+	if len(changedFiles) > 0 {
+		description += "\n\nChanged files:\n- " + strings.Join(changedFiles, "\n- ")
+	}
+	return description
+}
+
+func (m GitPullRequestManager) publishPullRequestOpenedEvent(project string, provider string, pullRequest *models.OpenedPullRequest) {
+	data := models.PullRequestOpenedEventData{
+		Project:        project,
+		Provider:       provider,
+		SourceBranch:   pullRequest.SourceBranch,
+		TargetBranch:   pullRequest.TargetBranch,
+		CommitID:       pullRequest.CommitID,
+		PullRequestURL: pullRequest.URL,
+	}
+	event := keptnv2.KeptnEvent(models.PullRequestOpenedEventType, keptnEventSource, data).WithKeptnContext(uuid.NewString()).KeptnContextExtendedCE
+	event.GitCommitID = pullRequest.CommitID
+	if err := m.eventPublisher.Publish(event); err != nil {
+		logger.WithError(err).Errorf("Could not send %s event for project %s", models.PullRequestOpenedEventType, project)
+	}
+}
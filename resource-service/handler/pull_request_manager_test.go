@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"errors"
+	"testing"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/resource-service/common"
+	common_mock "github.com/keptn/keptn/resource-service/common/fake"
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+)
+
+type testPullRequestManagerFields struct {
+	git            *common_mock.IGitMock
+	client         *common_mock.PullRequestClientMock
+	eventPublisher *common_mock.EventPublisherMock
+}
+
+func getTestPullRequestManagerFields() testPullRequestManagerFields {
+	return testPullRequestManagerFields{
+		git: &common_mock.IGitMock{
+			GetCurrentBranchFunc: func(gitContext common_models.GitContext) (string, error) { return "dev", nil },
+			GetCurrentRevisionFunc: func(gitContext common_models.GitContext) (string, error) {
+				return "1111111111111111111111111111111111111111", nil
+			},
+			CommitAllFunc: func(gitContext common_models.GitContext, message string) (string, error) {
+				return "2222222222222222222222222222222222222222", nil
+			},
+			GetChangedFilesFunc: func(gitContext common_models.GitContext, fromRevision string, toRevision string) ([]string, error) {
+				return []string{"my-service/slo.yaml"}, nil
+			},
+			PushBranchFunc: func(gitContext common_models.GitContext, branch string) error { return nil },
+			ResetHardFunc:  func(gitContext common_models.GitContext, revision string) error { return nil },
+		},
+		client: &common_mock.PullRequestClientMock{
+			CreatePullRequestFunc: func(provider string, credentials common_models.GitCredentials, pullRequest common_models.PullRequest) (string, error) {
+				return "https://github.com/keptn/my-project/pull/1", nil
+			},
+		},
+		eventPublisher: &common_mock.EventPublisherMock{
+			PublishFunc: func(event apimodels.KeptnContextExtendedCE) error { return nil },
+		},
+	}
+}
+
+func getTestPullRequestGitContext(provider string) common_models.GitContext {
+	return common_models.GitContext{
+		Project: "my-project",
+		Credentials: &common_models.GitCredentials{
+			User:                "user",
+			Token:               "token",
+			RemoteURI:           "https://github.com/keptn/my-project.git",
+			PullRequestProvider: provider,
+		},
+	}
+}
+
+func TestGitPullRequestManager_CommitAndOpenPullRequest(t *testing.T) {
+	fields := getTestPullRequestManagerFields()
+
+	pm := NewGitPullRequestManager(fields.git, fields.client, fields.eventPublisher)
+
+	pullRequest, err := pm.CommitAndOpenPullRequest(getTestPullRequestGitContext(common.GitProviderGitHub), "Updated resource")
+
+	require.Nil(t, err)
+	require.Equal(t, &models.OpenedPullRequest{
+		URL:          "https://github.com/keptn/my-project/pull/1",
+		SourceBranch: "keptn/dev/22222222",
+		TargetBranch: "dev",
+		CommitID:     "2222222222222222222222222222222222222222",
+	}, pullRequest)
+
+	require.Len(t, fields.git.CommitAllCalls(), 1)
+	require.Equal(t, "Updated resource", fields.git.CommitAllCalls()[0].Message)
+	require.Len(t, fields.git.PushBranchCalls(), 1)
+	require.Equal(t, "keptn/dev/22222222", fields.git.PushBranchCalls()[0].Branch)
+	require.Empty(t, fields.git.PushCalls())
+
+	// the stage branch is reset, since the commit has not been pushed to it
+	require.Len(t, fields.git.ResetHardCalls(), 1)
+	require.Equal(t, "1111111111111111111111111111111111111111", fields.git.ResetHardCalls()[0].Revision)
+
+	require.Len(t, fields.client.CreatePullRequestCalls(), 1)
+	require.Equal(t, common.GitProviderGitHub, fields.client.CreatePullRequestCalls()[0].Provider)
+	require.Equal(t, "https://github.com/keptn/my-project.git", fields.client.CreatePullRequestCalls()[0].Credentials.RemoteURI)
+	require.Equal(t, common_models.PullRequest{
+		Title:        "Updated resource in dev",
+		Description:  "Changes to the resources of the Keptn project my-project.\n\nChanged files:\n- my-service/slo.yaml",
+		SourceBranch: "keptn/dev/22222222",
+		TargetBranch: "dev",
+	}, fields.client.CreatePullRequestCalls()[0].PullRequest)
+
+	require.Len(t, fields.eventPublisher.PublishCalls(), 1)
+	event := fields.eventPublisher.PublishCalls()[0].Event
+	require.Equal(t, models.PullRequestOpenedEventType, *event.Type)
+	require.Equal(t, "2222222222222222222222222222222222222222", event.GitCommitID)
+	require.NotEmpty(t, event.Shkeptncontext)
+	require.Equal(t, models.PullRequestOpenedEventData{
+		Project:        "my-project",
+		Provider:       common.GitProviderGitHub,
+		SourceBranch:   "keptn/dev/22222222",
+		TargetBranch:   "dev",
+		CommitID:       "2222222222222222222222222222222222222222",
+		PullRequestURL: "https://github.com/keptn/my-project/pull/1",
+	}, event.Data)
+}
+
+func TestGitPullRequestManager_CommitAndOpenPullRequest_PushFails(t *testing.T) {
+	fields := getTestPullRequestManagerFields()
+	fields.git.PushBranchFunc = func(gitContext common_models.GitContext, branch string) error {
+		return kerrors.ErrAuthenticationRequired
+	}
+
+	pm := NewGitPullRequestManager(fields.git, fields.client, fields.eventPublisher)
+
+	_, err := pm.CommitAndOpenPullRequest(getTestPullRequestGitContext(common.GitProviderGitLab), "Updated resource")
+
+	require.ErrorIs(t, err, kerrors.ErrAuthenticationRequired)
+	require.Len(t, fields.git.ResetHardCalls(), 1)
+	require.Empty(t, fields.client.CreatePullRequestCalls())
+	require.Empty(t, fields.eventPublisher.PublishCalls())
+}
+
+func TestGitPullRequestManager_CommitAndOpenPullRequest_CreateFails(t *testing.T) {
+	fields := getTestPullRequestManagerFields()
+	fields.client.CreatePullRequestFunc = func(provider string, credentials common_models.GitCredentials, pullRequest common_models.PullRequest) (string, error) {
+		return "", kerrors.ErrCouldNotCreatePullRequest
+	}
+
+	pm := NewGitPullRequestManager(fields.git, fields.client, fields.eventPublisher)
+
+	_, err := pm.CommitAndOpenPullRequest(getTestPullRequestGitContext(common.GitProviderGitea), "Updated resource")
+
+	require.ErrorIs(t, err, kerrors.ErrCouldNotCreatePullRequest)
+	require.Len(t, fields.git.ResetHardCalls(), 1)
+	require.Empty(t, fields.eventPublisher.PublishCalls())
+}
+
+func TestGitPullRequestManager_CommitAndOpenPullRequest_PublishFails(t *testing.T) {
+	fields := getTestPullRequestManagerFields()
+	fields.eventPublisher.PublishFunc = func(event apimodels.KeptnContextExtendedCE) error {
+		return errors.New("oops")
+	}
+
+	pm := NewGitPullRequestManager(fields.git, fields.client, fields.eventPublisher)
+
+	// the pull request has been opened anyway
+	pullRequest, err := pm.CommitAndOpenPullRequest(getTestPullRequestGitContext(common.GitProviderGitHub), "Updated resource")
+	require.Nil(t, err)
+	require.Equal(t, "https://github.com/keptn/my-project/pull/1", pullRequest.URL)
+}
+
+func TestGitPullRequestManager_CommitAndOpenPullRequest_ShortRevision(t *testing.T) {
+	fields := getTestPullRequestManagerFields()
+	fields.git.CommitAllFunc = func(gitContext common_models.GitContext, message string) (string, error) {
+		return "2222", nil
+	}
+
+	pm := NewGitPullRequestManager(fields.git, fields.client, fields.eventPublisher)
+
+	pullRequest, err := pm.CommitAndOpenPullRequest(getTestPullRequestGitContext(common.GitProviderGitHub), "Updated resource")
+	require.Nil(t, err)
+	require.Equal(t, "keptn/dev/2222", pullRequest.SourceBranch)
+}
+
+func TestGitPullRequestManager_IsEnabled(t *testing.T) {
+	pm := NewGitPullRequestManager(nil, nil, nil)
+
+	require.True(t, pm.IsEnabled(getTestPullRequestGitContext(common.GitProviderGitHub)))
+	require.False(t, pm.IsEnabled(getTestPullRequestGitContext("")))
+	require.False(t, pm.IsEnabled(common_models.GitContext{Project: "my-project"}))
+}
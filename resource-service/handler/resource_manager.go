@@ -1,10 +1,14 @@
 package handler
 
 import (
+	"bytes"
 	"encoding/base64"
 	"errors"
 	"fmt"
 	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -24,6 +28,9 @@ type IResourceManager interface {
 	GetResource(params models.GetResourceParams) (*models.GetResourceResponse, error)
 	UpdateResource(params models.UpdateResourceParams) (*models.WriteResourceResponse, error)
 	DeleteResource(params models.DeleteResourceParams) (*models.WriteResourceResponse, error)
+	GetResourceHistory(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error)
+	GetResourceDiff(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error)
+	PromoteResources(params models.PromoteResourcesParams) (*models.PromoteResourcesResponse, error)
 }
 
 type ResourceManager struct {
@@ -31,6 +38,7 @@ type ResourceManager struct {
 	credentialReader     common.CredentialReader
 	fileSystem           common.IFileSystem
 	configurationContext IConfigurationContext
+	pullRequestManager   IPullRequestManager
 }
 
 func NewResourceManager(git common.IGit, credentialReader common.CredentialReader, fileWriter common.IFileSystem, stageContext IConfigurationContext) *ResourceManager {
@@ -43,6 +51,13 @@ func NewResourceManager(git common.IGit, credentialReader common.CredentialReade
 	return projectResourceManager
 }
 
+// WithPullRequests lets the ResourceManager propose changes to the upstream repositories of the projects that have pull requests enabled,
+// instead of pushing them to the stages directly
+func (p *ResourceManager) WithPullRequests(pullRequestManager IPullRequestManager) *ResourceManager {
+	p.pullRequestManager = pullRequestManager
+	return p
+}
+
 func (p ResourceManager) CreateResources(params models.CreateResourcesParams) (*models.WriteResourceResponse, error) {
 	common.LockProject(params.ProjectName)
 	defer common.UnlockProject(params.ProjectName)
@@ -162,6 +177,143 @@ func (p ResourceManager) DeleteResource(params models.DeleteResourceParams) (*mo
 	return resultCommit, resultErr
 }
 
+func (p ResourceManager) GetResourceHistory(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	if err := p.git.Pull(*gitContext); err != nil {
+		return nil, err
+	}
+	commits, err := p.git.GetFileHistory(*gitContext, getRelativeResourcePath(params.ProjectName, configPath, unescapedResourceName))
+	if err != nil {
+		return nil, err
+	}
+
+	return getPaginatedResourceHistory(commits, params.PageSize, params.NextPageKey), nil
+}
+
+func (p ResourceManager) GetResourceDiff(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	if err := p.git.Pull(*gitContext); err != nil {
+		return nil, err
+	}
+	toRevision := params.To
+	if toRevision == "" {
+		toRevision, err = p.git.GetCurrentRevision(*gitContext)
+		if err != nil {
+			return nil, err
+		}
+	}
+	diff, err := p.git.GetFileDiff(*gitContext, params.From, toRevision, getRelativeResourcePath(params.ProjectName, configPath, unescapedResourceName))
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GetResourceDiffResponse{
+		ResourceURI: params.ResourceURI,
+		From:        params.From,
+		To:          toRevision,
+		Diff:        diff,
+		Metadata: models.Version{
+			UpstreamURL: gitContext.Credentials.RemoteURI,
+			Version:     toRevision,
+		},
+	}, nil
+}
+
+// PromoteResources copies the resources of a service from the source stage to the target stage and commits all changes at once.
+// With the copy strategy, resources of the target stage that are matched by the patterns but do not exist in the source stage are deleted.
+// With the merge strategy, resources that differ between both stages are reported as conflicts, unless they should be overwritten
+func (p ResourceManager) PromoteResources(params models.PromoteResourcesParams) (*models.PromoteResourcesResponse, error) {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	sourceContext, sourcePath, err := p.establishContext(params.Project, &models.Stage{StageName: params.From}, params.Service)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.git.Pull(*sourceContext); err != nil {
+		return nil, err
+	}
+	// the source resources have to be read before the target stage is established, since this may check out another branch
+	sourceResources, err := p.readServiceResources(sourcePath, params.PromoteResourcesPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	targetContext, targetPath, err := p.establishContext(params.Project, params.Stage, params.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	var resultErr error
+	var result *models.PromoteResourcesResponse
+	_ = retry.Retry(func() error {
+		if err := p.git.Pull(*targetContext); err != nil {
+			resultErr = err
+			return nil
+		}
+		response, err := p.promoteResources(targetPath, sourceResources, params.PromoteResourcesPayload)
+		if err != nil {
+			resultErr = err
+			return nil
+		}
+
+		var commitID string
+		message := fmt.Sprintf("Promoted service %s from stage %s", params.Service.ServiceName, params.From)
+		if len(response.Added)+len(response.Updated)+len(response.Deleted) == 0 {
+			commitID, err = p.git.GetCurrentRevision(*targetContext)
+		} else if params.ShouldPush() && pullRequestsEnabled(p.pullRequestManager, *targetContext) {
+			var pullRequest *models.OpenedPullRequest
+			pullRequest, err = p.pullRequestManager.CommitAndOpenPullRequest(*targetContext, message)
+			if err == nil {
+				commitID = pullRequest.CommitID
+				response.PullRequestURL = pullRequest.URL
+				response.Metadata.Branch = pullRequest.SourceBranch
+			}
+		} else if params.ShouldPush() {
+			commitID, err = p.git.StageAndCommitAll(*targetContext, message)
+		} else {
+			commitID, err = p.git.CommitAll(*targetContext, message)
+		}
+		if err != nil {
+			if errors.Is(err, kerrors.ErrNonFastForwardUpdate) || errors.Is(err, kerrors.ErrForceNeeded) {
+				return err
+			}
+			resultErr = err
+			return nil
+		}
+		response.CommitID = commitID
+		response.Metadata.UpstreamURL = targetContext.Credentials.RemoteURI
+		response.Metadata.Version = commitID
+		result = response
+		return nil
+	}, retry.NumberOfRetries(5), retry.DelayBetweenRetries(1*time.Second))
+	return result, resultErr
+}
+
 func (p ResourceManager) establishContext(project models.Project, stage *models.Stage, service *models.Service) (*common_models.GitContext, string, error) {
 	credentials, err := p.credentialReader.GetCredentials(project.ProjectName)
 	if err != nil {
@@ -196,10 +348,7 @@ func (p ResourceManager) readResource(gitContext *common_models.GitContext, para
 	var err error
 
 	if params.GitCommitID != "" && params.GitCommitID != "\"\"" {
-		// if commit ID is set, path needs to be relative to the project directory
-		configPath = strings.TrimPrefix(configPath, common.GetProjectConfigPath(params.ProjectName))
-		// resource path must not start with "/", otherwise git is not able to resolve the revision
-		resourcePath := strings.TrimPrefix(configPath+"/"+resourceName, "/")
+		resourcePath := getRelativeResourcePath(params.ProjectName, configPath, resourceName)
 		fileContent, err = p.git.GetFileRevision(*gitContext, params.GitCommitID, resourcePath)
 		revision = params.GitCommitID
 	} else {
@@ -231,6 +380,128 @@ func (p ResourceManager) readResource(gitContext *common_models.GitContext, para
 	}, nil
 }
 
+// getRelativeResourcePath returns the path of a resource relative to the project directory, as it is required to read its revisions from git
+func getRelativeResourcePath(projectName, configPath, resourceName string) string {
+	configPath = strings.TrimPrefix(configPath, common.GetProjectConfigPath(projectName))
+	// resource path must not start with "/", otherwise git is not able to resolve the revision
+	return strings.TrimPrefix(configPath+"/"+resourceName, "/")
+}
+
+// getPaginatedResourceHistory returns the requested page of the commits that changed a resource
+func getPaginatedResourceHistory(commits []common_models.GitCommit, pageSize int64, nextPageKey string) *models.GetResourceHistoryResponse {
+	paginationInfo := Paginate(len(commits), pageSize, nextPageKey)
+	result := &models.GetResourceHistoryResponse{
+		NextPageKey: paginationInfo.NewNextPageKey,
+		TotalCount:  float64(len(commits)),
+		Commits:     []models.ResourceCommit{},
+	}
+	if paginationInfo.NextPageKey < int64(len(commits)) {
+		for _, commit := range commits[paginationInfo.NextPageKey:paginationInfo.EndIndex] {
+			result.Commits = append(result.Commits, models.ResourceCommit{
+				CommitID:  commit.ID,
+				Author:    commit.Author,
+				Message:   commit.Message,
+				Timestamp: commit.Timestamp,
+			})
+		}
+	}
+	result.PageSize = float64(len(result.Commits))
+	return result
+}
+
+// readServiceResources returns the content of the resources in the given service directory that are matched by the patterns of the payload,
+// indexed by their path relative to the service directory
+func (p ResourceManager) readServiceResources(servicePath string, payload models.PromoteResourcesPayload) (map[string][]byte, error) {
+	resources := map[string][]byte{}
+	err := p.fileSystem.WalkPath(servicePath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		resourcePath := strings.TrimPrefix(filepath.ToSlash(strings.TrimPrefix(path, servicePath)), "/")
+		// the metadata of the service is specific to each stage
+		if resourcePath == "metadata.yaml" || !payload.Includes(resourcePath) {
+			return nil
+		}
+		content, err := p.fileSystem.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		resources[resourcePath] = content
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+// promoteResources writes the source resources to the given service directory of the target stage and returns the resources that have been changed
+func (p ResourceManager) promoteResources(servicePath string, sourceResources map[string][]byte, payload models.PromoteResourcesPayload) (*models.PromoteResourcesResponse, error) {
+	targetResources, err := p.readServiceResources(servicePath, payload)
+	if err != nil {
+		return nil, err
+	}
+	result, err := planPromotion(sourceResources, targetResources, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, resourcePath := range append(append([]string{}, result.Added...), result.Updated...) {
+		if err := p.fileSystem.WriteFile(servicePath+"/"+resourcePath, sourceResources[resourcePath]); err != nil {
+			return nil, err
+		}
+	}
+	for _, resourcePath := range result.Deleted {
+		if err := p.fileSystem.DeleteFile(servicePath + "/" + resourcePath); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// planPromotion compares the resources of the source and target stage and returns the resources that have to be added, updated or deleted
+// in the target stage. With the merge strategy, resources that differ between both stages are reported as conflicts, unless they should be overwritten
+func planPromotion(sourceResources map[string][]byte, targetResources map[string][]byte, payload models.PromoteResourcesPayload) (*models.PromoteResourcesResponse, error) {
+	result := &models.PromoteResourcesResponse{
+		Added:   []string{},
+		Updated: []string{},
+		Deleted: []string{},
+	}
+	conflicts := []string{}
+	for resourcePath, content := range sourceResources {
+		targetContent, exists := targetResources[resourcePath]
+		if !exists {
+			result.Added = append(result.Added, resourcePath)
+		} else if !bytes.Equal(content, targetContent) {
+			if payload.IsMerge() && !payload.Overwrite {
+				conflicts = append(conflicts, resourcePath)
+			}
+			result.Updated = append(result.Updated, resourcePath)
+		}
+	}
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return nil, fmt.Errorf("%w: %s", kerrors.ErrPromotionConflict, strings.Join(conflicts, ", "))
+	}
+	if !payload.IsMerge() {
+		for resourcePath := range targetResources {
+			if _, exists := sourceResources[resourcePath]; !exists {
+				result.Deleted = append(result.Deleted, resourcePath)
+			}
+		}
+	}
+	sort.Strings(result.Added)
+	sort.Strings(result.Updated)
+	sort.Strings(result.Deleted)
+	return result, nil
+}
+
 func (p ResourceManager) writeAndCommitResource(gitContext *common_models.GitContext, resourcePath, resourceContent string) (*models.WriteResourceResponse, error) {
 
 	var resultErr error
@@ -305,6 +576,21 @@ func (p ResourceManager) storeResource(resourcePath, resourceContent string) err
 }
 
 func (p ResourceManager) stageAndCommit(gitContext *common_models.GitContext, message string) (*models.WriteResourceResponse, error) {
+	if pullRequestsEnabled(p.pullRequestManager, *gitContext) {
+		pullRequest, err := p.pullRequestManager.CommitAndOpenPullRequest(*gitContext, message)
+		if err != nil {
+			return nil, err
+		}
+		return &models.WriteResourceResponse{
+			CommitID:       pullRequest.CommitID,
+			PullRequestURL: pullRequest.URL,
+			Metadata: models.Version{
+				Branch:      pullRequest.SourceBranch,
+				UpstreamURL: gitContext.Credentials.RemoteURI,
+				Version:     pullRequest.CommitID,
+			},
+		}, nil
+	}
 	commitID, err := p.git.StageAndCommitAll(*gitContext, message)
 	if err != nil {
 		return nil, err
@@ -555,6 +555,107 @@ func TestResourceManager_UpdateResources_ProjectResource_CommitFailsOnFirstTry(t
 	require.Equal(t, testConfigDir+"/file2", fields.fileSystem.WriteBase64EncodedFileCalls()[1].Path)
 }
 
+func getTestPullRequestManager(enabledProject string) *handler_mock.IPullRequestManagerMock {
+	return &handler_mock.IPullRequestManagerMock{
+		IsEnabledFunc: func(gitContext common_models.GitContext) bool { return gitContext.Project == enabledProject },
+		CommitAndOpenPullRequestFunc: func(gitContext common_models.GitContext, message string) (*models.OpenedPullRequest, error) {
+			return &models.OpenedPullRequest{
+				URL:          "https://github.com/keptn/my-project/pull/1",
+				SourceBranch: "keptn/main/0123abcd",
+				TargetBranch: "main",
+				CommitID:     "0123abcd",
+			}, nil
+		},
+	}
+}
+
+func TestResourceManager_UpdateResources_ProjectResource_PullRequest(t *testing.T) {
+	fields := getTestResourceManagerFields()
+	pullRequestManager := getTestPullRequestManager("my-project")
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext).WithPullRequests(pullRequestManager)
+
+	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		UpdateResourcesPayload: models.UpdateResourcesPayload{
+			Resources: []models.Resource{
+				{
+					ResourceContent: "c3RyaW5n",
+					ResourceURI:     "file1",
+				},
+			},
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.WriteResourceResponse{
+		CommitID:       "0123abcd",
+		PullRequestURL: "https://github.com/keptn/my-project/pull/1",
+		Metadata:       models.Version{Branch: "keptn/main/0123abcd", UpstreamURL: "remote-url", Version: "0123abcd"},
+	}, revision)
+
+	require.Len(t, pullRequestManager.CommitAndOpenPullRequestCalls(), 1)
+	require.Equal(t, "my-project", pullRequestManager.CommitAndOpenPullRequestCalls()[0].GitContext.Project)
+	require.Equal(t, "Updated resource", pullRequestManager.CommitAndOpenPullRequestCalls()[0].Message)
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+}
+
+func TestResourceManager_UpdateResources_ProjectResource_PullRequestFails(t *testing.T) {
+	fields := getTestResourceManagerFields()
+	pullRequestManager := getTestPullRequestManager("my-project")
+	pullRequestManager.CommitAndOpenPullRequestFunc = func(gitContext common_models.GitContext, message string) (*models.OpenedPullRequest, error) {
+		return nil, errors2.ErrCouldNotCreatePullRequest
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext).WithPullRequests(pullRequestManager)
+
+	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		UpdateResourcesPayload: models.UpdateResourcesPayload{
+			Resources: []models.Resource{
+				{
+					ResourceContent: "c3RyaW5n",
+					ResourceURI:     "file1",
+				},
+			},
+		},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrCouldNotCreatePullRequest)
+	require.Nil(t, revision)
+	require.Len(t, pullRequestManager.CommitAndOpenPullRequestCalls(), 1)
+}
+
+func TestResourceManager_UpdateResources_ProjectResource_PullRequestsNotEnabled(t *testing.T) {
+	fields := getTestResourceManagerFields()
+	pullRequestManager := getTestPullRequestManager("other-project")
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext).WithPullRequests(pullRequestManager)
+
+	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		UpdateResourcesPayload: models.UpdateResourcesPayload{
+			Resources: []models.Resource{
+				{
+					ResourceContent: "c3RyaW5n",
+					ResourceURI:     "file1",
+				},
+			},
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.WriteResourceResponse{CommitID: "my-revision", Metadata: models.Version{UpstreamURL: "remote-url", Version: "my-revision"}}, revision)
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+	require.Empty(t, pullRequestManager.CommitAndOpenPullRequestCalls())
+}
+
 func TestResourceManager_UpdateResource_ProjectResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
@@ -1000,6 +1101,394 @@ func TestResourceManager_GetResource_ServiceResource_ProvideGitCommitID(t *testi
 	require.Equal(t, "my-service/file1", fields.git.GetFileRevisionCalls()[0].File)
 }
 
+func TestResourceManager_GetResourceHistory_ServiceResource(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		return testServiceConfigDir, nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.GetResourceHistory(models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "my-stage"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		ResourceURI: "helm%2Fvalues.yaml",
+		GetResourceHistoryQuery: models.GetResourceHistoryQuery{
+			PageSize: 2,
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.GetResourceHistoryResponse{
+		NextPageKey: "2",
+		PageSize:    2,
+		TotalCount:  3,
+		Commits: []models.ResourceCommit{
+			{CommitID: "commit-3", Author: "keptn", Message: "Updated resource", Timestamp: time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)},
+			{CommitID: "commit-2", Author: "keptn", Message: "Updated resource", Timestamp: time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)},
+		},
+	}, result)
+
+	require.Len(t, fields.git.PullCalls(), 1)
+	require.Len(t, fields.git.GetFileHistoryCalls(), 1)
+	require.Equal(t, "my-service/helm/values.yaml", fields.git.GetFileHistoryCalls()[0].File)
+
+	result, err = rm.GetResourceHistory(models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "my-stage"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		ResourceURI: "helm%2Fvalues.yaml",
+		GetResourceHistoryQuery: models.GetResourceHistoryQuery{
+			PageSize:    2,
+			NextPageKey: "2",
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, "0", result.NextPageKey)
+	require.Len(t, result.Commits, 1)
+	require.Equal(t, "commit-1", result.Commits[0].CommitID)
+}
+
+func TestResourceManager_GetResourceHistory_ProjectResource_ResourceNotFound(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.git.GetFileHistoryFunc = func(gitContext common_models.GitContext, file string) ([]common_models.GitCommit, error) {
+		return nil, errors2.ErrResourceNotFound
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.GetResourceHistory(models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI:             "file1",
+		GetResourceHistoryQuery: models.GetResourceHistoryQuery{PageSize: 20},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrResourceNotFound)
+	require.Nil(t, result)
+	require.Equal(t, "file1", fields.git.GetFileHistoryCalls()[0].File)
+}
+
+func TestResourceManager_GetResourceDiff_StageResource(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		return testConfigDir + "/stages/my-stage", nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.GetResourceDiff(models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "my-stage"},
+		},
+		ResourceURI: "file1",
+		GetResourceDiffQuery: models.GetResourceDiffQuery{
+			From: "commit-1",
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.GetResourceDiffResponse{
+		ResourceURI: "file1",
+		From:        "commit-1",
+		To:          "my-revision",
+		Diff:        "--- a/file1\n+++ b/file1\n",
+		Metadata: models.Version{
+			UpstreamURL: "remote-url",
+			Version:     "my-revision",
+		},
+	}, result)
+
+	require.Len(t, fields.git.PullCalls(), 1)
+	require.Len(t, fields.git.GetFileDiffCalls(), 1)
+	require.Equal(t, "commit-1", fields.git.GetFileDiffCalls()[0].FromRevision)
+	require.Equal(t, "my-revision", fields.git.GetFileDiffCalls()[0].ToRevision)
+	require.Equal(t, "stages/my-stage/file1", fields.git.GetFileDiffCalls()[0].File)
+}
+
+func TestResourceManager_GetResourceDiff_ProjectResource_ProvideToRevision(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.GetResourceDiff(models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		GetResourceDiffQuery: models.GetResourceDiffQuery{
+			From: "commit-1",
+			To:   "commit-2",
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, "commit-2", result.To)
+	require.Empty(t, fields.git.GetCurrentRevisionCalls())
+	require.Equal(t, "commit-2", fields.git.GetFileDiffCalls()[0].ToRevision)
+}
+
+func TestResourceManager_GetResourceDiff_ProjectResource_RevisionNotFound(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.git.GetFileDiffFunc = func(gitContext common_models.GitContext, fromRevision string, toRevision string, file string) (string, error) {
+		return "", errors2.ErrResolveRevision
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.GetResourceDiff(models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		GetResourceDiffQuery: models.GetResourceDiffQuery{
+			From: "unknown",
+		},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrResolveRevision)
+	require.Nil(t, result)
+}
+
+func getTestPromotionFields(sourceResources, targetResources map[string]string) testResourceManagerFields {
+	fields := getTestResourceManagerFields()
+	stageResources := map[string]map[string]string{
+		testConfigDir + "/stages/hardening/my-service":  sourceResources,
+		testConfigDir + "/stages/production/my-service": targetResources,
+	}
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		return testConfigDir + "/stages/" + params.Stage.StageName + "/" + params.Service.ServiceName, nil
+	}
+	fields.fileSystem.WalkPathFunc = func(path string, walkFunc filepath.WalkFunc) error {
+		_ = walkFunc(path+"/metadata.yaml", newFakeFileInfo("metadata.yaml", false), nil)
+		for resourcePath := range stageResources[path] {
+			if err := walkFunc(path+"/"+resourcePath, newFakeFileInfo(resourcePath, false), nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	fields.fileSystem.ReadFileFunc = func(filename string) ([]byte, error) {
+		for servicePath, resources := range stageResources {
+			if content, ok := resources[strings.TrimPrefix(filename, servicePath+"/")]; ok {
+				return []byte(content), nil
+			}
+		}
+		return []byte("metadata"), nil
+	}
+	return fields
+}
+
+func TestResourceManager_PromoteResources_Copy(t *testing.T) {
+	fields := getTestPromotionFields(
+		map[string]string{"values.yaml": "replicas: 2", "slo.yaml": "objectives"},
+		map[string]string{"values.yaml": "replicas: 1", "old.yaml": "old"},
+	)
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.PromoteResources(models.PromoteResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "production"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		PromoteResourcesQuery: models.PromoteResourcesQuery{From: "hardening"},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.PromoteResourcesResponse{
+		CommitID: "my-revision",
+		Added:    []string{"slo.yaml"},
+		Updated:  []string{"values.yaml"},
+		Deleted:  []string{"old.yaml"},
+		Metadata: models.Version{
+			UpstreamURL: "remote-url",
+			Version:     "my-revision",
+		},
+	}, result)
+
+	require.Len(t, fields.stageContext.EstablishCalls(), 2)
+	require.Equal(t, "hardening", fields.stageContext.EstablishCalls()[0].Params.Stage.StageName)
+	require.Equal(t, "production", fields.stageContext.EstablishCalls()[1].Params.Stage.StageName)
+
+	require.Len(t, fields.fileSystem.WriteFileCalls(), 2)
+	require.Equal(t, testConfigDir+"/stages/production/my-service/slo.yaml", fields.fileSystem.WriteFileCalls()[0].Path)
+	require.Equal(t, []byte("objectives"), fields.fileSystem.WriteFileCalls()[0].Content)
+	require.Equal(t, testConfigDir+"/stages/production/my-service/values.yaml", fields.fileSystem.WriteFileCalls()[1].Path)
+	require.Equal(t, []byte("replicas: 2"), fields.fileSystem.WriteFileCalls()[1].Content)
+
+	require.Len(t, fields.fileSystem.DeleteFileCalls(), 1)
+	require.Equal(t, testConfigDir+"/stages/production/my-service/old.yaml", fields.fileSystem.DeleteFileCalls()[0].Path)
+
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+	require.Equal(t, "Promoted service my-service from stage hardening", fields.git.StageAndCommitAllCalls()[0].Message)
+	require.Empty(t, fields.git.CommitAllCalls())
+}
+
+func TestResourceManager_PromoteResources_PullRequest(t *testing.T) {
+	fields := getTestPromotionFields(
+		map[string]string{"values.yaml": "replicas: 2"},
+		map[string]string{"values.yaml": "replicas: 1"},
+	)
+	pullRequestManager := getTestPullRequestManager("my-project")
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext).WithPullRequests(pullRequestManager)
+
+	result, err := rm.PromoteResources(models.PromoteResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "production"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		PromoteResourcesQuery: models.PromoteResourcesQuery{From: "hardening"},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.PromoteResourcesResponse{
+		CommitID:       "0123abcd",
+		Added:          []string{},
+		Updated:        []string{"values.yaml"},
+		Deleted:        []string{},
+		PullRequestURL: "https://github.com/keptn/my-project/pull/1",
+		Metadata: models.Version{
+			Branch:      "keptn/main/0123abcd",
+			UpstreamURL: "remote-url",
+			Version:     "0123abcd",
+		},
+	}, result)
+
+	require.Len(t, pullRequestManager.CommitAndOpenPullRequestCalls(), 1)
+	require.Equal(t, "Promoted service my-service from stage hardening", pullRequestManager.CommitAndOpenPullRequestCalls()[0].Message)
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+	require.Empty(t, fields.git.CommitAllCalls())
+}
+
+func TestResourceManager_PromoteResources_Merge_Conflict(t *testing.T) {
+	fields := getTestPromotionFields(
+		map[string]string{"values.yaml": "replicas: 2", "slo.yaml": "objectives"},
+		map[string]string{"values.yaml": "replicas: 1", "old.yaml": "old"},
+	)
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.PromoteResources(models.PromoteResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "production"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		PromoteResourcesQuery:   models.PromoteResourcesQuery{From: "hardening"},
+		PromoteResourcesPayload: models.PromoteResourcesPayload{Strategy: models.PromotionStrategyMerge},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrPromotionConflict)
+	require.Contains(t, err.Error(), "values.yaml")
+	require.Nil(t, result)
+
+	require.Empty(t, fields.fileSystem.WriteFileCalls())
+	require.Empty(t, fields.fileSystem.DeleteFileCalls())
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+}
+
+func TestResourceManager_PromoteResources_Merge_OverwriteWithoutPush(t *testing.T) {
+	fields := getTestPromotionFields(
+		map[string]string{"values.yaml": "replicas: 2", "slo.yaml": "objectives", "helm/chart.yaml": "chart"},
+		map[string]string{"values.yaml": "replicas: 1", "old.yaml": "old"},
+	)
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	push := false
+	result, err := rm.PromoteResources(models.PromoteResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "production"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		PromoteResourcesQuery: models.PromoteResourcesQuery{From: "hardening"},
+		PromoteResourcesPayload: models.PromoteResourcesPayload{
+			Strategy:  models.PromotionStrategyMerge,
+			Exclude:   []string{"helm"},
+			Overwrite: true,
+			Push:      &push,
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, "my-local-revision", result.CommitID)
+	require.Equal(t, []string{"slo.yaml"}, result.Added)
+	require.Equal(t, []string{"values.yaml"}, result.Updated)
+	require.Empty(t, result.Deleted)
+
+	require.Len(t, fields.fileSystem.WriteFileCalls(), 2)
+	require.Empty(t, fields.fileSystem.DeleteFileCalls())
+	require.Len(t, fields.git.CommitAllCalls(), 1)
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+}
+
+func TestResourceManager_PromoteResources_NothingChanged(t *testing.T) {
+	fields := getTestPromotionFields(
+		map[string]string{"values.yaml": "replicas: 1"},
+		map[string]string{"values.yaml": "replicas: 1"},
+	)
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.PromoteResources(models.PromoteResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "production"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		PromoteResourcesQuery: models.PromoteResourcesQuery{From: "hardening"},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, "my-revision", result.CommitID)
+	require.Empty(t, result.Added)
+	require.Empty(t, result.Updated)
+	require.Empty(t, result.Deleted)
+
+	require.Empty(t, fields.fileSystem.WriteFileCalls())
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+	require.Empty(t, fields.git.CommitAllCalls())
+}
+
+func TestResourceManager_PromoteResources_SourceServiceNotFound(t *testing.T) {
+	fields := getTestPromotionFields(nil, nil)
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		return "", errors2.ErrServiceNotFound
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+
+	result, err := rm.PromoteResources(models.PromoteResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "production"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		PromoteResourcesQuery: models.PromoteResourcesQuery{From: "hardening"},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrServiceNotFound)
+	require.Nil(t, result)
+	require.Len(t, fields.stageContext.EstablishCalls(), 1)
+}
+
 func TestResourceManager_GetResource_ProjectResource_PullFails(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
@@ -1259,7 +1748,7 @@ func (fakeFileInfo) Sys() interface{} {
 func getTestResourceManagerFields() testResourceManagerFields {
 	return testResourceManagerFields{
 		git: &common_mock.IGitMock{
-			ResetHardFunc:          func(gitContext common_models.GitContext) error { return nil },
+			ResetHardFunc:          func(gitContext common_models.GitContext, revision string) error { return nil },
 			CheckoutBranchFunc:     func(gitContext common_models.GitContext, branch string) error { return nil },
 			CloneRepoFunc:          func(gitContext common_models.GitContext) (bool, error) { return true, nil },
 			CreateBranchFunc:       func(gitContext common_models.GitContext, branch string, sourceBranch string) error { return nil },
@@ -1268,11 +1757,24 @@ func getTestResourceManagerFields() testResourceManagerFields {
 			GetFileRevisionFunc: func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
 				return []byte("file-content"), nil
 			},
+			GetFileHistoryFunc: func(gitContext common_models.GitContext, file string) ([]common_models.GitCommit, error) {
+				return []common_models.GitCommit{
+					{ID: "commit-3", Author: "keptn", Message: "Updated resource", Timestamp: time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)},
+					{ID: "commit-2", Author: "keptn", Message: "Updated resource", Timestamp: time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)},
+					{ID: "commit-1", Author: "keptn", Message: "Added resource", Timestamp: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+				}, nil
+			},
+			GetFileDiffFunc: func(gitContext common_models.GitContext, fromRevision string, toRevision string, file string) (string, error) {
+				return "--- a/file1\n+++ b/file1\n", nil
+			},
 			ProjectExistsFunc:     func(gitContext common_models.GitContext) bool { return true },
 			ProjectRepoExistsFunc: func(projectName string) bool { return true },
 			PullFunc:              func(gitContext common_models.GitContext) error { return nil },
 			PushFunc:              func(gitContext common_models.GitContext) error { return nil },
 			StageAndCommitAllFunc: func(gitContext common_models.GitContext, message string) (string, error) { return "my-revision", nil },
+			CommitAllFunc: func(gitContext common_models.GitContext, message string) (string, error) {
+				return "my-local-revision", nil
+			},
 		},
 		credentialReader: &common_mock.CredentialReaderMock{
 			GetCredentialsFunc: func(project string) (*common_models.GitCredentials, error) {
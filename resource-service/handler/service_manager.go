@@ -21,10 +21,11 @@ type IServiceManager interface {
 }
 
 type ServiceManager struct {
-	git              common.IGit
-	credentialReader common.CredentialReader
-	fileSystem       common.IFileSystem
-	stageContext     IConfigurationContext
+	git                common.IGit
+	credentialReader   common.CredentialReader
+	fileSystem         common.IFileSystem
+	stageContext       IConfigurationContext
+	pullRequestManager IPullRequestManager
 }
 
 func NewServiceManager(git common.IGit, credentialReader common.CredentialReader, fileWriter common.IFileSystem, stageContext IConfigurationContext) *ServiceManager {
@@ -37,6 +38,13 @@ func NewServiceManager(git common.IGit, credentialReader common.CredentialReader
 	return serviceManager
 }
 
+// WithPullRequests lets the ServiceManager propose the creation and deletion of services via pull requests for the projects that have
+// pull requests enabled
+func (s *ServiceManager) WithPullRequests(pullRequestManager IPullRequestManager) *ServiceManager {
+	s.pullRequestManager = pullRequestManager
+	return s
+}
+
 func (s ServiceManager) CreateService(params models.CreateServiceParams) error {
 	common.LockProject(params.ProjectName)
 	defer common.UnlockProject(params.ProjectName)
@@ -108,7 +116,7 @@ func (s ServiceManager) deleteService(gitContext *common_models.GitContext, serv
 		return "", err
 	}
 
-	return s.git.StageAndCommitAll(*gitContext, "Removed service: "+serviceName)
+	return commitChanges(s.git, s.pullRequestManager, *gitContext, "Removed service: "+serviceName)
 }
 
 func (s ServiceManager) establishServiceContext(project models.Project, stage models.Stage, service models.Service) (*common_models.GitContext, string, error) {
@@ -157,5 +165,5 @@ func (s ServiceManager) createService(gitContext *common_models.GitContext, serv
 	if err = s.fileSystem.WriteFile(servicePath+"/metadata.yaml", metadataString); err != nil {
 		return "", fmt.Errorf("could not create metadata file for service %s: %w", serviceName, err)
 	}
-	return s.git.StageAndCommitAll(*gitContext, "Added service: "+serviceName)
+	return commitChanges(s.git, s.pullRequestManager, *gitContext, "Added service: "+serviceName)
 }
@@ -61,6 +61,30 @@ func TestServiceManager_CreateService(t *testing.T) {
 	require.Equal(t, md.ServiceName, params.ServiceName)
 }
 
+func TestServiceManager_CreateService_WithPullRequest(t *testing.T) {
+	params := models.CreateServiceParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Stage:   models.Stage{StageName: "my-stage"},
+		CreateServicePayload: models.CreateServicePayload{
+			Service: models.Service{
+				ServiceName: "my-service",
+			},
+		},
+	}
+
+	fields := getTestServiceManagerFields()
+	pullRequestManager := getTestPullRequestManager("my-project")
+
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext).WithPullRequests(pullRequestManager)
+	err := p.CreateService(params)
+
+	require.Nil(t, err)
+
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+	require.Len(t, pullRequestManager.CommitAndOpenPullRequestCalls(), 1)
+	require.Equal(t, "Added service: my-service", pullRequestManager.CommitAndOpenPullRequestCalls()[0].Message)
+}
+
 func TestServiceManager_CreateService_CannotReadCredentials(t *testing.T) {
 	params := models.CreateServiceParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -520,7 +544,7 @@ func TestServiceManager_DeleteService_CannotCommit(t *testing.T) {
 func getTestServiceManagerFields() serviceManagerTestFields {
 	return serviceManagerTestFields{
 		git: &common_mock.IGitMock{
-			ResetHardFunc:         func(gitContext common_models.GitContext) error { return nil },
+			ResetHardFunc:         func(gitContext common_models.GitContext, revision string) error { return nil },
 			PullFunc:              func(gitContext common_models.GitContext) error { return nil },
 			ProjectExistsFunc:     func(gitContext common_models.GitContext) bool { return true },
 			ProjectRepoExistsFunc: func(projectName string) bool { return true },
@@ -15,6 +15,9 @@ type IServiceResourceHandler interface {
 	GetServiceResource(context *gin.Context)
 	UpdateServiceResource(context *gin.Context)
 	DeleteServiceResource(context *gin.Context)
+	GetServiceResourceHistory(context *gin.Context)
+	GetServiceResourceDiff(context *gin.Context)
+	PromoteServiceResources(context *gin.Context)
 }
 
 type ServiceResourceHandler struct {
@@ -297,3 +300,158 @@ func (ph *ServiceResourceHandler) DeleteServiceResource(c *gin.Context) {
 
 	c.JSON(http.StatusOK, result)
 }
+
+// GetServiceResourceHistory godoc
+// @Summary      Get the history of a service resource
+// @Description  Get the commits that changed a resource of the service of a stage, starting with the latest one
+// @Tags         Service Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName                                 path    string  true  "The name of the project"
+// @Param        stageName                                   path    string  true  "The name of the stage"
+// @Param        serviceName                                 path    string  true  "The name of the service"
+// @Param        resourceURI                           path  string  true    "The path of the resource file"
+// @Param        pageSize     query     int     false  "The number of items to return"
+// @Param        nextPageKey  query     string  false  "Pointer to the next set of items"
+// @Success      200          {object}  models.GetResourceHistoryResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/history [get]
+func (ph *ServiceResourceHandler) GetServiceResourceHistory(c *gin.Context) {
+	params := &models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getHistory := &models.GetResourceHistoryQuery{PageSize: 20}
+	if err := c.ShouldBindQuery(getHistory); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceHistoryQuery = *getHistory
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	history, err := ph.ServiceResourceManager.GetResourceHistory(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// GetServiceResourceDiff godoc
+// @Summary      Get the changes of a service resource
+// @Description  Get the changes of a resource of the service of a stage between two revisions as unified diff
+// @Tags         Service Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName                                 path    string  true  "The name of the project"
+// @Param        stageName                                   path    string  true  "The name of the stage"
+// @Param        serviceName                                 path    string  true  "The name of the service"
+// @Param        resourceURI                           path  string  true    "The path of the resource file"
+// @Param        from         query     string  true   "The revision the changes are compared to"
+// @Param        to           query     string  false  "The revision that contains the changes. Defaults to the current revision"
+// @Success      200          {object}  models.GetResourceDiffResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/diff [get]
+func (ph *ServiceResourceHandler) GetServiceResourceDiff(c *gin.Context) {
+	params := &models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getDiff := &models.GetResourceDiffQuery{}
+	if err := c.ShouldBindQuery(getDiff); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceDiffQuery = *getDiff
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	diff, err := ph.ServiceResourceManager.GetResourceDiff(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// PromoteServiceResources godoc
+// @Summary      Promote service resources to a stage
+// @Description  Copy or merge the resources of the service from the source stage into the given stage of a project with a single commit
+// @Tags         Service Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName                                 path    string  true  "The name of the project"
+// @Param        stageName                                   path    string  true  "The name of the stage the resources are promoted to"
+// @Param        serviceName                                 path    string  true  "The name of the service"
+// @Param        from         query     string  true   "The name of the stage the resources are promoted from"
+// @Param        promotion    body      models.PromoteResourcesPayload  false  "Strategy, include and exclude patterns of the promotion"
+// @Success      200          {object}  models.PromoteResourcesResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      409          {object}  models.Error  "Conflicting resources"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/promote [post]
+func (ph *ServiceResourceHandler) PromoteServiceResources(c *gin.Context) {
+	params := &models.PromoteResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+		},
+	}
+	promoteQuery := &models.PromoteResourcesQuery{}
+	if err := c.ShouldBindQuery(promoteQuery); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+	// the payload is optional, without it all resources are copied
+	promotePayload := &models.PromoteResourcesPayload{}
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(promotePayload); err != nil {
+			SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+			return
+		}
+	}
+
+	params.PromoteResourcesQuery = *promoteQuery
+	params.PromoteResourcesPayload = *promotePayload
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.ServiceResourceManager.PromoteResources(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
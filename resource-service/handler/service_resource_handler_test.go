@@ -897,3 +897,365 @@ func TestServiceResourceHandler_DeleteServiceResource(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceResourceHandler_GetServiceResourceHistory(t *testing.T) {
+	type fields struct {
+		ServiceResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.GetResourceHistoryParams
+		wantResult *models.GetResourceHistoryResponse
+		wantStatus int
+	}{
+		{
+			name: "get resource history",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceHistoryFunc: func(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+						return &testGetResourceHistoryResponse, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml/history?pageSize=1", nil),
+			wantParams: &models.GetResourceHistoryParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceHistoryQuery: models.GetResourceHistoryQuery{
+					PageSize: 1,
+				},
+			},
+			wantResult: &testGetResourceHistoryResponse,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "invalid page size",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml/history?pageSize=0", nil),
+			wantParams: nil,
+			wantResult: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "resource not found",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceHistoryFunc: func(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+						return nil, errors2.ErrResourceNotFound
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml/history", nil),
+			wantParams: &models.GetResourceHistoryParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceHistoryQuery: models.GetResourceHistoryQuery{
+					PageSize: 20,
+				},
+			},
+			wantResult: nil,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewServiceResourceHandler(tt.fields.ServiceResourceManager)
+
+			router := gin.Default()
+			router.GET("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI/history", ph.GetServiceResourceHistory)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ServiceResourceManager.GetResourceHistoryCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ServiceResourceManager.GetResourceHistoryCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ServiceResourceManager.GetResourceHistoryCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantResult != nil {
+				result := &models.GetResourceHistoryResponse{}
+				err := json.Unmarshal(resp.Body.Bytes(), result)
+				require.Nil(t, err)
+				require.Equal(t, tt.wantResult, result)
+			}
+		})
+	}
+}
+
+func TestServiceResourceHandler_GetServiceResourceDiff(t *testing.T) {
+	type fields struct {
+		ServiceResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.GetResourceDiffParams
+		wantResult *models.GetResourceDiffResponse
+		wantStatus int
+	}{
+		{
+			name: "get resource diff",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceDiffFunc: func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+						return &testGetResourceDiffResponse, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml/diff?from=commit-id&to=my-amazing-commit-id", nil),
+			wantParams: &models.GetResourceDiffParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceDiffQuery: models.GetResourceDiffQuery{
+					From: "commit-id",
+					To:   "my-amazing-commit-id",
+				},
+			},
+			wantResult: &testGetResourceDiffResponse,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "missing from revision",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml/diff", nil),
+			wantParams: nil,
+			wantResult: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "revision not found",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceDiffFunc: func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+						return nil, errors2.ErrResolveRevision
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml/diff?from=unknown", nil),
+			wantParams: &models.GetResourceDiffParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceDiffQuery: models.GetResourceDiffQuery{
+					From: "unknown",
+				},
+			},
+			wantResult: nil,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewServiceResourceHandler(tt.fields.ServiceResourceManager)
+
+			router := gin.Default()
+			router.GET("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI/diff", ph.GetServiceResourceDiff)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ServiceResourceManager.GetResourceDiffCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ServiceResourceManager.GetResourceDiffCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ServiceResourceManager.GetResourceDiffCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantResult != nil {
+				result := &models.GetResourceDiffResponse{}
+				err := json.Unmarshal(resp.Body.Bytes(), result)
+				require.Nil(t, err)
+				require.Equal(t, tt.wantResult, result)
+			}
+		})
+	}
+}
+
+func TestServiceResourceHandler_PromoteServiceResources(t *testing.T) {
+	testPromoteResourcesResponse := models.PromoteResourcesResponse{
+		CommitID: "my-commit-id",
+		Added:    []string{"slo.yaml"},
+		Updated:  []string{"helm/my-service/values.yaml"},
+		Deleted:  []string{},
+		Metadata: models.Version{
+			UpstreamURL: "remote-url",
+			Version:     "my-commit-id",
+		},
+	}
+	type fields struct {
+		ServiceResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.PromoteResourcesParams
+		wantResult *models.PromoteResourcesResponse
+		wantStatus int
+	}{
+		{
+			name: "promote resources without payload",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					PromoteResourcesFunc: func(params models.PromoteResourcesParams) (*models.PromoteResourcesResponse, error) {
+						return &testPromoteResourcesResponse, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/stage/production/service/my-service/promote?from=hardening", nil),
+			wantParams: &models.PromoteResourcesParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "production"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				PromoteResourcesQuery: models.PromoteResourcesQuery{From: "hardening"},
+			},
+			wantResult: &testPromoteResourcesResponse,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "promote resources with payload",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					PromoteResourcesFunc: func(params models.PromoteResourcesParams) (*models.PromoteResourcesResponse, error) {
+						return &testPromoteResourcesResponse, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/stage/production/service/my-service/promote?from=hardening",
+				bytes.NewBufferString(`{"strategy":"merge","include":["helm"],"exclude":["*.md"],"overwrite":true}`)),
+			wantParams: &models.PromoteResourcesParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "production"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				PromoteResourcesQuery: models.PromoteResourcesQuery{From: "hardening"},
+				PromoteResourcesPayload: models.PromoteResourcesPayload{
+					Strategy:  models.PromotionStrategyMerge,
+					Include:   []string{"helm"},
+					Exclude:   []string{"*.md"},
+					Overwrite: true,
+				},
+			},
+			wantResult: &testPromoteResourcesResponse,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "missing source stage",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/stage/production/service/my-service/promote", nil),
+			wantParams: nil,
+			wantResult: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid payload",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/stage/production/service/my-service/promote?from=hardening", bytes.NewBufferString("invalid")),
+			wantParams: nil,
+			wantResult: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "conflicting resources",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					PromoteResourcesFunc: func(params models.PromoteResourcesParams) (*models.PromoteResourcesResponse, error) {
+						return nil, errors2.ErrPromotionConflict
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/stage/production/service/my-service/promote?from=hardening", bytes.NewBufferString(`{"strategy":"merge"}`)),
+			wantParams: &models.PromoteResourcesParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "production"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				PromoteResourcesQuery:   models.PromoteResourcesQuery{From: "hardening"},
+				PromoteResourcesPayload: models.PromoteResourcesPayload{Strategy: models.PromotionStrategyMerge},
+			},
+			wantResult: nil,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name: "stage not found",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					PromoteResourcesFunc: func(params models.PromoteResourcesParams) (*models.PromoteResourcesResponse, error) {
+						return nil, errors2.ErrStageNotFound
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/stage/production/service/my-service/promote?from=unknown", nil),
+			wantParams: &models.PromoteResourcesParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "production"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				PromoteResourcesQuery: models.PromoteResourcesQuery{From: "unknown"},
+			},
+			wantResult: nil,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewServiceResourceHandler(tt.fields.ServiceResourceManager)
+
+			router := gin.Default()
+			router.POST("/project/:projectName/stage/:stageName/service/:serviceName/promote", ph.PromoteServiceResources)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ServiceResourceManager.PromoteResourcesCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ServiceResourceManager.PromoteResourcesCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ServiceResourceManager.PromoteResourcesCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantResult != nil {
+				result := &models.PromoteResourcesResponse{}
+				err := json.Unmarshal(resp.Body.Bytes(), result)
+				require.Nil(t, err)
+				require.Equal(t, tt.wantResult, result)
+			}
+		})
+	}
+}
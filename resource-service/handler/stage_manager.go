@@ -1,6 +1,7 @@
 package handler
 
 import (
+	stdErrors "errors"
 	"fmt"
 	"github.com/keptn/keptn/resource-service/common"
 	"github.com/keptn/keptn/resource-service/common_models"
@@ -18,8 +19,9 @@ type IStageManager interface {
 }
 
 type BranchingStageManager struct {
-	git              common.IGit
-	credentialReader common.CredentialReader
+	git                common.IGit
+	credentialReader   common.CredentialReader
+	pullRequestManager IPullRequestManager
 }
 
 func NewStageManager(git common.IGit, credentialReader common.CredentialReader) *BranchingStageManager {
@@ -30,6 +32,13 @@ func NewStageManager(git common.IGit, credentialReader common.CredentialReader)
 	return stageManager
 }
 
+// WithPullRequests lets the BranchingStageManager create stages without committing to the upstream repository for the projects that
+// have pull requests enabled
+func (s *BranchingStageManager) WithPullRequests(pullRequestManager IPullRequestManager) *BranchingStageManager {
+	s.pullRequestManager = pullRequestManager
+	return s
+}
+
 func (s BranchingStageManager) CreateStage(params models.CreateStageParams) error {
 	common.LockProject(params.ProjectName)
 	defer common.UnlockProject(params.ProjectName)
@@ -58,7 +67,12 @@ func (s BranchingStageManager) CreateStage(params models.CreateStageParams) erro
 		return fmt.Errorf("could not check out new branch %s of project %s: %w", params.StageName, params.ProjectName, err)
 	}
 
-	_, err = s.git.StageAndCommitAll(gitContext, "created stage")
+	if pullRequestsEnabled(s.pullRequestManager, gitContext) {
+		// the new branch has no counterpart in the upstream a pull request could be opened for, and pushing it leaves the existing branches untouched
+		err = s.git.PushBranch(gitContext, params.StageName)
+	} else {
+		_, err = s.git.StageAndCommitAll(gitContext, "created stage")
+	}
 	if err != nil {
 		return fmt.Errorf("could not push new branch %s of project %s: %w", params.StageName, params.ProjectName, err)
 	}
@@ -67,7 +81,31 @@ func (s BranchingStageManager) CreateStage(params models.CreateStageParams) erro
 }
 
 func (s BranchingStageManager) DeleteStage(params models.DeleteStageParams) error {
-	panic("implement me")
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	credentials, err := s.credentialReader.GetCredentials(params.ProjectName)
+	if err != nil {
+		return fmt.Errorf(errors.ErrMsgCouldNotRetrieveCredentials, params.ProjectName, err)
+	}
+
+	gitContext := common_models.GitContext{
+		Project:     params.ProjectName,
+		Credentials: credentials,
+	}
+
+	if !s.git.ProjectExists(gitContext) {
+		return errors.ErrProjectNotFound
+	}
+
+	if err := s.git.DeleteBranch(gitContext, params.StageName); err != nil {
+		if stdErrors.Is(err, errors.ErrBranchNotFound) {
+			return errors.ErrStageNotFound
+		}
+		return fmt.Errorf("could not delete branch %s of project %s: %w", params.StageName, params.ProjectName, err)
+	}
+
+	return nil
 }
 
 type DirectoryStageManager struct {
@@ -75,12 +113,20 @@ type DirectoryStageManager struct {
 	fileSystem           common.IFileSystem
 	credentialReader     common.CredentialReader
 	git                  common.IGit
+	pullRequestManager   IPullRequestManager
 }
 
 func NewDirectoryStageManager(configurationContext IConfigurationContext, fileSystem common.IFileSystem, credentialReader common.CredentialReader, git common.IGit) *DirectoryStageManager {
 	return &DirectoryStageManager{configurationContext: configurationContext, fileSystem: fileSystem, credentialReader: credentialReader, git: git}
 }
 
+// WithPullRequests lets the DirectoryStageManager propose the creation and deletion of stages via pull requests for the projects that have
+// pull requests enabled
+func (dm *DirectoryStageManager) WithPullRequests(pullRequestManager IPullRequestManager) *DirectoryStageManager {
+	dm.pullRequestManager = pullRequestManager
+	return dm
+}
+
 func (dm DirectoryStageManager) CreateStage(params models.CreateStageParams) error {
 	common.LockProject(params.ProjectName)
 	defer common.UnlockProject(params.ProjectName)
@@ -107,7 +153,7 @@ func (dm DirectoryStageManager) CreateStage(params models.CreateStageParams) err
 		return fmt.Errorf("could not create metadata file for stage %s: %w", params.StageName, err)
 	}
 
-	if _, err := dm.git.StageAndCommitAll(*gitContext, "Added stage: "+params.StageName); err != nil {
+	if _, err := commitChanges(dm.git, dm.pullRequestManager, *gitContext, "Added stage: "+params.StageName); err != nil {
 		return fmt.Errorf("could not initialize stage %s: %w", params.StageName, err)
 	}
 
@@ -130,7 +176,7 @@ func (dm DirectoryStageManager) DeleteStage(params models.DeleteStageParams) err
 		return fmt.Errorf("could not delete directory of stage %s: %w", params.StageName, err)
 	}
 
-	if _, err := dm.git.StageAndCommitAll(*gitContext, "Added stage: "+params.StageName); err != nil {
+	if _, err := commitChanges(dm.git, dm.pullRequestManager, *gitContext, "Removed stage: "+params.StageName); err != nil {
 		return fmt.Errorf("could not delete stage %s: %w", params.StageName, err)
 	}
 
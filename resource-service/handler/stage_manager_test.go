@@ -2,6 +2,7 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	common_mock "github.com/keptn/keptn/resource-service/common/fake"
 	"github.com/keptn/keptn/resource-service/common_models"
 	errors2 "github.com/keptn/keptn/resource-service/errors"
@@ -56,6 +57,34 @@ func TestStageManager_CreateStage(t *testing.T) {
 	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
 }
 
+func TestStageManager_CreateStage_WithPullRequests(t *testing.T) {
+	params := models.CreateStageParams{
+		Project: models.Project{ProjectName: "my-project"},
+		CreateStagePayload: models.CreateStagePayload{
+			Stage: models.Stage{
+				StageName: "my-stage",
+			},
+		},
+	}
+
+	fields := getTestStageManagerFields()
+	fields.git.PushBranchFunc = func(gitContext common_models.GitContext, branch string) error {
+		return nil
+	}
+	pullRequestManager := getTestPullRequestManager("my-project")
+
+	s := NewStageManager(fields.git, fields.credentialReader).WithPullRequests(pullRequestManager)
+	err := s.CreateStage(params)
+
+	require.Nil(t, err)
+
+	require.Len(t, fields.git.CreateBranchCalls(), 1)
+	require.Len(t, fields.git.PushBranchCalls(), 1)
+	require.Equal(t, "my-stage", fields.git.PushBranchCalls()[0].Branch)
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+	require.Empty(t, pullRequestManager.CommitAndOpenPullRequestCalls())
+}
+
 func TestStageManager_CreateStage_NoCredentialsFound(t *testing.T) {
 	params := models.CreateStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -229,6 +258,74 @@ func TestStageManager_CreateStage_CannotPushBranch(t *testing.T) {
 	require.Equal(t, fields.git.CreateBranchCalls()[0].Branch, "my-stage")
 }
 
+func TestStageManager_DeleteStage(t *testing.T) {
+	params := models.DeleteStageParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Stage:   models.Stage{StageName: "my-stage"},
+	}
+
+	fields := getTestStageManagerFields()
+	s := NewStageManager(fields.git, fields.credentialReader)
+	err := s.DeleteStage(params)
+
+	require.Nil(t, err)
+
+	require.Len(t, fields.git.DeleteBranchCalls(), 1)
+	require.Equal(t, "my-project", fields.git.DeleteBranchCalls()[0].GitContext.Project)
+	require.Equal(t, "my-stage", fields.git.DeleteBranchCalls()[0].Branch)
+}
+
+func TestStageManager_DeleteStage_ProjectDoesNotExist(t *testing.T) {
+	fields := getTestStageManagerFields()
+
+	fields.git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool {
+		return false
+	}
+
+	s := NewStageManager(fields.git, fields.credentialReader)
+	err := s.DeleteStage(models.DeleteStageParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Stage:   models.Stage{StageName: "my-stage"},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrProjectNotFound)
+
+	require.Empty(t, fields.git.DeleteBranchCalls())
+}
+
+func TestStageManager_DeleteStage_StageDoesNotExist(t *testing.T) {
+	fields := getTestStageManagerFields()
+
+	fields.git.DeleteBranchFunc = func(gitContext common_models.GitContext, branch string) error {
+		return fmt.Errorf(errors2.ErrMsgCouldNotDelete, branch, gitContext.Project, errors2.ErrBranchNotFound)
+	}
+
+	s := NewStageManager(fields.git, fields.credentialReader)
+	err := s.DeleteStage(models.DeleteStageParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Stage:   models.Stage{StageName: "my-stage"},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrStageNotFound)
+}
+
+func TestStageManager_DeleteStage_CannotDeleteBranch(t *testing.T) {
+	fields := getTestStageManagerFields()
+
+	fields.git.DeleteBranchFunc = func(gitContext common_models.GitContext, branch string) error {
+		return errors.New("oops")
+	}
+
+	s := NewStageManager(fields.git, fields.credentialReader)
+	err := s.DeleteStage(models.DeleteStageParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Stage:   models.Stage{StageName: "my-stage"},
+	})
+
+	require.NotNil(t, err)
+	require.NotErrorIs(t, err, errors2.ErrStageNotFound)
+}
+
 func getTestStageManagerFields() stageManagerTestFields {
 	return stageManagerTestFields{
 		git: &common_mock.IGitMock{
@@ -253,6 +350,9 @@ func getTestStageManagerFields() stageManagerTestFields {
 			CreateBranchFunc: func(gitContext common_models.GitContext, branch string, sourceBranch string) error {
 				return nil
 			},
+			DeleteBranchFunc: func(gitContext common_models.GitContext, branch string) error {
+				return nil
+			},
 		},
 		credentialReader: &common_mock.CredentialReaderMock{
 			GetCredentialsFunc: func(project string) (*common_models.GitCredentials, error) {
@@ -522,6 +622,24 @@ func TestDirectoryStageManager_DeleteStage_CannotDeleteDirectory(t *testing.T) {
 	require.Empty(t, fields.git.StageAndCommitAllCalls())
 }
 
+func TestDirectoryStageManager_DeleteStage_WithPullRequests(t *testing.T) {
+	fields := getTestStageManagerFields()
+	pullRequestManager := getTestPullRequestManager("my-project")
+
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git).WithPullRequests(pullRequestManager)
+
+	err := dm.DeleteStage(models.DeleteStageParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Stage:   models.Stage{StageName: "my-stage"},
+	})
+
+	require.Nil(t, err)
+
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+	require.Len(t, pullRequestManager.CommitAndOpenPullRequestCalls(), 1)
+	require.Equal(t, "Removed stage: my-stage", pullRequestManager.CommitAndOpenPullRequestCalls()[0].Message)
+}
+
 func TestDirectoryStageManager_DeleteStage_CannotCommitChanges(t *testing.T) {
 	fields := getTestStageManagerFields()
 
@@ -15,6 +15,8 @@ type IStageResourceHandler interface {
 	GetStageResource(context *gin.Context)
 	UpdateStageResource(context *gin.Context)
 	DeleteStageResource(context *gin.Context)
+	GetStageResourceHistory(context *gin.Context)
+	GetStageResourceDiff(context *gin.Context)
 }
 
 type StageResourceHandler struct {
@@ -286,3 +288,97 @@ func (ph *StageResourceHandler) DeleteStageResource(c *gin.Context) {
 
 	c.JSON(http.StatusOK, result)
 }
+
+// GetStageResourceHistory godoc
+// @Summary      Get the history of a stage resource
+// @Description  Get the commits that changed a resource of the stage of a project, starting with the latest one
+// @Tags         Stage Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName                                 path    string  true  "The name of the project"
+// @Param        stageName                                   path    string  true  "The name of the stage"
+// @Param        resourceURI                           path  string  true    "The path of the resource file"
+// @Param        pageSize     query     int     false  "The number of items to return"
+// @Param        nextPageKey  query     string  false  "Pointer to the next set of items"
+// @Success      200          {object}  models.GetResourceHistoryResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/resource/{resourceURI}/history [get]
+func (ph *StageResourceHandler) GetStageResourceHistory(c *gin.Context) {
+	params := &models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getHistory := &models.GetResourceHistoryQuery{PageSize: 20}
+	if err := c.ShouldBindQuery(getHistory); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceHistoryQuery = *getHistory
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	history, err := ph.StageResourceManager.GetResourceHistory(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// GetStageResourceDiff godoc
+// @Summary      Get the changes of a stage resource
+// @Description  Get the changes of a resource of the stage of a project between two revisions as unified diff
+// @Tags         Stage Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName                                 path    string  true  "The name of the project"
+// @Param        stageName                                   path    string  true  "The name of the stage"
+// @Param        resourceURI                           path  string  true    "The path of the resource file"
+// @Param        from         query     string  true   "The revision the changes are compared to"
+// @Param        to           query     string  false  "The revision that contains the changes. Defaults to the current revision"
+// @Success      200          {object}  models.GetResourceDiffResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/resource/{resourceURI}/diff [get]
+func (ph *StageResourceHandler) GetStageResourceDiff(c *gin.Context) {
+	params := &models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getDiff := &models.GetResourceDiffQuery{}
+	if err := c.ShouldBindQuery(getDiff); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceDiffQuery = *getDiff
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	diff, err := ph.StageResourceManager.GetResourceDiff(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+)
+
+// StorageBackend contains the managers that keep the resources of a project in one kind of storage
+type StorageBackend struct {
+	ProjectManager  IProjectManager
+	StageManager    IStageManager
+	ServiceManager  IServiceManager
+	ResourceManager IResourceManager
+	// WebhookManager is only set for backends that are synchronized with an upstream repository
+	WebhookManager IWebhookManager
+}
+
+// StorageRouter implements the interfaces of all managers and passes each call on to the storage backend of the respective project.
+// Projects that have not been assigned to a storage backend explicitly are kept in the default backend
+type StorageRouter struct {
+	backends        map[string]StorageBackend
+	projectBackends map[string]string
+	defaultBackend  string
+}
+
+func NewStorageRouter(backends map[string]StorageBackend, defaultBackend string, projectBackends map[string]string) (*StorageRouter, error) {
+	if _, ok := backends[defaultBackend]; !ok {
+		return nil, fmt.Errorf("%w: default backend %s is not configured", kerrors.ErrUnknownStorageBackend, defaultBackend)
+	}
+	for project, backend := range projectBackends {
+		if _, ok := backends[backend]; !ok {
+			return nil, fmt.Errorf("%w: backend %s of project %s is not configured", kerrors.ErrUnknownStorageBackend, backend, project)
+		}
+	}
+	return &StorageRouter{
+		backends:        backends,
+		projectBackends: projectBackends,
+		defaultBackend:  defaultBackend,
+	}, nil
+}
+
+// ParseProjectStorageBackends parses a comma separated list of <project>=<backend> assignments, e.g. "podtato-head=s3,sockshop=gridfs"
+func ParseProjectStorageBackends(value string) (map[string]string, error) {
+	projectBackends := map[string]string{}
+	for _, assignment := range strings.Split(value, ",") {
+		assignment = strings.TrimSpace(assignment)
+		if assignment == "" {
+			continue
+		}
+		parts := strings.SplitN(assignment, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("invalid storage backend assignment %s, expected <project>=<backend>", assignment)
+		}
+		projectBackends[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return projectBackends, nil
+}
+
+func (r StorageRouter) backend(projectName string) StorageBackend {
+	if backend, ok := r.projectBackends[projectName]; ok {
+		return r.backends[backend]
+	}
+	return r.backends[r.defaultBackend]
+}
+
+func (r StorageRouter) CreateProject(project models.CreateProjectParams) error {
+	return r.backend(project.ProjectName).ProjectManager.CreateProject(project)
+}
+
+func (r StorageRouter) UpdateProject(project models.UpdateProjectParams) error {
+	return r.backend(project.ProjectName).ProjectManager.UpdateProject(project)
+}
+
+func (r StorageRouter) DeleteProject(projectName string) error {
+	return r.backend(projectName).ProjectManager.DeleteProject(projectName)
+}
+
+func (r StorageRouter) CreateStage(params models.CreateStageParams) error {
+	return r.backend(params.ProjectName).StageManager.CreateStage(params)
+}
+
+func (r StorageRouter) DeleteStage(params models.DeleteStageParams) error {
+	return r.backend(params.ProjectName).StageManager.DeleteStage(params)
+}
+
+func (r StorageRouter) CreateService(params models.CreateServiceParams) error {
+	return r.backend(params.ProjectName).ServiceManager.CreateService(params)
+}
+
+func (r StorageRouter) DeleteService(params models.DeleteServiceParams) error {
+	return r.backend(params.ProjectName).ServiceManager.DeleteService(params)
+}
+
+func (r StorageRouter) CreateResources(params models.CreateResourcesParams) (*models.WriteResourceResponse, error) {
+	return r.backend(params.ProjectName).ResourceManager.CreateResources(params)
+}
+
+func (r StorageRouter) GetResources(params models.GetResourcesParams) (*models.GetResourcesResponse, error) {
+	return r.backend(params.ProjectName).ResourceManager.GetResources(params)
+}
+
+func (r StorageRouter) UpdateResources(params models.UpdateResourcesParams) (*models.WriteResourceResponse, error) {
+	return r.backend(params.ProjectName).ResourceManager.UpdateResources(params)
+}
+
+func (r StorageRouter) GetResource(params models.GetResourceParams) (*models.GetResourceResponse, error) {
+	return r.backend(params.ProjectName).ResourceManager.GetResource(params)
+}
+
+func (r StorageRouter) UpdateResource(params models.UpdateResourceParams) (*models.WriteResourceResponse, error) {
+	return r.backend(params.ProjectName).ResourceManager.UpdateResource(params)
+}
+
+func (r StorageRouter) DeleteResource(params models.DeleteResourceParams) (*models.WriteResourceResponse, error) {
+	return r.backend(params.ProjectName).ResourceManager.DeleteResource(params)
+}
+
+func (r StorageRouter) GetResourceHistory(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+	return r.backend(params.ProjectName).ResourceManager.GetResourceHistory(params)
+}
+
+func (r StorageRouter) GetResourceDiff(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+	return r.backend(params.ProjectName).ResourceManager.GetResourceDiff(params)
+}
+
+func (r StorageRouter) PromoteResources(params models.PromoteResourcesParams) (*models.PromoteResourcesResponse, error) {
+	return r.backend(params.ProjectName).ResourceManager.PromoteResources(params)
+}
+
+func (r StorageRouter) GetWebhookSecret(projectName string) (string, error) {
+	webhookManager := r.backend(projectName).WebhookManager
+	if webhookManager == nil {
+		return "", kerrors.ErrWebhookNotSupported
+	}
+	return webhookManager.GetWebhookSecret(projectName)
+}
+
+func (r StorageRouter) SyncProject(params models.SyncProjectParams) (*models.SyncProjectResponse, error) {
+	webhookManager := r.backend(params.ProjectName).WebhookManager
+	if webhookManager == nil {
+		return nil, kerrors.ErrWebhookNotSupported
+	}
+	return webhookManager.SyncProject(params)
+}
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"testing"
+
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	handler_mock "github.com/keptn/keptn/resource-service/handler/fake"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestStorageBackend() StorageBackend {
+	return StorageBackend{
+		ProjectManager: &handler_mock.IProjectManagerMock{
+			CreateProjectFunc: func(project models.CreateProjectParams) error { return nil },
+			DeleteProjectFunc: func(projectName string) error { return nil },
+		},
+		StageManager: &handler_mock.IStageManagerMock{
+			CreateStageFunc: func(params models.CreateStageParams) error { return nil },
+		},
+		ServiceManager: &handler_mock.IServiceManagerMock{
+			CreateServiceFunc: func(params models.CreateServiceParams) error { return nil },
+		},
+		ResourceManager: &handler_mock.IResourceManagerMock{
+			GetResourceFunc: func(params models.GetResourceParams) (*models.GetResourceResponse, error) {
+				return &models.GetResourceResponse{}, nil
+			},
+		},
+	}
+}
+
+func TestStorageRouter(t *testing.T) {
+	gitBackend := getTestStorageBackend()
+	s3Backend := getTestStorageBackend()
+
+	router, err := NewStorageRouter(map[string]StorageBackend{"git": gitBackend, "s3": s3Backend}, "git", map[string]string{"my-project": "s3"})
+	require.Nil(t, err)
+
+	require.Nil(t, router.CreateProject(models.CreateProjectParams{Project: models.Project{ProjectName: "my-project"}}))
+	require.Nil(t, router.CreateStage(models.CreateStageParams{Project: models.Project{ProjectName: "my-project"}}))
+	require.Nil(t, router.CreateService(models.CreateServiceParams{Project: models.Project{ProjectName: "my-project"}}))
+	_, err = router.GetResource(models.GetResourceParams{ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: "my-project"}}})
+	require.Nil(t, err)
+	require.Nil(t, router.DeleteProject("other-project"))
+
+	require.Len(t, s3Backend.ProjectManager.(*handler_mock.IProjectManagerMock).CreateProjectCalls(), 1)
+	require.Len(t, s3Backend.StageManager.(*handler_mock.IStageManagerMock).CreateStageCalls(), 1)
+	require.Len(t, s3Backend.ServiceManager.(*handler_mock.IServiceManagerMock).CreateServiceCalls(), 1)
+	require.Len(t, s3Backend.ResourceManager.(*handler_mock.IResourceManagerMock).GetResourceCalls(), 1)
+	require.Empty(t, s3Backend.ProjectManager.(*handler_mock.IProjectManagerMock).DeleteProjectCalls())
+
+	require.Empty(t, gitBackend.ProjectManager.(*handler_mock.IProjectManagerMock).CreateProjectCalls())
+	require.Len(t, gitBackend.ProjectManager.(*handler_mock.IProjectManagerMock).DeleteProjectCalls(), 1)
+	require.Equal(t, "other-project", gitBackend.ProjectManager.(*handler_mock.IProjectManagerMock).DeleteProjectCalls()[0].ProjectName)
+}
+
+func TestNewStorageRouter_UnknownBackend(t *testing.T) {
+	backends := map[string]StorageBackend{"git": getTestStorageBackend()}
+
+	_, err := NewStorageRouter(backends, "s3", nil)
+	require.ErrorIs(t, err, kerrors.ErrUnknownStorageBackend)
+
+	_, err = NewStorageRouter(backends, "git", map[string]string{"my-project": "gridfs"})
+	require.ErrorIs(t, err, kerrors.ErrUnknownStorageBackend)
+}
+
+func TestParseProjectStorageBackends(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			value: "",
+			want:  map[string]string{},
+		},
+		{
+			name:  "multiple projects",
+			value: "podtato-head=s3, sockshop = gridfs,",
+			want:  map[string]string{"podtato-head": "s3", "sockshop": "gridfs"},
+		},
+		{
+			name:    "missing backend",
+			value:   "podtato-head=",
+			wantErr: true,
+		},
+		{
+			name:    "missing separator",
+			value:   "podtato-head",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseProjectStorageBackends(tt.value)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStorageRouter_WebhookNotSupported(t *testing.T) {
+	gitBackend := getTestStorageBackend()
+	gitBackend.WebhookManager = &handler_mock.IWebhookManagerMock{
+		GetWebhookSecretFunc: func(projectName string) (string, error) { return "my-secret", nil },
+	}
+
+	router, err := NewStorageRouter(map[string]StorageBackend{"git": gitBackend, "s3": getTestStorageBackend()}, "git", map[string]string{"my-project": "s3"})
+	require.Nil(t, err)
+
+	secret, err := router.GetWebhookSecret("other-project")
+	require.Nil(t, err)
+	require.Equal(t, "my-secret", secret)
+
+	_, err = router.GetWebhookSecret("my-project")
+	require.ErrorIs(t, err, kerrors.ErrWebhookNotSupported)
+	_, err = router.SyncProject(models.SyncProjectParams{Project: models.Project{ProjectName: "my-project"}})
+	require.ErrorIs(t, err, kerrors.ErrWebhookNotSupported)
+}
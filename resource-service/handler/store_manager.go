@@ -0,0 +1,375 @@
+package handler
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/keptn/keptn/resource-service/common"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	"gopkg.in/yaml.v3"
+)
+
+// The store managers implement the managers of the resource-service on top of a common.IConfigurationStore, which does not require a
+// local clone of a git repository. Like with the branch based structure, the resources of each stage are kept separately and the resources
+// of a service are kept in the directory named after the service. Since the store rejects concurrent changes and the update is
+// repeated with the latest files, the managers do not need to lock the project.
+// Helm charts are stored as uploaded, i.e. they are not unpacked.
+
+const metadataFileName = "metadata.yaml"
+
+type StoreProjectManager struct {
+	store common.IConfigurationStore
+}
+
+func NewStoreProjectManager(store common.IConfigurationStore) *StoreProjectManager {
+	return &StoreProjectManager{store: store}
+}
+
+func (p StoreProjectManager) CreateProject(project models.CreateProjectParams) error {
+	metadata, err := yaml.Marshal(&common.ProjectMetadata{
+		ProjectName:               project.ProjectName,
+		CreationTimestamp:         time.Now().UTC().String(),
+		IsUsingDirectoryStructure: false,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = p.store.CreateProject(project.ProjectName, map[string][]byte{metadataFileName: metadata}, "initialized project")
+	return err
+}
+
+// UpdateProject only checks if the project exists, since projects of a store do not have an upstream repository
+// and there is no directory structure they could be migrated to
+func (p StoreProjectManager) UpdateProject(project models.UpdateProjectParams) error {
+	if !p.store.ProjectExists(project.ProjectName) {
+		return kerrors.ErrProjectNotFound
+	}
+	return nil
+}
+
+func (p StoreProjectManager) DeleteProject(projectName string) error {
+	return p.store.DeleteProject(projectName)
+}
+
+type StoreStageManager struct {
+	store common.IConfigurationStore
+}
+
+func NewStoreStageManager(store common.IConfigurationStore) *StoreStageManager {
+	return &StoreStageManager{store: store}
+}
+
+func (s StoreStageManager) CreateStage(params models.CreateStageParams) error {
+	_, err := s.store.CreateStage(params.ProjectName, params.StageName)
+	return err
+}
+
+func (s StoreStageManager) DeleteStage(params models.DeleteStageParams) error {
+	return s.store.DeleteStage(params.ProjectName, params.StageName)
+}
+
+type StoreServiceManager struct {
+	store common.IConfigurationStore
+}
+
+func NewStoreServiceManager(store common.IConfigurationStore) *StoreServiceManager {
+	return &StoreServiceManager{store: store}
+}
+
+func (s StoreServiceManager) CreateService(params models.CreateServiceParams) error {
+	metadata, err := yaml.Marshal(&common.ServiceMetadata{
+		ServiceName:       params.ServiceName,
+		CreationTimestamp: time.Now().UTC().String(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = s.store.Commit(params.ProjectName, params.StageName, "Added service: "+params.ServiceName, func(tree *common.StoreTree) error {
+		if len(tree.Files(params.ServiceName)) > 0 {
+			return kerrors.ErrServiceAlreadyExists
+		}
+		tree.Write(params.ServiceName+"/"+metadataFileName, metadata)
+		return nil
+	})
+	return err
+}
+
+func (s StoreServiceManager) DeleteService(params models.DeleteServiceParams) error {
+	_, err := s.store.Commit(params.ProjectName, params.StageName, "Removed service: "+params.ServiceName, func(tree *common.StoreTree) error {
+		if tree.Delete(params.ServiceName) == 0 {
+			return kerrors.ErrServiceNotFound
+		}
+		return nil
+	})
+	return err
+}
+
+type StoreResourceManager struct {
+	store common.IConfigurationStore
+}
+
+func NewStoreResourceManager(store common.IConfigurationStore) *StoreResourceManager {
+	return &StoreResourceManager{store: store}
+}
+
+func (p StoreResourceManager) CreateResources(params models.CreateResourcesParams) (*models.WriteResourceResponse, error) {
+	return p.writeResources(params.ResourceContext, params.Resources)
+}
+
+func (p StoreResourceManager) GetResources(params models.GetResourcesParams) (*models.GetResourcesResponse, error) {
+	revision := ""
+	if params.GitCommitID != "\"\"" {
+		revision = params.GitCommitID
+	}
+	tree, directory, err := p.getTree(params.ResourceContext, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	for _, file := range tree.Files(directory) {
+		files = append(files, strings.TrimPrefix(file, directory+"/"))
+	}
+	paginationInfo := Paginate(len(files), params.PageSize, params.NextPageKey)
+	result := &models.GetResourcesResponse{
+		NextPageKey: paginationInfo.NewNextPageKey,
+		TotalCount:  float64(len(files)),
+		Resources:   []models.GetResourceResponse{},
+	}
+	if paginationInfo.NextPageKey < int64(len(files)) {
+		for _, resourceURI := range files[paginationInfo.NextPageKey:paginationInfo.EndIndex] {
+			result.Resources = append(result.Resources, models.GetResourceResponse{
+				Resource: models.Resource{
+					ResourceURI: resourceURI,
+				},
+				Metadata: models.Version{Version: tree.Revision()},
+			})
+		}
+	}
+	return result, nil
+}
+
+func (p StoreResourceManager) UpdateResources(params models.UpdateResourcesParams) (*models.WriteResourceResponse, error) {
+	return p.writeResources(params.ResourceContext, params.Resources)
+}
+
+func (p StoreResourceManager) GetResource(params models.GetResourceParams) (*models.GetResourceResponse, error) {
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+	revision := ""
+	if params.GitCommitID != "\"\"" {
+		revision = params.GitCommitID
+	}
+	tree, directory, err := p.getTree(params.ResourceContext, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := tree.Read(resourcePath(directory, unescapedResourceName))
+	if err != nil {
+		return nil, err
+	}
+	return &models.GetResourceResponse{
+		Resource: models.Resource{
+			ResourceURI:     params.ResourceURI,
+			ResourceContent: models.ResourceContent(base64.StdEncoding.EncodeToString(content)),
+		},
+		Metadata: models.Version{Version: tree.Revision()},
+	}, nil
+}
+
+func (p StoreResourceManager) UpdateResource(params models.UpdateResourceParams) (*models.WriteResourceResponse, error) {
+	return p.writeResources(params.ResourceContext, []models.Resource{{ResourceURI: params.ResourceURI, ResourceContent: params.ResourceContent}})
+}
+
+func (p StoreResourceManager) DeleteResource(params models.DeleteResourceParams) (*models.WriteResourceResponse, error) {
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, err
+	}
+	return p.commit(params.ResourceContext, "Deleted resources", func(tree *common.StoreTree, directory string) error {
+		if tree.Delete(resourcePath(directory, unescapedResourceName)) == 0 {
+			return kerrors.ErrResourceNotFound
+		}
+		return nil
+	})
+}
+
+func (p StoreResourceManager) GetResourceHistory(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+	_, directory, err := p.getTree(params.ResourceContext, "")
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := p.store.GetFileHistory(params.ProjectName, stageName(params.ResourceContext), resourcePath(directory, unescapedResourceName))
+	if err != nil {
+		return nil, err
+	}
+	return getPaginatedResourceHistory(commits, params.PageSize, params.NextPageKey), nil
+}
+
+func (p StoreResourceManager) GetResourceDiff(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+	tree, directory, err := p.getTree(params.ResourceContext, "")
+	if err != nil {
+		return nil, err
+	}
+
+	toRevision := params.To
+	if toRevision == "" {
+		toRevision = tree.Revision()
+	}
+	diff, err := p.store.GetFileDiff(params.ProjectName, params.From, toRevision, resourcePath(directory, unescapedResourceName))
+	if err != nil {
+		return nil, err
+	}
+	return &models.GetResourceDiffResponse{
+		ResourceURI: params.ResourceURI,
+		From:        params.From,
+		To:          toRevision,
+		Diff:        diff,
+		Metadata:    models.Version{Version: toRevision},
+	}, nil
+}
+
+// PromoteResources copies the resources of a service from the source stage to the target stage with a single commit.
+// As a store does not have an upstream repository, the changes are always persisted, regardless of the push flag
+func (p StoreResourceManager) PromoteResources(params models.PromoteResourcesParams) (*models.PromoteResourcesResponse, error) {
+	sourceContext := params.ResourceContext
+	sourceContext.Stage = &models.Stage{StageName: params.From}
+	sourceTree, directory, err := p.getTree(sourceContext, "")
+	if err != nil {
+		return nil, err
+	}
+	sourceResources, err := readStoreServiceResources(sourceTree, directory, params.PromoteResourcesPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *models.PromoteResourcesResponse
+	response, err := p.commit(params.ResourceContext, fmt.Sprintf("Promoted service %s from stage %s", params.Service.ServiceName, params.From), func(tree *common.StoreTree, directory string) error {
+		targetResources, err := readStoreServiceResources(tree, directory, params.PromoteResourcesPayload)
+		if err != nil {
+			return err
+		}
+		result, err = planPromotion(sourceResources, targetResources, params.PromoteResourcesPayload)
+		if err != nil {
+			return err
+		}
+		for _, resourceName := range append(append([]string{}, result.Added...), result.Updated...) {
+			tree.Write(resourcePath(directory, resourceName), sourceResources[resourceName])
+		}
+		for _, resourceName := range result.Deleted {
+			tree.Delete(resourcePath(directory, resourceName))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.CommitID = response.CommitID
+	result.Metadata = response.Metadata
+	return result, nil
+}
+
+// getTree returns the files of the project or stage of the given context, as well as the directory of the service, if there is one
+func (p StoreResourceManager) getTree(resourceContext models.ResourceContext, revision string) (*common.StoreTree, string, error) {
+	tree, err := p.store.GetTree(resourceContext.ProjectName, stageName(resourceContext), revision)
+	if err != nil {
+		return nil, "", err
+	}
+	directory, err := serviceDirectory(tree, resourceContext)
+	if err != nil {
+		return nil, "", err
+	}
+	return tree, directory, nil
+}
+
+func (p StoreResourceManager) writeResources(resourceContext models.ResourceContext, resources []models.Resource) (*models.WriteResourceResponse, error) {
+	return p.commit(resourceContext, "Updated resource", func(tree *common.StoreTree, directory string) error {
+		for _, resource := range resources {
+			content, err := base64.StdEncoding.DecodeString(string(resource.ResourceContent))
+			if err != nil {
+				return kerrors.ErrResourceNotBase64Encoded
+			}
+			tree.Write(resourcePath(directory, resource.ResourceURI), content)
+		}
+		return nil
+	})
+}
+
+// commit applies the changes made by the update function to the files of the project or stage of the given context
+func (p StoreResourceManager) commit(resourceContext models.ResourceContext, message string, update func(tree *common.StoreTree, directory string) error) (*models.WriteResourceResponse, error) {
+	commitID, err := p.store.Commit(resourceContext.ProjectName, stageName(resourceContext), message, func(tree *common.StoreTree) error {
+		directory, err := serviceDirectory(tree, resourceContext)
+		if err != nil {
+			return err
+		}
+		return update(tree, directory)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &models.WriteResourceResponse{
+		CommitID: commitID,
+		Metadata: models.Version{Version: commitID},
+	}, nil
+}
+
+func stageName(resourceContext models.ResourceContext) string {
+	if resourceContext.Stage == nil {
+		return ""
+	}
+	return resourceContext.Stage.StageName
+}
+
+// serviceDirectory returns the directory of the service of the given context, or an empty string if the context does not contain a service
+func serviceDirectory(tree *common.StoreTree, resourceContext models.ResourceContext) (string, error) {
+	if resourceContext.Service == nil {
+		return "", nil
+	}
+	if len(tree.Files(resourceContext.Service.ServiceName)) == 0 {
+		return "", kerrors.ErrServiceNotFound
+	}
+	return resourceContext.Service.ServiceName, nil
+}
+
+func resourcePath(directory string, resourceName string) string {
+	resourceName = strings.TrimPrefix(resourceName, "/")
+	if directory == "" {
+		return resourceName
+	}
+	return directory + "/" + resourceName
+}
+
+// readStoreServiceResources returns the content of the resources of a service that are matched by the patterns of the payload,
+// indexed by their path relative to the service directory
+func readStoreServiceResources(tree *common.StoreTree, directory string, payload models.PromoteResourcesPayload) (map[string][]byte, error) {
+	resources := map[string][]byte{}
+	for _, file := range tree.Files(directory) {
+		resourceName := strings.TrimPrefix(file, directory+"/")
+		// the metadata of the service is specific to each stage
+		if resourceName == metadataFileName || !payload.Includes(resourceName) {
+			continue
+		}
+		content, err := tree.Read(file)
+		if err != nil {
+			return nil, err
+		}
+		resources[resourceName] = content
+	}
+	return resources, nil
+}
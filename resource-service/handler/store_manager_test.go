@@ -0,0 +1,300 @@
+package handler
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/keptn/keptn/resource-service/common"
+	common_mock "github.com/keptn/keptn/resource-service/common/fake"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// getTestStoreManagers returns the store managers with a project containing the stages dev and prod, and the service my-service in both stages
+func getTestStoreManagers(t *testing.T) (*common.ConfigurationStore, StorageBackend) {
+	store := common.NewConfigurationStore(common_mock.NewMemoryObjectStore())
+	backend := StorageBackend{
+		ProjectManager:  NewStoreProjectManager(store),
+		StageManager:    NewStoreStageManager(store),
+		ServiceManager:  NewStoreServiceManager(store),
+		ResourceManager: NewStoreResourceManager(store),
+	}
+
+	project := models.Project{ProjectName: "my-project"}
+	require.Nil(t, backend.ProjectManager.CreateProject(models.CreateProjectParams{Project: project}))
+	for _, stage := range []string{"dev", "prod"} {
+		require.Nil(t, backend.StageManager.CreateStage(models.CreateStageParams{Project: project, CreateStagePayload: models.CreateStagePayload{Stage: models.Stage{StageName: stage}}}))
+		require.Nil(t, backend.ServiceManager.CreateService(models.CreateServiceParams{
+			Project:              project,
+			Stage:                models.Stage{StageName: stage},
+			CreateServicePayload: models.CreateServicePayload{Service: models.Service{ServiceName: "my-service"}},
+		}))
+	}
+	return store, backend
+}
+
+func testServiceResourceContext(stage string) models.ResourceContext {
+	return models.ResourceContext{
+		Project: models.Project{ProjectName: "my-project"},
+		Stage:   &models.Stage{StageName: stage},
+		Service: &models.Service{ServiceName: "my-service"},
+	}
+}
+
+func testResource(uri string, content string) models.Resource {
+	return models.Resource{ResourceURI: uri, ResourceContent: models.ResourceContent(base64.StdEncoding.EncodeToString([]byte(content)))}
+}
+
+func TestStoreProjectManager(t *testing.T) {
+	store, backend := getTestStoreManagers(t)
+
+	tree, err := store.GetTree("my-project", "", "")
+	require.Nil(t, err)
+	content, err := tree.Read("metadata.yaml")
+	require.Nil(t, err)
+	metadata := &common.ProjectMetadata{}
+	require.Nil(t, yaml.Unmarshal(content, metadata))
+	require.Equal(t, "my-project", metadata.ProjectName)
+
+	err = backend.ProjectManager.CreateProject(models.CreateProjectParams{Project: models.Project{ProjectName: "my-project"}})
+	require.ErrorIs(t, err, kerrors.ErrProjectAlreadyExists)
+
+	require.Nil(t, backend.ProjectManager.UpdateProject(models.UpdateProjectParams{Project: models.Project{ProjectName: "my-project"}}))
+	err = backend.ProjectManager.UpdateProject(models.UpdateProjectParams{Project: models.Project{ProjectName: "other-project"}})
+	require.ErrorIs(t, err, kerrors.ErrProjectNotFound)
+
+	require.Nil(t, backend.ProjectManager.DeleteProject("my-project"))
+	require.False(t, store.ProjectExists("my-project"))
+}
+
+func TestStoreStageManager(t *testing.T) {
+	store, backend := getTestStoreManagers(t)
+	require.True(t, store.StageExists("my-project", "dev"))
+
+	err := backend.StageManager.CreateStage(models.CreateStageParams{Project: models.Project{ProjectName: "my-project"}, CreateStagePayload: models.CreateStagePayload{Stage: models.Stage{StageName: "dev"}}})
+	require.ErrorIs(t, err, kerrors.ErrStageAlreadyExists)
+	err = backend.StageManager.CreateStage(models.CreateStageParams{Project: models.Project{ProjectName: "other-project"}, CreateStagePayload: models.CreateStagePayload{Stage: models.Stage{StageName: "dev"}}})
+	require.ErrorIs(t, err, kerrors.ErrProjectNotFound)
+
+	require.Nil(t, backend.StageManager.DeleteStage(models.DeleteStageParams{Project: models.Project{ProjectName: "my-project"}, Stage: models.Stage{StageName: "dev"}}))
+	require.False(t, store.StageExists("my-project", "dev"))
+	err = backend.StageManager.DeleteStage(models.DeleteStageParams{Project: models.Project{ProjectName: "my-project"}, Stage: models.Stage{StageName: "dev"}})
+	require.ErrorIs(t, err, kerrors.ErrStageNotFound)
+}
+
+func TestStoreServiceManager(t *testing.T) {
+	store, backend := getTestStoreManagers(t)
+
+	tree, err := store.GetTree("my-project", "dev", "")
+	require.Nil(t, err)
+	require.Equal(t, []string{"my-service/metadata.yaml"}, tree.Files("my-service"))
+
+	err = backend.ServiceManager.CreateService(models.CreateServiceParams{
+		Project:              models.Project{ProjectName: "my-project"},
+		Stage:                models.Stage{StageName: "dev"},
+		CreateServicePayload: models.CreateServicePayload{Service: models.Service{ServiceName: "my-service"}},
+	})
+	require.ErrorIs(t, err, kerrors.ErrServiceAlreadyExists)
+	err = backend.ServiceManager.CreateService(models.CreateServiceParams{
+		Project:              models.Project{ProjectName: "my-project"},
+		Stage:                models.Stage{StageName: "staging"},
+		CreateServicePayload: models.CreateServicePayload{Service: models.Service{ServiceName: "my-service"}},
+	})
+	require.ErrorIs(t, err, kerrors.ErrStageNotFound)
+
+	deleteParams := models.DeleteServiceParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Stage:   models.Stage{StageName: "dev"},
+		Service: models.Service{ServiceName: "my-service"},
+	}
+	require.Nil(t, backend.ServiceManager.DeleteService(deleteParams))
+	require.ErrorIs(t, backend.ServiceManager.DeleteService(deleteParams), kerrors.ErrServiceNotFound)
+
+	// the service of the other stage is not affected
+	tree, err = store.GetTree("my-project", "prod", "")
+	require.Nil(t, err)
+	require.Len(t, tree.Files("my-service"), 1)
+}
+
+func TestStoreResourceManager_WriteAndReadResources(t *testing.T) {
+	_, backend := getTestStoreManagers(t)
+	resourceManager := backend.ResourceManager
+
+	created, err := resourceManager.CreateResources(models.CreateResourcesParams{
+		ResourceContext: testServiceResourceContext("dev"),
+		CreateResourcesPayload: models.CreateResourcesPayload{
+			Resources: []models.Resource{testResource("helm/values.yaml", "replicas: 1"), testResource("/slo.yaml", "objectives")},
+		},
+	})
+	require.Nil(t, err)
+	require.Equal(t, created.CommitID, created.Metadata.Version)
+
+	resources, err := resourceManager.GetResources(models.GetResourcesParams{
+		ResourceContext:   testServiceResourceContext("dev"),
+		GetResourcesQuery: models.GetResourcesQuery{PageSize: 2},
+	})
+	require.Nil(t, err)
+	require.Equal(t, float64(3), resources.TotalCount)
+	require.Equal(t, "2", resources.NextPageKey)
+	require.Len(t, resources.Resources, 2)
+	require.Equal(t, "helm/values.yaml", resources.Resources[0].ResourceURI)
+	require.Equal(t, "metadata.yaml", resources.Resources[1].ResourceURI)
+	require.Equal(t, created.CommitID, resources.Resources[0].Metadata.Version)
+
+	updated, err := resourceManager.UpdateResource(models.UpdateResourceParams{
+		ResourceContext:       testServiceResourceContext("dev"),
+		ResourceURI:           "slo.yaml",
+		UpdateResourcePayload: models.UpdateResourcePayload{ResourceContent: testResource("", "new objectives").ResourceContent},
+	})
+	require.Nil(t, err)
+	require.NotEqual(t, created.CommitID, updated.CommitID)
+
+	resource, err := resourceManager.GetResource(models.GetResourceParams{ResourceContext: testServiceResourceContext("dev"), ResourceURI: "slo.yaml"})
+	require.Nil(t, err)
+	require.Equal(t, testResource("slo.yaml", "new objectives"), resource.Resource)
+	require.Equal(t, updated.CommitID, resource.Metadata.Version)
+
+	// previous revisions can be read by their commit ID
+	resource, err = resourceManager.GetResource(models.GetResourceParams{
+		ResourceContext:  testServiceResourceContext("dev"),
+		ResourceURI:      "slo.yaml",
+		GetResourceQuery: models.GetResourceQuery{GitCommitID: created.CommitID},
+	})
+	require.Nil(t, err)
+	require.Equal(t, testResource("slo.yaml", "objectives"), resource.Resource)
+
+	// the resources of the other stage are not affected
+	_, err = resourceManager.GetResource(models.GetResourceParams{ResourceContext: testServiceResourceContext("prod"), ResourceURI: "slo.yaml"})
+	require.ErrorIs(t, err, kerrors.ErrResourceNotFound)
+
+	_, err = resourceManager.DeleteResource(models.DeleteResourceParams{ResourceContext: testServiceResourceContext("dev"), ResourceURI: "helm"})
+	require.Nil(t, err)
+	_, err = resourceManager.DeleteResource(models.DeleteResourceParams{ResourceContext: testServiceResourceContext("dev"), ResourceURI: "helm"})
+	require.ErrorIs(t, err, kerrors.ErrResourceNotFound)
+	_, err = resourceManager.GetResource(models.GetResourceParams{ResourceContext: testServiceResourceContext("dev"), ResourceURI: "helm%2Fvalues.yaml"})
+	require.ErrorIs(t, err, kerrors.ErrResourceNotFound)
+}
+
+func TestStoreResourceManager_ProjectResources(t *testing.T) {
+	_, backend := getTestStoreManagers(t)
+	projectContext := models.ResourceContext{Project: models.Project{ProjectName: "my-project"}}
+
+	_, err := backend.ResourceManager.CreateResources(models.CreateResourcesParams{
+		ResourceContext:        projectContext,
+		CreateResourcesPayload: models.CreateResourcesPayload{Resources: []models.Resource{testResource("shipyard.yaml", "stages")}},
+	})
+	require.Nil(t, err)
+
+	resources, err := backend.ResourceManager.GetResources(models.GetResourcesParams{ResourceContext: projectContext, GetResourcesQuery: models.GetResourcesQuery{PageSize: 10}})
+	require.Nil(t, err)
+	require.Len(t, resources.Resources, 2)
+	require.Equal(t, "metadata.yaml", resources.Resources[0].ResourceURI)
+	require.Equal(t, "shipyard.yaml", resources.Resources[1].ResourceURI)
+}
+
+func TestStoreResourceManager_UnknownService(t *testing.T) {
+	_, backend := getTestStoreManagers(t)
+	resourceContext := testServiceResourceContext("dev")
+	resourceContext.Service.ServiceName = "other-service"
+
+	_, err := backend.ResourceManager.CreateResources(models.CreateResourcesParams{
+		ResourceContext:        resourceContext,
+		CreateResourcesPayload: models.CreateResourcesPayload{Resources: []models.Resource{testResource("slo.yaml", "objectives")}},
+	})
+	require.ErrorIs(t, err, kerrors.ErrServiceNotFound)
+
+	_, err = backend.ResourceManager.GetResources(models.GetResourcesParams{ResourceContext: resourceContext, GetResourcesQuery: models.GetResourcesQuery{PageSize: 10}})
+	require.ErrorIs(t, err, kerrors.ErrServiceNotFound)
+
+	_, err = backend.ResourceManager.GetResources(models.GetResourcesParams{ResourceContext: testServiceResourceContext("staging"), GetResourcesQuery: models.GetResourcesQuery{PageSize: 10}})
+	require.ErrorIs(t, err, kerrors.ErrStageNotFound)
+}
+
+func TestStoreResourceManager_HistoryAndDiff(t *testing.T) {
+	_, backend := getTestStoreManagers(t)
+	resourceManager := backend.ResourceManager
+
+	var commits []string
+	for _, content := range []string{"a\nb\n", "a\nc\n"} {
+		response, err := resourceManager.UpdateResource(models.UpdateResourceParams{
+			ResourceContext:       testServiceResourceContext("dev"),
+			ResourceURI:           "slo.yaml",
+			UpdateResourcePayload: models.UpdateResourcePayload{ResourceContent: testResource("", content).ResourceContent},
+		})
+		require.Nil(t, err)
+		commits = append(commits, response.CommitID)
+	}
+
+	history, err := resourceManager.GetResourceHistory(models.GetResourceHistoryParams{
+		ResourceContext:         testServiceResourceContext("dev"),
+		ResourceURI:             "slo.yaml",
+		GetResourceHistoryQuery: models.GetResourceHistoryQuery{PageSize: 10},
+	})
+	require.Nil(t, err)
+	require.Equal(t, float64(2), history.TotalCount)
+	require.Equal(t, commits[1], history.Commits[0].CommitID)
+	require.Equal(t, commits[0], history.Commits[1].CommitID)
+
+	diff, err := resourceManager.GetResourceDiff(models.GetResourceDiffParams{
+		ResourceContext:      testServiceResourceContext("dev"),
+		ResourceURI:          "slo.yaml",
+		GetResourceDiffQuery: models.GetResourceDiffQuery{From: commits[0]},
+	})
+	require.Nil(t, err)
+	require.Equal(t, commits[1], diff.To)
+	require.Contains(t, diff.Diff, "--- a/my-service/slo.yaml\n+++ b/my-service/slo.yaml\n")
+	require.Contains(t, diff.Diff, " a\n-b\n+c\n")
+}
+
+func TestStoreResourceManager_PromoteResources(t *testing.T) {
+	_, backend := getTestStoreManagers(t)
+	resourceManager := backend.ResourceManager
+
+	_, err := resourceManager.CreateResources(models.CreateResourcesParams{
+		ResourceContext: testServiceResourceContext("dev"),
+		CreateResourcesPayload: models.CreateResourcesPayload{
+			Resources: []models.Resource{testResource("helm/values.yaml", "replicas: 2"), testResource("slo.yaml", "objectives")},
+		},
+	})
+	require.Nil(t, err)
+	_, err = resourceManager.CreateResources(models.CreateResourcesParams{
+		ResourceContext: testServiceResourceContext("prod"),
+		CreateResourcesPayload: models.CreateResourcesPayload{
+			Resources: []models.Resource{testResource("helm/values.yaml", "replicas: 1"), testResource("sli.yaml", "indicators")},
+		},
+	})
+	require.Nil(t, err)
+
+	params := models.PromoteResourcesParams{
+		ResourceContext:         testServiceResourceContext("prod"),
+		PromoteResourcesQuery:   models.PromoteResourcesQuery{From: "dev"},
+		PromoteResourcesPayload: models.PromoteResourcesPayload{Strategy: models.PromotionStrategyMerge},
+	}
+	_, err = resourceManager.PromoteResources(params)
+	require.ErrorIs(t, err, kerrors.ErrPromotionConflict)
+
+	params.PromoteResourcesPayload = models.PromoteResourcesPayload{Strategy: models.PromotionStrategyCopy}
+	result, err := resourceManager.PromoteResources(params)
+	require.Nil(t, err)
+	require.Equal(t, []string{"slo.yaml"}, result.Added)
+	require.Equal(t, []string{"helm/values.yaml"}, result.Updated)
+	require.Equal(t, []string{"sli.yaml"}, result.Deleted)
+	require.Equal(t, result.CommitID, result.Metadata.Version)
+
+	resources, err := resourceManager.GetResources(models.GetResourcesParams{ResourceContext: testServiceResourceContext("prod"), GetResourcesQuery: models.GetResourcesQuery{PageSize: 10}})
+	require.Nil(t, err)
+	require.Len(t, resources.Resources, 3)
+	resource, err := resourceManager.GetResource(models.GetResourceParams{ResourceContext: testServiceResourceContext("prod"), ResourceURI: "helm%2Fvalues.yaml"})
+	require.Nil(t, err)
+	require.Equal(t, testResource("helm%2Fvalues.yaml", "replicas: 2"), resource.Resource)
+
+	// promoting again does not change anything
+	again, err := resourceManager.PromoteResources(params)
+	require.Nil(t, err)
+	require.Empty(t, again.Added)
+	require.Empty(t, again.Updated)
+	require.Empty(t, again.Deleted)
+	require.Equal(t, result.CommitID, again.CommitID)
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+)
+
+// maxWebhookPayloadSize limits the size of the payloads that are buffered before their signature has been verified,
+// since the webhook endpoint is not protected by a Keptn API token
+const maxWebhookPayloadSize = 5 << 20
+
+type IWebhookHandler interface {
+	ReceiveGitWebhook(context *gin.Context)
+}
+
+type WebhookHandler struct {
+	WebhookManager IWebhookManager
+}
+
+func NewWebhookHandler(webhookManager IWebhookManager) *WebhookHandler {
+	return &WebhookHandler{
+		WebhookManager: webhookManager,
+	}
+}
+
+// ReceiveGitWebhook godoc
+// @Summary      Receive a push webhook of the upstream repository
+// @Description  Pull the branches of the project that have been pushed to the upstream repository and send a sh.keptn.event.configuration.changed event for each changed stage.
+// @Description  Push webhooks of GitHub, GitLab, Gitea and Bitbucket are supported. The webhook has to be signed with the webhook secret of the project
+// @Tags         Webhook
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string  true  "The name of the project"
+// @Success      200          {object}  models.SyncProjectResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      401          {object}  models.Error  "Invalid signature"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      413          {object}  models.Error  "Payload too large"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/webhook [post]
+func (wh *WebhookHandler) ReceiveGitWebhook(c *gin.Context) {
+	params := &models.SyncProjectParams{
+		Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+	}
+
+	// the signature is calculated over the raw payload, so it must not be bound
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxWebhookPayloadSize))
+	if err != nil {
+		// the reader fails once the limit has been reached
+		if len(body) >= maxWebhookPayloadSize {
+			SetRequestEntityTooLargeErrorResponse(c, "Webhook payload too large")
+			return
+		}
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	secret, err := wh.WebhookManager.GetWebhookSecret(params.ProjectName)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+	push, err := ParseGitPush(c.Request.Header, body, secret)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+	if push == nil {
+		// e.g. a ping sent when the webhook has been set up
+		c.JSON(http.StatusOK, models.SyncProjectResponse{Changes: []models.ConfigurationChange{}})
+		return
+	}
+	params.Push = *push
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := wh.WebhookManager.SyncProject(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
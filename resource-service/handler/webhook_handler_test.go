@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/resource-service/common"
+	errors2 "github.com/keptn/keptn/resource-service/errors"
+	handler_mock "github.com/keptn/keptn/resource-service/handler/fake"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWebhookRequest(project string, event string, payload string, signature string) *http.Request {
+	request := httptest.NewRequest(http.MethodPost, "/project/"+project+"/webhook", bytes.NewBuffer([]byte(payload)))
+	request.Header.Set("X-GitHub-Event", event)
+	request.Header.Set("X-Hub-Signature-256", "sha256="+signature)
+	return request
+}
+
+func TestWebhookHandler_ReceiveGitWebhook(t *testing.T) {
+	syncResponse := &models.SyncProjectResponse{Changes: []models.ConfigurationChange{
+		{Stage: "dev", Branch: "dev", CommitID: "2222222222222222222222222222222222222222", ChangedFiles: []string{"my-service/slo.yaml"}},
+	}}
+	type fields struct {
+		WebhookManager *handler_mock.IWebhookManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.SyncProjectParams
+		wantStatus int
+	}{
+		{
+			name: "push received",
+			fields: fields{
+				WebhookManager: &handler_mock.IWebhookManagerMock{
+					GetWebhookSecretFunc: func(projectName string) (string, error) { return testWebhookSecret, nil },
+					SyncProjectFunc: func(params models.SyncProjectParams) (*models.SyncProjectResponse, error) {
+						return syncResponse, nil
+					},
+				},
+			},
+			request: newTestWebhookRequest("my-project", "push", testPushPayload, signTestPayload(testPushPayload)),
+			wantParams: &models.SyncProjectParams{
+				Project: models.Project{ProjectName: "my-project"},
+				Push: models.GitPush{Provider: common.GitProviderGitHub, Branches: []models.GitBranchUpdate{{
+					Branch:       "dev",
+					Before:       "1111111111111111111111111111111111111111",
+					After:        "2222222222222222222222222222222222222222",
+					ChangedFiles: []string{"my-service/new.yaml", "my-service/slo.yaml", "my-service/old.yaml"},
+				}}},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "ping received",
+			fields: fields{
+				WebhookManager: &handler_mock.IWebhookManagerMock{
+					GetWebhookSecretFunc: func(projectName string) (string, error) { return testWebhookSecret, nil },
+					SyncProjectFunc: func(params models.SyncProjectParams) (*models.SyncProjectResponse, error) {
+						return nil, errors.New("should not have been called")
+					},
+				},
+			},
+			request:    newTestWebhookRequest("my-project", "ping", `{}`, signTestPayload(`{}`)),
+			wantParams: nil,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "invalid signature",
+			fields: fields{
+				WebhookManager: &handler_mock.IWebhookManagerMock{
+					GetWebhookSecretFunc: func(projectName string) (string, error) { return testWebhookSecret, nil },
+					SyncProjectFunc: func(params models.SyncProjectParams) (*models.SyncProjectResponse, error) {
+						return nil, errors.New("should not have been called")
+					},
+				},
+			},
+			request:    newTestWebhookRequest("my-project", "push", testPushPayload, signTestPayload("other")),
+			wantParams: nil,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "no webhook secret",
+			fields: fields{
+				WebhookManager: &handler_mock.IWebhookManagerMock{
+					GetWebhookSecretFunc: func(projectName string) (string, error) { return "", errors2.ErrWebhookSecretNotFound },
+					SyncProjectFunc: func(params models.SyncProjectParams) (*models.SyncProjectResponse, error) {
+						return nil, errors.New("should not have been called")
+					},
+				},
+			},
+			request:    newTestWebhookRequest("my-project", "push", testPushPayload, signTestPayload(testPushPayload)),
+			wantParams: nil,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "webhooks not supported by the storage backend",
+			fields: fields{
+				WebhookManager: &handler_mock.IWebhookManagerMock{
+					GetWebhookSecretFunc: func(projectName string) (string, error) { return "", errors2.ErrWebhookNotSupported },
+					SyncProjectFunc: func(params models.SyncProjectParams) (*models.SyncProjectResponse, error) {
+						return nil, errors.New("should not have been called")
+					},
+				},
+			},
+			request:    newTestWebhookRequest("my-project", "push", testPushPayload, signTestPayload(testPushPayload)),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "project not found",
+			fields: fields{
+				WebhookManager: &handler_mock.IWebhookManagerMock{
+					GetWebhookSecretFunc: func(projectName string) (string, error) { return testWebhookSecret, nil },
+					SyncProjectFunc: func(params models.SyncProjectParams) (*models.SyncProjectResponse, error) {
+						return nil, errors2.ErrProjectNotFound
+					},
+				},
+			},
+			request: newTestWebhookRequest("my-project", "push", testPushPayload, signTestPayload(testPushPayload)),
+			wantParams: &models.SyncProjectParams{
+				Project: models.Project{ProjectName: "my-project"},
+				Push: models.GitPush{Provider: common.GitProviderGitHub, Branches: []models.GitBranchUpdate{{
+					Branch:       "dev",
+					Before:       "1111111111111111111111111111111111111111",
+					After:        "2222222222222222222222222222222222222222",
+					ChangedFiles: []string{"my-service/new.yaml", "my-service/slo.yaml", "my-service/old.yaml"},
+				}}},
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "payload too large",
+			fields: fields{
+				WebhookManager: &handler_mock.IWebhookManagerMock{
+					GetWebhookSecretFunc: func(projectName string) (string, error) { return testWebhookSecret, nil },
+					SyncProjectFunc: func(params models.SyncProjectParams) (*models.SyncProjectResponse, error) {
+						return nil, errors.New("should not have been called")
+					},
+				},
+			},
+			request:    newTestWebhookRequest("my-project", "push", strings.Repeat(" ", maxWebhookPayloadSize)+testPushPayload, ""),
+			wantParams: nil,
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "unknown provider",
+			fields: fields{
+				WebhookManager: &handler_mock.IWebhookManagerMock{
+					GetWebhookSecretFunc: func(projectName string) (string, error) { return testWebhookSecret, nil },
+					SyncProjectFunc: func(params models.SyncProjectParams) (*models.SyncProjectResponse, error) {
+						return nil, errors.New("should not have been called")
+					},
+				},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/webhook", bytes.NewBuffer([]byte(testPushPayload))),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wh := NewWebhookHandler(tt.fields.WebhookManager)
+
+			router := gin.Default()
+			router.POST("/project/:projectName/webhook", wh.ReceiveGitWebhook)
+
+			resp := performRequest(router, tt.request)
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.WebhookManager.SyncProjectCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.WebhookManager.SyncProjectCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.WebhookManager.SyncProjectCalls())
+			}
+		})
+	}
+}
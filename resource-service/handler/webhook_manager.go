@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/resource-service/common"
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	logger "github.com/sirupsen/logrus"
+)
+
+const keptnEventSource = "resource-service"
+const shipyardFileName = "shipyard.yaml"
+
+// IWebhookManager provides an interface for synchronizing projects with their upstream repository when it has been changed
+//
+//go:generate moq -pkg handler_mock -skip-ensure -out ./fake/webhook_manager_mock.go . IWebhookManager
+type IWebhookManager interface {
+	GetWebhookSecret(projectName string) (string, error)
+	SyncProject(params models.SyncProjectParams) (*models.SyncProjectResponse, error)
+}
+
+type GitWebhookManager struct {
+	git                     common.IGit
+	credentialReader        common.CredentialReader
+	secretReader            common.WebhookSecretReader
+	eventPublisher          common.EventPublisher
+	directoryStageStructure bool
+	defaultSecret           string
+}
+
+// NewGitWebhookManager creates a manager that pulls the projects changed by a push. Projects without a webhook secret of their own
+// use the default secret, if it is set
+func NewGitWebhookManager(git common.IGit, credentialReader common.CredentialReader, secretReader common.WebhookSecretReader, eventPublisher common.EventPublisher, directoryStageStructure bool, defaultSecret string) *GitWebhookManager {
+	return &GitWebhookManager{
+		git:                     git,
+		credentialReader:        credentialReader,
+		secretReader:            secretReader,
+		eventPublisher:          eventPublisher,
+		directoryStageStructure: directoryStageStructure,
+		defaultSecret:           defaultSecret,
+	}
+}
+
+func (w GitWebhookManager) GetWebhookSecret(projectName string) (string, error) {
+	secret, err := w.secretReader.GetWebhookSecret(projectName)
+	if errors.Is(err, kerrors.ErrWebhookSecretNotFound) && w.defaultSecret != "" {
+		return w.defaultSecret, nil
+	}
+	return secret, err
+}
+
+// SyncProject pulls the pushed branches of the project and sends a sh.keptn.event.configuration.changed event for each stage that has been changed
+func (w GitWebhookManager) SyncProject(params models.SyncProjectParams) (*models.SyncProjectResponse, error) {
+	response, err := w.pullChanges(params)
+	if err != nil {
+		return nil, err
+	}
+	for _, change := range response.Changes {
+		w.publishConfigurationChangedEvent(params, change)
+	}
+	return response, nil
+}
+
+func (w GitWebhookManager) pullChanges(params models.SyncProjectParams) (*models.SyncProjectResponse, error) {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	credentials, err := w.credentialReader.GetCredentials(params.ProjectName)
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotRetrieveCredentials, params.ProjectName, err)
+	}
+	gitContext := common_models.GitContext{
+		Project:     params.ProjectName,
+		Credentials: credentials,
+	}
+	if !w.git.ProjectExists(gitContext) {
+		return nil, kerrors.ErrProjectNotFound
+	}
+
+	defaultBranch, err := w.git.GetDefaultBranch(gitContext)
+	if err != nil {
+		return nil, err
+	}
+	// checking out a branch fetches all branches of the upstream repository
+	if err := w.git.CheckoutBranch(gitContext, defaultBranch); err != nil {
+		return nil, err
+	}
+	if err := w.git.Pull(gitContext); err != nil {
+		return nil, err
+	}
+	stages, err := w.getStages(gitContext, defaultBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.SyncProjectResponse{Changes: []models.ConfigurationChange{}}
+	for _, update := range params.Push.Branches {
+		if update.Branch != defaultBranch {
+			// with the directory structure, all stages are kept in the default branch
+			if w.directoryStageStructure || !containsString(stages, update.Branch) {
+				logger.Debugf("Ignoring push to branch %s of project %s, which does not belong to a stage", update.Branch, params.ProjectName)
+				continue
+			}
+			if err := w.git.CheckoutBranch(gitContext, update.Branch); err != nil {
+				return nil, err
+			}
+			if err := w.git.Pull(gitContext); err != nil {
+				return nil, err
+			}
+		}
+		changedFiles := w.getChangedFiles(gitContext, update)
+		if update.Branch == defaultBranch {
+			response.Changes = append(response.Changes, groupChangesByStageDirectory(update, changedFiles, w.directoryStageStructure)...)
+		} else {
+			response.Changes = append(response.Changes, models.ConfigurationChange{
+				Stage:        update.Branch,
+				Branch:       update.Branch,
+				CommitID:     update.After,
+				ChangedFiles: changedFiles,
+			})
+		}
+	}
+	return response, nil
+}
+
+// getStages returns the stages defined in the shipyard file of the default branch
+func (w GitWebhookManager) getStages(gitContext common_models.GitContext, defaultBranch string) ([]string, error) {
+	content, err := w.git.GetFileRevision(gitContext, defaultBranch, shipyardFileName)
+	if errors.Is(err, kerrors.ErrResourceNotFound) {
+		return []string{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	shipyard, err := keptnv2.DecodeShipyardYAML(content)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode shipyard of project %s: %w", gitContext.Project, err)
+	}
+	stages := []string{}
+	for _, stage := range shipyard.Spec.Stages {
+		stages = append(stages, stage.Name)
+	}
+	return stages, nil
+}
+
+// getChangedFiles compares the revisions before and after the push. The files listed in the webhook payload are only used if
+// the previous revision is not known, e.g. because the branch has been created by the push
+func (w GitWebhookManager) getChangedFiles(gitContext common_models.GitContext, update models.GitBranchUpdate) []string {
+	if update.Before != "" {
+		changedFiles, err := w.git.GetChangedFiles(gitContext, update.Before, update.After)
+		if err == nil {
+			return changedFiles
+		}
+		logger.Debugf("Could not compare revisions %s and %s of project %s: %v", update.Before, update.After, gitContext.Project, err)
+	}
+	if update.ChangedFiles == nil {
+		return []string{}
+	}
+	return update.ChangedFiles
+}
+
+// groupChangesByStageDirectory assigns the changed files of the default branch to the stages whose directories contain them.
+// Files outside of the stage directories belong to the project
+func groupChangesByStageDirectory(update models.GitBranchUpdate, changedFiles []string, directoryStageStructure bool) []models.ConfigurationChange {
+	changes := []models.ConfigurationChange{}
+	projectChange := models.ConfigurationChange{Branch: update.Branch, CommitID: update.After, ChangedFiles: []string{}}
+	stageChanges := map[string]int{}
+	for _, file := range changedFiles {
+		stage := ""
+		if directoryStageStructure && strings.HasPrefix(file, common.StageDirectoryName+"/") {
+			stage = strings.SplitN(strings.TrimPrefix(file, common.StageDirectoryName+"/"), "/", 2)[0]
+		}
+		if stage == "" {
+			projectChange.ChangedFiles = append(projectChange.ChangedFiles, file)
+			continue
+		}
+		if _, ok := stageChanges[stage]; !ok {
+			stageChanges[stage] = len(changes)
+			changes = append(changes, models.ConfigurationChange{Stage: stage, Branch: update.Branch, CommitID: update.After, ChangedFiles: []string{}})
+		}
+		changes[stageChanges[stage]].ChangedFiles = append(changes[stageChanges[stage]].ChangedFiles, file)
+	}
+	if len(projectChange.ChangedFiles) > 0 || len(changes) == 0 {
+		changes = append([]models.ConfigurationChange{projectChange}, changes...)
+	}
+	return changes
+}
+
+func (w GitWebhookManager) publishConfigurationChangedEvent(params models.SyncProjectParams, change models.ConfigurationChange) {
+	data := models.ConfigurationChangedEventData{
+		Project:      params.ProjectName,
+		Stage:        change.Stage,
+		Provider:     params.Push.Provider,
+		Branch:       change.Branch,
+		CommitID:     change.CommitID,
+		ChangedFiles: change.ChangedFiles,
+	}
+	event := keptnv2.KeptnEvent(models.ConfigurationChangedEventType, keptnEventSource, data).WithKeptnContext(uuid.NewString()).KeptnContextExtendedCE
+	event.GitCommitID = change.CommitID
+	if err := w.eventPublisher.Publish(event); err != nil {
+		logger.WithError(err).Errorf("Could not send %s event for project %s", models.ConfigurationChangedEventType, params.ProjectName)
+	}
+}
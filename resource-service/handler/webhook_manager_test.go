@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"errors"
+	"testing"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/resource-service/common"
+	common_mock "github.com/keptn/keptn/resource-service/common/fake"
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+)
+
+const testWebhookShipyard = `apiVersion: "spec.keptn.sh/0.2.3"
+kind: "Shipyard"
+metadata:
+  name: "shipyard"
+spec:
+  stages:
+    - name: "dev"
+    - name: "production"
+`
+
+type testWebhookManagerFields struct {
+	git              *common_mock.IGitMock
+	credentialReader *common_mock.CredentialReaderMock
+	secretReader     *common_mock.WebhookSecretReaderMock
+	eventPublisher   *common_mock.EventPublisherMock
+}
+
+func getTestWebhookManagerFields() testWebhookManagerFields {
+	return testWebhookManagerFields{
+		git: &common_mock.IGitMock{
+			CheckoutBranchFunc:   func(gitContext common_models.GitContext, branch string) error { return nil },
+			GetDefaultBranchFunc: func(gitContext common_models.GitContext) (string, error) { return "main", nil },
+			GetFileRevisionFunc: func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+				return []byte(testWebhookShipyard), nil
+			},
+			GetChangedFilesFunc: func(gitContext common_models.GitContext, fromRevision string, toRevision string) ([]string, error) {
+				return []string{"my-service/slo.yaml"}, nil
+			},
+			ProjectExistsFunc: func(gitContext common_models.GitContext) bool { return true },
+			PullFunc:          func(gitContext common_models.GitContext) error { return nil },
+		},
+		credentialReader: &common_mock.CredentialReaderMock{
+			GetCredentialsFunc: func(project string) (*common_models.GitCredentials, error) {
+				return &common_models.GitCredentials{
+					User:      "user",
+					Token:     "token",
+					RemoteURI: "remote-url",
+				}, nil
+			},
+		},
+		secretReader: &common_mock.WebhookSecretReaderMock{
+			GetWebhookSecretFunc: func(project string) (string, error) {
+				return "", kerrors.ErrWebhookSecretNotFound
+			},
+		},
+		eventPublisher: &common_mock.EventPublisherMock{
+			PublishFunc: func(event apimodels.KeptnContextExtendedCE) error { return nil },
+		},
+	}
+}
+
+func TestGitWebhookManager_GetWebhookSecret(t *testing.T) {
+	fields := getTestWebhookManagerFields()
+
+	wm := NewGitWebhookManager(fields.git, fields.credentialReader, fields.secretReader, fields.eventPublisher, false, "")
+	_, err := wm.GetWebhookSecret("my-project")
+	require.ErrorIs(t, err, kerrors.ErrWebhookSecretNotFound)
+
+	wm = NewGitWebhookManager(fields.git, fields.credentialReader, fields.secretReader, fields.eventPublisher, false, "default-secret")
+	secret, err := wm.GetWebhookSecret("my-project")
+	require.Nil(t, err)
+	require.Equal(t, "default-secret", secret)
+
+	fields.secretReader.GetWebhookSecretFunc = func(project string) (string, error) {
+		return "project-secret", nil
+	}
+	secret, err = wm.GetWebhookSecret("my-project")
+	require.Nil(t, err)
+	require.Equal(t, "project-secret", secret)
+}
+
+func TestGitWebhookManager_SyncProject_BranchStructure(t *testing.T) {
+	fields := getTestWebhookManagerFields()
+
+	wm := NewGitWebhookManager(fields.git, fields.credentialReader, fields.secretReader, fields.eventPublisher, false, "")
+
+	response, err := wm.SyncProject(models.SyncProjectParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Push: models.GitPush{
+			Provider: common.GitProviderGitHub,
+			Branches: []models.GitBranchUpdate{
+				{Branch: "dev", Before: "rev-1", After: "rev-2", ChangedFiles: []string{"from-payload.yaml"}},
+				{Branch: "feature", Before: "rev-1", After: "rev-3"},
+				{Branch: "main", After: "rev-4", ChangedFiles: []string{"shipyard.yaml"}},
+			},
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.SyncProjectResponse{Changes: []models.ConfigurationChange{
+		{Stage: "dev", Branch: "dev", CommitID: "rev-2", ChangedFiles: []string{"my-service/slo.yaml"}},
+		{Branch: "main", CommitID: "rev-4", ChangedFiles: []string{"shipyard.yaml"}},
+	}}, response)
+
+	// the default branch is pulled first, then the pushed stage
+	require.Len(t, fields.git.CheckoutBranchCalls(), 2)
+	require.Equal(t, "main", fields.git.CheckoutBranchCalls()[0].Branch)
+	require.Equal(t, "dev", fields.git.CheckoutBranchCalls()[1].Branch)
+	require.Len(t, fields.git.PullCalls(), 2)
+	require.Len(t, fields.git.GetChangedFilesCalls(), 1)
+	require.Equal(t, "rev-1", fields.git.GetChangedFilesCalls()[0].FromRevision)
+	require.Equal(t, "rev-2", fields.git.GetChangedFilesCalls()[0].ToRevision)
+
+	require.Len(t, fields.eventPublisher.PublishCalls(), 2)
+	event := fields.eventPublisher.PublishCalls()[0].Event
+	require.Equal(t, models.ConfigurationChangedEventType, *event.Type)
+	require.Equal(t, "rev-2", event.GitCommitID)
+	require.NotEmpty(t, event.Shkeptncontext)
+	require.Equal(t, models.ConfigurationChangedEventData{
+		Project:      "my-project",
+		Stage:        "dev",
+		Provider:     common.GitProviderGitHub,
+		Branch:       "dev",
+		CommitID:     "rev-2",
+		ChangedFiles: []string{"my-service/slo.yaml"},
+	}, event.Data)
+}
+
+func TestGitWebhookManager_SyncProject_DirectoryStructure(t *testing.T) {
+	fields := getTestWebhookManagerFields()
+	fields.git.GetChangedFilesFunc = func(gitContext common_models.GitContext, fromRevision string, toRevision string) ([]string, error) {
+		return []string{".keptn-stages/dev/my-service/slo.yaml", ".keptn-stages/production/my-service/slo.yaml", ".keptn-stages/dev/my-service/sli.yaml"}, nil
+	}
+
+	wm := NewGitWebhookManager(fields.git, fields.credentialReader, fields.secretReader, fields.eventPublisher, true, "")
+
+	response, err := wm.SyncProject(models.SyncProjectParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Push: models.GitPush{
+			Provider: common.GitProviderGitLab,
+			Branches: []models.GitBranchUpdate{
+				{Branch: "main", Before: "rev-1", After: "rev-2"},
+				{Branch: "dev", Before: "rev-1", After: "rev-3"},
+			},
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.SyncProjectResponse{Changes: []models.ConfigurationChange{
+		{Stage: "dev", Branch: "main", CommitID: "rev-2", ChangedFiles: []string{".keptn-stages/dev/my-service/slo.yaml", ".keptn-stages/dev/my-service/sli.yaml"}},
+		{Stage: "production", Branch: "main", CommitID: "rev-2", ChangedFiles: []string{".keptn-stages/production/my-service/slo.yaml"}},
+	}}, response)
+	require.Len(t, fields.git.CheckoutBranchCalls(), 1)
+	require.Len(t, fields.eventPublisher.PublishCalls(), 2)
+}
+
+func TestGitWebhookManager_SyncProject_UnknownPreviousRevision(t *testing.T) {
+	fields := getTestWebhookManagerFields()
+	fields.git.GetChangedFilesFunc = func(gitContext common_models.GitContext, fromRevision string, toRevision string) ([]string, error) {
+		return nil, kerrors.ErrResolveRevision
+	}
+
+	wm := NewGitWebhookManager(fields.git, fields.credentialReader, fields.secretReader, fields.eventPublisher, false, "")
+
+	response, err := wm.SyncProject(models.SyncProjectParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Push: models.GitPush{
+			Provider: common.GitProviderGitHub,
+			Branches: []models.GitBranchUpdate{
+				{Branch: "dev", Before: "rev-1", After: "rev-2", ChangedFiles: []string{"my-service/slo.yaml"}},
+				{Branch: "production", After: "rev-3"},
+			},
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, []string{"my-service/slo.yaml"}, response.Changes[0].ChangedFiles)
+	require.Equal(t, []string{}, response.Changes[1].ChangedFiles)
+}
+
+func TestGitWebhookManager_SyncProject_PublishFails(t *testing.T) {
+	fields := getTestWebhookManagerFields()
+	fields.eventPublisher.PublishFunc = func(event apimodels.KeptnContextExtendedCE) error {
+		return errors.New("oops")
+	}
+
+	wm := NewGitWebhookManager(fields.git, fields.credentialReader, fields.secretReader, fields.eventPublisher, false, "")
+
+	// the project has been synchronized anyway
+	response, err := wm.SyncProject(models.SyncProjectParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Push:    models.GitPush{Provider: common.GitProviderGitHub, Branches: []models.GitBranchUpdate{{Branch: "dev", Before: "rev-1", After: "rev-2"}}},
+	})
+	require.Nil(t, err)
+	require.Len(t, response.Changes, 1)
+}
+
+func TestGitWebhookManager_SyncProject_ProjectNotFound(t *testing.T) {
+	fields := getTestWebhookManagerFields()
+	fields.git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool { return false }
+
+	wm := NewGitWebhookManager(fields.git, fields.credentialReader, fields.secretReader, fields.eventPublisher, false, "")
+
+	_, err := wm.SyncProject(models.SyncProjectParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Push:    models.GitPush{Provider: common.GitProviderGitHub, Branches: []models.GitBranchUpdate{{Branch: "dev", After: "rev-2"}}},
+	})
+	require.ErrorIs(t, err, kerrors.ErrProjectNotFound)
+	require.Empty(t, fields.eventPublisher.PublishCalls())
+}
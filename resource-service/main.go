@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"github.com/kelseyhightower/envconfig"
 	"github.com/keptn/keptn/resource-service/common"
 	"io/ioutil"
@@ -12,13 +13,18 @@ import (
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	keptnmongoutils "github.com/keptn/go-utils/pkg/common/mongoutils"
 	"github.com/keptn/go-utils/pkg/common/osutils"
+	"github.com/keptn/keptn/cp-common/audit"
 	"github.com/keptn/keptn/resource-service/config"
 	"github.com/keptn/keptn/resource-service/controller"
 	"github.com/keptn/keptn/resource-service/handler"
 	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // @title        Resource Service API
@@ -71,6 +77,14 @@ func main() {
 	wg := &sync.WaitGroup{}
 	engine.Use(handler.GracefulShutdownMiddleware(wg))
 
+	auditClient := audit.NewClient(config.Global.ShipyardControllerURL, config.Global.InternalAPIToken, nil)
+	auditDone := make(chan struct{})
+	go func() {
+		auditClient.Run(ctx)
+		close(auditDone)
+	}()
+	engine.Use(handler.AuditMiddleware(auditClient))
+
 	apiV1 := engine.Group("/v1")
 	apiHealth := engine.Group("")
 
@@ -84,37 +98,51 @@ func main() {
 
 	git := common.NewGit(&common.GogitReal{})
 	configurationContext := createConfigurationContext(git, fileSystem)
+	eventPublisher := common.NewNatsEventPublisher()
+	defer eventPublisher.Close()
+
+	pullRequestManager := handler.NewGitPullRequestManager(git, common.NewForgeClient(nil), eventPublisher)
+
+	gitBackend := handler.StorageBackend{
+		ProjectManager:  handler.NewProjectManager(git, credentialReader, fileSystem).WithPullRequests(pullRequestManager),
+		StageManager:    createStageManager(configurationContext, git, fileSystem, credentialReader, pullRequestManager),
+		ServiceManager:  handler.NewServiceManager(git, credentialReader, fileSystem, configurationContext).WithPullRequests(pullRequestManager),
+		ResourceManager: handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext).WithPullRequests(pullRequestManager),
+		WebhookManager:  handler.NewGitWebhookManager(git, credentialReader, credentialReader, eventPublisher, config.Global.DirectoryStageStructure, config.Global.WebhookSecret),
+	}
+	storageRouter, err := createStorageRouter(ctx, gitBackend)
+	if err != nil {
+		log.Fatalf("could not set up storage backends: %s", err.Error())
+	}
 
-	projectManager := handler.NewProjectManager(git, credentialReader, fileSystem)
-	projectHandler := handler.NewProjectHandler(projectManager)
+	projectHandler := handler.NewProjectHandler(storageRouter)
 	projectController := controller.NewProjectController(projectHandler)
 	projectController.Inject(apiV1)
 
-	stageManager := createStageManager(configurationContext, git, fileSystem, credentialReader)
-	stageHandler := handler.NewStageHandler(stageManager)
+	stageHandler := handler.NewStageHandler(storageRouter)
 	stageController := controller.NewStageController(stageHandler)
 	stageController.Inject(apiV1)
 
-	serviceManager := handler.NewServiceManager(git, credentialReader, fileSystem, configurationContext)
-	serviceHandler := handler.NewServiceHandler(serviceManager)
+	serviceHandler := handler.NewServiceHandler(storageRouter)
 	serviceController := controller.NewServiceController(serviceHandler)
 	serviceController.Inject(apiV1)
 
-	projectResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext)
-	projectResourceHandler := handler.NewProjectResourceHandler(projectResourceManager)
+	projectResourceHandler := handler.NewProjectResourceHandler(storageRouter)
 	projectResourceController := controller.NewProjectResourceController(projectResourceHandler)
 	projectResourceController.Inject(apiV1)
 
-	stageResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext)
-	stageResourceHandler := handler.NewStageResourceHandler(stageResourceManager)
+	stageResourceHandler := handler.NewStageResourceHandler(storageRouter)
 	stageResourceController := controller.NewStageResourceController(stageResourceHandler)
 	stageResourceController.Inject(apiV1)
 
-	serviceResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext)
-	serviceResourceHandler := handler.NewServiceResourceHandler(serviceResourceManager)
+	serviceResourceHandler := handler.NewServiceResourceHandler(storageRouter)
 	serviceResourceController := controller.NewServiceResourceController(serviceResourceHandler)
 	serviceResourceController.Inject(apiV1)
 
+	webhookHandler := handler.NewWebhookHandler(storageRouter)
+	webhookController := controller.NewWebhookController(webhookHandler)
+	webhookController.Inject(apiV1)
+
 	healthHandler := handler.NewHealthHandler()
 	healthController := controller.NewHealthController(healthHandler)
 	healthController.Inject(apiHealth)
@@ -135,6 +163,9 @@ func main() {
 
 	gracefulShutdown(ctx, wg, srv)
 
+	// send the remaining audit records
+	cancel()
+	<-auditDone
 }
 
 func createConfigurationContext(git *common.Git, fileSystem *common.FileSystem) handler.IConfigurationContext {
@@ -147,16 +178,74 @@ func createConfigurationContext(git *common.Git, fileSystem *common.FileSystem)
 	return configContext
 }
 
-func createStageManager(configurationContext handler.IConfigurationContext, git common.IGit, fileSystem common.IFileSystem, credentialReader common.CredentialReader) handler.IStageManager {
+func createStageManager(configurationContext handler.IConfigurationContext, git common.IGit, fileSystem common.IFileSystem, credentialReader common.CredentialReader, pullRequestManager handler.IPullRequestManager) handler.IStageManager {
 	var stageManager handler.IStageManager
 	if config.Global.DirectoryStageStructure {
-		stageManager = handler.NewDirectoryStageManager(configurationContext, fileSystem, credentialReader, git)
+		stageManager = handler.NewDirectoryStageManager(configurationContext, fileSystem, credentialReader, git).WithPullRequests(pullRequestManager)
 	} else {
-		stageManager = handler.NewStageManager(git, credentialReader)
+		stageManager = handler.NewStageManager(git, credentialReader).WithPullRequests(pullRequestManager)
 	}
 	return stageManager
 }
 
+// createStorageRouter sets up the storage backends that are used by the default backend or by any of the projects.
+// Projects are kept in the local git clones unless they have been assigned to another backend
+func createStorageRouter(ctx context.Context, gitBackend handler.StorageBackend) (*handler.StorageRouter, error) {
+	projectBackends, err := handler.ParseProjectStorageBackends(config.Global.ProjectStorageBackends)
+	if err != nil {
+		return nil, err
+	}
+	usedBackends := map[string]bool{config.Global.StorageBackend: true}
+	for _, backend := range projectBackends {
+		usedBackends[backend] = true
+	}
+
+	backends := map[string]handler.StorageBackend{common.StorageBackendGit: gitBackend}
+	if usedBackends[common.StorageBackendS3] {
+		log.Infof("Using S3 bucket %s at %s as storage backend", config.Global.S3Bucket, config.Global.S3Endpoint)
+		backends[common.StorageBackendS3] = createStoreBackend(common.NewS3ObjectStore(common.S3Config{
+			Endpoint:        config.Global.S3Endpoint,
+			Bucket:          config.Global.S3Bucket,
+			Region:          config.Global.S3Region,
+			AccessKeyID:     config.Global.S3AccessKeyID,
+			SecretAccessKey: config.Global.S3SecretAccessKey,
+		}, nil))
+	}
+	if usedBackends[common.StorageBackendGridFS] {
+		objectStore, err := createGridFSObjectStore(ctx)
+		if err != nil {
+			return nil, err
+		}
+		log.Infof("Using GridFS bucket %s as storage backend", config.Global.GridFSBucket)
+		backends[common.StorageBackendGridFS] = createStoreBackend(objectStore)
+	}
+	return handler.NewStorageRouter(backends, config.Global.StorageBackend, projectBackends)
+}
+
+func createStoreBackend(objectStore common.IObjectStore) handler.StorageBackend {
+	store := common.NewConfigurationStore(objectStore)
+	return handler.StorageBackend{
+		ProjectManager:  handler.NewStoreProjectManager(store),
+		StageManager:    handler.NewStoreStageManager(store),
+		ServiceManager:  handler.NewStoreServiceManager(store),
+		ResourceManager: handler.NewStoreResourceManager(store),
+	}
+}
+
+func createGridFSObjectStore(ctx context.Context) (*common.GridFSObjectStore, error) {
+	connectionString, databaseName, err := keptnmongoutils.GetMongoConnectionStringFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	connectCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(connectCtx, options.Client().ApplyURI(connectionString))
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to MongoDB: %w", err)
+	}
+	return common.NewGridFSObjectStore(client.Database(databaseName), config.Global.GridFSBucket), nil
+}
+
 func gracefulShutdown(ctx context.Context, wg *sync.WaitGroup, srv *http.Server) {
 	quit := make(chan os.Signal, 1)
 
@@ -0,0 +1,139 @@
+package models
+
+import (
+	"path"
+	"strings"
+
+	"github.com/keptn/keptn/resource-service/errors"
+)
+
+// PromotionStrategyCopy replaces the resources of the target stage with the ones of the source stage
+const PromotionStrategyCopy = "copy"
+
+// PromotionStrategyMerge adds the resources of the source stage to the target stage, keeping resources that only exist in the target stage
+const PromotionStrategyMerge = "merge"
+
+type PromoteResourcesQuery struct {
+	// From is the name of the stage the resources are promoted from
+	From string `json:"from" form:"from"`
+}
+
+type PromoteResourcesPayload struct {
+	// Strategy is either copy (default) or merge
+	Strategy string `json:"strategy,omitempty"`
+	// Include contains glob patterns of the resources to be promoted. If empty, all resources are promoted
+	Include []string `json:"include,omitempty"`
+	// Exclude contains glob patterns of the resources that are not promoted
+	Exclude []string `json:"exclude,omitempty"`
+	// Overwrite allows the merge strategy to replace resources that differ between the source and target stage
+	Overwrite bool `json:"overwrite,omitempty"`
+	// Push determines whether the commit is pushed to the upstream repository. Defaults to true
+	Push *bool `json:"push,omitempty"`
+}
+
+// ShouldPush returns whether the commit containing the promoted resources should be pushed to the upstream repository
+func (p PromoteResourcesPayload) ShouldPush() bool {
+	return p.Push == nil || *p.Push
+}
+
+// IsMerge returns whether the resources should be merged into the target stage instead of replacing its resources
+func (p PromoteResourcesPayload) IsMerge() bool {
+	return p.Strategy == PromotionStrategyMerge
+}
+
+// Includes returns whether the resource with the given path, relative to the service directory, is matched by the include and exclude patterns.
+// A pattern matches a resource if it matches its path or the path of one of its parent directories.
+// Patterns without a "/" are matched against the name of the resource and of each of its parent directories
+func (p PromoteResourcesPayload) Includes(resourcePath string) bool {
+	if len(p.Include) > 0 && !matchesAnyGlob(p.Include, resourcePath) {
+		return false
+	}
+	return !matchesAnyGlob(p.Exclude, resourcePath)
+}
+
+func (p PromoteResourcesPayload) Validate() error {
+	if p.Strategy != "" && p.Strategy != PromotionStrategyCopy && p.Strategy != PromotionStrategyMerge {
+		return errors.ErrInvalidPromotionStrategy
+	}
+	for _, pattern := range append(append([]string{}, p.Include...), p.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil || strings.TrimSpace(pattern) == "" {
+			return errors.ErrInvalidGlobPattern
+		}
+	}
+	return nil
+}
+
+type PromoteResourcesParams struct {
+	ResourceContext
+	PromoteResourcesQuery
+	PromoteResourcesPayload
+}
+
+func (p PromoteResourcesParams) Validate() error {
+	if err := p.ResourceContext.Validate(); err != nil {
+		return err
+	}
+	if p.Stage == nil {
+		return errors.ErrStageNotFound
+	}
+	if p.Service == nil {
+		return errors.ErrServiceNotFound
+	}
+	if err := validateEntityName(p.From); err != nil {
+		return err
+	}
+	if p.From == p.Stage.StageName {
+		return errors.ErrPromotionSameStage
+	}
+	return p.PromoteResourcesPayload.Validate()
+}
+
+// PromoteResourcesResponse promoted resources
+//
+// swagger:model PromoteResourcesResponse
+type PromoteResourcesResponse struct {
+
+	// ID of the commit containing the promoted resources. If nothing has been changed, this is the current revision of the target stage
+	CommitID string `json:"commitID"`
+
+	// Resources that have been added to the target stage
+	Added []string `json:"added"`
+
+	// Resources of the target stage that have been replaced
+	Updated []string `json:"updated"`
+
+	// Resources that have been removed from the target stage
+	Deleted []string `json:"deleted"`
+
+	Metadata Version `json:"metadata"`
+
+	// URL of the pull request containing the promoted resources, if pull requests are enabled for the project
+	PullRequestURL string `json:"pullRequestURL,omitempty"`
+}
+
+func matchesAnyGlob(patterns []string, resourcePath string) bool {
+	for _, pattern := range patterns {
+		if matchesGlob(pattern, resourcePath) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesGlob(pattern string, resourcePath string) bool {
+	pattern = strings.Trim(pattern, "/")
+	if !strings.Contains(pattern, "/") {
+		for _, segment := range strings.Split(resourcePath, "/") {
+			if match, _ := path.Match(pattern, segment); match {
+				return true
+			}
+		}
+		return false
+	}
+	for p := resourcePath; p != "." && p != "/"; p = path.Dir(p) {
+		if match, _ := path.Match(pattern, p); match {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package models
+
+import "testing"
+
+func TestPromoteResourcesParams_Validate(t *testing.T) {
+	validContext := ResourceContext{
+		Project: Project{ProjectName: "my-project"},
+		Stage:   &Stage{StageName: "production"},
+		Service: &Service{ServiceName: "my-service"},
+	}
+	tests := []struct {
+		name    string
+		params  PromoteResourcesParams
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			params: PromoteResourcesParams{
+				ResourceContext:       validContext,
+				PromoteResourcesQuery: PromoteResourcesQuery{From: "hardening"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid with merge strategy and patterns",
+			params: PromoteResourcesParams{
+				ResourceContext:       validContext,
+				PromoteResourcesQuery: PromoteResourcesQuery{From: "hardening"},
+				PromoteResourcesPayload: PromoteResourcesPayload{
+					Strategy: PromotionStrategyMerge,
+					Include:  []string{"helm", "*.yaml"},
+					Exclude:  []string{"helm/*/values.yaml"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing source stage",
+			params: PromoteResourcesParams{
+				ResourceContext: validContext,
+			},
+			wantErr: true,
+		},
+		{
+			name: "same source and target stage",
+			params: PromoteResourcesParams{
+				ResourceContext:       validContext,
+				PromoteResourcesQuery: PromoteResourcesQuery{From: "production"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid strategy",
+			params: PromoteResourcesParams{
+				ResourceContext:         validContext,
+				PromoteResourcesQuery:   PromoteResourcesQuery{From: "hardening"},
+				PromoteResourcesPayload: PromoteResourcesPayload{Strategy: "rebase"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid glob pattern",
+			params: PromoteResourcesParams{
+				ResourceContext:         validContext,
+				PromoteResourcesQuery:   PromoteResourcesQuery{From: "hardening"},
+				PromoteResourcesPayload: PromoteResourcesPayload{Exclude: []string{"helm/[a-"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing service",
+			params: PromoteResourcesParams{
+				ResourceContext: ResourceContext{
+					Project: Project{ProjectName: "my-project"},
+					Stage:   &Stage{StageName: "production"},
+				},
+				PromoteResourcesQuery: PromoteResourcesQuery{From: "hardening"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.params.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPromoteResourcesPayload_Includes(t *testing.T) {
+	tests := []struct {
+		name         string
+		payload      PromoteResourcesPayload
+		resourcePath string
+		want         bool
+	}{
+		{
+			name:         "no patterns",
+			resourcePath: "helm/my-service/values.yaml",
+			want:         true,
+		},
+		{
+			name:         "included by parent directory",
+			payload:      PromoteResourcesPayload{Include: []string{"helm"}},
+			resourcePath: "helm/my-service/values.yaml",
+			want:         true,
+		},
+		{
+			name:         "included by file name",
+			payload:      PromoteResourcesPayload{Include: []string{"*.yaml"}},
+			resourcePath: "helm/my-service/values.yaml",
+			want:         true,
+		},
+		{
+			name:         "not included",
+			payload:      PromoteResourcesPayload{Include: []string{"slo.yaml"}},
+			resourcePath: "helm/my-service/values.yaml",
+			want:         false,
+		},
+		{
+			name:         "excluded by path",
+			payload:      PromoteResourcesPayload{Include: []string{"helm"}, Exclude: []string{"helm/*/values.yaml"}},
+			resourcePath: "helm/my-service/values.yaml",
+			want:         false,
+		},
+		{
+			name:         "path pattern does not match a file name in a sub directory",
+			payload:      PromoteResourcesPayload{Exclude: []string{"my-service/values.yaml"}},
+			resourcePath: "helm/my-service/values.yaml",
+			want:         true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.payload.Includes(tt.resourcePath); got != tt.want {
+				t.Errorf("Includes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPromoteResourcesPayload_ShouldPush(t *testing.T) {
+	push := false
+	if !(PromoteResourcesPayload{}).ShouldPush() {
+		t.Errorf("ShouldPush() = false, want true")
+	}
+	if (PromoteResourcesPayload{Push: &push}).ShouldPush() {
+		t.Errorf("ShouldPush() = true, want false")
+	}
+}
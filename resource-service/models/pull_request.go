@@ -0,0 +1,26 @@
+package models
+
+// PullRequestOpenedEventType is the type of the event that is sent when changes to the resources of a project have been proposed
+// to its upstream repository via a pull request
+const PullRequestOpenedEventType = "sh.keptn.event.configuration.pullrequest.opened"
+
+// OpenedPullRequest describes a pull request (merge request in GitLab) that has been opened in the upstream repository of a project
+type OpenedPullRequest struct {
+	URL string
+	// SourceBranch is the branch that has been created for the changes
+	SourceBranch string
+	// TargetBranch is the branch that the changes should be merged into
+	TargetBranch string
+	CommitID     string
+}
+
+// PullRequestOpenedEventData is the data of a sh.keptn.event.configuration.pullrequest.opened event
+type PullRequestOpenedEventData struct {
+	Project string `json:"project"`
+	// Provider is the git provider of the upstream repository, e.g. github
+	Provider       string `json:"provider"`
+	SourceBranch   string `json:"sourceBranch"`
+	TargetBranch   string `json:"targetBranch"`
+	CommitID       string `json:"commitID"`
+	PullRequestURL string `json:"pullRequestURL"`
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"github.com/keptn/keptn/resource-service/errors"
 	"strings"
+	"time"
 )
 
 type ResourceContent string
@@ -100,6 +101,56 @@ func (p GetResourceParams) Validate() error {
 	return nil
 }
 
+type GetResourceHistoryQuery struct {
+	NextPageKey string `json:"nextPageKey,omitempty" form:"nextPageKey"`
+	PageSize    int64  `json:"pageSize,omitempty" form:"pageSize"`
+}
+
+type GetResourceHistoryParams struct {
+	ResourceContext
+	ResourceURI string
+	GetResourceHistoryQuery
+}
+
+func (p GetResourceHistoryParams) Validate() error {
+	if err := p.ResourceContext.Validate(); err != nil {
+		return err
+	}
+	if err := validateResourceURI(p.ResourceURI); err != nil {
+		return err
+	}
+	if p.PageSize <= 0 {
+		return errors.ErrInvalidPageSize
+	}
+	return nil
+}
+
+type GetResourceDiffQuery struct {
+	// From is the revision the changes are compared to
+	From string `json:"from" form:"from"`
+	// To is the revision that contains the changes. If empty, the current revision is used
+	To string `json:"to,omitempty" form:"to"`
+}
+
+type GetResourceDiffParams struct {
+	ResourceContext
+	ResourceURI string
+	GetResourceDiffQuery
+}
+
+func (p GetResourceDiffParams) Validate() error {
+	if err := p.ResourceContext.Validate(); err != nil {
+		return err
+	}
+	if err := validateResourceURI(p.ResourceURI); err != nil {
+		return err
+	}
+	if p.From == "" {
+		return errors.ErrRevisionMustNotBeEmpty
+	}
+	return nil
+}
+
 type DeleteResourceParams struct {
 	ResourceContext
 	ResourceURI string
@@ -212,9 +263,67 @@ type GetResourceResponse struct {
 	Metadata Version `json:"metadata"`
 }
 
+// ResourceCommit resource commit
+//
+// swagger:model ResourceCommit
+type ResourceCommit struct {
+
+	// ID of the commit
+	CommitID string `json:"commitID"`
+
+	// Name of the author of the commit
+	Author string `json:"author"`
+
+	// Commit message
+	Message string `json:"message"`
+
+	// Time of the commit
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// GetResourceHistoryResponse resource history
+//
+// swagger:model GetResourceHistoryResponse
+type GetResourceHistoryResponse struct {
+
+	// Pointer to next page, base64 encoded
+	NextPageKey string `json:"nextPageKey,omitempty"`
+
+	// Size of returned page
+	PageSize float64 `json:"pageSize,omitempty"`
+
+	// Commits that changed the resource, starting with the latest one
+	Commits []ResourceCommit `json:"commits"`
+
+	// Total number of commits
+	TotalCount float64 `json:"totalCount,omitempty"`
+}
+
+// GetResourceDiffResponse resource diff
+//
+// swagger:model GetResourceDiffResponse
+type GetResourceDiffResponse struct {
+
+	// Resource URI in URL-encoded format
+	ResourceURI string `json:"resourceURI"`
+
+	// Revision the changes are compared to
+	From string `json:"from"`
+
+	// Revision that contains the changes
+	To string `json:"to"`
+
+	// Changes of the resource in unified diff format. Empty if the resource has not been changed
+	Diff string `json:"diff"`
+
+	Metadata Version `json:"metadata"`
+}
+
 type WriteResourceResponse struct {
 	CommitID string  `json:"commitID"`
 	Metadata Version `json:"metadata"`
+	// PullRequestURL is set if the changes have been proposed via a pull request instead of being pushed to the stage
+	PullRequestURL string `json:"pullRequestURL,omitempty"`
 }
 
 func validateResourceURI(uri string) error {
@@ -597,3 +597,78 @@ func TestResource_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestGetResourceDiffParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  GetResourceDiffParams
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			params: GetResourceDiffParams{
+				ResourceContext:      ResourceContext{Project: Project{ProjectName: "my-project"}},
+				ResourceURI:          "helm/chart.tgz",
+				GetResourceDiffQuery: GetResourceDiffQuery{From: "HEAD~1"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing from revision",
+			params: GetResourceDiffParams{
+				ResourceContext: ResourceContext{Project: Project{ProjectName: "my-project"}},
+				ResourceURI:     "helm/chart.tgz",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid resource name",
+			params: GetResourceDiffParams{
+				ResourceContext:      ResourceContext{Project: Project{ProjectName: "my-project"}},
+				ResourceURI:          "../chart.tgz",
+				GetResourceDiffQuery: GetResourceDiffQuery{From: "HEAD~1"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.params.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetResourceHistoryParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  GetResourceHistoryParams
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			params: GetResourceHistoryParams{
+				ResourceContext:         ResourceContext{Project: Project{ProjectName: "my-project"}},
+				ResourceURI:             "helm/chart.tgz",
+				GetResourceHistoryQuery: GetResourceHistoryQuery{PageSize: 20},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid page size",
+			params: GetResourceHistoryParams{
+				ResourceContext: ResourceContext{Project: Project{ProjectName: "my-project"}},
+				ResourceURI:     "helm/chart.tgz",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.params.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"errors"
+	"strings"
+)
+
+// ConfigurationChangedEventType is the type of the event that is sent when the upstream repository of a project has been changed outside of Keptn
+const ConfigurationChangedEventType = "sh.keptn.event.configuration.changed"
+
+// GitPush describes the branches that have been updated by a push to the upstream repository of a project
+type GitPush struct {
+	// Provider is the git provider that has sent the webhook, e.g. github
+	Provider string
+	Branches []GitBranchUpdate
+}
+
+// GitBranchUpdate describes the update of a single branch
+type GitBranchUpdate struct {
+	Branch string
+	// Before is the revision of the branch before the push. It is empty if the branch has been created by the push
+	Before string
+	// After is the revision of the branch after the push
+	After string
+	// ChangedFiles contains the files that have been added, modified or removed. It is nil if the webhook payload does not list all changed files
+	ChangedFiles []string
+}
+
+type SyncProjectParams struct {
+	Project
+	Push GitPush
+}
+
+func (s SyncProjectParams) Validate() error {
+	if err := validateEntityName(s.ProjectName); err != nil {
+		return err
+	}
+	for _, update := range s.Push.Branches {
+		if strings.TrimSpace(update.Branch) == "" || update.After == "" {
+			return errors.New("branch and revision of a pushed branch must not be empty")
+		}
+	}
+	return nil
+}
+
+// SyncProjectResponse changes of the upstream repository
+//
+// swagger:model SyncProjectResponse
+type SyncProjectResponse struct {
+
+	// Changes of the stages. Changes of the project, e.g. of the shipyard file, have an empty stage
+	Changes []ConfigurationChange `json:"changes"`
+}
+
+// ConfigurationChange changed files of a stage
+//
+// swagger:model ConfigurationChange
+type ConfigurationChange struct {
+
+	// Name of the stage
+	Stage string `json:"stage,omitempty"`
+
+	// Branch that has been pushed
+	Branch string `json:"branch"`
+
+	// Revision of the branch after the push
+	CommitID string `json:"commitID"`
+
+	// Files that have been added, modified or removed, relative to the root of the repository
+	ChangedFiles []string `json:"changedFiles"`
+}
+
+// ConfigurationChangedEventData is the data of a sh.keptn.event.configuration.changed event
+type ConfigurationChangedEventData struct {
+	Project string `json:"project"`
+	Stage   string `json:"stage,omitempty"`
+	// Provider is the git provider that has sent the webhook, e.g. github
+	Provider     string   `json:"provider"`
+	Branch       string   `json:"branch"`
+	CommitID     string   `json:"commitID"`
+	ChangedFiles []string `json:"changedFiles"`
+}
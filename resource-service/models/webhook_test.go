@@ -0,0 +1,51 @@
+package models
+
+import "testing"
+
+func TestSyncProjectParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  SyncProjectParams
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			params: SyncProjectParams{
+				Project: Project{ProjectName: "my-project"},
+				Push:    GitPush{Provider: "github", Branches: []GitBranchUpdate{{Branch: "dev", After: "my-revision"}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid without branches",
+			params: SyncProjectParams{
+				Project: Project{ProjectName: "my-project"},
+				Push:    GitPush{Provider: "github"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid project name",
+			params: SyncProjectParams{
+				Project: Project{ProjectName: "my/project"},
+				Push:    GitPush{Provider: "github", Branches: []GitBranchUpdate{{Branch: "dev", After: "my-revision"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing revision",
+			params: SyncProjectParams{
+				Project: Project{ProjectName: "my-project"},
+				Push:    GitPush{Provider: "github", Branches: []GitBranchUpdate{{Branch: "dev"}}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.params.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
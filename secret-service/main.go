@@ -4,8 +4,10 @@ import (
 	"context"
 	"github.com/gin-gonic/gin"
 	"github.com/keptn/go-utils/pkg/common/osutils"
+	"github.com/keptn/keptn/cp-common/audit"
 	_ "github.com/keptn/keptn/secret-service/docs"
 	"github.com/keptn/keptn/secret-service/pkg/backend"
+	"github.com/keptn/keptn/secret-service/pkg/common"
 	"github.com/keptn/keptn/secret-service/pkg/controller"
 	"github.com/keptn/keptn/secret-service/pkg/handler"
 	"github.com/keptn/keptn/secret-service/pkg/repository"
@@ -34,6 +36,9 @@ import (
 // @BasePath  /v1
 
 const envVarLogLevel = "LOG_LEVEL"
+const envVarShipyardControllerURL = "SHIPYARD_CONTROLLER_URL"
+const defaultShipyardControllerURL = "http://shipyard-controller:8080"
+const envVarInternalAPIToken = "INTERNAL_API_TOKEN"
 
 func main() {
 	log.SetLevel(log.InfoLevel)
@@ -59,11 +64,24 @@ func main() {
 
 	log.Infof("Registered Backends: %v", backend.GetRegisteredBackends())
 
-	engine := gin.Default()
-	apiV1 := engine.Group("/v1")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	// only kubernetes supported, so we hard code it for now
 	secretsBackend := backend.CreateBackend("kubernetes")
+
+	auditClient := audit.NewClient(common.EnvBasedStringSupplier(envVarShipyardControllerURL, defaultShipyardControllerURL)(), os.Getenv(envVarInternalAPIToken), nil)
+	auditDone := make(chan struct{})
+	go func() {
+		auditClient.Run(ctx)
+		close(auditDone)
+	}()
+
+	engine := gin.Default()
+	// the audit middleware needs to be registered before the API group is created
+	engine.Use(handler.AuditMiddleware(auditClient, secretsBackend))
+	apiV1 := engine.Group("/v1")
+
 	secretController := controller.NewSecretController(handler.NewSecretHandler(secretsBackend))
 	secretController.Inject(apiV1)
 
@@ -94,11 +112,13 @@ func main() {
 	<-quit
 	log.Println("Shutting down server...")
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatal("Server forced to shutdown: ", err)
 	}
 
+	// send the remaining audit records
+	cancel()
+	<-auditDone
+
 	log.Println("Server exiting")
 }
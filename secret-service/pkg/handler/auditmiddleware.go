@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/cp-common/audit"
+	"github.com/keptn/keptn/secret-service/pkg/backend"
+	"github.com/keptn/keptn/secret-service/pkg/model"
+	log "github.com/sirupsen/logrus"
+)
+
+const auditServiceName = "secret-service"
+
+// secretAuditActions maps the state-changing methods of the secret endpoint to the names of their audit actions
+var secretAuditActions = map[string]string{
+	http.MethodPost:   "secret.create",
+	http.MethodPut:    "secret.update",
+	http.MethodDelete: "secret.delete",
+}
+
+// secretAuditSummary describes a secret in an audit record. It only contains the keys of the secret, never its values
+type secretAuditSummary struct {
+	Keys []string `json:"keys"`
+}
+
+// AuditMiddleware records an audit record for each call that creates, updates or deletes a secret, once the call has been handled
+func AuditMiddleware(recorder audit.Recorder, secretManager backend.SecretManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		action, ok := secretAuditActions[c.Request.Method]
+		if !ok || c.FullPath() != "/v1/secret" {
+			c.Next()
+			return
+		}
+
+		secret := readAuditSecret(c)
+		if secret.Scope == "" {
+			secret.Scope = model.DefaultSecretScope
+		}
+
+		record := audit.Record{
+			Service:    auditServiceName,
+			Principal:  c.GetHeader(audit.PrincipalHeader),
+			ClientIP:   c.ClientIP(),
+			Action:     action,
+			TargetType: "secret",
+			Target:     secret.Scope + "/" + secret.Name,
+			RequestID:  c.GetHeader(audit.RequestIDHeader),
+		}
+		if record.Principal == "" {
+			record.Principal = audit.PrincipalInternal
+		}
+		if c.Request.Method != http.MethodPost {
+			record.Before = getSecretAuditSummary(secretManager, secret.SecretMetadata)
+		}
+		if secret.Data != nil {
+			record.After = summarizeSecretKeys(keysOf(secret.Data))
+		}
+
+		c.Next()
+
+		record.StatusCode = c.Writer.Status()
+		recorder.Record(record)
+	}
+}
+
+// readAuditSecret returns the secret the call refers to. The body of the request is restored, so that it can still be read by the handler
+func readAuditSecret(c *gin.Context) model.Secret {
+	secret := model.Secret{}
+	if c.Request.Method == http.MethodDelete {
+		secret.Name = c.Query("name")
+		secret.Scope = c.Query("scope")
+		return secret
+	}
+	if c.Request.Body == nil {
+		return secret
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return secret
+	}
+	_ = json.Unmarshal(body, &secret)
+	return secret
+}
+
+func getSecretAuditSummary(secretManager backend.SecretManager, metadata model.SecretMetadata) string {
+	secrets, err := secretManager.GetSecrets()
+	if err != nil {
+		log.WithError(err).Errorf("Could not retrieve secret %s/%s for audit record", metadata.Scope, metadata.Name)
+		return ""
+	}
+	for _, secret := range secrets {
+		if secret.Name == metadata.Name && secret.Scope == metadata.Scope {
+			return summarizeSecretKeys(secret.Keys)
+		}
+	}
+	return ""
+}
+
+func summarizeSecretKeys(keys []string) string {
+	sorted := append([]string{}, keys...)
+	sort.Strings(sorted)
+	summary, err := json.Marshal(secretAuditSummary{Keys: sorted})
+	if err != nil {
+		return ""
+	}
+	return string(summary)
+}
+
+func keysOf(data model.Data) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,113 @@
+package handler_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/cp-common/audit"
+	auditfake "github.com/keptn/keptn/cp-common/audit/fake"
+	"github.com/keptn/keptn/secret-service/pkg/backend/fake"
+	"github.com/keptn/keptn/secret-service/pkg/handler"
+	"github.com/keptn/keptn/secret-service/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAuditTestRouter(recorder audit.Recorder, secretsBackend *fake.SecretBackendMock) *gin.Engine {
+	router := gin.New()
+	router.Use(handler.AuditMiddleware(recorder, secretsBackend))
+	secretHandler := handler.NewSecretHandler(secretsBackend)
+	apiV1 := router.Group("/v1")
+	apiV1.POST("/secret", secretHandler.CreateSecret)
+	apiV1.PUT("/secret", secretHandler.UpdateSecret)
+	apiV1.DELETE("/secret", secretHandler.DeleteSecret)
+	apiV1.GET("/secret", secretHandler.GetSecrets)
+	return router
+}
+
+func newAuditTestBackend() *fake.SecretBackendMock {
+	return &fake.SecretBackendMock{
+		CreateSecretFunc: func(secret model.Secret) error { return nil },
+		UpdateSecretFunc: func(secret model.Secret) error { return nil },
+		DeleteSecretFunc: func(secret model.Secret) error { return nil },
+		GetSecretsFunc: func() ([]model.GetSecretResponseItem, error) {
+			return []model.GetSecretResponseItem{
+				{SecretMetadata: model.SecretMetadata{Name: "my-secret", Scope: "my-scope"}, Keys: []string{"username", "password"}},
+			}, nil
+		},
+	}
+}
+
+func TestAuditMiddleware_CreateSecret(t *testing.T) {
+	recorder := &auditfake.RecorderMock{RecordFunc: func(record audit.Record) {}}
+	router := newAuditTestRouter(recorder, newAuditTestBackend())
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/secret", bytes.NewBufferString(`{"name":"my-secret","data":{"username":"keptn","password":"my-password"}}`))
+	request.Header.Set("X-Keptn-Principal", "api-token:1a2b3c4d")
+	request.Header.Set("X-Request-ID", "my-request")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, request)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	require.Len(t, recorder.RecordCalls(), 1)
+	assert.Equal(t, audit.Record{
+		Service:    "secret-service",
+		Principal:  "api-token:1a2b3c4d",
+		ClientIP:   "192.0.2.1",
+		Action:     "secret.create",
+		TargetType: "secret",
+		Target:     "keptn-default/my-secret",
+		After:      `{"keys":["password","username"]}`,
+		RequestID:  "my-request",
+		StatusCode: http.StatusCreated,
+	}, recorder.RecordCalls()[0].Record)
+}
+
+func TestAuditMiddleware_UpdateSecret(t *testing.T) {
+	recorder := &auditfake.RecorderMock{RecordFunc: func(record audit.Record) {}}
+	router := newAuditTestRouter(recorder, newAuditTestBackend())
+
+	request := httptest.NewRequest(http.MethodPut, "/v1/secret", bytes.NewBufferString(`{"name":"my-secret","scope":"my-scope","data":{"token":"my-token"}}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, request)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, recorder.RecordCalls(), 1)
+	record := recorder.RecordCalls()[0].Record
+	assert.Equal(t, "secret.update", record.Action)
+	assert.Equal(t, audit.PrincipalInternal, record.Principal)
+	assert.Equal(t, "my-scope/my-secret", record.Target)
+	assert.Equal(t, `{"keys":["password","username"]}`, record.Before)
+	assert.Equal(t, `{"keys":["token"]}`, record.After)
+	assert.NotContains(t, record.After, "my-token")
+}
+
+func TestAuditMiddleware_DeleteSecret(t *testing.T) {
+	recorder := &auditfake.RecorderMock{RecordFunc: func(record audit.Record) {}}
+	router := newAuditTestRouter(recorder, newAuditTestBackend())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/v1/secret?name=my-secret&scope=my-scope", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, recorder.RecordCalls(), 1)
+	record := recorder.RecordCalls()[0].Record
+	assert.Equal(t, "secret.delete", record.Action)
+	assert.Equal(t, "my-scope/my-secret", record.Target)
+	assert.Equal(t, `{"keys":["password","username"]}`, record.Before)
+	assert.Empty(t, record.After)
+}
+
+func TestAuditMiddleware_SkipsReadOnlyCalls(t *testing.T) {
+	recorder := &auditfake.RecorderMock{}
+	router := newAuditTestRouter(recorder, newAuditTestBackend())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/secret", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, recorder.RecordCalls())
+}
@@ -0,0 +1,55 @@
+package common
+
+import (
+	"fmt"
+)
+
+// PriorityClassLabel is the label of a sequence.triggered event that can be used to run a sequence with a priority class other than the default one of the sequence
+const PriorityClassLabel = "keptn.sh/priority-class"
+
+// ConcurrencyLimits contains the maximum numbers of sequences that may be running at the same time.
+// A value of 0 means that the number of running sequences is not limited
+type ConcurrencyLimits struct {
+	// MaxPerProject is the maximum number of running sequences in each project
+	MaxPerProject int
+	// MaxPerStage is the maximum number of running sequences in each stage of a project
+	MaxPerStage int
+	// MaxPerSequence contains the maximum number of running sequences with a given name, across all projects
+	MaxPerSequence map[string]int
+}
+
+// PriorityClasses determines the priority of sequences. Sequences with a higher priority are dispatched before sequences with a lower one
+type PriorityClasses struct {
+	// classes maps the names of the priority classes to their priority
+	classes map[string]int
+	// sequenceClasses maps the names of sequences to the name of their default priority class
+	sequenceClasses map[string]string
+}
+
+// NewPriorityClasses creates a new PriorityClasses instance. An error is returned if a sequence refers to a priority class that is not defined
+func NewPriorityClasses(classes map[string]int, sequenceClasses map[string]string) (PriorityClasses, error) {
+	for sequence, class := range sequenceClasses {
+		if _, ok := classes[class]; !ok {
+			return PriorityClasses{}, fmt.Errorf("sequence %s refers to unknown priority class %s", sequence, class)
+		}
+	}
+	return PriorityClasses{
+		classes:         classes,
+		sequenceClasses: sequenceClasses,
+	}, nil
+}
+
+// GetPriority returns the priority of a sequence with the given name. If the labels of the sequence contain a known priority class,
+// the priority of that class is used. Otherwise, the priority of the default class of the sequence is returned.
+// Sequences without a priority class have a priority of 0
+func (p PriorityClasses) GetPriority(sequenceName string, labels map[string]string) int {
+	if class, ok := labels[PriorityClassLabel]; ok {
+		if priority, ok := p.classes[class]; ok {
+			return priority
+		}
+	}
+	if class, ok := p.sequenceClasses[sequenceName]; ok {
+		return p.classes[class]
+	}
+	return 0
+}
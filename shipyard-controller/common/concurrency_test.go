@@ -0,0 +1,47 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPriorityClasses(t *testing.T) {
+	_, err := NewPriorityClasses(map[string]int{"high": 100}, map[string]string{"remediation": "high"})
+	require.Nil(t, err)
+
+	_, err = NewPriorityClasses(map[string]int{"high": 100}, map[string]string{"remediation": "urgent"})
+	require.NotNil(t, err)
+
+	_, err = NewPriorityClasses(nil, nil)
+	require.Nil(t, err)
+}
+
+func TestPriorityClasses_GetPriority(t *testing.T) {
+	priorityClasses, err := NewPriorityClasses(
+		map[string]int{"high": 100, "low": -100},
+		map[string]string{"remediation": "high", "evaluation": "low"},
+	)
+	require.Nil(t, err)
+
+	tests := []struct {
+		name         string
+		sequenceName string
+		labels       map[string]string
+		want         int
+	}{
+		{name: "default class of sequence", sequenceName: "remediation", want: 100},
+		{name: "negative priority", sequenceName: "evaluation", want: -100},
+		{name: "sequence without class", sequenceName: "delivery", want: 0},
+		{name: "class set via label", sequenceName: "delivery", labels: map[string]string{PriorityClassLabel: "high"}, want: 100},
+		{name: "label overrides default class", sequenceName: "remediation", labels: map[string]string{PriorityClassLabel: "low"}, want: -100},
+		{name: "unknown class in label", sequenceName: "remediation", labels: map[string]string{PriorityClassLabel: "urgent"}, want: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, priorityClasses.GetPriority(tt.sequenceName, tt.labels))
+		})
+	}
+
+	require.Equal(t, 0, PriorityClasses{}.GetPriority("remediation", nil))
+}
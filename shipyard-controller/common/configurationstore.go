@@ -1,8 +1,12 @@
 package common
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strings"
 
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
@@ -26,9 +30,14 @@ type ConfigurationStore interface {
 	UpdateProjectResource(projectName string, resource *apimodels.Resource) error
 	DeleteProject(projectName string) error
 	CreateStage(projectName string, stage string) error
+	DeleteStage(projectName string, stage string) error
 	CreateService(projectName string, stageName string, serviceName string) error
 	GetProjectResource(projectName string, resourceURI string) (*apimodels.Resource, error)
 	GetStageResource(projectName, stageName, resourceURI string) (*apimodels.Resource, error)
+	GetStageResources(projectName, stageName string) ([]*apimodels.Resource, error)
+	GetServiceResources(projectName, stageName, serviceName string) ([]*apimodels.Resource, error)
+	CreateStageResources(projectName, stageName string, resources []*apimodels.Resource) error
+	CreateServiceResources(projectName, stageName, serviceName string, resources []*apimodels.Resource) error
 	DeleteService(projectName string, stageName string, serviceName string) error
 }
 
@@ -37,6 +46,8 @@ type GitConfigurationStore struct {
 	stagesAPI   *keptnapi.StageHandler
 	servicesAPI *keptnapi.ServiceHandler
 	resourceAPI *keptnapi.ResourceHandler
+	endpoint    string
+	httpClient  *http.Client
 }
 
 func NewGitConfigurationStore(configurationServiceEndpoint string) *GitConfigurationStore {
@@ -45,6 +56,8 @@ func NewGitConfigurationStore(configurationServiceEndpoint string) *GitConfigura
 		stagesAPI:   keptnapi.NewStageHandler(configurationServiceEndpoint),
 		servicesAPI: keptnapi.NewServiceHandler(configurationServiceEndpoint),
 		resourceAPI: keptnapi.NewResourceHandler(configurationServiceEndpoint),
+		endpoint:    strings.TrimSuffix(configurationServiceEndpoint, "/"),
+		httpClient:  &http.Client{},
 	}
 }
 
@@ -56,6 +69,61 @@ func (g GitConfigurationStore) GetStageResource(projectName, stageName, resource
 	return g.resourceAPI.GetStageResource(projectName, stageName, resourceURI)
 }
 
+// GetStageResources returns all resources of the given stage, including their content.
+// Note that the resources of the services within the stage are included as well
+func (g GitConfigurationStore) GetStageResources(projectName, stageName string) ([]*apimodels.Resource, error) {
+	resources, err := g.resourceAPI.GetAllStageResources(projectName, stageName)
+	if err != nil {
+		return nil, err
+	}
+	result := []*apimodels.Resource{}
+	for _, resource := range resources {
+		if resource.ResourceURI == nil {
+			continue
+		}
+		resourceWithContent, err := g.resourceAPI.GetStageResource(projectName, stageName, *resource.ResourceURI)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resourceWithContent)
+	}
+	return result, nil
+}
+
+// GetServiceResources returns all resources of the given service in the given stage, including their content
+func (g GitConfigurationStore) GetServiceResources(projectName, stageName, serviceName string) ([]*apimodels.Resource, error) {
+	resources, err := g.resourceAPI.GetAllServiceResources(projectName, stageName, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	result := []*apimodels.Resource{}
+	for _, resource := range resources {
+		if resource.ResourceURI == nil {
+			continue
+		}
+		resourceWithContent, err := g.resourceAPI.GetServiceResource(projectName, stageName, serviceName, *resource.ResourceURI)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resourceWithContent)
+	}
+	return result, nil
+}
+
+func (g GitConfigurationStore) CreateStageResources(projectName, stageName string, resources []*apimodels.Resource) error {
+	if _, err := g.resourceAPI.CreateStageResources(projectName, stageName, resources); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (g GitConfigurationStore) CreateServiceResources(projectName, stageName, serviceName string, resources []*apimodels.Resource) error {
+	if _, err := g.resourceAPI.CreateServiceResources(projectName, stageName, serviceName, resources); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (g GitConfigurationStore) CreateProject(project apimodels.Project) error {
 	if _, err := g.projectAPI.CreateProject(project); err != nil {
 		return g.buildErrResponse(err)
@@ -102,6 +170,30 @@ func (g GitConfigurationStore) CreateStage(projectName string, stageName string)
 	return nil
 }
 
+// DeleteStage removes the branch or directory of a stage from the repository of the project.
+// A stage that does not exist anymore is not considered to be an error
+func (g GitConfigurationStore) DeleteStage(projectName string, stageName string) error {
+	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/v1/project/%s/stage/%s", g.endpoint, url.PathEscape(projectName), url.PathEscape(stageName)), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound || (resp.StatusCode >= 200 && resp.StatusCode < 300) {
+		return nil
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	apiErr := &apimodels.Error{}
+	if err := json.Unmarshal(body, apiErr); err != nil || apiErr.Message == nil {
+		return fmt.Errorf("could not delete stage %s of project %s: %s", stageName, projectName, http.StatusText(resp.StatusCode))
+	}
+	return g.buildErrResponse(apiErr)
+}
+
 func (g GitConfigurationStore) CreateService(projectName string, stageName string, serviceName string) error {
 	if _, err := g.servicesAPI.CreateServiceInStage(projectName, stageName, serviceName); err != nil {
 		return g.buildErrResponse(err)
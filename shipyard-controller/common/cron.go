@@ -0,0 +1,189 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronMacros contains the supported shortcuts for common cron expressions
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronMonthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var cronWeekdayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+type cronField struct {
+	name   string
+	min    int
+	max    int
+	names  map[string]int
+	values map[int]bool
+	// any indicates that the field has been specified as '*', i.e. without any restriction
+	any bool
+}
+
+// CronSchedule is a parsed cron expression consisting of the five fields minute, hour, day of month, month and day of week.
+// Each field supports wildcards ('*'), single values, ranges ('1-5'), steps ('*/15', '0-30/10') and lists ('1,15').
+// Months and weekdays can also be specified by their three letter names (e.g. 'jan', 'mon')
+type CronSchedule struct {
+	minute     cronField
+	hour       cronField
+	dayOfMonth cronField
+	month      cronField
+	dayOfWeek  cronField
+}
+
+// ParseCronExpression parses the given cron expression. Besides the five field syntax, the macros @yearly, @annually, @monthly, @weekly,
+// @daily, @midnight and @hourly are supported
+func ParseCronExpression(expression string) (*CronSchedule, error) {
+	expression = strings.TrimSpace(expression)
+	if macro, ok := cronMacros[strings.ToLower(expression)]; ok {
+		expression = macro
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression '%s': expected 5 fields, but got %d", expression, len(fields))
+	}
+
+	schedule := &CronSchedule{
+		minute:     cronField{name: "minute", min: 0, max: 59},
+		hour:       cronField{name: "hour", min: 0, max: 23},
+		dayOfMonth: cronField{name: "day of month", min: 1, max: 31},
+		month:      cronField{name: "month", min: 1, max: 12, names: cronMonthNames},
+		dayOfWeek:  cronField{name: "day of week", min: 0, max: 7, names: cronWeekdayNames},
+	}
+
+	for index, field := range []*cronField{&schedule.minute, &schedule.hour, &schedule.dayOfMonth, &schedule.month, &schedule.dayOfWeek} {
+		if err := field.parse(fields[index]); err != nil {
+			return nil, fmt.Errorf("invalid cron expression '%s': %w", expression, err)
+		}
+	}
+
+	// 7 is an alias for sunday
+	if schedule.dayOfWeek.values[7] {
+		schedule.dayOfWeek.values[0] = true
+	}
+	return schedule, nil
+}
+
+func (f *cronField) parse(value string) error {
+	f.values = map[int]bool{}
+	f.any = value == "*"
+	for _, part := range strings.Split(value, ",") {
+		if err := f.parsePart(part); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *cronField) parsePart(part string) error {
+	rangePart := part
+	step := 1
+	if index := strings.Index(part, "/"); index >= 0 {
+		rangePart = part[:index]
+		parsedStep, err := strconv.Atoi(part[index+1:])
+		if err != nil || parsedStep <= 0 {
+			return fmt.Errorf("invalid step '%s' in %s field", part[index+1:], f.name)
+		}
+		step = parsedStep
+	}
+
+	start, end := f.min, f.max
+	if rangePart != "*" {
+		bounds := strings.Split(rangePart, "-")
+		if len(bounds) > 2 {
+			return fmt.Errorf("invalid range '%s' in %s field", rangePart, f.name)
+		}
+		var err error
+		if start, err = f.parseValue(bounds[0]); err != nil {
+			return err
+		}
+		end = start
+		if len(bounds) == 2 {
+			if end, err = f.parseValue(bounds[1]); err != nil {
+				return err
+			}
+		} else if step > 1 {
+			// a single value with a step, e.g. '5/15', is interpreted as the range from the value to the maximum
+			end = f.max
+		}
+		if start > end {
+			return fmt.Errorf("invalid range '%s' in %s field", rangePart, f.name)
+		}
+	}
+
+	for i := start; i <= end; i += step {
+		f.values[i] = true
+	}
+	return nil
+}
+
+func (f *cronField) parseValue(value string) (int, error) {
+	if named, ok := f.names[strings.ToLower(value)]; ok {
+		return named, nil
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value '%s' in %s field", value, f.name)
+	}
+	if parsed < f.min || parsed > f.max {
+		return 0, fmt.Errorf("value %d of %s field is out of range [%d-%d]", parsed, f.name, f.min, f.max)
+	}
+	return parsed, nil
+}
+
+// Next returns the first point in time after the given one that matches the cron schedule.
+// The schedule is evaluated in the location of the given time. If no matching time can be found within the next five years, the zero time is returned
+func (s *CronSchedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if !s.month.values[int(t.Month())] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.hour.values[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.minute.values[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// matchesDay checks the day of month and the day of week fields. As in the standard cron implementation, a day matches if either of
+// the fields matches, unless one of them is unrestricted
+func (s *CronSchedule) matchesDay(t time.Time) bool {
+	dayOfMonthMatches := s.dayOfMonth.values[t.Day()]
+	dayOfWeekMatches := s.dayOfWeek.values[int(t.Weekday())]
+	if s.dayOfMonth.any || s.dayOfWeek.any {
+		return dayOfMonthMatches && dayOfWeekMatches
+	}
+	return dayOfMonthMatches || dayOfWeekMatches
+}
@@ -0,0 +1,90 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCronExpression(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		wantErr    bool
+	}{
+		{name: "every minute", expression: "* * * * *"},
+		{name: "ranges, steps and lists", expression: "*/15 8-18 1,15 * mon-fri"},
+		{name: "month names", expression: "0 0 1 jan,jul *"},
+		{name: "macro", expression: "@daily"},
+		{name: "sunday as 7", expression: "0 0 * * 7"},
+		{name: "too few fields", expression: "* * * *", wantErr: true},
+		{name: "value out of range", expression: "60 * * * *", wantErr: true},
+		{name: "invalid step", expression: "*/0 * * * *", wantErr: true},
+		{name: "invalid range", expression: "0 18-8 * * *", wantErr: true},
+		{name: "invalid name", expression: "0 0 * * someday", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCronExpression(tt.expression)
+			if tt.wantErr {
+				require.NotNil(t, err)
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	from := time.Date(2022, 5, 10, 9, 51, 30, 0, time.UTC) // a tuesday
+
+	tests := []struct {
+		name       string
+		expression string
+		want       time.Time
+	}{
+		{
+			name:       "every minute",
+			expression: "* * * * *",
+			want:       time.Date(2022, 5, 10, 9, 52, 0, 0, time.UTC),
+		},
+		{
+			name:       "every 15 minutes",
+			expression: "*/15 * * * *",
+			want:       time.Date(2022, 5, 10, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "nightly",
+			expression: "0 2 * * *",
+			want:       time.Date(2022, 5, 11, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "weekly on sunday",
+			expression: "@weekly",
+			want:       time.Date(2022, 5, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "first of next year",
+			expression: "30 6 1 jan *",
+			want:       time.Date(2023, 1, 1, 6, 30, 0, 0, time.UTC),
+		},
+		{
+			name:       "day of month or day of week",
+			expression: "0 0 20 * mon",
+			want:       time.Date(2022, 5, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "leap day",
+			expression: "0 0 29 2 *",
+			want:       time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseCronExpression(tt.expression)
+			require.Nil(t, err)
+			require.Equal(t, tt.want, schedule.Next(from))
+		})
+	}
+}
@@ -28,21 +28,36 @@ var _ common.ConfigurationStore = &ConfigurationStoreMock{}
 // 			CreateServiceFunc: func(projectName string, stageName string, serviceName string) error {
 // 				panic("mock out the CreateService method")
 // 			},
+// 			CreateServiceResourcesFunc: func(projectName string, stageName string, serviceName string, resources []*apimodels.Resource) error {
+// 				panic("mock out the CreateServiceResources method")
+// 			},
 // 			CreateStageFunc: func(projectName string, stage string) error {
 // 				panic("mock out the CreateStage method")
 // 			},
+// 			CreateStageResourcesFunc: func(projectName string, stageName string, resources []*apimodels.Resource) error {
+// 				panic("mock out the CreateStageResources method")
+// 			},
 // 			DeleteProjectFunc: func(projectName string) error {
 // 				panic("mock out the DeleteProject method")
 // 			},
 // 			DeleteServiceFunc: func(projectName string, stageName string, serviceName string) error {
 // 				panic("mock out the DeleteService method")
 // 			},
+// 			DeleteStageFunc: func(projectName string, stage string) error {
+// 				panic("mock out the DeleteStage method")
+// 			},
 // 			GetProjectResourceFunc: func(projectName string, resourceURI string) (*apimodels.Resource, error) {
 // 				panic("mock out the GetProjectResource method")
 // 			},
+// 			GetServiceResourcesFunc: func(projectName string, stageName string, serviceName string) ([]*apimodels.Resource, error) {
+// 				panic("mock out the GetServiceResources method")
+// 			},
 // 			GetStageResourceFunc: func(projectName string, stageName string, resourceURI string) (*apimodels.Resource, error) {
 // 				panic("mock out the GetStageResource method")
 // 			},
+// 			GetStageResourcesFunc: func(projectName string, stageName string) ([]*apimodels.Resource, error) {
+// 				panic("mock out the GetStageResources method")
+// 			},
 // 			UpdateProjectFunc: func(project apimodels.Project) error {
 // 				panic("mock out the UpdateProject method")
 // 			},
@@ -65,21 +80,36 @@ type ConfigurationStoreMock struct {
 	// CreateServiceFunc mocks the CreateService method.
 	CreateServiceFunc func(projectName string, stageName string, serviceName string) error
 
+	// CreateServiceResourcesFunc mocks the CreateServiceResources method.
+	CreateServiceResourcesFunc func(projectName string, stageName string, serviceName string, resources []*apimodels.Resource) error
+
 	// CreateStageFunc mocks the CreateStage method.
 	CreateStageFunc func(projectName string, stage string) error
 
+	// CreateStageResourcesFunc mocks the CreateStageResources method.
+	CreateStageResourcesFunc func(projectName string, stageName string, resources []*apimodels.Resource) error
+
 	// DeleteProjectFunc mocks the DeleteProject method.
 	DeleteProjectFunc func(projectName string) error
 
 	// DeleteServiceFunc mocks the DeleteService method.
 	DeleteServiceFunc func(projectName string, stageName string, serviceName string) error
 
+	// DeleteStageFunc mocks the DeleteStage method.
+	DeleteStageFunc func(projectName string, stage string) error
+
 	// GetProjectResourceFunc mocks the GetProjectResource method.
 	GetProjectResourceFunc func(projectName string, resourceURI string) (*apimodels.Resource, error)
 
+	// GetServiceResourcesFunc mocks the GetServiceResources method.
+	GetServiceResourcesFunc func(projectName string, stageName string, serviceName string) ([]*apimodels.Resource, error)
+
 	// GetStageResourceFunc mocks the GetStageResource method.
 	GetStageResourceFunc func(projectName string, stageName string, resourceURI string) (*apimodels.Resource, error)
 
+	// GetStageResourcesFunc mocks the GetStageResources method.
+	GetStageResourcesFunc func(projectName string, stageName string) ([]*apimodels.Resource, error)
+
 	// UpdateProjectFunc mocks the UpdateProject method.
 	UpdateProjectFunc func(project apimodels.Project) error
 
@@ -109,6 +139,17 @@ type ConfigurationStoreMock struct {
 			// ServiceName is the serviceName argument value.
 			ServiceName string
 		}
+		// CreateServiceResources holds details about calls to the CreateServiceResources method.
+		CreateServiceResources []struct {
+			// ProjectName is the projectName argument value.
+			ProjectName string
+			// StageName is the stageName argument value.
+			StageName string
+			// ServiceName is the serviceName argument value.
+			ServiceName string
+			// Resources is the resources argument value.
+			Resources []*apimodels.Resource
+		}
 		// CreateStage holds details about calls to the CreateStage method.
 		CreateStage []struct {
 			// ProjectName is the projectName argument value.
@@ -116,6 +157,15 @@ type ConfigurationStoreMock struct {
 			// Stage is the stage argument value.
 			Stage string
 		}
+		// CreateStageResources holds details about calls to the CreateStageResources method.
+		CreateStageResources []struct {
+			// ProjectName is the projectName argument value.
+			ProjectName string
+			// StageName is the stageName argument value.
+			StageName string
+			// Resources is the resources argument value.
+			Resources []*apimodels.Resource
+		}
 		// DeleteProject holds details about calls to the DeleteProject method.
 		DeleteProject []struct {
 			// ProjectName is the projectName argument value.
@@ -130,6 +180,13 @@ type ConfigurationStoreMock struct {
 			// ServiceName is the serviceName argument value.
 			ServiceName string
 		}
+		// DeleteStage holds details about calls to the DeleteStage method.
+		DeleteStage []struct {
+			// ProjectName is the projectName argument value.
+			ProjectName string
+			// Stage is the stage argument value.
+			Stage string
+		}
 		// GetProjectResource holds details about calls to the GetProjectResource method.
 		GetProjectResource []struct {
 			// ProjectName is the projectName argument value.
@@ -137,6 +194,15 @@ type ConfigurationStoreMock struct {
 			// ResourceURI is the resourceURI argument value.
 			ResourceURI string
 		}
+		// GetServiceResources holds details about calls to the GetServiceResources method.
+		GetServiceResources []struct {
+			// ProjectName is the projectName argument value.
+			ProjectName string
+			// StageName is the stageName argument value.
+			StageName string
+			// ServiceName is the serviceName argument value.
+			ServiceName string
+		}
 		// GetStageResource holds details about calls to the GetStageResource method.
 		GetStageResource []struct {
 			// ProjectName is the projectName argument value.
@@ -146,6 +212,13 @@ type ConfigurationStoreMock struct {
 			// ResourceURI is the resourceURI argument value.
 			ResourceURI string
 		}
+		// GetStageResources holds details about calls to the GetStageResources method.
+		GetStageResources []struct {
+			// ProjectName is the projectName argument value.
+			ProjectName string
+			// StageName is the stageName argument value.
+			StageName string
+		}
 		// UpdateProject holds details about calls to the UpdateProject method.
 		UpdateProject []struct {
 			// Project is the project argument value.
@@ -159,16 +232,21 @@ type ConfigurationStoreMock struct {
 			Resource *apimodels.Resource
 		}
 	}
-	lockCreateProject         sync.RWMutex
-	lockCreateProjectShipyard sync.RWMutex
-	lockCreateService         sync.RWMutex
-	lockCreateStage           sync.RWMutex
-	lockDeleteProject         sync.RWMutex
-	lockDeleteService         sync.RWMutex
-	lockGetProjectResource    sync.RWMutex
-	lockGetStageResource      sync.RWMutex
-	lockUpdateProject         sync.RWMutex
-	lockUpdateProjectResource sync.RWMutex
+	lockCreateProject          sync.RWMutex
+	lockCreateProjectShipyard  sync.RWMutex
+	lockCreateService          sync.RWMutex
+	lockCreateServiceResources sync.RWMutex
+	lockCreateStage            sync.RWMutex
+	lockCreateStageResources   sync.RWMutex
+	lockDeleteProject          sync.RWMutex
+	lockDeleteService          sync.RWMutex
+	lockDeleteStage            sync.RWMutex
+	lockGetProjectResource     sync.RWMutex
+	lockGetServiceResources    sync.RWMutex
+	lockGetStageResource       sync.RWMutex
+	lockGetStageResources      sync.RWMutex
+	lockUpdateProject          sync.RWMutex
+	lockUpdateProjectResource  sync.RWMutex
 }
 
 // CreateProject calls CreateProjectFunc.
@@ -189,7 +267,8 @@ func (mock *ConfigurationStoreMock) CreateProject(project apimodels.Project) err
 
 // CreateProjectCalls gets all the calls that were made to CreateProject.
 // Check the length with:
-//     len(mockedConfigurationStore.CreateProjectCalls())
+//
+// 	len(mockedConfigurationStore.CreateProjectCalls())
 func (mock *ConfigurationStoreMock) CreateProjectCalls() []struct {
 	Project apimodels.Project
 } {
@@ -222,7 +301,8 @@ func (mock *ConfigurationStoreMock) CreateProjectShipyard(projectName string, re
 
 // CreateProjectShipyardCalls gets all the calls that were made to CreateProjectShipyard.
 // Check the length with:
-//     len(mockedConfigurationStore.CreateProjectShipyardCalls())
+//
+// 	len(mockedConfigurationStore.CreateProjectShipyardCalls())
 func (mock *ConfigurationStoreMock) CreateProjectShipyardCalls() []struct {
 	ProjectName string
 	Resources   []*apimodels.Resource
@@ -259,7 +339,8 @@ func (mock *ConfigurationStoreMock) CreateService(projectName string, stageName
 
 // CreateServiceCalls gets all the calls that were made to CreateService.
 // Check the length with:
-//     len(mockedConfigurationStore.CreateServiceCalls())
+//
+// 	len(mockedConfigurationStore.CreateServiceCalls())
 func (mock *ConfigurationStoreMock) CreateServiceCalls() []struct {
 	ProjectName string
 	StageName   string
@@ -276,6 +357,50 @@ func (mock *ConfigurationStoreMock) CreateServiceCalls() []struct {
 	return calls
 }
 
+// CreateServiceResources calls CreateServiceResourcesFunc.
+func (mock *ConfigurationStoreMock) CreateServiceResources(projectName string, stageName string, serviceName string, resources []*apimodels.Resource) error {
+	if mock.CreateServiceResourcesFunc == nil {
+		panic("ConfigurationStoreMock.CreateServiceResourcesFunc: method is nil but ConfigurationStore.CreateServiceResources was just called")
+	}
+	callInfo := struct {
+		ProjectName string
+		StageName   string
+		ServiceName string
+		Resources   []*apimodels.Resource
+	}{
+		ProjectName: projectName,
+		StageName:   stageName,
+		ServiceName: serviceName,
+		Resources:   resources,
+	}
+	mock.lockCreateServiceResources.Lock()
+	mock.calls.CreateServiceResources = append(mock.calls.CreateServiceResources, callInfo)
+	mock.lockCreateServiceResources.Unlock()
+	return mock.CreateServiceResourcesFunc(projectName, stageName, serviceName, resources)
+}
+
+// CreateServiceResourcesCalls gets all the calls that were made to CreateServiceResources.
+// Check the length with:
+//
+// 	len(mockedConfigurationStore.CreateServiceResourcesCalls())
+func (mock *ConfigurationStoreMock) CreateServiceResourcesCalls() []struct {
+	ProjectName string
+	StageName   string
+	ServiceName string
+	Resources   []*apimodels.Resource
+} {
+	var calls []struct {
+		ProjectName string
+		StageName   string
+		ServiceName string
+		Resources   []*apimodels.Resource
+	}
+	mock.lockCreateServiceResources.RLock()
+	calls = mock.calls.CreateServiceResources
+	mock.lockCreateServiceResources.RUnlock()
+	return calls
+}
+
 // CreateStage calls CreateStageFunc.
 func (mock *ConfigurationStoreMock) CreateStage(projectName string, stage string) error {
 	if mock.CreateStageFunc == nil {
@@ -296,7 +421,8 @@ func (mock *ConfigurationStoreMock) CreateStage(projectName string, stage string
 
 // CreateStageCalls gets all the calls that were made to CreateStage.
 // Check the length with:
-//     len(mockedConfigurationStore.CreateStageCalls())
+//
+// 	len(mockedConfigurationStore.CreateStageCalls())
 func (mock *ConfigurationStoreMock) CreateStageCalls() []struct {
 	ProjectName string
 	Stage       string
@@ -311,6 +437,46 @@ func (mock *ConfigurationStoreMock) CreateStageCalls() []struct {
 	return calls
 }
 
+// CreateStageResources calls CreateStageResourcesFunc.
+func (mock *ConfigurationStoreMock) CreateStageResources(projectName string, stageName string, resources []*apimodels.Resource) error {
+	if mock.CreateStageResourcesFunc == nil {
+		panic("ConfigurationStoreMock.CreateStageResourcesFunc: method is nil but ConfigurationStore.CreateStageResources was just called")
+	}
+	callInfo := struct {
+		ProjectName string
+		StageName   string
+		Resources   []*apimodels.Resource
+	}{
+		ProjectName: projectName,
+		StageName:   stageName,
+		Resources:   resources,
+	}
+	mock.lockCreateStageResources.Lock()
+	mock.calls.CreateStageResources = append(mock.calls.CreateStageResources, callInfo)
+	mock.lockCreateStageResources.Unlock()
+	return mock.CreateStageResourcesFunc(projectName, stageName, resources)
+}
+
+// CreateStageResourcesCalls gets all the calls that were made to CreateStageResources.
+// Check the length with:
+//
+// 	len(mockedConfigurationStore.CreateStageResourcesCalls())
+func (mock *ConfigurationStoreMock) CreateStageResourcesCalls() []struct {
+	ProjectName string
+	StageName   string
+	Resources   []*apimodels.Resource
+} {
+	var calls []struct {
+		ProjectName string
+		StageName   string
+		Resources   []*apimodels.Resource
+	}
+	mock.lockCreateStageResources.RLock()
+	calls = mock.calls.CreateStageResources
+	mock.lockCreateStageResources.RUnlock()
+	return calls
+}
+
 // DeleteProject calls DeleteProjectFunc.
 func (mock *ConfigurationStoreMock) DeleteProject(projectName string) error {
 	if mock.DeleteProjectFunc == nil {
@@ -329,7 +495,8 @@ func (mock *ConfigurationStoreMock) DeleteProject(projectName string) error {
 
 // DeleteProjectCalls gets all the calls that were made to DeleteProject.
 // Check the length with:
-//     len(mockedConfigurationStore.DeleteProjectCalls())
+//
+// 	len(mockedConfigurationStore.DeleteProjectCalls())
 func (mock *ConfigurationStoreMock) DeleteProjectCalls() []struct {
 	ProjectName string
 } {
@@ -364,7 +531,8 @@ func (mock *ConfigurationStoreMock) DeleteService(projectName string, stageName
 
 // DeleteServiceCalls gets all the calls that were made to DeleteService.
 // Check the length with:
-//     len(mockedConfigurationStore.DeleteServiceCalls())
+//
+// 	len(mockedConfigurationStore.DeleteServiceCalls())
 func (mock *ConfigurationStoreMock) DeleteServiceCalls() []struct {
 	ProjectName string
 	StageName   string
@@ -381,6 +549,42 @@ func (mock *ConfigurationStoreMock) DeleteServiceCalls() []struct {
 	return calls
 }
 
+// DeleteStage calls DeleteStageFunc.
+func (mock *ConfigurationStoreMock) DeleteStage(projectName string, stage string) error {
+	if mock.DeleteStageFunc == nil {
+		panic("ConfigurationStoreMock.DeleteStageFunc: method is nil but ConfigurationStore.DeleteStage was just called")
+	}
+	callInfo := struct {
+		ProjectName string
+		Stage       string
+	}{
+		ProjectName: projectName,
+		Stage:       stage,
+	}
+	mock.lockDeleteStage.Lock()
+	mock.calls.DeleteStage = append(mock.calls.DeleteStage, callInfo)
+	mock.lockDeleteStage.Unlock()
+	return mock.DeleteStageFunc(projectName, stage)
+}
+
+// DeleteStageCalls gets all the calls that were made to DeleteStage.
+// Check the length with:
+//
+// 	len(mockedConfigurationStore.DeleteStageCalls())
+func (mock *ConfigurationStoreMock) DeleteStageCalls() []struct {
+	ProjectName string
+	Stage       string
+} {
+	var calls []struct {
+		ProjectName string
+		Stage       string
+	}
+	mock.lockDeleteStage.RLock()
+	calls = mock.calls.DeleteStage
+	mock.lockDeleteStage.RUnlock()
+	return calls
+}
+
 // GetProjectResource calls GetProjectResourceFunc.
 func (mock *ConfigurationStoreMock) GetProjectResource(projectName string, resourceURI string) (*apimodels.Resource, error) {
 	if mock.GetProjectResourceFunc == nil {
@@ -401,7 +605,8 @@ func (mock *ConfigurationStoreMock) GetProjectResource(projectName string, resou
 
 // GetProjectResourceCalls gets all the calls that were made to GetProjectResource.
 // Check the length with:
-//     len(mockedConfigurationStore.GetProjectResourceCalls())
+//
+// 	len(mockedConfigurationStore.GetProjectResourceCalls())
 func (mock *ConfigurationStoreMock) GetProjectResourceCalls() []struct {
 	ProjectName string
 	ResourceURI string
@@ -416,6 +621,46 @@ func (mock *ConfigurationStoreMock) GetProjectResourceCalls() []struct {
 	return calls
 }
 
+// GetServiceResources calls GetServiceResourcesFunc.
+func (mock *ConfigurationStoreMock) GetServiceResources(projectName string, stageName string, serviceName string) ([]*apimodels.Resource, error) {
+	if mock.GetServiceResourcesFunc == nil {
+		panic("ConfigurationStoreMock.GetServiceResourcesFunc: method is nil but ConfigurationStore.GetServiceResources was just called")
+	}
+	callInfo := struct {
+		ProjectName string
+		StageName   string
+		ServiceName string
+	}{
+		ProjectName: projectName,
+		StageName:   stageName,
+		ServiceName: serviceName,
+	}
+	mock.lockGetServiceResources.Lock()
+	mock.calls.GetServiceResources = append(mock.calls.GetServiceResources, callInfo)
+	mock.lockGetServiceResources.Unlock()
+	return mock.GetServiceResourcesFunc(projectName, stageName, serviceName)
+}
+
+// GetServiceResourcesCalls gets all the calls that were made to GetServiceResources.
+// Check the length with:
+//
+// 	len(mockedConfigurationStore.GetServiceResourcesCalls())
+func (mock *ConfigurationStoreMock) GetServiceResourcesCalls() []struct {
+	ProjectName string
+	StageName   string
+	ServiceName string
+} {
+	var calls []struct {
+		ProjectName string
+		StageName   string
+		ServiceName string
+	}
+	mock.lockGetServiceResources.RLock()
+	calls = mock.calls.GetServiceResources
+	mock.lockGetServiceResources.RUnlock()
+	return calls
+}
+
 // GetStageResource calls GetStageResourceFunc.
 func (mock *ConfigurationStoreMock) GetStageResource(projectName string, stageName string, resourceURI string) (*apimodels.Resource, error) {
 	if mock.GetStageResourceFunc == nil {
@@ -438,7 +683,8 @@ func (mock *ConfigurationStoreMock) GetStageResource(projectName string, stageNa
 
 // GetStageResourceCalls gets all the calls that were made to GetStageResource.
 // Check the length with:
-//     len(mockedConfigurationStore.GetStageResourceCalls())
+//
+// 	len(mockedConfigurationStore.GetStageResourceCalls())
 func (mock *ConfigurationStoreMock) GetStageResourceCalls() []struct {
 	ProjectName string
 	StageName   string
@@ -455,6 +701,42 @@ func (mock *ConfigurationStoreMock) GetStageResourceCalls() []struct {
 	return calls
 }
 
+// GetStageResources calls GetStageResourcesFunc.
+func (mock *ConfigurationStoreMock) GetStageResources(projectName string, stageName string) ([]*apimodels.Resource, error) {
+	if mock.GetStageResourcesFunc == nil {
+		panic("ConfigurationStoreMock.GetStageResourcesFunc: method is nil but ConfigurationStore.GetStageResources was just called")
+	}
+	callInfo := struct {
+		ProjectName string
+		StageName   string
+	}{
+		ProjectName: projectName,
+		StageName:   stageName,
+	}
+	mock.lockGetStageResources.Lock()
+	mock.calls.GetStageResources = append(mock.calls.GetStageResources, callInfo)
+	mock.lockGetStageResources.Unlock()
+	return mock.GetStageResourcesFunc(projectName, stageName)
+}
+
+// GetStageResourcesCalls gets all the calls that were made to GetStageResources.
+// Check the length with:
+//
+// 	len(mockedConfigurationStore.GetStageResourcesCalls())
+func (mock *ConfigurationStoreMock) GetStageResourcesCalls() []struct {
+	ProjectName string
+	StageName   string
+} {
+	var calls []struct {
+		ProjectName string
+		StageName   string
+	}
+	mock.lockGetStageResources.RLock()
+	calls = mock.calls.GetStageResources
+	mock.lockGetStageResources.RUnlock()
+	return calls
+}
+
 // UpdateProject calls UpdateProjectFunc.
 func (mock *ConfigurationStoreMock) UpdateProject(project apimodels.Project) error {
 	if mock.UpdateProjectFunc == nil {
@@ -473,7 +755,8 @@ func (mock *ConfigurationStoreMock) UpdateProject(project apimodels.Project) err
 
 // UpdateProjectCalls gets all the calls that were made to UpdateProject.
 // Check the length with:
-//     len(mockedConfigurationStore.UpdateProjectCalls())
+//
+// 	len(mockedConfigurationStore.UpdateProjectCalls())
 func (mock *ConfigurationStoreMock) UpdateProjectCalls() []struct {
 	Project apimodels.Project
 } {
@@ -506,7 +789,8 @@ func (mock *ConfigurationStoreMock) UpdateProjectResource(projectName string, re
 
 // UpdateProjectResourceCalls gets all the calls that were made to UpdateProjectResource.
 // Check the length with:
-//     len(mockedConfigurationStore.UpdateProjectResourceCalls())
+//
+// 	len(mockedConfigurationStore.UpdateProjectResourceCalls())
 func (mock *ConfigurationStoreMock) UpdateProjectResourceCalls() []struct {
 	ProjectName string
 	Resource    *apimodels.Resource
@@ -0,0 +1,249 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// FreezeActionWait specifies that sequences affected by a freeze window are kept in the queue until the window has ended
+	FreezeActionWait = "wait"
+	// FreezeActionReject specifies that sequences affected by a freeze window are finished with an error as soon as they are triggered
+	FreezeActionReject = "reject"
+)
+
+var freezeWindowDays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// FreezeWindow defines a period in which sequences must not be executed in a stage.
+// A freeze window is either a fixed period defined by its start and end, or a recurring period defined by the days of the week and a time range
+type FreezeWindow struct {
+	// Name is the name of the freeze window
+	Name string `json:"name" yaml:"name"`
+	// Sequences contains the names of the sequences affected by the freeze window. If empty, all sequences are affected
+	Sequences []string `json:"sequences,omitempty" yaml:"sequences,omitempty"`
+	// Start is the beginning of a fixed freeze window, e.g. '2022-12-24T00:00:00Z'
+	Start string `json:"start,omitempty" yaml:"start,omitempty"`
+	// End is the end of a fixed freeze window, e.g. '2022-12-27T00:00:00Z'
+	End string `json:"end,omitempty" yaml:"end,omitempty"`
+	// Days contains the days of the week on which a recurring freeze window starts, e.g. 'fri'. If empty, the window starts on every day
+	Days []string `json:"days,omitempty" yaml:"days,omitempty"`
+	// From is the time of the day at which a recurring freeze window starts, e.g. '15:00'. Defaults to '00:00'
+	From string `json:"from,omitempty" yaml:"from,omitempty"`
+	// To is the time of the day at which a recurring freeze window ends, e.g. '23:00'. If it is before From, the window ends on the following day. Defaults to the end of the day
+	To string `json:"to,omitempty" yaml:"to,omitempty"`
+	// Timezone is the IANA timezone in which From and To are interpreted, e.g. 'Europe/Vienna'. Defaults to UTC
+	Timezone string `json:"timezone,omitempty" yaml:"timezone,omitempty"`
+	// Action defines what happens to the affected sequences, i.e. 'wait' or 'reject'. Defaults to 'wait'
+	Action string `json:"action,omitempty" yaml:"action,omitempty"`
+	// Message is an optional explanation of the freeze window, which is included in the messages of affected sequences
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// GetAction returns the action to be applied to sequences affected by the freeze window
+func (w FreezeWindow) GetAction() string {
+	if w.Action == "" {
+		return FreezeActionWait
+	}
+	return w.Action
+}
+
+// AppliesTo determines whether the given sequence is affected by the freeze window
+func (w FreezeWindow) AppliesTo(sequenceName string) bool {
+	if len(w.Sequences) == 0 {
+		return true
+	}
+	for _, sequence := range w.Sequences {
+		if sequence == sequenceName {
+			return true
+		}
+	}
+	return false
+}
+
+// IsActive determines whether the freeze window is active at the given point in time
+func (w FreezeWindow) IsActive(t time.Time) bool {
+	if w.Start != "" || w.End != "" {
+		start, end, err := w.getPeriod()
+		if err != nil {
+			return false
+		}
+		return !t.Before(start) && t.Before(end)
+	}
+
+	location, from, to, err := w.getRecurringRange()
+	if err != nil {
+		return false
+	}
+	local := t.In(location)
+	minuteOfDay := local.Hour()*60 + local.Minute()
+
+	if from < to {
+		return w.startsOn(local.Weekday()) && minuteOfDay >= from && minuteOfDay < to
+	}
+	// the window spans midnight, i.e. it is either active after its start on the same day, or before its end on the following day
+	if w.startsOn(local.Weekday()) && minuteOfDay >= from {
+		return true
+	}
+	return w.startsOn(local.AddDate(0, 0, -1).Weekday()) && minuteOfDay < to
+}
+
+// Validate checks whether the freeze window is valid
+func (w FreezeWindow) Validate() error {
+	if w.Name == "" {
+		return fmt.Errorf("name must not be empty")
+	}
+	switch w.GetAction() {
+	case FreezeActionWait, FreezeActionReject:
+	default:
+		return fmt.Errorf("invalid action '%s', expected '%s' or '%s'", w.Action, FreezeActionWait, FreezeActionReject)
+	}
+
+	if w.Start != "" || w.End != "" {
+		if len(w.Days) > 0 || w.From != "" || w.To != "" || w.Timezone != "" {
+			return fmt.Errorf("start and end cannot be combined with days, from, to or timezone")
+		}
+		start, end, err := w.getPeriod()
+		if err != nil {
+			return err
+		}
+		if !end.After(start) {
+			return fmt.Errorf("end must be after start")
+		}
+		return nil
+	}
+
+	for _, day := range w.Days {
+		if _, ok := freezeWindowDays[strings.ToLower(day)]; !ok {
+			return fmt.Errorf("invalid day '%s', expected one of mon, tue, wed, thu, fri, sat, sun", day)
+		}
+	}
+	_, from, to, err := w.getRecurringRange()
+	if err != nil {
+		return err
+	}
+	if from == to {
+		return fmt.Errorf("from and to must not be equal")
+	}
+	return nil
+}
+
+func (w FreezeWindow) startsOn(weekday time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, day := range w.Days {
+		if freezeWindowDays[strings.ToLower(day)] == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+func (w FreezeWindow) getPeriod() (time.Time, time.Time, error) {
+	start, err := time.Parse(time.RFC3339, w.Start)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start '%s', expected a timestamp in RFC3339 format", w.Start)
+	}
+	end, err := time.Parse(time.RFC3339, w.End)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid end '%s', expected a timestamp in RFC3339 format", w.End)
+	}
+	return start, end, nil
+}
+
+// getRecurringRange returns the location, and the minutes of the day at which a recurring freeze window starts and ends
+func (w FreezeWindow) getRecurringRange() (*time.Location, int, int, error) {
+	location := time.UTC
+	if w.Timezone != "" {
+		var err error
+		location, err = time.LoadLocation(w.Timezone)
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("invalid timezone '%s'", w.Timezone)
+		}
+	}
+	from, err := parseTimeOfDay(w.From, 0)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("invalid from '%s': %w", w.From, err)
+	}
+	to, err := parseTimeOfDay(w.To, 24*60)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("invalid to '%s': %w", w.To, err)
+	}
+	return location, from, to, nil
+}
+
+// parseTimeOfDay parses a time of the day in the format 'HH:MM' and returns the minutes since midnight
+func parseTimeOfDay(value string, defaultValue int) (int, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("expected a time of the day in the format HH:MM")
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// GetActiveFreezeWindow returns the first freeze window that is active at the given point in time and affects the given sequence.
+// If no such freeze window exists, nil is returned
+func GetActiveFreezeWindow(freezeWindows []FreezeWindow, sequenceName string, t time.Time) *FreezeWindow {
+	for index := range freezeWindows {
+		if freezeWindows[index].AppliesTo(sequenceName) && freezeWindows[index].IsActive(t) {
+			return &freezeWindows[index]
+		}
+	}
+	return nil
+}
+
+// shipyardFreezeWindows is used to read the freeze windows of the stages within a shipyard, since they are not contained in keptnv2.Stage
+type shipyardFreezeWindows struct {
+	Spec struct {
+		Stages []struct {
+			Name   string         `yaml:"name"`
+			Freeze []FreezeWindow `yaml:"freeze"`
+		} `yaml:"stages"`
+	} `yaml:"spec"`
+}
+
+// GetShipyardFreezeWindows returns the freeze windows defined within the given shipyard content, grouped by the names of their stages
+func GetShipyardFreezeWindows(shipyardContent string) (map[string][]FreezeWindow, error) {
+	shipyard := &shipyardFreezeWindows{}
+	if err := yaml.Unmarshal([]byte(shipyardContent), shipyard); err != nil {
+		return nil, fmt.Errorf("could not decode freeze windows of shipyard: %w", err)
+	}
+
+	freezeWindows := map[string][]FreezeWindow{}
+	for _, stage := range shipyard.Spec.Stages {
+		if len(stage.Freeze) > 0 {
+			freezeWindows[stage.Name] = stage.Freeze
+		}
+	}
+	return freezeWindows, nil
+}
+
+// ValidateShipyardFreezeWindows checks whether the freeze windows defined within the given shipyard content are valid
+func ValidateShipyardFreezeWindows(shipyardContent string) error {
+	freezeWindows, err := GetShipyardFreezeWindows(shipyardContent)
+	if err != nil {
+		return err
+	}
+	for stageName, stageFreezeWindows := range freezeWindows {
+		for _, freezeWindow := range stageFreezeWindows {
+			if err := freezeWindow.Validate(); err != nil {
+				return fmt.Errorf("freeze window %s in stage %s: %w", freezeWindow.Name, stageName, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const shipyardWithFreezeWindows = `apiVersion: "spec.keptn.sh/0.2.2"
+kind: "Shipyard"
+metadata:
+  name: "shipyard-sockshop"
+spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "deployment"
+    - name: "production"
+      freeze:
+        - name: "friday-afternoon"
+          sequences: ["delivery"]
+          days: ["fri"]
+          from: "15:00"
+          message: "no deployments to production on Friday afternoons"
+        - name: "christmas"
+          start: "2022-12-24T00:00:00Z"
+          end: "2022-12-27T00:00:00Z"
+          action: "reject"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "deployment"`
+
+func TestGetShipyardFreezeWindows(t *testing.T) {
+	freezeWindows, err := GetShipyardFreezeWindows(shipyardWithFreezeWindows)
+	require.Nil(t, err)
+	require.Len(t, freezeWindows, 1)
+	require.Len(t, freezeWindows["production"], 2)
+	require.Equal(t, "friday-afternoon", freezeWindows["production"][0].Name)
+	require.Equal(t, FreezeActionWait, freezeWindows["production"][0].GetAction())
+	require.Equal(t, FreezeActionReject, freezeWindows["production"][1].GetAction())
+
+	require.Nil(t, ValidateShipyardFreezeWindows(shipyardWithFreezeWindows))
+}
+
+func TestFreezeWindow_IsActive(t *testing.T) {
+	// 2022-03-04 is a Friday
+	friday := func(hour, minute int) time.Time {
+		return time.Date(2022, 3, 4, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name   string
+		window FreezeWindow
+		t      time.Time
+		want   bool
+	}{
+		{
+			name:   "recurring window - active",
+			window: FreezeWindow{Days: []string{"fri"}, From: "15:00"},
+			t:      friday(15, 0),
+			want:   true,
+		},
+		{
+			name:   "recurring window - before start",
+			window: FreezeWindow{Days: []string{"fri"}, From: "15:00"},
+			t:      friday(14, 59),
+			want:   false,
+		},
+		{
+			name:   "recurring window - other day",
+			window: FreezeWindow{Days: []string{"fri"}, From: "15:00"},
+			t:      friday(16, 0).AddDate(0, 0, 1),
+			want:   false,
+		},
+		{
+			name:   "recurring window spanning midnight - active on the following day",
+			window: FreezeWindow{Days: []string{"FRI"}, From: "22:00", To: "06:00"},
+			t:      friday(5, 59).AddDate(0, 0, 1),
+			want:   true,
+		},
+		{
+			name:   "recurring window spanning midnight - ended on the following day",
+			window: FreezeWindow{Days: []string{"fri"}, From: "22:00", To: "06:00"},
+			t:      friday(6, 0).AddDate(0, 0, 1),
+			want:   false,
+		},
+		{
+			name:   "recurring window in timezone",
+			window: FreezeWindow{From: "15:00", To: "18:00", Timezone: "Europe/Vienna"},
+			t:      friday(14, 30),
+			want:   true,
+		},
+		{
+			name:   "fixed window - active",
+			window: FreezeWindow{Start: "2022-12-24T00:00:00Z", End: "2022-12-27T00:00:00Z"},
+			t:      time.Date(2022, 12, 26, 23, 59, 0, 0, time.UTC),
+			want:   true,
+		},
+		{
+			name:   "fixed window - ended",
+			window: FreezeWindow{Start: "2022-12-24T00:00:00Z", End: "2022-12-27T00:00:00Z"},
+			t:      time.Date(2022, 12, 27, 0, 0, 0, 0, time.UTC),
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.window.IsActive(tt.t))
+		})
+	}
+}
+
+func TestFreezeWindow_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  FreezeWindow
+		wantErr bool
+	}{
+		{
+			name:   "valid recurring window",
+			window: FreezeWindow{Name: "weekend", Days: []string{"sat", "sun"}},
+		},
+		{
+			name:   "valid fixed window",
+			window: FreezeWindow{Name: "christmas", Start: "2022-12-24T00:00:00Z", End: "2022-12-27T00:00:00Z", Action: FreezeActionReject},
+		},
+		{
+			name:    "missing name",
+			window:  FreezeWindow{Days: []string{"sat"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid action",
+			window:  FreezeWindow{Name: "weekend", Action: "ignore"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid day",
+			window:  FreezeWindow{Name: "weekend", Days: []string{"saturday"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid time of the day",
+			window:  FreezeWindow{Name: "evening", From: "25:00"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid timezone",
+			window:  FreezeWindow{Name: "evening", From: "18:00", Timezone: "Somewhere/Else"},
+			wantErr: true,
+		},
+		{
+			name:    "end before start",
+			window:  FreezeWindow{Name: "christmas", Start: "2022-12-27T00:00:00Z", End: "2022-12-24T00:00:00Z"},
+			wantErr: true,
+		},
+		{
+			name:    "fixed window combined with recurring window",
+			window:  FreezeWindow{Name: "christmas", Start: "2022-12-24T00:00:00Z", End: "2022-12-27T00:00:00Z", Days: []string{"fri"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.window.Validate()
+			require.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
+
+func TestGetActiveFreezeWindow(t *testing.T) {
+	freezeWindows := []FreezeWindow{
+		{Name: "delivery-freeze", Sequences: []string{"delivery"}},
+	}
+	now := time.Now()
+
+	require.Nil(t, GetActiveFreezeWindow(freezeWindows, "evaluation", now))
+
+	freezeWindow := GetActiveFreezeWindow(freezeWindows, "delivery", now)
+	require.NotNil(t, freezeWindow)
+	require.Equal(t, "delivery-freeze", freezeWindow.Name)
+}
@@ -99,6 +99,24 @@ func ValidateGitRemoteURL(gitUrl string) error {
 	return nil
 }
 
+// ValidateGitPullRequestProvider checks that the provider used to open pull requests for the upstream of a project is supported.
+// Pull requests are opened via the API of the provider, which requires an upstream accessed via HTTP(S) with a token
+func ValidateGitPullRequestProvider(provider string, gitUrl string, gitToken string) error {
+	if provider == "" {
+		return nil
+	}
+
+	if provider != "github" && provider != "gitlab" && provider != "gitea" {
+		return fmt.Errorf("only github, gitlab or gitea are supported as gitPullRequestProvider")
+	}
+
+	if !strings.HasPrefix(gitUrl, "https://") && !strings.HasPrefix(gitUrl, "http://") || gitToken == "" {
+		return fmt.Errorf("pull requests require a gitRemoteURL using http or https, and a gitToken")
+	}
+
+	return nil
+}
+
 // ValidateShipyardStages godoc
 func ValidateShipyardStages(shipyard *keptnv2.Shipyard) error {
 	// A shipyard must have at least one stage
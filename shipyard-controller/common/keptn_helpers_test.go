@@ -175,3 +175,29 @@ func TestValidateGitRemoteURL(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateGitPullRequestProvider(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		url      string
+		token    string
+		wantErr  bool
+	}{
+		{"no provider", "", "ssh://someSshUrl.com", "", false},
+		{"github", "github", "https://github.com/keptn/my-project.git", "token", false},
+		{"gitlab", "gitlab", "http://gitlab.local/keptn/my-project.git", "token", false},
+		{"gitea", "gitea", "https://gitea.local/keptn/my-project.git", "token", false},
+		{"unsupported provider", "bitbucket", "https://bitbucket.org/keptn/my-project.git", "token", true},
+		{"ssh upstream", "github", "ssh://someSshUrl.com", "", true},
+		{"missing token", "github", "https://github.com/keptn/my-project.git", "", true},
+		{"missing upstream", "github", "", "token", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateGitPullRequestProvider(tt.provider, tt.url, tt.token); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateGitPullRequestProvider() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
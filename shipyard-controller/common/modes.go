@@ -1,5 +1,7 @@
 package common
 
+import "time"
+
 type SDMode int
 
 const (
@@ -7,4 +9,18 @@ const (
 	SDModeRW SDMode = iota
 	// SDModeW set Sequence Dispatcher to act in write only mode, this is needed with multiple replicas
 	SDModeW
+	// SDModePartitioned sets the dispatchers to act in read and write mode on every replica. Each replica only dispatches
+	// the queued items it has claimed, and keeps its claims alive via heartbeats. Claims of replicas that stop sending heartbeats
+	// expire after a while, and can then be claimed by other replicas
+	SDModePartitioned
 )
+
+// ClaimOptions configures how the dispatchers claim queued items when running in SDModePartitioned
+type ClaimOptions struct {
+	// Owner uniquely identifies the replica claiming the items
+	Owner string
+	// TTL is the duration after which a claim expires if it is not renewed
+	TTL time.Duration
+	// BatchSize is the maximum number of items that are claimed in one iteration of a dispatcher
+	BatchSize int
+}
@@ -0,0 +1,169 @@
+package common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrShipyardStagesNotFound is returned if the list of stages cannot be found within the content of a shipyard
+var ErrShipyardStagesNotFound = errors.New("shipyard does not contain a list of stages")
+
+// ErrShipyardStageNotFound is returned if a stage is not part of the shipyard
+var ErrShipyardStageNotFound = errors.New("stage is not part of the shipyard")
+
+// AddShipyardStage adds a stage without any sequences at the given position to the stages of the shipyard content.
+// If the position is negative or beyond the last stage, the stage is appended
+func AddShipyardStage(shipyardContent, stageName string, position int) (string, error) {
+	return updateShipyardStages(shipyardContent, func(stages *yaml.Node) error {
+		stage := &yaml.Node{
+			Kind: yaml.MappingNode,
+			Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Value: "name"},
+				{Kind: yaml.ScalarNode, Value: stageName, Style: yaml.DoubleQuotedStyle},
+			},
+		}
+		if position < 0 || position > len(stages.Content) {
+			position = len(stages.Content)
+		}
+		stages.Content = append(stages.Content[:position], append([]*yaml.Node{stage}, stages.Content[position:]...)...)
+		return nil
+	})
+}
+
+// RemoveShipyardStage removes a stage from the stages of the shipyard content
+func RemoveShipyardStage(shipyardContent, stageName string) (string, error) {
+	return updateShipyardStages(shipyardContent, func(stages *yaml.Node) error {
+		index := findShipyardStage(stages, stageName)
+		if index < 0 {
+			return ErrShipyardStageNotFound
+		}
+		stages.Content = append(stages.Content[:index], stages.Content[index+1:]...)
+		return nil
+	})
+}
+
+// MoveShipyardStage moves a stage of the shipyard content to the given position.
+// If the position is negative or beyond the last stage, the stage is moved to the end
+func MoveShipyardStage(shipyardContent, stageName string, position int) (string, error) {
+	return updateShipyardStages(shipyardContent, func(stages *yaml.Node) error {
+		index := findShipyardStage(stages, stageName)
+		if index < 0 {
+			return ErrShipyardStageNotFound
+		}
+		stage := stages.Content[index]
+		stages.Content = append(stages.Content[:index], stages.Content[index+1:]...)
+		if position < 0 || position > len(stages.Content) {
+			position = len(stages.Content)
+		}
+		stages.Content = append(stages.Content[:position], append([]*yaml.Node{stage}, stages.Content[position:]...)...)
+		return nil
+	})
+}
+
+// RenameShipyardStage renames a stage of the shipyard content. The events of sequences that are triggered by the sequences of the stage,
+// e.g. 'dev.delivery.finished', are updated accordingly
+func RenameShipyardStage(shipyardContent, stageName, newStageName string) (string, error) {
+	return updateShipyardStages(shipyardContent, func(stages *yaml.Node) error {
+		index := findShipyardStage(stages, stageName)
+		if index < 0 {
+			return ErrShipyardStageNotFound
+		}
+		getMappingValue(stages.Content[index], "name").Value = newStageName
+
+		for _, stage := range stages.Content {
+			sequences := getMappingValue(stage, "sequences")
+			if sequences == nil || sequences.Kind != yaml.SequenceNode {
+				continue
+			}
+			for _, sequence := range sequences.Content {
+				triggers := getMappingValue(sequence, "triggeredOn")
+				if triggers == nil || triggers.Kind != yaml.SequenceNode {
+					continue
+				}
+				for _, trigger := range triggers.Content {
+					event := getMappingValue(trigger, "event")
+					if event != nil && strings.HasPrefix(event.Value, stageName+".") {
+						event.Value = newStageName + strings.TrimPrefix(event.Value, stageName)
+					}
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// GetShipyardStageReferences returns the sequences of other stages that are triggered by a sequence of the given stage, in the format '<stage>.<sequence>'
+func GetShipyardStageReferences(shipyard *keptnv2.Shipyard, stageName string) []string {
+	references := []string{}
+	for _, stage := range shipyard.Spec.Stages {
+		if stage.Name == stageName {
+			continue
+		}
+		for _, sequence := range stage.Sequences {
+			for _, trigger := range sequence.TriggeredOn {
+				if strings.HasPrefix(trigger.Event, stageName+".") {
+					references = append(references, fmt.Sprintf("%s.%s", stage.Name, sequence.Name))
+					break
+				}
+			}
+		}
+	}
+	return references
+}
+
+// updateShipyardStages applies the given update to the list of stages of the shipyard content.
+// The content is modified on the level of yaml nodes, which retains comments and properties that are not part of the shipyard spec
+func updateShipyardStages(shipyardContent string, update func(stages *yaml.Node) error) (string, error) {
+	document := &yaml.Node{}
+	if err := yaml.Unmarshal([]byte(shipyardContent), document); err != nil {
+		return "", err
+	}
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+		return "", ErrShipyardStagesNotFound
+	}
+	stages := getMappingValue(getMappingValue(document.Content[0], "spec"), "stages")
+	if stages == nil || stages.Kind != yaml.SequenceNode {
+		return "", ErrShipyardStagesNotFound
+	}
+
+	if err := update(stages); err != nil {
+		return "", err
+	}
+
+	buf := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(document); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func findShipyardStage(stages *yaml.Node, stageName string) int {
+	for index, stage := range stages.Content {
+		if name := getMappingValue(stage, "name"); name != nil && name.Value == stageName {
+			return index
+		}
+	}
+	return -1
+}
+
+func getMappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package common
+
+import (
+	"testing"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/require"
+)
+
+const testStagesShipyard = `apiVersion: "spec.keptn.sh/0.2.3"
+kind: "Shipyard"
+metadata:
+  name: "shipyard-sockshop"
+spec:
+  stages:
+    # the first stage
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "deployment"
+              timeout: "10m"
+    - name: "production"
+      sequences:
+        - name: "delivery"
+          triggeredOn:
+            - event: "dev.delivery.finished"
+          tasks:
+            - name: "deployment"
+`
+
+func getStageNames(t *testing.T, shipyardContent string) []string {
+	shipyard, err := UnmarshalShipyard(shipyardContent)
+	require.Nil(t, err)
+	names := []string{}
+	for _, stage := range shipyard.Spec.Stages {
+		names = append(names, stage.Name)
+	}
+	return names
+}
+
+func TestAddShipyardStage(t *testing.T) {
+	tests := []struct {
+		name       string
+		position   int
+		wantStages []string
+	}{
+		{name: "append stage", position: -1, wantStages: []string{"dev", "production", "hardening"}},
+		{name: "insert stage", position: 1, wantStages: []string{"dev", "hardening", "production"}},
+		{name: "insert first stage", position: 0, wantStages: []string{"hardening", "dev", "production"}},
+		{name: "position out of range", position: 5, wantStages: []string{"dev", "production", "hardening"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, err := AddShipyardStage(testStagesShipyard, "hardening", tt.position)
+			require.Nil(t, err)
+			require.Equal(t, tt.wantStages, getStageNames(t, updated))
+			// comments and task properties are retained
+			require.Contains(t, updated, "# the first stage")
+			require.Contains(t, updated, `timeout: "10m"`)
+		})
+	}
+}
+
+func TestRemoveShipyardStage(t *testing.T) {
+	updated, err := RemoveShipyardStage(testStagesShipyard, "production")
+	require.Nil(t, err)
+	require.Equal(t, []string{"dev"}, getStageNames(t, updated))
+
+	_, err = RemoveShipyardStage(testStagesShipyard, "staging")
+	require.ErrorIs(t, err, ErrShipyardStageNotFound)
+}
+
+func TestMoveShipyardStage(t *testing.T) {
+	updated, err := MoveShipyardStage(testStagesShipyard, "production", 0)
+	require.Nil(t, err)
+	require.Equal(t, []string{"production", "dev"}, getStageNames(t, updated))
+
+	updated, err = MoveShipyardStage(testStagesShipyard, "dev", -1)
+	require.Nil(t, err)
+	require.Equal(t, []string{"production", "dev"}, getStageNames(t, updated))
+
+	_, err = MoveShipyardStage(testStagesShipyard, "staging", 0)
+	require.ErrorIs(t, err, ErrShipyardStageNotFound)
+}
+
+func TestRenameShipyardStage(t *testing.T) {
+	updated, err := RenameShipyardStage(testStagesShipyard, "dev", "development")
+	require.Nil(t, err)
+	require.Equal(t, []string{"development", "production"}, getStageNames(t, updated))
+
+	shipyard, err := UnmarshalShipyard(updated)
+	require.Nil(t, err)
+	require.Equal(t, "development.delivery.finished", shipyard.Spec.Stages[1].Sequences[0].TriggeredOn[0].Event)
+	require.Contains(t, updated, `timeout: "10m"`)
+
+	_, err = RenameShipyardStage(testStagesShipyard, "staging", "development")
+	require.ErrorIs(t, err, ErrShipyardStageNotFound)
+}
+
+func TestUpdateShipyardStages_InvalidShipyard(t *testing.T) {
+	_, err := AddShipyardStage("apiVersion: spec.keptn.sh/0.2.3", "dev", -1)
+	require.ErrorIs(t, err, ErrShipyardStagesNotFound)
+
+	_, err = AddShipyardStage("spec:\n  stages: dev", "dev", -1)
+	require.ErrorIs(t, err, ErrShipyardStagesNotFound)
+}
+
+func TestGetShipyardStageReferences(t *testing.T) {
+	shipyard := &keptnv2.Shipyard{
+		Spec: keptnv2.ShipyardSpec{
+			Stages: []keptnv2.Stage{
+				{Name: "dev", Sequences: []keptnv2.Sequence{newTriggeredSequence("delivery", "")}},
+				{Name: "staging", Sequences: []keptnv2.Sequence{newTriggeredSequence("delivery", "dev.delivery.finished")}},
+				{Name: "production", Sequences: []keptnv2.Sequence{
+					newTriggeredSequence("delivery", "staging.delivery.finished"),
+					newTriggeredSequence("rollback", "production.delivery.finished"),
+				}},
+			},
+		},
+	}
+	require.Equal(t, []string{"staging.delivery"}, GetShipyardStageReferences(shipyard, "dev"))
+	require.Equal(t, []string{"production.delivery"}, GetShipyardStageReferences(shipyard, "staging"))
+	require.Empty(t, GetShipyardStageReferences(shipyard, "production"))
+}
@@ -0,0 +1,282 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TaskAttributes contains the attributes of a shipyard task that are interpreted by the shipyard controller. Since they are not contained in keptnv2.Task,
+// they are kept separately from the properties of the task, which are passed to the task executors as they are
+type TaskAttributes struct {
+	// Parallel is the name of the parallel group of the task. Consecutive tasks of a sequence that belong to the same group are triggered at the same time,
+	// and the sequence only proceeds once all of them have been finished
+	Parallel string `json:"parallel,omitempty" bson:"parallel,omitempty" yaml:"parallel,omitempty"`
+	// When is the condition that needs to be met for the task to be executed, e.g. 'evaluation.result == warning'
+	When string `json:"when,omitempty" bson:"when,omitempty" yaml:"when,omitempty"`
+	// Retry is the retry policy of the task
+	Retry *TaskRetryPolicy `json:"retry,omitempty" bson:"retry,omitempty" yaml:"retry,omitempty"`
+	// Timeout is the maximum duration of the task, starting with its first .started event, e.g. '30m'
+	Timeout string `json:"timeout,omitempty" bson:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+// GetParallelGroup returns the name of the parallel group the task belongs to. If the task does not belong to a group, an empty string is returned
+func (a TaskAttributes) GetParallelGroup() string {
+	return strings.TrimSpace(a.Parallel)
+}
+
+// GetCondition returns the condition that needs to be met for the task to be executed. If the task has no condition, an empty string is returned
+func (a TaskAttributes) GetCondition() string {
+	return strings.TrimSpace(a.When)
+}
+
+// GetTimeout returns the maximum duration of the task. If the task has no timeout, 0 is returned
+func (a TaskAttributes) GetTimeout() (time.Duration, error) {
+	if strings.TrimSpace(a.Timeout) == "" {
+		return 0, nil
+	}
+	return ParseTimeout(a.Timeout)
+}
+
+// Validate checks whether the condition, retry policy and timeout of the task are valid
+func (a TaskAttributes) Validate() error {
+	if condition := a.GetCondition(); condition != "" {
+		if _, err := ParseTaskCondition(condition); err != nil {
+			return err
+		}
+	}
+	if _, err := a.GetTimeout(); err != nil {
+		return err
+	}
+	if a.Retry != nil {
+		return a.Retry.Validate()
+	}
+	return nil
+}
+
+const (
+	// RetryOnErrored specifies that a task is retried if its status is 'errored'
+	RetryOnErrored = "errored"
+	// RetryOnFailed specifies that a task is retried if its result is 'fail'
+	RetryOnFailed = "failed"
+	// MaxTaskRetries is the highest number of retries a retry policy may define
+	MaxTaskRetries = 10
+	// MaxTaskRetryBackoff is the longest duration to wait before a retry, regardless of the backoff of the retry policy
+	MaxTaskRetryBackoff = 24 * time.Hour
+)
+
+// TaskRetryPolicy defines if, and how often a task should be retried if it could not be completed successfully
+type TaskRetryPolicy struct {
+	// MaxRetries is the maximum number of retries of the task
+	MaxRetries int `json:"maxRetries" bson:"maxRetries" yaml:"maxRetries"`
+	// Backoff is the duration to wait before the first retry. With each further retry, the duration is doubled
+	Backoff string `json:"backoff,omitempty" bson:"backoff,omitempty" yaml:"backoff,omitempty"`
+	// RetryOn contains the outcomes of a task that should lead to a retry, i.e. 'errored' and/or 'failed'. Defaults to 'errored'
+	RetryOn []string `json:"retryOn,omitempty" bson:"retryOn,omitempty" yaml:"retryOn,omitempty"`
+}
+
+// GetBackoff returns the duration to wait before the given retry, starting with 1 for the first retry
+func (p TaskRetryPolicy) GetBackoff(retry int) time.Duration {
+	if p.Backoff == "" || retry < 1 {
+		return 0
+	}
+	backoff, err := time.ParseDuration(p.Backoff)
+	if err != nil || backoff <= 0 {
+		return 0
+	}
+	exponent := retry - 1
+	if exponent > MaxTaskRetries {
+		exponent = MaxTaskRetries
+	}
+	// the multiplication is only done if it cannot exceed the maximum, which also prevents an overflow
+	if backoff > MaxTaskRetryBackoff>>exponent {
+		return MaxTaskRetryBackoff
+	}
+	return backoff << exponent
+}
+
+// ShouldRetryOn determines whether the given outcome of a task should lead to a retry
+func (p TaskRetryPolicy) ShouldRetryOn(outcome string) bool {
+	if len(p.RetryOn) == 0 {
+		return outcome == RetryOnErrored
+	}
+	for _, retryOn := range p.RetryOn {
+		if retryOn == outcome {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks whether the retry policy is valid
+func (p TaskRetryPolicy) Validate() error {
+	if p.MaxRetries < 0 || p.MaxRetries > MaxTaskRetries {
+		return fmt.Errorf("maxRetries must be between 0 and %d", MaxTaskRetries)
+	}
+	if p.Backoff != "" {
+		if backoff, err := time.ParseDuration(p.Backoff); err != nil || backoff < 0 {
+			return fmt.Errorf("invalid backoff '%s', expected a positive duration like '30s'", p.Backoff)
+		}
+	}
+	for _, retryOn := range p.RetryOn {
+		if retryOn != RetryOnErrored && retryOn != RetryOnFailed {
+			return fmt.Errorf("invalid retryOn value '%s', expected '%s' or '%s'", retryOn, RetryOnErrored, RetryOnFailed)
+		}
+	}
+	return nil
+}
+
+const (
+	taskConditionOperatorEquals    = "=="
+	taskConditionOperatorNotEquals = "!="
+	taskConditionAnd               = "&&"
+	taskConditionOr                = "||"
+)
+
+// shipyardTaskAttributes is used to read the attributes of shipyard tasks, since they are not contained in keptnv2.Task
+type shipyardTaskAttributes struct {
+	Spec struct {
+		Stages []struct {
+			Name      string `yaml:"name"`
+			Sequences []struct {
+				Name  string `yaml:"name"`
+				Tasks []struct {
+					Name           string `yaml:"name"`
+					TaskAttributes `yaml:",inline"`
+				} `yaml:"tasks"`
+			} `yaml:"sequences"`
+		} `yaml:"stages"`
+	} `yaml:"spec"`
+}
+
+// GetShipyardTaskAttributes returns the attributes of the tasks defined within the given shipyard content, grouped by the names of their stages and sequences.
+// The attributes of a sequence are ordered like its tasks, i.e. the attributes of the n-th task of a sequence are at index n
+func GetShipyardTaskAttributes(shipyardContent string) (map[string]map[string][]TaskAttributes, error) {
+	shipyard := &shipyardTaskAttributes{}
+	if err := yaml.Unmarshal([]byte(shipyardContent), shipyard); err != nil {
+		return nil, fmt.Errorf("could not decode task attributes of shipyard: %w", err)
+	}
+
+	attributes := map[string]map[string][]TaskAttributes{}
+	for _, stage := range shipyard.Spec.Stages {
+		attributes[stage.Name] = map[string][]TaskAttributes{}
+		for _, sequence := range stage.Sequences {
+			sequenceAttributes := make([]TaskAttributes, 0, len(sequence.Tasks))
+			for _, task := range sequence.Tasks {
+				sequenceAttributes = append(sequenceAttributes, task.TaskAttributes)
+			}
+			attributes[stage.Name][sequence.Name] = sequenceAttributes
+		}
+	}
+	return attributes, nil
+}
+
+// TaskConditionClause is a single comparison within a task condition, e.g. 'evaluation.result == warning'
+type TaskConditionClause struct {
+	Task     string
+	Property string
+	Operator string
+	Value    string
+}
+
+// TaskCondition is a parsed task condition. It consists of a list of alternatives that are combined with a logical OR,
+// each of them consisting of a list of clauses that are combined with a logical AND
+type TaskCondition struct {
+	Alternatives [][]TaskConditionClause
+}
+
+// ParseTaskCondition parses a condition such as 'evaluation.result == warning || test.status == errored'.
+// Comparisons can refer to the 'result' or the 'status' of a previous task, and can be combined using '&&' and '||', where '&&' takes precedence
+func ParseTaskCondition(condition string) (*TaskCondition, error) {
+	if strings.TrimSpace(condition) == "" {
+		return nil, fmt.Errorf("condition must not be empty")
+	}
+	result := &TaskCondition{}
+	for _, alternative := range strings.Split(condition, taskConditionOr) {
+		clauses := []TaskConditionClause{}
+		for _, clauseString := range strings.Split(alternative, taskConditionAnd) {
+			clause, err := parseTaskConditionClause(clauseString)
+			if err != nil {
+				return nil, fmt.Errorf("invalid condition '%s': %w", condition, err)
+			}
+			clauses = append(clauses, *clause)
+		}
+		result.Alternatives = append(result.Alternatives, clauses)
+	}
+	return result, nil
+}
+
+func parseTaskConditionClause(clause string) (*TaskConditionClause, error) {
+	operator := taskConditionOperatorEquals
+	if strings.Contains(clause, taskConditionOperatorNotEquals) {
+		operator = taskConditionOperatorNotEquals
+	}
+	operands := strings.Split(clause, operator)
+	if len(operands) != 2 {
+		return nil, fmt.Errorf("'%s' is not a comparison of the form <task>.<property> %s <value>", strings.TrimSpace(clause), operator)
+	}
+	left := strings.TrimSpace(operands[0])
+	value := strings.Trim(strings.TrimSpace(operands[1]), `"'`)
+
+	separatorIndex := strings.LastIndex(left, ".")
+	if separatorIndex <= 0 || separatorIndex == len(left)-1 {
+		return nil, fmt.Errorf("'%s' does not refer to a property of a task", left)
+	}
+	property := left[separatorIndex+1:]
+	if property != "result" && property != "status" {
+		return nil, fmt.Errorf("unsupported property '%s', only 'result' and 'status' can be used", property)
+	}
+	if value == "" {
+		return nil, fmt.Errorf("missing value to compare %s with", left)
+	}
+	return &TaskConditionClause{
+		Task:     left[:separatorIndex],
+		Property: property,
+		Operator: operator,
+		Value:    value,
+	}, nil
+}
+
+// Evaluate evaluates the condition. The getValue function is used to retrieve the current value of a property of a task.
+func (c TaskCondition) Evaluate(getValue func(task, property string) string) bool {
+	for _, alternative := range c.Alternatives {
+		matches := true
+		for _, clause := range alternative {
+			if !clause.matches(getValue(clause.Task, clause.Property)) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+func (c TaskConditionClause) matches(value string) bool {
+	if c.Operator == taskConditionOperatorNotEquals {
+		return value != c.Value
+	}
+	return value == c.Value
+}
+
+// ValidateShipyardTasks checks whether the conditions, retry policies and timeouts of all tasks within the given shipyard content are valid
+func ValidateShipyardTasks(shipyardContent string) error {
+	shipyard := &shipyardTaskAttributes{}
+	if err := yaml.Unmarshal([]byte(shipyardContent), shipyard); err != nil {
+		return fmt.Errorf("could not decode task attributes of shipyard: %w", err)
+	}
+	for _, stage := range shipyard.Spec.Stages {
+		for _, sequence := range stage.Sequences {
+			for _, task := range sequence.Tasks {
+				if err := task.Validate(); err != nil {
+					return fmt.Errorf("task %s of sequence %s in stage %s: %w", task.Name, sequence.Name, stage.Name, err)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,293 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const shipyardWithTaskAttributes = `apiVersion: "spec.keptn.sh/0.2.2"
+kind: "Shipyard"
+metadata:
+  name: "shipyard-sockshop"
+spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "deployment"
+              timeout: "30m"
+              retry:
+                maxRetries: 2
+                backoff: "30s"
+                retryOn: ["errored", "failed"]
+              properties:
+                deploymentstrategy: "direct"
+            - name: "test"
+              parallel: "verification"
+              properties:
+                teststrategy: "functional"
+                timeout: "5m"
+                retry: true
+            - name: "security-scan"
+              parallel: "verification"
+            - name: "evaluation"
+            - name: "approval"
+              when: "evaluation.result == warning"
+            - name: "release"`
+
+func TestGetShipyardTaskAttributes(t *testing.T) {
+	attributes, err := GetShipyardTaskAttributes(shipyardWithTaskAttributes)
+	require.Nil(t, err)
+
+	tasks := attributes["dev"]["delivery"]
+	require.Len(t, tasks, 6)
+
+	require.Equal(t, "", tasks[0].GetParallelGroup())
+	require.Equal(t, &TaskRetryPolicy{MaxRetries: 2, Backoff: "30s", RetryOn: []string{RetryOnErrored, RetryOnFailed}}, tasks[0].Retry)
+	require.Nil(t, tasks[1].Retry)
+
+	timeout, err := tasks[0].GetTimeout()
+	require.Nil(t, err)
+	require.Equal(t, 30*time.Minute, timeout)
+
+	timeout, err = tasks[1].GetTimeout()
+	require.Nil(t, err)
+	require.Zero(t, timeout)
+
+	require.Equal(t, "verification", tasks[1].GetParallelGroup())
+	require.Equal(t, "verification", tasks[2].GetParallelGroup())
+
+	require.Equal(t, "evaluation.result == warning", tasks[4].GetCondition())
+	require.Equal(t, "", tasks[5].GetCondition())
+	require.Equal(t, TaskAttributes{}, tasks[5])
+
+	require.Nil(t, ValidateShipyardTasks(shipyardWithTaskAttributes))
+}
+
+func TestUnmarshalShipyard_TaskAttributesAreNotProperties(t *testing.T) {
+	shipyard, err := UnmarshalShipyard(shipyardWithTaskAttributes)
+	require.Nil(t, err)
+
+	tasks := shipyard.Spec.Stages[0].Sequences[0].Tasks
+	require.Len(t, tasks, 6)
+
+	// the properties of the tasks are passed to the task executors as they are, even if they use the name of a task attribute
+	require.Equal(t, map[string]interface{}{"deploymentstrategy": "direct"}, tasks[0].Properties)
+	require.Equal(t, map[string]interface{}{"teststrategy": "functional", "timeout": "5m", "retry": true}, tasks[1].Properties)
+	require.Nil(t, tasks[2].Properties)
+	require.Nil(t, tasks[5].Properties)
+}
+
+func TestParseTaskCondition(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition string
+		want      *TaskCondition
+		wantErr   bool
+	}{
+		{
+			name:      "single comparison",
+			condition: "evaluation.result == warning",
+			want: &TaskCondition{
+				Alternatives: [][]TaskConditionClause{
+					{{Task: "evaluation", Property: "result", Operator: "==", Value: "warning"}},
+				},
+			},
+		},
+		{
+			name:      "combined comparisons",
+			condition: `test.status != 'errored' && evaluation.result == "pass" || security-scan.result == fail`,
+			want: &TaskCondition{
+				Alternatives: [][]TaskConditionClause{
+					{
+						{Task: "test", Property: "status", Operator: "!=", Value: "errored"},
+						{Task: "evaluation", Property: "result", Operator: "==", Value: "pass"},
+					},
+					{{Task: "security-scan", Property: "result", Operator: "==", Value: "fail"}},
+				},
+			},
+		},
+		{
+			name:      "empty condition",
+			condition: " ",
+			wantErr:   true,
+		},
+		{
+			name:      "no comparison",
+			condition: "evaluation.result",
+			wantErr:   true,
+		},
+		{
+			name:      "no task",
+			condition: "result == pass",
+			wantErr:   true,
+		},
+		{
+			name:      "unsupported property",
+			condition: "evaluation.score == 100",
+			wantErr:   true,
+		},
+		{
+			name:      "missing value",
+			condition: "evaluation.result == ",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTaskCondition(tt.condition)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTaskCondition_Evaluate(t *testing.T) {
+	condition, err := ParseTaskCondition("evaluation.result == warning || test.status == errored && evaluation.result != fail")
+	require.Nil(t, err)
+
+	values := map[string]string{}
+	getValue := func(task, property string) string {
+		return values[task+"."+property]
+	}
+
+	values["evaluation.result"] = "warning"
+	require.True(t, condition.Evaluate(getValue))
+
+	values["evaluation.result"] = "pass"
+	require.False(t, condition.Evaluate(getValue))
+
+	values["test.status"] = "errored"
+	require.True(t, condition.Evaluate(getValue))
+
+	values["evaluation.result"] = "fail"
+	require.False(t, condition.Evaluate(getValue))
+}
+
+func TestValidateShipyardTasks(t *testing.T) {
+	shipyardContent := `apiVersion: "spec.keptn.sh/0.2.2"
+kind: "Shipyard"
+spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "evaluation"
+            - name: "approval"
+              when: "evaluation.result warning"`
+
+	err := ValidateShipyardTasks(shipyardContent)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "task approval of sequence delivery in stage dev")
+}
+
+func TestValidateShipyardTasks_Timeout(t *testing.T) {
+	shipyardContent := `apiVersion: "spec.keptn.sh/0.2.2"
+kind: "Shipyard"
+spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "deployment"
+              timeout: "-5m"
+              properties:
+                timeout: "not a duration"`
+
+	err := ValidateShipyardTasks(shipyardContent)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "task deployment of sequence delivery in stage dev")
+}
+
+func TestValidateShipyardTasks_IgnoresProperties(t *testing.T) {
+	shipyardContent := `apiVersion: "spec.keptn.sh/0.2.2"
+kind: "Shipyard"
+spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "deployment"
+              properties:
+                timeout: "not a duration"
+                when: "always"`
+
+	require.Nil(t, ValidateShipyardTasks(shipyardContent))
+}
+
+func TestTaskRetryPolicy_GetBackoff(t *testing.T) {
+	policy := TaskRetryPolicy{MaxRetries: 3, Backoff: "10s"}
+	require.Equal(t, time.Duration(0), policy.GetBackoff(0))
+	require.Equal(t, 10*time.Second, policy.GetBackoff(1))
+	require.Equal(t, 20*time.Second, policy.GetBackoff(2))
+	require.Equal(t, 40*time.Second, policy.GetBackoff(3))
+
+	require.Equal(t, time.Duration(0), TaskRetryPolicy{MaxRetries: 3}.GetBackoff(1))
+
+	// the backoff is capped, and does not overflow for large numbers of retries or long durations
+	require.Equal(t, 10*time.Second<<MaxTaskRetries, policy.GetBackoff(64))
+	require.Equal(t, MaxTaskRetryBackoff, TaskRetryPolicy{MaxRetries: 3, Backoff: "1h"}.GetBackoff(1000))
+	require.Equal(t, MaxTaskRetryBackoff, TaskRetryPolicy{MaxRetries: 3, Backoff: "2000000h"}.GetBackoff(2))
+}
+
+func TestTaskRetryPolicy_ShouldRetryOn(t *testing.T) {
+	require.True(t, TaskRetryPolicy{}.ShouldRetryOn(RetryOnErrored))
+	require.False(t, TaskRetryPolicy{}.ShouldRetryOn(RetryOnFailed))
+
+	policy := TaskRetryPolicy{RetryOn: []string{RetryOnFailed}}
+	require.False(t, policy.ShouldRetryOn(RetryOnErrored))
+	require.True(t, policy.ShouldRetryOn(RetryOnFailed))
+}
+
+func TestTaskRetryPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  TaskRetryPolicy
+		wantErr bool
+	}{
+		{
+			name:   "valid policy",
+			policy: TaskRetryPolicy{MaxRetries: 2, Backoff: "1m", RetryOn: []string{RetryOnErrored, RetryOnFailed}},
+		},
+		{
+			name:    "negative maxRetries",
+			policy:  TaskRetryPolicy{MaxRetries: -1},
+			wantErr: true,
+		},
+		{
+			name:    "too many maxRetries",
+			policy:  TaskRetryPolicy{MaxRetries: MaxTaskRetries + 1},
+			wantErr: true,
+		},
+		{
+			name:    "invalid backoff",
+			policy:  TaskRetryPolicy{MaxRetries: 1, Backoff: "soon"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid retryOn",
+			policy:  TaskRetryPolicy{MaxRetries: 1, RetryOn: []string{"warning"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if tt.wantErr {
+				require.NotNil(t, err)
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+// ValidateShipyardTriggers checks whether the 'triggeredOn' events of all sequences within the shipyard refer to sequences that exist.
+// A valid reference has the format '<stage>.<sequence>.finished'
+func ValidateShipyardTriggers(shipyard *keptnv2.Shipyard) error {
+	for _, stage := range shipyard.Spec.Stages {
+		for _, sequence := range stage.Sequences {
+			for _, trigger := range sequence.TriggeredOn {
+				if err := validateTrigger(shipyard, trigger.Event); err != nil {
+					return fmt.Errorf("sequence %s in stage %s: %w", sequence.Name, stage.Name, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func validateTrigger(shipyard *keptnv2.Shipyard, event string) error {
+	parts := strings.Split(event, ".")
+	if len(parts) != 3 || parts[2] != string(FinishedEvent) {
+		return fmt.Errorf("invalid trigger '%s', expected the format '<stage>.<sequence>.finished'", event)
+	}
+	if !hasSequence(shipyard, parts[0], parts[1]) {
+		return fmt.Errorf("trigger '%s' refers to sequence %s in stage %s, which does not exist", event, parts[1], parts[0])
+	}
+	return nil
+}
+
+func hasSequence(shipyard *keptnv2.Shipyard, stageName, sequenceName string) bool {
+	for _, stage := range shipyard.Spec.Stages {
+		if stage.Name != stageName {
+			continue
+		}
+		for _, sequence := range stage.Sequences {
+			if sequence.Name == sequenceName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetShipyardSequenceCycle returns the sequences that trigger each other in a cycle, in the format '<stage>.<sequence>', e.g. [dev.delivery, production.delivery, dev.delivery].
+// If the sequences of the shipyard do not contain a cycle, nil is returned
+func GetShipyardSequenceCycle(shipyard *keptnv2.Shipyard) []string {
+	// triggers maps each sequence to the sequences that are triggered once it is finished
+	triggers := map[string][]string{}
+	sequences := []string{}
+	for _, stage := range shipyard.Spec.Stages {
+		for _, sequence := range stage.Sequences {
+			sequenceKey := stage.Name + "." + sequence.Name
+			sequences = append(sequences, sequenceKey)
+			for _, trigger := range sequence.TriggeredOn {
+				triggeringSequence := strings.TrimSuffix(trigger.Event, "."+string(FinishedEvent))
+				triggers[triggeringSequence] = append(triggers[triggeringSequence], sequenceKey)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+	state := map[string]int{}
+	path := []string{}
+
+	var visit func(sequence string) []string
+	visit = func(sequence string) []string {
+		state[sequence] = inProgress
+		path = append(path, sequence)
+		for _, next := range triggers[sequence] {
+			switch state[next] {
+			case inProgress:
+				for index := range path {
+					if path[index] == next {
+						return append(append([]string{}, path[index:]...), next)
+					}
+				}
+			case unvisited:
+				if cycle := visit(next); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[sequence] = done
+		return nil
+	}
+
+	for _, sequence := range sequences {
+		if state[sequence] == unvisited {
+			if cycle := visit(sequence); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// MatchesEventSubject determines whether the given event type matches the subject of a subscription.
+// The subject may contain the wildcards '*', which matches exactly one token, and '>', which matches one or more tokens at the end of the event type
+func MatchesEventSubject(eventType, subject string) bool {
+	eventTokens := strings.Split(eventType, ".")
+	subjectTokens := strings.Split(subject, ".")
+	for index, subjectToken := range subjectTokens {
+		if subjectToken == ">" {
+			return index == len(subjectTokens)-1 && len(eventTokens) > index
+		}
+		if index >= len(eventTokens) || (subjectToken != "*" && subjectToken != eventTokens[index]) {
+			return false
+		}
+	}
+	return len(eventTokens) == len(subjectTokens)
+}
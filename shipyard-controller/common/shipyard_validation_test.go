@@ -0,0 +1,98 @@
+package common
+
+import (
+	"testing"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/require"
+)
+
+func newTriggeredSequence(name, triggeredOn string) keptnv2.Sequence {
+	sequence := keptnv2.Sequence{Name: name, Tasks: []keptnv2.Task{{Name: "deployment"}}}
+	if triggeredOn != "" {
+		sequence.TriggeredOn = []keptnv2.Trigger{{Event: triggeredOn}}
+	}
+	return sequence
+}
+
+func TestValidateShipyardTriggers(t *testing.T) {
+	tests := []struct {
+		name        string
+		triggeredOn string
+		wantErr     string
+	}{
+		{name: "valid trigger", triggeredOn: "dev.delivery.finished"},
+		{name: "unknown stage", triggeredOn: "staging.delivery.finished", wantErr: "refers to sequence delivery in stage staging, which does not exist"},
+		{name: "unknown sequence", triggeredOn: "dev.rollback.finished", wantErr: "refers to sequence rollback in stage dev, which does not exist"},
+		{name: "invalid format", triggeredOn: "dev.delivery", wantErr: "invalid trigger 'dev.delivery'"},
+		{name: "not a finished event", triggeredOn: "dev.delivery.started", wantErr: "invalid trigger 'dev.delivery.started'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shipyard := &keptnv2.Shipyard{
+				Spec: keptnv2.ShipyardSpec{
+					Stages: []keptnv2.Stage{
+						{Name: "dev", Sequences: []keptnv2.Sequence{newTriggeredSequence("delivery", "")}},
+						{Name: "production", Sequences: []keptnv2.Sequence{newTriggeredSequence("delivery", tt.triggeredOn)}},
+					},
+				},
+			}
+			err := ValidateShipyardTriggers(shipyard)
+			if tt.wantErr == "" {
+				require.Nil(t, err)
+				return
+			}
+			require.NotNil(t, err)
+			require.Contains(t, err.Error(), "sequence delivery in stage production")
+			require.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestGetShipyardSequenceCycle(t *testing.T) {
+	shipyard := &keptnv2.Shipyard{
+		Spec: keptnv2.ShipyardSpec{
+			Stages: []keptnv2.Stage{
+				{Name: "dev", Sequences: []keptnv2.Sequence{newTriggeredSequence("delivery", "")}},
+				{Name: "staging", Sequences: []keptnv2.Sequence{newTriggeredSequence("delivery", "dev.delivery.finished")}},
+				{Name: "production", Sequences: []keptnv2.Sequence{
+					newTriggeredSequence("delivery", "staging.delivery.finished"),
+					newTriggeredSequence("rollback", "production.delivery.finished"),
+				}},
+			},
+		},
+	}
+	require.Nil(t, GetShipyardSequenceCycle(shipyard))
+
+	// let the dev stage be triggered by the rollback in production
+	shipyard.Spec.Stages[0].Sequences[0].TriggeredOn = []keptnv2.Trigger{{Event: "production.rollback.finished"}}
+	require.Equal(t, []string{
+		"dev.delivery",
+		"staging.delivery",
+		"production.delivery",
+		"production.rollback",
+		"dev.delivery",
+	}, GetShipyardSequenceCycle(shipyard))
+}
+
+func TestMatchesEventSubject(t *testing.T) {
+	tests := []struct {
+		subject string
+		want    bool
+	}{
+		{subject: "sh.keptn.event.deployment.triggered", want: true},
+		{subject: "sh.keptn.event.deployment.finished", want: false},
+		{subject: "sh.keptn.event.*.triggered", want: true},
+		{subject: "sh.keptn.event.>", want: true},
+		{subject: "sh.keptn.>", want: true},
+		{subject: "sh.keptn.event.deployment.triggered.>", want: false},
+		{subject: "sh.keptn.event.deployment", want: false},
+		{subject: "sh.keptn.event.*", want: false},
+		{subject: "sh.>.deployment.triggered", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.subject, func(t *testing.T) {
+			require.Equal(t, tt.want, MatchesEventSubject("sh.keptn.event.deployment.triggered", tt.subject))
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ParseTimeout parses a timeout defined in the shipyard, e.g. '30m'. Only positive durations are valid timeouts
+func ParseTimeout(timeout string) (time.Duration, error) {
+	duration, err := time.ParseDuration(strings.TrimSpace(timeout))
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid timeout '%s', expected a positive duration like '30m'", timeout)
+	}
+	return duration, nil
+}
+
+// shipyardSequenceTimeouts is used to read the timeouts of the sequences within a shipyard, since they are not contained in keptnv2.Sequence
+type shipyardSequenceTimeouts struct {
+	Spec struct {
+		Stages []struct {
+			Name      string `yaml:"name"`
+			Sequences []struct {
+				Name    string `yaml:"name"`
+				Timeout string `yaml:"timeout"`
+			} `yaml:"sequences"`
+		} `yaml:"stages"`
+	} `yaml:"spec"`
+}
+
+// GetShipyardSequenceTimeouts returns the timeouts of the sequences defined within the given shipyard content, grouped by the names of their stages and sequences
+func GetShipyardSequenceTimeouts(shipyardContent string) (map[string]map[string]string, error) {
+	shipyard := &shipyardSequenceTimeouts{}
+	if err := yaml.Unmarshal([]byte(shipyardContent), shipyard); err != nil {
+		return nil, fmt.Errorf("could not decode sequence timeouts of shipyard: %w", err)
+	}
+
+	timeouts := map[string]map[string]string{}
+	for _, stage := range shipyard.Spec.Stages {
+		for _, sequence := range stage.Sequences {
+			if sequence.Timeout == "" {
+				continue
+			}
+			if timeouts[stage.Name] == nil {
+				timeouts[stage.Name] = map[string]string{}
+			}
+			timeouts[stage.Name][sequence.Name] = sequence.Timeout
+		}
+	}
+	return timeouts, nil
+}
+
+// ValidateShipyardSequenceTimeouts checks whether the timeouts of the sequences defined within the given shipyard content are valid
+func ValidateShipyardSequenceTimeouts(shipyardContent string) error {
+	timeouts, err := GetShipyardSequenceTimeouts(shipyardContent)
+	if err != nil {
+		return err
+	}
+	for stageName, sequenceTimeouts := range timeouts {
+		for sequenceName, timeout := range sequenceTimeouts {
+			if _, err := ParseTimeout(timeout); err != nil {
+				return fmt.Errorf("sequence %s in stage %s: %w", sequenceName, stageName, err)
+			}
+		}
+	}
+	return nil
+}
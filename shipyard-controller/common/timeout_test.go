@@ -0,0 +1,80 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const shipyardWithSequenceTimeouts = `apiVersion: "spec.keptn.sh/0.2.2"
+kind: "Shipyard"
+metadata:
+  name: "shipyard-sockshop"
+spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          timeout: "1h"
+          tasks:
+            - name: "deployment"
+        - name: "evaluation"
+          tasks:
+            - name: "evaluation"
+    - name: "production"
+      sequences:
+        - name: "delivery"
+          timeout: "2h30m"
+          tasks:
+            - name: "deployment"`
+
+func TestGetShipyardSequenceTimeouts(t *testing.T) {
+	timeouts, err := GetShipyardSequenceTimeouts(shipyardWithSequenceTimeouts)
+	require.Nil(t, err)
+	require.Equal(t, map[string]map[string]string{
+		"dev":        {"delivery": "1h"},
+		"production": {"delivery": "2h30m"},
+	}, timeouts)
+
+	require.Nil(t, ValidateShipyardSequenceTimeouts(shipyardWithSequenceTimeouts))
+}
+
+func TestValidateShipyardSequenceTimeouts(t *testing.T) {
+	err := ValidateShipyardSequenceTimeouts(`apiVersion: "spec.keptn.sh/0.2.2"
+kind: "Shipyard"
+spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          timeout: "one hour"`)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "sequence delivery in stage dev")
+}
+
+func TestParseTimeout(t *testing.T) {
+	tests := []struct {
+		timeout string
+		want    time.Duration
+		wantErr bool
+	}{
+		{timeout: "30m", want: 30 * time.Minute},
+		{timeout: "1h30m", want: 90 * time.Minute},
+		{timeout: "0s", wantErr: true},
+		{timeout: "-1h", wantErr: true},
+		{timeout: "30", wantErr: true},
+		{timeout: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.timeout, func(t *testing.T) {
+			got, err := ParseTimeout(tt.timeout)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 // EnvConfig holds the parsed environment variables
 // TODO: add other environment variables supported by Shippy
 type EnvConfig struct {
@@ -19,4 +21,52 @@ type EnvConfig struct {
 	// PreStopHookTime is the duration of the preStop hook. The duration defined via this value will be the duration between signaling the
 	// termination of the shipyard-controller's pod and the reception of the SIGTERM signal
 	PreStopHookTime int `envconfig:"PRE_STOP_HOOK_TIME" default:"5"`
+	// LockTTL is the duration of the lease of a lock shared between the replicas of the shipyard-controller.
+	// Locks are renewed periodically while being held, so this value determines how fast a lock held by a crashed replica can be taken over by another one
+	LockTTL time.Duration `envconfig:"LOCK_TTL" default:"30s"`
+	// DispatchMode determines how queued sequences and events are dispatched if multiple replicas are running.
+	// "leader" uses a leader election to make sure that only one replica dispatches queued items.
+	// "partitioned" lets each replica claim and dispatch a portion of the queued items
+	DispatchMode string `envconfig:"DISPATCH_MODE" default:"leader"`
+	// QueueClaimTTL is the duration after which the claims of a replica on queued items expire if the replica stops renewing them.
+	// Only used if DispatchMode is set to "partitioned"
+	QueueClaimTTL time.Duration `envconfig:"QUEUE_CLAIM_TTL" default:"1m"`
+	// QueueClaimBatchSize is the maximum number of queued items claimed by a replica in one iteration of a dispatcher.
+	// Only used if DispatchMode is set to "partitioned"
+	QueueClaimBatchSize int `envconfig:"QUEUE_CLAIM_BATCH_SIZE" default:"20"`
+	// ScheduleSyncInterval is the interval in which the scheduler checks for schedules whose sequences need to be triggered
+	ScheduleSyncInterval time.Duration `envconfig:"SCHEDULE_SYNC_INTERVAL" default:"30s"`
+	// MaxSequencesPerProject is the maximum number of sequences that may be running at the same time in each project. 0 means that the number is not limited
+	MaxSequencesPerProject int `envconfig:"MAX_SEQUENCES_PER_PROJECT" default:"0"`
+	// MaxSequencesPerStage is the maximum number of sequences that may be running at the same time in each stage of a project. 0 means that the number is not limited
+	MaxSequencesPerStage int `envconfig:"MAX_SEQUENCES_PER_STAGE" default:"0"`
+	// MaxSequencesPerName contains the maximum number of sequences with a given name that may be running at the same time across all projects, e.g. "delivery:5,evaluation:20"
+	MaxSequencesPerName map[string]int `envconfig:"MAX_SEQUENCES_PER_NAME" default:""`
+	// PriorityClasses contains the names of the available priority classes and their priority, e.g. "high:100,low:-100".
+	// Queued sequences with a higher priority are dispatched before sequences with a lower one
+	PriorityClasses map[string]int `envconfig:"PRIORITY_CLASSES" default:""`
+	// SequencePriorityClasses maps sequence names to the name of the priority class they are assigned to by default, e.g. "remediation:high".
+	// The priority class of a single sequence can be set via the "keptn.sh/priority-class" label of its triggering event
+	SequencePriorityClasses map[string]string `envconfig:"SEQUENCE_PRIORITY_CLASSES" default:""`
+	// SecretServiceURL is the URL of the secret-service, which is used to include the names of the secrets in the export of a project
+	SecretServiceURL string `envconfig:"SECRET_SERVICE" default:"http://secret-service:8080"`
+	// NotificationDispatchInterval is the interval in which pending notifications are sent via their channels
+	NotificationDispatchInterval time.Duration `envconfig:"NOTIFICATION_DISPATCH_INTERVAL" default:"10s"`
+	// NotificationMaxAttempts is the maximum number of attempts to deliver a notification before its delivery is marked as failed
+	NotificationMaxAttempts int `envconfig:"NOTIFICATION_MAX_ATTEMPTS" default:"5"`
+	// NotificationRetryBackoff is the duration between the first and the second attempt to deliver a notification. It is doubled with each further attempt
+	NotificationRetryBackoff time.Duration `envconfig:"NOTIFICATION_RETRY_BACKOFF" default:"30s"`
+	// NotificationDeliveryTTL is the duration after which the entries of the notification delivery log are removed
+	NotificationDeliveryTTL time.Duration `envconfig:"NOTIFICATION_DELIVERY_TTL" default:"168h"`
+	// AuditRecordTTL is the duration after which audit records are removed
+	AuditRecordTTL time.Duration `envconfig:"AUDIT_RECORD_TTL" default:"2160h"`
+	// OTLPEndpoint is the URL of the OpenTelemetry collector the spans of the shipyard-controller are exported to, e.g. "http://otel-collector:4318".
+	// If empty, no spans are exported. Further settings of the exporter can be passed via the other OTEL_EXPORTER_OTLP_* environment variables
+	OTLPEndpoint string `envconfig:"OTEL_EXPORTER_OTLP_ENDPOINT" default:""`
+	// InternalAPIToken is the token that Keptn services within the cluster send in the x-token header if they call the shipyard-controller directly,
+	// i.e. without the roles set by the API gateway. Calls without roles are denied if they do not send this token, or if no token is configured
+	InternalAPIToken string `envconfig:"INTERNAL_API_TOKEN" default:""`
 }
+
+// DispatchModePartitioned is the value of DispatchMode that enables the active-active dispatching of queued items
+const DispatchModePartitioned = "partitioned"
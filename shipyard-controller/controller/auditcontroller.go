@@ -0,0 +1,19 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+)
+
+type AuditController struct {
+	AuditHandler handler.IAuditHandler
+}
+
+func NewAuditController(auditHandler handler.IAuditHandler) Controller {
+	return &AuditController{AuditHandler: auditHandler}
+}
+
+func (controller AuditController) Inject(apiGroup *gin.RouterGroup) {
+	apiGroup.POST("/audit", controller.AuditHandler.CreateAuditRecord)
+	apiGroup.GET("/audit", controller.AuditHandler.GetAuditRecords)
+}
@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+)
+
+type NotificationController struct {
+	NotificationHandler handler.INotificationHandler
+}
+
+func NewNotificationController(notificationHandler handler.INotificationHandler) Controller {
+	return &NotificationController{NotificationHandler: notificationHandler}
+}
+
+func (controller NotificationController) Inject(apiGroup *gin.RouterGroup) {
+	apiGroup.POST("/project/:project/notification/channel", controller.NotificationHandler.CreateNotificationChannel)
+	apiGroup.GET("/project/:project/notification/channel", controller.NotificationHandler.GetNotificationChannels)
+	apiGroup.GET("/project/:project/notification/channel/:channelId", controller.NotificationHandler.GetNotificationChannel)
+	apiGroup.PUT("/project/:project/notification/channel/:channelId", controller.NotificationHandler.UpdateNotificationChannel)
+	apiGroup.DELETE("/project/:project/notification/channel/:channelId", controller.NotificationHandler.DeleteNotificationChannel)
+	apiGroup.GET("/project/:project/notification/delivery", controller.NotificationHandler.GetNotificationDeliveries)
+}
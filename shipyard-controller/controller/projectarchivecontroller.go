@@ -0,0 +1,19 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+)
+
+type ProjectArchiveController struct {
+	ProjectArchiveHandler handler.IProjectArchiveHandler
+}
+
+func NewProjectArchiveController(projectArchiveHandler handler.IProjectArchiveHandler) Controller {
+	return &ProjectArchiveController{ProjectArchiveHandler: projectArchiveHandler}
+}
+
+func (controller ProjectArchiveController) Inject(apiGroup *gin.RouterGroup) {
+	apiGroup.GET("/project/:project/export", controller.ProjectArchiveHandler.ExportProject)
+	apiGroup.POST("/project/:project/import", controller.ProjectArchiveHandler.ImportProject)
+}
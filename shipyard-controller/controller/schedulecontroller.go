@@ -0,0 +1,22 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+)
+
+type ScheduleController struct {
+	ScheduleHandler handler.IScheduleHandler
+}
+
+func NewScheduleController(scheduleHandler handler.IScheduleHandler) Controller {
+	return &ScheduleController{ScheduleHandler: scheduleHandler}
+}
+
+func (controller ScheduleController) Inject(apiGroup *gin.RouterGroup) {
+	apiGroup.POST("/schedule", controller.ScheduleHandler.CreateSchedule)
+	apiGroup.GET("/schedule", controller.ScheduleHandler.GetSchedules)
+	apiGroup.GET("/schedule/:scheduleId", controller.ScheduleHandler.GetSchedule)
+	apiGroup.PUT("/schedule/:scheduleId", controller.ScheduleHandler.UpdateSchedule)
+	apiGroup.DELETE("/schedule/:scheduleId", controller.ScheduleHandler.DeleteSchedule)
+}
@@ -0,0 +1,18 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+)
+
+type SequenceExecutionController struct {
+	SequenceExecutionHandler handler.ISequenceExecutionHandler
+}
+
+func NewSequenceExecutionController(sequenceExecutionHandler handler.ISequenceExecutionHandler) *SequenceExecutionController {
+	return &SequenceExecutionController{SequenceExecutionHandler: sequenceExecutionHandler}
+}
+
+func (controller SequenceExecutionController) Inject(apiGroup *gin.RouterGroup) {
+	apiGroup.GET("/sequence-execution", controller.SequenceExecutionHandler.GetSequenceExecutions)
+}
@@ -17,5 +17,7 @@ func (controller ServiceController) Inject(apiGroup *gin.RouterGroup) {
 	apiGroup.GET("/project/:project/stage/:stage/service", controller.ServiceHandler.GetServices)
 	apiGroup.GET("/project/:project/stage/:stage/service/:service", controller.ServiceHandler.GetService)
 	apiGroup.POST("/project/:project/service", controller.ServiceHandler.CreateService)
+	apiGroup.GET("/project/:project/service/:service", controller.ServiceHandler.GetServiceMetadata)
+	apiGroup.PUT("/project/:project/service/:service", controller.ServiceHandler.UpdateService)
 	apiGroup.DELETE("/project/:project/service/:service", controller.ServiceHandler.DeleteService)
 }
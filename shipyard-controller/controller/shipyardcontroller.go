@@ -0,0 +1,19 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+)
+
+type ShipyardController struct {
+	ShipyardHandler handler.IShipyardHandler
+}
+
+func NewShipyardController(shipyardHandler handler.IShipyardHandler) Controller {
+	return &ShipyardController{ShipyardHandler: shipyardHandler}
+}
+
+func (controller ShipyardController) Inject(apiGroup *gin.RouterGroup) {
+	apiGroup.POST("/project/:project/shipyard/validate", controller.ShipyardHandler.ValidateShipyard)
+	apiGroup.POST("/project/:project/sequence/plan", controller.ShipyardHandler.PlanSequence)
+}
@@ -0,0 +1,22 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+)
+
+type TokenController struct {
+	TokenHandler handler.IAPITokenHandler
+}
+
+func NewTokenController(tokenHandler handler.IAPITokenHandler) Controller {
+	return &TokenController{TokenHandler: tokenHandler}
+}
+
+func (controller TokenController) Inject(apiGroup *gin.RouterGroup) {
+	apiGroup.POST("/token", controller.TokenHandler.CreateToken)
+	apiGroup.GET("/token", controller.TokenHandler.GetTokens)
+	apiGroup.POST("/token/validate", controller.TokenHandler.ValidateToken)
+	apiGroup.POST("/token/:name/rotate", controller.TokenHandler.RotateToken)
+	apiGroup.DELETE("/token/:name", controller.TokenHandler.DeleteToken)
+}
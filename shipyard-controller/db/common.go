@@ -14,3 +14,12 @@ func closeCursor(ctx context.Context, cur *mongo.Cursor) {
 		log.Errorf("Could not close cursor: %v", err)
 	}
 }
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
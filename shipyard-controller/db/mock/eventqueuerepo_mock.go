@@ -15,6 +15,9 @@ import (
 //
 // 		// make and configure a mocked db.EventQueueRepo
 // 		mockedEventQueueRepo := &EventQueueRepoMock{
+// 			ClaimQueuedEventsFunc: func(timestamp time.Time, owner string, ttl time.Duration, limit int) ([]models.QueueItem, error) {
+// 				panic("mock out the ClaimQueuedEvents method")
+// 			},
 // 			CreateOrUpdateEventQueueStateFunc: func(state models.EventQueueSequenceState) error {
 // 				panic("mock out the CreateOrUpdateEventQueueState method")
 // 			},
@@ -42,6 +45,12 @@ import (
 // 			QueueEventFunc: func(item models.QueueItem) error {
 // 				panic("mock out the QueueEvent method")
 // 			},
+// 			ReleaseClaimedEventsFunc: func(owner string) error {
+// 				panic("mock out the ReleaseClaimedEvents method")
+// 			},
+// 			RenewEventClaimsFunc: func(owner string, ttl time.Duration) error {
+// 				panic("mock out the RenewEventClaims method")
+// 			},
 // 		}
 //
 // 		// use mockedEventQueueRepo in code that requires db.EventQueueRepo
@@ -49,6 +58,9 @@ import (
 //
 // 	}
 type EventQueueRepoMock struct {
+	// ClaimQueuedEventsFunc mocks the ClaimQueuedEvents method.
+	ClaimQueuedEventsFunc func(timestamp time.Time, owner string, ttl time.Duration, limit int) ([]models.QueueItem, error)
+
 	// CreateOrUpdateEventQueueStateFunc mocks the CreateOrUpdateEventQueueState method.
 	CreateOrUpdateEventQueueStateFunc func(state models.EventQueueSequenceState) error
 
@@ -76,8 +88,25 @@ type EventQueueRepoMock struct {
 	// QueueEventFunc mocks the QueueEvent method.
 	QueueEventFunc func(item models.QueueItem) error
 
+	// ReleaseClaimedEventsFunc mocks the ReleaseClaimedEvents method.
+	ReleaseClaimedEventsFunc func(owner string) error
+
+	// RenewEventClaimsFunc mocks the RenewEventClaims method.
+	RenewEventClaimsFunc func(owner string, ttl time.Duration) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// ClaimQueuedEvents holds details about calls to the ClaimQueuedEvents method.
+		ClaimQueuedEvents []struct {
+			// Timestamp is the timestamp argument value.
+			Timestamp time.Time
+			// Owner is the owner argument value.
+			Owner string
+			// Ttl is the ttl argument value.
+			Ttl time.Duration
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// CreateOrUpdateEventQueueState holds details about calls to the CreateOrUpdateEventQueueState method.
 		CreateOrUpdateEventQueueState []struct {
 			// State is the state argument value.
@@ -123,7 +152,20 @@ type EventQueueRepoMock struct {
 			// Item is the item argument value.
 			Item models.QueueItem
 		}
+		// ReleaseClaimedEvents holds details about calls to the ReleaseClaimedEvents method.
+		ReleaseClaimedEvents []struct {
+			// Owner is the owner argument value.
+			Owner string
+		}
+		// RenewEventClaims holds details about calls to the RenewEventClaims method.
+		RenewEventClaims []struct {
+			// Owner is the owner argument value.
+			Owner string
+			// Ttl is the ttl argument value.
+			Ttl time.Duration
+		}
 	}
+	lockClaimQueuedEvents             sync.RWMutex
 	lockCreateOrUpdateEventQueueState sync.RWMutex
 	lockDeleteEventQueueStates        sync.RWMutex
 	lockDeleteQueuedEvent             sync.RWMutex
@@ -133,6 +175,52 @@ type EventQueueRepoMock struct {
 	lockIsEventInQueue                sync.RWMutex
 	lockIsSequenceOfEventPaused       sync.RWMutex
 	lockQueueEvent                    sync.RWMutex
+	lockReleaseClaimedEvents          sync.RWMutex
+	lockRenewEventClaims              sync.RWMutex
+}
+
+// ClaimQueuedEvents calls ClaimQueuedEventsFunc.
+func (mock *EventQueueRepoMock) ClaimQueuedEvents(timestamp time.Time, owner string, ttl time.Duration, limit int) ([]models.QueueItem, error) {
+	if mock.ClaimQueuedEventsFunc == nil {
+		panic("EventQueueRepoMock.ClaimQueuedEventsFunc: method is nil but EventQueueRepo.ClaimQueuedEvents was just called")
+	}
+	callInfo := struct {
+		Timestamp time.Time
+		Owner     string
+		Ttl       time.Duration
+		Limit     int
+	}{
+		Timestamp: timestamp,
+		Owner:     owner,
+		Ttl:       ttl,
+		Limit:     limit,
+	}
+	mock.lockClaimQueuedEvents.Lock()
+	mock.calls.ClaimQueuedEvents = append(mock.calls.ClaimQueuedEvents, callInfo)
+	mock.lockClaimQueuedEvents.Unlock()
+	return mock.ClaimQueuedEventsFunc(timestamp, owner, ttl, limit)
+}
+
+// ClaimQueuedEventsCalls gets all the calls that were made to ClaimQueuedEvents.
+// Check the length with:
+//
+// 	len(mockedEventQueueRepo.ClaimQueuedEventsCalls())
+func (mock *EventQueueRepoMock) ClaimQueuedEventsCalls() []struct {
+	Timestamp time.Time
+	Owner     string
+	Ttl       time.Duration
+	Limit     int
+} {
+	var calls []struct {
+		Timestamp time.Time
+		Owner     string
+		Ttl       time.Duration
+		Limit     int
+	}
+	mock.lockClaimQueuedEvents.RLock()
+	calls = mock.calls.ClaimQueuedEvents
+	mock.lockClaimQueuedEvents.RUnlock()
+	return calls
 }
 
 // CreateOrUpdateEventQueueState calls CreateOrUpdateEventQueueStateFunc.
@@ -153,7 +241,8 @@ func (mock *EventQueueRepoMock) CreateOrUpdateEventQueueState(state models.Event
 
 // CreateOrUpdateEventQueueStateCalls gets all the calls that were made to CreateOrUpdateEventQueueState.
 // Check the length with:
-//     len(mockedEventQueueRepo.CreateOrUpdateEventQueueStateCalls())
+//
+// 	len(mockedEventQueueRepo.CreateOrUpdateEventQueueStateCalls())
 func (mock *EventQueueRepoMock) CreateOrUpdateEventQueueStateCalls() []struct {
 	State models.EventQueueSequenceState
 } {
@@ -184,7 +273,8 @@ func (mock *EventQueueRepoMock) DeleteEventQueueStates(state models.EventQueueSe
 
 // DeleteEventQueueStatesCalls gets all the calls that were made to DeleteEventQueueStates.
 // Check the length with:
-//     len(mockedEventQueueRepo.DeleteEventQueueStatesCalls())
+//
+// 	len(mockedEventQueueRepo.DeleteEventQueueStatesCalls())
 func (mock *EventQueueRepoMock) DeleteEventQueueStatesCalls() []struct {
 	State models.EventQueueSequenceState
 } {
@@ -215,7 +305,8 @@ func (mock *EventQueueRepoMock) DeleteQueuedEvent(eventID string) error {
 
 // DeleteQueuedEventCalls gets all the calls that were made to DeleteQueuedEvent.
 // Check the length with:
-//     len(mockedEventQueueRepo.DeleteQueuedEventCalls())
+//
+// 	len(mockedEventQueueRepo.DeleteQueuedEventCalls())
 func (mock *EventQueueRepoMock) DeleteQueuedEventCalls() []struct {
 	EventID string
 } {
@@ -246,7 +337,8 @@ func (mock *EventQueueRepoMock) DeleteQueuedEvents(scope models.EventScope) erro
 
 // DeleteQueuedEventsCalls gets all the calls that were made to DeleteQueuedEvents.
 // Check the length with:
-//     len(mockedEventQueueRepo.DeleteQueuedEventsCalls())
+//
+// 	len(mockedEventQueueRepo.DeleteQueuedEventsCalls())
 func (mock *EventQueueRepoMock) DeleteQueuedEventsCalls() []struct {
 	Scope models.EventScope
 } {
@@ -277,7 +369,8 @@ func (mock *EventQueueRepoMock) GetEventQueueSequenceStates(filter models.EventQ
 
 // GetEventQueueSequenceStatesCalls gets all the calls that were made to GetEventQueueSequenceStates.
 // Check the length with:
-//     len(mockedEventQueueRepo.GetEventQueueSequenceStatesCalls())
+//
+// 	len(mockedEventQueueRepo.GetEventQueueSequenceStatesCalls())
 func (mock *EventQueueRepoMock) GetEventQueueSequenceStatesCalls() []struct {
 	Filter models.EventQueueSequenceState
 } {
@@ -308,7 +401,8 @@ func (mock *EventQueueRepoMock) GetQueuedEvents(timestamp time.Time) ([]models.Q
 
 // GetQueuedEventsCalls gets all the calls that were made to GetQueuedEvents.
 // Check the length with:
-//     len(mockedEventQueueRepo.GetQueuedEventsCalls())
+//
+// 	len(mockedEventQueueRepo.GetQueuedEventsCalls())
 func (mock *EventQueueRepoMock) GetQueuedEventsCalls() []struct {
 	Timestamp time.Time
 } {
@@ -339,7 +433,8 @@ func (mock *EventQueueRepoMock) IsEventInQueue(eventID string) (bool, error) {
 
 // IsEventInQueueCalls gets all the calls that were made to IsEventInQueue.
 // Check the length with:
-//     len(mockedEventQueueRepo.IsEventInQueueCalls())
+//
+// 	len(mockedEventQueueRepo.IsEventInQueueCalls())
 func (mock *EventQueueRepoMock) IsEventInQueueCalls() []struct {
 	EventID string
 } {
@@ -370,7 +465,8 @@ func (mock *EventQueueRepoMock) IsSequenceOfEventPaused(eventScope models.EventS
 
 // IsSequenceOfEventPausedCalls gets all the calls that were made to IsSequenceOfEventPaused.
 // Check the length with:
-//     len(mockedEventQueueRepo.IsSequenceOfEventPausedCalls())
+//
+// 	len(mockedEventQueueRepo.IsSequenceOfEventPausedCalls())
 func (mock *EventQueueRepoMock) IsSequenceOfEventPausedCalls() []struct {
 	EventScope models.EventScope
 } {
@@ -401,7 +497,8 @@ func (mock *EventQueueRepoMock) QueueEvent(item models.QueueItem) error {
 
 // QueueEventCalls gets all the calls that were made to QueueEvent.
 // Check the length with:
-//     len(mockedEventQueueRepo.QueueEventCalls())
+//
+// 	len(mockedEventQueueRepo.QueueEventCalls())
 func (mock *EventQueueRepoMock) QueueEventCalls() []struct {
 	Item models.QueueItem
 } {
@@ -413,3 +510,71 @@ func (mock *EventQueueRepoMock) QueueEventCalls() []struct {
 	mock.lockQueueEvent.RUnlock()
 	return calls
 }
+
+// ReleaseClaimedEvents calls ReleaseClaimedEventsFunc.
+func (mock *EventQueueRepoMock) ReleaseClaimedEvents(owner string) error {
+	if mock.ReleaseClaimedEventsFunc == nil {
+		panic("EventQueueRepoMock.ReleaseClaimedEventsFunc: method is nil but EventQueueRepo.ReleaseClaimedEvents was just called")
+	}
+	callInfo := struct {
+		Owner string
+	}{
+		Owner: owner,
+	}
+	mock.lockReleaseClaimedEvents.Lock()
+	mock.calls.ReleaseClaimedEvents = append(mock.calls.ReleaseClaimedEvents, callInfo)
+	mock.lockReleaseClaimedEvents.Unlock()
+	return mock.ReleaseClaimedEventsFunc(owner)
+}
+
+// ReleaseClaimedEventsCalls gets all the calls that were made to ReleaseClaimedEvents.
+// Check the length with:
+//
+// 	len(mockedEventQueueRepo.ReleaseClaimedEventsCalls())
+func (mock *EventQueueRepoMock) ReleaseClaimedEventsCalls() []struct {
+	Owner string
+} {
+	var calls []struct {
+		Owner string
+	}
+	mock.lockReleaseClaimedEvents.RLock()
+	calls = mock.calls.ReleaseClaimedEvents
+	mock.lockReleaseClaimedEvents.RUnlock()
+	return calls
+}
+
+// RenewEventClaims calls RenewEventClaimsFunc.
+func (mock *EventQueueRepoMock) RenewEventClaims(owner string, ttl time.Duration) error {
+	if mock.RenewEventClaimsFunc == nil {
+		panic("EventQueueRepoMock.RenewEventClaimsFunc: method is nil but EventQueueRepo.RenewEventClaims was just called")
+	}
+	callInfo := struct {
+		Owner string
+		Ttl   time.Duration
+	}{
+		Owner: owner,
+		Ttl:   ttl,
+	}
+	mock.lockRenewEventClaims.Lock()
+	mock.calls.RenewEventClaims = append(mock.calls.RenewEventClaims, callInfo)
+	mock.lockRenewEventClaims.Unlock()
+	return mock.RenewEventClaimsFunc(owner, ttl)
+}
+
+// RenewEventClaimsCalls gets all the calls that were made to RenewEventClaims.
+// Check the length with:
+//
+// 	len(mockedEventQueueRepo.RenewEventClaimsCalls())
+func (mock *EventQueueRepoMock) RenewEventClaimsCalls() []struct {
+	Owner string
+	Ttl   time.Duration
+} {
+	var calls []struct {
+		Owner string
+		Ttl   time.Duration
+	}
+	mock.lockRenewEventClaims.RLock()
+	calls = mock.calls.RenewEventClaims
+	mock.lockRenewEventClaims.RUnlock()
+	return calls
+}
@@ -26,6 +26,9 @@ import (
 // 			GetByTriggeredIDFunc: func(project string, triggeredID string) (*models.SequenceExecution, error) {
 // 				panic("mock out the GetByTriggeredID method")
 // 			},
+// 			GetPaginatedFunc: func(filter models.SequenceExecutionFilter, nextPageKey int64, pageSize int64) ([]models.SequenceExecution, int64, error) {
+// 				panic("mock out the GetPaginated method")
+// 			},
 // 			IsContextPausedFunc: func(eventScope models.EventScope) bool {
 // 				panic("mock out the IsContextPaused method")
 // 			},
@@ -60,6 +63,9 @@ type SequenceExecutionRepoMock struct {
 	// GetByTriggeredIDFunc mocks the GetByTriggeredID method.
 	GetByTriggeredIDFunc func(project string, triggeredID string) (*models.SequenceExecution, error)
 
+	// GetPaginatedFunc mocks the GetPaginated method.
+	GetPaginatedFunc func(filter models.SequenceExecutionFilter, nextPageKey int64, pageSize int64) ([]models.SequenceExecution, int64, error)
+
 	// IsContextPausedFunc mocks the IsContextPaused method.
 	IsContextPausedFunc func(eventScope models.EventScope) bool
 
@@ -81,7 +87,7 @@ type SequenceExecutionRepoMock struct {
 		AppendTaskEvent []struct {
 			// TaskSequence is the taskSequence argument value.
 			TaskSequence models.SequenceExecution
-			//models.KeptnContextExtendedCEis the event argument value.
+			// Event is the event argument value.
 			Event models.TaskEvent
 		}
 		// Clear holds details about calls to the Clear method.
@@ -101,6 +107,15 @@ type SequenceExecutionRepoMock struct {
 			// TriggeredID is the triggeredID argument value.
 			TriggeredID string
 		}
+		// GetPaginated holds details about calls to the GetPaginated method.
+		GetPaginated []struct {
+			// Filter is the filter argument value.
+			Filter models.SequenceExecutionFilter
+			// NextPageKey is the nextPageKey argument value.
+			NextPageKey int64
+			// PageSize is the pageSize argument value.
+			PageSize int64
+		}
 		// IsContextPaused holds details about calls to the IsContextPaused method.
 		IsContextPaused []struct {
 			// EventScope is the eventScope argument value.
@@ -133,6 +148,7 @@ type SequenceExecutionRepoMock struct {
 	lockClear            sync.RWMutex
 	lockGet              sync.RWMutex
 	lockGetByTriggeredID sync.RWMutex
+	lockGetPaginated     sync.RWMutex
 	lockIsContextPaused  sync.RWMutex
 	lockPauseContext     sync.RWMutex
 	lockResumeContext    sync.RWMutex
@@ -160,7 +176,8 @@ func (mock *SequenceExecutionRepoMock) AppendTaskEvent(taskSequence models.Seque
 
 // AppendTaskEventCalls gets all the calls that were made to AppendTaskEvent.
 // Check the length with:
-//     len(mockedSequenceExecutionRepo.AppendTaskEventCalls())
+//
+// 	len(mockedSequenceExecutionRepo.AppendTaskEventCalls())
 func (mock *SequenceExecutionRepoMock) AppendTaskEventCalls() []struct {
 	TaskSequence models.SequenceExecution
 	Event        models.TaskEvent
@@ -193,7 +210,8 @@ func (mock *SequenceExecutionRepoMock) Clear(projectName string) error {
 
 // ClearCalls gets all the calls that were made to Clear.
 // Check the length with:
-//     len(mockedSequenceExecutionRepo.ClearCalls())
+//
+// 	len(mockedSequenceExecutionRepo.ClearCalls())
 func (mock *SequenceExecutionRepoMock) ClearCalls() []struct {
 	ProjectName string
 } {
@@ -224,7 +242,8 @@ func (mock *SequenceExecutionRepoMock) Get(filter models.SequenceExecutionFilter
 
 // GetCalls gets all the calls that were made to Get.
 // Check the length with:
-//     len(mockedSequenceExecutionRepo.GetCalls())
+//
+// 	len(mockedSequenceExecutionRepo.GetCalls())
 func (mock *SequenceExecutionRepoMock) GetCalls() []struct {
 	Filter models.SequenceExecutionFilter
 } {
@@ -257,7 +276,8 @@ func (mock *SequenceExecutionRepoMock) GetByTriggeredID(project string, triggere
 
 // GetByTriggeredIDCalls gets all the calls that were made to GetByTriggeredID.
 // Check the length with:
-//     len(mockedSequenceExecutionRepo.GetByTriggeredIDCalls())
+//
+// 	len(mockedSequenceExecutionRepo.GetByTriggeredIDCalls())
 func (mock *SequenceExecutionRepoMock) GetByTriggeredIDCalls() []struct {
 	Project     string
 	TriggeredID string
@@ -272,6 +292,46 @@ func (mock *SequenceExecutionRepoMock) GetByTriggeredIDCalls() []struct {
 	return calls
 }
 
+// GetPaginated calls GetPaginatedFunc.
+func (mock *SequenceExecutionRepoMock) GetPaginated(filter models.SequenceExecutionFilter, nextPageKey int64, pageSize int64) ([]models.SequenceExecution, int64, error) {
+	if mock.GetPaginatedFunc == nil {
+		panic("SequenceExecutionRepoMock.GetPaginatedFunc: method is nil but SequenceExecutionRepo.GetPaginated was just called")
+	}
+	callInfo := struct {
+		Filter      models.SequenceExecutionFilter
+		NextPageKey int64
+		PageSize    int64
+	}{
+		Filter:      filter,
+		NextPageKey: nextPageKey,
+		PageSize:    pageSize,
+	}
+	mock.lockGetPaginated.Lock()
+	mock.calls.GetPaginated = append(mock.calls.GetPaginated, callInfo)
+	mock.lockGetPaginated.Unlock()
+	return mock.GetPaginatedFunc(filter, nextPageKey, pageSize)
+}
+
+// GetPaginatedCalls gets all the calls that were made to GetPaginated.
+// Check the length with:
+//
+// 	len(mockedSequenceExecutionRepo.GetPaginatedCalls())
+func (mock *SequenceExecutionRepoMock) GetPaginatedCalls() []struct {
+	Filter      models.SequenceExecutionFilter
+	NextPageKey int64
+	PageSize    int64
+} {
+	var calls []struct {
+		Filter      models.SequenceExecutionFilter
+		NextPageKey int64
+		PageSize    int64
+	}
+	mock.lockGetPaginated.RLock()
+	calls = mock.calls.GetPaginated
+	mock.lockGetPaginated.RUnlock()
+	return calls
+}
+
 // IsContextPaused calls IsContextPausedFunc.
 func (mock *SequenceExecutionRepoMock) IsContextPaused(eventScope models.EventScope) bool {
 	if mock.IsContextPausedFunc == nil {
@@ -290,7 +350,8 @@ func (mock *SequenceExecutionRepoMock) IsContextPaused(eventScope models.EventSc
 
 // IsContextPausedCalls gets all the calls that were made to IsContextPaused.
 // Check the length with:
-//     len(mockedSequenceExecutionRepo.IsContextPausedCalls())
+//
+// 	len(mockedSequenceExecutionRepo.IsContextPausedCalls())
 func (mock *SequenceExecutionRepoMock) IsContextPausedCalls() []struct {
 	EventScope models.EventScope
 } {
@@ -321,7 +382,8 @@ func (mock *SequenceExecutionRepoMock) PauseContext(eventScope models.EventScope
 
 // PauseContextCalls gets all the calls that were made to PauseContext.
 // Check the length with:
-//     len(mockedSequenceExecutionRepo.PauseContextCalls())
+//
+// 	len(mockedSequenceExecutionRepo.PauseContextCalls())
 func (mock *SequenceExecutionRepoMock) PauseContextCalls() []struct {
 	EventScope models.EventScope
 } {
@@ -352,7 +414,8 @@ func (mock *SequenceExecutionRepoMock) ResumeContext(eventScope models.EventScop
 
 // ResumeContextCalls gets all the calls that were made to ResumeContext.
 // Check the length with:
-//     len(mockedSequenceExecutionRepo.ResumeContextCalls())
+//
+// 	len(mockedSequenceExecutionRepo.ResumeContextCalls())
 func (mock *SequenceExecutionRepoMock) ResumeContextCalls() []struct {
 	EventScope models.EventScope
 } {
@@ -383,7 +446,8 @@ func (mock *SequenceExecutionRepoMock) UpdateStatus(taskSequence models.Sequence
 
 // UpdateStatusCalls gets all the calls that were made to UpdateStatus.
 // Check the length with:
-//     len(mockedSequenceExecutionRepo.UpdateStatusCalls())
+//
+// 	len(mockedSequenceExecutionRepo.UpdateStatusCalls())
 func (mock *SequenceExecutionRepoMock) UpdateStatusCalls() []struct {
 	TaskSequence models.SequenceExecution
 } {
@@ -416,7 +480,8 @@ func (mock *SequenceExecutionRepoMock) Upsert(item models.SequenceExecution, opt
 
 // UpsertCalls gets all the calls that were made to Upsert.
 // Check the length with:
-//     len(mockedSequenceExecutionRepo.UpsertCalls())
+//
+// 	len(mockedSequenceExecutionRepo.UpsertCalls())
 func (mock *SequenceExecutionRepoMock) UpsertCalls() []struct {
 	Item    models.SequenceExecution
 	Options *models.SequenceExecutionUpsertOptions
@@ -6,6 +6,7 @@ package db_mock
 import (
 	"github.com/keptn/keptn/shipyard-controller/models"
 	"sync"
+	"time"
 )
 
 // SequenceQueueRepoMock is a mock implementation of db.SequenceQueueRepo.
@@ -14,6 +15,9 @@ import (
 //
 // 		// make and configure a mocked db.SequenceQueueRepo
 // 		mockedSequenceQueueRepo := &SequenceQueueRepoMock{
+// 			ClaimQueuedSequencesFunc: func(owner string, ttl time.Duration, limit int) ([]models.QueueItem, error) {
+// 				panic("mock out the ClaimQueuedSequences method")
+// 			},
 // 			DeleteQueuedSequencesFunc: func(itemFilter models.QueueItem) error {
 // 				panic("mock out the DeleteQueuedSequences method")
 // 			},
@@ -23,6 +27,12 @@ import (
 // 			QueueSequenceFunc: func(item models.QueueItem) error {
 // 				panic("mock out the QueueSequence method")
 // 			},
+// 			ReleaseClaimedSequencesFunc: func(owner string) error {
+// 				panic("mock out the ReleaseClaimedSequences method")
+// 			},
+// 			RenewSequenceClaimsFunc: func(owner string, ttl time.Duration) error {
+// 				panic("mock out the RenewSequenceClaims method")
+// 			},
 // 		}
 //
 // 		// use mockedSequenceQueueRepo in code that requires db.SequenceQueueRepo
@@ -30,6 +40,9 @@ import (
 //
 // 	}
 type SequenceQueueRepoMock struct {
+	// ClaimQueuedSequencesFunc mocks the ClaimQueuedSequences method.
+	ClaimQueuedSequencesFunc func(owner string, ttl time.Duration, limit int) ([]models.QueueItem, error)
+
 	// DeleteQueuedSequencesFunc mocks the DeleteQueuedSequences method.
 	DeleteQueuedSequencesFunc func(itemFilter models.QueueItem) error
 
@@ -39,8 +52,23 @@ type SequenceQueueRepoMock struct {
 	// QueueSequenceFunc mocks the QueueSequence method.
 	QueueSequenceFunc func(item models.QueueItem) error
 
+	// ReleaseClaimedSequencesFunc mocks the ReleaseClaimedSequences method.
+	ReleaseClaimedSequencesFunc func(owner string) error
+
+	// RenewSequenceClaimsFunc mocks the RenewSequenceClaims method.
+	RenewSequenceClaimsFunc func(owner string, ttl time.Duration) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// ClaimQueuedSequences holds details about calls to the ClaimQueuedSequences method.
+		ClaimQueuedSequences []struct {
+			// Owner is the owner argument value.
+			Owner string
+			// Ttl is the ttl argument value.
+			Ttl time.Duration
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// DeleteQueuedSequences holds details about calls to the DeleteQueuedSequences method.
 		DeleteQueuedSequences []struct {
 			// ItemFilter is the itemFilter argument value.
@@ -54,10 +82,65 @@ type SequenceQueueRepoMock struct {
 			// Item is the item argument value.
 			Item models.QueueItem
 		}
+		// ReleaseClaimedSequences holds details about calls to the ReleaseClaimedSequences method.
+		ReleaseClaimedSequences []struct {
+			// Owner is the owner argument value.
+			Owner string
+		}
+		// RenewSequenceClaims holds details about calls to the RenewSequenceClaims method.
+		RenewSequenceClaims []struct {
+			// Owner is the owner argument value.
+			Owner string
+			// Ttl is the ttl argument value.
+			Ttl time.Duration
+		}
 	}
-	lockDeleteQueuedSequences sync.RWMutex
-	lockGetQueuedSequences    sync.RWMutex
-	lockQueueSequence         sync.RWMutex
+	lockClaimQueuedSequences    sync.RWMutex
+	lockDeleteQueuedSequences   sync.RWMutex
+	lockGetQueuedSequences      sync.RWMutex
+	lockQueueSequence           sync.RWMutex
+	lockReleaseClaimedSequences sync.RWMutex
+	lockRenewSequenceClaims     sync.RWMutex
+}
+
+// ClaimQueuedSequences calls ClaimQueuedSequencesFunc.
+func (mock *SequenceQueueRepoMock) ClaimQueuedSequences(owner string, ttl time.Duration, limit int) ([]models.QueueItem, error) {
+	if mock.ClaimQueuedSequencesFunc == nil {
+		panic("SequenceQueueRepoMock.ClaimQueuedSequencesFunc: method is nil but SequenceQueueRepo.ClaimQueuedSequences was just called")
+	}
+	callInfo := struct {
+		Owner string
+		Ttl   time.Duration
+		Limit int
+	}{
+		Owner: owner,
+		Ttl:   ttl,
+		Limit: limit,
+	}
+	mock.lockClaimQueuedSequences.Lock()
+	mock.calls.ClaimQueuedSequences = append(mock.calls.ClaimQueuedSequences, callInfo)
+	mock.lockClaimQueuedSequences.Unlock()
+	return mock.ClaimQueuedSequencesFunc(owner, ttl, limit)
+}
+
+// ClaimQueuedSequencesCalls gets all the calls that were made to ClaimQueuedSequences.
+// Check the length with:
+//
+// 	len(mockedSequenceQueueRepo.ClaimQueuedSequencesCalls())
+func (mock *SequenceQueueRepoMock) ClaimQueuedSequencesCalls() []struct {
+	Owner string
+	Ttl   time.Duration
+	Limit int
+} {
+	var calls []struct {
+		Owner string
+		Ttl   time.Duration
+		Limit int
+	}
+	mock.lockClaimQueuedSequences.RLock()
+	calls = mock.calls.ClaimQueuedSequences
+	mock.lockClaimQueuedSequences.RUnlock()
+	return calls
 }
 
 // DeleteQueuedSequences calls DeleteQueuedSequencesFunc.
@@ -78,7 +161,8 @@ func (mock *SequenceQueueRepoMock) DeleteQueuedSequences(itemFilter models.Queue
 
 // DeleteQueuedSequencesCalls gets all the calls that were made to DeleteQueuedSequences.
 // Check the length with:
-//     len(mockedSequenceQueueRepo.DeleteQueuedSequencesCalls())
+//
+// 	len(mockedSequenceQueueRepo.DeleteQueuedSequencesCalls())
 func (mock *SequenceQueueRepoMock) DeleteQueuedSequencesCalls() []struct {
 	ItemFilter models.QueueItem
 } {
@@ -106,7 +190,8 @@ func (mock *SequenceQueueRepoMock) GetQueuedSequences() ([]models.QueueItem, err
 
 // GetQueuedSequencesCalls gets all the calls that were made to GetQueuedSequences.
 // Check the length with:
-//     len(mockedSequenceQueueRepo.GetQueuedSequencesCalls())
+//
+// 	len(mockedSequenceQueueRepo.GetQueuedSequencesCalls())
 func (mock *SequenceQueueRepoMock) GetQueuedSequencesCalls() []struct {
 } {
 	var calls []struct {
@@ -135,7 +220,8 @@ func (mock *SequenceQueueRepoMock) QueueSequence(item models.QueueItem) error {
 
 // QueueSequenceCalls gets all the calls that were made to QueueSequence.
 // Check the length with:
-//     len(mockedSequenceQueueRepo.QueueSequenceCalls())
+//
+// 	len(mockedSequenceQueueRepo.QueueSequenceCalls())
 func (mock *SequenceQueueRepoMock) QueueSequenceCalls() []struct {
 	Item models.QueueItem
 } {
@@ -147,3 +233,71 @@ func (mock *SequenceQueueRepoMock) QueueSequenceCalls() []struct {
 	mock.lockQueueSequence.RUnlock()
 	return calls
 }
+
+// ReleaseClaimedSequences calls ReleaseClaimedSequencesFunc.
+func (mock *SequenceQueueRepoMock) ReleaseClaimedSequences(owner string) error {
+	if mock.ReleaseClaimedSequencesFunc == nil {
+		panic("SequenceQueueRepoMock.ReleaseClaimedSequencesFunc: method is nil but SequenceQueueRepo.ReleaseClaimedSequences was just called")
+	}
+	callInfo := struct {
+		Owner string
+	}{
+		Owner: owner,
+	}
+	mock.lockReleaseClaimedSequences.Lock()
+	mock.calls.ReleaseClaimedSequences = append(mock.calls.ReleaseClaimedSequences, callInfo)
+	mock.lockReleaseClaimedSequences.Unlock()
+	return mock.ReleaseClaimedSequencesFunc(owner)
+}
+
+// ReleaseClaimedSequencesCalls gets all the calls that were made to ReleaseClaimedSequences.
+// Check the length with:
+//
+// 	len(mockedSequenceQueueRepo.ReleaseClaimedSequencesCalls())
+func (mock *SequenceQueueRepoMock) ReleaseClaimedSequencesCalls() []struct {
+	Owner string
+} {
+	var calls []struct {
+		Owner string
+	}
+	mock.lockReleaseClaimedSequences.RLock()
+	calls = mock.calls.ReleaseClaimedSequences
+	mock.lockReleaseClaimedSequences.RUnlock()
+	return calls
+}
+
+// RenewSequenceClaims calls RenewSequenceClaimsFunc.
+func (mock *SequenceQueueRepoMock) RenewSequenceClaims(owner string, ttl time.Duration) error {
+	if mock.RenewSequenceClaimsFunc == nil {
+		panic("SequenceQueueRepoMock.RenewSequenceClaimsFunc: method is nil but SequenceQueueRepo.RenewSequenceClaims was just called")
+	}
+	callInfo := struct {
+		Owner string
+		Ttl   time.Duration
+	}{
+		Owner: owner,
+		Ttl:   ttl,
+	}
+	mock.lockRenewSequenceClaims.Lock()
+	mock.calls.RenewSequenceClaims = append(mock.calls.RenewSequenceClaims, callInfo)
+	mock.lockRenewSequenceClaims.Unlock()
+	return mock.RenewSequenceClaimsFunc(owner, ttl)
+}
+
+// RenewSequenceClaimsCalls gets all the calls that were made to RenewSequenceClaims.
+// Check the length with:
+//
+// 	len(mockedSequenceQueueRepo.RenewSequenceClaimsCalls())
+func (mock *SequenceQueueRepoMock) RenewSequenceClaimsCalls() []struct {
+	Owner string
+	Ttl   time.Duration
+} {
+	var calls []struct {
+		Owner string
+		Ttl   time.Duration
+	}
+	mock.lockRenewSequenceClaims.RLock()
+	calls = mock.calls.RenewSequenceClaims
+	mock.lockRenewSequenceClaims.RUnlock()
+	return calls
+}
@@ -42,6 +42,9 @@ import (
 // 			GetUniformIntegrationsFunc: func(filter models.GetUniformIntegrationsParams) ([]apimodels.Integration, error) {
 // 				panic("mock out the GetUniformIntegrations method")
 // 			},
+// 			RenameServiceInSubscriptionsFunc: func(projectName string, serviceName string, newServiceName string) error {
+// 				panic("mock out the RenameServiceInSubscriptions method")
+// 			},
 // 			UpdateLastSeenFunc: func(integrationID string) (*apimodels.Integration, error) {
 // 				panic("mock out the UpdateLastSeen method")
 // 			},
@@ -82,6 +85,9 @@ type UniformRepoMock struct {
 	// GetUniformIntegrationsFunc mocks the GetUniformIntegrations method.
 	GetUniformIntegrationsFunc func(filter models.GetUniformIntegrationsParams) ([]apimodels.Integration, error)
 
+	// RenameServiceInSubscriptionsFunc mocks the RenameServiceInSubscriptions method.
+	RenameServiceInSubscriptionsFunc func(projectName string, serviceName string, newServiceName string) error
+
 	// UpdateLastSeenFunc mocks the UpdateLastSeen method.
 	UpdateLastSeenFunc func(integrationID string) (*apimodels.Integration, error)
 
@@ -141,6 +147,15 @@ type UniformRepoMock struct {
 			// Filter is the filter argument value.
 			Filter models.GetUniformIntegrationsParams
 		}
+		// RenameServiceInSubscriptions holds details about calls to the RenameServiceInSubscriptions method.
+		RenameServiceInSubscriptions []struct {
+			// ProjectName is the projectName argument value.
+			ProjectName string
+			// ServiceName is the serviceName argument value.
+			ServiceName string
+			// NewServiceName is the newServiceName argument value.
+			NewServiceName string
+		}
 		// UpdateLastSeen holds details about calls to the UpdateLastSeen method.
 		UpdateLastSeen []struct {
 			// IntegrationID is the integrationID argument value.
@@ -165,6 +180,7 @@ type UniformRepoMock struct {
 	lockGetSubscription                  sync.RWMutex
 	lockGetSubscriptions                 sync.RWMutex
 	lockGetUniformIntegrations           sync.RWMutex
+	lockRenameServiceInSubscriptions     sync.RWMutex
 	lockUpdateLastSeen                   sync.RWMutex
 	lockUpdateVersionInfo                sync.RWMutex
 }
@@ -460,6 +476,45 @@ func (mock *UniformRepoMock) GetUniformIntegrationsCalls() []struct {
 	return calls
 }
 
+// RenameServiceInSubscriptions calls RenameServiceInSubscriptionsFunc.
+func (mock *UniformRepoMock) RenameServiceInSubscriptions(projectName string, serviceName string, newServiceName string) error {
+	if mock.RenameServiceInSubscriptionsFunc == nil {
+		panic("UniformRepoMock.RenameServiceInSubscriptionsFunc: method is nil but UniformRepo.RenameServiceInSubscriptions was just called")
+	}
+	callInfo := struct {
+		ProjectName    string
+		ServiceName    string
+		NewServiceName string
+	}{
+		ProjectName:    projectName,
+		ServiceName:    serviceName,
+		NewServiceName: newServiceName,
+	}
+	mock.lockRenameServiceInSubscriptions.Lock()
+	mock.calls.RenameServiceInSubscriptions = append(mock.calls.RenameServiceInSubscriptions, callInfo)
+	mock.lockRenameServiceInSubscriptions.Unlock()
+	return mock.RenameServiceInSubscriptionsFunc(projectName, serviceName, newServiceName)
+}
+
+// RenameServiceInSubscriptionsCalls gets all the calls that were made to RenameServiceInSubscriptions.
+// Check the length with:
+//     len(mockedUniformRepo.RenameServiceInSubscriptionsCalls())
+func (mock *UniformRepoMock) RenameServiceInSubscriptionsCalls() []struct {
+	ProjectName    string
+	ServiceName    string
+	NewServiceName string
+} {
+	var calls []struct {
+		ProjectName    string
+		ServiceName    string
+		NewServiceName string
+	}
+	mock.lockRenameServiceInSubscriptions.RLock()
+	calls = mock.calls.RenameServiceInSubscriptions
+	mock.lockRenameServiceInSubscriptions.RUnlock()
+	return calls
+}
+
 // UpdateLastSeen calls UpdateLastSeenFunc.
 func (mock *UniformRepoMock) UpdateLastSeen(integrationID string) (*apimodels.Integration, error) {
 	if mock.UpdateLastSeenFunc == nil {
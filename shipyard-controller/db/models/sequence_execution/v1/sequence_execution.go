@@ -5,6 +5,7 @@ import (
 	"time"
 
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/keptn/keptn/shipyard-controller/models"
 )
 
@@ -24,6 +25,10 @@ type JsonStringEncodedSequenceExecution struct {
 	// EncodedInputProperties contains properties of the event which triggered the task sequence
 	EncodedInputProperties string    `json:"encodedInputProperties" bson:"encodedInputProperties"`
 	TriggeredAt            time.Time `json:"triggeredAt" bson:"triggeredAt"`
+	Priority               int       `json:"priority,omitempty" bson:"priority,omitempty"`
+	Timeout                string    `json:"timeout,omitempty" bson:"timeout,omitempty"`
+	// TaskAttributes contains the shipyard attributes of the tasks of the sequence, in the order of the tasks
+	TaskAttributes []common.TaskAttributes `json:"taskAttributes,omitempty" bson:"taskAttributes,omitempty"`
 }
 
 type Sequence struct {
@@ -64,6 +69,29 @@ type SequenceExecutionStatus struct {
 	PreviousTasks []TaskExecutionResult `json:"previousTasks" bson:"previousTasks"`
 	// CurrentTask represents the state of the currently active task
 	CurrentTask TaskExecutionState `json:"currentTask" bson:"currentTask"`
+	// ParallelTasks represents the states of the tasks that are active in parallel to the CurrentTask
+	ParallelTasks []TaskExecutionState `json:"parallelTasks" bson:"parallelTasks"`
+	// FreezeOverridden indicates that the sequence may be started even if a freeze window of its stage is currently active
+	FreezeOverridden bool `json:"freezeOverridden,omitempty" bson:"freezeOverridden,omitempty"`
+	// StartedAt is the time at which the sequence has been started
+	StartedAt time.Time `json:"startedAt,omitempty" bson:"startedAt,omitempty"`
+}
+
+func (s SequenceExecutionStatus) DecodeParallelTasks() []models.TaskExecutionState {
+	if len(s.ParallelTasks) == 0 {
+		return nil
+	}
+	result := []models.TaskExecutionState{}
+
+	for _, parallelTask := range s.ParallelTasks {
+		result = append(result, models.TaskExecutionState{
+			Name:        parallelTask.Name,
+			TriggeredID: parallelTask.TriggeredID,
+			Events:      parallelTask.DecodeEvents(),
+			Attempts:    decodeAttempts(parallelTask.Attempts),
+		})
+	}
+	return result
 }
 
 func (s SequenceExecutionStatus) DecodePreviousTasks() []models.TaskExecutionResult {
@@ -75,6 +103,10 @@ func (s SequenceExecutionStatus) DecodePreviousTasks() []models.TaskExecutionRes
 			TriggeredID: previousTask.TriggeredID,
 			Result:      previousTask.Result,
 			Status:      previousTask.Status,
+			Attempts:    decodeAttempts(previousTask.Attempts),
+			StartedAt:   previousTask.StartedAt,
+			FinishedAt:  previousTask.FinishedAt,
+			Executors:   previousTask.Executors,
 		}
 
 		if previousTask.EncodedProperties != "" {
@@ -96,12 +128,42 @@ type TaskExecutionResult struct {
 	Status      keptnv2.StatusType `json:"status" bson:"status"`
 	// EncodedProperties contains the aggregated results of the task's executors
 	EncodedProperties string `json:"encodedProperties" bson:"encodedProperties"`
+	// Attempts contains the unsuccessful attempts of the task that have been retried
+	Attempts   []TaskExecutionAttempt `json:"attempts,omitempty" bson:"attempts,omitempty"`
+	StartedAt  string                 `json:"startedAt,omitempty" bson:"startedAt,omitempty"`
+	FinishedAt string                 `json:"finishedAt,omitempty" bson:"finishedAt,omitempty"`
+	Executors  []string               `json:"executors,omitempty" bson:"executors,omitempty"`
+}
+
+type TaskExecutionAttempt struct {
+	TriggeredID string             `json:"triggeredID" bson:"triggeredID"`
+	Result      keptnv2.ResultType `json:"result" bson:"result"`
+	Status      keptnv2.StatusType `json:"status" bson:"status"`
+	FinishedAt  string             `json:"finishedAt" bson:"finishedAt"`
+}
+
+func decodeAttempts(attempts []TaskExecutionAttempt) []models.TaskExecutionAttempt {
+	if len(attempts) == 0 {
+		return nil
+	}
+	result := []models.TaskExecutionAttempt{}
+	for _, attempt := range attempts {
+		result = append(result, models.TaskExecutionAttempt{
+			TriggeredID: attempt.TriggeredID,
+			Result:      attempt.Result,
+			Status:      attempt.Status,
+			FinishedAt:  attempt.FinishedAt,
+		})
+	}
+	return result
 }
 
 type TaskExecutionState struct {
 	Name        string      `json:"name" bson:"name"`
 	TriggeredID string      `json:"triggeredID" bson:"triggeredID"`
 	Events      []TaskEvent `json:"events" bson:"events"`
+	// Attempts contains the previous attempts of the task, if it has been retried
+	Attempts []TaskExecutionAttempt `json:"attempts,omitempty" bson:"attempts,omitempty"`
 }
 
 func (s TaskExecutionState) DecodeEvents() []models.TaskEvent {
@@ -109,11 +171,12 @@ func (s TaskExecutionState) DecodeEvents() []models.TaskEvent {
 
 	for _, event := range s.Events {
 		newEvent := models.TaskEvent{
-			EventType: event.EventType,
-			Source:    event.Source,
-			Result:    event.Result,
-			Status:    event.Status,
-			Time:      event.Time,
+			TriggeredID: event.TriggeredID,
+			EventType:   event.EventType,
+			Source:      event.Source,
+			Result:      event.Result,
+			Status:      event.Status,
+			Time:        event.Time,
 		}
 		if event.EncodedProperties != "" {
 			properties := map[string]interface{}{}
@@ -127,6 +190,7 @@ func (s TaskExecutionState) DecodeEvents() []models.TaskEvent {
 }
 
 type TaskEvent struct {
+	TriggeredID       string             `json:"triggeredID,omitempty" bson:"triggeredID,omitempty"`
 	EventType         string             `json:"eventType" bson:"eventType"`
 	Source            string             `json:"source" bson:"source"`
 	Result            keptnv2.ResultType `json:"result" bson:"result"`
@@ -151,10 +215,17 @@ func (e JsonStringEncodedSequenceExecution) ToSequenceExecution() models.Sequenc
 				Name:        e.Status.CurrentTask.Name,
 				TriggeredID: e.Status.CurrentTask.TriggeredID,
 				Events:      e.Status.CurrentTask.DecodeEvents(),
+				Attempts:    decodeAttempts(e.Status.CurrentTask.Attempts),
 			},
+			ParallelTasks:    e.Status.DecodeParallelTasks(),
+			FreezeOverridden: e.Status.FreezeOverridden,
+			StartedAt:        e.Status.StartedAt.UTC(),
 		},
-		Scope:       e.Scope,
-		TriggeredAt: e.TriggeredAt.UTC(),
+		Scope:          e.Scope,
+		TriggeredAt:    e.TriggeredAt.UTC(),
+		Priority:       e.Priority,
+		Timeout:        e.Timeout,
+		TaskAttributes: e.TaskAttributes,
 	}
 	inputProperties := map[string]interface{}{}
 	err := json.Unmarshal([]byte(e.EncodedInputProperties), &inputProperties)
@@ -137,6 +137,7 @@ var testJsonStringEncodedSequenceExecution = JsonStringEncodedSequenceExecution{
 				},
 			},
 		},
+		ParallelTasks: []TaskExecutionState{},
 	},
 	Scope: models.EventScope{
 		EventData: keptnv2.EventData{
@@ -52,10 +52,13 @@ func fromSequenceExecution(se models.SequenceExecution) JsonStringEncodedSequenc
 			Name:  se.Sequence.Name,
 			Tasks: transformTasks(se.Sequence.Tasks),
 		},
-		Status:        transformStatus(se.Status),
-		Scope:         se.Scope,
-		SchemaVersion: SchemaVersion{SchemaVersion: SchemaVersionV1},
-		TriggeredAt:   se.TriggeredAt,
+		Status:         transformStatus(se.Status),
+		Scope:          se.Scope,
+		SchemaVersion:  SchemaVersion{SchemaVersion: SchemaVersionV1},
+		TriggeredAt:    se.TriggeredAt,
+		Priority:       se.Priority,
+		Timeout:        se.Timeout,
+		TaskAttributes: se.TaskAttributes,
 	}
 	if se.InputProperties != nil {
 		inputPropertiesJsonString, err := json.Marshal(se.InputProperties)
@@ -91,6 +94,13 @@ func transformStatus(status models.SequenceExecutionStatus) SequenceExecutionSta
 		StateBeforePause: status.StateBeforePause,
 		PreviousTasks:    transformPreviousTasks(status.PreviousTasks),
 		CurrentTask:      transformCurrentTask(status.CurrentTask),
+		ParallelTasks:    []TaskExecutionState{},
+		FreezeOverridden: status.FreezeOverridden,
+		StartedAt:        status.StartedAt,
+	}
+
+	for _, parallelTask := range status.ParallelTasks {
+		newStatus.ParallelTasks = append(newStatus.ParallelTasks, transformCurrentTask(parallelTask))
 	}
 
 	return newStatus
@@ -101,10 +111,27 @@ func transformCurrentTask(task models.TaskExecutionState) TaskExecutionState {
 		Name:        task.Name,
 		TriggeredID: task.TriggeredID,
 		Events:      transformTaskEvents(task.Events),
+		Attempts:    transformAttempts(task.Attempts),
 	}
 	return newTaskExecutionState
 }
 
+func transformAttempts(attempts []models.TaskExecutionAttempt) []TaskExecutionAttempt {
+	if len(attempts) == 0 {
+		return nil
+	}
+	newAttempts := []TaskExecutionAttempt{}
+	for _, attempt := range attempts {
+		newAttempts = append(newAttempts, TaskExecutionAttempt{
+			TriggeredID: attempt.TriggeredID,
+			Result:      attempt.Result,
+			Status:      attempt.Status,
+			FinishedAt:  attempt.FinishedAt,
+		})
+	}
+	return newAttempts
+}
+
 func transformTaskEvents(events []models.TaskEvent) []TaskEvent {
 	newTaskEvents := []TaskEvent{}
 
@@ -117,11 +144,12 @@ func transformTaskEvents(events []models.TaskEvent) []TaskEvent {
 
 func transformTaskEvent(e models.TaskEvent) TaskEvent {
 	newTaskEvent := TaskEvent{
-		EventType: e.EventType,
-		Source:    e.Source,
-		Result:    e.Result,
-		Status:    e.Status,
-		Time:      e.Time,
+		TriggeredID: e.TriggeredID,
+		EventType:   e.EventType,
+		Source:      e.Source,
+		Result:      e.Result,
+		Status:      e.Status,
+		Time:        e.Time,
 	}
 
 	if e.Properties != nil {
@@ -142,6 +170,10 @@ func transformPreviousTasks(tasks []models.TaskExecutionResult) []TaskExecutionR
 			TriggeredID: t.TriggeredID,
 			Result:      t.Result,
 			Status:      t.Status,
+			Attempts:    transformAttempts(t.Attempts),
+			StartedAt:   t.StartedAt,
+			FinishedAt:  t.FinishedAt,
+			Executors:   t.Executors,
 		}
 
 		if t.Properties != nil {
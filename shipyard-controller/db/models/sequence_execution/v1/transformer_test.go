@@ -5,6 +5,7 @@ import (
 	"github.com/keptn/keptn/shipyard-controller/models"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestFromSequenceExecution(t *testing.T) {
@@ -265,3 +266,147 @@ func TestModelTransformer_TransformToSequenceExecution(t *testing.T) {
 		})
 	}
 }
+
+func TestModelTransformer_ParallelTasks(t *testing.T) {
+	se := models.SequenceExecution{
+		ID: "id",
+		Sequence: keptnv2.Sequence{
+			Name: "delivery",
+			Tasks: []keptnv2.Task{
+				{Name: "test", Properties: map[string]interface{}{"parallel": "verification"}},
+				{Name: "security-scan", Properties: map[string]interface{}{"parallel": "verification"}},
+			},
+		},
+		Status: models.SequenceExecutionStatus{
+			State: "started",
+			CurrentTask: models.TaskExecutionState{
+				Name:        "test",
+				TriggeredID: "tr1",
+				Events:      []models.TaskEvent{},
+			},
+			ParallelTasks: []models.TaskExecutionState{
+				{
+					Name:        "security-scan",
+					TriggeredID: "tr2",
+					Events: []models.TaskEvent{
+						{
+							TriggeredID: "tr2",
+							EventType:   keptnv2.GetStartedEventType("security-scan"),
+							Source:      "scanner",
+						},
+					},
+				},
+			},
+		},
+		Scope: models.EventScope{
+			EventData:    keptnv2.EventData{Project: "my-project", Stage: "my-stage", Service: "my-service"},
+			KeptnContext: "ctx1",
+		},
+	}
+
+	mt := ModelTransformer{}
+	dbItem := mt.TransformToDBModel(se)
+
+	encoded, ok := dbItem.(JsonStringEncodedSequenceExecution)
+	require.True(t, ok)
+	require.Len(t, encoded.Status.ParallelTasks, 1)
+	require.Equal(t, "tr2", encoded.Status.ParallelTasks[0].Events[0].TriggeredID)
+
+	got, err := mt.TransformToSequenceExecution(dbItem)
+	require.Nil(t, err)
+	require.Equal(t, se.Status.ParallelTasks, got.Status.ParallelTasks)
+	require.Equal(t, se.Sequence.Tasks, got.Sequence.Tasks)
+}
+
+func TestModelTransformer_TaskAttempts(t *testing.T) {
+	attempts := []models.TaskExecutionAttempt{
+		{TriggeredID: "tr1", Result: keptnv2.ResultFailed, Status: keptnv2.StatusErrored, FinishedAt: "2022-01-01T00:00:00.000Z"},
+	}
+	se := models.SequenceExecution{
+		ID: "id",
+		Status: models.SequenceExecutionStatus{
+			State: "started",
+			PreviousTasks: []models.TaskExecutionResult{
+				{Name: "deployment", TriggeredID: "tr2", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded, Properties: map[string]interface{}{}, Attempts: attempts},
+			},
+			CurrentTask: models.TaskExecutionState{
+				Name:        "test",
+				TriggeredID: "tr4",
+				Events:      []models.TaskEvent{},
+				Attempts:    []models.TaskExecutionAttempt{{TriggeredID: "tr3", Result: keptnv2.ResultFailed, Status: keptnv2.StatusErrored}},
+			},
+		},
+	}
+
+	mt := ModelTransformer{}
+	got, err := mt.TransformToSequenceExecution(mt.TransformToDBModel(se))
+	require.Nil(t, err)
+	require.Equal(t, attempts, got.Status.PreviousTasks[0].Attempts)
+	require.Equal(t, se.Status.CurrentTask.Attempts, got.Status.CurrentTask.Attempts)
+}
+
+func TestModelTransformer_FreezeOverridden(t *testing.T) {
+	se := models.SequenceExecution{
+		ID: "id",
+		Status: models.SequenceExecutionStatus{
+			State:            "waiting",
+			FreezeOverridden: true,
+		},
+	}
+
+	mt := ModelTransformer{}
+	got, err := mt.TransformToSequenceExecution(mt.TransformToDBModel(se))
+	require.Nil(t, err)
+	require.True(t, got.Status.FreezeOverridden)
+}
+
+func TestModelTransformer_Priority(t *testing.T) {
+	se := models.SequenceExecution{
+		ID:       "id",
+		Priority: 100,
+	}
+
+	mt := ModelTransformer{}
+	got, err := mt.TransformToSequenceExecution(mt.TransformToDBModel(se))
+	require.Nil(t, err)
+	require.Equal(t, 100, got.Priority)
+}
+
+func TestModelTransformer_Timeout(t *testing.T) {
+	startedAt := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	se := models.SequenceExecution{
+		ID:      "id",
+		Timeout: "2h",
+		Status: models.SequenceExecutionStatus{
+			State:     "started",
+			StartedAt: startedAt,
+		},
+	}
+
+	mt := ModelTransformer{}
+	got, err := mt.TransformToSequenceExecution(mt.TransformToDBModel(se))
+	require.Nil(t, err)
+	require.Equal(t, "2h", got.Timeout)
+	require.Equal(t, startedAt, got.Status.StartedAt)
+}
+
+func TestModelTransformer_TaskTiming(t *testing.T) {
+	se := models.SequenceExecution{
+		ID: "id",
+		Status: models.SequenceExecutionStatus{
+			PreviousTasks: []models.TaskExecutionResult{
+				{
+					Name:       "deployment",
+					StartedAt:  "2022-05-10T09:00:00.000Z",
+					FinishedAt: "2022-05-10T09:05:00.000Z",
+					Executors:  []string{"helm-service", "jmeter-service"},
+				},
+			},
+		},
+	}
+
+	mt := ModelTransformer{}
+	got, err := mt.TransformToSequenceExecution(mt.TransformToDBModel(se))
+	require.Nil(t, err)
+	require.Equal(t, se.Status.PreviousTasks, got.Status.PreviousTasks)
+}
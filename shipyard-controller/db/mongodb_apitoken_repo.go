@@ -0,0 +1,133 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const apiTokenCollectionName = "apiTokens"
+
+// MongoDBAPITokenRepo stores the named API tokens in a MongoDB collection
+type MongoDBAPITokenRepo struct {
+	DBConnection *MongoDBConnection
+}
+
+// NewMongoDBAPITokenRepo creates a new MongoDBAPITokenRepo
+func NewMongoDBAPITokenRepo(dbConnection *MongoDBConnection) *MongoDBAPITokenRepo {
+	return &MongoDBAPITokenRepo{DBConnection: dbConnection}
+}
+
+// CreateAPIToken stores a new API token. If a token with the same name already exists, ErrAPITokenAlreadyExists is returned
+func (m *MongoDBAPITokenRepo) CreateAPIToken(token models.APIToken) error {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	if _, err := collection.InsertOne(ctx, token); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrAPITokenAlreadyExists
+		}
+		return fmt.Errorf("could not store api token %s: %w", token.Name, err)
+	}
+	return nil
+}
+
+// GetAPITokens returns all API tokens, ordered by their name
+func (m *MongoDBAPITokenRepo) GetAPITokens() ([]models.APIToken, error) {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	cur, err := collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve api tokens: %w", err)
+	}
+	defer closeCursor(ctx, cur)
+
+	tokens := []models.APIToken{}
+	if err := cur.All(ctx, &tokens); err != nil {
+		return nil, fmt.Errorf("could not decode api tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// GetAPITokenByHash returns the API token with the given hash. If no token is found, ErrAPITokenNotFound is returned
+func (m *MongoDBAPITokenRepo) GetAPITokenByHash(hash string) (*models.APIToken, error) {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	res := collection.FindOne(ctx, bson.M{"hash": hash})
+	if res.Err() != nil {
+		if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+			return nil, ErrAPITokenNotFound
+		}
+		return nil, fmt.Errorf("could not retrieve api token: %w", res.Err())
+	}
+
+	token := &models.APIToken{}
+	if err := res.Decode(token); err != nil {
+		return nil, fmt.Errorf("could not decode api token: %w", err)
+	}
+	return token, nil
+}
+
+// UpdateAPITokenHash replaces the hash of the API token with the given name. If no token is found, ErrAPITokenNotFound is returned
+func (m *MongoDBAPITokenRepo) UpdateAPITokenHash(name, hash string, rotatedAt time.Time) error {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	res, err := collection.UpdateOne(ctx, bson.M{"_id": name}, bson.M{"$set": bson.M{"hash": hash, "rotatedAt": rotatedAt}})
+	if err != nil {
+		return fmt.Errorf("could not update api token %s: %w", name, err)
+	}
+	if res.MatchedCount == 0 {
+		return ErrAPITokenNotFound
+	}
+	return nil
+}
+
+// DeleteAPIToken deletes the API token with the given name. If no token is found, ErrAPITokenNotFound is returned
+func (m *MongoDBAPITokenRepo) DeleteAPIToken(name string) error {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	res, err := collection.DeleteOne(ctx, bson.M{"_id": name})
+	if err != nil {
+		return fmt.Errorf("could not delete api token %s: %w", name, err)
+	}
+	if res.DeletedCount == 0 {
+		return ErrAPITokenNotFound
+	}
+	return nil
+}
+
+func (m *MongoDBAPITokenRepo) getCollectionAndContext() (*mongo.Collection, context.Context, context.CancelFunc, error) {
+	err := m.DBConnection.EnsureDBConnection()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	collection := m.DBConnection.Client.Database(getDatabaseName()).Collection(apiTokenCollectionName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	return collection, ctx, cancel, nil
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMongoDBAPITokenRepo_CRUD(t *testing.T) {
+	repo := NewMongoDBAPITokenRepo(GetMongoDBConnectionInstance())
+
+	token := models.APIToken{
+		Name:      "ci-pipeline",
+		Hash:      "my-hash",
+		Roles:     []string{models.TokenRoleOperator},
+		Projects:  []string{"my-project"},
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+	err := repo.CreateAPIToken(token)
+	require.Nil(t, err)
+
+	err = repo.CreateAPIToken(token)
+	require.ErrorIs(t, err, ErrAPITokenAlreadyExists)
+
+	stored, err := repo.GetAPITokenByHash("my-hash")
+	require.Nil(t, err)
+	require.Equal(t, token, *stored)
+
+	rotatedAt := time.Now().UTC().Truncate(time.Millisecond)
+	err = repo.UpdateAPITokenHash("ci-pipeline", "my-new-hash", rotatedAt)
+	require.Nil(t, err)
+
+	// the previous token is no longer valid after rotating it
+	_, err = repo.GetAPITokenByHash("my-hash")
+	require.ErrorIs(t, err, ErrAPITokenNotFound)
+
+	stored, err = repo.GetAPITokenByHash("my-new-hash")
+	require.Nil(t, err)
+	require.Equal(t, rotatedAt, *stored.RotatedAt)
+
+	tokens, err := repo.GetAPITokens()
+	require.Nil(t, err)
+	require.Len(t, tokens, 1)
+
+	err = repo.DeleteAPIToken("ci-pipeline")
+	require.Nil(t, err)
+
+	err = repo.DeleteAPIToken("ci-pipeline")
+	require.ErrorIs(t, err, ErrAPITokenNotFound)
+
+	err = repo.UpdateAPITokenHash("ci-pipeline", "my-hash", rotatedAt)
+	require.ErrorIs(t, err, ErrAPITokenNotFound)
+}
@@ -0,0 +1,128 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const auditCollectionName = "auditRecords"
+
+// MongoDBAuditRepo stores audit records in a MongoDB collection
+type MongoDBAuditRepo struct {
+	DBConnection *MongoDBConnection
+}
+
+// NewMongoDBAuditRepo creates a new MongoDBAuditRepo
+func NewMongoDBAuditRepo(dbConnection *MongoDBConnection) *MongoDBAuditRepo {
+	return &MongoDBAuditRepo{DBConnection: dbConnection}
+}
+
+// SetupTTLIndex makes sure that audit records are removed from the collection after the given duration has passed since their creation
+func (m *MongoDBAuditRepo) SetupTTLIndex(duration time.Duration) error {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return fmt.Errorf("could not get collection: %s", err.Error())
+	}
+	defer cancel()
+
+	return SetupTTLIndex(ctx, "time", duration, collection)
+}
+
+// CreateAuditRecord stores the given audit record
+func (m *MongoDBAuditRepo) CreateAuditRecord(record models.AuditRecord) error {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	if _, err := collection.InsertOne(ctx, record); err != nil {
+		return fmt.Errorf("could not store audit record: %w", err)
+	}
+	return nil
+}
+
+// GetAuditRecords returns the audit records matching the given parameters, ordered from newest to oldest
+func (m *MongoDBAuditRepo) GetAuditRecords(params models.GetAuditRecordsParams) (*models.GetAuditRecordsResponse, error) {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	searchOptions := bson.M{}
+	searchOptions = appendFilterAs(searchOptions, params.Service, "service")
+	searchOptions = appendFilterAs(searchOptions, params.Principal, "principal")
+	searchOptions = appendFilterAs(searchOptions, params.Action, "action")
+	searchOptions = appendFilterAs(searchOptions, params.TargetType, "targetType")
+	searchOptions = appendFilterAs(searchOptions, params.Target, "target")
+	searchOptions = appendFilterAs(searchOptions, params.Project, "project")
+	searchOptions = appendFilterAs(searchOptions, params.RequestID, "requestID")
+
+	timeFilter := bson.M{}
+	if params.From != "" {
+		from, err := time.Parse(time.RFC3339, params.From)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from time: %w", err)
+		}
+		timeFilter["$gte"] = from
+	}
+	if params.To != "" {
+		to, err := time.Parse(time.RFC3339, params.To)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to time: %w", err)
+		}
+		timeFilter["$lt"] = to
+	}
+	if len(timeFilter) > 0 {
+		searchOptions["time"] = timeFilter
+	}
+
+	totalCount, err := collection.CountDocuments(ctx, searchOptions)
+	if err != nil {
+		return nil, fmt.Errorf("could not count audit records: %w", err)
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "time", Value: -1}}).SetSkip(params.NextPageKey)
+	if params.PageSize > 0 {
+		findOptions = findOptions.SetLimit(params.PageSize)
+	}
+
+	cur, err := collection.Find(ctx, searchOptions, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve audit records: %w", err)
+	}
+	defer closeCursor(ctx, cur)
+
+	records := []models.AuditRecord{}
+	if err := cur.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("could not decode audit records: %w", err)
+	}
+
+	result := &models.GetAuditRecordsResponse{
+		Records:    records,
+		PageSize:   int64(len(records)),
+		TotalCount: totalCount,
+	}
+	if params.PageSize > 0 && params.PageSize+params.NextPageKey < totalCount {
+		result.NextPageKey = params.PageSize + params.NextPageKey
+	}
+	return result, nil
+}
+
+func (m *MongoDBAuditRepo) getCollectionAndContext() (*mongo.Collection, context.Context, context.CancelFunc, error) {
+	err := m.DBConnection.EnsureDBConnection()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	collection := m.DBConnection.Client.Database(getDatabaseName()).Collection(auditCollectionName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	return collection, ctx, cancel, nil
+}
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMongoDBAuditRepo_GetAuditRecords(t *testing.T) {
+	repo := NewMongoDBAuditRepo(GetMongoDBConnectionInstance())
+
+	now := time.Now().UTC().Truncate(time.Second)
+	records := []models.AuditRecord{
+		{ID: "record-1", Time: now.Add(-2 * time.Hour), Service: "shipyard-controller", Principal: "api-token:1a2b3c4d", Action: "project.create", TargetType: "project", Target: "my-audit-project", Project: "my-audit-project"},
+		{ID: "record-2", Time: now.Add(-time.Hour), Service: "secret-service", Principal: "api-token:1a2b3c4d", Action: "secret.update", TargetType: "secret", Target: "keptn-default/my-secret"},
+		{ID: "record-3", Time: now, Service: "shipyard-controller", Principal: "internal", Action: "project.delete", TargetType: "project", Target: "my-audit-project", Project: "my-audit-project", RequestID: "my-request"},
+	}
+	for _, record := range records {
+		require.Nil(t, repo.CreateAuditRecord(record))
+	}
+
+	res, err := repo.GetAuditRecords(models.GetAuditRecordsParams{})
+	require.Nil(t, err)
+	require.Len(t, res.Records, 3)
+	require.Equal(t, int64(3), res.TotalCount)
+	// the newest record is returned first
+	require.Equal(t, "record-3", res.Records[0].ID)
+
+	res, err = repo.GetAuditRecords(models.GetAuditRecordsParams{Project: "my-audit-project"})
+	require.Nil(t, err)
+	require.Len(t, res.Records, 2)
+
+	res, err = repo.GetAuditRecords(models.GetAuditRecordsParams{Principal: "api-token:1a2b3c4d", TargetType: "secret"})
+	require.Nil(t, err)
+	require.Len(t, res.Records, 1)
+	require.Equal(t, "record-2", res.Records[0].ID)
+
+	res, err = repo.GetAuditRecords(models.GetAuditRecordsParams{RequestID: "my-request"})
+	require.Nil(t, err)
+	require.Len(t, res.Records, 1)
+	require.Equal(t, "record-3", res.Records[0].ID)
+
+	res, err = repo.GetAuditRecords(models.GetAuditRecordsParams{
+		From: now.Add(-90 * time.Minute).Format(time.RFC3339),
+		To:   now.Format(time.RFC3339),
+	})
+	require.Nil(t, err)
+	require.Len(t, res.Records, 1)
+	require.Equal(t, "record-2", res.Records[0].ID)
+
+	res, err = repo.GetAuditRecords(models.GetAuditRecordsParams{PageSize: 2})
+	require.Nil(t, err)
+	require.Len(t, res.Records, 2)
+	require.Equal(t, int64(2), res.NextPageKey)
+
+	res, err = repo.GetAuditRecords(models.GetAuditRecordsParams{PageSize: 2, NextPageKey: 2})
+	require.Nil(t, err)
+	require.Len(t, res.Records, 1)
+	require.Equal(t, "record-1", res.Records[0].ID)
+
+	_, err = repo.GetAuditRecords(models.GetAuditRecordsParams{From: "yesterday"})
+	require.NotNil(t, err)
+}
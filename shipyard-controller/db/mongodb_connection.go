@@ -9,6 +9,7 @@ import (
 
 	keptnmongoutils "github.com/keptn/go-utils/pkg/common/mongoutils"
 	logger "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -25,6 +26,8 @@ const clientConnectionFailed = "failed to connect client to MongoDB: %v"
 // MongoDBConnection takes care of establishing a connection to the mongodb
 type MongoDBConnection struct {
 	Client *mongo.Client
+	// Monitor is notified about the commands sent by the client. It has to be set before the client is created
+	Monitor *event.CommandMonitor
 }
 
 func GetMongoDBConnectionInstance() *MongoDBConnection {
@@ -73,6 +76,9 @@ func (m *MongoDBConnection) connectMongoDBClient() error {
 	clientOptions := options.Client()
 	clientOptions = clientOptions.ApplyURI(connectionString)
 	clientOptions = clientOptions.SetConnectTimeout(30 * time.Second)
+	if m.Monitor != nil {
+		clientOptions = clientOptions.SetMonitor(m.Monitor)
+	}
 	m.Client, err = mongo.NewClient(clientOptions)
 	if err != nil {
 		logger.Errorf(clientCreationFailed, err)
@@ -44,6 +44,45 @@ func (m *MongoDBEventQueueRepo) GetQueuedEvents(timestamp time.Time) ([]models.Q
 	return getQueueItemsFromCollection(collection, ctx, searchOptions)
 }
 
+// ClaimQueuedEvents claims up to 'limit' queued events that should be sent next, and that are not claimed by another owner yet, or whose claim has expired.
+// It returns all events that should be sent next and are currently claimed by the given owner
+func (m *MongoDBEventQueueRepo) ClaimQueuedEvents(timestamp time.Time, owner string, ttl time.Duration, limit int) ([]models.QueueItem, error) {
+	collection, ctx, cancel, err := m.getCollectionAndContext(eventQueueCollectionName)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	searchOptions := bson.M{}
+	searchOptions["timestamp"] = bson.M{
+		"$lte": timeutils.GetKeptnTimeStamp(timestamp),
+	}
+
+	return claimQueueItems(ctx, collection, searchOptions, owner, ttl, limit)
+}
+
+// RenewEventClaims extends the claims on all events claimed by the given owner
+func (m *MongoDBEventQueueRepo) RenewEventClaims(owner string, ttl time.Duration) error {
+	collection, ctx, cancel, err := m.getCollectionAndContext(eventQueueCollectionName)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	return renewQueueItemClaims(ctx, collection, owner, ttl)
+}
+
+// ReleaseClaimedEvents releases all events claimed by the given owner, so they can be claimed by other owners
+func (m *MongoDBEventQueueRepo) ReleaseClaimedEvents(owner string) error {
+	collection, ctx, cancel, err := m.getCollectionAndContext(eventQueueCollectionName)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	return releaseQueueItemClaims(ctx, collection, owner)
+}
+
 func (m *MongoDBEventQueueRepo) QueueEvent(item models.QueueItem) error {
 	collection, ctx, cancel, err := m.getCollectionAndContext(eventQueueCollectionName)
 	if err != nil {
@@ -0,0 +1,145 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const lockCollectionName = "shipyard-controller-locks"
+
+// MongoDBLockRepo stores leases on shared resources in a MongoDB collection.
+// Every document in the collection represents one lock, identified by its key.
+// Expired locks are not deleted, but taken over by the next owner, which ensures that the fencing token of a lock is increasing monotonically.
+type MongoDBLockRepo struct {
+	DBConnection *MongoDBConnection
+}
+
+// NewMongoDBLockRepo creates a new MongoDBLockRepo
+func NewMongoDBLockRepo(dbConnection *MongoDBConnection) *MongoDBLockRepo {
+	return &MongoDBLockRepo{DBConnection: dbConnection}
+}
+
+// Acquire tries to acquire the lock with the given key for the given owner.
+// If the lock is currently held by another owner and has not yet expired, ErrLockHeld is returned
+func (m *MongoDBLockRepo) Acquire(key, owner string, ttl time.Duration) (*models.Lock, error) {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	now := time.Now().UTC()
+
+	// only match the lock if it has expired (or has been released). If the lock does not exist yet, it will be created by the upsert.
+	// If it exists, but is still held, the upsert will fail with a duplicate key error
+	filter := bson.M{
+		"_id":       key,
+		"expiresAt": bson.M{"$lte": now},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"owner":      owner,
+			"acquiredAt": now,
+			"expiresAt":  now.Add(ttl),
+		},
+		"$inc": bson.M{"token": 1},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	res := collection.FindOneAndUpdate(ctx, filter, update, opts)
+	if res.Err() != nil {
+		if mongo.IsDuplicateKeyError(res.Err()) {
+			return nil, ErrLockHeld
+		}
+		return nil, fmt.Errorf("could not acquire lock %s: %w", key, res.Err())
+	}
+
+	lock := &models.Lock{}
+	if err := res.Decode(lock); err != nil {
+		return nil, fmt.Errorf("could not decode lock %s: %w", key, err)
+	}
+	return lock, nil
+}
+
+// Renew extends the lease of the given lock.
+// If the lock has expired and has been taken over by another owner in the meantime, ErrLockLost is returned
+func (m *MongoDBLockRepo) Renew(lock models.Lock, ttl time.Duration) (*models.Lock, error) {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	now := time.Now().UTC()
+
+	filter := bson.M{
+		"_id":       lock.Key,
+		"owner":     lock.Owner,
+		"token":     lock.Token,
+		"expiresAt": bson.M{"$gt": now},
+	}
+	update := bson.M{
+		"$set": bson.M{"expiresAt": now.Add(ttl)},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	res := collection.FindOneAndUpdate(ctx, filter, update, opts)
+	if res.Err() != nil {
+		if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+			return nil, ErrLockLost
+		}
+		return nil, fmt.Errorf("could not renew lock %s: %w", lock.Key, res.Err())
+	}
+
+	renewedLock := &models.Lock{}
+	if err := res.Decode(renewedLock); err != nil {
+		return nil, fmt.Errorf("could not decode lock %s: %w", lock.Key, err)
+	}
+	return renewedLock, nil
+}
+
+// Release releases the given lock, so that it can immediately be acquired by another owner.
+// If the lock has expired and has been taken over by another owner in the meantime, ErrLockLost is returned
+func (m *MongoDBLockRepo) Release(lock models.Lock) error {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	filter := bson.M{
+		"_id":   lock.Key,
+		"owner": lock.Owner,
+		"token": lock.Token,
+	}
+	update := bson.M{
+		"$set": bson.M{"expiresAt": time.Now().UTC()},
+	}
+
+	res, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("could not release lock %s: %w", lock.Key, err)
+	}
+	if res.MatchedCount == 0 {
+		return ErrLockLost
+	}
+	return nil
+}
+
+func (m *MongoDBLockRepo) getCollectionAndContext() (*mongo.Collection, context.Context, context.CancelFunc, error) {
+	err := m.DBConnection.EnsureDBConnection()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	collection := m.DBConnection.Client.Database(getDatabaseName()).Collection(lockCollectionName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	return collection, ctx, cancel, nil
+}
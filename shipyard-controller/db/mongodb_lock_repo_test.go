@@ -0,0 +1,84 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMongoDBLockRepo_AcquireAndRelease(t *testing.T) {
+	repo := NewMongoDBLockRepo(GetMongoDBConnectionInstance())
+
+	lock, err := repo.Acquire("my-lock", "owner-1", time.Minute)
+	require.Nil(t, err)
+	require.Equal(t, "my-lock", lock.Key)
+	require.Equal(t, "owner-1", lock.Owner)
+	require.Equal(t, int64(1), lock.Token)
+
+	// the lock should not be available to anyone else while being held
+	_, err = repo.Acquire("my-lock", "owner-2", time.Minute)
+	require.ErrorIs(t, err, ErrLockHeld)
+
+	// also a second acquisition by the same owner should fail
+	_, err = repo.Acquire("my-lock", "owner-1", time.Minute)
+	require.ErrorIs(t, err, ErrLockHeld)
+
+	err = repo.Release(*lock)
+	require.Nil(t, err)
+
+	// after releasing, the lock can be acquired by someone else, and the fencing token is increased
+	lock2, err := repo.Acquire("my-lock", "owner-2", time.Minute)
+	require.Nil(t, err)
+	require.Equal(t, "owner-2", lock2.Owner)
+	require.Equal(t, int64(2), lock2.Token)
+
+	// releasing a lock that has been taken over by someone else is not possible
+	err = repo.Release(*lock)
+	require.ErrorIs(t, err, ErrLockLost)
+
+	err = repo.Release(*lock2)
+	require.Nil(t, err)
+}
+
+func TestMongoDBLockRepo_ExpiredLockCanBeTakenOver(t *testing.T) {
+	repo := NewMongoDBLockRepo(GetMongoDBConnectionInstance())
+
+	lock, err := repo.Acquire("my-expiring-lock", "owner-1", 100*time.Millisecond)
+	require.Nil(t, err)
+
+	<-time.After(200 * time.Millisecond)
+
+	// an expired lock cannot be renewed anymore
+	_, err = repo.Renew(*lock, time.Minute)
+	require.ErrorIs(t, err, ErrLockLost)
+
+	lock2, err := repo.Acquire("my-expiring-lock", "owner-2", time.Minute)
+	require.Nil(t, err)
+	require.Equal(t, "owner-2", lock2.Owner)
+	require.Greater(t, lock2.Token, lock.Token)
+
+	err = repo.Release(*lock2)
+	require.Nil(t, err)
+}
+
+func TestMongoDBLockRepo_Renew(t *testing.T) {
+	repo := NewMongoDBLockRepo(GetMongoDBConnectionInstance())
+
+	lock, err := repo.Acquire("my-renewed-lock", "owner-1", 500*time.Millisecond)
+	require.Nil(t, err)
+
+	renewedLock, err := repo.Renew(*lock, time.Minute)
+	require.Nil(t, err)
+	require.Equal(t, lock.Token, renewedLock.Token)
+	require.True(t, renewedLock.ExpiresAt.After(lock.ExpiresAt))
+
+	<-time.After(time.Second)
+
+	// the renewed lease is still valid
+	_, err = repo.Acquire("my-renewed-lock", "owner-2", time.Minute)
+	require.ErrorIs(t, err, ErrLockHeld)
+
+	err = repo.Release(*renewedLock)
+	require.Nil(t, err)
+}
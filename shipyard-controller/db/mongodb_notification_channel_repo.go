@@ -0,0 +1,130 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const notificationChannelCollectionName = "notificationChannels"
+
+// MongoDBNotificationChannelRepo stores the notification channels of projects in a MongoDB collection
+type MongoDBNotificationChannelRepo struct {
+	DBConnection *MongoDBConnection
+}
+
+// NewMongoDBNotificationChannelRepo creates a new MongoDBNotificationChannelRepo
+func NewMongoDBNotificationChannelRepo(dbConnection *MongoDBConnection) *MongoDBNotificationChannelRepo {
+	return &MongoDBNotificationChannelRepo{DBConnection: dbConnection}
+}
+
+// CreateNotificationChannel stores a new notification channel
+func (m *MongoDBNotificationChannelRepo) CreateNotificationChannel(channel models.NotificationChannel) error {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	if _, err := collection.InsertOne(ctx, channel); err != nil {
+		return fmt.Errorf("could not store notification channel %s: %w", channel.ID, err)
+	}
+	return nil
+}
+
+// GetNotificationChannels returns all notification channels of the given project, ordered by their creation date
+func (m *MongoDBNotificationChannelRepo) GetNotificationChannels(project string) ([]models.NotificationChannel, error) {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	cur, err := collection.Find(ctx, bson.M{"project": project}, options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve notification channels: %w", err)
+	}
+	defer closeCursor(ctx, cur)
+
+	channels := []models.NotificationChannel{}
+	if err := cur.All(ctx, &channels); err != nil {
+		return nil, fmt.Errorf("could not decode notification channels: %w", err)
+	}
+	return channels, nil
+}
+
+// GetNotificationChannel returns the notification channel with the given ID. If no channel is found in the given project, ErrNotificationChannelNotFound is returned
+func (m *MongoDBNotificationChannelRepo) GetNotificationChannel(project, id string) (*models.NotificationChannel, error) {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	res := collection.FindOne(ctx, bson.M{"_id": id, "project": project})
+	if res.Err() != nil {
+		if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+			return nil, ErrNotificationChannelNotFound
+		}
+		return nil, fmt.Errorf("could not retrieve notification channel %s: %w", id, res.Err())
+	}
+
+	channel := &models.NotificationChannel{}
+	if err := res.Decode(channel); err != nil {
+		return nil, fmt.Errorf("could not decode notification channel %s: %w", id, err)
+	}
+	return channel, nil
+}
+
+// UpdateNotificationChannel replaces the stored notification channel with the given one. If no channel is found, ErrNotificationChannelNotFound is returned
+func (m *MongoDBNotificationChannelRepo) UpdateNotificationChannel(channel models.NotificationChannel) error {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	res, err := collection.ReplaceOne(ctx, bson.M{"_id": channel.ID, "project": channel.Project}, channel)
+	if err != nil {
+		return fmt.Errorf("could not update notification channel %s: %w", channel.ID, err)
+	}
+	if res.MatchedCount == 0 {
+		return ErrNotificationChannelNotFound
+	}
+	return nil
+}
+
+// DeleteNotificationChannel deletes the notification channel with the given ID. If no channel is found in the given project, ErrNotificationChannelNotFound is returned
+func (m *MongoDBNotificationChannelRepo) DeleteNotificationChannel(project, id string) error {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	res, err := collection.DeleteOne(ctx, bson.M{"_id": id, "project": project})
+	if err != nil {
+		return fmt.Errorf("could not delete notification channel %s: %w", id, err)
+	}
+	if res.DeletedCount == 0 {
+		return ErrNotificationChannelNotFound
+	}
+	return nil
+}
+
+func (m *MongoDBNotificationChannelRepo) getCollectionAndContext() (*mongo.Collection, context.Context, context.CancelFunc, error) {
+	err := m.DBConnection.EnsureDBConnection()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	collection := m.DBConnection.Client.Database(getDatabaseName()).Collection(notificationChannelCollectionName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	return collection, ctx, cancel, nil
+}
@@ -0,0 +1,57 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMongoDBNotificationChannelRepo_CRUD(t *testing.T) {
+	repo := NewMongoDBNotificationChannelRepo(GetMongoDBConnectionInstance())
+
+	channel := models.NotificationChannel{
+		ID:        "my-channel",
+		Project:   "my-project",
+		Name:      "team-chat",
+		Type:      models.NotificationChannelSlack,
+		URL:       "https://hooks.slack.com/services/my-hook",
+		Filter:    models.NotificationFilter{Stages: []string{"production"}},
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+	err := repo.CreateNotificationChannel(channel)
+	require.Nil(t, err)
+
+	channels, err := repo.GetNotificationChannels("my-project")
+	require.Nil(t, err)
+	require.Len(t, channels, 1)
+
+	channels, err = repo.GetNotificationChannels("other-project")
+	require.Nil(t, err)
+	require.Empty(t, channels)
+
+	// channels can only be retrieved via the project they belong to
+	_, err = repo.GetNotificationChannel("other-project", "my-channel")
+	require.ErrorIs(t, err, ErrNotificationChannelNotFound)
+
+	channel.Filter.Stages = []string{"staging", "production"}
+	err = repo.UpdateNotificationChannel(channel)
+	require.Nil(t, err)
+
+	stored, err := repo.GetNotificationChannel("my-project", "my-channel")
+	require.Nil(t, err)
+	require.Equal(t, []string{"staging", "production"}, stored.Filter.Stages)
+
+	err = repo.DeleteNotificationChannel("other-project", "my-channel")
+	require.ErrorIs(t, err, ErrNotificationChannelNotFound)
+
+	err = repo.DeleteNotificationChannel("my-project", "my-channel")
+	require.Nil(t, err)
+
+	_, err = repo.GetNotificationChannel("my-project", "my-channel")
+	require.ErrorIs(t, err, ErrNotificationChannelNotFound)
+
+	err = repo.UpdateNotificationChannel(channel)
+	require.ErrorIs(t, err, ErrNotificationChannelNotFound)
+}
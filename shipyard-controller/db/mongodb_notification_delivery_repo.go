@@ -0,0 +1,174 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const notificationDeliveryCollectionName = "notificationDeliveries"
+
+// MongoDBNotificationDeliveryRepo stores the deliveries of notifications in a MongoDB collection
+type MongoDBNotificationDeliveryRepo struct {
+	DBConnection *MongoDBConnection
+}
+
+// NewMongoDBNotificationDeliveryRepo creates a new MongoDBNotificationDeliveryRepo
+func NewMongoDBNotificationDeliveryRepo(dbConnection *MongoDBConnection) *MongoDBNotificationDeliveryRepo {
+	return &MongoDBNotificationDeliveryRepo{DBConnection: dbConnection}
+}
+
+// SetupTTLIndex makes sure that deliveries are removed from the collection after the given duration has passed since their creation
+func (m *MongoDBNotificationDeliveryRepo) SetupTTLIndex(duration time.Duration) error {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return fmt.Errorf("could not get collection: %s", err.Error())
+	}
+	defer cancel()
+
+	return SetupTTLIndex(ctx, "createdAt", duration, collection)
+}
+
+// CreateNotificationDeliveries stores the given deliveries
+func (m *MongoDBNotificationDeliveryRepo) CreateNotificationDeliveries(deliveries []models.NotificationDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	inserts := []interface{}{}
+	for _, delivery := range deliveries {
+		inserts = append(inserts, delivery)
+	}
+	if _, err := collection.InsertMany(ctx, inserts); err != nil {
+		return fmt.Errorf("could not store notification deliveries: %w", err)
+	}
+	return nil
+}
+
+// GetNotificationDeliveries returns the deliveries matching the given parameters, ordered from newest to oldest
+func (m *MongoDBNotificationDeliveryRepo) GetNotificationDeliveries(params models.GetNotificationDeliveriesParams) (*models.GetNotificationDeliveriesResponse, error) {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	searchOptions := bson.M{"project": params.Project}
+	searchOptions = appendFilterAs(searchOptions, params.ChannelID, "channelId")
+	searchOptions = appendFilterAs(searchOptions, params.KeptnContext, "notification.keptnContext")
+	searchOptions = appendFilterAs(searchOptions, params.Status, "status")
+
+	totalCount, err := collection.CountDocuments(ctx, searchOptions)
+	if err != nil {
+		return nil, fmt.Errorf("could not count notification deliveries: %w", err)
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}}).SetSkip(params.NextPageKey)
+	if params.PageSize > 0 {
+		findOptions = findOptions.SetLimit(params.PageSize)
+	}
+
+	deliveries, err := m.findDeliveries(ctx, collection, searchOptions, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.GetNotificationDeliveriesResponse{
+		Deliveries: deliveries,
+		PageSize:   int64(len(deliveries)),
+		TotalCount: totalCount,
+	}
+	if params.PageSize > 0 && params.PageSize+params.NextPageKey < totalCount {
+		result.NextPageKey = params.PageSize + params.NextPageKey
+	}
+	return result, nil
+}
+
+// UpdateNotificationDelivery replaces the stored delivery with the given one
+func (m *MongoDBNotificationDeliveryRepo) UpdateNotificationDelivery(delivery models.NotificationDelivery) error {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	if _, err := collection.ReplaceOne(ctx, bson.M{"_id": delivery.ID}, delivery); err != nil {
+		return fmt.Errorf("could not update notification delivery %s: %w", delivery.ID, err)
+	}
+	return nil
+}
+
+// GetDueNotificationDeliveries returns all pending deliveries whose next attempt is due at the given point in time
+func (m *MongoDBNotificationDeliveryRepo) GetDueNotificationDeliveries(dueAt time.Time) ([]models.NotificationDelivery, error) {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	filter := bson.M{
+		"status":        models.NotificationDeliveryPending,
+		"nextAttemptAt": bson.M{"$lte": dueAt},
+	}
+	return m.findDeliveries(ctx, collection, filter, options.Find().SetSort(bson.D{{Key: "nextAttemptAt", Value: 1}}))
+}
+
+// ClaimNotificationDelivery postpones the next attempt of the delivery to the given point in time, so that it is not picked up by another replica while it is being sent.
+// If the sending replica crashes, the delivery is retried once the claim has expired.
+// The update is only applied if the next attempt of the stored delivery has not been changed in the meantime. If it has, ErrNotificationDeliveryClaimed is returned
+func (m *MongoDBNotificationDeliveryRepo) ClaimNotificationDelivery(delivery models.NotificationDelivery, claimedUntil time.Time) error {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	filter := bson.M{
+		"_id":           delivery.ID,
+		"status":        models.NotificationDeliveryPending,
+		"nextAttemptAt": delivery.NextAttemptAt,
+	}
+	res, err := collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"nextAttemptAt": claimedUntil}})
+	if err != nil {
+		return fmt.Errorf("could not claim notification delivery %s: %w", delivery.ID, err)
+	}
+	if res.MatchedCount == 0 {
+		return ErrNotificationDeliveryClaimed
+	}
+	return nil
+}
+
+func (m *MongoDBNotificationDeliveryRepo) findDeliveries(ctx context.Context, collection *mongo.Collection, filter bson.M, opts *options.FindOptions) ([]models.NotificationDelivery, error) {
+	cur, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve notification deliveries: %w", err)
+	}
+	defer closeCursor(ctx, cur)
+
+	deliveries := []models.NotificationDelivery{}
+	if err := cur.All(ctx, &deliveries); err != nil {
+		return nil, fmt.Errorf("could not decode notification deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+func (m *MongoDBNotificationDeliveryRepo) getCollectionAndContext() (*mongo.Collection, context.Context, context.CancelFunc, error) {
+	err := m.DBConnection.EnsureDBConnection()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	collection := m.DBConnection.Client.Database(getDatabaseName()).Collection(notificationDeliveryCollectionName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	return collection, ctx, cancel, nil
+}
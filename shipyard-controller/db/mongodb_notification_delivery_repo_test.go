@@ -0,0 +1,89 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMongoDBNotificationDeliveryRepo_GetNotificationDeliveries(t *testing.T) {
+	repo := NewMongoDBNotificationDeliveryRepo(GetMongoDBConnectionInstance())
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	err := repo.CreateNotificationDeliveries([]models.NotificationDelivery{
+		{ID: "delivery-1", ChannelID: "channel-1", Project: "my-delivery-project", Status: models.NotificationDeliveryDelivered, Notification: models.Notification{KeptnContext: "context-1"}, CreatedAt: now.Add(-2 * time.Minute)},
+		{ID: "delivery-2", ChannelID: "channel-2", Project: "my-delivery-project", Status: models.NotificationDeliveryFailed, Notification: models.Notification{KeptnContext: "context-1"}, CreatedAt: now.Add(-time.Minute)},
+		{ID: "delivery-3", ChannelID: "channel-1", Project: "my-delivery-project", Status: models.NotificationDeliveryPending, Notification: models.Notification{KeptnContext: "context-2"}, CreatedAt: now},
+		{ID: "delivery-4", ChannelID: "channel-3", Project: "other-delivery-project", Status: models.NotificationDeliveryPending, CreatedAt: now},
+	})
+	require.Nil(t, err)
+
+	res, err := repo.GetNotificationDeliveries(models.GetNotificationDeliveriesParams{Project: "my-delivery-project"})
+	require.Nil(t, err)
+	require.Len(t, res.Deliveries, 3)
+	require.Equal(t, int64(3), res.TotalCount)
+	// the newest delivery is returned first
+	require.Equal(t, "delivery-3", res.Deliveries[0].ID)
+
+	res, err = repo.GetNotificationDeliveries(models.GetNotificationDeliveriesParams{Project: "my-delivery-project", ChannelID: "channel-1"})
+	require.Nil(t, err)
+	require.Len(t, res.Deliveries, 2)
+
+	res, err = repo.GetNotificationDeliveries(models.GetNotificationDeliveriesParams{Project: "my-delivery-project", KeptnContext: "context-1", Status: models.NotificationDeliveryFailed})
+	require.Nil(t, err)
+	require.Len(t, res.Deliveries, 1)
+	require.Equal(t, "delivery-2", res.Deliveries[0].ID)
+
+	res, err = repo.GetNotificationDeliveries(models.GetNotificationDeliveriesParams{Project: "my-delivery-project", PageSize: 2})
+	require.Nil(t, err)
+	require.Len(t, res.Deliveries, 2)
+	require.Equal(t, int64(2), res.NextPageKey)
+
+	res, err = repo.GetNotificationDeliveries(models.GetNotificationDeliveriesParams{Project: "my-delivery-project", PageSize: 2, NextPageKey: 2})
+	require.Nil(t, err)
+	require.Len(t, res.Deliveries, 1)
+	require.Equal(t, "delivery-1", res.Deliveries[0].ID)
+	require.Equal(t, int64(0), res.NextPageKey)
+}
+
+func TestMongoDBNotificationDeliveryRepo_ClaimNotificationDelivery(t *testing.T) {
+	repo := NewMongoDBNotificationDeliveryRepo(GetMongoDBConnectionInstance())
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	dueAt := now.Add(-time.Minute)
+	notDueAt := now.Add(time.Hour)
+
+	err := repo.CreateNotificationDeliveries([]models.NotificationDelivery{
+		{ID: "due-delivery", Project: "my-claim-project", Status: models.NotificationDeliveryPending, NextAttemptAt: &dueAt},
+		{ID: "future-delivery", Project: "my-claim-project", Status: models.NotificationDeliveryPending, NextAttemptAt: &notDueAt},
+		{ID: "delivered-delivery", Project: "my-claim-project", Status: models.NotificationDeliveryDelivered},
+	})
+	require.Nil(t, err)
+
+	dueDeliveries, err := repo.GetDueNotificationDeliveries(now)
+	require.Nil(t, err)
+	require.Len(t, dueDeliveries, 1)
+	require.Equal(t, "due-delivery", dueDeliveries[0].ID)
+
+	err = repo.ClaimNotificationDelivery(dueDeliveries[0], now.Add(time.Minute))
+	require.Nil(t, err)
+
+	// the same attempt cannot be claimed twice
+	err = repo.ClaimNotificationDelivery(dueDeliveries[0], now.Add(time.Minute))
+	require.ErrorIs(t, err, ErrNotificationDeliveryClaimed)
+
+	dueDeliveries, err = repo.GetDueNotificationDeliveries(now)
+	require.Nil(t, err)
+	require.Empty(t, dueDeliveries)
+
+	delivery := models.NotificationDelivery{ID: "due-delivery", Project: "my-claim-project", Status: models.NotificationDeliveryDelivered, Attempts: 1, DeliveredAt: &now}
+	err = repo.UpdateNotificationDelivery(delivery)
+	require.Nil(t, err)
+
+	// delivered notifications are not picked up anymore, even if their claim has expired
+	dueDeliveries, err = repo.GetDueNotificationDeliveries(now.Add(2 * time.Minute))
+	require.Nil(t, err)
+	require.Empty(t, dueDeliveries)
+}
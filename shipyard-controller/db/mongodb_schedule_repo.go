@@ -0,0 +1,185 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const scheduleCollectionName = "schedules"
+
+// MongoDBScheduleRepo stores the schedules of sequences in a MongoDB collection
+type MongoDBScheduleRepo struct {
+	DBConnection *MongoDBConnection
+}
+
+// NewMongoDBScheduleRepo creates a new MongoDBScheduleRepo
+func NewMongoDBScheduleRepo(dbConnection *MongoDBConnection) *MongoDBScheduleRepo {
+	return &MongoDBScheduleRepo{DBConnection: dbConnection}
+}
+
+// CreateSchedule stores a new schedule
+func (m *MongoDBScheduleRepo) CreateSchedule(schedule models.Schedule) error {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	if _, err := collection.InsertOne(ctx, schedule); err != nil {
+		return fmt.Errorf("could not store schedule %s: %w", schedule.ID, err)
+	}
+	return nil
+}
+
+// GetSchedules returns all schedules matching the given filter, ordered by their creation date
+func (m *MongoDBScheduleRepo) GetSchedules(filter models.GetSchedulesParams) ([]models.Schedule, error) {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	searchOptions := bson.M{}
+	searchOptions = appendFilterAs(searchOptions, filter.Project, "project")
+	searchOptions = appendFilterAs(searchOptions, filter.Stage, "stage")
+	searchOptions = appendFilterAs(searchOptions, filter.Service, "service")
+
+	return m.findSchedules(ctx, collection, searchOptions, options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}}))
+}
+
+// GetSchedule returns the schedule with the given ID. If no schedule is found, ErrScheduleNotFound is returned
+func (m *MongoDBScheduleRepo) GetSchedule(id string) (*models.Schedule, error) {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	res := collection.FindOne(ctx, bson.M{"_id": id})
+	if res.Err() != nil {
+		if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+			return nil, ErrScheduleNotFound
+		}
+		return nil, fmt.Errorf("could not retrieve schedule %s: %w", id, res.Err())
+	}
+
+	schedule := &models.Schedule{}
+	if err := res.Decode(schedule); err != nil {
+		return nil, fmt.Errorf("could not decode schedule %s: %w", id, err)
+	}
+	return schedule, nil
+}
+
+// UpdateSchedule replaces the stored schedule with the given one. If no schedule is found, ErrScheduleNotFound is returned
+func (m *MongoDBScheduleRepo) UpdateSchedule(schedule models.Schedule) error {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	res, err := collection.ReplaceOne(ctx, bson.M{"_id": schedule.ID}, schedule)
+	if err != nil {
+		return fmt.Errorf("could not update schedule %s: %w", schedule.ID, err)
+	}
+	if res.MatchedCount == 0 {
+		return ErrScheduleNotFound
+	}
+	return nil
+}
+
+// DeleteSchedule deletes the schedule with the given ID. If no schedule is found, ErrScheduleNotFound is returned
+func (m *MongoDBScheduleRepo) DeleteSchedule(id string) error {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	res, err := collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("could not delete schedule %s: %w", id, err)
+	}
+	if res.DeletedCount == 0 {
+		return ErrScheduleNotFound
+	}
+	return nil
+}
+
+// GetDueSchedules returns all schedules whose next run is due at the given point in time
+func (m *MongoDBScheduleRepo) GetDueSchedules(dueAt time.Time) ([]models.Schedule, error) {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	return m.findSchedules(ctx, collection, bson.M{"nextRunAt": bson.M{"$lte": dueAt}}, options.Find().SetSort(bson.D{{Key: "nextRunAt", Value: 1}}))
+}
+
+// ClaimScheduleRun marks the currently due run of the schedule as executed, and sets the time of its next run.
+// The update is only applied if the next run of the stored schedule has not been changed in the meantime. This ensures that each run is only executed once,
+// even if multiple replicas are processing the due schedules. If the run has already been claimed, ErrScheduleRunClaimed is returned
+func (m *MongoDBScheduleRepo) ClaimScheduleRun(schedule models.Schedule, nextRunAt *time.Time, keptnContext string) error {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	filter := bson.M{
+		"_id":       schedule.ID,
+		"nextRunAt": schedule.NextRunAt,
+	}
+	set := bson.M{
+		"lastRunAt":        time.Now().UTC(),
+		"lastKeptnContext": keptnContext,
+	}
+	update := bson.M{"$set": set}
+	if nextRunAt != nil {
+		set["nextRunAt"] = nextRunAt
+	} else {
+		update["$unset"] = bson.M{"nextRunAt": ""}
+	}
+
+	res, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("could not claim run of schedule %s: %w", schedule.ID, err)
+	}
+	if res.MatchedCount == 0 {
+		return ErrScheduleRunClaimed
+	}
+	return nil
+}
+
+func (m *MongoDBScheduleRepo) findSchedules(ctx context.Context, collection *mongo.Collection, filter bson.M, opts *options.FindOptions) ([]models.Schedule, error) {
+	cur, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve schedules: %w", err)
+	}
+	defer cur.Close(ctx)
+
+	schedules := []models.Schedule{}
+	if err := cur.All(ctx, &schedules); err != nil {
+		return nil, fmt.Errorf("could not decode schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+func (m *MongoDBScheduleRepo) getCollectionAndContext() (*mongo.Collection, context.Context, context.CancelFunc, error) {
+	err := m.DBConnection.EnsureDBConnection()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	collection := m.DBConnection.Client.Database(getDatabaseName()).Collection(scheduleCollectionName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	return collection, ctx, cancel, nil
+}
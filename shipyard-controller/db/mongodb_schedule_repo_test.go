@@ -0,0 +1,101 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMongoDBScheduleRepo_CRUD(t *testing.T) {
+	repo := NewMongoDBScheduleRepo(GetMongoDBConnectionInstance())
+
+	schedule := models.Schedule{
+		ID:            "my-schedule",
+		Project:       "my-project",
+		Stage:         "dev",
+		Service:       "my-service",
+		Sequence:      "evaluation",
+		Cron:          "0 2 * * *",
+		OverlapPolicy: models.ScheduleOverlapSkip,
+		CreatedAt:     time.Now().UTC().Truncate(time.Millisecond),
+	}
+	err := repo.CreateSchedule(schedule)
+	require.Nil(t, err)
+
+	schedules, err := repo.GetSchedules(models.GetSchedulesParams{Project: "my-project"})
+	require.Nil(t, err)
+	require.Len(t, schedules, 1)
+
+	schedules, err = repo.GetSchedules(models.GetSchedulesParams{Project: "other-project"})
+	require.Nil(t, err)
+	require.Empty(t, schedules)
+
+	schedule.Cron = "0 3 * * *"
+	err = repo.UpdateSchedule(schedule)
+	require.Nil(t, err)
+
+	stored, err := repo.GetSchedule("my-schedule")
+	require.Nil(t, err)
+	require.Equal(t, "0 3 * * *", stored.Cron)
+
+	err = repo.DeleteSchedule("my-schedule")
+	require.Nil(t, err)
+
+	_, err = repo.GetSchedule("my-schedule")
+	require.ErrorIs(t, err, ErrScheduleNotFound)
+
+	err = repo.DeleteSchedule("my-schedule")
+	require.ErrorIs(t, err, ErrScheduleNotFound)
+
+	err = repo.UpdateSchedule(schedule)
+	require.ErrorIs(t, err, ErrScheduleNotFound)
+}
+
+func TestMongoDBScheduleRepo_ClaimScheduleRun(t *testing.T) {
+	repo := NewMongoDBScheduleRepo(GetMongoDBConnectionInstance())
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	dueAt := now.Add(-time.Minute)
+	notDueAt := now.Add(time.Hour)
+
+	err := repo.CreateSchedule(models.Schedule{ID: "due-schedule", Project: "my-claim-project", NextRunAt: &dueAt})
+	require.Nil(t, err)
+	err = repo.CreateSchedule(models.Schedule{ID: "future-schedule", Project: "my-claim-project", NextRunAt: &notDueAt})
+	require.Nil(t, err)
+	err = repo.CreateSchedule(models.Schedule{ID: "finished-schedule", Project: "my-claim-project"})
+	require.Nil(t, err)
+
+	dueSchedules, err := repo.GetDueSchedules(now)
+	require.Nil(t, err)
+	require.Len(t, dueSchedules, 1)
+	require.Equal(t, "due-schedule", dueSchedules[0].ID)
+
+	nextRunAt := now.Add(24 * time.Hour)
+	err = repo.ClaimScheduleRun(dueSchedules[0], &nextRunAt, "my-context")
+	require.Nil(t, err)
+
+	// the same run cannot be claimed twice
+	err = repo.ClaimScheduleRun(dueSchedules[0], &nextRunAt, "my-other-context")
+	require.ErrorIs(t, err, ErrScheduleRunClaimed)
+
+	stored, err := repo.GetSchedule("due-schedule")
+	require.Nil(t, err)
+	require.Equal(t, nextRunAt, stored.NextRunAt.UTC())
+	require.Equal(t, "my-context", stored.LastKeptnContext)
+	require.NotNil(t, stored.LastRunAt)
+
+	// claiming the last run of a schedule removes its next run
+	err = repo.ClaimScheduleRun(*stored, nil, "my-last-context")
+	require.Nil(t, err)
+
+	stored, err = repo.GetSchedule("due-schedule")
+	require.Nil(t, err)
+	require.Nil(t, stored.NextRunAt)
+
+	dueSchedules, err = repo.GetDueSchedules(now.Add(48 * time.Hour))
+	require.Nil(t, err)
+	require.Len(t, dueSchedules, 1)
+	require.Equal(t, "future-schedule", dueSchedules[0].ID)
+}
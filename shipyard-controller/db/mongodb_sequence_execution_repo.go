@@ -25,6 +25,12 @@ const eventQueueSequenceStateCollectionName = "shipyard-controller-event-queue-s
 var ErrProjectNameMustNotBeEmpty = errors.New("project name must not be empty")
 var ErrSequenceIDMustNotBeEmpty = errors.New("sequence ID must not be empty")
 
+// ErrStaleFencingToken indicates that a sequence execution has already been written by the holder of a newer lock on the sequence
+var ErrStaleFencingToken = errors.New("sequence execution has already been updated by the holder of a newer lock")
+
+// fencingTokenField stores the highest fencing token of the locks that have been held while writing a sequence execution
+const fencingTokenField = "fencingToken"
+
 type SequenceExecutionRepoOpt func(repo *MongoDBSequenceExecutionRepo)
 
 func WithSequenceExecutionModelTransformer(transformer sequence_execution.ModelTransformer) SequenceExecutionRepoOpt {
@@ -86,6 +92,52 @@ func (mdbrepo *MongoDBSequenceExecutionRepo) Get(filter models.SequenceExecution
 	return result, nil
 }
 
+// GetPaginated returns a page of the matching sequence executions, ordered from the newest to the oldest one, as well as the total number of matching sequence executions
+func (mdbrepo *MongoDBSequenceExecutionRepo) GetPaginated(filter models.SequenceExecutionFilter, nextPageKey int64, pageSize int64) ([]models.SequenceExecution, int64, error) {
+	collection, ctx, cancel, err := mdbrepo.getSequenceExecutionStateCollection(filter.Scope.Project)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cancel()
+
+	searchOptions := mdbrepo.getSearchOptions(filter)
+
+	totalCount, err := collection.CountDocuments(ctx, searchOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error counting elements in sequence execution collection: %w", err)
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "triggeredAt", Value: -1}}).SetSkip(nextPageKey)
+	if pageSize > 0 {
+		findOptions = findOptions.SetLimit(pageSize)
+	}
+
+	cur, err := collection.Find(ctx, searchOptions, findOptions)
+	defer closeCursor(ctx, cur)
+
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, 0, err
+	}
+
+	result := []models.SequenceExecution{}
+
+	for cur.Next(ctx) {
+		var outInterface interface{}
+		if err := cur.Decode(&outInterface); err != nil {
+			log.Errorf("Could not decode sequenceExecution: %v", err)
+			continue
+		}
+		sequenceExecution, err := mdbrepo.transformBSONToSequenceExecution(outInterface)
+		if err != nil {
+			log.Errorf("Could not decode sequenceExecution: %v", err)
+			continue
+		}
+		result = append(result, *sequenceExecution)
+	}
+
+	return result, totalCount, nil
+}
+
 // GetByTriggeredID searches for a sequence execution with the given triggeredID.
 func (mdbrepo *MongoDBSequenceExecutionRepo) GetByTriggeredID(project, triggeredID string) (*models.SequenceExecution, error) {
 	collection, ctx, cancel, err := mdbrepo.getSequenceExecutionStateCollection(project)
@@ -147,18 +199,22 @@ func (mdbrepo *MongoDBSequenceExecutionRepo) Upsert(item models.SequenceExecutio
 		internalDBItem = item
 	}
 
-	filter := bson.D{{"_id", item.ID}}
+	filter := withFencingToken(bson.D{{"_id", item.ID}}, item.FencingToken)
 
 	if upsertOptions != nil && upsertOptions.Replace {
-		_, err = collection.ReplaceOne(ctx, filter, internalDBItem)
-	} else {
-		update := bson.D{{"$set", internalDBItem}}
-		_, err = collection.UpdateOne(ctx, filter, update, opts)
+		replacement, err := withFencingTokenField(internalDBItem, item.FencingToken)
+		if err != nil {
+			return err
+		}
+		_, err = collection.ReplaceOne(ctx, filter, replacement)
+		return fencingError(err, item.FencingToken)
 	}
-	if err != nil {
-		return err
+	update := bson.D{{"$set", internalDBItem}}
+	if item.FencingToken > 0 {
+		update = append(update, bson.E{Key: "$max", Value: bson.M{fencingTokenField: item.FencingToken}})
 	}
-	return nil
+	_, err = collection.UpdateOne(ctx, filter, update, opts)
+	return fencingError(err, item.FencingToken)
 }
 
 // AppendTaskEvent adds an event that is relevant to the execution of the current task.
@@ -179,7 +235,7 @@ func (mdbrepo *MongoDBSequenceExecutionRepo) AppendTaskEvent(taskSequence models
 	// return the resulting document after the update
 	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
 
-	filter := bson.D{{"_id", taskSequence.ID}}
+	filter := withFencingToken(bson.D{{"_id", taskSequence.ID}}, taskSequence.FencingToken)
 
 	// by using the $push operator in the FindOneAndUpdate function, we ensure that we follow an append-only approach to this property,
 	// since this is the one property that can potentially be updated by multiple threads handling .finished/.started events for the same task
@@ -191,10 +247,18 @@ func (mdbrepo *MongoDBSequenceExecutionRepo) AppendTaskEvent(taskSequence models
 		eventItem = event
 	}
 	update := bson.M{"$push": bson.M{"status.currentTask.events": eventItem}}
+	if isParallelTaskEvent(taskSequence, event) {
+		// the parallel task is looked up by its triggeredID as part of the update, since the parallel tasks may have changed in the meantime
+		update = bson.M{"$push": bson.M{"status.parallelTasks.$[task].events": eventItem}}
+		opts.SetArrayFilters(options.ArrayFilters{Filters: []interface{}{bson.M{"task.triggeredID": event.TriggeredID}}})
+	}
+	if taskSequence.FencingToken > 0 {
+		update["$max"] = bson.M{fencingTokenField: taskSequence.FencingToken}
+	}
 
 	res := collection.FindOneAndUpdate(ctx, filter, update, opts)
 	if res.Err() != nil {
-		return nil, err
+		return nil, fencingError(res.Err(), taskSequence.FencingToken)
 	}
 
 	outInterface := map[string]interface{}{}
@@ -209,6 +273,49 @@ func (mdbrepo *MongoDBSequenceExecutionRepo) AppendTaskEvent(taskSequence models
 	return sequenceExecution, nil
 }
 
+// withFencingToken restricts the given filter to sequence executions that have not been written with a newer fencing token than the given one.
+// Since the sequence executions are upserted, a write with an outdated token fails with a duplicate key error.
+// If no fencing token is given, the filter is returned unchanged
+func withFencingToken(filter bson.D, fencingToken int64) bson.D {
+	if fencingToken == 0 {
+		return filter
+	}
+	return append(filter, bson.E{Key: "$or", Value: bson.A{
+		bson.M{fencingTokenField: bson.M{"$exists": false}},
+		bson.M{fencingTokenField: bson.M{"$lte": fencingToken}},
+	}})
+}
+
+// withFencingTokenField adds the given fencing token to a replacement of a sequence execution
+func withFencingTokenField(item interface{}, fencingToken int64) (interface{}, error) {
+	if fencingToken == 0 {
+		return item, nil
+	}
+	data, err := bson.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	replacement := bson.M{}
+	if err := bson.Unmarshal(data, &replacement); err != nil {
+		return nil, err
+	}
+	replacement[fencingTokenField] = fencingToken
+	return replacement, nil
+}
+
+// fencingError translates the duplicate key error caused by a write with an outdated fencing token into ErrStaleFencingToken
+func fencingError(err error, fencingToken int64) error {
+	if err != nil && fencingToken > 0 && mongo.IsDuplicateKeyError(err) {
+		return ErrStaleFencingToken
+	}
+	return err
+}
+
+// isParallelTaskEvent determines whether the event belongs to one of the tasks running in parallel to the current task
+func isParallelTaskEvent(taskSequence models.SequenceExecution, event models.TaskEvent) bool {
+	return event.TriggeredID != "" && event.TriggeredID != taskSequence.Status.CurrentTask.TriggeredID
+}
+
 // UpdateStatus is used to update the overall state of the sequence, e.g. when it was paused via the API.
 // This will not update a complete sequence execution, but just the attributes representing the overall state of the sequence
 func (mdbrepo *MongoDBSequenceExecutionRepo) UpdateStatus(taskSequence models.SequenceExecution) (*models.SequenceExecution, error) {
@@ -227,16 +334,24 @@ func (mdbrepo *MongoDBSequenceExecutionRepo) UpdateStatus(taskSequence models.Se
 	// return the resulting document after the update
 	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
 
-	filter := bson.D{{"_id", taskSequence.ID}}
+	filter := withFencingToken(bson.D{{"_id", taskSequence.ID}}, taskSequence.FencingToken)
 
-	update := bson.M{"$set": bson.M{
+	set := bson.M{
 		"status.state":            taskSequence.Status.State,
 		"status.stateBeforePause": taskSequence.Status.StateBeforePause,
-	}}
+		"status.freezeOverridden": taskSequence.Status.FreezeOverridden,
+	}
+	if !taskSequence.Status.StartedAt.IsZero() {
+		set["status.startedAt"] = taskSequence.Status.StartedAt
+	}
+	update := bson.M{"$set": set}
+	if taskSequence.FencingToken > 0 {
+		update["$max"] = bson.M{fencingTokenField: taskSequence.FencingToken}
+	}
 
 	res := collection.FindOneAndUpdate(ctx, filter, update, opts)
 	if res.Err() != nil {
-		return nil, err
+		return nil, fencingError(res.Err(), taskSequence.FencingToken)
 	}
 
 	outInterface := map[string]interface{}{}
@@ -346,11 +461,26 @@ func (mdbrepo *MongoDBSequenceExecutionRepo) getSearchOptions(filter models.Sequ
 	searchOptions = appendFilterAs(searchOptions, filter.Scope.Project, "scope.project")
 	searchOptions = appendFilterAs(searchOptions, filter.Scope.Stage, "scope.stage")
 	searchOptions = appendFilterAs(searchOptions, filter.Scope.Service, "scope.service")
-	searchOptions = appendFilterAs(searchOptions, filter.CurrentTriggeredID, "status.currentTask.triggeredID")
-	if !filter.TriggeredAt.IsZero() {
-		searchOptions["triggeredAt"] = bson.M{
-			"$lt": filter.TriggeredAt,
+	if filter.CurrentTriggeredID != "" {
+		// the triggeredID can either belong to the current task, or to one of the tasks running in parallel to it
+		searchOptions["$and"] = []bson.M{
+			{
+				"$or": []bson.M{
+					{"status.currentTask.triggeredID": filter.CurrentTriggeredID},
+					{"status.parallelTasks.triggeredID": filter.CurrentTriggeredID},
+				},
+			},
+		}
+	}
+	if !filter.TriggeredAt.IsZero() || !filter.TriggeredAfter.IsZero() {
+		triggeredAtFilter := bson.M{}
+		if !filter.TriggeredAt.IsZero() {
+			triggeredAtFilter["$lt"] = filter.TriggeredAt
+		}
+		if !filter.TriggeredAfter.IsZero() {
+			triggeredAtFilter["$gte"] = filter.TriggeredAfter
 		}
+		searchOptions["triggeredAt"] = triggeredAtFilter
 	}
 
 	if filter.Status != nil && len(filter.Status) > 0 {
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"fmt"
 	"sync"
 
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
@@ -80,6 +81,49 @@ func TestMongoDBTaskSequenceV2Repo_InsertAndRetrieveByTime(t *testing.T) {
 	require.Empty(t, get)
 }
 
+func TestMongoDBTaskSequenceV2Repo_GetPaginated(t *testing.T) {
+	scope, sequence := getTestSequenceExecution()
+
+	mdbrepo := NewMongoDBSequenceExecutionRepo(GetMongoDBConnectionInstance())
+
+	triggeredAt := sequence.TriggeredAt
+	for i := 0; i < 3; i++ {
+		sequence.ID = fmt.Sprintf("my-sequence-id-%d", i)
+		sequence.TriggeredAt = triggeredAt.Add(time.Duration(i) * time.Minute)
+		err := mdbrepo.Upsert(sequence, nil)
+		require.Nil(t, err)
+	}
+
+	get, totalCount, err := mdbrepo.GetPaginated(models.SequenceExecutionFilter{Scope: scope}, 0, 2)
+
+	require.Nil(t, err)
+	require.Equal(t, int64(3), totalCount)
+	require.Len(t, get, 2)
+	require.Equal(t, "my-sequence-id-2", get[0].ID)
+	require.Equal(t, "my-sequence-id-1", get[1].ID)
+
+	get, totalCount, err = mdbrepo.GetPaginated(models.SequenceExecutionFilter{Scope: scope}, 2, 2)
+
+	require.Nil(t, err)
+	require.Equal(t, int64(3), totalCount)
+	require.Len(t, get, 1)
+	require.Equal(t, "my-sequence-id-0", get[0].ID)
+
+	get, totalCount, err = mdbrepo.GetPaginated(models.SequenceExecutionFilter{
+		Scope:          scope,
+		TriggeredAfter: triggeredAt.Add(time.Minute),
+		TriggeredAt:    triggeredAt.Add(2 * time.Minute),
+	}, 0, 0)
+
+	require.Nil(t, err)
+	require.Equal(t, int64(1), totalCount)
+	require.Len(t, get, 1)
+	require.Equal(t, "my-sequence-id-1", get[0].ID)
+
+	err = mdbrepo.Clear("my-project")
+	require.Nil(t, err)
+}
+
 func TestMongoDBTaskSequenceV2Repo_GetByTriggeredID(t *testing.T) {
 	scope, sequence := getTestSequenceExecution()
 
@@ -233,6 +277,64 @@ func TestMongoDBTaskSequenceV2Repo_AppendTaskEventMultipleWriters(t *testing.T)
 	require.Len(t, get[0].Status.CurrentTask.Events, nrConcurrentWrites+1)
 }
 
+func TestMongoDBTaskSequenceV2Repo_AppendTaskEventOfParallelTask(t *testing.T) {
+	scope, sequence := getTestSequenceExecution()
+	sequence.ID = "my-parallel-sequence-id"
+	sequence.Status.ParallelTasks = []models.TaskExecutionState{
+		{
+			Name:        "security-scan",
+			TriggeredID: "5678",
+			Events:      []models.TaskEvent{},
+		},
+	}
+
+	mdbrepo := NewMongoDBSequenceExecutionRepo(GetMongoDBConnectionInstance())
+
+	err := mdbrepo.Upsert(sequence, nil)
+	require.Nil(t, err)
+
+	startedEvent := models.TaskEvent{
+		TriggeredID: "5678",
+		EventType:   "security-scan.started",
+		Source:      "my-source",
+		Time:        timeutils.GetKeptnTimeStamp(time.Now().UTC()),
+	}
+	result, err := mdbrepo.AppendTaskEvent(sequence, startedEvent)
+	require.Nil(t, err)
+
+	// the event has been appended to the parallel task, and not to the current task
+	require.Len(t, result.Status.CurrentTask.Events, 1)
+	require.Len(t, result.Status.ParallelTasks, 1)
+	require.Equal(t, []models.TaskEvent{startedEvent}, result.Status.ParallelTasks[0].Events)
+
+	// the parallel task is found even if it is not contained in the passed sequence execution anymore
+	staleSequence := sequence
+	staleSequence.Status.ParallelTasks = nil
+	finishedEvent := models.TaskEvent{
+		TriggeredID: "5678",
+		EventType:   "security-scan.finished",
+		Source:      "my-source",
+		Time:        timeutils.GetKeptnTimeStamp(time.Now().UTC()),
+	}
+	result, err = mdbrepo.AppendTaskEvent(staleSequence, finishedEvent)
+	require.Nil(t, err)
+
+	require.Len(t, result.Status.CurrentTask.Events, 1)
+	require.Equal(t, []models.TaskEvent{startedEvent, finishedEvent}, result.Status.ParallelTasks[0].Events)
+
+	// the sequence execution can be retrieved via the triggeredID of the parallel task
+	get, err := mdbrepo.Get(models.SequenceExecutionFilter{
+		Scope:              models.EventScope{EventData: keptnv2.EventData{Project: scope.Project}},
+		CurrentTriggeredID: "5678",
+	})
+	require.Nil(t, err)
+	require.Len(t, get, 1)
+	require.Equal(t, sequence.ID, get[0].ID)
+
+	err = mdbrepo.Clear("my-project")
+	require.Nil(t, err)
+}
+
 func TestMongoDBTaskSequenceV2Repo_UpdateStatus(t *testing.T) {
 	scope, sequence := getTestSequenceExecution()
 
@@ -267,6 +369,45 @@ func TestMongoDBTaskSequenceV2Repo_UpdateStatus(t *testing.T) {
 
 }
 
+func TestMongoDBTaskSequenceV2Repo_RejectsStaleFencingToken(t *testing.T) {
+	_, sequence := getTestSequenceExecution()
+	sequence.ID = "fenced-sequence"
+
+	mdbrepo := NewMongoDBSequenceExecutionRepo(GetMongoDBConnectionInstance())
+	defer mdbrepo.Clear("my-project")
+
+	sequence.FencingToken = 2
+	err := mdbrepo.Upsert(sequence, nil)
+	require.Nil(t, err)
+
+	// the holder of an outdated lock must not update the sequence anymore
+	stale := sequence
+	stale.FencingToken = 1
+	stale.Status.State = apimodels.SequencePaused
+	_, err = mdbrepo.UpdateStatus(stale)
+	require.ErrorIs(t, err, ErrStaleFencingToken)
+
+	_, err = mdbrepo.AppendTaskEvent(stale, models.TaskEvent{EventType: keptnv2.GetStartedEventType("deployment")})
+	require.ErrorIs(t, err, ErrStaleFencingToken)
+
+	err = mdbrepo.Upsert(stale, nil)
+	require.ErrorIs(t, err, ErrStaleFencingToken)
+
+	// the holder of the current lock, as well as the holder of a newer lock, can still update the sequence
+	sequence.Status.State = apimodels.SequencePaused
+	updated, err := mdbrepo.UpdateStatus(sequence)
+	require.Nil(t, err)
+	require.Equal(t, apimodels.SequencePaused, updated.Status.State)
+
+	sequence.FencingToken = 3
+	_, err = mdbrepo.AppendTaskEvent(sequence, models.TaskEvent{EventType: keptnv2.GetStartedEventType("deployment")})
+	require.Nil(t, err)
+
+	sequence.FencingToken = 2
+	err = mdbrepo.Upsert(sequence, nil)
+	require.ErrorIs(t, err, ErrStaleFencingToken)
+}
+
 func getTestSequenceExecution() (models.EventScope, models.SequenceExecution) {
 	scope := models.EventScope{
 		KeptnContext: "my-context",
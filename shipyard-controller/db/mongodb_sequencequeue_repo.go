@@ -37,13 +37,47 @@ func (sq *MongoDBSequenceQueueRepo) GetQueuedSequences() ([]models.QueueItem, er
 	}
 	defer cancel()
 
-	// ascending order -> oldest to newest
-	sortOptions := options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}})
+	// descending priority, and ascending order within the same priority -> oldest to newest
+	sortOptions := options.Find().SetSort(queueItemSortOrder)
 
 	return getQueueItemsFromCollection(collection, ctx, bson.M{}, sortOptions)
 
 }
 
+// ClaimQueuedSequences claims up to 'limit' queued sequences that are not claimed by another owner yet, or whose claim has expired.
+// It returns all sequences that are currently claimed by the given owner, ordered by their priority, and from oldest to newest within the same priority
+func (sq *MongoDBSequenceQueueRepo) ClaimQueuedSequences(owner string, ttl time.Duration, limit int) ([]models.QueueItem, error) {
+	collection, ctx, cancel, err := sq.getCollectionAndContext()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	return claimQueueItems(ctx, collection, bson.M{}, owner, ttl, limit)
+}
+
+// RenewSequenceClaims extends the claims on all sequences claimed by the given owner
+func (sq *MongoDBSequenceQueueRepo) RenewSequenceClaims(owner string, ttl time.Duration) error {
+	collection, ctx, cancel, err := sq.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	return renewQueueItemClaims(ctx, collection, owner, ttl)
+}
+
+// ReleaseClaimedSequences releases all sequences claimed by the given owner, so they can be claimed by other owners
+func (sq *MongoDBSequenceQueueRepo) ReleaseClaimedSequences(owner string) error {
+	collection, ctx, cancel, err := sq.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	return releaseQueueItemClaims(ctx, collection, owner)
+}
+
 func (sq *MongoDBSequenceQueueRepo) DeleteQueuedSequences(itemFilter models.QueueItem) error {
 	collection, ctx, cancel, err := sq.getCollectionAndContext()
 	if err != nil {
@@ -109,3 +109,141 @@ func verifyQueueItemEqual(t *testing.T, a, b models.QueueItem) {
 	require.Equal(t, a.Scope, b.Scope)
 	require.Equal(t, a.EventID, b.EventID)
 }
+
+func Test_MongoDBSequenceQueueRepoClaims(t *testing.T) {
+	nowTime := time.Now().UTC()
+
+	newQueueItem := func(eventID string, offset time.Duration) models.QueueItem {
+		return models.QueueItem{
+			Scope: models.EventScope{
+				EventData: keptnv2.EventData{
+					Project: "my-project",
+					Stage:   "my-stage",
+					Service: "my-service",
+				},
+				KeptnContext: "my-context-" + eventID,
+				EventType:    keptnv2.GetTriggeredEventType("dev.delivery"),
+			},
+			EventID:   eventID,
+			Timestamp: nowTime.Add(offset),
+		}
+	}
+
+	mdbrepo := NewMongoDBSequenceQueueRepo(GetMongoDBConnectionInstance())
+
+	err := mdbrepo.DeleteQueuedSequences(models.QueueItem{})
+	require.Nil(t, err)
+
+	for i, id := range []string{"claim-1", "claim-2", "claim-3"} {
+		err = mdbrepo.QueueSequence(newQueueItem(id, time.Duration(i)*time.Second))
+		require.Nil(t, err)
+	}
+
+	// replica-1 claims the two oldest items
+	claimed, err := mdbrepo.ClaimQueuedSequences("replica-1", time.Minute, 2)
+	require.Nil(t, err)
+	require.Len(t, claimed, 2)
+	require.Equal(t, "claim-1", claimed[0].EventID)
+	require.Equal(t, "claim-2", claimed[1].EventID)
+
+	// replica-2 can only claim the remaining item
+	claimed, err = mdbrepo.ClaimQueuedSequences("replica-2", 100*time.Millisecond, 2)
+	require.Nil(t, err)
+	require.Len(t, claimed, 1)
+	require.Equal(t, "claim-3", claimed[0].EventID)
+
+	// claiming again returns the items that have been claimed previously
+	claimed, err = mdbrepo.ClaimQueuedSequences("replica-1", time.Minute, 2)
+	require.Nil(t, err)
+	require.Len(t, claimed, 2)
+
+	// after the claim of replica-2 has expired, replica-1 does not take over its item, since it already holds as many items as the limit allows
+	<-time.After(200 * time.Millisecond)
+	claimed, err = mdbrepo.ClaimQueuedSequences("replica-1", time.Minute, 2)
+	require.Nil(t, err)
+	require.Len(t, claimed, 2)
+	require.Equal(t, "claim-1", claimed[0].EventID)
+	require.Equal(t, "claim-2", claimed[1].EventID)
+
+	// with a higher limit, replica-1 can take over the item of replica-2
+	claimed, err = mdbrepo.ClaimQueuedSequences("replica-1", time.Minute, 3)
+	require.Nil(t, err)
+	require.Len(t, claimed, 3)
+
+	// a lower limit restricts the number of returned items
+	claimed, err = mdbrepo.ClaimQueuedSequences("replica-1", time.Minute, 1)
+	require.Nil(t, err)
+	require.Len(t, claimed, 1)
+	require.Equal(t, "claim-1", claimed[0].EventID)
+
+	// renewing the claims of replica-2 should not affect the items taken over by replica-1
+	err = mdbrepo.RenewSequenceClaims("replica-2", time.Minute)
+	require.Nil(t, err)
+
+	// once released, the items are available to other replicas again
+	err = mdbrepo.ReleaseClaimedSequences("replica-1")
+	require.Nil(t, err)
+
+	claimed, err = mdbrepo.ClaimQueuedSequences("replica-2", time.Minute, 5)
+	require.Nil(t, err)
+	require.Len(t, claimed, 3)
+
+	// all items should still be visible in the complete queue
+	sequences, err := mdbrepo.GetQueuedSequences()
+	require.Nil(t, err)
+	require.Len(t, sequences, 3)
+
+	err = mdbrepo.DeleteQueuedSequences(models.QueueItem{})
+	require.Nil(t, err)
+}
+
+func Test_MongoDBSequenceQueueRepoPriority(t *testing.T) {
+	nowTime := time.Now().UTC()
+
+	newQueueItem := func(eventID string, offset time.Duration, priority int) models.QueueItem {
+		return models.QueueItem{
+			Scope: models.EventScope{
+				EventData: keptnv2.EventData{
+					Project: "my-project",
+					Stage:   "my-stage",
+					Service: "my-service",
+				},
+				KeptnContext: "my-context-" + eventID,
+				EventType:    keptnv2.GetTriggeredEventType("dev.delivery"),
+			},
+			EventID:   eventID,
+			Timestamp: nowTime.Add(offset),
+			Priority:  priority,
+		}
+	}
+
+	mdbrepo := NewMongoDBSequenceQueueRepo(GetMongoDBConnectionInstance())
+
+	err := mdbrepo.DeleteQueuedSequences(models.QueueItem{})
+	require.Nil(t, err)
+
+	delivery := newQueueItem("delivery", 0, 0)
+	lowPriority := newQueueItem("low-priority", -time.Second, -10)
+	remediation := newQueueItem("remediation", 2*time.Second, 100)
+
+	require.Nil(t, mdbrepo.QueueSequence(delivery))
+	require.Nil(t, mdbrepo.QueueSequence(lowPriority))
+	require.Nil(t, mdbrepo.QueueSequence(remediation))
+
+	// items with a higher priority are returned first, regardless of their timestamp
+	sequences, err := mdbrepo.GetQueuedSequences()
+	require.Nil(t, err)
+	require.Len(t, sequences, 3)
+	verifyQueueItemEqual(t, remediation, sequences[0])
+	verifyQueueItemEqual(t, delivery, sequences[1])
+	verifyQueueItemEqual(t, lowPriority, sequences[2])
+
+	// the item with the highest priority is claimed first
+	claimed, err := mdbrepo.ClaimQueuedSequences("replica-1", time.Minute, 1)
+	require.Nil(t, err)
+	require.Len(t, claimed, 1)
+	verifyQueueItemEqual(t, remediation, claimed[0])
+
+	err = mdbrepo.DeleteQueuedSequences(models.QueueItem{})
+	require.Nil(t, err)
+}
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const serviceMetadataCollectionName = "service-metadata"
+
+// MongoDBServiceMetadataRepo stores the metadata of services in a MongoDB collection
+type MongoDBServiceMetadataRepo struct {
+	DBConnection *MongoDBConnection
+}
+
+// NewMongoDBServiceMetadataRepo creates a new MongoDBServiceMetadataRepo
+func NewMongoDBServiceMetadataRepo(dbConnection *MongoDBConnection) *MongoDBServiceMetadataRepo {
+	return &MongoDBServiceMetadataRepo{DBConnection: dbConnection}
+}
+
+// GetServiceMetadata returns the metadata of the given service. If no metadata is found, ErrServiceMetadataNotFound is returned
+func (m *MongoDBServiceMetadataRepo) GetServiceMetadata(projectName, serviceName string) (*models.ServiceMetadata, error) {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	res := collection.FindOne(ctx, serviceMetadataFilter(projectName, serviceName))
+	if res.Err() != nil {
+		if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+			return nil, ErrServiceMetadataNotFound
+		}
+		return nil, fmt.Errorf("could not retrieve metadata of service %s: %w", serviceName, res.Err())
+	}
+
+	metadata := &models.ServiceMetadata{}
+	if err := res.Decode(metadata); err != nil {
+		return nil, fmt.Errorf("could not decode metadata of service %s: %w", serviceName, err)
+	}
+	return metadata, nil
+}
+
+// UpsertServiceMetadata replaces the stored metadata of the service with the given one, or creates it if it does not exist yet
+func (m *MongoDBServiceMetadataRepo) UpsertServiceMetadata(metadata models.ServiceMetadata) error {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	opts := options.Replace().SetUpsert(true)
+	if _, err := collection.ReplaceOne(ctx, serviceMetadataFilter(metadata.Project, metadata.Service), metadata, opts); err != nil {
+		return fmt.Errorf("could not store metadata of service %s: %w", metadata.Service, err)
+	}
+	return nil
+}
+
+// DeleteServiceMetadata deletes the metadata of the given service. Deleting the metadata of a service without metadata is not considered an error
+func (m *MongoDBServiceMetadataRepo) DeleteServiceMetadata(projectName, serviceName string) error {
+	collection, ctx, cancel, err := m.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	if _, err := collection.DeleteOne(ctx, serviceMetadataFilter(projectName, serviceName)); err != nil {
+		return fmt.Errorf("could not delete metadata of service %s: %w", serviceName, err)
+	}
+	return nil
+}
+
+func serviceMetadataFilter(projectName, serviceName string) bson.M {
+	return bson.M{"project": projectName, "service": serviceName}
+}
+
+func (m *MongoDBServiceMetadataRepo) getCollectionAndContext() (*mongo.Collection, context.Context, context.CancelFunc, error) {
+	err := m.DBConnection.EnsureDBConnection()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	collection := m.DBConnection.Client.Database(getDatabaseName()).Collection(serviceMetadataCollectionName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	return collection, ctx, cancel, nil
+}
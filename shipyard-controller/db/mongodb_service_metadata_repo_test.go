@@ -0,0 +1,45 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMongoDBServiceMetadataRepo_CRUD(t *testing.T) {
+	repo := NewMongoDBServiceMetadataRepo(GetMongoDBConnectionInstance())
+
+	_, err := repo.GetServiceMetadata("my-project", "my-service")
+	require.ErrorIs(t, err, ErrServiceMetadataNotFound)
+
+	metadata := models.ServiceMetadata{
+		Project:     "my-project",
+		Service:     "my-service",
+		Description: "my description",
+		Owners:      []string{"team-a"},
+		Labels:      map[string]string{"tier": "backend"},
+	}
+	err = repo.UpsertServiceMetadata(metadata)
+	require.Nil(t, err)
+
+	metadata.Owners = []string{"team-a", "team-b"}
+	err = repo.UpsertServiceMetadata(metadata)
+	require.Nil(t, err)
+
+	stored, err := repo.GetServiceMetadata("my-project", "my-service")
+	require.Nil(t, err)
+	require.Equal(t, metadata, *stored)
+
+	_, err = repo.GetServiceMetadata("other-project", "my-service")
+	require.ErrorIs(t, err, ErrServiceMetadataNotFound)
+
+	err = repo.DeleteServiceMetadata("my-project", "my-service")
+	require.Nil(t, err)
+
+	_, err = repo.GetServiceMetadata("my-project", "my-service")
+	require.ErrorIs(t, err, ErrServiceMetadataNotFound)
+
+	err = repo.DeleteServiceMetadata("my-project", "my-service")
+	require.Nil(t, err)
+}
@@ -407,3 +407,47 @@ func (mdbrepo *MongoDBUniformRepo) DeleteServiceFromSubscriptions(subscriptionNa
 	}
 	return nil
 }
+
+// RenameServiceInSubscriptions replaces the name of a service within the filters of all subscriptions that apply to the given project
+func (mdbrepo *MongoDBUniformRepo) RenameServiceInSubscriptions(projectName, serviceName, newServiceName string) error {
+	collection, ctx, cancel, err := mdbrepo.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	cur, err := collection.Find(ctx, bson.D{{Key: "subscriptions.filter.services", Value: serviceName}})
+	if err != nil {
+		return err
+	}
+	defer closeCursor(ctx, cur)
+
+	for cur.Next(ctx) {
+		integration := &apimodels.Integration{}
+		if err := cur.Decode(integration); err != nil {
+			//log the error, but continue
+			logger.Errorf("could not decode integration: %s", err.Error())
+			continue
+		}
+
+		for i := range integration.Subscriptions {
+			filter := &integration.Subscriptions[i].Filter
+			// subscriptions without a project filter apply to all projects, but the service is only renamed within the given one
+			if len(filter.Projects) > 0 && !containsString(filter.Projects, projectName) {
+				continue
+			}
+			for j := range filter.Services {
+				if filter.Services[j] == serviceName {
+					filter.Services[j] = newServiceName
+				}
+			}
+		}
+
+		filter := bson.D{{Key: "_id", Value: integration.ID}}
+		update := bson.D{{Key: "$set", Value: bson.D{{Key: "subscriptions", Value: integration.Subscriptions}}}}
+		if _, err := collection.UpdateOne(ctx, filter, update); err != nil {
+			return fmt.Errorf("could not update subscriptions of integration %s: %w", integration.ID, err)
+		}
+	}
+	return nil
+}
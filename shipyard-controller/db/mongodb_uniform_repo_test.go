@@ -655,3 +655,50 @@ func TestMongoDBUniformRepo_UpdateVersionInfo(t *testing.T) {
 	// make sure the subscriptions are not touched by the version update
 	require.Len(t, updated.Subscriptions, 3)
 }
+
+func TestMongoDBUniformRepo_RenameServiceInSubscriptions(t *testing.T) {
+	testIntegration := apimodels.Integration{
+		ID:   "i-rename",
+		Name: "integration-rename",
+		Subscriptions: []apimodels.EventSubscription{
+			{
+				Event: "sh.keptn.event.test.triggered",
+				Filter: apimodels.EventSubscriptionFilter{
+					Projects: []string{"pr1"},
+					Services: []string{"sv1", "sv2"},
+				},
+			},
+			{
+				Event: "sh.keptn.event.deployment.triggered",
+				Filter: apimodels.EventSubscriptionFilter{
+					Services: []string{"sv1"},
+				},
+			},
+			{
+				Event: "sh.keptn.event.evaluation.triggered",
+				Filter: apimodels.EventSubscriptionFilter{
+					Projects: []string{"pr2"},
+					Services: []string{"sv1"},
+				},
+			},
+		},
+	}
+
+	mdbrepo := NewMongoDBUniformRepo(GetMongoDBConnectionInstance())
+
+	err := mdbrepo.CreateOrUpdateUniformIntegration(testIntegration)
+	require.Nil(t, err)
+
+	err = mdbrepo.RenameServiceInSubscriptions("pr1", "sv1", "sv1-renamed")
+	require.Nil(t, err)
+
+	integrations, err := mdbrepo.GetUniformIntegrations(models.GetUniformIntegrationsParams{ID: testIntegration.ID})
+	require.Nil(t, err)
+	require.Len(t, integrations, 1)
+
+	subscriptions := integrations[0].Subscriptions
+	require.Equal(t, []string{"sv1-renamed", "sv2"}, subscriptions[0].Filter.Services)
+	require.Equal(t, []string{"sv1-renamed"}, subscriptions[1].Filter.Services)
+	// subscriptions of other projects are not touched
+	require.Equal(t, []string{"sv1"}, subscriptions[2].Filter.Services)
+}
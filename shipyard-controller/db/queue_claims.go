@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const claimedByProperty = "claimedBy"
+const claimedUntilProperty = "claimedUntil"
+
+// queueItemSortOrder sorts queue items by their priority, and by their timestamp within the same priority
+var queueItemSortOrder = bson.D{{Key: "priority", Value: -1}, {Key: "timestamp", Value: 1}}
+
+// claimQueueItems claims queue items matching the filter that are currently not claimed by any owner, or whose claim has expired, until the
+// given owner holds up to 'limit' items. Each item is claimed with an atomic find-and-modify operation, which ensures that an item can only be
+// claimed by a single owner at a time.
+// The result contains at most 'limit' items claimed by the given owner (i.e. the newly claimed ones, and the ones that have been claimed previously), ordered by their priority and timestamp
+func claimQueueItems(ctx context.Context, collection *mongo.Collection, filter bson.M, owner string, ttl time.Duration, limit int) ([]models.QueueItem, error) {
+	now := time.Now().UTC()
+
+	claimedFilter := bson.M{
+		"$and": []bson.M{
+			filter,
+			{claimedByProperty: owner},
+		},
+	}
+	// items that are still held by the owner count towards the limit, so that an owner cannot accumulate more items with every call
+	alreadyClaimed, err := collection.CountDocuments(ctx, bson.M{
+		"$and": []bson.M{
+			claimedFilter,
+			{claimedUntilProperty: bson.M{"$gt": now}},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not count claimed queue items: %w", err)
+	}
+
+	claimFilter := bson.M{
+		"$and": []bson.M{
+			filter,
+			{
+				"$or": []bson.M{
+					{claimedUntilProperty: bson.M{"$exists": false}},
+					{claimedUntilProperty: bson.M{"$lte": now}},
+				},
+			},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			claimedByProperty:    owner,
+			claimedUntilProperty: now.Add(ttl),
+		},
+	}
+	// items with the highest priority are claimed first, and the oldest ones within the same priority
+	opts := options.FindOneAndUpdate().SetSort(queueItemSortOrder)
+
+	for i := int(alreadyClaimed); i < limit; i++ {
+		res := collection.FindOneAndUpdate(ctx, claimFilter, update, opts)
+		if res.Err() != nil {
+			if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+				break
+			}
+			return nil, fmt.Errorf("could not claim queue items: %w", res.Err())
+		}
+	}
+
+	sortOptions := options.Find().SetSort(queueItemSortOrder).SetLimit(int64(limit))
+	return getQueueItemsFromCollection(collection, ctx, claimedFilter, sortOptions)
+}
+
+// renewQueueItemClaims extends the claims of the given owner
+func renewQueueItemClaims(ctx context.Context, collection *mongo.Collection, owner string, ttl time.Duration) error {
+	_, err := collection.UpdateMany(
+		ctx,
+		bson.M{claimedByProperty: owner},
+		bson.M{"$set": bson.M{claimedUntilProperty: time.Now().UTC().Add(ttl)}},
+	)
+	if err != nil {
+		return fmt.Errorf("could not renew claims of %s: %w", owner, err)
+	}
+	return nil
+}
+
+// releaseQueueItemClaims removes the claims of the given owner, which makes the items available to other owners again
+func releaseQueueItemClaims(ctx context.Context, collection *mongo.Collection, owner string) error {
+	_, err := collection.UpdateMany(
+		ctx,
+		bson.M{claimedByProperty: owner},
+		bson.M{"$unset": bson.M{claimedByProperty: "", claimedUntilProperty: ""}},
+	)
+	if err != nil {
+		return fmt.Errorf("could not release claims of %s: %w", owner, err)
+	}
+	return nil
+}
@@ -23,6 +23,33 @@ var ErrServiceNotFound = errors.New("service not found")
 // ErrOpenRemediationNotFound indicates that no open remediation has been found
 var ErrOpenRemediationNotFound = errors.New("open remediation not found")
 
+// ErrLockHeld indicates that a lock is currently held by another owner
+var ErrLockHeld = errors.New("lock is held by another owner")
+
+// ErrScheduleNotFound indicates that a schedule has not been found
+var ErrScheduleNotFound = errors.New("schedule not found")
+
+// ErrScheduleRunClaimed indicates that a run of a schedule has already been claimed, e.g. by another replica
+var ErrScheduleRunClaimed = errors.New("schedule run has already been claimed")
+
+// ErrNotificationChannelNotFound indicates that a notification channel has not been found
+var ErrNotificationChannelNotFound = errors.New("notification channel not found")
+
+// ErrNotificationDeliveryClaimed indicates that the next attempt of a notification delivery has already been claimed, e.g. by another replica
+var ErrNotificationDeliveryClaimed = errors.New("notification delivery has already been claimed")
+
+// ErrServiceMetadataNotFound indicates that no metadata has been stored for a service
+var ErrServiceMetadataNotFound = errors.New("service metadata not found")
+
+// ErrAPITokenNotFound indicates that an API token has not been found
+var ErrAPITokenNotFound = errors.New("api token not found")
+
+// ErrAPITokenAlreadyExists indicates that an API token with the same name already exists
+var ErrAPITokenAlreadyExists = errors.New("api token already exists")
+
+// ErrLockLost indicates that a lock has expired and has been taken over by another owner
+var ErrLockLost = errors.New("lock has been lost")
+
 //go:generate moq --skip-ensure -pkg db_mock -out ./mock/sequencestaterepo_mock.go . SequenceStateRepo
 type SequenceStateRepo interface {
 	CreateSequenceState(state apimodels.SequenceState) error
@@ -39,6 +66,7 @@ type UniformRepo interface {
 	CreateOrUpdateUniformIntegration(integration apimodels.Integration) error
 	CreateOrUpdateSubscription(integrationID string, subscription apimodels.EventSubscription) error
 	DeleteServiceFromSubscriptions(subscriptionName string) error
+	RenameServiceInSubscriptions(projectName, serviceName, newServiceName string) error
 	DeleteSubscription(integrationID, subscriptionID string) error
 	GetSubscription(integrationID, subscriptionID string) (*apimodels.EventSubscription, error)
 	GetSubscriptions(integrationID string) ([]apimodels.EventSubscription, error)
@@ -57,6 +85,9 @@ type LogRepo interface {
 type EventQueueRepo interface {
 	QueueEvent(item models.QueueItem) error
 	GetQueuedEvents(timestamp time.Time) ([]models.QueueItem, error)
+	ClaimQueuedEvents(timestamp time.Time, owner string, ttl time.Duration, limit int) ([]models.QueueItem, error)
+	RenewEventClaims(owner string, ttl time.Duration) error
+	ReleaseClaimedEvents(owner string) error
 	IsEventInQueue(eventID string) (bool, error)
 	IsSequenceOfEventPaused(eventScope models.EventScope) bool
 	DeleteQueuedEvent(eventID string) error
@@ -96,12 +127,16 @@ type ProjectRepo interface {
 type SequenceQueueRepo interface {
 	QueueSequence(item models.QueueItem) error
 	GetQueuedSequences() ([]models.QueueItem, error)
+	ClaimQueuedSequences(owner string, ttl time.Duration, limit int) ([]models.QueueItem, error)
+	RenewSequenceClaims(owner string, ttl time.Duration) error
+	ReleaseClaimedSequences(owner string) error
 	DeleteQueuedSequences(itemFilter models.QueueItem) error
 }
 
 //go:generate moq --skip-ensure -pkg db_mock -out ./mock/sequenceexecution_mock.go . SequenceExecutionRepo
 type SequenceExecutionRepo interface {
 	Get(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error)
+	GetPaginated(filter models.SequenceExecutionFilter, nextPageKey int64, pageSize int64) ([]models.SequenceExecution, int64, error)
 	GetByTriggeredID(project, triggeredID string) (*models.SequenceExecution, error)
 	Upsert(item models.SequenceExecution, options *models.SequenceExecutionUpsertOptions) error
 	AppendTaskEvent(taskSequence models.SequenceExecution, event models.TaskEvent) (*models.SequenceExecution, error)
@@ -111,3 +146,70 @@ type SequenceExecutionRepo interface {
 	IsContextPaused(eventScope models.EventScope) bool
 	Clear(projectName string) error
 }
+
+//go:generate moq --skip-ensure -pkg db_mock -out ./mock/lockrepo_mock.go . LockRepo
+// LockRepo defines the interface for acquiring, renewing and releasing leases on shared resources
+type LockRepo interface {
+	Acquire(key, owner string, ttl time.Duration) (*models.Lock, error)
+	Renew(lock models.Lock, ttl time.Duration) (*models.Lock, error)
+	Release(lock models.Lock) error
+}
+
+//go:generate moq --skip-ensure -pkg db_mock -out ./mock/schedulerepo_mock.go . ScheduleRepo
+// ScheduleRepo defines the interface for storing the schedules that trigger sequences
+type ScheduleRepo interface {
+	CreateSchedule(schedule models.Schedule) error
+	GetSchedules(filter models.GetSchedulesParams) ([]models.Schedule, error)
+	GetSchedule(id string) (*models.Schedule, error)
+	UpdateSchedule(schedule models.Schedule) error
+	DeleteSchedule(id string) error
+	GetDueSchedules(dueAt time.Time) ([]models.Schedule, error)
+	ClaimScheduleRun(schedule models.Schedule, nextRunAt *time.Time, keptnContext string) error
+}
+
+//go:generate moq --skip-ensure -pkg db_mock -out ./mock/notificationchannelrepo_mock.go . NotificationChannelRepo
+// NotificationChannelRepo defines the interface for storing the channels notifications about sequences are sent to
+type NotificationChannelRepo interface {
+	CreateNotificationChannel(channel models.NotificationChannel) error
+	GetNotificationChannels(project string) ([]models.NotificationChannel, error)
+	GetNotificationChannel(project, id string) (*models.NotificationChannel, error)
+	UpdateNotificationChannel(channel models.NotificationChannel) error
+	DeleteNotificationChannel(project, id string) error
+}
+
+//go:generate moq --skip-ensure -pkg db_mock -out ./mock/notificationdeliveryrepo_mock.go . NotificationDeliveryRepo
+// NotificationDeliveryRepo defines the interface for storing the deliveries of notifications
+type NotificationDeliveryRepo interface {
+	CreateNotificationDeliveries(deliveries []models.NotificationDelivery) error
+	GetNotificationDeliveries(params models.GetNotificationDeliveriesParams) (*models.GetNotificationDeliveriesResponse, error)
+	UpdateNotificationDelivery(delivery models.NotificationDelivery) error
+	GetDueNotificationDeliveries(dueAt time.Time) ([]models.NotificationDelivery, error)
+	ClaimNotificationDelivery(delivery models.NotificationDelivery, claimedUntil time.Time) error
+	SetupTTLIndex(duration time.Duration) error
+}
+
+//go:generate moq --skip-ensure -pkg db_mock -out ./mock/auditrepo_mock.go . AuditRepo
+// AuditRepo defines the interface for storing the audit records of state-changing API calls
+type AuditRepo interface {
+	CreateAuditRecord(record models.AuditRecord) error
+	GetAuditRecords(params models.GetAuditRecordsParams) (*models.GetAuditRecordsResponse, error)
+	SetupTTLIndex(duration time.Duration) error
+}
+
+//go:generate moq --skip-ensure -pkg db_mock -out ./mock/servicemetadatarepo_mock.go . ServiceMetadataRepo
+// ServiceMetadataRepo defines the interface for storing the descriptive properties of services, such as their owners and labels
+type ServiceMetadataRepo interface {
+	GetServiceMetadata(projectName, serviceName string) (*models.ServiceMetadata, error)
+	UpsertServiceMetadata(metadata models.ServiceMetadata) error
+	DeleteServiceMetadata(projectName, serviceName string) error
+}
+
+//go:generate moq --skip-ensure -pkg db_mock -out ./mock/apitokenrepo_mock.go . APITokenRepo
+// APITokenRepo defines the interface for storing the named API tokens and the roles that have been granted to them
+type APITokenRepo interface {
+	CreateAPIToken(token models.APIToken) error
+	GetAPITokens() ([]models.APIToken, error)
+	GetAPITokenByHash(hash string) (*models.APIToken, error)
+	UpdateAPITokenHash(name, hash string, rotatedAt time.Time) error
+	DeleteAPIToken(name string) error
+}
@@ -1759,6 +1759,10 @@ const docTemplate = `{
                     "description": "git proxy user",
                     "type": "string"
                 },
+                "gitPullRequestProvider": {
+                    "description": "Git provider used to propose changes via pull requests instead of pushing them to the upstream: github, gitlab or gitea",
+                    "type": "string"
+                },
                 "gitRemoteURL": {
                     "description": "git remote URL",
                     "type": "string"
@@ -2449,6 +2453,10 @@ const docTemplate = `{
                     "description": "git proxy user",
                     "type": "string"
                 },
+                "gitPullRequestProvider": {
+                    "description": "Git provider used to propose changes via pull requests instead of pushing them to the upstream: github, gitlab or gitea",
+                    "type": "string"
+                },
                 "gitRemoteURL": {
                     "description": "git remote URL",
                     "type": "string"
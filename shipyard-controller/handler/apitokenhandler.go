@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/models"
+)
+
+var apiTokenNameRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+type APITokenParamsValidator struct{}
+
+func (a APITokenParamsValidator) Validate(params interface{}) error {
+	switch t := params.(type) {
+	case *models.CreateAPITokenParams:
+		return a.validateCreateAPITokenParams(t)
+	default:
+		return nil
+	}
+}
+
+func (a APITokenParamsValidator) validateCreateAPITokenParams(params *models.CreateAPITokenParams) error {
+	if !apiTokenNameRegex.MatchString(params.Name) {
+		return fmt.Errorf("invalid name '%s', the name must consist of at most 63 lower case alphanumeric characters or '-'", params.Name)
+	}
+	if len(params.Roles) == 0 {
+		return errors.New("at least one role must be specified")
+	}
+	for _, role := range params.Roles {
+		if !containsString(models.TokenRoles, role) {
+			return fmt.Errorf("invalid role '%s', expected one of %v", role, models.TokenRoles)
+		}
+	}
+	for _, project := range params.Projects {
+		if project == "" {
+			return errors.New("project names must not be empty")
+		}
+	}
+	return nil
+}
+
+type IAPITokenHandler interface {
+	CreateToken(context *gin.Context)
+	GetTokens(context *gin.Context)
+	RotateToken(context *gin.Context)
+	DeleteToken(context *gin.Context)
+	ValidateToken(context *gin.Context)
+}
+
+type APITokenHandler struct {
+	apiTokenManager IAPITokenManager
+}
+
+func NewAPITokenHandler(apiTokenManager IAPITokenManager) *APITokenHandler {
+	return &APITokenHandler{
+		apiTokenManager: apiTokenManager,
+	}
+}
+
+// CreateToken godoc
+// @Summary      Create an API token
+// @Description  Create a named API token with the given roles, optionally limited to a set of projects. The token is only included in this response
+// @Tags         Token
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        token  body      models.CreateAPITokenParams    true  "API token"
+// @Success      201    {object}  models.CreateAPITokenResponse  "ok"
+// @Failure      400    {object}  models.Error                   "Invalid payload"
+// @Failure      409    {object}  models.Error                   "Conflict"
+// @Failure      500    {object}  models.Error                   "Internal error"
+// @Router       /token [post]
+func (th *APITokenHandler) CreateToken(c *gin.Context) {
+	params := &models.CreateAPITokenParams{}
+	if err := c.ShouldBindJSON(params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidRequestFormatMsg, err.Error()))
+		return
+	}
+	if err := (APITokenParamsValidator{}).Validate(params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidPayloadMsg, err.Error()))
+		return
+	}
+
+	token, err := th.apiTokenManager.CreateToken(*params)
+	if err != nil {
+		setAPITokenErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, token)
+}
+
+// GetTokens godoc
+// @Summary      Get API tokens
+// @Description  Get the names, roles and projects of all API tokens. The tokens themselves are not included
+// @Tags         Token
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  models.GetAPITokensResponse  "ok"
+// @Failure      500  {object}  models.Error                 "Internal error"
+// @Router       /token [get]
+func (th *APITokenHandler) GetTokens(c *gin.Context) {
+	tokens, err := th.apiTokenManager.GetTokens()
+	if err != nil {
+		SetInternalServerErrorResponse(c, fmt.Sprintf(UnableQueryAPITokensMsg, err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, &models.GetAPITokensResponse{Tokens: tokens})
+}
+
+// RotateToken godoc
+// @Summary      Rotate an API token
+// @Description  Replace an API token by a new one with the same roles and projects. The previous token becomes invalid immediately
+// @Tags         Token
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        name  path      string                         true  "The name of the API token"
+// @Success      200   {object}  models.CreateAPITokenResponse  "ok"
+// @Failure      404   {object}  models.Error                   "Not found"
+// @Failure      500   {object}  models.Error                   "Internal error"
+// @Router       /token/{name}/rotate [post]
+func (th *APITokenHandler) RotateToken(c *gin.Context) {
+	token, err := th.apiTokenManager.RotateToken(c.Param("name"))
+	if err != nil {
+		setAPITokenErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, token)
+}
+
+// DeleteToken godoc
+// @Summary      Revoke an API token
+// @Description  Delete an API token. Requests using the token are rejected afterwards
+// @Tags         Token
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        name  path      string                         true  "The name of the API token"
+// @Success      200   {object}  models.DeleteAPITokenResponse  "ok"
+// @Failure      404   {object}  models.Error                   "Not found"
+// @Failure      500   {object}  models.Error                   "Internal error"
+// @Router       /token/{name} [delete]
+func (th *APITokenHandler) DeleteToken(c *gin.Context) {
+	if err := th.apiTokenManager.DeleteToken(c.Param("name")); err != nil {
+		setAPITokenErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, &models.DeleteAPITokenResponse{})
+}
+
+// ValidateToken godoc
+// @Summary      Validate an API token
+// @Description  INTERNAL Endpoint: Get the roles and projects of an API token. This endpoint is used by the api-service to authorize requests and is not exposed via the API gateway
+// @Tags         Token
+// @Accept       json
+// @Produce      json
+// @Param        token  body      models.ValidateAPITokenParams  true  "API token"
+// @Success      200    {object}  models.APIToken                "ok"
+// @Failure      400    {object}  models.Error                   "Invalid payload"
+// @Failure      401    {object}  models.Error                   "Invalid token"
+// @Failure      500    {object}  models.Error                   "Internal error"
+// @Router       /token/validate [post]
+func (th *APITokenHandler) ValidateToken(c *gin.Context) {
+	params := &models.ValidateAPITokenParams{}
+	if err := c.ShouldBindJSON(params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidRequestFormatMsg, err.Error()))
+		return
+	}
+
+	token, err := th.apiTokenManager.ValidateToken(params.Token)
+	if err != nil {
+		if errors.Is(err, ErrAPITokenNotFound) {
+			SetUnauthorizedErrorResponse(c, err.Error())
+			return
+		}
+		SetInternalServerErrorResponse(c, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, token)
+}
+
+func setAPITokenErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrAPITokenNotFound):
+		SetNotFoundErrorResponse(c, err.Error())
+	case errors.Is(err, ErrAPITokenAlreadyExists):
+		SetConflictErrorResponse(c, err.Error())
+	default:
+		SetInternalServerErrorResponse(c, err.Error())
+	}
+}
@@ -0,0 +1,127 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+	"github.com/keptn/keptn/shipyard-controller/handler/fake"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPITokenHandler_CreateToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		manager    *fake.IAPITokenManagerMock
+		payload    string
+		wantStatus int
+	}{
+		{
+			name: "create token",
+			manager: &fake.IAPITokenManagerMock{
+				CreateTokenFunc: func(params models.CreateAPITokenParams) (*models.CreateAPITokenResponse, error) {
+					return &models.CreateAPITokenResponse{APIToken: models.APIToken{Name: params.Name, Hash: "my-hash", Roles: params.Roles}, Token: "my-token"}, nil
+				},
+			},
+			payload:    `{"name":"ci-pipeline","roles":["operator"],"projects":["sockshop"]}`,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "invalid role",
+			manager:    &fake.IAPITokenManagerMock{},
+			payload:    `{"name":"ci-pipeline","roles":["root"]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing roles",
+			manager:    &fake.IAPITokenManagerMock{},
+			payload:    `{"name":"ci-pipeline"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid name",
+			manager:    &fake.IAPITokenManagerMock{},
+			payload:    `{"name":"CI Pipeline","roles":["viewer"]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "token already exists",
+			manager: &fake.IAPITokenManagerMock{
+				CreateTokenFunc: func(params models.CreateAPITokenParams) (*models.CreateAPITokenResponse, error) {
+					return nil, handler.ErrAPITokenAlreadyExists
+				},
+			},
+			payload:    `{"name":"ci-pipeline","roles":["viewer"]}`,
+			wantStatus: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := handler.NewAPITokenHandler(tt.manager)
+
+			router := gin.Default()
+			router.POST("/token", th.CreateToken)
+			w := performRequest(router, httptest.NewRequest(http.MethodPost, "/token", bytes.NewBufferString(tt.payload)))
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusCreated {
+				response := map[string]interface{}{}
+				require.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, "my-token", response["token"])
+				require.Equal(t, "ci-pipeline", response["name"])
+				// the hash of the token is never returned
+				require.NotContains(t, w.Body.String(), "my-hash")
+			}
+		})
+	}
+}
+
+func TestAPITokenHandler_ValidateToken(t *testing.T) {
+	manager := &fake.IAPITokenManagerMock{
+		ValidateTokenFunc: func(token string) (*models.APIToken, error) {
+			if token == "my-token" {
+				return &models.APIToken{Name: "ci-pipeline", Roles: []string{models.TokenRoleViewer}}, nil
+			}
+			return nil, handler.ErrAPITokenNotFound
+		},
+	}
+	th := handler.NewAPITokenHandler(manager)
+
+	router := gin.Default()
+	router.POST("/token/validate", th.ValidateToken)
+
+	w := performRequest(router, httptest.NewRequest(http.MethodPost, "/token/validate", bytes.NewBufferString(`{"token":"my-token"}`)))
+	require.Equal(t, http.StatusOK, w.Code)
+	token := &models.APIToken{}
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), token))
+	require.Equal(t, "ci-pipeline", token.Name)
+
+	w = performRequest(router, httptest.NewRequest(http.MethodPost, "/token/validate", bytes.NewBufferString(`{"token":"other-token"}`)))
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAPITokenHandler_DeleteToken(t *testing.T) {
+	manager := &fake.IAPITokenManagerMock{
+		DeleteTokenFunc: func(name string) error {
+			if name == "ci-pipeline" {
+				return nil
+			}
+			return handler.ErrAPITokenNotFound
+		},
+	}
+	th := handler.NewAPITokenHandler(manager)
+
+	router := gin.Default()
+	router.DELETE("/token/:name", th.DeleteToken)
+
+	w := performRequest(router, httptest.NewRequest(http.MethodDelete, "/token/ci-pipeline", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = performRequest(router, httptest.NewRequest(http.MethodDelete, "/token/unknown", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
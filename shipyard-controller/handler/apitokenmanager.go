@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/benbjohnson/clock"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+)
+
+// apiTokenLength is the number of random bytes of a generated API token
+const apiTokenLength = 32
+
+//go:generate moq -pkg fake -skip-ensure -out ./fake/apitokenmanager.go . IAPITokenManager
+type IAPITokenManager interface {
+	CreateToken(params models.CreateAPITokenParams) (*models.CreateAPITokenResponse, error)
+	GetTokens() ([]models.APIToken, error)
+	RotateToken(name string) (*models.CreateAPITokenResponse, error)
+	DeleteToken(name string) error
+	ValidateToken(token string) (*models.APIToken, error)
+}
+
+// APITokenManager manages the named API tokens. The tokens are generated by the manager and only their hashes are stored,
+// i.e. the plain token is only returned once, when the token is created or rotated
+type APITokenManager struct {
+	apiTokenRepo db.APITokenRepo
+	theClock     clock.Clock
+}
+
+func NewAPITokenManager(apiTokenRepo db.APITokenRepo, theClock clock.Clock) *APITokenManager {
+	return &APITokenManager{
+		apiTokenRepo: apiTokenRepo,
+		theClock:     theClock,
+	}
+}
+
+func (tm *APITokenManager) CreateToken(params models.CreateAPITokenParams) (*models.CreateAPITokenResponse, error) {
+	plainToken, err := generateAPIToken()
+	if err != nil {
+		return nil, err
+	}
+
+	token := models.APIToken{
+		Name:      params.Name,
+		Hash:      HashAPIToken(plainToken),
+		Roles:     params.Roles,
+		Projects:  params.Projects,
+		CreatedAt: tm.theClock.Now().UTC(),
+	}
+	if err := tm.apiTokenRepo.CreateAPIToken(token); err != nil {
+		if errors.Is(err, db.ErrAPITokenAlreadyExists) {
+			return nil, ErrAPITokenAlreadyExists
+		}
+		return nil, err
+	}
+	return &models.CreateAPITokenResponse{APIToken: token, Token: plainToken}, nil
+}
+
+func (tm *APITokenManager) GetTokens() ([]models.APIToken, error) {
+	return tm.apiTokenRepo.GetAPITokens()
+}
+
+// RotateToken replaces the token with the given name by a new one. The roles and projects of the token are kept,
+// while the previous token becomes invalid immediately
+func (tm *APITokenManager) RotateToken(name string) (*models.CreateAPITokenResponse, error) {
+	tokens, err := tm.apiTokenRepo.GetAPITokens()
+	if err != nil {
+		return nil, err
+	}
+	var token *models.APIToken
+	for index := range tokens {
+		if tokens[index].Name == name {
+			token = &tokens[index]
+			break
+		}
+	}
+	if token == nil {
+		return nil, ErrAPITokenNotFound
+	}
+
+	plainToken, err := generateAPIToken()
+	if err != nil {
+		return nil, err
+	}
+	rotatedAt := tm.theClock.Now().UTC()
+	token.Hash = HashAPIToken(plainToken)
+	token.RotatedAt = &rotatedAt
+
+	if err := tm.apiTokenRepo.UpdateAPITokenHash(name, token.Hash, rotatedAt); err != nil {
+		if errors.Is(err, db.ErrAPITokenNotFound) {
+			return nil, ErrAPITokenNotFound
+		}
+		return nil, err
+	}
+	return &models.CreateAPITokenResponse{APIToken: *token, Token: plainToken}, nil
+}
+
+func (tm *APITokenManager) DeleteToken(name string) error {
+	if err := tm.apiTokenRepo.DeleteAPIToken(name); err != nil {
+		if errors.Is(err, db.ErrAPITokenNotFound) {
+			return ErrAPITokenNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// ValidateToken returns the API token matching the given plain token. If there is none, ErrAPITokenNotFound is returned
+func (tm *APITokenManager) ValidateToken(token string) (*models.APIToken, error) {
+	if token == "" {
+		return nil, ErrAPITokenNotFound
+	}
+	apiToken, err := tm.apiTokenRepo.GetAPITokenByHash(HashAPIToken(token))
+	if err != nil {
+		if errors.Is(err, db.ErrAPITokenNotFound) {
+			return nil, ErrAPITokenNotFound
+		}
+		return nil, err
+	}
+	return apiToken, nil
+}
+
+// HashAPIToken returns the hex encoded SHA-256 hash of the given token
+func HashAPIToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func generateAPIToken() (string, error) {
+	token := make([]byte, apiTokenLength)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("could not generate api token: %w", err)
+	}
+	return hex.EncodeToString(token), nil
+}
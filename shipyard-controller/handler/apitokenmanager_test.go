@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPITokenManager_CreateToken(t *testing.T) {
+	theClock := clock.NewMock()
+	theClock.Set(time.Date(2022, 6, 1, 2, 0, 0, 0, time.UTC))
+
+	apiTokenRepo := &db_mock.APITokenRepoMock{
+		CreateAPITokenFunc: func(token models.APIToken) error {
+			return nil
+		},
+	}
+	tm := NewAPITokenManager(apiTokenRepo, theClock)
+
+	response, err := tm.CreateToken(models.CreateAPITokenParams{Name: "ci", Roles: []string{models.TokenRoleOperator}, Projects: []string{"sockshop"}})
+	require.Nil(t, err)
+	require.Len(t, response.Token, 2*apiTokenLength)
+
+	// only the hash of the token is stored
+	stored := apiTokenRepo.CreateAPITokenCalls()[0].Token
+	require.Equal(t, HashAPIToken(response.Token), stored.Hash)
+	require.NotContains(t, stored.Hash, response.Token)
+	require.Equal(t, "ci", stored.Name)
+	require.Equal(t, []string{"sockshop"}, stored.Projects)
+	require.Equal(t, theClock.Now().UTC(), stored.CreatedAt)
+
+	apiTokenRepo.CreateAPITokenFunc = func(token models.APIToken) error {
+		return db.ErrAPITokenAlreadyExists
+	}
+	_, err = tm.CreateToken(models.CreateAPITokenParams{Name: "ci", Roles: []string{models.TokenRoleOperator}})
+	require.ErrorIs(t, err, ErrAPITokenAlreadyExists)
+}
+
+func TestAPITokenManager_RotateToken(t *testing.T) {
+	theClock := clock.NewMock()
+	theClock.Set(time.Date(2022, 6, 1, 2, 0, 0, 0, time.UTC))
+
+	apiTokenRepo := &db_mock.APITokenRepoMock{
+		GetAPITokensFunc: func() ([]models.APIToken, error) {
+			return []models.APIToken{{Name: "ci", Hash: "old-hash", Roles: []string{models.TokenRoleViewer}}}, nil
+		},
+		UpdateAPITokenHashFunc: func(name string, hash string, rotatedAt time.Time) error {
+			return nil
+		},
+	}
+	tm := NewAPITokenManager(apiTokenRepo, theClock)
+
+	response, err := tm.RotateToken("ci")
+	require.Nil(t, err)
+	require.Equal(t, []string{models.TokenRoleViewer}, response.Roles)
+	require.Equal(t, theClock.Now().UTC(), *response.RotatedAt)
+	require.Equal(t, HashAPIToken(response.Token), apiTokenRepo.UpdateAPITokenHashCalls()[0].Hash)
+
+	_, err = tm.RotateToken("unknown")
+	require.ErrorIs(t, err, ErrAPITokenNotFound)
+}
+
+func TestAPITokenManager_ValidateToken(t *testing.T) {
+	apiTokenRepo := &db_mock.APITokenRepoMock{
+		GetAPITokenByHashFunc: func(hash string) (*models.APIToken, error) {
+			if hash == HashAPIToken("my-token") {
+				return &models.APIToken{Name: "ci", Roles: []string{models.TokenRoleAdmin}}, nil
+			}
+			return nil, db.ErrAPITokenNotFound
+		},
+	}
+	tm := NewAPITokenManager(apiTokenRepo, clock.NewMock())
+
+	token, err := tm.ValidateToken("my-token")
+	require.Nil(t, err)
+	require.Equal(t, "ci", token.Name)
+
+	_, err = tm.ValidateToken("other-token")
+	require.ErrorIs(t, err, ErrAPITokenNotFound)
+
+	_, err = tm.ValidateToken("")
+	require.ErrorIs(t, err, ErrAPITokenNotFound)
+}
+
+func TestAPITokenManager_DeleteToken(t *testing.T) {
+	apiTokenRepo := &db_mock.APITokenRepoMock{
+		DeleteAPITokenFunc: func(name string) error {
+			return db.ErrAPITokenNotFound
+		},
+	}
+	tm := NewAPITokenManager(apiTokenRepo, clock.NewMock())
+
+	err := tm.DeleteToken("unknown")
+	require.ErrorIs(t, err, ErrAPITokenNotFound)
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/models"
+)
+
+type AuditParamsValidator struct{}
+
+func (a AuditParamsValidator) Validate(params interface{}) error {
+	switch t := params.(type) {
+	case *models.AuditRecord:
+		return a.validateAuditRecord(t)
+	case *models.GetAuditRecordsParams:
+		return a.validateGetAuditRecordsParams(t)
+	default:
+		return nil
+	}
+}
+
+func (a AuditParamsValidator) validateAuditRecord(record *models.AuditRecord) error {
+	if record.Service == "" {
+		return errors.New("service must be specified")
+	}
+	if record.Action == "" {
+		return errors.New("action must be specified")
+	}
+	if record.Principal == "" {
+		return errors.New("principal must be specified")
+	}
+	return nil
+}
+
+func (a AuditParamsValidator) validateGetAuditRecordsParams(params *models.GetAuditRecordsParams) error {
+	if params.From != "" {
+		if _, err := time.Parse(time.RFC3339, params.From); err != nil {
+			return fmt.Errorf("from must be a timestamp in RFC3339 format: %w", err)
+		}
+	}
+	if params.To != "" {
+		if _, err := time.Parse(time.RFC3339, params.To); err != nil {
+			return fmt.Errorf("to must be a timestamp in RFC3339 format: %w", err)
+		}
+	}
+	return nil
+}
+
+type IAuditHandler interface {
+	CreateAuditRecord(context *gin.Context)
+	GetAuditRecords(context *gin.Context)
+}
+
+type AuditHandler struct {
+	auditManager IAuditManager
+}
+
+func NewAuditHandler(auditManager IAuditManager) *AuditHandler {
+	return &AuditHandler{
+		auditManager: auditManager,
+	}
+}
+
+// CreateAuditRecord godoc
+// @Summary      Create an audit record
+// @Description  INTERNAL Endpoint: Store an audit record of a state-changing API call that has been handled by another Keptn service. This endpoint is not exposed via the API gateway
+// @Tags         Audit
+// @Accept       json
+// @Produce      json
+// @Param        record  body      models.AuditRecord                true  "Audit record"
+// @Success      201     {object}  models.CreateAuditRecordResponse  "ok"
+// @Failure      400     {object}  models.Error                      "Invalid payload"
+// @Failure      500     {object}  models.Error                      "Internal error"
+// @Router       /audit [post]
+func (ah *AuditHandler) CreateAuditRecord(c *gin.Context) {
+	record := &models.AuditRecord{}
+	if err := c.ShouldBindJSON(record); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidRequestFormatMsg, err.Error()))
+		return
+	}
+	if err := (AuditParamsValidator{}).Validate(record); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidPayloadMsg, err.Error()))
+		return
+	}
+
+	created, err := ah.auditManager.CreateAuditRecord(*record)
+	if err != nil {
+		SetInternalServerErrorResponse(c, fmt.Sprintf(UnableCreateAuditRecordMsg, err.Error()))
+		return
+	}
+	c.JSON(http.StatusCreated, &models.CreateAuditRecordResponse{ID: created.ID})
+}
+
+// GetAuditRecords godoc
+// @Summary      Get audit records
+// @Description  Get the audit records of the state-changing API calls that have been handled by the Keptn control plane, ordered from newest to oldest
+// @Tags         Audit
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        service      query     string                          false  "The Keptn service that handled the call"
+// @Param        principal    query     string                          false  "The principal that made the call"
+// @Param        action       query     string                          false  "The action, e.g. 'project.delete'"
+// @Param        targetType   query     string                          false  "The type of the target, e.g. 'project'"
+// @Param        target       query     string                          false  "The target of the action"
+// @Param        project      query     string                          false  "The project"
+// @Param        requestID    query     string                          false  "The ID of the request"
+// @Param        from         query     string                          false  "Only include records created at or after the given point in time (RFC3339)"
+// @Param        to           query     string                          false  "Only include records created before the given point in time (RFC3339)"
+// @Param        pageSize     query     int                             false  "The number of items to return"
+// @Param        nextPageKey  query     int                             false  "Pointer to the next set of items"
+// @Success      200          {object}  models.GetAuditRecordsResponse  "ok"
+// @Failure      400          {object}  models.Error                    "Invalid payload"
+// @Failure      500          {object}  models.Error                    "Internal error"
+// @Router       /audit [get]
+func (ah *AuditHandler) GetAuditRecords(c *gin.Context) {
+	params := &models.GetAuditRecordsParams{}
+	if err := c.ShouldBindQuery(params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidRequestFormatMsg, err.Error()))
+		return
+	}
+	if err := (AuditParamsValidator{}).Validate(params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidRequestFormatMsg, err.Error()))
+		return
+	}
+
+	records, err := ah.auditManager.GetAuditRecords(*params)
+	if err != nil {
+		SetInternalServerErrorResponse(c, fmt.Sprintf(UnableQueryAuditRecordsMsg, err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, records)
+}
@@ -0,0 +1,97 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+	"github.com/keptn/keptn/shipyard-controller/handler/fake"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditHandler_CreateAuditRecord(t *testing.T) {
+	tests := []struct {
+		name         string
+		auditManager *fake.IAuditManagerMock
+		payload      string
+		wantStatus   int
+	}{
+		{
+			name: "create record",
+			auditManager: &fake.IAuditManagerMock{
+				CreateAuditRecordFunc: func(record models.AuditRecord) (*models.AuditRecord, error) {
+					record.ID = "my-record"
+					return &record, nil
+				},
+			},
+			payload:    `{"service":"secret-service","principal":"api-token:1a2b3c4d","action":"secret.delete","targetType":"secret","target":"keptn-default/my-secret","statusCode":200}`,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:         "missing action",
+			auditManager: &fake.IAuditManagerMock{},
+			payload:      `{"service":"secret-service","principal":"api-token:1a2b3c4d"}`,
+			wantStatus:   http.StatusBadRequest,
+		},
+		{
+			name: "internal error",
+			auditManager: &fake.IAuditManagerMock{
+				CreateAuditRecordFunc: func(record models.AuditRecord) (*models.AuditRecord, error) {
+					return nil, errors.New("oops")
+				},
+			},
+			payload:    `{"service":"secret-service","principal":"api-token:1a2b3c4d","action":"secret.delete"}`,
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ah := handler.NewAuditHandler(tt.auditManager)
+
+			router := gin.Default()
+			router.POST("/audit", ah.CreateAuditRecord)
+			w := performRequest(router, httptest.NewRequest(http.MethodPost, "/audit", bytes.NewBufferString(tt.payload)))
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusCreated {
+				response := &models.CreateAuditRecordResponse{}
+				require.Nil(t, json.Unmarshal(w.Body.Bytes(), response))
+				require.Equal(t, "my-record", response.ID)
+				require.Equal(t, "keptn-default/my-secret", tt.auditManager.CreateAuditRecordCalls()[0].Record.Target)
+			}
+		})
+	}
+}
+
+func TestAuditHandler_GetAuditRecords(t *testing.T) {
+	auditManager := &fake.IAuditManagerMock{
+		GetAuditRecordsFunc: func(params models.GetAuditRecordsParams) (*models.GetAuditRecordsResponse, error) {
+			return &models.GetAuditRecordsResponse{
+				TotalCount: 1,
+				Records:    []models.AuditRecord{{ID: "my-record", Action: "project.delete"}},
+			}, nil
+		},
+	}
+	ah := handler.NewAuditHandler(auditManager)
+
+	router := gin.Default()
+	router.GET("/audit", ah.GetAuditRecords)
+	w := performRequest(router, httptest.NewRequest(http.MethodGet, "/audit?project=my-project&action=project.delete&from=2022-06-01T00:00:00Z", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, models.GetAuditRecordsParams{Project: "my-project", Action: "project.delete", From: "2022-06-01T00:00:00Z"}, auditManager.GetAuditRecordsCalls()[0].Params)
+
+	response := &models.GetAuditRecordsResponse{}
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), response))
+	require.Len(t, response.Records, 1)
+
+	w = performRequest(router, httptest.NewRequest(http.MethodGet, "/audit?from=yesterday", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Len(t, auditManager.GetAuditRecordsCalls(), 1)
+}
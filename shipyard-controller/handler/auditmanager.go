@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"github.com/benbjohnson/clock"
+	"github.com/google/uuid"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+)
+
+//go:generate moq -pkg fake -skip-ensure -out ./fake/auditmanager.go . IAuditManager
+type IAuditManager interface {
+	CreateAuditRecord(record models.AuditRecord) (*models.AuditRecord, error)
+	GetAuditRecords(params models.GetAuditRecordsParams) (*models.GetAuditRecordsResponse, error)
+}
+
+// AuditManager stores the audit records of the shipyard-controller, as well as the ones that are sent by the other Keptn services
+type AuditManager struct {
+	auditRepo db.AuditRepo
+	theClock  clock.Clock
+}
+
+func NewAuditManager(auditRepo db.AuditRepo, theClock clock.Clock) *AuditManager {
+	return &AuditManager{
+		auditRepo: auditRepo,
+		theClock:  theClock,
+	}
+}
+
+// CreateAuditRecord stores the given record. The ID and the time of the record are set if they are missing
+func (am *AuditManager) CreateAuditRecord(record models.AuditRecord) (*models.AuditRecord, error) {
+	if record.ID == "" {
+		record.ID = uuid.New().String()
+	}
+	if record.Time.IsZero() {
+		record.Time = am.theClock.Now().UTC()
+	}
+	if err := am.auditRepo.CreateAuditRecord(record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (am *AuditManager) GetAuditRecords(params models.GetAuditRecordsParams) (*models.GetAuditRecordsResponse, error) {
+	return am.auditRepo.GetAuditRecords(params)
+}
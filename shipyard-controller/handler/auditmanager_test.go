@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditManager_CreateAuditRecord(t *testing.T) {
+	theClock := clock.NewMock()
+	theClock.Set(time.Date(2022, 6, 1, 2, 0, 0, 0, time.UTC))
+
+	auditRepo := &db_mock.AuditRepoMock{
+		CreateAuditRecordFunc: func(record models.AuditRecord) error {
+			return nil
+		},
+	}
+	am := NewAuditManager(auditRepo, theClock)
+
+	record, err := am.CreateAuditRecord(models.AuditRecord{Service: "secret-service", Principal: "internal", Action: "secret.create"})
+	require.Nil(t, err)
+	require.NotEmpty(t, record.ID)
+	require.Equal(t, theClock.Now().UTC(), record.Time)
+	require.Equal(t, *record, auditRepo.CreateAuditRecordCalls()[0].Record)
+
+	// records sent by other services keep their ID and time
+	sentAt := theClock.Now().UTC().Add(-time.Second)
+	record, err = am.CreateAuditRecord(models.AuditRecord{ID: "my-record", Time: sentAt, Service: "secret-service", Principal: "internal", Action: "secret.create"})
+	require.Nil(t, err)
+	require.Equal(t, "my-record", record.ID)
+	require.Equal(t, sentAt, record.Time)
+}
@@ -0,0 +1,377 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// auditPrincipalHeader carries the principal that has been authenticated by the API gateway
+	auditPrincipalHeader = "X-Keptn-Principal"
+	// requestIDHeader carries the ID of the request that has been assigned by the API gateway
+	requestIDHeader = "X-Request-ID"
+
+	auditServiceName = "shipyard-controller"
+
+	maxAuditSummaryLength = 2048
+	maxAuditValueLength   = 256
+	redactedAuditValue    = "***"
+)
+
+// sensitiveAuditFields contains parts of the names of payload fields whose values are never included in audit records
+var sensitiveAuditFields = []string{"token", "password", "privatekey", "pass", "secret", "header"}
+
+// AuditAction describes how calls to a route of the API are recorded in the audit log
+type AuditAction struct {
+	// Name is the name of the action, e.g. 'project.delete'
+	Name string
+	// TargetType is the type of the object the action is applied to, e.g. 'project'
+	TargetType string
+	// TargetParams are the path parameters that identify the target
+	TargetParams []string
+	// TargetField is the field of the request payload that identifies the target, if it is not part of the path
+	TargetField string
+	// TargetResponseField is the field of the response payload that identifies the target, e.g. for objects whose ID is generated
+	TargetResponseField string
+	// ProjectField is the field of the request payload that contains the project, if it is not part of the path
+	ProjectField string
+	// Before returns the state of the target before the call
+	Before func(project, target string) interface{}
+}
+
+// NewAuditActions returns the audit actions of the state-changing routes of the shipyard-controller API.
+// Calls to routes that are not included, e.g. the heartbeats of integrations, are not recorded
+func NewAuditActions(projectMVRepo db.ProjectMVRepo) map[string]AuditAction {
+	projectBefore := func(project, _ string) interface{} {
+		return getProjectAuditSummary(projectMVRepo, project)
+	}
+	stageBefore := func(project, stage string) interface{} {
+		return getStageAuditSummary(projectMVRepo, project, stage)
+	}
+	serviceBefore := func(project, service string) interface{} {
+		return getServiceAuditSummary(projectMVRepo, project, service)
+	}
+
+	return map[string]AuditAction{
+		"POST /v1/project":                 {Name: "project.create", TargetType: "project", TargetField: "name", ProjectField: "name"},
+		"PUT /v1/project":                  {Name: "project.update", TargetType: "project", TargetField: "name", ProjectField: "name", Before: projectBefore},
+		"DELETE /v1/project/:project":      {Name: "project.delete", TargetType: "project", TargetParams: []string{"project"}, Before: projectBefore},
+		"POST /v1/project/:project/import": {Name: "project.import", TargetType: "project", TargetParams: []string{"project"}},
+		"POST /v1/project/:project/stage":  {Name: "stage.create", TargetType: "stage", TargetField: "stageName"},
+		"PUT /v1/project/:project/stage/:stage": {
+			Name: "stage.update", TargetType: "stage", TargetParams: []string{"stage"}, Before: stageBefore,
+		},
+		"DELETE /v1/project/:project/stage/:stage": {
+			Name: "stage.delete", TargetType: "stage", TargetParams: []string{"stage"}, Before: stageBefore,
+		},
+		"POST /v1/project/:project/service": {Name: "service.create", TargetType: "service", TargetField: "serviceName"},
+		"PUT /v1/project/:project/service/:service": {
+			Name: "service.update", TargetType: "service", TargetParams: []string{"service"}, Before: serviceBefore,
+		},
+		"DELETE /v1/project/:project/service/:service": {
+			Name: "service.delete", TargetType: "service", TargetParams: []string{"service"}, Before: serviceBefore,
+		},
+		"POST /v1/project/:project/stage/:stage/service/:service/evaluation": {
+			Name: "evaluation.trigger", TargetType: "service", TargetParams: []string{"stage", "service"},
+		},
+		"POST /v1/sequence/:project/:keptnContext/control": {
+			Name: "sequence.control", TargetType: "sequence", TargetParams: []string{"keptnContext"},
+		},
+		"POST /v1/schedule":               {Name: "schedule.create", TargetType: "schedule", TargetResponseField: "id", ProjectField: "project"},
+		"PUT /v1/schedule/:scheduleId":    {Name: "schedule.update", TargetType: "schedule", TargetParams: []string{"scheduleId"}, ProjectField: "project"},
+		"DELETE /v1/schedule/:scheduleId": {Name: "schedule.delete", TargetType: "schedule", TargetParams: []string{"scheduleId"}},
+		"POST /v1/project/:project/notification/channel": {
+			Name: "notificationchannel.create", TargetType: "notificationchannel", TargetResponseField: "id",
+		},
+		"PUT /v1/project/:project/notification/channel/:channelId": {
+			Name: "notificationchannel.update", TargetType: "notificationchannel", TargetParams: []string{"channelId"},
+		},
+		"DELETE /v1/project/:project/notification/channel/:channelId": {
+			Name: "notificationchannel.delete", TargetType: "notificationchannel", TargetParams: []string{"channelId"},
+		},
+		"POST /v1/uniform/registration": {Name: "integration.register", TargetType: "integration", TargetResponseField: "id"},
+		"DELETE /v1/uniform/registration/:integrationID": {
+			Name: "integration.unregister", TargetType: "integration", TargetParams: []string{"integrationID"},
+		},
+		"POST /v1/uniform/registration/:integrationID/subscription": {
+			Name: "subscription.create", TargetType: "subscription", TargetParams: []string{"integrationID"}, TargetResponseField: "id",
+		},
+		"PUT /v1/uniform/registration/:integrationID/subscription/:subscriptionID": {
+			Name: "subscription.update", TargetType: "subscription", TargetParams: []string{"integrationID", "subscriptionID"},
+		},
+		"DELETE /v1/uniform/registration/:integrationID/subscription/:subscriptionID": {
+			Name: "subscription.delete", TargetType: "subscription", TargetParams: []string{"integrationID", "subscriptionID"},
+		},
+		"DELETE /v1/log":              {Name: "log.delete", TargetType: "log"},
+		"POST /v1/token":              {Name: "token.create", TargetType: "token", TargetField: "name"},
+		"POST /v1/token/:name/rotate": {Name: "token.rotate", TargetType: "token", TargetParams: []string{"name"}},
+		"DELETE /v1/token/:name":      {Name: "token.delete", TargetType: "token", TargetParams: []string{"name"}},
+	}
+}
+
+// AuditMiddleware records an audit record for each call to one of the given routes, once the call has been handled
+func AuditMiddleware(auditManager IAuditManager, actions map[string]AuditAction) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		action, ok := actions[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			c.Next()
+			return
+		}
+
+		requestID := c.GetHeader(requestIDHeader)
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+		c.Header(requestIDHeader, requestID)
+
+		payload := readAuditPayload(c)
+
+		record := models.AuditRecord{
+			Service:    auditServiceName,
+			Principal:  c.GetHeader(auditPrincipalHeader),
+			ClientIP:   c.ClientIP(),
+			Action:     action.Name,
+			TargetType: action.TargetType,
+			Target:     getAuditTarget(c, action, payload),
+			Project:    c.Param("project"),
+			RequestID:  requestID,
+		}
+		if record.Principal == "" {
+			record.Principal = models.AuditPrincipalInternal
+		}
+		if record.Project == "" && action.ProjectField != "" {
+			record.Project = getPayloadField(payload, action.ProjectField)
+		}
+		if action.Before != nil {
+			record.Before = SummarizeForAudit(action.Before(record.Project, record.Target))
+		}
+		if payload != nil {
+			record.After = SummarizeForAudit(payload)
+		}
+
+		var responseWriter *auditResponseWriter
+		if action.TargetResponseField != "" {
+			responseWriter = &auditResponseWriter{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+			c.Writer = responseWriter
+		}
+
+		c.Next()
+
+		record.StatusCode = c.Writer.Status()
+		if responseWriter != nil && record.Target == "" {
+			response := map[string]interface{}{}
+			if err := json.Unmarshal(responseWriter.body.Bytes(), &response); err == nil {
+				record.Target = getPayloadField(response, action.TargetResponseField)
+			}
+		}
+
+		if _, err := auditManager.CreateAuditRecord(record); err != nil {
+			log.WithError(err).Errorf("could not store audit record of action %s on %s %s", record.Action, record.TargetType, record.Target)
+		}
+	}
+}
+
+// SummarizeForAudit returns a compact JSON representation of the given value that can be included in an audit record.
+// Values of fields that may contain credentials are redacted, credentials contained in URLs are removed and long values are truncated
+func SummarizeForAudit(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return ""
+	}
+	summary, err := json.Marshal(redactAuditValue("", generic))
+	if err != nil {
+		return ""
+	}
+	if len(summary) > maxAuditSummaryLength {
+		return string(summary[:maxAuditSummaryLength]) + "..."
+	}
+	return string(summary)
+}
+
+func redactAuditValue(field string, value interface{}) interface{} {
+	lowerField := strings.ToLower(field)
+	for _, sensitiveField := range sensitiveAuditFields {
+		if strings.Contains(lowerField, sensitiveField) {
+			return redactedAuditValue
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			v[key] = redactAuditValue(key, nested)
+		}
+		return v
+	case []interface{}:
+		for index, nested := range v {
+			v[index] = redactAuditValue(field, nested)
+		}
+		return v
+	case string:
+		// the URL of a webhook often contains a secret itself, e.g. for Slack
+		if lowerField == "url" && v != "" {
+			return redactedAuditValue
+		}
+		if len(v) > maxAuditValueLength {
+			return fmt.Sprintf("<%d characters>", len(v))
+		}
+		return removeURLCredentials(v)
+	default:
+		return v
+	}
+}
+
+func removeURLCredentials(value string) string {
+	if !strings.Contains(value, "@") || !strings.Contains(value, "://") {
+		return value
+	}
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.User == nil {
+		return value
+	}
+	parsed.User = nil
+	return parsed.String()
+}
+
+// readAuditPayload returns the decoded JSON payload of the request. The body of the request is restored, so that it can still be read by the handler
+func readAuditPayload(c *gin.Context) map[string]interface{} {
+	if c.Request.Body == nil || c.Request.Body == http.NoBody {
+		return nil
+	}
+	if contentType := c.ContentType(); contentType != "" && !strings.Contains(contentType, "json") {
+		return nil
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil || len(body) == 0 {
+		return nil
+	}
+	payload := map[string]interface{}{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil
+	}
+	return payload
+}
+
+func getAuditTarget(c *gin.Context, action AuditAction, payload map[string]interface{}) string {
+	if len(action.TargetParams) > 0 {
+		values := []string{}
+		for _, param := range action.TargetParams {
+			values = append(values, c.Param(param))
+		}
+		return strings.Join(values, "/")
+	}
+	if action.TargetField != "" {
+		return getPayloadField(payload, action.TargetField)
+	}
+	return ""
+}
+
+func getPayloadField(payload map[string]interface{}, field string) string {
+	if value, ok := payload[field].(string); ok {
+		return value
+	}
+	return ""
+}
+
+// auditResponseWriter keeps a copy of the response body, so that generated IDs can be added to the audit record
+type auditResponseWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *auditResponseWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func getProjectAuditSummary(projectMVRepo db.ProjectMVRepo, projectName string) interface{} {
+	project, err := projectMVRepo.GetProject(projectName)
+	if err != nil || project == nil {
+		return nil
+	}
+	stages := []string{}
+	for _, stage := range project.Stages {
+		stages = append(stages, stage.StageName)
+	}
+	return map[string]interface{}{
+		"gitRemoteURL":    project.GitRemoteURI,
+		"gitUser":         project.GitUser,
+		"gitProxyURL":     project.GitProxyURL,
+		"insecureSkipTLS": project.InsecureSkipTLS,
+		"shipyardVersion": project.ShipyardVersion,
+		"stages":          stages,
+	}
+}
+
+func getStageAuditSummary(projectMVRepo db.ProjectMVRepo, projectName, stageName string) interface{} {
+	stage := getExpandedStage(projectMVRepo, projectName, stageName)
+	if stage == nil {
+		return nil
+	}
+	services := []string{}
+	for _, service := range stage.Services {
+		services = append(services, service.ServiceName)
+	}
+	return map[string]interface{}{
+		"stageName":    stage.StageName,
+		"parentStages": stage.ParentStages,
+		"services":     services,
+	}
+}
+
+func getServiceAuditSummary(projectMVRepo db.ProjectMVRepo, projectName, serviceName string) interface{} {
+	project, err := projectMVRepo.GetProject(projectName)
+	if err != nil || project == nil {
+		return nil
+	}
+	deployedImages := map[string]string{}
+	found := false
+	for _, stage := range project.Stages {
+		for _, service := range stage.Services {
+			if service.ServiceName == serviceName {
+				found = true
+				deployedImages[stage.StageName] = service.DeployedImage
+			}
+		}
+	}
+	if !found {
+		return nil
+	}
+	return map[string]interface{}{
+		"serviceName":    serviceName,
+		"deployedImages": deployedImages,
+	}
+}
+
+func getExpandedStage(projectMVRepo db.ProjectMVRepo, projectName, stageName string) *apimodels.ExpandedStage {
+	project, err := projectMVRepo.GetProject(projectName)
+	if err != nil || project == nil {
+		return nil
+	}
+	for _, stage := range project.Stages {
+		if stage.StageName == stageName {
+			return stage
+		}
+	}
+	return nil
+}
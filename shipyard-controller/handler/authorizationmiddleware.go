@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/models"
+)
+
+const (
+	// rolesHeader carries the comma separated roles of the API token that has been authenticated by the API gateway
+	rolesHeader = "X-Keptn-Roles"
+	// projectsHeader carries the comma separated projects the API token is limited to. If it is empty, the token is not limited
+	projectsHeader = "X-Keptn-Projects"
+	// internalTokenHeader carries the token Keptn services within the cluster authenticate with if they call the shipyard-controller directly
+	internalTokenHeader = "x-token"
+
+	authorizedProjectsKey = "authorizedProjects"
+
+	approvalFinishedEventSuffix = ".approval.finished"
+)
+
+type permission int
+
+const (
+	permissionRead permission = iota
+	permissionApprove
+	permissionOperate
+	permissionAdmin
+)
+
+var rolePermissions = map[string]permission{
+	models.TokenRoleViewer:   permissionRead,
+	models.TokenRoleApprover: permissionApprove,
+	models.TokenRoleOperator: permissionOperate,
+	models.TokenRoleAdmin:    permissionAdmin,
+}
+
+// adminRoutes can only be called with a token that has the admin role
+var adminRoutes = map[string]bool{
+	"POST /v1/project":            true,
+	"DELETE /v1/project/:project": true,
+	"GET /v1/audit":               true,
+	"POST /v1/token":              true,
+	"GET /v1/token":               true,
+	"POST /v1/token/:name/rotate": true,
+	"DELETE /v1/token/:name":      true,
+}
+
+// publicRoutes can be called without roles and without a token, e.g. by the probes of Kubernetes
+var publicRoutes = map[string]bool{
+	"GET /health":                true,
+	"GET /metrics":               true,
+	"GET /swagger-ui/*filepath":  true,
+	"HEAD /swagger-ui/*filepath": true,
+}
+
+// legacyInternalRoutes can also be called without roles and without the internal API token, in addition to all reading routes
+// that do not require the admin role. They are used by Keptn services within the cluster that are built against client versions
+// which do not send the internal API token yet
+var legacyInternalRoutes = map[string]bool{
+	"POST /v1/log":                                     true,
+	"POST /v1/uniform/registration":                    true,
+	"PUT /v1/uniform/registration/:integrationID/ping": true,
+	"DELETE /v1/uniform/registration/:integrationID":   true,
+}
+
+// unscopedReadRoutes can be called with a token that is limited to a set of projects, even though they do not refer to a single project.
+// The list of projects is filtered by the ProjectHandler
+var unscopedReadRoutes = map[string]bool{
+	"GET /v1/project":              true,
+	"GET /v1/uniform/registration": true,
+	"GET /v1/log":                  true,
+}
+
+// AuthorizationMiddleware checks whether the roles and projects of the API token that has been authenticated by the API gateway
+// permit the call. Apart from the public routes, every call must carry the given internal API token, which is sent by the API gateway
+// and by other Keptn services within the cluster. Calls of Keptn services do not carry roles and are not restricted any further.
+// Calls without roles and without a token are limited to the routes of legacyInternalRoutes and to reading routes.
+// If no internal API token is configured, all other calls are denied
+func AuthorizationMiddleware(internalAPIToken string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.FullPath() == "" {
+			c.Next()
+			return
+		}
+		route := c.Request.Method + " " + c.FullPath()
+		if publicRoutes[route] {
+			c.Next()
+			return
+		}
+
+		// the roles and projects headers can be set by anyone who can reach the shipyard-controller, so they are only
+		// trusted if the call also carries the internal API token, which the API gateway adds after authenticating the caller
+		if !isInternalCall(c, internalAPIToken) {
+			if !isLegacyInternalCall(c, route) {
+				SetForbiddenErrorResponse(c, ErrInsufficientPermissions.Error())
+				c.Abort()
+				return
+			}
+			c.Next()
+			return
+		}
+
+		rolesValue := c.GetHeader(rolesHeader)
+		if rolesValue == "" {
+			c.Next()
+			return
+		}
+
+		payload := readAuditPayload(c)
+
+		if getGrantedPermission(splitHeaderValues(rolesValue)) < getRequiredPermission(c.Request.Method, route, payload) {
+			SetForbiddenErrorResponse(c, ErrInsufficientPermissions.Error())
+			c.Abort()
+			return
+		}
+
+		projects := splitHeaderValues(c.GetHeader(projectsHeader))
+		if len(projects) > 0 {
+			project := getRequestProject(c, route, payload)
+			if (project == "" && !unscopedReadRoutes[route]) || (project != "" && !containsString(projects, project)) {
+				SetForbiddenErrorResponse(c, ErrInsufficientPermissions.Error())
+				c.Abort()
+				return
+			}
+			c.Set(authorizedProjectsKey, projects)
+		}
+		c.Next()
+	}
+}
+
+// GetAuthorizedProjects returns the projects the caller is limited to. If the caller is not limited, false is returned
+func GetAuthorizedProjects(c *gin.Context) ([]string, bool) {
+	value, ok := c.Get(authorizedProjectsKey)
+	if !ok {
+		return nil, false
+	}
+	projects, ok := value.([]string)
+	return projects, ok
+}
+
+func isInternalCall(c *gin.Context, internalAPIToken string) bool {
+	if internalAPIToken == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(c.GetHeader(internalTokenHeader)), []byte(internalAPIToken)) == 1
+}
+
+// isLegacyInternalCall checks whether a call without the internal API token is sent by a Keptn service that does not send the token yet.
+// Such calls must neither carry a token nor roles, and are limited to routes that do not change the configuration of Keptn
+func isLegacyInternalCall(c *gin.Context, route string) bool {
+	if c.GetHeader(internalTokenHeader) != "" || c.GetHeader(rolesHeader) != "" {
+		return false
+	}
+	return legacyInternalRoutes[route] || getRequiredPermission(c.Request.Method, route, nil) == permissionRead
+}
+
+func getRequiredPermission(method, route string, payload map[string]interface{}) permission {
+	if adminRoutes[route] {
+		return permissionAdmin
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return permissionRead
+	}
+	if route == "POST /v1/event" && strings.HasSuffix(getPayloadField(payload, "type"), approvalFinishedEventSuffix) {
+		return permissionApprove
+	}
+	return permissionOperate
+}
+
+func getGrantedPermission(roles []string) permission {
+	granted := permission(-1)
+	for _, role := range roles {
+		if p, ok := rolePermissions[role]; ok && p > granted {
+			granted = p
+		}
+	}
+	return granted
+}
+
+func getRequestProject(c *gin.Context, route string, payload map[string]interface{}) string {
+	if project := c.Param("project"); project != "" {
+		return project
+	}
+	if project := c.Query("project"); project != "" {
+		return project
+	}
+	if route == "PUT /v1/project" {
+		return getPayloadField(payload, "name")
+	}
+	if project := getPayloadField(payload, "project"); project != "" {
+		return project
+	}
+	if data, ok := payload["data"].(map[string]interface{}); ok {
+		return getPayloadField(data, "project")
+	}
+	return ""
+}
+
+func splitHeaderValues(value string) []string {
+	values := []string{}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
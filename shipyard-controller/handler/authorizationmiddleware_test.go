@@ -0,0 +1,298 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+	"github.com/stretchr/testify/require"
+)
+
+const internalAPIToken = "internal-token"
+
+func newAuthorizationTestRouter() *gin.Engine {
+	router := gin.New()
+	router.Use(handler.AuthorizationMiddleware(internalAPIToken))
+	router.GET("/health", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	apiV1 := router.Group("/v1")
+	ok := func(c *gin.Context) {
+		projects, _ := handler.GetAuthorizedProjects(c)
+		c.JSON(http.StatusOK, projects)
+	}
+	apiV1.GET("/project", ok)
+	apiV1.POST("/project", ok)
+	apiV1.GET("/project/:project", ok)
+	apiV1.DELETE("/project/:project", ok)
+	apiV1.POST("/project/:project/service", ok)
+	apiV1.POST("/event", func(c *gin.Context) {
+		// the payload must still be readable by the handler
+		body, _ := io.ReadAll(c.Request.Body)
+		if len(body) == 0 {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	apiV1.DELETE("/schedule/:scheduleId", ok)
+	apiV1.GET("/token", ok)
+	apiV1.POST("/audit", ok)
+	apiV1.PUT("/uniform/registration/:integrationID/ping", ok)
+	return router
+}
+
+func TestAuthorizationMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		payload    string
+		roles      string
+		projects   string
+		token      string
+		wantStatus int
+	}{
+		{
+			name:       "internal call without roles",
+			method:     http.MethodDelete,
+			path:       "/v1/project/sockshop",
+			token:      internalAPIToken,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "call without roles and token",
+			method:     http.MethodDelete,
+			path:       "/v1/project/sockshop",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "call without roles and with invalid token",
+			method:     http.MethodGet,
+			path:       "/v1/project/sockshop",
+			token:      "other-token",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "internal call creates audit record",
+			method:     http.MethodPost,
+			path:       "/v1/audit",
+			payload:    `{"service":"api-service","action":"event.send"}`,
+			token:      internalAPIToken,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "call without token creates audit record",
+			method:     http.MethodPost,
+			path:       "/v1/audit",
+			payload:    `{"service":"api-service","action":"event.send"}`,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "legacy internal call reads project",
+			method:     http.MethodGet,
+			path:       "/v1/project/sockshop",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "legacy internal call pings integration",
+			method:     http.MethodPut,
+			path:       "/v1/uniform/registration/my-integration/ping",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "legacy internal call lists tokens",
+			method:     http.MethodGet,
+			path:       "/v1/token",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "legacy internal call sends event",
+			method:     http.MethodPost,
+			path:       "/v1/event",
+			payload:    `{"type":"sh.keptn.event.dev.delivery.triggered","data":{"project":"sockshop"}}`,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "viewer without token reads project",
+			method:     http.MethodGet,
+			path:       "/v1/project/sockshop",
+			roles:      "viewer",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "health check without roles",
+			method:     http.MethodGet,
+			path:       "/health",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "viewer reads project",
+			method:     http.MethodGet,
+			path:       "/v1/project/sockshop",
+			roles:      "viewer",
+			token:      internalAPIToken,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "admin without token",
+			method:     http.MethodDelete,
+			path:       "/v1/project/sockshop",
+			roles:      "admin",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "admin with invalid token",
+			method:     http.MethodDelete,
+			path:       "/v1/project/sockshop",
+			roles:      "admin",
+			token:      "other-token",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "viewer creates service",
+			method:     http.MethodPost,
+			path:       "/v1/project/sockshop/service",
+			payload:    `{"serviceName":"carts"}`,
+			roles:      "viewer",
+			token:      internalAPIToken,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "unknown role",
+			method:     http.MethodGet,
+			path:       "/v1/project/sockshop",
+			roles:      "root",
+			token:      internalAPIToken,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "operator creates service",
+			method:     http.MethodPost,
+			path:       "/v1/project/sockshop/service",
+			payload:    `{"serviceName":"carts"}`,
+			roles:      "operator",
+			token:      internalAPIToken,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "operator deletes project",
+			method:     http.MethodDelete,
+			path:       "/v1/project/sockshop",
+			roles:      "operator",
+			token:      internalAPIToken,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "admin deletes project",
+			method:     http.MethodDelete,
+			path:       "/v1/project/sockshop",
+			roles:      "viewer,admin",
+			token:      internalAPIToken,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "operator lists tokens",
+			method:     http.MethodGet,
+			path:       "/v1/token",
+			roles:      "operator",
+			token:      internalAPIToken,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "approver sends approval",
+			method:     http.MethodPost,
+			path:       "/v1/event",
+			payload:    `{"type":"sh.keptn.event.approval.finished","data":{"project":"sockshop"}}`,
+			roles:      "approver",
+			projects:   "sockshop",
+			token:      internalAPIToken,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "approver triggers delivery",
+			method:     http.MethodPost,
+			path:       "/v1/event",
+			payload:    `{"type":"sh.keptn.event.dev.delivery.triggered","data":{"project":"sockshop"}}`,
+			roles:      "approver",
+			token:      internalAPIToken,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "scoped token sends event of other project",
+			method:     http.MethodPost,
+			path:       "/v1/event",
+			payload:    `{"type":"sh.keptn.event.dev.delivery.triggered","data":{"project":"podtato-head"}}`,
+			roles:      "operator",
+			projects:   "sockshop",
+			token:      internalAPIToken,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "scoped token reads other project",
+			method:     http.MethodGet,
+			path:       "/v1/project/podtato-head",
+			roles:      "viewer",
+			projects:   "sockshop, carts",
+			token:      internalAPIToken,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "scoped token without project",
+			method:     http.MethodDelete,
+			path:       "/v1/schedule/my-schedule",
+			roles:      "operator",
+			projects:   "sockshop",
+			token:      internalAPIToken,
+			wantStatus: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newAuthorizationTestRouter()
+
+			request := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.payload))
+			request.Header.Set("X-Keptn-Roles", tt.roles)
+			request.Header.Set("X-Keptn-Projects", tt.projects)
+			request.Header.Set("x-token", tt.token)
+			w := performRequest(router, request)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestAuthorizationMiddleware_NoInternalToken(t *testing.T) {
+	router := gin.New()
+	router.Use(handler.AuthorizationMiddleware(""))
+	router.DELETE("/v1/project/:project", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	// calls to non-public routes are denied if no internal token is configured, even if they send an empty token
+	request := httptest.NewRequest(http.MethodDelete, "/v1/project/sockshop", nil)
+	request.Header.Set("x-token", "")
+	w := performRequest(router, request)
+
+	require.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestAuthorizationMiddleware_ListProjects(t *testing.T) {
+	router := newAuthorizationTestRouter()
+
+	request := httptest.NewRequest(http.MethodGet, "/v1/project", nil)
+	request.Header.Set("X-Keptn-Roles", "viewer")
+	request.Header.Set("X-Keptn-Projects", "sockshop,podtato-head")
+	request.Header.Set("x-token", internalAPIToken)
+	w := performRequest(router, request)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	projects := []string{}
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), &projects))
+	require.Equal(t, []string{"sockshop", "podtato-head"}, projects)
+}
@@ -54,3 +54,17 @@ func SetConflictErrorResponse(c *gin.Context, msg string) {
 		Message: &msg,
 	})
 }
+
+func SetUnauthorizedErrorResponse(c *gin.Context, msg string) {
+	c.JSON(http.StatusUnauthorized, models.Error{
+		Code:    http.StatusUnauthorized,
+		Message: &msg,
+	})
+}
+
+func SetForbiddenErrorResponse(c *gin.Context, msg string) {
+	c.JSON(http.StatusForbidden, models.Error{
+		Code:    http.StatusForbidden,
+		Message: &msg,
+	})
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/common"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+)
+
+//go:generate moq -pkg fake -skip-ensure -out ./fake/concurrencylimiter.go . IConcurrencyLimiter
+// IConcurrencyLimiter determines whether a sequence may run, based on the number of sequences that are already running
+type IConcurrencyLimiter interface {
+	// CheckLimits returns an error wrapping ErrConcurrencyLimitReached if the given sequence must not run, because one of the concurrency limits has been reached
+	CheckLimits(sequenceExecution models.SequenceExecution) error
+	// RunWithinLimits executes the given function if none of the concurrency limits prevents the given sequence from running.
+	// The limits are checked and the function is executed while holding the lock of each limit that applies to the sequence, so that the
+	// free slots cannot be taken by another sequence in the meantime. The context passed to the function is cancelled if one of the locks,
+	// or the given context, is done
+	RunWithinLimits(ctx context.Context, sequenceExecution models.SequenceExecution, fn func(ctx context.Context) error) error
+}
+
+type ConcurrencyLimiter struct {
+	limits                common.ConcurrencyLimits
+	sequenceExecutionRepo db.SequenceExecutionRepo
+	projectMVRepo         db.ProjectMVRepo
+	locker                ILocker
+}
+
+// NewConcurrencyLimiter creates a new ConcurrencyLimiter
+func NewConcurrencyLimiter(limits common.ConcurrencyLimits, sequenceExecutionRepo db.SequenceExecutionRepo, projectMVRepo db.ProjectMVRepo, locker ILocker) *ConcurrencyLimiter {
+	return &ConcurrencyLimiter{
+		limits:                limits,
+		sequenceExecutionRepo: sequenceExecutionRepo,
+		projectMVRepo:         projectMVRepo,
+		locker:                locker,
+	}
+}
+
+func (cl *ConcurrencyLimiter) RunWithinLimits(ctx context.Context, sequenceExecution models.SequenceExecution, fn func(ctx context.Context) error) error {
+	return cl.withLimitLocks(ctx, cl.getLimitLockKeys(sequenceExecution), func(ctx context.Context) error {
+		if err := cl.CheckLimits(sequenceExecution); err != nil {
+			return err
+		}
+		return fn(ctx)
+	})
+}
+
+// withLimitLocks executes the given function while holding all locks with the given keys. The locks are acquired one after another,
+// and db.ErrLockHeld is returned as soon as one of them is held by someone else
+func (cl *ConcurrencyLimiter) withLimitLocks(ctx context.Context, keys []string, fn func(ctx context.Context) error) error {
+	if len(keys) == 0 || cl.locker == nil {
+		return fn(ctx)
+	}
+	return withLock(cl.locker, keys[0], func(lockCtx context.Context) error {
+		ctx, cancel := cancelWhenDone(ctx, lockCtx)
+		defer cancel()
+		return cl.withLimitLocks(ctx, keys[1:], fn)
+	})
+}
+
+// getLimitLockKeys returns the keys of the locks of all limits that apply to the given sequence, always in the same order
+func (cl *ConcurrencyLimiter) getLimitLockKeys(sequenceExecution models.SequenceExecution) []string {
+	keys := []string{}
+	if cl.limits.MaxPerProject > 0 {
+		keys = append(keys, projectConcurrencyLimitLockKey(sequenceExecution.Scope.Project))
+	}
+	if cl.limits.MaxPerStage > 0 {
+		keys = append(keys, stageConcurrencyLimitLockKey(sequenceExecution.Scope.Project, sequenceExecution.Scope.Stage))
+	}
+	if cl.limits.MaxPerSequence[sequenceExecution.Sequence.Name] > 0 {
+		keys = append(keys, sequenceConcurrencyLimitLockKey(sequenceExecution.Sequence.Name))
+	}
+	return keys
+}
+
+func (cl *ConcurrencyLimiter) CheckLimits(sequenceExecution models.SequenceExecution) error {
+	if cl.limits.MaxPerProject > 0 {
+		running, err := cl.getRunningSequences(sequenceExecution.Scope.Project, "", "")
+		if err != nil {
+			return err
+		}
+		if err := checkLimit(sequenceExecution, running, cl.limits.MaxPerProject, "in project "+sequenceExecution.Scope.Project); err != nil {
+			return err
+		}
+	}
+
+	if cl.limits.MaxPerStage > 0 {
+		running, err := cl.getRunningSequences(sequenceExecution.Scope.Project, sequenceExecution.Scope.Stage, "")
+		if err != nil {
+			return err
+		}
+		if err := checkLimit(sequenceExecution, running, cl.limits.MaxPerStage, fmt.Sprintf("in stage %s of project %s", sequenceExecution.Scope.Stage, sequenceExecution.Scope.Project)); err != nil {
+			return err
+		}
+	}
+
+	if limit := cl.limits.MaxPerSequence[sequenceExecution.Sequence.Name]; limit > 0 {
+		// sequence executions are stored per project, so we need to look into each of them
+		projects, err := cl.projectMVRepo.GetProjects()
+		if err != nil {
+			return fmt.Errorf("could not load projects: %w", err)
+		}
+		running := []models.SequenceExecution{}
+		for _, project := range projects {
+			runningInProject, err := cl.getRunningSequences(project.ProjectName, "", sequenceExecution.Sequence.Name)
+			if err != nil {
+				return err
+			}
+			running = append(running, runningInProject...)
+		}
+		if err := checkLimit(sequenceExecution, running, limit, "with the name "+sequenceExecution.Sequence.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cl *ConcurrencyLimiter) getRunningSequences(project, stage, name string) ([]models.SequenceExecution, error) {
+	running, err := cl.sequenceExecutionRepo.Get(models.SequenceExecutionFilter{
+		Scope: models.EventScope{
+			EventData: keptnv2.EventData{
+				Project: project,
+				Stage:   stage,
+			},
+		},
+		Name:   name,
+		Status: []string{apimodels.SequenceStartedState},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not load running sequences of project %s: %w", project, err)
+	}
+	return running, nil
+}
+
+// checkLimit returns an error wrapping ErrConcurrencyLimitReached if the number of running sequences that take precedence over the given one has reached the limit.
+// If the given sequence is not running yet, all running sequences take precedence over it. Otherwise, only the ones with a higher priority, or the ones that have been triggered earlier
+// take precedence. This way, if more sequences than allowed are running (e.g. because the limits have been lowered), only the ones within the limit can proceed
+func checkLimit(sequenceExecution models.SequenceExecution, running []models.SequenceExecution, limit int, scope string) error {
+	ahead := 0
+	for _, other := range running {
+		if other.ID == sequenceExecution.ID {
+			continue
+		}
+		if sequenceExecution.Status.State != apimodels.SequenceStartedState || precedes(other, sequenceExecution) {
+			ahead++
+		}
+	}
+	if ahead >= limit {
+		return fmt.Errorf("%w: %d sequences are already running %s (limit: %d)", ErrConcurrencyLimitReached, ahead, scope, limit)
+	}
+	return nil
+}
+
+// precedes returns true if sequence a should be run before sequence b
+func precedes(a, b models.SequenceExecution) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	if !a.TriggeredAt.Equal(b.TriggeredAt) {
+		return a.TriggeredAt.Before(b.TriggeredAt)
+	}
+	return a.ID < b.ID
+}
@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/common"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
+	"github.com/keptn/keptn/shipyard-controller/handler/fake"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func newConcurrencyLimiterTestSequence(id, project, stage, name, state string, priority int, triggeredAt time.Time) models.SequenceExecution {
+	return models.SequenceExecution{
+		ID:       id,
+		Sequence: keptnv2.Sequence{Name: name},
+		Status:   models.SequenceExecutionStatus{State: state},
+		Scope: models.EventScope{
+			EventData: keptnv2.EventData{
+				Project: project,
+				Stage:   stage,
+			},
+		},
+		Priority:    priority,
+		TriggeredAt: triggeredAt,
+	}
+}
+
+func TestConcurrencyLimiter_CheckLimits(t *testing.T) {
+	now := time.Now().UTC()
+	running := []models.SequenceExecution{
+		newConcurrencyLimiterTestSequence("running-1", "my-project", "dev", "delivery", apimodels.SequenceStartedState, 0, now.Add(-2*time.Minute)),
+		newConcurrencyLimiterTestSequence("running-2", "my-project", "prod", "delivery", apimodels.SequenceStartedState, 0, now.Add(-time.Minute)),
+	}
+
+	sequenceExecutionRepo := &db_mock.SequenceExecutionRepoMock{
+		GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+			result := []models.SequenceExecution{}
+			for _, sequenceExecution := range running {
+				if filter.Scope.Stage != "" && filter.Scope.Stage != sequenceExecution.Scope.Stage {
+					continue
+				}
+				result = append(result, sequenceExecution)
+			}
+			return result, nil
+		},
+	}
+
+	tests := []struct {
+		name              string
+		limits            common.ConcurrencyLimits
+		sequenceExecution models.SequenceExecution
+		wantErr           bool
+	}{
+		{
+			name:              "no limits",
+			limits:            common.ConcurrencyLimits{},
+			sequenceExecution: newConcurrencyLimiterTestSequence("new", "my-project", "dev", "delivery", apimodels.SequenceTriggeredState, 0, now),
+		},
+		{
+			name:              "project limit reached",
+			limits:            common.ConcurrencyLimits{MaxPerProject: 2},
+			sequenceExecution: newConcurrencyLimiterTestSequence("new", "my-project", "dev", "delivery", apimodels.SequenceTriggeredState, 0, now),
+			wantErr:           true,
+		},
+		{
+			name:              "project limit not reached",
+			limits:            common.ConcurrencyLimits{MaxPerProject: 3},
+			sequenceExecution: newConcurrencyLimiterTestSequence("new", "my-project", "dev", "delivery", apimodels.SequenceTriggeredState, 0, now),
+		},
+		{
+			name:              "stage limit reached",
+			limits:            common.ConcurrencyLimits{MaxPerStage: 1},
+			sequenceExecution: newConcurrencyLimiterTestSequence("new", "my-project", "dev", "delivery", apimodels.SequenceTriggeredState, 0, now),
+			wantErr:           true,
+		},
+		{
+			name:              "stage limit not reached in other stage",
+			limits:            common.ConcurrencyLimits{MaxPerStage: 1},
+			sequenceExecution: newConcurrencyLimiterTestSequence("new", "my-project", "staging", "delivery", apimodels.SequenceTriggeredState, 0, now),
+		},
+		{
+			name:              "running sequence within the limit",
+			limits:            common.ConcurrencyLimits{MaxPerProject: 1},
+			sequenceExecution: running[0],
+		},
+		{
+			name:              "running sequence exceeding the limit",
+			limits:            common.ConcurrencyLimits{MaxPerProject: 1},
+			sequenceExecution: running[1],
+			wantErr:           true,
+		},
+		{
+			name:              "running sequence with higher priority",
+			limits:            common.ConcurrencyLimits{MaxPerProject: 1},
+			sequenceExecution: newConcurrencyLimiterTestSequence("running-2", "my-project", "prod", "delivery", apimodels.SequenceStartedState, 100, now.Add(-time.Minute)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl := NewConcurrencyLimiter(tt.limits, sequenceExecutionRepo, &db_mock.ProjectMVRepoMock{}, nil)
+			err := cl.CheckLimits(tt.sequenceExecution)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrConcurrencyLimitReached)
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestConcurrencyLimiter_CheckLimitsPerSequence(t *testing.T) {
+	now := time.Now().UTC()
+
+	projectMVRepo := &db_mock.ProjectMVRepoMock{
+		GetProjectsFunc: func() ([]*apimodels.ExpandedProject, error) {
+			return []*apimodels.ExpandedProject{{ProjectName: "project-a"}, {ProjectName: "project-b"}}, nil
+		},
+	}
+	sequenceExecutionRepo := &db_mock.SequenceExecutionRepoMock{
+		GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+			return []models.SequenceExecution{
+				newConcurrencyLimiterTestSequence("running-"+filter.Scope.Project, filter.Scope.Project, "dev", filter.Name, apimodels.SequenceStartedState, 0, now.Add(-time.Minute)),
+			}, nil
+		},
+	}
+
+	cl := NewConcurrencyLimiter(common.ConcurrencyLimits{MaxPerSequence: map[string]int{"delivery": 2}}, sequenceExecutionRepo, projectMVRepo, nil)
+
+	err := cl.CheckLimits(newConcurrencyLimiterTestSequence("new", "project-c", "dev", "delivery", apimodels.SequenceTriggeredState, 0, now))
+	require.ErrorIs(t, err, ErrConcurrencyLimitReached)
+	require.Contains(t, err.Error(), "2 sequences are already running with the name delivery (limit: 2)")
+
+	require.Len(t, sequenceExecutionRepo.GetCalls(), 2)
+	require.Equal(t, "project-a", sequenceExecutionRepo.GetCalls()[0].Filter.Scope.Project)
+	require.Equal(t, "delivery", sequenceExecutionRepo.GetCalls()[0].Filter.Name)
+	require.Equal(t, []string{apimodels.SequenceStartedState}, sequenceExecutionRepo.GetCalls()[0].Filter.Status)
+
+	// sequences without a limit are not affected
+	err = cl.CheckLimits(newConcurrencyLimiterTestSequence("new", "project-c", "dev", "evaluation", apimodels.SequenceTriggeredState, 0, now))
+	require.Nil(t, err)
+	require.Len(t, sequenceExecutionRepo.GetCalls(), 2)
+}
+
+func TestConcurrencyLimiter_RunWithinLimits(t *testing.T) {
+	now := time.Now().UTC()
+	limits := common.ConcurrencyLimits{MaxPerProject: 2, MaxPerStage: 1, MaxPerSequence: map[string]int{"delivery": 5}}
+
+	newLocker := func(heldKey string) *fake.ILockerMock {
+		return &fake.ILockerMock{
+			TryLockFunc: func(key string) (*models.Lock, context.Context, error) {
+				if key == heldKey {
+					return nil, nil, db.ErrLockHeld
+				}
+				return &models.Lock{Key: key, Token: 1}, context.Background(), nil
+			},
+			UnlockFunc: func(lock models.Lock) error {
+				return nil
+			},
+		}
+	}
+	lockedKeys := func(locker *fake.ILockerMock) []string {
+		keys := []string{}
+		for _, call := range locker.TryLockCalls() {
+			keys = append(keys, call.Key)
+		}
+		return keys
+	}
+
+	t.Run("runs the function while holding the locks of all limits", func(t *testing.T) {
+		sequenceExecutionRepo := &db_mock.SequenceExecutionRepoMock{
+			GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+				return []models.SequenceExecution{}, nil
+			},
+		}
+		projectMVRepo := &db_mock.ProjectMVRepoMock{
+			GetProjectsFunc: func() ([]*apimodels.ExpandedProject, error) {
+				return []*apimodels.ExpandedProject{{ProjectName: "my-project"}}, nil
+			},
+		}
+		locker := newLocker("")
+		cl := NewConcurrencyLimiter(limits, sequenceExecutionRepo, projectMVRepo, locker)
+
+		called := false
+		err := cl.RunWithinLimits(context.Background(), newConcurrencyLimiterTestSequence("new", "my-project", "dev", "delivery", apimodels.SequenceTriggeredState, 0, now), func(ctx context.Context) error {
+			called = true
+			// the limits must be checked after all locks have been acquired, and none of them may have been released yet
+			require.Len(t, locker.TryLockCalls(), 3)
+			require.Len(t, sequenceExecutionRepo.GetCalls(), 3)
+			require.Empty(t, locker.UnlockCalls())
+			require.Nil(t, ctx.Err())
+			return nil
+		})
+		require.Nil(t, err)
+		require.True(t, called)
+		require.Equal(t, []string{"concurrency-limit.project.my-project", "concurrency-limit.stage.my-project.dev", "concurrency-limit.sequence.delivery"}, lockedKeys(locker))
+		require.Len(t, locker.UnlockCalls(), 3)
+	})
+
+	t.Run("does not run the function if a limit has been reached", func(t *testing.T) {
+		sequenceExecutionRepo := &db_mock.SequenceExecutionRepoMock{
+			GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+				return []models.SequenceExecution{
+					newConcurrencyLimiterTestSequence("running", "my-project", "dev", "delivery", apimodels.SequenceStartedState, 0, now.Add(-time.Minute)),
+				}, nil
+			},
+		}
+		locker := newLocker("")
+		cl := NewConcurrencyLimiter(common.ConcurrencyLimits{MaxPerStage: 1}, sequenceExecutionRepo, &db_mock.ProjectMVRepoMock{}, locker)
+
+		err := cl.RunWithinLimits(context.Background(), newConcurrencyLimiterTestSequence("new", "my-project", "dev", "delivery", apimodels.SequenceTriggeredState, 0, now), func(ctx context.Context) error {
+			t.Fatal("function must not be called")
+			return nil
+		})
+		require.ErrorIs(t, err, ErrConcurrencyLimitReached)
+		require.Equal(t, []string{"concurrency-limit.stage.my-project.dev"}, lockedKeys(locker))
+		require.Len(t, locker.UnlockCalls(), 1)
+	})
+
+	t.Run("does not check the limits if the lock of a limit is held by someone else", func(t *testing.T) {
+		sequenceExecutionRepo := &db_mock.SequenceExecutionRepoMock{}
+		locker := newLocker("concurrency-limit.stage.my-project.dev")
+		cl := NewConcurrencyLimiter(limits, sequenceExecutionRepo, &db_mock.ProjectMVRepoMock{}, locker)
+
+		err := cl.RunWithinLimits(context.Background(), newConcurrencyLimiterTestSequence("new", "my-project", "dev", "delivery", apimodels.SequenceTriggeredState, 0, now), func(ctx context.Context) error {
+			t.Fatal("function must not be called")
+			return nil
+		})
+		require.ErrorIs(t, err, db.ErrLockHeld)
+		require.Empty(t, sequenceExecutionRepo.GetCalls())
+		require.Equal(t, []string{"concurrency-limit.project.my-project", "concurrency-limit.stage.my-project.dev"}, lockedKeys(locker))
+		require.Len(t, locker.UnlockCalls(), 1)
+	})
+
+	t.Run("cancels the context of the function if the outer context is done", func(t *testing.T) {
+		sequenceExecutionRepo := &db_mock.SequenceExecutionRepoMock{
+			GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+				return []models.SequenceExecution{}, nil
+			},
+		}
+		cl := NewConcurrencyLimiter(common.ConcurrencyLimits{MaxPerProject: 1}, sequenceExecutionRepo, &db_mock.ProjectMVRepoMock{}, newLocker(""))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := cl.RunWithinLimits(ctx, newConcurrencyLimiterTestSequence("new", "my-project", "dev", "delivery", apimodels.SequenceTriggeredState, 0, now), checkLockHeld)
+		require.ErrorIs(t, err, db.ErrLockLost)
+	})
+}
@@ -11,12 +11,24 @@ var ErrServiceAlreadyExists = errors.New("service already exists")
 
 var ErrServiceNotFound = errors.New("service not found")
 
+var ErrServiceHasActiveSequences = errors.New("service has active sequences")
+
 var ErrProjectNotFound = errors.New("project not found")
 
 var ErrInvalidStageChange = errors.New("stage name cannot be changed or removed")
 
 var ErrStageNotFound = errors.New("stage not found")
 
+var ErrStageAlreadyExists = errors.New("stage already exists")
+
+var ErrStageHasActiveSequences = errors.New("stage has active sequences")
+
+var ErrStageReferenced = errors.New("stage is referenced by sequences of other stages")
+
+var ErrLastStage = errors.New("the last stage of a project cannot be deleted")
+
+var ErrInvalidStagePosition = errors.New("invalid stage position")
+
 var ErrChangesRollback = errors.New("failed to rollback changes")
 
 var ErrSequencePaused = errors.New("sequence is paused")
@@ -25,12 +37,34 @@ var ErrSequenceBlocked = errors.New("sequence is currently blocked")
 
 var ErrSequenceBlockedWaiting = errors.New("sequence is currently blocked by waiting for another sequence to end")
 
+var ErrSequenceFrozen = errors.New("sequence is currently blocked by a freeze window")
+
+var ErrConcurrencyLimitReached = errors.New("sequence is currently blocked by a concurrency limit")
+
 var ErrNoMatchingEvent = errors.New("no matching event found")
 
 var ErrSequenceNotFound = errors.New("sequence not found")
 
+var ErrSequenceNotRetryable = errors.New("sequence cannot be retried")
+
 var ErrInternalError = errors.New("internal server error")
 
+var ErrScheduleNotFound = errors.New("schedule not found")
+
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+var ErrNotificationChannelNotFound = errors.New("notification channel not found")
+
+var ErrAPITokenNotFound = errors.New("api token not found")
+
+var ErrAPITokenAlreadyExists = errors.New("api token already exists")
+
+var ErrInsufficientPermissions = errors.New("insufficient permissions")
+
+var ErrInvalidShipyardEncoding = errors.New("shipyard must be encoded in base64")
+
+var ErrInvalidArchiveEncoding = errors.New("archive must be encoded in base64")
+
 var InvalidRequestFormatMsg = "Invalid request format: %s"
 
 var UnableRetrieveLogsMsg = "Unable to retrieve logs: %s"
@@ -49,8 +83,32 @@ var UnableControleSequenceMsg = "Unable to control sequence: %s"
 
 var UnableFindSequenceMsg = "Unable to control sequence: %s"
 
+var UnableQueryTaskAttemptsMsg = "Unable to query task attempts: %s"
+
+var UnableQuerySchedulesMsg = "Unable to query schedules: %s"
+
+var UnableQueryNotificationChannelsMsg = "Unable to query notification channels: %s"
+
+var UnableQueryNotificationDeliveriesMsg = "Unable to query notification deliveries: %s"
+
+var UnableQuerySequenceExecutionsMsg = "Unable to query sequence executions: %s"
+
 var UnableQueryIntegrationsMsg = "Unable to query uniform integrations repository: %s"
 
+var UnableUpdateStageMsg = "Unable to update stages of project: %s"
+
+var UnableUpdateServiceMsg = "Unable to update service: %s"
+
+var UnableExportProjectMsg = "Unable to export project: %s"
+
+var UnableImportProjectMsg = "Unable to import project: %s"
+
+var UnableCreateAuditRecordMsg = "Unable to store audit record: %s"
+
+var UnableQueryAuditRecordsMsg = "Unable to query audit records: %s"
+
+var UnableQueryAPITokensMsg = "Unable to query api tokens: %s"
+
 var UnableMarshallProvisioningData = "Error marshalling provisioning data: %s"
 
 var UnableUnMarshallProvisioningData = "Error unmarshalling provisioning data: %s"
@@ -12,9 +12,12 @@ import (
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	keptncommon "github.com/keptn/go-utils/pkg/lib/keptn"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	commontracing "github.com/keptn/keptn/cp-common/tracing"
 	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/metrics"
 	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/keptn/keptn/shipyard-controller/tracing"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -22,7 +25,7 @@ import (
 // IEventDispatcher is responsible for dispatching events to be sent to the event broker
 type IEventDispatcher interface {
 	Add(event models.DispatcherEvent, skipQueue bool) error
-	Run(ctx context.Context)
+	Run(ctx context.Context, mode common.SDMode)
 	Stop()
 }
 
@@ -33,10 +36,14 @@ type EventDispatcher struct {
 	eventRepo             db.EventRepo
 	eventQueueRepo        db.EventQueueRepo
 	sequenceExecutionRepo db.SequenceExecutionRepo
+	concurrencyLimiter    IConcurrencyLimiter
 	eventSender           keptncommon.EventSender
 	theClock              clock.Clock
 	syncInterval          time.Duration
 	ticker                *clock.Ticker
+	locker                ILocker
+	mode                  common.SDMode
+	claimOptions          common.ClaimOptions
 }
 
 // NewEventDispatcher creates a new EventDispatcher
@@ -44,16 +51,22 @@ func NewEventDispatcher(
 	eventRepo db.EventRepo,
 	eventQueueRepo db.EventQueueRepo,
 	sequenceExecutionRepo db.SequenceExecutionRepo,
+	concurrencyLimiter IConcurrencyLimiter,
 	eventSender keptncommon.EventSender,
 	syncInterval time.Duration,
+	locker ILocker,
+	claimOptions common.ClaimOptions,
 ) *EventDispatcher {
 	return &EventDispatcher{
 		eventRepo:             eventRepo,
 		eventQueueRepo:        eventQueueRepo,
 		sequenceExecutionRepo: sequenceExecutionRepo,
+		concurrencyLimiter:    concurrencyLimiter,
 		eventSender:           eventSender,
 		theClock:              clock.New(),
 		syncInterval:          syncInterval,
+		locker:                locker,
+		claimOptions:          claimOptions,
 	}
 }
 
@@ -69,14 +82,14 @@ func (e *EventDispatcher) Add(event models.DispatcherEvent, skipQueue bool) erro
 	}
 
 	if skipQueue {
-		return e.eventSender.Send(context.TODO(), event.Event)
+		return e.send(event.Event)
 	}
 	if e.theClock.Now().UTC().Equal(event.TimeStamp) || e.theClock.Now().UTC().After(event.TimeStamp) {
 		// try to send event immediately
 		if err := e.tryToSendEvent(*eventScope, event); err != nil {
-			// if the event cannot be sent because it is blocked by other sequences,
+			// if the event cannot be sent because it is blocked by other sequences, a concurrency limit, or another replica is currently dispatching events for the same stage,
 			// we'll add it to the queue and try to send it again later
-			if !strings.Contains(err.Error(), OtherActiveSequencesRunning) && err != ErrSequencePaused {
+			if !strings.Contains(err.Error(), OtherActiveSequencesRunning) && err != ErrSequencePaused && !errors.Is(err, ErrConcurrencyLimitReached) && !errors.Is(err, db.ErrLockHeld) && !errors.Is(err, db.ErrLockLost) {
 				// in all other cases, return the error
 				return err
 			}
@@ -100,39 +113,68 @@ func (e *EventDispatcher) OnSequenceFinished(event apimodels.KeptnContextExtende
 	e.cleanupQueueOfSequence(models.EventScope{KeptnContext: event.Shkeptncontext})
 }
 
-func (e *EventDispatcher) OnSequenceTimeout(event apimodels.KeptnContextExtendedCE) {
+func (e *EventDispatcher) OnSequenceTimeout(event apimodels.KeptnContextExtendedCE, reason models.SequenceTimeoutReason) {
 	e.cleanupQueueOfSequence(models.EventScope{KeptnContext: event.Shkeptncontext})
 }
 
 // Run starts the event dispatcher loop which will periodically fetch (queued) events
 // from the database and eventually forward/send them to the event broker
-// The fetch interval is configured when creating a EventDispatcher using the "syncInterval" field
-func (e *EventDispatcher) Run(ctx context.Context) {
+// The fetch interval is configured when creating a EventDispatcher using the "syncInterval" field.
+// In SDModePartitioned, only the queued events claimed by the current replica are fetched
+func (e *EventDispatcher) Run(ctx context.Context, mode common.SDMode) {
+	e.mode = mode
 	e.ticker = e.theClock.Ticker(e.syncInterval)
+	heartbeat := newHeartbeat(e.theClock, mode, e.claimOptions)
 	go func() {
+		defer heartbeat.Stop()
 		for {
 			select {
 			case <-ctx.Done():
 				log.Info("cancelling event dispatcher loop")
+				e.releaseClaims()
 				return
+			case <-heartbeat.C:
+				if err := e.eventQueueRepo.RenewEventClaims(e.claimOptions.Owner, e.claimOptions.TTL); err != nil {
+					log.WithError(err).Error("could not renew claims on queued events")
+				}
 			case <-e.ticker.C:
 				log.Debugf("%.2f seconds have passed. Dispatching events", e.syncInterval.Seconds())
+				startedAt := time.Now()
 				e.dispatchEvents()
+				metrics.ObserveDispatcherLoop(metrics.EventDispatcherName, startedAt)
 			}
 		}
 	}()
 }
 
 func (e *EventDispatcher) Stop() {
+	e.releaseClaims()
 	if e.ticker == nil {
 		return
 	}
 	e.ticker.Stop()
 }
 
+// releaseClaims makes the events claimed by this replica available to the other replicas again
+func (e *EventDispatcher) releaseClaims() {
+	if e.mode != common.SDModePartitioned {
+		return
+	}
+	if err := e.eventQueueRepo.ReleaseClaimedEvents(e.claimOptions.Owner); err != nil {
+		log.WithError(err).Error("could not release claims on queued events")
+	}
+}
+
+func (e *EventDispatcher) getQueuedEvents() ([]models.QueueItem, error) {
+	if e.mode == common.SDModePartitioned {
+		return e.eventQueueRepo.ClaimQueuedEvents(e.theClock.Now().UTC(), e.claimOptions.Owner, e.claimOptions.TTL, e.claimOptions.BatchSize)
+	}
+	return e.eventQueueRepo.GetQueuedEvents(e.theClock.Now().UTC())
+}
+
 func (e *EventDispatcher) dispatchEvents() {
 
-	events, err := e.eventQueueRepo.GetQueuedEvents(e.theClock.Now().UTC())
+	events, err := e.getQueuedEvents()
 	if err != nil {
 		log.Debugf("could not fetch event queue: %s", err.Error())
 	}
@@ -151,11 +193,16 @@ func (e *EventDispatcher) dispatchEvents() {
 		}
 		triggeredEvent := events[0]
 
+		// the trace context is carried as a top-level attribute of the CloudEvent, since the extensions object of the stored event is not a valid CloudEvent attribute
+		traceContext := commontracing.ContextFromEvent(context.Background(), triggeredEvent)
+		triggeredEvent.Extensions = nil
+
 		ce := &cloudevents.Event{}
 		if err := keptnv2.Decode(triggeredEvent, ce); err != nil {
 			log.Errorf("could not convert triggeredEvent to CloudEvent: %s", err.Error())
 			continue
 		}
+		commontracing.InjectIntoCloudEvent(traceContext, ce)
 
 		// set the time of the cloud event to the current time since it is being sent now
 		ce.SetTime(e.theClock.Now().UTC())
@@ -179,6 +226,13 @@ func (e *EventDispatcher) dispatchEvents() {
 }
 
 func (e *EventDispatcher) tryToSendEvent(eventScope models.EventScope, event models.DispatcherEvent) error {
+	// make sure that the check for other running sequences and the sending of the event is not interleaved with another replica
+	return withLock(e.locker, eventDispatchLockKey(eventScope), func(ctx context.Context) error {
+		return e.tryToSendEventUnlocked(ctx, eventScope, event)
+	})
+}
+
+func (e *EventDispatcher) tryToSendEventUnlocked(ctx context.Context, eventScope models.EventScope, event models.DispatcherEvent) error {
 	if e.sequenceExecutionRepo.IsContextPaused(eventScope) {
 		log.Infof("sequence %s is currently paused. will not send event %s", eventScope.KeptnContext, event.Event.ID())
 		return ErrSequencePaused
@@ -196,6 +250,13 @@ func (e *EventDispatcher) tryToSendEvent(eventScope models.EventScope, event mod
 		return ErrSequenceNotFound
 	}
 
+	if e.concurrencyLimiter != nil {
+		if err := e.concurrencyLimiter.CheckLimits(sequenceExecutions[0]); err != nil {
+			log.Infof("will not send event %s yet: %v", event.Event.ID(), err)
+			return err
+		}
+	}
+
 	filter := models.SequenceExecutionFilter{
 		Scope: models.EventScope{
 			EventData: keptnv2.EventData{
@@ -216,14 +277,27 @@ func (e *EventDispatcher) tryToSendEvent(eventScope models.EventScope, event mod
 		return err2
 	}
 
-	return e.eventSender.Send(context.TODO(), event.Event)
+	if err := checkLockHeld(ctx); err != nil {
+		return err
+	}
+
+	return e.send(event.Event)
+}
+
+// send sends the given event to the event broker. The trace of the event is continued by a span covering the sending of the event
+func (e *EventDispatcher) send(event cloudevents.Event) error {
+	ctx, span := tracing.StartCloudEventSpan(event, "shipyard-controller.EventDispatcher.send")
+	commontracing.InjectIntoCloudEvent(ctx, &event)
+	err := e.eventSender.Send(ctx, event)
+	tracing.EndSpan(span, err)
+	return err
 }
 
 func checkStarted(startedSequenceExecutions []models.SequenceExecution, event models.DispatcherEvent, e *EventDispatcher) error {
 	if startedSequenceExecutions != nil && len(startedSequenceExecutions) > 0 {
 		// if there is another sequence with the state 'started'
 		for _, otherSequence := range startedSequenceExecutions {
-			if otherSequence.Status.CurrentTask.TriggeredID != event.Event.ID() {
+			if !otherSequence.Status.IsCurrentTask(event.Event.ID()) {
 				if !e.isCurrentEventOverrulingOtherEvent(otherSequence, event) {
 					return errors.New(fmt.Sprint(OtherActiveSequencesRunning, otherSequence.Scope.KeptnContext))
 				}
@@ -242,7 +316,7 @@ func (e *EventDispatcher) isCurrentEventOverrulingOtherEvent(otherSequence model
 		return false
 	}
 	for _, otherEvent := range otherQueuedEvents {
-		if otherEvent.EventID == otherSequence.Status.CurrentTask.TriggeredID && otherEvent.Timestamp.Before(queuedEvent.TimeStamp) {
+		if otherSequence.Status.IsCurrentTask(otherEvent.EventID) && otherEvent.Timestamp.Before(queuedEvent.TimeStamp) {
 			return true
 		}
 	}
@@ -12,10 +12,16 @@ import (
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	"github.com/keptn/go-utils/pkg/lib/v0_2_0/fake"
 
+	"github.com/keptn/keptn/cp-common/tracing"
 	"github.com/keptn/keptn/shipyard-controller/common"
+	"github.com/keptn/keptn/shipyard-controller/db"
 	dbmock "github.com/keptn/keptn/shipyard-controller/db/mock"
+	handlerfake "github.com/keptn/keptn/shipyard-controller/handler/fake"
 	"github.com/keptn/keptn/shipyard-controller/models"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func Test_WhenTimeOfEventIsOlder_EventIsSentImmediately(t *testing.T) {
@@ -431,7 +437,7 @@ func Test_WhenSyncTimeElapses_EventsAreDispatched(t *testing.T) {
 
 	require.Equal(t, 0, len(eventSender.SentEvents))
 	require.Equal(t, 3, len(eventQueueRepo.QueueEventCalls()))
-	dispatcher.Run(context.Background())
+	dispatcher.Run(context.Background(), common.SDModeRW)
 	mockClock.Add(9 * time.Second)
 	require.Equal(t, 0, len(eventSender.SentEvents))
 	mockClock.Add(2 * time.Second)
@@ -538,7 +544,7 @@ func Test_WhenAnEventCouldNotBeFetched_NextEventIsProcessed(t *testing.T) {
 	_ = dispatcher.Add(dispatcherEvent1, false)
 	_ = dispatcher.Add(dispatcherEvent2, false)
 	_ = dispatcher.Add(dispatcherEvent3, false)
-	dispatcher.Run(context.Background())
+	dispatcher.Run(context.Background(), common.SDModeRW)
 
 	mockClock.Add(10 * time.Second)
 	require.Eventually(t, func() bool {
@@ -585,7 +591,7 @@ func TestEventDispatcher_OnSequenceTimeout(t *testing.T) {
 		eventQueueRepo: eventQueueRepo,
 	}
 
-	dispatcher.OnSequenceTimeout(apimodels.KeptnContextExtendedCE{Shkeptncontext: "my-context"})
+	dispatcher.OnSequenceTimeout(apimodels.KeptnContextExtendedCE{Shkeptncontext: "my-context"}, models.SequenceTimeoutReasonTask)
 
 	require.Len(t, eventQueueRepo.DeleteEventQueueStatesCalls(), 1)
 	require.Len(t, eventQueueRepo.DeleteQueuedEventsCalls(), 1)
@@ -616,3 +622,220 @@ func TestEventDispatcher_OnSequenceFinished_DeletingStateFailsButDeletingQueueSh
 	require.Equal(t, "my-context", eventQueueRepo.DeleteEventQueueStatesCalls()[0].State.Scope.KeptnContext)
 	require.Equal(t, "my-context", eventQueueRepo.DeleteQueuedEventsCalls()[0].Scope.KeptnContext)
 }
+
+func Test_WhenLockIsHeldByOtherReplica_EventIsQueued(t *testing.T) {
+	timeBefore := time.Date(2021, 4, 21, 15, 00, 00, 0, time.UTC)
+	timeAfter := time.Date(2021, 4, 21, 15, 00, 00, 1, time.UTC)
+
+	eventQueueRepo := &dbmock.EventQueueRepoMock{
+		QueueEventFunc: func(item models.QueueItem) error {
+			return nil
+		},
+	}
+	locker := &handlerfake.ILockerMock{
+		TryLockFunc: func(key string) (*models.Lock, context.Context, error) {
+			return nil, nil, db.ErrLockHeld
+		},
+	}
+
+	eventSender := &fake.EventSender{}
+	mockClock := clock.NewMock()
+	mockClock.Set(timeAfter)
+
+	dispatcher := EventDispatcher{
+		eventRepo:             &dbmock.EventRepoMock{},
+		eventQueueRepo:        eventQueueRepo,
+		eventSender:           eventSender,
+		theClock:              mockClock,
+		syncInterval:          10 * time.Second,
+		sequenceExecutionRepo: &dbmock.SequenceExecutionRepoMock{},
+		locker:                locker,
+	}
+	data := keptnv2.EventData{
+		Project: "my-project",
+		Stage:   "my-stage",
+		Service: "my-service",
+	}
+	event, _ := keptnv2.KeptnEvent(keptnv2.GetTriggeredEventType("task"), "source", data).Build()
+	event.Shkeptncontext = "my-context-id"
+	dispatcherEvent := models.DispatcherEvent{Event: keptnv2.ToCloudEvent(event), TimeStamp: timeBefore}
+
+	err := dispatcher.Add(dispatcherEvent, false)
+	require.Nil(t, err)
+	require.Empty(t, eventSender.SentEvents)
+
+	require.Len(t, locker.TryLockCalls(), 1)
+	require.Equal(t, "event-dispatcher.my-project.my-stage", locker.TryLockCalls()[0].Key)
+	require.Len(t, eventQueueRepo.QueueEventCalls(), 1)
+}
+
+func Test_EventDispatcherPartitionedMode(t *testing.T) {
+	mockClock := clock.NewMock()
+
+	eventQueueRepo := &dbmock.EventQueueRepoMock{
+		ClaimQueuedEventsFunc: func(timestamp time.Time, owner string, ttl time.Duration, limit int) ([]models.QueueItem, error) {
+			return nil, nil
+		},
+		RenewEventClaimsFunc: func(owner string, ttl time.Duration) error {
+			return nil
+		},
+		ReleaseClaimedEventsFunc: func(owner string) error {
+			return nil
+		},
+	}
+
+	dispatcher := NewEventDispatcher(&dbmock.EventRepoMock{}, eventQueueRepo, &dbmock.SequenceExecutionRepoMock{}, nil, &fake.EventSender{}, 10*time.Second, nil, common.ClaimOptions{
+		Owner:     "my-replica",
+		TTL:       30 * time.Second,
+		BatchSize: 10,
+	})
+	dispatcher.theClock = mockClock
+
+	ctx, cancel := context.WithCancel(context.Background())
+	dispatcher.Run(ctx, common.SDModePartitioned)
+
+	mockClock.Add(10 * time.Second)
+
+	require.Eventually(t, func() bool {
+		return len(eventQueueRepo.ClaimQueuedEventsCalls()) == 1 && len(eventQueueRepo.RenewEventClaimsCalls()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, "my-replica", eventQueueRepo.ClaimQueuedEventsCalls()[0].Owner)
+	require.Empty(t, eventQueueRepo.GetQueuedEventsCalls())
+
+	cancel()
+
+	require.Eventually(t, func() bool {
+		return len(eventQueueRepo.ReleaseClaimedEventsCalls()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func Test_WhenConcurrencyLimitIsReached_EventIsQueued(t *testing.T) {
+	now := time.Date(2021, 4, 21, 15, 00, 00, 0, time.UTC)
+
+	eventQueueRepo := &dbmock.EventQueueRepoMock{
+		QueueEventFunc: func(item models.QueueItem) error {
+			return nil
+		},
+	}
+	sequenceExecutionRepo := &dbmock.SequenceExecutionRepoMock{
+		GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+			if filter.CurrentTriggeredID != "" {
+				return []models.SequenceExecution{
+					{
+						ID: "my-id",
+						Status: models.SequenceExecutionStatus{
+							State: apimodels.SequenceStartedState,
+						},
+					},
+				}, nil
+			}
+			return nil, nil
+		},
+		IsContextPausedFunc: func(eventScope models.EventScope) bool {
+			return false
+		},
+	}
+	limitReached := true
+	concurrencyLimiter := &handlerfake.IConcurrencyLimiterMock{
+		CheckLimitsFunc: func(sequenceExecution models.SequenceExecution) error {
+			if limitReached {
+				return fmt.Errorf("%w: 1 sequences are already running in project my-project (limit: 1)", ErrConcurrencyLimitReached)
+			}
+			return nil
+		},
+	}
+	eventSender := &fake.EventSender{}
+	mockClock := clock.NewMock()
+	mockClock.Set(now)
+
+	dispatcher := EventDispatcher{
+		eventRepo:             &dbmock.EventRepoMock{},
+		eventQueueRepo:        eventQueueRepo,
+		eventSender:           eventSender,
+		theClock:              mockClock,
+		syncInterval:          10 * time.Second,
+		sequenceExecutionRepo: sequenceExecutionRepo,
+		concurrencyLimiter:    concurrencyLimiter,
+	}
+	data := keptnv2.EventData{
+		Project: "my-project",
+		Stage:   "my-stage",
+		Service: "my-service",
+	}
+	event, _ := keptnv2.KeptnEvent(keptnv2.GetTriggeredEventType("task"), "source", data).Build()
+	event.Shkeptncontext = "my-context-id"
+
+	// the event is queued instead of being sent
+	err := dispatcher.Add(models.DispatcherEvent{Event: keptnv2.ToCloudEvent(event), TimeStamp: now}, false)
+	require.Nil(t, err)
+	require.Empty(t, eventSender.SentEvents)
+	require.Len(t, eventQueueRepo.QueueEventCalls(), 1)
+	require.Equal(t, "my-id", concurrencyLimiter.CheckLimitsCalls()[0].SequenceExecution.ID)
+
+	// once the limit is not reached anymore, the event is sent
+	limitReached = false
+	err = dispatcher.Add(models.DispatcherEvent{Event: keptnv2.ToCloudEvent(event), TimeStamp: now}, false)
+	require.Nil(t, err)
+	require.Len(t, eventSender.SentEvents, 1)
+	require.Len(t, eventQueueRepo.QueueEventCalls(), 1)
+}
+
+func Test_WhenQueuedEventIsDispatched_TraceOfEventIsContinued(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previousProvider)
+
+	eventRepo := &dbmock.EventRepoMock{
+		GetEventsFunc: func(project string, filter common.EventFilter, status ...common.EventStatus) ([]apimodels.KeptnContextExtendedCE, error) {
+			return []apimodels.KeptnContextExtendedCE{{
+				Shkeptncontext: "my-context",
+				ID:             *filter.ID,
+				Specversion:    "1.0",
+				Source:         stringp("source"),
+				Type:           stringp("my-type"),
+				Data:           keptnv2.EventData{Project: "my-project", Stage: "my-stage", Service: "my-service"},
+				Extensions:     map[string]interface{}{tracing.TraceParentExtension: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+			}}, nil
+		},
+	}
+	eventQueueRepo := &dbmock.EventQueueRepoMock{
+		GetQueuedEventsFunc: func(timestamp time.Time) ([]models.QueueItem, error) {
+			return []models.QueueItem{{EventID: "my-id", Scope: models.EventScope{EventData: keptnv2.EventData{Project: "my-project"}}}}, nil
+		},
+		DeleteQueuedEventFunc: func(eventID string) error {
+			return nil
+		},
+	}
+	sequenceExecutionRepo := &dbmock.SequenceExecutionRepoMock{
+		GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+			if filter.CurrentTriggeredID != "" {
+				return []models.SequenceExecution{{Status: models.SequenceExecutionStatus{State: apimodels.SequenceTriggeredState}}}, nil
+			}
+			return nil, nil
+		},
+		IsContextPausedFunc: func(eventScope models.EventScope) bool {
+			return false
+		},
+	}
+	eventSender := &fake.EventSender{}
+
+	dispatcher := EventDispatcher{
+		eventRepo:             eventRepo,
+		eventQueueRepo:        eventQueueRepo,
+		eventSender:           eventSender,
+		theClock:              clock.NewMock(),
+		sequenceExecutionRepo: sequenceExecutionRepo,
+	}
+
+	dispatcher.dispatchEvents()
+
+	require.Len(t, eventSender.SentEvents, 1)
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	require.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+
+	traceParent := eventSender.SentEvents[0].Extensions()[tracing.TraceParentExtension]
+	require.Equal(t, fmt.Sprintf("00-%s-%s-01", spans[0].SpanContext().TraceID(), spans[0].SpanContext().SpanID()), traceParent)
+}
@@ -5,6 +5,7 @@ package fake
 
 import (
 	"context"
+	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/keptn/keptn/shipyard-controller/models"
 	"sync"
 )
@@ -18,7 +19,7 @@ import (
 // 			AddFunc: func(event models.DispatcherEvent, skipQueue bool) error {
 // 				panic("mock out the Add method")
 // 			},
-// 			RunFunc: func(ctx context.Context)  {
+// 			RunFunc: func(ctx context.Context, mode common.SDMode)  {
 // 				panic("mock out the Run method")
 // 			},
 // 			StopFunc: func()  {
@@ -35,7 +36,7 @@ type IEventDispatcherMock struct {
 	AddFunc func(event models.DispatcherEvent, skipQueue bool) error
 
 	// RunFunc mocks the Run method.
-	RunFunc func(ctx context.Context)
+	RunFunc func(ctx context.Context, mode common.SDMode)
 
 	// StopFunc mocks the Stop method.
 	StopFunc func()
@@ -44,7 +45,7 @@ type IEventDispatcherMock struct {
 	calls struct {
 		// Add holds details about calls to the Add method.
 		Add []struct {
-			//models.KeptnContextExtendedCEis the event argument value.
+			// Event is the event argument value.
 			Event models.DispatcherEvent
 			// SkipQueue is the skipQueue argument value.
 			SkipQueue bool
@@ -53,6 +54,8 @@ type IEventDispatcherMock struct {
 		Run []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// Mode is the mode argument value.
+			Mode common.SDMode
 		}
 		// Stop holds details about calls to the Stop method.
 		Stop []struct {
@@ -83,7 +86,8 @@ func (mock *IEventDispatcherMock) Add(event models.DispatcherEvent, skipQueue bo
 
 // AddCalls gets all the calls that were made to Add.
 // Check the length with:
-//     len(mockedIEventDispatcher.AddCalls())
+//
+// 	len(mockedIEventDispatcher.AddCalls())
 func (mock *IEventDispatcherMock) AddCalls() []struct {
 	Event     models.DispatcherEvent
 	SkipQueue bool
@@ -99,29 +103,34 @@ func (mock *IEventDispatcherMock) AddCalls() []struct {
 }
 
 // Run calls RunFunc.
-func (mock *IEventDispatcherMock) Run(ctx context.Context) {
+func (mock *IEventDispatcherMock) Run(ctx context.Context, mode common.SDMode) {
 	if mock.RunFunc == nil {
 		panic("IEventDispatcherMock.RunFunc: method is nil but IEventDispatcher.Run was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
+		Ctx  context.Context
+		Mode common.SDMode
 	}{
-		Ctx: ctx,
+		Ctx:  ctx,
+		Mode: mode,
 	}
 	mock.lockRun.Lock()
 	mock.calls.Run = append(mock.calls.Run, callInfo)
 	mock.lockRun.Unlock()
-	mock.RunFunc(ctx)
+	mock.RunFunc(ctx, mode)
 }
 
 // RunCalls gets all the calls that were made to Run.
 // Check the length with:
-//     len(mockedIEventDispatcher.RunCalls())
+//
+// 	len(mockedIEventDispatcher.RunCalls())
 func (mock *IEventDispatcherMock) RunCalls() []struct {
-	Ctx context.Context
+	Ctx  context.Context
+	Mode common.SDMode
 } {
 	var calls []struct {
-		Ctx context.Context
+		Ctx  context.Context
+		Mode common.SDMode
 	}
 	mock.lockRun.RLock()
 	calls = mock.calls.Run
@@ -144,7 +153,8 @@ func (mock *IEventDispatcherMock) Stop() {
 
 // StopCalls gets all the calls that were made to Stop.
 // Check the length with:
-//     len(mockedIEventDispatcher.StopCalls())
+//
+// 	len(mockedIEventDispatcher.StopCalls())
 func (mock *IEventDispatcherMock) StopCalls() []struct {
 } {
 	var calls []struct {
@@ -27,6 +27,12 @@ import (
 // 			GetServiceFunc: func(projectName string, stageName string, serviceName string) (*apimodels.ExpandedService, error) {
 // 				panic("mock out the GetService method")
 // 			},
+// 			GetServiceMetadataFunc: func(projectName string, serviceName string) (*models.ServiceMetadata, error) {
+// 				panic("mock out the GetServiceMetadata method")
+// 			},
+// 			UpdateServiceFunc: func(projectName string, serviceName string, params *models.UpdateServiceParams) (*models.ServiceMetadata, error) {
+// 				panic("mock out the UpdateService method")
+// 			},
 // 		}
 //
 // 		// use mockedIServiceManager in code that requires handler.IServiceManager
@@ -46,6 +52,12 @@ type IServiceManagerMock struct {
 	// GetServiceFunc mocks the GetService method.
 	GetServiceFunc func(projectName string, stageName string, serviceName string) (*apimodels.ExpandedService, error)
 
+	// GetServiceMetadataFunc mocks the GetServiceMetadata method.
+	GetServiceMetadataFunc func(projectName string, serviceName string) (*models.ServiceMetadata, error)
+
+	// UpdateServiceFunc mocks the UpdateService method.
+	UpdateServiceFunc func(projectName string, serviceName string, params *models.UpdateServiceParams) (*models.ServiceMetadata, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CreateService holds details about calls to the CreateService method.
@@ -78,11 +90,29 @@ type IServiceManagerMock struct {
 			// ServiceName is the serviceName argument value.
 			ServiceName string
 		}
+		// GetServiceMetadata holds details about calls to the GetServiceMetadata method.
+		GetServiceMetadata []struct {
+			// ProjectName is the projectName argument value.
+			ProjectName string
+			// ServiceName is the serviceName argument value.
+			ServiceName string
+		}
+		// UpdateService holds details about calls to the UpdateService method.
+		UpdateService []struct {
+			// ProjectName is the projectName argument value.
+			ProjectName string
+			// ServiceName is the serviceName argument value.
+			ServiceName string
+			// Params is the params argument value.
+			Params *models.UpdateServiceParams
+		}
 	}
-	lockCreateService  sync.RWMutex
-	lockDeleteService  sync.RWMutex
-	lockGetAllServices sync.RWMutex
-	lockGetService     sync.RWMutex
+	lockCreateService      sync.RWMutex
+	lockDeleteService      sync.RWMutex
+	lockGetAllServices     sync.RWMutex
+	lockGetService         sync.RWMutex
+	lockGetServiceMetadata sync.RWMutex
+	lockUpdateService      sync.RWMutex
 }
 
 // CreateService calls CreateServiceFunc.
@@ -228,3 +258,77 @@ func (mock *IServiceManagerMock) GetServiceCalls() []struct {
 	mock.lockGetService.RUnlock()
 	return calls
 }
+
+// GetServiceMetadata calls GetServiceMetadataFunc.
+func (mock *IServiceManagerMock) GetServiceMetadata(projectName string, serviceName string) (*models.ServiceMetadata, error) {
+	if mock.GetServiceMetadataFunc == nil {
+		panic("IServiceManagerMock.GetServiceMetadataFunc: method is nil but IServiceManager.GetServiceMetadata was just called")
+	}
+	callInfo := struct {
+		ProjectName string
+		ServiceName string
+	}{
+		ProjectName: projectName,
+		ServiceName: serviceName,
+	}
+	mock.lockGetServiceMetadata.Lock()
+	mock.calls.GetServiceMetadata = append(mock.calls.GetServiceMetadata, callInfo)
+	mock.lockGetServiceMetadata.Unlock()
+	return mock.GetServiceMetadataFunc(projectName, serviceName)
+}
+
+// GetServiceMetadataCalls gets all the calls that were made to GetServiceMetadata.
+// Check the length with:
+//     len(mockedIServiceManager.GetServiceMetadataCalls())
+func (mock *IServiceManagerMock) GetServiceMetadataCalls() []struct {
+	ProjectName string
+	ServiceName string
+} {
+	var calls []struct {
+		ProjectName string
+		ServiceName string
+	}
+	mock.lockGetServiceMetadata.RLock()
+	calls = mock.calls.GetServiceMetadata
+	mock.lockGetServiceMetadata.RUnlock()
+	return calls
+}
+
+// UpdateService calls UpdateServiceFunc.
+func (mock *IServiceManagerMock) UpdateService(projectName string, serviceName string, params *models.UpdateServiceParams) (*models.ServiceMetadata, error) {
+	if mock.UpdateServiceFunc == nil {
+		panic("IServiceManagerMock.UpdateServiceFunc: method is nil but IServiceManager.UpdateService was just called")
+	}
+	callInfo := struct {
+		ProjectName string
+		ServiceName string
+		Params      *models.UpdateServiceParams
+	}{
+		ProjectName: projectName,
+		ServiceName: serviceName,
+		Params:      params,
+	}
+	mock.lockUpdateService.Lock()
+	mock.calls.UpdateService = append(mock.calls.UpdateService, callInfo)
+	mock.lockUpdateService.Unlock()
+	return mock.UpdateServiceFunc(projectName, serviceName, params)
+}
+
+// UpdateServiceCalls gets all the calls that were made to UpdateService.
+// Check the length with:
+//     len(mockedIServiceManager.UpdateServiceCalls())
+func (mock *IServiceManagerMock) UpdateServiceCalls() []struct {
+	ProjectName string
+	ServiceName string
+	Params      *models.UpdateServiceParams
+} {
+	var calls []struct {
+		ProjectName string
+		ServiceName string
+		Params      *models.UpdateServiceParams
+	}
+	mock.lockUpdateService.RLock()
+	calls = mock.calls.UpdateService
+	mock.lockUpdateService.RUnlock()
+	return calls
+}
@@ -16,7 +16,7 @@ import (
 //
 // 		// make and configure a mocked handler.IShipyardController
 // 		mockedIShipyardController := &IShipyardControllerMock{
-// 			ControlSequenceFunc: func(controlSequence models.SequenceControl) error {
+// 			ControlSequenceFunc: func(controlSequence apimodels.SequenceControl) error {
 // 				panic("mock out the ControlSequence method")
 // 			},
 // 			GetAllTriggeredEventsFunc: func(filter common.EventFilter) ([]apimodels.KeptnContextExtendedCE, error) {
@@ -28,7 +28,10 @@ import (
 // 			HandleIncomingEventFunc: func(event apimodels.KeptnContextExtendedCE, waitForCompletion bool) error {
 // 				panic("mock out the HandleIncomingEvent method")
 // 			},
-// 			StartDispatchersFunc: func(ctx context.Context)  {
+// 			RetrySequenceFunc: func(controlSequence apimodels.SequenceControl, fromTask string) error {
+// 				panic("mock out the RetrySequence method")
+// 			},
+// 			StartDispatchersFunc: func(ctx context.Context, mode common.SDMode)  {
 // 				panic("mock out the StartDispatchers method")
 // 			},
 // 			StartTaskSequenceFunc: func(event apimodels.KeptnContextExtendedCE) error {
@@ -56,6 +59,9 @@ type IShipyardControllerMock struct {
 	// HandleIncomingEventFunc mocks the HandleIncomingEvent method.
 	HandleIncomingEventFunc func(event apimodels.KeptnContextExtendedCE, waitForCompletion bool) error
 
+	// RetrySequenceFunc mocks the RetrySequence method.
+	RetrySequenceFunc func(controlSequence apimodels.SequenceControl, fromTask string) error
+
 	// StartDispatchersFunc mocks the StartDispatchers method.
 	StartDispatchersFunc func(ctx context.Context, mode common.SDMode)
 
@@ -86,19 +92,28 @@ type IShipyardControllerMock struct {
 		}
 		// HandleIncomingEvent holds details about calls to the HandleIncomingEvent method.
 		HandleIncomingEvent []struct {
-			//models.KeptnContextExtendedCEis the event argument value.
+			// Event is the event argument value.
 			Event apimodels.KeptnContextExtendedCE
 			// WaitForCompletion is the waitForCompletion argument value.
 			WaitForCompletion bool
 		}
+		// RetrySequence holds details about calls to the RetrySequence method.
+		RetrySequence []struct {
+			// ControlSequence is the controlSequence argument value.
+			ControlSequence apimodels.SequenceControl
+			// FromTask is the fromTask argument value.
+			FromTask string
+		}
 		// StartDispatchers holds details about calls to the StartDispatchers method.
 		StartDispatchers []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// Mode is the mode argument value.
+			Mode common.SDMode
 		}
 		// StartTaskSequence holds details about calls to the StartTaskSequence method.
 		StartTaskSequence []struct {
-			//models.KeptnContextExtendedCEis the event argument value.
+			// Event is the event argument value.
 			Event apimodels.KeptnContextExtendedCE
 		}
 		// StopDispatchers holds details about calls to the StopDispatchers method.
@@ -109,6 +124,7 @@ type IShipyardControllerMock struct {
 	lockGetAllTriggeredEvents       sync.RWMutex
 	lockGetTriggeredEventsOfProject sync.RWMutex
 	lockHandleIncomingEvent         sync.RWMutex
+	lockRetrySequence               sync.RWMutex
 	lockStartDispatchers            sync.RWMutex
 	lockStartTaskSequence           sync.RWMutex
 	lockStopDispatchers             sync.RWMutex
@@ -132,7 +148,8 @@ func (mock *IShipyardControllerMock) ControlSequence(controlSequence apimodels.S
 
 // ControlSequenceCalls gets all the calls that were made to ControlSequence.
 // Check the length with:
-//     len(mockedIShipyardController.ControlSequenceCalls())
+//
+// 	len(mockedIShipyardController.ControlSequenceCalls())
 func (mock *IShipyardControllerMock) ControlSequenceCalls() []struct {
 	ControlSequence apimodels.SequenceControl
 } {
@@ -163,7 +180,8 @@ func (mock *IShipyardControllerMock) GetAllTriggeredEvents(filter common.EventFi
 
 // GetAllTriggeredEventsCalls gets all the calls that were made to GetAllTriggeredEvents.
 // Check the length with:
-//     len(mockedIShipyardController.GetAllTriggeredEventsCalls())
+//
+// 	len(mockedIShipyardController.GetAllTriggeredEventsCalls())
 func (mock *IShipyardControllerMock) GetAllTriggeredEventsCalls() []struct {
 	Filter common.EventFilter
 } {
@@ -196,7 +214,8 @@ func (mock *IShipyardControllerMock) GetTriggeredEventsOfProject(project string,
 
 // GetTriggeredEventsOfProjectCalls gets all the calls that were made to GetTriggeredEventsOfProject.
 // Check the length with:
-//     len(mockedIShipyardController.GetTriggeredEventsOfProjectCalls())
+//
+// 	len(mockedIShipyardController.GetTriggeredEventsOfProjectCalls())
 func (mock *IShipyardControllerMock) GetTriggeredEventsOfProjectCalls() []struct {
 	Project string
 	Filter  common.EventFilter
@@ -231,7 +250,8 @@ func (mock *IShipyardControllerMock) HandleIncomingEvent(event apimodels.KeptnCo
 
 // HandleIncomingEventCalls gets all the calls that were made to HandleIncomingEvent.
 // Check the length with:
-//     len(mockedIShipyardController.HandleIncomingEventCalls())
+//
+// 	len(mockedIShipyardController.HandleIncomingEventCalls())
 func (mock *IShipyardControllerMock) HandleIncomingEventCalls() []struct {
 	Event             apimodels.KeptnContextExtendedCE
 	WaitForCompletion bool
@@ -246,15 +266,53 @@ func (mock *IShipyardControllerMock) HandleIncomingEventCalls() []struct {
 	return calls
 }
 
+// RetrySequence calls RetrySequenceFunc.
+func (mock *IShipyardControllerMock) RetrySequence(controlSequence apimodels.SequenceControl, fromTask string) error {
+	if mock.RetrySequenceFunc == nil {
+		panic("IShipyardControllerMock.RetrySequenceFunc: method is nil but IShipyardController.RetrySequence was just called")
+	}
+	callInfo := struct {
+		ControlSequence apimodels.SequenceControl
+		FromTask        string
+	}{
+		ControlSequence: controlSequence,
+		FromTask:        fromTask,
+	}
+	mock.lockRetrySequence.Lock()
+	mock.calls.RetrySequence = append(mock.calls.RetrySequence, callInfo)
+	mock.lockRetrySequence.Unlock()
+	return mock.RetrySequenceFunc(controlSequence, fromTask)
+}
+
+// RetrySequenceCalls gets all the calls that were made to RetrySequence.
+// Check the length with:
+//
+// 	len(mockedIShipyardController.RetrySequenceCalls())
+func (mock *IShipyardControllerMock) RetrySequenceCalls() []struct {
+	ControlSequence apimodels.SequenceControl
+	FromTask        string
+} {
+	var calls []struct {
+		ControlSequence apimodels.SequenceControl
+		FromTask        string
+	}
+	mock.lockRetrySequence.RLock()
+	calls = mock.calls.RetrySequence
+	mock.lockRetrySequence.RUnlock()
+	return calls
+}
+
 // StartDispatchers calls StartDispatchersFunc.
 func (mock *IShipyardControllerMock) StartDispatchers(ctx context.Context, mode common.SDMode) {
 	if mock.StartDispatchersFunc == nil {
 		panic("IShipyardControllerMock.StartDispatchersFunc: method is nil but IShipyardController.StartDispatchers was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
+		Ctx  context.Context
+		Mode common.SDMode
 	}{
-		Ctx: ctx,
+		Ctx:  ctx,
+		Mode: mode,
 	}
 	mock.lockStartDispatchers.Lock()
 	mock.calls.StartDispatchers = append(mock.calls.StartDispatchers, callInfo)
@@ -264,12 +322,15 @@ func (mock *IShipyardControllerMock) StartDispatchers(ctx context.Context, mode
 
 // StartDispatchersCalls gets all the calls that were made to StartDispatchers.
 // Check the length with:
-//     len(mockedIShipyardController.StartDispatchersCalls())
+//
+// 	len(mockedIShipyardController.StartDispatchersCalls())
 func (mock *IShipyardControllerMock) StartDispatchersCalls() []struct {
-	Ctx context.Context
+	Ctx  context.Context
+	Mode common.SDMode
 } {
 	var calls []struct {
-		Ctx context.Context
+		Ctx  context.Context
+		Mode common.SDMode
 	}
 	mock.lockStartDispatchers.RLock()
 	calls = mock.calls.StartDispatchers
@@ -295,7 +356,8 @@ func (mock *IShipyardControllerMock) StartTaskSequence(event apimodels.KeptnCont
 
 // StartTaskSequenceCalls gets all the calls that were made to StartTaskSequence.
 // Check the length with:
-//     len(mockedIShipyardController.StartTaskSequenceCalls())
+//
+// 	len(mockedIShipyardController.StartTaskSequenceCalls())
 func (mock *IShipyardControllerMock) StartTaskSequenceCalls() []struct {
 	Event apimodels.KeptnContextExtendedCE
 } {
@@ -323,7 +385,8 @@ func (mock *IShipyardControllerMock) StopDispatchers() {
 
 // StopDispatchersCalls gets all the calls that were made to StopDispatchers.
 // Check the length with:
-//     len(mockedIShipyardController.StopDispatchersCalls())
+//
+// 	len(mockedIShipyardController.StopDispatchersCalls())
 func (mock *IShipyardControllerMock) StopDispatchersCalls() []struct {
 } {
 	var calls []struct {
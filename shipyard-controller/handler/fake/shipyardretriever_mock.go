@@ -5,6 +5,7 @@ package fake
 
 import (
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/common"
 	"sync"
 )
 
@@ -14,9 +15,18 @@ import (
 //
 // 		// make and configure a mocked handler.IShipyardRetriever
 // 		mockedIShipyardRetriever := &IShipyardRetrieverMock{
+// 			GetCachedFreezeWindowsFunc: func(projectName string) (map[string][]common.FreezeWindow, error) {
+// 				panic("mock out the GetCachedFreezeWindows method")
+// 			},
+// 			GetCachedSequenceTimeoutsFunc: func(projectName string) (map[string]map[string]string, error) {
+// 				panic("mock out the GetCachedSequenceTimeouts method")
+// 			},
 // 			GetCachedShipyardFunc: func(projectName string) (*keptnv2.Shipyard, error) {
 // 				panic("mock out the GetCachedShipyard method")
 // 			},
+// 			GetCachedTaskAttributesFunc: func(projectName string) (map[string]map[string][]common.TaskAttributes, error) {
+// 				panic("mock out the GetCachedTaskAttributes method")
+// 			},
 // 			GetLatestCommitIDFunc: func(projectName string, stageName string) (string, error) {
 // 				panic("mock out the GetLatestCommitID method")
 // 			},
@@ -30,9 +40,18 @@ import (
 //
 // 	}
 type IShipyardRetrieverMock struct {
+	// GetCachedFreezeWindowsFunc mocks the GetCachedFreezeWindows method.
+	GetCachedFreezeWindowsFunc func(projectName string) (map[string][]common.FreezeWindow, error)
+
+	// GetCachedSequenceTimeoutsFunc mocks the GetCachedSequenceTimeouts method.
+	GetCachedSequenceTimeoutsFunc func(projectName string) (map[string]map[string]string, error)
+
 	// GetCachedShipyardFunc mocks the GetCachedShipyard method.
 	GetCachedShipyardFunc func(projectName string) (*keptnv2.Shipyard, error)
 
+	// GetCachedTaskAttributesFunc mocks the GetCachedTaskAttributes method.
+	GetCachedTaskAttributesFunc func(projectName string) (map[string]map[string][]common.TaskAttributes, error)
+
 	// GetLatestCommitIDFunc mocks the GetLatestCommitID method.
 	GetLatestCommitIDFunc func(projectName string, stageName string) (string, error)
 
@@ -41,11 +60,26 @@ type IShipyardRetrieverMock struct {
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// GetCachedFreezeWindows holds details about calls to the GetCachedFreezeWindows method.
+		GetCachedFreezeWindows []struct {
+			// ProjectName is the projectName argument value.
+			ProjectName string
+		}
+		// GetCachedSequenceTimeouts holds details about calls to the GetCachedSequenceTimeouts method.
+		GetCachedSequenceTimeouts []struct {
+			// ProjectName is the projectName argument value.
+			ProjectName string
+		}
 		// GetCachedShipyard holds details about calls to the GetCachedShipyard method.
 		GetCachedShipyard []struct {
 			// ProjectName is the projectName argument value.
 			ProjectName string
 		}
+		// GetCachedTaskAttributes holds details about calls to the GetCachedTaskAttributes method.
+		GetCachedTaskAttributes []struct {
+			// ProjectName is the projectName argument value.
+			ProjectName string
+		}
 		// GetLatestCommitID holds details about calls to the GetLatestCommitID method.
 		GetLatestCommitID []struct {
 			// ProjectName is the projectName argument value.
@@ -59,9 +93,76 @@ type IShipyardRetrieverMock struct {
 			ProjectName string
 		}
 	}
-	lockGetCachedShipyard sync.RWMutex
-	lockGetLatestCommitID sync.RWMutex
-	lockGetShipyard       sync.RWMutex
+	lockGetCachedFreezeWindows    sync.RWMutex
+	lockGetCachedSequenceTimeouts sync.RWMutex
+	lockGetCachedShipyard         sync.RWMutex
+	lockGetCachedTaskAttributes   sync.RWMutex
+	lockGetLatestCommitID         sync.RWMutex
+	lockGetShipyard               sync.RWMutex
+}
+
+// GetCachedFreezeWindows calls GetCachedFreezeWindowsFunc.
+func (mock *IShipyardRetrieverMock) GetCachedFreezeWindows(projectName string) (map[string][]common.FreezeWindow, error) {
+	if mock.GetCachedFreezeWindowsFunc == nil {
+		panic("IShipyardRetrieverMock.GetCachedFreezeWindowsFunc: method is nil but IShipyardRetriever.GetCachedFreezeWindows was just called")
+	}
+	callInfo := struct {
+		ProjectName string
+	}{
+		ProjectName: projectName,
+	}
+	mock.lockGetCachedFreezeWindows.Lock()
+	mock.calls.GetCachedFreezeWindows = append(mock.calls.GetCachedFreezeWindows, callInfo)
+	mock.lockGetCachedFreezeWindows.Unlock()
+	return mock.GetCachedFreezeWindowsFunc(projectName)
+}
+
+// GetCachedFreezeWindowsCalls gets all the calls that were made to GetCachedFreezeWindows.
+// Check the length with:
+//
+// 	len(mockedIShipyardRetriever.GetCachedFreezeWindowsCalls())
+func (mock *IShipyardRetrieverMock) GetCachedFreezeWindowsCalls() []struct {
+	ProjectName string
+} {
+	var calls []struct {
+		ProjectName string
+	}
+	mock.lockGetCachedFreezeWindows.RLock()
+	calls = mock.calls.GetCachedFreezeWindows
+	mock.lockGetCachedFreezeWindows.RUnlock()
+	return calls
+}
+
+// GetCachedSequenceTimeouts calls GetCachedSequenceTimeoutsFunc.
+func (mock *IShipyardRetrieverMock) GetCachedSequenceTimeouts(projectName string) (map[string]map[string]string, error) {
+	if mock.GetCachedSequenceTimeoutsFunc == nil {
+		panic("IShipyardRetrieverMock.GetCachedSequenceTimeoutsFunc: method is nil but IShipyardRetriever.GetCachedSequenceTimeouts was just called")
+	}
+	callInfo := struct {
+		ProjectName string
+	}{
+		ProjectName: projectName,
+	}
+	mock.lockGetCachedSequenceTimeouts.Lock()
+	mock.calls.GetCachedSequenceTimeouts = append(mock.calls.GetCachedSequenceTimeouts, callInfo)
+	mock.lockGetCachedSequenceTimeouts.Unlock()
+	return mock.GetCachedSequenceTimeoutsFunc(projectName)
+}
+
+// GetCachedSequenceTimeoutsCalls gets all the calls that were made to GetCachedSequenceTimeouts.
+// Check the length with:
+//
+// 	len(mockedIShipyardRetriever.GetCachedSequenceTimeoutsCalls())
+func (mock *IShipyardRetrieverMock) GetCachedSequenceTimeoutsCalls() []struct {
+	ProjectName string
+} {
+	var calls []struct {
+		ProjectName string
+	}
+	mock.lockGetCachedSequenceTimeouts.RLock()
+	calls = mock.calls.GetCachedSequenceTimeouts
+	mock.lockGetCachedSequenceTimeouts.RUnlock()
+	return calls
 }
 
 // GetCachedShipyard calls GetCachedShipyardFunc.
@@ -82,7 +183,8 @@ func (mock *IShipyardRetrieverMock) GetCachedShipyard(projectName string) (*kept
 
 // GetCachedShipyardCalls gets all the calls that were made to GetCachedShipyard.
 // Check the length with:
-//     len(mockedIShipyardRetriever.GetCachedShipyardCalls())
+//
+// 	len(mockedIShipyardRetriever.GetCachedShipyardCalls())
 func (mock *IShipyardRetrieverMock) GetCachedShipyardCalls() []struct {
 	ProjectName string
 } {
@@ -95,6 +197,38 @@ func (mock *IShipyardRetrieverMock) GetCachedShipyardCalls() []struct {
 	return calls
 }
 
+// GetCachedTaskAttributes calls GetCachedTaskAttributesFunc.
+func (mock *IShipyardRetrieverMock) GetCachedTaskAttributes(projectName string) (map[string]map[string][]common.TaskAttributes, error) {
+	if mock.GetCachedTaskAttributesFunc == nil {
+		panic("IShipyardRetrieverMock.GetCachedTaskAttributesFunc: method is nil but IShipyardRetriever.GetCachedTaskAttributes was just called")
+	}
+	callInfo := struct {
+		ProjectName string
+	}{
+		ProjectName: projectName,
+	}
+	mock.lockGetCachedTaskAttributes.Lock()
+	mock.calls.GetCachedTaskAttributes = append(mock.calls.GetCachedTaskAttributes, callInfo)
+	mock.lockGetCachedTaskAttributes.Unlock()
+	return mock.GetCachedTaskAttributesFunc(projectName)
+}
+
+// GetCachedTaskAttributesCalls gets all the calls that were made to GetCachedTaskAttributes.
+// Check the length with:
+//
+// 	len(mockedIShipyardRetriever.GetCachedTaskAttributesCalls())
+func (mock *IShipyardRetrieverMock) GetCachedTaskAttributesCalls() []struct {
+	ProjectName string
+} {
+	var calls []struct {
+		ProjectName string
+	}
+	mock.lockGetCachedTaskAttributes.RLock()
+	calls = mock.calls.GetCachedTaskAttributes
+	mock.lockGetCachedTaskAttributes.RUnlock()
+	return calls
+}
+
 // GetLatestCommitID calls GetLatestCommitIDFunc.
 func (mock *IShipyardRetrieverMock) GetLatestCommitID(projectName string, stageName string) (string, error) {
 	if mock.GetLatestCommitIDFunc == nil {
@@ -115,7 +249,8 @@ func (mock *IShipyardRetrieverMock) GetLatestCommitID(projectName string, stageN
 
 // GetLatestCommitIDCalls gets all the calls that were made to GetLatestCommitID.
 // Check the length with:
-//     len(mockedIShipyardRetriever.GetLatestCommitIDCalls())
+//
+// 	len(mockedIShipyardRetriever.GetLatestCommitIDCalls())
 func (mock *IShipyardRetrieverMock) GetLatestCommitIDCalls() []struct {
 	ProjectName string
 	StageName   string
@@ -148,7 +283,8 @@ func (mock *IShipyardRetrieverMock) GetShipyard(projectName string) (*keptnv2.Sh
 
 // GetShipyardCalls gets all the calls that were made to GetShipyard.
 // Check the length with:
-//     len(mockedIShipyardRetriever.GetShipyardCalls())
+//
+// 	len(mockedIShipyardRetriever.GetShipyardCalls())
 func (mock *IShipyardRetrieverMock) GetShipyardCalls() []struct {
 	ProjectName string
 } {
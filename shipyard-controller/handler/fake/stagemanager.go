@@ -5,6 +5,7 @@ package fake
 
 import (
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/shipyard-controller/models"
 	"sync"
 )
 
@@ -14,12 +15,21 @@ import (
 //
 // 		// make and configure a mocked handler.IStageManager
 // 		mockedIStageManager := &IStageManagerMock{
+// 			CreateStageFunc: func(projectName string, params models.CreateStageParams) error {
+// 				panic("mock out the CreateStage method")
+// 			},
+// 			DeleteStageFunc: func(projectName string, stageName string) error {
+// 				panic("mock out the DeleteStage method")
+// 			},
 // 			GetAllStagesFunc: func(projectName string) ([]*apimodels.ExpandedStage, error) {
 // 				panic("mock out the GetAllStages method")
 // 			},
 // 			GetStageFunc: func(projectName string, stageName string) (*apimodels.ExpandedStage, error) {
 // 				panic("mock out the GetStage method")
 // 			},
+// 			UpdateStageFunc: func(projectName string, stageName string, params models.UpdateStageParams) error {
+// 				panic("mock out the UpdateStage method")
+// 			},
 // 		}
 //
 // 		// use mockedIStageManager in code that requires handler.IStageManager
@@ -27,14 +37,37 @@ import (
 //
 // 	}
 type IStageManagerMock struct {
+	// CreateStageFunc mocks the CreateStage method.
+	CreateStageFunc func(projectName string, params models.CreateStageParams) error
+
+	// DeleteStageFunc mocks the DeleteStage method.
+	DeleteStageFunc func(projectName string, stageName string) error
+
 	// GetAllStagesFunc mocks the GetAllStages method.
 	GetAllStagesFunc func(projectName string) ([]*apimodels.ExpandedStage, error)
 
 	// GetStageFunc mocks the GetStage method.
 	GetStageFunc func(projectName string, stageName string) (*apimodels.ExpandedStage, error)
 
+	// UpdateStageFunc mocks the UpdateStage method.
+	UpdateStageFunc func(projectName string, stageName string, params models.UpdateStageParams) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// CreateStage holds details about calls to the CreateStage method.
+		CreateStage []struct {
+			// ProjectName is the projectName argument value.
+			ProjectName string
+			// Params is the params argument value.
+			Params models.CreateStageParams
+		}
+		// DeleteStage holds details about calls to the DeleteStage method.
+		DeleteStage []struct {
+			// ProjectName is the projectName argument value.
+			ProjectName string
+			// StageName is the stageName argument value.
+			StageName string
+		}
 		// GetAllStages holds details about calls to the GetAllStages method.
 		GetAllStages []struct {
 			// ProjectName is the projectName argument value.
@@ -47,9 +80,93 @@ type IStageManagerMock struct {
 			// StageName is the stageName argument value.
 			StageName string
 		}
+		// UpdateStage holds details about calls to the UpdateStage method.
+		UpdateStage []struct {
+			// ProjectName is the projectName argument value.
+			ProjectName string
+			// StageName is the stageName argument value.
+			StageName string
+			// Params is the params argument value.
+			Params models.UpdateStageParams
+		}
 	}
+	lockCreateStage  sync.RWMutex
+	lockDeleteStage  sync.RWMutex
 	lockGetAllStages sync.RWMutex
 	lockGetStage     sync.RWMutex
+	lockUpdateStage  sync.RWMutex
+}
+
+// CreateStage calls CreateStageFunc.
+func (mock *IStageManagerMock) CreateStage(projectName string, params models.CreateStageParams) error {
+	if mock.CreateStageFunc == nil {
+		panic("IStageManagerMock.CreateStageFunc: method is nil but IStageManager.CreateStage was just called")
+	}
+	callInfo := struct {
+		ProjectName string
+		Params      models.CreateStageParams
+	}{
+		ProjectName: projectName,
+		Params:      params,
+	}
+	mock.lockCreateStage.Lock()
+	mock.calls.CreateStage = append(mock.calls.CreateStage, callInfo)
+	mock.lockCreateStage.Unlock()
+	return mock.CreateStageFunc(projectName, params)
+}
+
+// CreateStageCalls gets all the calls that were made to CreateStage.
+// Check the length with:
+//
+// 	len(mockedIStageManager.CreateStageCalls())
+func (mock *IStageManagerMock) CreateStageCalls() []struct {
+	ProjectName string
+	Params      models.CreateStageParams
+} {
+	var calls []struct {
+		ProjectName string
+		Params      models.CreateStageParams
+	}
+	mock.lockCreateStage.RLock()
+	calls = mock.calls.CreateStage
+	mock.lockCreateStage.RUnlock()
+	return calls
+}
+
+// DeleteStage calls DeleteStageFunc.
+func (mock *IStageManagerMock) DeleteStage(projectName string, stageName string) error {
+	if mock.DeleteStageFunc == nil {
+		panic("IStageManagerMock.DeleteStageFunc: method is nil but IStageManager.DeleteStage was just called")
+	}
+	callInfo := struct {
+		ProjectName string
+		StageName   string
+	}{
+		ProjectName: projectName,
+		StageName:   stageName,
+	}
+	mock.lockDeleteStage.Lock()
+	mock.calls.DeleteStage = append(mock.calls.DeleteStage, callInfo)
+	mock.lockDeleteStage.Unlock()
+	return mock.DeleteStageFunc(projectName, stageName)
+}
+
+// DeleteStageCalls gets all the calls that were made to DeleteStage.
+// Check the length with:
+//
+// 	len(mockedIStageManager.DeleteStageCalls())
+func (mock *IStageManagerMock) DeleteStageCalls() []struct {
+	ProjectName string
+	StageName   string
+} {
+	var calls []struct {
+		ProjectName string
+		StageName   string
+	}
+	mock.lockDeleteStage.RLock()
+	calls = mock.calls.DeleteStage
+	mock.lockDeleteStage.RUnlock()
+	return calls
 }
 
 // GetAllStages calls GetAllStagesFunc.
@@ -70,7 +187,8 @@ func (mock *IStageManagerMock) GetAllStages(projectName string) ([]*apimodels.Ex
 
 // GetAllStagesCalls gets all the calls that were made to GetAllStages.
 // Check the length with:
-//     len(mockedIStageManager.GetAllStagesCalls())
+//
+// 	len(mockedIStageManager.GetAllStagesCalls())
 func (mock *IStageManagerMock) GetAllStagesCalls() []struct {
 	ProjectName string
 } {
@@ -103,7 +221,8 @@ func (mock *IStageManagerMock) GetStage(projectName string, stageName string) (*
 
 // GetStageCalls gets all the calls that were made to GetStage.
 // Check the length with:
-//     len(mockedIStageManager.GetStageCalls())
+//
+// 	len(mockedIStageManager.GetStageCalls())
 func (mock *IStageManagerMock) GetStageCalls() []struct {
 	ProjectName string
 	StageName   string
@@ -117,3 +236,43 @@ func (mock *IStageManagerMock) GetStageCalls() []struct {
 	mock.lockGetStage.RUnlock()
 	return calls
 }
+
+// UpdateStage calls UpdateStageFunc.
+func (mock *IStageManagerMock) UpdateStage(projectName string, stageName string, params models.UpdateStageParams) error {
+	if mock.UpdateStageFunc == nil {
+		panic("IStageManagerMock.UpdateStageFunc: method is nil but IStageManager.UpdateStage was just called")
+	}
+	callInfo := struct {
+		ProjectName string
+		StageName   string
+		Params      models.UpdateStageParams
+	}{
+		ProjectName: projectName,
+		StageName:   stageName,
+		Params:      params,
+	}
+	mock.lockUpdateStage.Lock()
+	mock.calls.UpdateStage = append(mock.calls.UpdateStage, callInfo)
+	mock.lockUpdateStage.Unlock()
+	return mock.UpdateStageFunc(projectName, stageName, params)
+}
+
+// UpdateStageCalls gets all the calls that were made to UpdateStage.
+// Check the length with:
+//
+// 	len(mockedIStageManager.UpdateStageCalls())
+func (mock *IStageManagerMock) UpdateStageCalls() []struct {
+	ProjectName string
+	StageName   string
+	Params      models.UpdateStageParams
+} {
+	var calls []struct {
+		ProjectName string
+		StageName   string
+		Params      models.UpdateStageParams
+	}
+	mock.lockUpdateStage.RLock()
+	calls = mock.calls.UpdateStage
+	mock.lockUpdateStage.RUnlock()
+	return calls
+}
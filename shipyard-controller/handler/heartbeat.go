@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/keptn/keptn/shipyard-controller/common"
+)
+
+// heartbeat periodically signals that the claims of a replica on queued items should be renewed.
+// If the dispatchers are not running in partitioned mode, the channel will never receive a signal
+type heartbeat struct {
+	C      <-chan time.Time
+	ticker *clock.Ticker
+}
+
+func newHeartbeat(theClock clock.Clock, mode common.SDMode, claimOptions common.ClaimOptions) *heartbeat {
+	if mode != common.SDModePartitioned {
+		return &heartbeat{}
+	}
+	ticker := theClock.Ticker(claimOptions.TTL / 3)
+	return &heartbeat{C: ticker.C, ticker: ticker}
+}
+
+// Stop stops the heartbeat
+func (h *heartbeat) Stop() {
+	if h.ticker != nil {
+		h.ticker.Stop()
+	}
+}
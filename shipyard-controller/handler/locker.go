@@ -0,0 +1,273 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	log "github.com/sirupsen/logrus"
+)
+
+const minLockRetryInterval = 100 * time.Millisecond
+
+// projectLockTimeout is the maximum duration to wait for another request to finish changing the same project
+const projectLockTimeout = 1 * time.Minute
+
+//go:generate moq -pkg fake -skip-ensure -out ./fake/locker.go . ILocker
+// ILocker provides locks on resources that are shared between all replicas of the shipyard-controller
+type ILocker interface {
+	// TryLock tries to acquire the lock with the given key and returns db.ErrLockHeld if it is currently held by someone else.
+	// The returned context is cancelled as soon as the lock is released or lost
+	TryLock(key string) (*models.Lock, context.Context, error)
+	// Lock blocks until the lock with the given key has been acquired, or the context has been cancelled.
+	// The returned context is cancelled as soon as the lock is released or lost
+	Lock(ctx context.Context, key string) (*models.Lock, context.Context, error)
+	// Unlock releases a lock that has been acquired via TryLock or Lock
+	Unlock(lock models.Lock) error
+}
+
+// DistributedLocker is an implementation of ILocker which stores the locks in a db.LockRepo,
+// which makes them visible to all replicas of the shipyard-controller.
+// Locks held by the DistributedLocker are renewed in the background until they are released.
+// If a lock cannot be renewed before it expires, the context of its holder is cancelled
+type DistributedLocker struct {
+	lockRepo   db.LockRepo
+	owner      string
+	ttl        time.Duration
+	theClock   clock.Clock
+	heldLocks  map[string]heldLock
+	heldLocksM sync.Mutex
+}
+
+type heldLock struct {
+	stopRenewal context.CancelFunc
+	latest      <-chan models.Lock
+}
+
+// NewDistributedLocker creates a new DistributedLocker. The owner should uniquely identify the current replica,
+// and the ttl determines after which time a lock that has not been renewed (e.g. due to a crashed replica) can be taken over by another owner
+func NewDistributedLocker(lockRepo db.LockRepo, owner string, ttl time.Duration, theClock clock.Clock) *DistributedLocker {
+	return &DistributedLocker{
+		lockRepo:  lockRepo,
+		owner:     owner,
+		ttl:       ttl,
+		theClock:  theClock,
+		heldLocks: map[string]heldLock{},
+	}
+}
+
+// TryLock tries to acquire the lock with the given key.
+// If the lock is currently held by another owner, db.ErrLockHeld is returned
+func (d *DistributedLocker) TryLock(key string) (*models.Lock, context.Context, error) {
+	lock, err := d.lockRepo.Acquire(key, d.owner, d.ttl)
+	if err != nil {
+		return nil, nil, err
+	}
+	log.Debugf("Acquired lock %s with token %d", lock.Key, lock.Token)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d.heldLocksM.Lock()
+	defer d.heldLocksM.Unlock()
+	d.heldLocks[heldLockKey(*lock)] = heldLock{
+		stopRenewal: cancel,
+		latest:      d.keepAlive(ctx, cancel, *lock),
+	}
+	return lock, ctx, nil
+}
+
+// Unlock stops the renewal of the given lock and releases it
+func (d *DistributedLocker) Unlock(lock models.Lock) error {
+	d.heldLocksM.Lock()
+	held, ok := d.heldLocks[heldLockKey(lock)]
+	delete(d.heldLocks, heldLockKey(lock))
+	d.heldLocksM.Unlock()
+
+	if ok {
+		held.stopRenewal()
+		lock = <-held.latest
+	}
+	if err := d.lockRepo.Release(lock); err != nil {
+		return err
+	}
+	log.Debugf("Released lock %s with token %d", lock.Key, lock.Token)
+	return nil
+}
+
+// Lock blocks until the lock with the given key has been acquired, or the context has been cancelled
+func (d *DistributedLocker) Lock(ctx context.Context, key string) (*models.Lock, context.Context, error) {
+	retryInterval := d.ttl / 10
+	if retryInterval < minLockRetryInterval {
+		retryInterval = minLockRetryInterval
+	}
+	for {
+		lock, lockCtx, err := d.TryLock(key)
+		if err == nil {
+			return lock, lockCtx, nil
+		}
+		if !errors.Is(err, db.ErrLockHeld) {
+			return nil, nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, nil, fmt.Errorf("could not acquire lock %s: %w", key, ctx.Err())
+		case <-d.theClock.After(retryInterval):
+		}
+	}
+}
+
+// keepAlive renews the lock periodically until the context is cancelled. Afterwards, the latest state of the lock is sent to the returned channel.
+// If the lock has been lost, or has expired because it could not be renewed, the context is cancelled via the given lost function
+func (d *DistributedLocker) keepAlive(ctx context.Context, lost context.CancelFunc, lock models.Lock) <-chan models.Lock {
+	result := make(chan models.Lock, 1)
+	ticker := d.theClock.Ticker(d.ttl / 3)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				result <- lock
+				return
+			case <-ticker.C:
+				renewed, err := d.lockRepo.Renew(lock, d.ttl)
+				if err != nil {
+					log.WithError(err).Errorf("Could not renew lock %s", lock.Key)
+					if errors.Is(err, db.ErrLockLost) || lock.IsExpired(d.theClock.Now()) {
+						// another owner may already hold the lock, so the current holder must stop acting on it
+						lost()
+						result <- lock
+						return
+					}
+					continue
+				}
+				lock = *renewed
+			}
+		}
+	}()
+	return result
+}
+
+// withLock executes the given function while holding the lock with the given key.
+// The context passed to the function is cancelled if the lock is lost before the function returns, and carries the fencing token of the lock.
+// If no locker is provided, the function is executed without acquiring a lock
+func withLock(locker ILocker, key string, fn func(ctx context.Context) error) error {
+	if locker == nil {
+		return fn(context.Background())
+	}
+	lock, lockCtx, err := locker.TryLock(key)
+	if err != nil {
+		return err
+	}
+	defer unlock(locker, *lock)
+	return fn(withFencingToken(lockCtx, *lock))
+}
+
+// withBlockingLock executes the given function after waiting for the lock with the given key to become available.
+// The context passed to the function is cancelled if the lock is lost before the function returns, and carries the fencing token of the lock.
+// If no locker is provided, the function is executed without acquiring a lock
+func withBlockingLock(locker ILocker, key string, timeout time.Duration, fn func(ctx context.Context) error) error {
+	if locker == nil {
+		return fn(context.Background())
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	lock, lockCtx, err := locker.Lock(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer unlock(locker, *lock)
+	return fn(withFencingToken(lockCtx, *lock))
+}
+
+// lockProject blocks until the lock of the given project has been acquired, so that changes to the project that are requested
+// via different replicas of the shipyard-controller do not interfere. The returned function releases the lock.
+// If no locker is provided, no lock is acquired
+func lockProject(locker ILocker, project string) (func(), error) {
+	if locker == nil {
+		return func() {}, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), projectLockTimeout)
+	defer cancel()
+	lock, _, err := locker.Lock(ctx, projectLockKey(project))
+	if err != nil {
+		return nil, err
+	}
+	return func() { unlock(locker, *lock) }, nil
+}
+
+// checkLockHeld returns db.ErrLockLost if the lock belonging to the given context is not held anymore.
+// It should be called right before acting on a locked resource
+func checkLockHeld(ctx context.Context) error {
+	if ctx.Err() != nil {
+		return db.ErrLockLost
+	}
+	return nil
+}
+
+// cancelWhenDone returns a copy of ctx that is additionally cancelled as soon as the other context is done
+func cancelWhenDone(ctx context.Context, other context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-other.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+type fencingTokenKey struct{}
+
+func withFencingToken(ctx context.Context, lock models.Lock) context.Context {
+	return context.WithValue(ctx, fencingTokenKey{}, lock.Token)
+}
+
+// fencingTokenFromContext returns the fencing token of the lock belonging to the given context, or 0 if the context does not belong to a lock.
+// Writes that carry the token are rejected if the locked resource has already been written by the holder of a newer lock
+func fencingTokenFromContext(ctx context.Context) int64 {
+	token, _ := ctx.Value(fencingTokenKey{}).(int64)
+	return token
+}
+
+func unlock(locker ILocker, lock models.Lock) {
+	if err := locker.Unlock(lock); err != nil {
+		log.WithError(err).Errorf("Could not release lock %s", lock.Key)
+	}
+}
+
+func heldLockKey(lock models.Lock) string {
+	return fmt.Sprintf("%s/%d", lock.Key, lock.Token)
+}
+
+func projectLockKey(project string) string {
+	return fmt.Sprintf("project.%s", project)
+}
+
+func sequenceDispatchLockKey(scope models.EventScope) string {
+	return fmt.Sprintf("sequence-dispatcher.%s.%s.%s", scope.Project, scope.Stage, scope.Service)
+}
+
+func eventDispatchLockKey(scope models.EventScope) string {
+	return fmt.Sprintf("event-dispatcher.%s.%s", scope.Project, scope.Stage)
+}
+
+func sequenceContextLockKey(scope models.EventScope) string {
+	return fmt.Sprintf("sequence.%s.%s.%s", scope.Project, scope.Stage, scope.KeptnContext)
+}
+
+func projectConcurrencyLimitLockKey(project string) string {
+	return fmt.Sprintf("concurrency-limit.project.%s", project)
+}
+
+func stageConcurrencyLimitLockKey(project, stage string) string {
+	return fmt.Sprintf("concurrency-limit.stage.%s.%s", project, stage)
+}
+
+func sequenceConcurrencyLimitLockKey(sequenceName string) string {
+	return fmt.Sprintf("concurrency-limit.sequence.%s", sequenceName)
+}
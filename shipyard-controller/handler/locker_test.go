@@ -0,0 +1,218 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	dbmock "github.com/keptn/keptn/shipyard-controller/db/mock"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistributedLocker_TryLockAndUnlock(t *testing.T) {
+	lockRepo := &dbmock.LockRepoMock{
+		AcquireFunc: func(key string, owner string, ttl time.Duration) (*models.Lock, error) {
+			return &models.Lock{Key: key, Owner: owner, Token: 1}, nil
+		},
+		ReleaseFunc: func(lock models.Lock) error {
+			return nil
+		},
+	}
+
+	locker := handler.NewDistributedLocker(lockRepo, "my-replica", 30*time.Second, clock.NewMock())
+
+	lock, _, err := locker.TryLock("my-key")
+	require.Nil(t, err)
+	require.Equal(t, "my-key", lock.Key)
+	require.Equal(t, "my-replica", lock.Owner)
+
+	require.Len(t, lockRepo.AcquireCalls(), 1)
+	require.Equal(t, 30*time.Second, lockRepo.AcquireCalls()[0].Ttl)
+
+	err = locker.Unlock(*lock)
+	require.Nil(t, err)
+
+	require.Len(t, lockRepo.ReleaseCalls(), 1)
+	require.Equal(t, *lock, lockRepo.ReleaseCalls()[0].Lock)
+}
+
+func TestDistributedLocker_TryLockHeldByOtherOwner(t *testing.T) {
+	lockRepo := &dbmock.LockRepoMock{
+		AcquireFunc: func(key string, owner string, ttl time.Duration) (*models.Lock, error) {
+			return nil, db.ErrLockHeld
+		},
+	}
+
+	locker := handler.NewDistributedLocker(lockRepo, "my-replica", 30*time.Second, clock.NewMock())
+
+	lock, _, err := locker.TryLock("my-key")
+	require.Nil(t, lock)
+	require.ErrorIs(t, err, db.ErrLockHeld)
+}
+
+func TestDistributedLocker_LockRenewsLease(t *testing.T) {
+	theClock := clock.NewMock()
+	expiry := theClock.Now().Add(30 * time.Second)
+	lockRepo := &dbmock.LockRepoMock{
+		AcquireFunc: func(key string, owner string, ttl time.Duration) (*models.Lock, error) {
+			return &models.Lock{Key: key, Owner: owner, Token: 1, ExpiresAt: expiry}, nil
+		},
+		RenewFunc: func(lock models.Lock, ttl time.Duration) (*models.Lock, error) {
+			lock.ExpiresAt = lock.ExpiresAt.Add(ttl)
+			return &lock, nil
+		},
+		ReleaseFunc: func(lock models.Lock) error {
+			return nil
+		},
+	}
+
+	locker := handler.NewDistributedLocker(lockRepo, "my-replica", 30*time.Second, theClock)
+
+	lock, _, err := locker.Lock(context.Background(), "my-key")
+	require.Nil(t, err)
+
+	theClock.Add(10 * time.Second)
+
+	require.Eventually(t, func() bool {
+		return len(lockRepo.RenewCalls()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	err = locker.Unlock(*lock)
+	require.Nil(t, err)
+
+	// the most recent state of the lock should have been released
+	require.Len(t, lockRepo.ReleaseCalls(), 1)
+	require.Equal(t, expiry.Add(30*time.Second), lockRepo.ReleaseCalls()[0].Lock.ExpiresAt)
+}
+
+func TestDistributedLocker_LockWaitsForLockToBeReleased(t *testing.T) {
+	acquireAttempts := 0
+	lockRepo := &dbmock.LockRepoMock{
+		AcquireFunc: func(key string, owner string, ttl time.Duration) (*models.Lock, error) {
+			acquireAttempts++
+			if acquireAttempts < 3 {
+				return nil, db.ErrLockHeld
+			}
+			return &models.Lock{Key: key, Owner: owner, Token: 2}, nil
+		},
+		ReleaseFunc: func(lock models.Lock) error {
+			return nil
+		},
+	}
+
+	locker := handler.NewDistributedLocker(lockRepo, "my-replica", time.Second, clock.New())
+
+	lock, _, err := locker.Lock(context.Background(), "my-key")
+	require.Nil(t, err)
+	require.Equal(t, int64(2), lock.Token)
+	require.Len(t, lockRepo.AcquireCalls(), 3)
+
+	require.Nil(t, locker.Unlock(*lock))
+}
+
+func TestDistributedLocker_LockTimesOut(t *testing.T) {
+	lockRepo := &dbmock.LockRepoMock{
+		AcquireFunc: func(key string, owner string, ttl time.Duration) (*models.Lock, error) {
+			return nil, db.ErrLockHeld
+		},
+	}
+
+	locker := handler.NewDistributedLocker(lockRepo, "my-replica", time.Second, clock.New())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	lock, _, err := locker.Lock(ctx, "my-key")
+	require.Nil(t, lock)
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestDistributedLocker_LockReturnsUnexpectedError(t *testing.T) {
+	lockRepo := &dbmock.LockRepoMock{
+		AcquireFunc: func(key string, owner string, ttl time.Duration) (*models.Lock, error) {
+			return nil, errors.New("oops")
+		},
+	}
+
+	locker := handler.NewDistributedLocker(lockRepo, "my-replica", time.Second, clock.New())
+
+	lock, _, err := locker.Lock(context.Background(), "my-key")
+	require.Nil(t, lock)
+	require.NotNil(t, err)
+	require.Len(t, lockRepo.AcquireCalls(), 1)
+}
+
+func TestDistributedLocker_LockLost(t *testing.T) {
+	theClock := clock.NewMock()
+	lockRepo := &dbmock.LockRepoMock{
+		AcquireFunc: func(key string, owner string, ttl time.Duration) (*models.Lock, error) {
+			return &models.Lock{Key: key, Owner: owner, Token: 1, ExpiresAt: theClock.Now().Add(ttl)}, nil
+		},
+		RenewFunc: func(lock models.Lock, ttl time.Duration) (*models.Lock, error) {
+			return nil, db.ErrLockLost
+		},
+		ReleaseFunc: func(lock models.Lock) error {
+			return db.ErrLockLost
+		},
+	}
+
+	locker := handler.NewDistributedLocker(lockRepo, "my-replica", 30*time.Second, theClock)
+
+	lock, lockCtx, err := locker.TryLock("my-key")
+	require.Nil(t, err)
+	require.Nil(t, lockCtx.Err())
+
+	theClock.Add(10 * time.Second)
+
+	// the holder of the lock should be notified that it has been lost
+	require.Eventually(t, func() bool {
+		return lockCtx.Err() != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.ErrorIs(t, locker.Unlock(*lock), db.ErrLockLost)
+}
+
+func TestDistributedLocker_LockExpiredWhileRenewalFails(t *testing.T) {
+	theClock := clock.NewMock()
+	lockRepo := &dbmock.LockRepoMock{
+		AcquireFunc: func(key string, owner string, ttl time.Duration) (*models.Lock, error) {
+			return &models.Lock{Key: key, Owner: owner, Token: 1, ExpiresAt: theClock.Now().Add(ttl)}, nil
+		},
+		RenewFunc: func(lock models.Lock, ttl time.Duration) (*models.Lock, error) {
+			return nil, errors.New("oops")
+		},
+		ReleaseFunc: func(lock models.Lock) error {
+			return nil
+		},
+	}
+
+	locker := handler.NewDistributedLocker(lockRepo, "my-replica", 30*time.Second, theClock)
+
+	lock, lockCtx, err := locker.TryLock("my-key")
+	require.Nil(t, err)
+
+	// as long as the lock has not expired, failed renewals are retried
+	theClock.Add(10 * time.Second)
+	require.Eventually(t, func() bool {
+		return len(lockRepo.RenewCalls()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Nil(t, lockCtx.Err())
+
+	theClock.Add(10 * time.Second)
+	require.Eventually(t, func() bool {
+		return len(lockRepo.RenewCalls()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Nil(t, lockCtx.Err())
+
+	theClock.Add(10 * time.Second)
+	require.Eventually(t, func() bool {
+		return lockCtx.Err() != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Nil(t, locker.Unlock(*lock))
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type IMetricsHandler interface {
+	Metrics(context *gin.Context)
+}
+
+type MetricsHandler struct {
+	handler http.Handler
+}
+
+// NewMetricsHandler creates a new MetricsHandler exposing the metrics collected by the given gatherer in the Prometheus format
+func NewMetricsHandler(gatherer prometheus.Gatherer) *MetricsHandler {
+	return &MetricsHandler{handler: promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{})}
+}
+
+// Metrics writes the internal metrics of the shipyard-controller in the Prometheus text format, e.g. the depths of the queues,
+// the number of sequences per state, the duration of tasks and dispatcher loops, and the time since the last heartbeat of each integration
+func (h *MetricsHandler) Metrics(c *gin.Context) {
+	h.handler.ServeHTTP(c.Writer, c.Request)
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// notificationClaimDuration is the duration for which a delivery is claimed by a replica while it is being sent
+const notificationClaimDuration = 1 * time.Minute
+
+// NotificationRetryOptions determines how often and in which intervals the delivery of a notification is retried
+type NotificationRetryOptions struct {
+	// MaxAttempts is the maximum number of attempts to deliver a notification
+	MaxAttempts int
+	// Backoff is the duration between the first and the second attempt. It is doubled with each further attempt
+	Backoff time.Duration
+}
+
+// getBackoff returns the duration to wait after the given number of failed attempts
+func (o NotificationRetryOptions) getBackoff(attempts int) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+	return o.Backoff * time.Duration(1<<(attempts-1))
+}
+
+// NotificationDispatcher periodically sends the pending notification deliveries via their channels.
+// The dispatcher can run on every replica of the shipyard-controller, since each attempt of a delivery is claimed by exactly one of them
+type NotificationDispatcher struct {
+	deliveryRepo db.NotificationDeliveryRepo
+	channelRepo  db.NotificationChannelRepo
+	senders      map[string]NotificationSender
+	retryOptions NotificationRetryOptions
+	syncInterval time.Duration
+	theClock     clock.Clock
+}
+
+// NewNotificationDispatcher creates a new NotificationDispatcher
+func NewNotificationDispatcher(deliveryRepo db.NotificationDeliveryRepo, channelRepo db.NotificationChannelRepo, senders map[string]NotificationSender, retryOptions NotificationRetryOptions, syncInterval time.Duration, theClock clock.Clock) *NotificationDispatcher {
+	return &NotificationDispatcher{
+		deliveryRepo: deliveryRepo,
+		channelRepo:  channelRepo,
+		senders:      senders,
+		retryOptions: retryOptions,
+		syncInterval: syncInterval,
+		theClock:     theClock,
+	}
+}
+
+func (nd *NotificationDispatcher) Run(ctx context.Context) {
+	ticker := nd.theClock.Ticker(nd.syncInterval)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				log.Info("cancelling NotificationDispatcher loop")
+				ticker.Stop()
+				return
+			case <-ticker.C:
+				log.Debugf("%.2f seconds have passed. Looking for due notifications", nd.syncInterval.Seconds())
+				nd.dispatchDueDeliveries()
+			}
+		}
+	}()
+}
+
+func (nd *NotificationDispatcher) dispatchDueDeliveries() {
+	deliveries, err := nd.deliveryRepo.GetDueNotificationDeliveries(nd.theClock.Now().UTC())
+	if err != nil {
+		log.WithError(err).Error("could not load due notification deliveries")
+		return
+	}
+
+	for _, delivery := range deliveries {
+		if err := nd.dispatch(delivery); err != nil {
+			log.WithError(err).Errorf("could not dispatch notification delivery %s", delivery.ID)
+		}
+	}
+}
+
+func (nd *NotificationDispatcher) dispatch(delivery models.NotificationDelivery) error {
+	if err := nd.deliveryRepo.ClaimNotificationDelivery(delivery, nd.theClock.Now().UTC().Add(notificationClaimDuration)); err != nil {
+		if errors.Is(err, db.ErrNotificationDeliveryClaimed) {
+			log.Debugf("notification delivery %s has already been claimed", delivery.ID)
+			return nil
+		}
+		return err
+	}
+
+	sendErr := nd.send(delivery)
+
+	now := nd.theClock.Now().UTC()
+	delivery.Attempts++
+	if sendErr == nil {
+		delivery.Status = models.NotificationDeliveryDelivered
+		delivery.LastError = ""
+		delivery.NextAttemptAt = nil
+		delivery.DeliveredAt = &now
+	} else {
+		log.WithError(sendErr).Warnf("attempt %d to deliver notification %s via channel %s failed", delivery.Attempts, delivery.ID, delivery.ChannelID)
+		delivery.LastError = sendErr.Error()
+		if delivery.Attempts >= nd.retryOptions.MaxAttempts || errors.Is(sendErr, ErrNotificationChannelNotFound) {
+			delivery.Status = models.NotificationDeliveryFailed
+			delivery.NextAttemptAt = nil
+		} else {
+			nextAttemptAt := now.Add(nd.retryOptions.getBackoff(delivery.Attempts))
+			delivery.NextAttemptAt = &nextAttemptAt
+		}
+	}
+	return nd.deliveryRepo.UpdateNotificationDelivery(delivery)
+}
+
+func (nd *NotificationDispatcher) send(delivery models.NotificationDelivery) error {
+	channel, err := nd.channelRepo.GetNotificationChannel(delivery.Project, delivery.ChannelID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotificationChannelNotFound) {
+			// the channel has been deleted after the notification has been created
+			return ErrNotificationChannelNotFound
+		}
+		return fmt.Errorf("could not load notification channel: %w", err)
+	}
+	sender, ok := nd.senders[channel.Type]
+	if !ok {
+		return fmt.Errorf("unsupported notification channel type '%s'", channel.Type)
+	}
+	return sender.Send(*channel, delivery.Notification)
+}
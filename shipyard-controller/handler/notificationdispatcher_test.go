@@ -0,0 +1,156 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+	"github.com/keptn/keptn/shipyard-controller/handler/fake"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationDispatcher(t *testing.T) {
+	now := time.Date(2022, 3, 1, 2, 0, 0, 0, time.UTC)
+	// the dispatcher runs after the first tick, and the third attempt is followed by a backoff of 4 * 30s
+	retryAt := now.Add(10*time.Second + 4*30*time.Second)
+	tests := []struct {
+		name              string
+		attempts          int
+		sendErr           error
+		channelErr        error
+		claimErr          error
+		wantSendCalls     int
+		wantUpdate        bool
+		wantStatus        string
+		wantNextAttemptAt *time.Time
+	}{
+		{
+			name:          "deliver notification",
+			wantSendCalls: 1,
+			wantUpdate:    true,
+			wantStatus:    models.NotificationDeliveryDelivered,
+		},
+		{
+			name:              "retry failed delivery with backoff",
+			attempts:          2,
+			sendErr:           errors.New("oops"),
+			wantSendCalls:     1,
+			wantUpdate:        true,
+			wantStatus:        models.NotificationDeliveryPending,
+			wantNextAttemptAt: &retryAt,
+		},
+		{
+			name:          "mark delivery as failed after max attempts",
+			attempts:      4,
+			sendErr:       errors.New("oops"),
+			wantSendCalls: 1,
+			wantUpdate:    true,
+			wantStatus:    models.NotificationDeliveryFailed,
+		},
+		{
+			name:          "mark delivery as failed if channel has been deleted",
+			channelErr:    db.ErrNotificationChannelNotFound,
+			wantSendCalls: 0,
+			wantUpdate:    true,
+			wantStatus:    models.NotificationDeliveryFailed,
+		},
+		{
+			name:          "do not send delivery that has been claimed by another replica",
+			claimErr:      db.ErrNotificationDeliveryClaimed,
+			wantSendCalls: 0,
+			wantUpdate:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theClock := clock.NewMock()
+			theClock.Set(now)
+
+			dueAt := theClock.Now().UTC()
+			delivery := models.NotificationDelivery{
+				ID:            "my-delivery",
+				ChannelID:     "my-channel",
+				Project:       "my-project",
+				Status:        models.NotificationDeliveryPending,
+				Attempts:      tt.attempts,
+				NextAttemptAt: &dueAt,
+			}
+
+			deliveryRepo := &db_mock.NotificationDeliveryRepoMock{
+				GetDueNotificationDeliveriesFunc: func(dueAt time.Time) ([]models.NotificationDelivery, error) {
+					return []models.NotificationDelivery{delivery}, nil
+				},
+				ClaimNotificationDeliveryFunc: func(delivery models.NotificationDelivery, claimedUntil time.Time) error {
+					return tt.claimErr
+				},
+				UpdateNotificationDeliveryFunc: func(delivery models.NotificationDelivery) error {
+					return nil
+				},
+			}
+			channelRepo := &db_mock.NotificationChannelRepoMock{
+				GetNotificationChannelFunc: func(project string, id string) (*models.NotificationChannel, error) {
+					if tt.channelErr != nil {
+						return nil, tt.channelErr
+					}
+					return &models.NotificationChannel{ID: id, Project: project, Type: models.NotificationChannelWebhook}, nil
+				},
+			}
+			sender := &fake.NotificationSenderMock{
+				SendFunc: func(channel models.NotificationChannel, notification models.Notification) error {
+					return tt.sendErr
+				},
+			}
+
+			dispatcher := handler.NewNotificationDispatcher(
+				deliveryRepo,
+				channelRepo,
+				map[string]handler.NotificationSender{models.NotificationChannelWebhook: sender},
+				handler.NotificationRetryOptions{MaxAttempts: 5, Backoff: 30 * time.Second},
+				10*time.Second,
+				theClock,
+			)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			dispatcher.Run(ctx)
+
+			// give the dispatcher's goroutine time to start listening on the ticker
+			time.Sleep(100 * time.Millisecond)
+			theClock.Add(10 * time.Second)
+
+			require.Eventually(t, func() bool {
+				return len(deliveryRepo.ClaimNotificationDeliveryCalls()) == 1
+			}, 5*time.Second, 10*time.Millisecond)
+
+			if !tt.wantUpdate {
+				require.Never(t, func() bool {
+					return len(deliveryRepo.UpdateNotificationDeliveryCalls()) > 0
+				}, 500*time.Millisecond, 10*time.Millisecond)
+				require.Empty(t, sender.SendCalls())
+				return
+			}
+
+			require.Eventually(t, func() bool {
+				return len(deliveryRepo.UpdateNotificationDeliveryCalls()) == 1
+			}, 5*time.Second, 10*time.Millisecond)
+			require.Len(t, sender.SendCalls(), tt.wantSendCalls)
+
+			updated := deliveryRepo.UpdateNotificationDeliveryCalls()[0].Delivery
+			require.Equal(t, tt.attempts+1, updated.Attempts)
+			require.Equal(t, tt.wantStatus, updated.Status)
+			require.Equal(t, tt.wantNextAttemptAt, updated.NextAttemptAt)
+			if tt.wantStatus == models.NotificationDeliveryDelivered {
+				require.NotNil(t, updated.DeliveredAt)
+				require.Empty(t, updated.LastError)
+			} else {
+				require.NotEmpty(t, updated.LastError)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/models"
+)
+
+type NotificationChannelParamsValidator struct{}
+
+func (n NotificationChannelParamsValidator) Validate(params interface{}) error {
+	switch t := params.(type) {
+	case *models.NotificationChannelParams:
+		return n.validateNotificationChannelParams(t)
+	default:
+		return nil
+	}
+}
+
+func (n NotificationChannelParamsValidator) validateNotificationChannelParams(params *models.NotificationChannelParams) error {
+	if params.Name == "" {
+		return errors.New("name must be specified")
+	}
+	switch params.Type {
+	case models.NotificationChannelWebhook, models.NotificationChannelSlack:
+		if err := validateNotificationURL(params.URL); err != nil {
+			return err
+		}
+	case models.NotificationChannelSMTP:
+		if err := validateSMTPSettings(params.SMTP); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("invalid channel type '%s', expected one of '%s', '%s' or '%s'", params.Type, models.NotificationChannelWebhook, models.NotificationChannelSlack, models.NotificationChannelSMTP)
+	}
+	return validateNotificationFilter(params.Filter)
+}
+
+func validateNotificationURL(rawURL string) error {
+	if rawURL == "" {
+		return errors.New("url must be specified")
+	}
+	parsed, err := url.ParseRequestURI(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errors.New("url must be a valid http or https URL")
+	}
+	return nil
+}
+
+func validateSMTPSettings(settings *models.SMTPSettings) error {
+	if settings == nil {
+		return errors.New("smtp settings must be specified")
+	}
+	if settings.Host == "" || settings.From == "" {
+		return errors.New("host and sender of the smtp settings must be specified")
+	}
+	if settings.Port <= 0 || settings.Port > 65535 {
+		return fmt.Errorf("invalid smtp port %d", settings.Port)
+	}
+	if len(settings.To) == 0 {
+		return errors.New("at least one recipient must be specified")
+	}
+	return nil
+}
+
+func validateNotificationFilter(filter models.NotificationFilter) error {
+	for _, event := range filter.Events {
+		if !containsString(models.NotificationEvents, event) {
+			return fmt.Errorf("invalid event '%s', expected one of %v", event, models.NotificationEvents)
+		}
+	}
+	results := []string{string(keptnv2.ResultPass), string(keptnv2.ResultWarning), string(keptnv2.ResultFailed)}
+	for _, result := range filter.Results {
+		if !containsString(results, result) {
+			return fmt.Errorf("invalid result '%s', expected one of %v", result, results)
+		}
+	}
+	return nil
+}
+
+type INotificationHandler interface {
+	CreateNotificationChannel(context *gin.Context)
+	GetNotificationChannels(context *gin.Context)
+	GetNotificationChannel(context *gin.Context)
+	UpdateNotificationChannel(context *gin.Context)
+	DeleteNotificationChannel(context *gin.Context)
+	GetNotificationDeliveries(context *gin.Context)
+}
+
+type NotificationHandler struct {
+	notificationManager INotificationManager
+}
+
+func NewNotificationHandler(notificationManager INotificationManager) *NotificationHandler {
+	return &NotificationHandler{
+		notificationManager: notificationManager,
+	}
+}
+
+// CreateNotificationChannel godoc
+// @Summary      Create a notification channel
+// @Description  Create a channel that receives notifications about the sequences of a project, e.g. a generic webhook, a Slack-compatible webhook or an SMTP server
+// @Tags         Notification
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project  path      string                                    true  "The name of the project"
+// @Param        channel  body      models.NotificationChannelParams          true  "Notification channel"
+// @Success      201      {object}  models.CreateNotificationChannelResponse  "ok"
+// @Failure      400      {object}  models.Error                              "Invalid payload"
+// @Failure      404      {object}  models.Error                              "Not found"
+// @Failure      500      {object}  models.Error                              "Internal error"
+// @Router       /project/{project}/notification/channel [post]
+func (nh *NotificationHandler) CreateNotificationChannel(c *gin.Context) {
+	params := &models.NotificationChannelParams{}
+	if err := c.ShouldBindJSON(params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidRequestFormatMsg, err.Error()))
+		return
+	}
+	if err := (NotificationChannelParamsValidator{}).Validate(params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidPayloadMsg, err.Error()))
+		return
+	}
+
+	channel, err := nh.notificationManager.CreateNotificationChannel(c.Param("project"), *params)
+	if err != nil {
+		setNotificationErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, &models.CreateNotificationChannelResponse{ID: channel.ID})
+}
+
+// GetNotificationChannels godoc
+// @Summary      Get notification channels
+// @Description  Get all notification channels of a project. Passwords of SMTP servers are not included
+// @Tags         Notification
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project  path      string                                  true  "The name of the project"
+// @Success      200      {object}  models.GetNotificationChannelsResponse  "ok"
+// @Failure      404      {object}  models.Error                            "Not found"
+// @Failure      500      {object}  models.Error                            "Internal error"
+// @Router       /project/{project}/notification/channel [get]
+func (nh *NotificationHandler) GetNotificationChannels(c *gin.Context) {
+	channels, err := nh.notificationManager.GetNotificationChannels(c.Param("project"))
+	if err != nil {
+		if errors.Is(err, ErrProjectNotFound) {
+			SetNotFoundErrorResponse(c, err.Error())
+			return
+		}
+		SetInternalServerErrorResponse(c, fmt.Sprintf(UnableQueryNotificationChannelsMsg, err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, &models.GetNotificationChannelsResponse{Channels: channels})
+}
+
+// GetNotificationChannel godoc
+// @Summary      Get a notification channel
+// @Description  Get a notification channel by its ID. The password of an SMTP server is not included
+// @Tags         Notification
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project    path      string                      true  "The name of the project"
+// @Param        channelId  path      string                      true  "The ID of the notification channel"
+// @Success      200        {object}  models.NotificationChannel  "ok"
+// @Failure      404        {object}  models.Error                "Not found"
+// @Failure      500        {object}  models.Error                "Internal error"
+// @Router       /project/{project}/notification/channel/{channelId} [get]
+func (nh *NotificationHandler) GetNotificationChannel(c *gin.Context) {
+	channel, err := nh.notificationManager.GetNotificationChannel(c.Param("project"), c.Param("channelId"))
+	if err != nil {
+		setNotificationErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, channel)
+}
+
+// UpdateNotificationChannel godoc
+// @Summary      Update a notification channel
+// @Description  Update a notification channel. If the password of an SMTP server is omitted, the stored password is kept
+// @Tags         Notification
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project    path      string                            true  "The name of the project"
+// @Param        channelId  path      string                            true  "The ID of the notification channel"
+// @Param        channel    body      models.NotificationChannelParams  true  "Notification channel"
+// @Success      200        {object}  models.NotificationChannel        "ok"
+// @Failure      400        {object}  models.Error                      "Invalid payload"
+// @Failure      404        {object}  models.Error                      "Not found"
+// @Failure      500        {object}  models.Error                      "Internal error"
+// @Router       /project/{project}/notification/channel/{channelId} [put]
+func (nh *NotificationHandler) UpdateNotificationChannel(c *gin.Context) {
+	params := &models.NotificationChannelParams{}
+	if err := c.ShouldBindJSON(params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidRequestFormatMsg, err.Error()))
+		return
+	}
+	if err := (NotificationChannelParamsValidator{}).Validate(params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidPayloadMsg, err.Error()))
+		return
+	}
+
+	channel, err := nh.notificationManager.UpdateNotificationChannel(c.Param("project"), c.Param("channelId"), *params)
+	if err != nil {
+		setNotificationErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, channel)
+}
+
+// DeleteNotificationChannel godoc
+// @Summary      Delete a notification channel
+// @Description  Delete a notification channel. Pending deliveries of the channel are marked as failed
+// @Tags         Notification
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project    path      string                                    true  "The name of the project"
+// @Param        channelId  path      string                                    true  "The ID of the notification channel"
+// @Success      200        {object}  models.DeleteNotificationChannelResponse  "ok"
+// @Failure      404        {object}  models.Error                              "Not found"
+// @Failure      500        {object}  models.Error                              "Internal error"
+// @Router       /project/{project}/notification/channel/{channelId} [delete]
+func (nh *NotificationHandler) DeleteNotificationChannel(c *gin.Context) {
+	if err := nh.notificationManager.DeleteNotificationChannel(c.Param("project"), c.Param("channelId")); err != nil {
+		setNotificationErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, &models.DeleteNotificationChannelResponse{})
+}
+
+// GetNotificationDeliveries godoc
+// @Summary      Get notification deliveries
+// @Description  Get the delivery log of the notifications of a project, ordered from newest to oldest
+// @Tags         Notification
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project       path      string                                    true   "The name of the project"
+// @Param        channelId     query     string                                    false  "The ID of the notification channel"
+// @Param        keptnContext  query     string                                    false  "The keptn context of the sequence"
+// @Param        status        query     string                                    false  "The status of the deliveries ('pending', 'delivered' or 'failed')"
+// @Param        pageSize      query     int                                       false  "The number of items to return"
+// @Param        nextPageKey   query     int                                       false  "Pointer to the next set of items"
+// @Success      200           {object}  models.GetNotificationDeliveriesResponse  "ok"
+// @Failure      400           {object}  models.Error                              "Invalid payload"
+// @Failure      404           {object}  models.Error                              "Not found"
+// @Failure      500           {object}  models.Error                              "Internal error"
+// @Router       /project/{project}/notification/delivery [get]
+func (nh *NotificationHandler) GetNotificationDeliveries(c *gin.Context) {
+	params := &models.GetNotificationDeliveriesParams{}
+	if err := c.ShouldBindQuery(params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidRequestFormatMsg, err.Error()))
+		return
+	}
+	switch params.Status {
+	case "", models.NotificationDeliveryPending, models.NotificationDeliveryDelivered, models.NotificationDeliveryFailed:
+	default:
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidRequestFormatMsg, fmt.Sprintf("invalid status '%s'", params.Status)))
+		return
+	}
+	params.Project = c.Param("project")
+
+	deliveries, err := nh.notificationManager.GetNotificationDeliveries(*params)
+	if err != nil {
+		if errors.Is(err, ErrProjectNotFound) {
+			SetNotFoundErrorResponse(c, err.Error())
+			return
+		}
+		SetInternalServerErrorResponse(c, fmt.Sprintf(UnableQueryNotificationDeliveriesMsg, err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, deliveries)
+}
+
+func setNotificationErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrNotificationChannelNotFound), errors.Is(err, ErrProjectNotFound):
+		SetNotFoundErrorResponse(c, err.Error())
+	default:
+		SetInternalServerErrorResponse(c, err.Error())
+	}
+}
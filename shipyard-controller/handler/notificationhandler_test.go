@@ -0,0 +1,243 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+	"github.com/keptn/keptn/shipyard-controller/handler/fake"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationChannelParamsValidator(t *testing.T) {
+	smtpSettings := &models.SMTPSettings{Host: "smtp.example.com", Port: 587, From: "keptn@example.com", To: []string{"team@example.com"}}
+	tests := []struct {
+		name    string
+		params  *models.NotificationChannelParams
+		wantErr bool
+	}{
+		{
+			name:    "valid webhook channel",
+			params:  &models.NotificationChannelParams{Name: "my-channel", Type: models.NotificationChannelWebhook, URL: "https://example.com/hook"},
+			wantErr: false,
+		},
+		{
+			name: "valid slack channel with filter",
+			params: &models.NotificationChannelParams{
+				Name: "my-channel",
+				Type: models.NotificationChannelSlack,
+				URL:  "https://hooks.slack.com/services/abc",
+				Filter: models.NotificationFilter{
+					Events:  []string{models.NotificationSequenceFinished},
+					Stages:  []string{"production"},
+					Results: []string{"fail"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "valid smtp channel",
+			params:  &models.NotificationChannelParams{Name: "my-channel", Type: models.NotificationChannelSMTP, SMTP: smtpSettings},
+			wantErr: false,
+		},
+		{
+			name:    "missing name",
+			params:  &models.NotificationChannelParams{Type: models.NotificationChannelWebhook, URL: "https://example.com/hook"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid type",
+			params:  &models.NotificationChannelParams{Name: "my-channel", Type: "pigeon", URL: "https://example.com/hook"},
+			wantErr: true,
+		},
+		{
+			name:    "missing url",
+			params:  &models.NotificationChannelParams{Name: "my-channel", Type: models.NotificationChannelWebhook},
+			wantErr: true,
+		},
+		{
+			name:    "url with invalid scheme",
+			params:  &models.NotificationChannelParams{Name: "my-channel", Type: models.NotificationChannelSlack, URL: "ftp://example.com/hook"},
+			wantErr: true,
+		},
+		{
+			name:    "missing smtp settings",
+			params:  &models.NotificationChannelParams{Name: "my-channel", Type: models.NotificationChannelSMTP},
+			wantErr: true,
+		},
+		{
+			name:    "smtp settings without recipients",
+			params:  &models.NotificationChannelParams{Name: "my-channel", Type: models.NotificationChannelSMTP, SMTP: &models.SMTPSettings{Host: "smtp.example.com", Port: 25, From: "keptn@example.com"}},
+			wantErr: true,
+		},
+		{
+			name:    "smtp settings with invalid port",
+			params:  &models.NotificationChannelParams{Name: "my-channel", Type: models.NotificationChannelSMTP, SMTP: &models.SMTPSettings{Host: "smtp.example.com", Port: 70000, From: "keptn@example.com", To: []string{"team@example.com"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid event in filter",
+			params:  &models.NotificationChannelParams{Name: "my-channel", Type: models.NotificationChannelWebhook, URL: "https://example.com/hook", Filter: models.NotificationFilter{Events: []string{"sequence.exploded"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid result in filter",
+			params:  &models.NotificationChannelParams{Name: "my-channel", Type: models.NotificationChannelWebhook, URL: "https://example.com/hook", Filter: models.NotificationFilter{Results: []string{"great"}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := handler.NotificationChannelParamsValidator{}.Validate(tt.params)
+			require.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestNotificationHandler_CreateNotificationChannel(t *testing.T) {
+	tests := []struct {
+		name                string
+		notificationManager *fake.INotificationManagerMock
+		payload             string
+		wantStatus          int
+	}{
+		{
+			name: "create channel",
+			notificationManager: &fake.INotificationManagerMock{
+				CreateNotificationChannelFunc: func(project string, params models.NotificationChannelParams) (*models.NotificationChannel, error) {
+					return &models.NotificationChannel{ID: "my-channel"}, nil
+				},
+			},
+			payload:    `{"name":"my-channel","type":"webhook","url":"https://example.com/hook"}`,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:                "invalid payload",
+			notificationManager: &fake.INotificationManagerMock{},
+			payload:             `{"name":"my-channel","type":"webhook"}`,
+			wantStatus:          http.StatusBadRequest,
+		},
+		{
+			name: "project not found",
+			notificationManager: &fake.INotificationManagerMock{
+				CreateNotificationChannelFunc: func(project string, params models.NotificationChannelParams) (*models.NotificationChannel, error) {
+					return nil, handler.ErrProjectNotFound
+				},
+			},
+			payload:    `{"name":"my-channel","type":"webhook","url":"https://example.com/hook"}`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			notificationManager: &fake.INotificationManagerMock{
+				CreateNotificationChannelFunc: func(project string, params models.NotificationChannelParams) (*models.NotificationChannel, error) {
+					return nil, errors.New("oops")
+				},
+			},
+			payload:    `{"name":"my-channel","type":"webhook","url":"https://example.com/hook"}`,
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nh := handler.NewNotificationHandler(tt.notificationManager)
+
+			router := gin.Default()
+			router.POST("/project/:project/notification/channel", nh.CreateNotificationChannel)
+			w := performRequest(router, httptest.NewRequest(http.MethodPost, "/project/my-project/notification/channel", bytes.NewBufferString(tt.payload)))
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusCreated {
+				response := &models.CreateNotificationChannelResponse{}
+				require.Nil(t, json.Unmarshal(w.Body.Bytes(), response))
+				require.Equal(t, "my-channel", response.ID)
+				require.Equal(t, "my-project", tt.notificationManager.CreateNotificationChannelCalls()[0].Project)
+			}
+		})
+	}
+}
+
+func TestNotificationHandler_GetNotificationChannel_NotFound(t *testing.T) {
+	notificationManager := &fake.INotificationManagerMock{
+		GetNotificationChannelFunc: func(project string, id string) (*models.NotificationChannel, error) {
+			return nil, handler.ErrNotificationChannelNotFound
+		},
+	}
+	nh := handler.NewNotificationHandler(notificationManager)
+
+	router := gin.Default()
+	router.GET("/project/:project/notification/channel/:channelId", nh.GetNotificationChannel)
+	w := performRequest(router, httptest.NewRequest(http.MethodGet, "/project/my-project/notification/channel/my-channel", nil))
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(t, "my-channel", notificationManager.GetNotificationChannelCalls()[0].Id)
+}
+
+func TestNotificationHandler_DeleteNotificationChannel(t *testing.T) {
+	tests := []struct {
+		name       string
+		deleteErr  error
+		wantStatus int
+	}{
+		{
+			name:       "delete channel",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "channel not found",
+			deleteErr:  handler.ErrNotificationChannelNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notificationManager := &fake.INotificationManagerMock{
+				DeleteNotificationChannelFunc: func(project string, id string) error {
+					return tt.deleteErr
+				},
+			}
+			nh := handler.NewNotificationHandler(notificationManager)
+
+			router := gin.Default()
+			router.DELETE("/project/:project/notification/channel/:channelId", nh.DeleteNotificationChannel)
+			w := performRequest(router, httptest.NewRequest(http.MethodDelete, "/project/my-project/notification/channel/my-channel", nil))
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			require.Equal(t, "my-project", notificationManager.DeleteNotificationChannelCalls()[0].Project)
+			require.Equal(t, "my-channel", notificationManager.DeleteNotificationChannelCalls()[0].Id)
+		})
+	}
+}
+
+func TestNotificationHandler_GetNotificationDeliveries(t *testing.T) {
+	notificationManager := &fake.INotificationManagerMock{
+		GetNotificationDeliveriesFunc: func(params models.GetNotificationDeliveriesParams) (*models.GetNotificationDeliveriesResponse, error) {
+			return &models.GetNotificationDeliveriesResponse{
+				TotalCount: 1,
+				Deliveries: []models.NotificationDelivery{{ID: "my-delivery", Status: models.NotificationDeliveryFailed}},
+			}, nil
+		},
+	}
+	nh := handler.NewNotificationHandler(notificationManager)
+
+	router := gin.Default()
+	router.GET("/project/:project/notification/delivery", nh.GetNotificationDeliveries)
+	w := performRequest(router, httptest.NewRequest(http.MethodGet, "/project/my-project/notification/delivery?status=failed&channelId=my-channel", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, models.GetNotificationDeliveriesParams{Project: "my-project", ChannelID: "my-channel", Status: models.NotificationDeliveryFailed}, notificationManager.GetNotificationDeliveriesCalls()[0].Params)
+
+	response := &models.GetNotificationDeliveriesResponse{}
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), response))
+	require.Len(t, response.Deliveries, 1)
+
+	w = performRequest(router, httptest.NewRequest(http.MethodGet, "/project/my-project/notification/delivery?status=lost", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Len(t, notificationManager.GetNotificationDeliveriesCalls(), 1)
+}
@@ -0,0 +1,296 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/benbjohnson/clock"
+	"github.com/google/uuid"
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	log "github.com/sirupsen/logrus"
+)
+
+//go:generate moq -pkg fake -skip-ensure -out ./fake/notificationmanager.go . INotificationManager
+type INotificationManager interface {
+	CreateNotificationChannel(project string, params models.NotificationChannelParams) (*models.NotificationChannel, error)
+	GetNotificationChannels(project string) ([]models.NotificationChannel, error)
+	GetNotificationChannel(project, id string) (*models.NotificationChannel, error)
+	UpdateNotificationChannel(project, id string, params models.NotificationChannelParams) (*models.NotificationChannel, error)
+	DeleteNotificationChannel(project, id string) error
+	GetNotificationDeliveries(params models.GetNotificationDeliveriesParams) (*models.GetNotificationDeliveriesResponse, error)
+}
+
+// NotificationManager manages the notification channels of projects. As a sequence hook, it creates a delivery for each channel whose filter matches
+// an event of a sequence. The deliveries are sent by the NotificationDispatcher
+type NotificationManager struct {
+	channelRepo           db.NotificationChannelRepo
+	deliveryRepo          db.NotificationDeliveryRepo
+	projectMVRepo         db.ProjectMVRepo
+	sequenceExecutionRepo db.SequenceExecutionRepo
+	theClock              clock.Clock
+}
+
+func NewNotificationManager(channelRepo db.NotificationChannelRepo, deliveryRepo db.NotificationDeliveryRepo, projectMVRepo db.ProjectMVRepo, sequenceExecutionRepo db.SequenceExecutionRepo, theClock clock.Clock) *NotificationManager {
+	return &NotificationManager{
+		channelRepo:           channelRepo,
+		deliveryRepo:          deliveryRepo,
+		projectMVRepo:         projectMVRepo,
+		sequenceExecutionRepo: sequenceExecutionRepo,
+		theClock:              theClock,
+	}
+}
+
+func (nm *NotificationManager) CreateNotificationChannel(project string, params models.NotificationChannelParams) (*models.NotificationChannel, error) {
+	if err := nm.validateProject(project); err != nil {
+		return nil, err
+	}
+
+	channel := &models.NotificationChannel{
+		ID:        uuid.New().String(),
+		Project:   project,
+		CreatedAt: nm.theClock.Now().UTC(),
+	}
+	applyNotificationChannelParams(channel, params)
+
+	if err := nm.channelRepo.CreateNotificationChannel(*channel); err != nil {
+		return nil, err
+	}
+	redacted := channel.Redacted()
+	return &redacted, nil
+}
+
+func (nm *NotificationManager) GetNotificationChannels(project string) ([]models.NotificationChannel, error) {
+	if err := nm.validateProject(project); err != nil {
+		return nil, err
+	}
+
+	channels, err := nm.channelRepo.GetNotificationChannels(project)
+	if err != nil {
+		return nil, err
+	}
+	for index := range channels {
+		channels[index] = channels[index].Redacted()
+	}
+	return channels, nil
+}
+
+func (nm *NotificationManager) GetNotificationChannel(project, id string) (*models.NotificationChannel, error) {
+	channel, err := nm.getNotificationChannel(project, id)
+	if err != nil {
+		return nil, err
+	}
+	redacted := channel.Redacted()
+	return &redacted, nil
+}
+
+func (nm *NotificationManager) UpdateNotificationChannel(project, id string, params models.NotificationChannelParams) (*models.NotificationChannel, error) {
+	channel, err := nm.getNotificationChannel(project, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// the password is not included in the responses of the API, so it is kept if the client does not send a new one
+	if params.SMTP != nil && params.SMTP.Password == "" && channel.SMTP != nil {
+		smtpSettings := *params.SMTP
+		smtpSettings.Password = channel.SMTP.Password
+		params.SMTP = &smtpSettings
+	}
+	applyNotificationChannelParams(channel, params)
+
+	if err := nm.channelRepo.UpdateNotificationChannel(*channel); err != nil {
+		if errors.Is(err, db.ErrNotificationChannelNotFound) {
+			return nil, ErrNotificationChannelNotFound
+		}
+		return nil, err
+	}
+	redacted := channel.Redacted()
+	return &redacted, nil
+}
+
+func (nm *NotificationManager) DeleteNotificationChannel(project, id string) error {
+	if err := nm.channelRepo.DeleteNotificationChannel(project, id); err != nil {
+		if errors.Is(err, db.ErrNotificationChannelNotFound) {
+			return ErrNotificationChannelNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (nm *NotificationManager) GetNotificationDeliveries(params models.GetNotificationDeliveriesParams) (*models.GetNotificationDeliveriesResponse, error) {
+	if err := nm.validateProject(params.Project); err != nil {
+		return nil, err
+	}
+	return nm.deliveryRepo.GetNotificationDeliveries(params)
+}
+
+func (nm *NotificationManager) OnSequenceTriggered(event apimodels.KeptnContextExtendedCE) {
+	nm.notifyForEvent(models.NotificationSequenceTriggered, event)
+}
+
+func (nm *NotificationManager) OnSequenceStarted(event apimodels.KeptnContextExtendedCE) {
+	nm.notifyForEvent(models.NotificationSequenceStarted, event)
+}
+
+func (nm *NotificationManager) OnSequenceWaiting(event apimodels.KeptnContextExtendedCE) {
+	nm.notifyForEvent(models.NotificationSequenceWaiting, event)
+}
+
+func (nm *NotificationManager) OnSequenceFinished(event apimodels.KeptnContextExtendedCE) {
+	nm.notifyForEvent(models.NotificationSequenceFinished, event)
+}
+
+func (nm *NotificationManager) OnSequenceTimeout(event apimodels.KeptnContextExtendedCE, reason models.SequenceTimeoutReason) {
+	nm.notifyForEvent(models.NotificationSequenceTimedOut, event)
+}
+
+func (nm *NotificationManager) OnSequenceAborted(eventScope models.EventScope) {
+	nm.notify(models.NotificationSequenceAborted, eventScope, "")
+}
+
+func (nm *NotificationManager) OnSequencePaused(pause models.EventScope) {
+	nm.notify(models.NotificationSequencePaused, pause, "")
+}
+
+func (nm *NotificationManager) OnSequenceResumed(resume models.EventScope) {
+	nm.notify(models.NotificationSequenceResumed, resume, "")
+}
+
+func (nm *NotificationManager) notifyForEvent(notificationEvent string, event apimodels.KeptnContextExtendedCE) {
+	eventScope, err := models.NewEventScope(event)
+	if err != nil {
+		log.WithError(err).Errorf("could not create %s notification for event %s", notificationEvent, event.ID)
+		return
+	}
+	// the hooks either receive an event of the sequence itself, or an event of one of its tasks. In the latter case, the name of the sequence is
+	// retrieved from its sequence execution
+	sequenceName := ""
+	if _, name, _, err := keptnv2.ParseSequenceEventType(eventScope.EventType); err == nil {
+		sequenceName = name
+	}
+	nm.notify(notificationEvent, *eventScope, sequenceName)
+}
+
+// notify creates a pending delivery of the notification for each channel of the project whose filter matches it
+func (nm *NotificationManager) notify(notificationEvent string, eventScope models.EventScope, sequenceName string) {
+	channels, err := nm.channelRepo.GetNotificationChannels(eventScope.Project)
+	if err != nil {
+		log.WithError(err).Errorf("could not load notification channels of project %s", eventScope.Project)
+		return
+	}
+	if len(channels) == 0 {
+		return
+	}
+
+	now := nm.theClock.Now().UTC()
+	notification := models.Notification{
+		Event:        notificationEvent,
+		Project:      eventScope.Project,
+		Stage:        eventScope.Stage,
+		Service:      eventScope.Service,
+		Sequence:     sequenceName,
+		KeptnContext: eventScope.KeptnContext,
+		Result:       string(eventScope.Result),
+		Status:       string(eventScope.Status),
+		Message:      eventScope.Message,
+		Time:         now,
+	}
+	if notification.Sequence == "" || notification.Stage == "" || notification.Service == "" {
+		nm.completeNotification(&notification)
+	}
+
+	deliveries := []models.NotificationDelivery{}
+	for _, channel := range channels {
+		if !channel.Filter.Matches(notification) {
+			continue
+		}
+		deliveries = append(deliveries, models.NotificationDelivery{
+			ID:            uuid.New().String(),
+			ChannelID:     channel.ID,
+			Project:       channel.Project,
+			Notification:  notification,
+			Status:        models.NotificationDeliveryPending,
+			NextAttemptAt: &now,
+			CreatedAt:     now,
+		})
+	}
+	if err := nm.deliveryRepo.CreateNotificationDeliveries(deliveries); err != nil {
+		log.WithError(err).Errorf("could not store %s notification for sequence with keptnContext %s", notificationEvent, eventScope.KeptnContext)
+	}
+}
+
+// completeNotification adds the properties of the sequence that are not contained in the event passed to the hook
+func (nm *NotificationManager) completeNotification(notification *models.Notification) {
+	sequenceExecutions, err := nm.sequenceExecutionRepo.Get(models.SequenceExecutionFilter{
+		Scope: models.EventScope{
+			EventData: keptnv2.EventData{
+				Project: notification.Project,
+				Stage:   notification.Stage,
+			},
+			KeptnContext: notification.KeptnContext,
+		},
+	})
+	if err != nil {
+		log.WithError(err).Warnf("could not load sequence execution with keptnContext %s", notification.KeptnContext)
+		return
+	}
+	if len(sequenceExecutions) == 0 {
+		return
+	}
+	sequenceExecution := sequenceExecutions[0]
+	if notification.Sequence == "" {
+		notification.Sequence = sequenceExecution.Sequence.Name
+	}
+	if notification.Stage == "" {
+		notification.Stage = sequenceExecution.Scope.Stage
+	}
+	if notification.Service == "" {
+		notification.Service = sequenceExecution.Scope.Service
+	}
+}
+
+func (nm *NotificationManager) getNotificationChannel(project, id string) (*models.NotificationChannel, error) {
+	if err := nm.validateProject(project); err != nil {
+		return nil, err
+	}
+	channel, err := nm.channelRepo.GetNotificationChannel(project, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNotificationChannelNotFound) {
+			return nil, ErrNotificationChannelNotFound
+		}
+		return nil, err
+	}
+	return channel, nil
+}
+
+func (nm *NotificationManager) validateProject(project string) error {
+	p, err := nm.projectMVRepo.GetProject(project)
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		return ErrProjectNotFound
+	}
+	return nil
+}
+
+func applyNotificationChannelParams(channel *models.NotificationChannel, params models.NotificationChannelParams) {
+	channel.Name = params.Name
+	channel.Type = params.Type
+	channel.URL = params.URL
+	channel.Headers = params.Headers
+	channel.SMTP = params.SMTP
+	channel.Filter = params.Filter
+	// the properties of the other channel types are not stored, so that they do not show up in the channel
+	if channel.Type == models.NotificationChannelSMTP {
+		channel.URL = ""
+		channel.Headers = nil
+	} else {
+		channel.SMTP = nil
+		if channel.Type != models.NotificationChannelWebhook {
+			channel.Headers = nil
+		}
+	}
+}
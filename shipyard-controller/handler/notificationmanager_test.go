@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/common"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func newNotificationManagerTestProjectMVRepo() *db_mock.ProjectMVRepoMock {
+	return &db_mock.ProjectMVRepoMock{
+		GetProjectFunc: func(projectName string) (*apimodels.ExpandedProject, error) {
+			if projectName != "my-project" {
+				return nil, nil
+			}
+			return &apimodels.ExpandedProject{ProjectName: projectName}, nil
+		},
+	}
+}
+
+func TestNotificationManager_CreateNotificationChannel(t *testing.T) {
+	channelRepo := &db_mock.NotificationChannelRepoMock{
+		CreateNotificationChannelFunc: func(channel models.NotificationChannel) error {
+			return nil
+		},
+	}
+	nm := NewNotificationManager(channelRepo, &db_mock.NotificationDeliveryRepoMock{}, newNotificationManagerTestProjectMVRepo(), &db_mock.SequenceExecutionRepoMock{}, clock.NewMock())
+
+	channel, err := nm.CreateNotificationChannel("my-project", models.NotificationChannelParams{
+		Name: "my-channel",
+		Type: models.NotificationChannelSMTP,
+		URL:  "https://example.com/hook",
+		SMTP: &models.SMTPSettings{Host: "smtp.example.com", Port: 587, Username: "keptn", Password: "secret", From: "keptn@example.com", To: []string{"team@example.com"}},
+	})
+	require.Nil(t, err)
+	require.NotEmpty(t, channel.ID)
+	require.Empty(t, channel.SMTP.Password)
+
+	require.Len(t, channelRepo.CreateNotificationChannelCalls(), 1)
+	stored := channelRepo.CreateNotificationChannelCalls()[0].Channel
+	require.Equal(t, "my-project", stored.Project)
+	require.Equal(t, "secret", stored.SMTP.Password)
+	require.Empty(t, stored.URL)
+
+	_, err = nm.CreateNotificationChannel("unknown-project", models.NotificationChannelParams{Name: "my-channel", Type: models.NotificationChannelWebhook, URL: "https://example.com/hook"})
+	require.ErrorIs(t, err, ErrProjectNotFound)
+}
+
+func TestNotificationManager_UpdateNotificationChannel_KeepsPassword(t *testing.T) {
+	channelRepo := &db_mock.NotificationChannelRepoMock{
+		GetNotificationChannelFunc: func(project string, id string) (*models.NotificationChannel, error) {
+			return &models.NotificationChannel{
+				ID:      id,
+				Project: project,
+				Name:    "my-channel",
+				Type:    models.NotificationChannelSMTP,
+				SMTP:    &models.SMTPSettings{Host: "smtp.example.com", Port: 587, Username: "keptn", Password: "secret", From: "keptn@example.com", To: []string{"team@example.com"}},
+			}, nil
+		},
+		UpdateNotificationChannelFunc: func(channel models.NotificationChannel) error {
+			return nil
+		},
+	}
+	nm := NewNotificationManager(channelRepo, &db_mock.NotificationDeliveryRepoMock{}, newNotificationManagerTestProjectMVRepo(), &db_mock.SequenceExecutionRepoMock{}, clock.NewMock())
+
+	channel, err := nm.UpdateNotificationChannel("my-project", "my-channel", models.NotificationChannelParams{
+		Name: "my-renamed-channel",
+		Type: models.NotificationChannelSMTP,
+		SMTP: &models.SMTPSettings{Host: "smtp.example.com", Port: 465, Username: "keptn", From: "keptn@example.com", To: []string{"ops@example.com"}},
+	})
+	require.Nil(t, err)
+	require.Equal(t, "my-renamed-channel", channel.Name)
+	require.Empty(t, channel.SMTP.Password)
+
+	stored := channelRepo.UpdateNotificationChannelCalls()[0].Channel
+	require.Equal(t, "secret", stored.SMTP.Password)
+	require.Equal(t, 465, stored.SMTP.Port)
+	require.Equal(t, []string{"ops@example.com"}, stored.SMTP.To)
+}
+
+func TestNotificationManager_GetNotificationChannel_NotFound(t *testing.T) {
+	channelRepo := &db_mock.NotificationChannelRepoMock{
+		GetNotificationChannelFunc: func(project string, id string) (*models.NotificationChannel, error) {
+			return nil, db.ErrNotificationChannelNotFound
+		},
+	}
+	nm := NewNotificationManager(channelRepo, &db_mock.NotificationDeliveryRepoMock{}, newNotificationManagerTestProjectMVRepo(), &db_mock.SequenceExecutionRepoMock{}, clock.NewMock())
+
+	_, err := nm.GetNotificationChannel("my-project", "my-channel")
+	require.ErrorIs(t, err, ErrNotificationChannelNotFound)
+}
+
+func TestNotificationManager_OnSequenceFinished(t *testing.T) {
+	theClock := clock.NewMock()
+	theClock.Set(time.Date(2022, 3, 1, 2, 0, 0, 0, time.UTC))
+
+	channelRepo := &db_mock.NotificationChannelRepoMock{
+		GetNotificationChannelsFunc: func(project string) ([]models.NotificationChannel, error) {
+			return []models.NotificationChannel{
+				{ID: "all-finished", Project: project},
+				{ID: "failed-in-production", Project: project, Filter: models.NotificationFilter{Stages: []string{"production"}, Results: []string{"fail"}}},
+				{ID: "failed-in-dev", Project: project, Filter: models.NotificationFilter{Stages: []string{"dev"}, Results: []string{"fail"}}},
+				{ID: "other-sequence", Project: project, Filter: models.NotificationFilter{Sequences: []string{"evaluation"}}},
+				{ID: "only-started", Project: project, Filter: models.NotificationFilter{Events: []string{models.NotificationSequenceStarted}}},
+			}, nil
+		},
+	}
+	deliveryRepo := &db_mock.NotificationDeliveryRepoMock{
+		CreateNotificationDeliveriesFunc: func(deliveries []models.NotificationDelivery) error {
+			return nil
+		},
+	}
+	nm := NewNotificationManager(channelRepo, deliveryRepo, newNotificationManagerTestProjectMVRepo(), &db_mock.SequenceExecutionRepoMock{}, theClock)
+
+	nm.OnSequenceFinished(apimodels.KeptnContextExtendedCE{
+		ID:             "my-event",
+		Shkeptncontext: "my-context",
+		Type:           common.Stringp(keptnv2.GetFinishedEventType("production.delivery")),
+		Data: keptnv2.EventData{
+			Project: "my-project",
+			Stage:   "production",
+			Service: "my-service",
+			Result:  keptnv2.ResultFailed,
+			Status:  keptnv2.StatusSucceeded,
+		},
+	})
+
+	require.Len(t, deliveryRepo.CreateNotificationDeliveriesCalls(), 1)
+	deliveries := deliveryRepo.CreateNotificationDeliveriesCalls()[0].Deliveries
+	require.Len(t, deliveries, 2)
+	require.Equal(t, "all-finished", deliveries[0].ChannelID)
+	require.Equal(t, "failed-in-production", deliveries[1].ChannelID)
+
+	delivery := deliveries[1]
+	require.Equal(t, models.NotificationDeliveryPending, delivery.Status)
+	require.Equal(t, theClock.Now().UTC(), *delivery.NextAttemptAt)
+	require.Equal(t, models.Notification{
+		Event:        models.NotificationSequenceFinished,
+		Project:      "my-project",
+		Stage:        "production",
+		Service:      "my-service",
+		Sequence:     "delivery",
+		KeptnContext: "my-context",
+		Result:       string(keptnv2.ResultFailed),
+		Status:       string(keptnv2.StatusSucceeded),
+		Time:         theClock.Now().UTC(),
+	}, delivery.Notification)
+}
+
+func TestNotificationManager_OnSequenceAborted(t *testing.T) {
+	channelRepo := &db_mock.NotificationChannelRepoMock{
+		GetNotificationChannelsFunc: func(project string) ([]models.NotificationChannel, error) {
+			return []models.NotificationChannel{
+				{ID: "delivery-only", Project: project, Filter: models.NotificationFilter{Sequences: []string{"delivery"}}},
+			}, nil
+		},
+	}
+	deliveryRepo := &db_mock.NotificationDeliveryRepoMock{
+		CreateNotificationDeliveriesFunc: func(deliveries []models.NotificationDelivery) error {
+			return nil
+		},
+	}
+	sequenceExecutionRepo := &db_mock.SequenceExecutionRepoMock{
+		GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+			require.Equal(t, "my-context", filter.Scope.KeptnContext)
+			return []models.SequenceExecution{
+				{
+					Sequence: keptnv2.Sequence{Name: "delivery"},
+					Scope: models.EventScope{
+						EventData: keptnv2.EventData{Project: "my-project", Stage: "dev", Service: "my-service"},
+					},
+				},
+			}, nil
+		},
+	}
+	nm := NewNotificationManager(channelRepo, deliveryRepo, newNotificationManagerTestProjectMVRepo(), sequenceExecutionRepo, clock.NewMock())
+
+	nm.OnSequenceAborted(models.EventScope{
+		EventData:    keptnv2.EventData{Project: "my-project"},
+		KeptnContext: "my-context",
+	})
+
+	require.Len(t, deliveryRepo.CreateNotificationDeliveriesCalls(), 1)
+	deliveries := deliveryRepo.CreateNotificationDeliveriesCalls()[0].Deliveries
+	require.Len(t, deliveries, 1)
+	require.Equal(t, "delivery", deliveries[0].Notification.Sequence)
+	require.Equal(t, "dev", deliveries[0].Notification.Stage)
+	require.Equal(t, "my-service", deliveries[0].Notification.Service)
+}
+
+func TestNotificationManager_NoChannels(t *testing.T) {
+	channelRepo := &db_mock.NotificationChannelRepoMock{
+		GetNotificationChannelsFunc: func(project string) ([]models.NotificationChannel, error) {
+			return []models.NotificationChannel{}, nil
+		},
+	}
+	deliveryRepo := &db_mock.NotificationDeliveryRepoMock{}
+	nm := NewNotificationManager(channelRepo, deliveryRepo, newNotificationManagerTestProjectMVRepo(), &db_mock.SequenceExecutionRepoMock{}, clock.NewMock())
+
+	nm.OnSequencePaused(models.EventScope{EventData: keptnv2.EventData{Project: "my-project"}, KeptnContext: "my-context"})
+
+	require.Empty(t, deliveryRepo.CreateNotificationDeliveriesCalls())
+}
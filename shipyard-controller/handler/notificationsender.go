@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keptn/keptn/shipyard-controller/models"
+)
+
+const notificationRequestTimeout = 10 * time.Second
+
+//go:generate moq -pkg fake -skip-ensure -out ./fake/notificationsender.go . NotificationSender
+// NotificationSender delivers a notification via a notification channel
+type NotificationSender interface {
+	Send(channel models.NotificationChannel, notification models.Notification) error
+}
+
+// NewNotificationSenders returns the senders for all supported types of notification channels
+func NewNotificationSenders() map[string]NotificationSender {
+	client := &http.Client{Timeout: notificationRequestTimeout}
+	return map[string]NotificationSender{
+		models.NotificationChannelWebhook: NewWebhookNotificationSender(client),
+		models.NotificationChannelSlack:   NewSlackNotificationSender(client),
+		models.NotificationChannelSMTP:    NewSMTPNotificationSender(),
+	}
+}
+
+// WebhookNotificationSender posts the notification as JSON payload to the URL of the channel
+type WebhookNotificationSender struct {
+	client *http.Client
+}
+
+func NewWebhookNotificationSender(client *http.Client) *WebhookNotificationSender {
+	return &WebhookNotificationSender{client: client}
+}
+
+func (s *WebhookNotificationSender) Send(channel models.NotificationChannel, notification models.Notification) error {
+	return postNotificationPayload(s.client, channel.URL, channel.Headers, notification)
+}
+
+// SlackNotificationSender posts the notification as message to the Slack-compatible incoming webhook of the channel
+type SlackNotificationSender struct {
+	client *http.Client
+}
+
+func NewSlackNotificationSender(client *http.Client) *SlackNotificationSender {
+	return &SlackNotificationSender{client: client}
+}
+
+func (s *SlackNotificationSender) Send(channel models.NotificationChannel, notification models.Notification) error {
+	return postNotificationPayload(s.client, channel.URL, nil, map[string]string{"text": FormatNotificationMessage(notification)})
+}
+
+// SMTPNotificationSender sends the notification as email to the recipients of the channel
+type SMTPNotificationSender struct {
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func NewSMTPNotificationSender() *SMTPNotificationSender {
+	return &SMTPNotificationSender{sendMail: smtp.SendMail}
+}
+
+func (s *SMTPNotificationSender) Send(channel models.NotificationChannel, notification models.Notification) error {
+	if channel.SMTP == nil {
+		return fmt.Errorf("notification channel %s does not contain SMTP settings", channel.ID)
+	}
+	settings := *channel.SMTP
+
+	var auth smtp.Auth
+	if settings.Username != "" {
+		auth = smtp.PlainAuth("", settings.Username, settings.Password, settings.Host)
+	}
+
+	msg := &bytes.Buffer{}
+	fmt.Fprintf(msg, "From: %s\r\n", settings.From)
+	fmt.Fprintf(msg, "To: %s\r\n", strings.Join(settings.To, ", "))
+	fmt.Fprintf(msg, "Subject: %s\r\n", formatNotificationSubject(notification))
+	fmt.Fprint(msg, "Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	fmt.Fprintf(msg, "%s\r\n", FormatNotificationMessage(notification))
+
+	addr := net.JoinHostPort(settings.Host, strconv.Itoa(settings.Port))
+	if err := s.sendMail(addr, auth, settings.From, settings.To, msg.Bytes()); err != nil {
+		return fmt.Errorf("could not send mail via %s: %w", addr, err)
+	}
+	return nil
+}
+
+// FormatNotificationMessage returns a human-readable description of the given notification
+func FormatNotificationMessage(notification models.Notification) string {
+	msg := &strings.Builder{}
+	fmt.Fprintf(msg, "Sequence '%s' ", notification.Sequence)
+	if notification.Service != "" {
+		fmt.Fprintf(msg, "for service '%s' ", notification.Service)
+	}
+	if notification.Stage != "" {
+		fmt.Fprintf(msg, "in stage '%s' ", notification.Stage)
+	}
+	fmt.Fprintf(msg, "of project '%s' %s", notification.Project, describeNotificationEvent(notification.Event))
+	if notification.Result != "" {
+		fmt.Fprintf(msg, " with result '%s'", notification.Result)
+	}
+	msg.WriteString(".")
+	if notification.Message != "" {
+		fmt.Fprintf(msg, " %s", notification.Message)
+	}
+	fmt.Fprintf(msg, " (keptnContext: %s)", notification.KeptnContext)
+	return msg.String()
+}
+
+func formatNotificationSubject(notification models.Notification) string {
+	return fmt.Sprintf("[Keptn] %s/%s: sequence '%s' %s", notification.Project, notification.Stage, notification.Sequence, describeNotificationEvent(notification.Event))
+}
+
+func describeNotificationEvent(event string) string {
+	switch event {
+	case models.NotificationSequenceTriggered:
+		return "has been triggered"
+	case models.NotificationSequenceStarted:
+		return "has been started"
+	case models.NotificationSequenceWaiting:
+		return "is waiting"
+	case models.NotificationSequenceFinished:
+		return "has been finished"
+	case models.NotificationSequenceAborted:
+		return "has been aborted"
+	case models.NotificationSequenceTimedOut:
+		return "has timed out"
+	case models.NotificationSequencePaused:
+		return "has been paused"
+	case models.NotificationSequenceResumed:
+		return "has been resumed"
+	default:
+		return event
+	}
+}
+
+// postNotificationPayload posts the given payload to the given URL. The URL is not included in the returned errors, since it may contain credentials,
+// e.g. in the case of Slack webhooks, and the errors are stored in the delivery log
+func postNotificationPayload(client *http.Client, endpoint string, headers map[string]string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("could not marshal notification: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.New("could not create request: invalid URL")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		urlErr := &url.Error{}
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("could not send notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("notification was rejected with status code %d", resp.StatusCode)
+	}
+	return nil
+}
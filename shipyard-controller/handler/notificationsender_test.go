@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestNotification() models.Notification {
+	return models.Notification{
+		Event:        models.NotificationSequenceFinished,
+		Project:      "my-project",
+		Stage:        "production",
+		Service:      "my-service",
+		Sequence:     "delivery",
+		KeptnContext: "my-context",
+		Result:       "fail",
+		Time:         time.Date(2022, 3, 1, 2, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestWebhookNotificationSender_Send(t *testing.T) {
+	var received models.Notification
+	var receivedHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedHeader = r.Header.Get("X-Token")
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sender := NewWebhookNotificationSender(server.Client())
+	err := sender.Send(models.NotificationChannel{URL: server.URL, Headers: map[string]string{"X-Token": "my-token"}}, newTestNotification())
+
+	require.Nil(t, err)
+	require.Equal(t, "my-token", receivedHeader)
+	require.Equal(t, newTestNotification(), received)
+}
+
+func TestWebhookNotificationSender_Send_Rejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	sender := NewWebhookNotificationSender(server.Client())
+	err := sender.Send(models.NotificationChannel{URL: server.URL + "/secret-token"}, newTestNotification())
+
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "403")
+	require.NotContains(t, err.Error(), "secret-token")
+}
+
+func TestWebhookNotificationSender_Send_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	sender := NewWebhookNotificationSender(server.Client())
+	err := sender.Send(models.NotificationChannel{URL: server.URL + "/secret-token"}, newTestNotification())
+
+	require.NotNil(t, err)
+	require.NotContains(t, err.Error(), "secret-token")
+}
+
+func TestSlackNotificationSender_Send(t *testing.T) {
+	received := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	sender := NewSlackNotificationSender(server.Client())
+	err := sender.Send(models.NotificationChannel{URL: server.URL}, newTestNotification())
+
+	require.Nil(t, err)
+	require.Equal(t, "Sequence 'delivery' for service 'my-service' in stage 'production' of project 'my-project' has been finished with result 'fail'. (keptnContext: my-context)", received["text"])
+}
+
+func TestSMTPNotificationSender_Send(t *testing.T) {
+	var receivedAddr, receivedFrom string
+	var receivedTo []string
+	var receivedMsg string
+	var receivedAuth smtp.Auth
+	sender := &SMTPNotificationSender{
+		sendMail: func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+			receivedAddr = addr
+			receivedAuth = a
+			receivedFrom = from
+			receivedTo = to
+			receivedMsg = string(msg)
+			return nil
+		},
+	}
+
+	err := sender.Send(models.NotificationChannel{
+		Type: models.NotificationChannelSMTP,
+		SMTP: &models.SMTPSettings{Host: "smtp.example.com", Port: 587, Username: "keptn", Password: "secret", From: "keptn@example.com", To: []string{"a@example.com", "b@example.com"}},
+	}, newTestNotification())
+
+	require.Nil(t, err)
+	require.Equal(t, "smtp.example.com:587", receivedAddr)
+	require.NotNil(t, receivedAuth)
+	require.Equal(t, "keptn@example.com", receivedFrom)
+	require.Equal(t, []string{"a@example.com", "b@example.com"}, receivedTo)
+	require.True(t, strings.HasPrefix(receivedMsg, "From: keptn@example.com\r\nTo: a@example.com, b@example.com\r\nSubject: [Keptn] my-project/production: sequence 'delivery' has been finished\r\n"))
+	require.Contains(t, receivedMsg, "(keptnContext: my-context)")
+
+	err = sender.Send(models.NotificationChannel{Type: models.NotificationChannelSMTP}, newTestNotification())
+	require.NotNil(t, err)
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/common"
+	"github.com/keptn/keptn/shipyard-controller/config"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	log "github.com/sirupsen/logrus"
+)
+
+type IProjectArchiveHandler interface {
+	ExportProject(context *gin.Context)
+	ImportProject(context *gin.Context)
+}
+
+type ProjectArchiveHandler struct {
+	projectArchiveManager IProjectArchiveManager
+	env                   config.EnvConfig
+	repositoryProvisioner IRepositoryProvisioner
+	locker                ILocker
+}
+
+// NewProjectArchiveHandler creates a new ProjectArchiveHandler
+func NewProjectArchiveHandler(projectArchiveManager IProjectArchiveManager, env config.EnvConfig, repositoryProvisioner IRepositoryProvisioner, locker ILocker) *ProjectArchiveHandler {
+	return &ProjectArchiveHandler{
+		projectArchiveManager: projectArchiveManager,
+		env:                   env,
+		repositoryProvisioner: repositoryProvisioner,
+		locker:                locker,
+	}
+}
+
+// ExportProject godoc
+// @Summary      Export a project
+// @Description  Export the shipyard, the stage and service resources, the subscriptions and the names of the secrets of a project as a tar.gz archive. The values of the secrets are not exported
+// @Tags         Projects
+// @Security     ApiKeyAuth
+// @Produce      application/gzip
+// @Param        project           path      string        true   "The name of the project"
+// @Param        includeSequences  query     boolean       false  "Include the execution history of the sequences of the project"
+// @Success      200               {file}    file          "ok"
+// @Failure      400               {object}  models.Error  "Invalid payload"
+// @Failure      404               {object}  models.Error  "Not found"
+// @Failure      500               {object}  models.Error  "Internal error"
+// @Router       /project/{project}/export [get]
+func (ah *ProjectArchiveHandler) ExportProject(c *gin.Context) {
+	projectName := c.Param("project")
+
+	params := models.ExportProjectParams{}
+	if err := c.ShouldBindQuery(&params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidRequestFormatMsg, err.Error()))
+		return
+	}
+
+	archive, err := ah.projectArchiveManager.Export(projectName, params)
+	if err != nil {
+		if errors.Is(err, ErrProjectNotFound) {
+			SetNotFoundErrorResponse(c, fmt.Sprintf(ProjectNotFoundMsg, projectName))
+			return
+		}
+		SetInternalServerErrorResponse(c, fmt.Sprintf(UnableExportProjectMsg, err.Error()))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.tar.gz", projectName))
+	c.Data(http.StatusOK, "application/gzip", archive)
+}
+
+// ImportProject godoc
+// @Summary      Import a project
+// @Description  Create a project from an archive created by the export of a project. The stages, services, resources and subscriptions of the archive are recreated.
+// @Description  Subscriptions of integrations that are not registered are skipped, and secrets that do not exist need to be created manually
+// @Tags         Projects
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project  path      string                        true  "The name of the project that is created"
+// @Param        import   body      models.ImportProjectParams    true  "The archive and the upstream of the project"
+// @Success      201      {object}  models.ImportProjectResponse  "ok"
+// @Failure      400      {object}  models.Error                  "Invalid payload"
+// @Failure      409      {object}  models.Error                  "Conflict"
+// @Failure      424      {object}  models.Error                  "Failed dependency"
+// @Failure      500      {object}  models.Error                  "Internal error"
+// @Router       /project/{project}/import [post]
+func (ah *ProjectArchiveHandler) ImportProject(c *gin.Context) {
+	projectName := c.Param("project")
+
+	params := &models.ImportProjectParams{}
+	if err := c.ShouldBindJSON(params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidRequestFormatMsg, err.Error()))
+		return
+	}
+
+	content, err := base64.StdEncoding.DecodeString(params.Archive)
+	if err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidPayloadMsg, ErrInvalidArchiveEncoding.Error()))
+		return
+	}
+	archive, err := models.ReadProjectArchive(content)
+	if err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidPayloadMsg, err.Error()))
+		return
+	}
+
+	encodedShipyard := base64.StdEncoding.EncodeToString(archive.Shipyard)
+	createProjectParams := &models.CreateProjectParams{
+		Name:              &projectName,
+		Shipyard:          &encodedShipyard,
+		GitRemoteURL:      params.GitRemoteURL,
+		GitToken:          params.GitToken,
+		GitUser:           params.GitUser,
+		GitPrivateKey:     params.GitPrivateKey,
+		GitPrivateKeyPass: params.GitPrivateKeyPass,
+		GitProxyURL:       params.GitProxyURL,
+		GitProxyScheme:    params.GitProxyScheme,
+		GitProxyUser:      params.GitProxyUser,
+		GitProxyPassword:  params.GitProxyPassword,
+		GitPemCertificate: params.GitPemCertificate,
+		InsecureSkipTLS:   params.InsecureSkipTLS,
+	}
+
+	if err := provisionRepository(ah.repositoryProvisioner, ah.env.AutomaticProvisioningURL, createProjectParams); err != nil {
+		log.Errorf(err.Error())
+		SetFailedDependencyErrorResponse(c, UnableProvisionInstanceGeneric)
+		return
+	}
+
+	projectValidator := ProjectValidator{ProjectNameMaxSize: ah.env.ProjectNameMaxSize}
+	if err := projectValidator.Validate(createProjectParams); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidPayloadMsg, err.Error()))
+		return
+	}
+
+	unlockProject, err := lockProject(ah.locker, projectName)
+	if err != nil {
+		SetInternalServerErrorResponse(c, err.Error())
+		return
+	}
+	defer unlockProject()
+
+	response, err := ah.projectArchiveManager.Import(createProjectParams, *archive)
+	if err != nil {
+		if errors.Is(err, ErrProjectAlreadyExists) {
+			SetConflictErrorResponse(c, err.Error())
+			return
+		}
+		if errors.Is(err, common.ErrConfigStoreUpstreamNotFound) {
+			SetBadRequestErrorResponse(c, err.Error())
+			return
+		}
+		SetInternalServerErrorResponse(c, fmt.Sprintf(UnableImportProjectMsg, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
@@ -0,0 +1,183 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/config"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+	"github.com/keptn/keptn/shipyard-controller/handler/fake"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+const projectArchiveHandlerTestShipyard = `apiVersion: "spec.keptn.sh/0.2.3"
+kind: "Shipyard"
+metadata:
+  name: "shipyard-sockshop"
+spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "deployment"`
+
+func TestProjectArchiveHandler_ExportProject(t *testing.T) {
+	tests := []struct {
+		name         string
+		exportErr    error
+		query        string
+		expectStatus int
+		expectParams models.ExportProjectParams
+	}{
+		{
+			name:         "export project",
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "export project including sequences",
+			query:        "?includeSequences=true",
+			expectStatus: http.StatusOK,
+			expectParams: models.ExportProjectParams{IncludeSequences: true},
+		},
+		{
+			name:         "project not found",
+			exportErr:    handler.ErrProjectNotFound,
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:         "internal error",
+			exportErr:    errors.New("oops"),
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectArchiveManager := &fake.IProjectArchiveManagerMock{
+				ExportFunc: func(projectName string, params models.ExportProjectParams) ([]byte, error) {
+					if tt.exportErr != nil {
+						return nil, tt.exportErr
+					}
+					return []byte("my-archive"), nil
+				},
+			}
+			router := gin.Default()
+			projectArchiveHandler := handler.NewProjectArchiveHandler(projectArchiveManager, config.EnvConfig{ProjectNameMaxSize: 200}, &fake.IRepositoryProvisionerMock{}, nil)
+			router.GET("/project/:project/export", projectArchiveHandler.ExportProject)
+
+			req := httptest.NewRequest(http.MethodGet, "/project/my-project/export"+tt.query, nil)
+			w := performRequest(router, req)
+
+			require.Equal(t, tt.expectStatus, w.Code)
+			require.Len(t, projectArchiveManager.ExportCalls(), 1)
+			require.Equal(t, "my-project", projectArchiveManager.ExportCalls()[0].ProjectName)
+			require.Equal(t, tt.expectParams, projectArchiveManager.ExportCalls()[0].Params)
+			if tt.expectStatus == http.StatusOK {
+				require.Equal(t, "my-archive", w.Body.String())
+				require.Equal(t, "application/gzip", w.Header().Get("Content-Type"))
+				require.Equal(t, "attachment; filename=my-project.tar.gz", w.Header().Get("Content-Disposition"))
+			}
+		})
+	}
+}
+
+func TestProjectArchiveHandler_ImportProject(t *testing.T) {
+	archive, err := models.WriteProjectArchive(models.ProjectArchive{
+		Metadata: models.ProjectArchiveMetadata{Version: models.ProjectArchiveVersion, Project: "my-project"},
+		Shipyard: []byte(projectArchiveHandlerTestShipyard),
+	})
+	require.Nil(t, err)
+	invalidShipyardArchive, err := models.WriteProjectArchive(models.ProjectArchive{
+		Metadata: models.ProjectArchiveMetadata{Version: models.ProjectArchiveVersion, Project: "my-project"},
+		Shipyard: []byte("invalid"),
+	})
+	require.Nil(t, err)
+
+	tests := []struct {
+		name         string
+		params       models.ImportProjectParams
+		importErr    error
+		expectStatus int
+		expectImport bool
+	}{
+		{
+			name:         "import project",
+			params:       models.ImportProjectParams{Archive: base64.StdEncoding.EncodeToString(archive), GitRemoteURL: "http://my-repo", GitToken: "my-token"},
+			expectStatus: http.StatusCreated,
+			expectImport: true,
+		},
+		{
+			name:         "archive not encoded in base64",
+			params:       models.ImportProjectParams{Archive: "%%%"},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid archive",
+			params:       models.ImportProjectParams{Archive: base64.StdEncoding.EncodeToString([]byte("my-archive"))},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid shipyard",
+			params:       models.ImportProjectParams{Archive: base64.StdEncoding.EncodeToString(invalidShipyardArchive)},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "project already exists",
+			params:       models.ImportProjectParams{Archive: base64.StdEncoding.EncodeToString(archive)},
+			importErr:    handler.ErrProjectAlreadyExists,
+			expectStatus: http.StatusConflict,
+			expectImport: true,
+		},
+		{
+			name:         "internal error",
+			params:       models.ImportProjectParams{Archive: base64.StdEncoding.EncodeToString(archive)},
+			importErr:    errors.New("oops"),
+			expectStatus: http.StatusInternalServerError,
+			expectImport: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectArchiveManager := &fake.IProjectArchiveManagerMock{
+				ImportFunc: func(params *models.CreateProjectParams, archive models.ProjectArchive) (*models.ImportProjectResponse, error) {
+					if tt.importErr != nil {
+						return nil, tt.importErr
+					}
+					return &models.ImportProjectResponse{Warnings: []string{"my-warning"}}, nil
+				},
+			}
+			router := gin.Default()
+			projectArchiveHandler := handler.NewProjectArchiveHandler(projectArchiveManager, config.EnvConfig{ProjectNameMaxSize: 200}, &fake.IRepositoryProvisionerMock{}, nil)
+			router.POST("/project/:project/import", projectArchiveHandler.ImportProject)
+
+			payload, _ := json.Marshal(tt.params)
+			req := httptest.NewRequest(http.MethodPost, "/project/imported-project/import", bytes.NewBuffer(payload))
+			w := performRequest(router, req)
+
+			require.Equal(t, tt.expectStatus, w.Code)
+			if !tt.expectImport {
+				require.Empty(t, projectArchiveManager.ImportCalls())
+				return
+			}
+			require.Len(t, projectArchiveManager.ImportCalls(), 1)
+			createParams := projectArchiveManager.ImportCalls()[0].Params
+			require.Equal(t, "imported-project", *createParams.Name)
+			require.Equal(t, base64.StdEncoding.EncodeToString([]byte(projectArchiveHandlerTestShipyard)), *createParams.Shipyard)
+			require.Equal(t, tt.params.GitRemoteURL, createParams.GitRemoteURL)
+			require.Equal(t, tt.params.GitToken, createParams.GitToken)
+
+			if tt.expectStatus == http.StatusCreated {
+				response := &models.ImportProjectResponse{}
+				require.Nil(t, json.Unmarshal(w.Body.Bytes(), response))
+				require.Equal(t, []string{"my-warning"}, response.Warnings)
+			}
+		})
+	}
+}
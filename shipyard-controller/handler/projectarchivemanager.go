@@ -0,0 +1,340 @@
+package handler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/benbjohnson/clock"
+	"github.com/google/uuid"
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/common"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	log "github.com/sirupsen/logrus"
+)
+
+const shipyardResourceURI = "shipyard.yaml"
+
+//go:generate moq -pkg fake -skip-ensure -out ./fake/projectarchivemanager.go . IProjectArchiveManager
+type IProjectArchiveManager interface {
+	// Export creates a tar.gz archive containing the shipyard, the resources, the subscriptions and the names of the secrets of the given project
+	Export(projectName string, params models.ExportProjectParams) ([]byte, error)
+	// Import creates the project described by the given params, and recreates the services, resources and subscriptions of the given archive within it
+	Import(params *models.CreateProjectParams, archive models.ProjectArchive) (*models.ImportProjectResponse, error)
+}
+
+//go:generate moq -pkg fake -skip-ensure -out ./fake/secretlister.go . SecretLister
+// SecretLister lists the secrets managed by the secret-service
+type SecretLister interface {
+	GetSecrets() (*apimodels.GetSecretsResponse, error)
+}
+
+type ProjectArchiveManager struct {
+	projectManager        IProjectManager
+	serviceManager        IServiceManager
+	configurationStore    common.ConfigurationStore
+	projectMVRepo         db.ProjectMVRepo
+	uniformRepo           db.UniformRepo
+	sequenceExecutionRepo db.SequenceExecutionRepo
+	secretLister          SecretLister
+	clock                 clock.Clock
+}
+
+// NewProjectArchiveManager creates a new ProjectArchiveManager
+func NewProjectArchiveManager(
+	projectManager IProjectManager,
+	serviceManager IServiceManager,
+	configurationStore common.ConfigurationStore,
+	projectMVRepo db.ProjectMVRepo,
+	uniformRepo db.UniformRepo,
+	sequenceExecutionRepo db.SequenceExecutionRepo,
+	secretLister SecretLister,
+	clock clock.Clock,
+) *ProjectArchiveManager {
+	return &ProjectArchiveManager{
+		projectManager:        projectManager,
+		serviceManager:        serviceManager,
+		configurationStore:    configurationStore,
+		projectMVRepo:         projectMVRepo,
+		uniformRepo:           uniformRepo,
+		sequenceExecutionRepo: sequenceExecutionRepo,
+		secretLister:          secretLister,
+		clock:                 clock,
+	}
+}
+
+func (am *ProjectArchiveManager) Export(projectName string, params models.ExportProjectParams) ([]byte, error) {
+	project, err := am.projectMVRepo.GetProject(projectName)
+	if err != nil {
+		return nil, fmt.Errorf("could not load project %s: %w", projectName, err)
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+
+	shipyard, err := am.configurationStore.GetProjectResource(projectName, shipyardResourceURI)
+	if err != nil {
+		return nil, fmt.Errorf("could not load shipyard of project %s: %w", projectName, err)
+	}
+
+	archive := models.ProjectArchive{
+		Metadata: models.ProjectArchiveMetadata{
+			Version:    models.ProjectArchiveVersion,
+			Project:    projectName,
+			ExportedAt: am.clock.Now().UTC(),
+			Stages:     []models.ProjectArchiveStage{},
+		},
+		Shipyard:         []byte(shipyard.ResourceContent),
+		StageResources:   map[string][]models.ProjectArchiveResource{},
+		ServiceResources: map[string]map[string][]models.ProjectArchiveResource{},
+	}
+
+	for _, stage := range project.Stages {
+		archivedStage := models.ProjectArchiveStage{Name: stage.StageName, Services: []string{}}
+		archive.ServiceResources[stage.StageName] = map[string][]models.ProjectArchiveResource{}
+		for _, service := range stage.Services {
+			archivedStage.Services = append(archivedStage.Services, service.ServiceName)
+			resources, err := am.configurationStore.GetServiceResources(projectName, stage.StageName, service.ServiceName)
+			if err != nil {
+				return nil, fmt.Errorf("could not load resources of service %s in stage %s: %w", service.ServiceName, stage.StageName, err)
+			}
+			archive.ServiceResources[stage.StageName][service.ServiceName] = toProjectArchiveResources(resources, nil)
+		}
+		archive.Metadata.Stages = append(archive.Metadata.Stages, archivedStage)
+
+		resources, err := am.configurationStore.GetStageResources(projectName, stage.StageName)
+		if err != nil {
+			return nil, fmt.Errorf("could not load resources of stage %s: %w", stage.StageName, err)
+		}
+		// the resources of the services are part of the stage resources, but they have already been exported above
+		archive.StageResources[stage.StageName] = toProjectArchiveResources(resources, archivedStage.Services)
+	}
+
+	if archive.Subscriptions, err = am.getProjectSubscriptions(projectName); err != nil {
+		return nil, err
+	}
+	if archive.Secrets, err = am.getSecrets(); err != nil {
+		return nil, err
+	}
+
+	if params.IncludeSequences {
+		sequenceExecutions, err := am.sequenceExecutionRepo.Get(models.SequenceExecutionFilter{
+			Scope: models.EventScope{EventData: keptnv2.EventData{Project: projectName}},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not load sequence executions of project %s: %w", projectName, err)
+		}
+		archive.Sequences = []models.SequenceExecutionHistory{}
+		for _, sequenceExecution := range sequenceExecutions {
+			archive.Sequences = append(archive.Sequences, models.NewSequenceExecutionHistory(sequenceExecution))
+		}
+	}
+
+	return models.WriteProjectArchive(archive)
+}
+
+func (am *ProjectArchiveManager) Import(params *models.CreateProjectParams, archive models.ProjectArchive) (*models.ImportProjectResponse, error) {
+	projectName := *params.Name
+
+	err, rollback := am.projectManager.Create(params)
+	if err != nil {
+		if err := rollback(); err != nil {
+			log.Errorf("could not roll back creation of project %s: %s", projectName, err.Error())
+		}
+		return nil, err
+	}
+
+	response, err := am.importArchiveContent(projectName, archive)
+	if err != nil {
+		// do not leave a partially imported project behind
+		log.Infof("Rollback: deleting partially imported project %s", projectName)
+		if _, err := am.projectManager.Delete(projectName); err != nil {
+			log.Errorf("Rollback failed: could not delete project %s: %s", projectName, err.Error())
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (am *ProjectArchiveManager) importArchiveContent(projectName string, archive models.ProjectArchive) (*models.ImportProjectResponse, error) {
+	response := &models.ImportProjectResponse{
+		Warnings:       []string{},
+		MissingSecrets: []models.ProjectArchiveSecret{},
+	}
+
+	// services are created in all stages of the project, so each of them only needs to be created once
+	services := map[string]bool{}
+	for _, stage := range archive.Metadata.Stages {
+		for _, service := range stage.Services {
+			services[service] = true
+		}
+	}
+	for _, service := range sortedKeys(services) {
+		serviceName := service
+		if err := am.serviceManager.CreateService(projectName, &models.CreateServiceParams{ServiceName: &serviceName}); err != nil {
+			return nil, fmt.Errorf("could not create service %s: %w", serviceName, err)
+		}
+	}
+
+	for _, stage := range archive.Metadata.Stages {
+		if resources := archive.StageResources[stage.Name]; len(resources) > 0 {
+			if err := am.configurationStore.CreateStageResources(projectName, stage.Name, toAPIResources(resources)); err != nil {
+				return nil, fmt.Errorf("could not create resources of stage %s: %w", stage.Name, err)
+			}
+		}
+		for _, service := range stage.Services {
+			if resources := archive.ServiceResources[stage.Name][service]; len(resources) > 0 {
+				if err := am.configurationStore.CreateServiceResources(projectName, stage.Name, service, toAPIResources(resources)); err != nil {
+					return nil, fmt.Errorf("could not create resources of service %s in stage %s: %w", service, stage.Name, err)
+				}
+			}
+		}
+	}
+
+	if len(archive.Subscriptions) > 0 {
+		integrations, err := am.uniformRepo.GetUniformIntegrations(models.GetUniformIntegrationsParams{})
+		if err != nil {
+			return nil, fmt.Errorf("could not load integrations: %w", err)
+		}
+		for _, subscription := range archive.Subscriptions {
+			imported := false
+			for _, integration := range integrations {
+				if integration.Name != subscription.Integration {
+					continue
+				}
+				eventSubscription := apimodels.EventSubscription{
+					ID:    uuid.New().String(),
+					Event: subscription.Event,
+					Filter: apimodels.EventSubscriptionFilter{
+						Projects: []string{projectName},
+						Stages:   subscription.Filter.Stages,
+						Services: subscription.Filter.Services,
+					},
+				}
+				if err := am.uniformRepo.CreateOrUpdateSubscription(integration.ID, eventSubscription); err != nil {
+					return nil, fmt.Errorf("could not create subscription of integration %s: %w", integration.Name, err)
+				}
+				imported = true
+			}
+			if !imported {
+				response.Warnings = append(response.Warnings, fmt.Sprintf("integration %s is not registered, its subscription to %s has not been imported", subscription.Integration, subscription.Event))
+			}
+		}
+	}
+
+	if len(archive.Secrets) > 0 {
+		existingSecrets, err := am.getSecrets()
+		if err != nil {
+			response.Warnings = append(response.Warnings, fmt.Sprintf("could not check for missing secrets: %s", err.Error()))
+			response.MissingSecrets = archive.Secrets
+		} else {
+			for _, secret := range archive.Secrets {
+				if !containsSecret(existingSecrets, secret) {
+					response.MissingSecrets = append(response.MissingSecrets, secret)
+				}
+			}
+		}
+	}
+	return response, nil
+}
+
+// getProjectSubscriptions returns the subscriptions that are explicitly restricted to the given project.
+// Subscriptions without a project filter apply to every project and are therefore not part of the export
+func (am *ProjectArchiveManager) getProjectSubscriptions(projectName string) ([]models.ProjectArchiveSubscription, error) {
+	integrations, err := am.uniformRepo.GetUniformIntegrations(models.GetUniformIntegrationsParams{Project: projectName})
+	if err != nil {
+		return nil, fmt.Errorf("could not load subscriptions of project %s: %w", projectName, err)
+	}
+	subscriptions := []models.ProjectArchiveSubscription{}
+	for _, integration := range integrations {
+		for _, subscription := range integration.Subscriptions {
+			if !containsString(subscription.Filter.Projects, projectName) {
+				continue
+			}
+			subscriptions = append(subscriptions, models.ProjectArchiveSubscription{
+				Integration: integration.Name,
+				Event:       subscription.Event,
+				Filter: apimodels.EventSubscriptionFilter{
+					Projects: []string{projectName},
+					Stages:   subscription.Filter.Stages,
+					Services: subscription.Filter.Services,
+				},
+			})
+		}
+	}
+	return subscriptions, nil
+}
+
+// getSecrets returns the names, scopes and keys of all secrets managed by the secret-service. The values of the secrets are never exported
+func (am *ProjectArchiveManager) getSecrets() ([]models.ProjectArchiveSecret, error) {
+	response, err := am.secretLister.GetSecrets()
+	if err != nil {
+		return nil, fmt.Errorf("could not load secrets: %w", err)
+	}
+	secrets := []models.ProjectArchiveSecret{}
+	for _, secret := range response.Secrets {
+		archivedSecret := models.ProjectArchiveSecret{Keys: secret.Keys}
+		if secret.Name != nil {
+			archivedSecret.Name = *secret.Name
+		}
+		if secret.Scope != nil {
+			archivedSecret.Scope = *secret.Scope
+		}
+		secrets = append(secrets, archivedSecret)
+	}
+	return secrets, nil
+}
+
+func containsSecret(secrets []models.ProjectArchiveSecret, secret models.ProjectArchiveSecret) bool {
+	for _, s := range secrets {
+		if s.Name == secret.Name && s.Scope == secret.Scope {
+			return true
+		}
+	}
+	return false
+}
+
+// toProjectArchiveResources converts the given resources for a project archive, skipping the ones located in the directories of the given services
+func toProjectArchiveResources(resources []*apimodels.Resource, serviceDirectories []string) []models.ProjectArchiveResource {
+	result := []models.ProjectArchiveResource{}
+	for _, resource := range resources {
+		if resource == nil || resource.ResourceURI == nil {
+			continue
+		}
+		uri := strings.TrimPrefix(*resource.ResourceURI, "/")
+		if containsString(serviceDirectories, strings.Split(uri, "/")[0]) {
+			continue
+		}
+		result = append(result, models.ProjectArchiveResource{URI: uri, Content: []byte(resource.ResourceContent)})
+	}
+	return result
+}
+
+func toAPIResources(resources []models.ProjectArchiveResource) []*apimodels.Resource {
+	result := []*apimodels.Resource{}
+	for _, resource := range resources {
+		uri := resource.URI
+		result = append(result, &apimodels.Resource{ResourceURI: &uri, ResourceContent: string(resource.Content)})
+	}
+	return result
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,256 @@
+package handler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/shipyard-controller/common"
+	common_mock "github.com/keptn/keptn/shipyard-controller/common/fake"
+	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
+	"github.com/keptn/keptn/shipyard-controller/handler/fake"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestResource(uri, content string) *apimodels.Resource {
+	return &apimodels.Resource{ResourceURI: &uri, ResourceContent: content}
+}
+
+func newTestSecretLister() *fake.SecretListerMock {
+	name, scope := "dynatrace", "dynatrace-service"
+	return &fake.SecretListerMock{
+		GetSecretsFunc: func() (*apimodels.GetSecretsResponse, error) {
+			return &apimodels.GetSecretsResponse{
+				Secrets: []apimodels.GetSecretResponseItem{
+					{SecretMetadata: apimodels.SecretMetadata{Name: &name, Scope: &scope}, Keys: []string{"DT_API_TOKEN"}},
+				},
+			}, nil
+		},
+	}
+}
+
+func TestProjectArchiveManager_Export(t *testing.T) {
+	projectMVRepo := &db_mock.ProjectMVRepoMock{
+		GetProjectFunc: func(projectName string) (*apimodels.ExpandedProject, error) {
+			if projectName != "my-project" {
+				return nil, nil
+			}
+			return &apimodels.ExpandedProject{
+				ProjectName: "my-project",
+				Stages: []*apimodels.ExpandedStage{
+					{StageName: "dev", Services: []*apimodels.ExpandedService{{ServiceName: "carts"}}},
+					{StageName: "prod", Services: []*apimodels.ExpandedService{{ServiceName: "carts"}}},
+				},
+			}, nil
+		},
+	}
+	configurationStore := &common_mock.ConfigurationStoreMock{
+		GetProjectResourceFunc: func(projectName string, resourceURI string) (*apimodels.Resource, error) {
+			return newTestResource(resourceURI, "my-shipyard"), nil
+		},
+		GetStageResourcesFunc: func(projectName string, stageName string) ([]*apimodels.Resource, error) {
+			return []*apimodels.Resource{
+				newTestResource("/slo.yaml", "slo-"+stageName),
+				newTestResource("/carts/helm/carts.tgz", "chart"),
+			}, nil
+		},
+		GetServiceResourcesFunc: func(projectName string, stageName string, serviceName string) ([]*apimodels.Resource, error) {
+			return []*apimodels.Resource{newTestResource("/helm/carts.tgz", "chart-"+stageName)}, nil
+		},
+	}
+	uniformRepo := &db_mock.UniformRepoMock{
+		GetUniformIntegrationsFunc: func(filter models.GetUniformIntegrationsParams) ([]apimodels.Integration, error) {
+			return []apimodels.Integration{
+				{
+					Name: "helm-service",
+					Subscriptions: []apimodels.EventSubscription{
+						{Event: "sh.keptn.event.deployment.triggered", Filter: apimodels.EventSubscriptionFilter{Projects: []string{"my-project"}, Stages: []string{"dev"}}},
+						{Event: "sh.keptn.event.release.triggered", Filter: apimodels.EventSubscriptionFilter{Projects: []string{"other-project"}}},
+						{Event: "sh.keptn.event.rollback.triggered"},
+					},
+				},
+			}, nil
+		},
+	}
+	sequenceExecutionRepo := &db_mock.SequenceExecutionRepoMock{
+		GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+			return []models.SequenceExecution{{ID: "my-sequence"}}, nil
+		},
+	}
+	mockClock := clock.NewMock()
+	mockClock.Set(time.Date(2022, 5, 10, 9, 0, 0, 0, time.UTC))
+
+	am := NewProjectArchiveManager(&fake.IProjectManagerMock{}, &fake.IServiceManagerMock{}, configurationStore, projectMVRepo, uniformRepo, sequenceExecutionRepo, newTestSecretLister(), mockClock)
+
+	content, err := am.Export("my-project", models.ExportProjectParams{})
+	require.Nil(t, err)
+
+	archive, err := models.ReadProjectArchive(content)
+	require.Nil(t, err)
+	require.Equal(t, models.ProjectArchiveMetadata{
+		Version:    models.ProjectArchiveVersion,
+		Project:    "my-project",
+		ExportedAt: mockClock.Now(),
+		Stages: []models.ProjectArchiveStage{
+			{Name: "dev", Services: []string{"carts"}},
+			{Name: "prod", Services: []string{"carts"}},
+		},
+	}, archive.Metadata)
+	require.Equal(t, "my-shipyard", string(archive.Shipyard))
+	require.Equal(t, []string{"shipyard.yaml"}, []string{configurationStore.GetProjectResourceCalls()[0].ResourceURI})
+
+	// the resources of the services are not exported as stage resources
+	require.Equal(t, []models.ProjectArchiveResource{{URI: "slo.yaml", Content: []byte("slo-dev")}}, archive.StageResources["dev"])
+	require.Equal(t, []models.ProjectArchiveResource{{URI: "helm/carts.tgz", Content: []byte("chart-prod")}}, archive.ServiceResources["prod"]["carts"])
+
+	require.Equal(t, []models.ProjectArchiveSubscription{
+		{Integration: "helm-service", Event: "sh.keptn.event.deployment.triggered", Filter: apimodels.EventSubscriptionFilter{Projects: []string{"my-project"}, Stages: []string{"dev"}}},
+	}, archive.Subscriptions)
+	require.Equal(t, []models.ProjectArchiveSecret{{Name: "dynatrace", Scope: "dynatrace-service", Keys: []string{"DT_API_TOKEN"}}}, archive.Secrets)
+
+	require.Nil(t, archive.Sequences)
+	require.Empty(t, sequenceExecutionRepo.GetCalls())
+
+	// include the sequences
+	content, err = am.Export("my-project", models.ExportProjectParams{IncludeSequences: true})
+	require.Nil(t, err)
+
+	archive, err = models.ReadProjectArchive(content)
+	require.Nil(t, err)
+	require.Len(t, archive.Sequences, 1)
+	require.Equal(t, "my-sequence", archive.Sequences[0].ID)
+	require.Equal(t, "my-project", sequenceExecutionRepo.GetCalls()[0].Filter.Scope.Project)
+
+	// unknown project
+	_, err = am.Export("unknown-project", models.ExportProjectParams{})
+	require.ErrorIs(t, err, ErrProjectNotFound)
+}
+
+func newTestProjectArchive() models.ProjectArchive {
+	return models.ProjectArchive{
+		Metadata: models.ProjectArchiveMetadata{
+			Version: models.ProjectArchiveVersion,
+			Project: "my-project",
+			Stages: []models.ProjectArchiveStage{
+				{Name: "dev", Services: []string{"carts", "orders"}},
+				{Name: "prod", Services: []string{"carts"}},
+			},
+		},
+		Shipyard: []byte("my-shipyard"),
+		StageResources: map[string][]models.ProjectArchiveResource{
+			"dev": {{URI: "slo.yaml", Content: []byte("slo")}},
+		},
+		ServiceResources: map[string]map[string][]models.ProjectArchiveResource{
+			"prod": {"carts": {{URI: "helm/carts.tgz", Content: []byte("chart")}}},
+		},
+		Subscriptions: []models.ProjectArchiveSubscription{
+			{Integration: "helm-service", Event: "sh.keptn.event.deployment.triggered", Filter: apimodels.EventSubscriptionFilter{Projects: []string{"my-project"}, Stages: []string{"dev"}}},
+			{Integration: "jmeter-service", Event: "sh.keptn.event.test.triggered"},
+		},
+		Secrets: []models.ProjectArchiveSecret{
+			{Name: "dynatrace", Scope: "dynatrace-service"},
+			{Name: "slack", Scope: "keptn-default"},
+		},
+	}
+}
+
+func TestProjectArchiveManager_Import(t *testing.T) {
+	projectManager := &fake.IProjectManagerMock{
+		CreateFunc: func(params *models.CreateProjectParams) (error, common.RollbackFunc) {
+			return nil, func() error { return nil }
+		},
+	}
+	serviceManager := &fake.IServiceManagerMock{
+		CreateServiceFunc: func(projectName string, params *models.CreateServiceParams) error {
+			return nil
+		},
+	}
+	configurationStore := &common_mock.ConfigurationStoreMock{
+		CreateStageResourcesFunc: func(projectName string, stageName string, resources []*apimodels.Resource) error {
+			return nil
+		},
+		CreateServiceResourcesFunc: func(projectName string, stageName string, serviceName string, resources []*apimodels.Resource) error {
+			return nil
+		},
+	}
+	uniformRepo := &db_mock.UniformRepoMock{
+		GetUniformIntegrationsFunc: func(filter models.GetUniformIntegrationsParams) ([]apimodels.Integration, error) {
+			return []apimodels.Integration{{ID: "helm-service-id", Name: "helm-service"}}, nil
+		},
+		CreateOrUpdateSubscriptionFunc: func(integrationID string, subscription apimodels.EventSubscription) error {
+			return nil
+		},
+	}
+
+	am := NewProjectArchiveManager(projectManager, serviceManager, configurationStore, &db_mock.ProjectMVRepoMock{}, uniformRepo, &db_mock.SequenceExecutionRepoMock{}, newTestSecretLister(), clock.NewMock())
+
+	projectName := "imported-project"
+	response, err := am.Import(&models.CreateProjectParams{Name: &projectName}, newTestProjectArchive())
+	require.Nil(t, err)
+
+	require.Len(t, projectManager.CreateCalls(), 1)
+
+	require.Len(t, serviceManager.CreateServiceCalls(), 2)
+	require.Equal(t, "carts", *serviceManager.CreateServiceCalls()[0].Params.ServiceName)
+	require.Equal(t, "orders", *serviceManager.CreateServiceCalls()[1].Params.ServiceName)
+	require.Equal(t, projectName, serviceManager.CreateServiceCalls()[0].ProjectName)
+
+	require.Len(t, configurationStore.CreateStageResourcesCalls(), 1)
+	require.Equal(t, "dev", configurationStore.CreateStageResourcesCalls()[0].StageName)
+	require.Equal(t, "slo.yaml", *configurationStore.CreateStageResourcesCalls()[0].Resources[0].ResourceURI)
+	require.Len(t, configurationStore.CreateServiceResourcesCalls(), 1)
+	require.Equal(t, "prod", configurationStore.CreateServiceResourcesCalls()[0].StageName)
+	require.Equal(t, "carts", configurationStore.CreateServiceResourcesCalls()[0].ServiceName)
+	require.Equal(t, "chart", configurationStore.CreateServiceResourcesCalls()[0].Resources[0].ResourceContent)
+
+	// the subscription is bound to the imported project
+	require.Len(t, uniformRepo.CreateOrUpdateSubscriptionCalls(), 1)
+	require.Equal(t, "helm-service-id", uniformRepo.CreateOrUpdateSubscriptionCalls()[0].IntegrationID)
+	subscription := uniformRepo.CreateOrUpdateSubscriptionCalls()[0].Subscription
+	require.NotEmpty(t, subscription.ID)
+	require.Equal(t, "sh.keptn.event.deployment.triggered", subscription.Event)
+	require.Equal(t, apimodels.EventSubscriptionFilter{Projects: []string{projectName}, Stages: []string{"dev"}}, subscription.Filter)
+
+	require.Equal(t, []string{"integration jmeter-service is not registered, its subscription to sh.keptn.event.test.triggered has not been imported"}, response.Warnings)
+	require.Equal(t, []models.ProjectArchiveSecret{{Name: "slack", Scope: "keptn-default"}}, response.MissingSecrets)
+}
+
+func TestProjectArchiveManager_ImportFails(t *testing.T) {
+	rolledBack := false
+	projectManager := &fake.IProjectManagerMock{
+		CreateFunc: func(params *models.CreateProjectParams) (error, common.RollbackFunc) {
+			return ErrProjectAlreadyExists, func() error {
+				rolledBack = true
+				return nil
+			}
+		},
+		DeleteFunc: func(projectName string) (string, error) {
+			return "", nil
+		},
+	}
+	serviceManager := &fake.IServiceManagerMock{
+		CreateServiceFunc: func(projectName string, params *models.CreateServiceParams) error {
+			return errors.New("oops")
+		},
+	}
+
+	am := NewProjectArchiveManager(projectManager, serviceManager, &common_mock.ConfigurationStoreMock{}, &db_mock.ProjectMVRepoMock{}, &db_mock.UniformRepoMock{}, &db_mock.SequenceExecutionRepoMock{}, newTestSecretLister(), clock.NewMock())
+
+	projectName := "imported-project"
+	_, err := am.Import(&models.CreateProjectParams{Name: &projectName}, newTestProjectArchive())
+	require.ErrorIs(t, err, ErrProjectAlreadyExists)
+	require.True(t, rolledBack)
+	require.Empty(t, serviceManager.CreateServiceCalls())
+
+	// the project is deleted again if the content of the archive cannot be imported
+	projectManager.CreateFunc = func(params *models.CreateProjectParams) (error, common.RollbackFunc) {
+		return nil, func() error { return nil }
+	}
+	_, err = am.Import(&models.CreateProjectParams{Name: &projectName}, newTestProjectArchive())
+	require.NotNil(t, err)
+	require.Len(t, projectManager.DeleteCalls(), 1)
+	require.Equal(t, projectName, projectManager.DeleteCalls()[0].ProjectName)
+}
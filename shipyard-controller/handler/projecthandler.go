@@ -7,7 +7,6 @@ import (
 
 	keptncommon "github.com/keptn/go-utils/pkg/lib/keptn"
 	"github.com/keptn/keptn/shipyard-controller/config"
-	"gopkg.in/yaml.v3"
 
 	"net/http"
 	"sort"
@@ -49,13 +48,12 @@ func (p ProjectValidator) validateCreateProjectParams(createProjectParams *model
 	if createProjectParams.Shipyard == nil || *createProjectParams.Shipyard == "" {
 		return errors.New("shipyard must contain a valid shipyard spec encoded in base64")
 	}
-	shipyard := &keptnv2.Shipyard{}
 	decodeString, err := base64.StdEncoding.DecodeString(*createProjectParams.Shipyard)
 	if err != nil {
 		return errors.New("could not decode shipyard content")
 	}
 
-	err = yaml.Unmarshal(decodeString, shipyard)
+	shipyard, err := common.UnmarshalShipyard(string(decodeString))
 	if err != nil {
 		return fmt.Errorf("could not unmarshal provided shipyard content")
 	}
@@ -68,6 +66,18 @@ func (p ProjectValidator) validateCreateProjectParams(createProjectParams *model
 		return fmt.Errorf("provided shipyard file is not valid: %s", err.Error())
 	}
 
+	if err := common.ValidateShipyardTasks(string(decodeString)); err != nil {
+		return fmt.Errorf("provided shipyard file is not valid: %s", err.Error())
+	}
+
+	if err := common.ValidateShipyardFreezeWindows(string(decodeString)); err != nil {
+		return fmt.Errorf("provided shipyard file is not valid: %s", err.Error())
+	}
+
+	if err := common.ValidateShipyardSequenceTimeouts(string(decodeString)); err != nil {
+		return fmt.Errorf("provided shipyard file is not valid: %s", err.Error())
+	}
+
 	if err := common.ValidateGitRemoteURL(createProjectParams.GitRemoteURL); err != nil {
 		return fmt.Errorf("provided gitRemoteURL is not valid: %s", err.Error())
 	}
@@ -91,6 +101,10 @@ func (p ProjectValidator) validateCreateProjectParams(createProjectParams *model
 		return fmt.Errorf("SSH authorization and PEM Certificate be used together")
 	}
 
+	if err := common.ValidateGitPullRequestProvider(createProjectParams.GitPullRequestProvider, createProjectParams.GitRemoteURL, createProjectParams.GitToken); err != nil {
+		return fmt.Errorf("provided gitPullRequestProvider is not valid: %s", err.Error())
+	}
+
 	if createProjectParams.GitPemCertificate != "" {
 		decodeString, err = base64.StdEncoding.DecodeString(createProjectParams.GitPemCertificate)
 		if err != nil {
@@ -110,13 +124,12 @@ func (p ProjectValidator) validateUpdateProjectParams(updateProjectParams *model
 	}
 
 	if updateProjectParams.Shipyard != nil && *updateProjectParams.Shipyard != "" {
-		shipyard := &keptnv2.Shipyard{}
 		decodeString, err := base64.StdEncoding.DecodeString(*updateProjectParams.Shipyard)
 		if err != nil {
 			return errors.New("could not decode shipyard content")
 		}
 
-		err = yaml.Unmarshal(decodeString, shipyard)
+		shipyard, err := common.UnmarshalShipyard(string(decodeString))
 		if err != nil {
 			return fmt.Errorf("could not unmarshal provided shipyard content")
 		}
@@ -128,6 +141,18 @@ func (p ProjectValidator) validateUpdateProjectParams(updateProjectParams *model
 		if err := common.ValidateShipyardStages(shipyard); err != nil {
 			return fmt.Errorf("provided shipyard file is not valid: %s", err.Error())
 		}
+
+		if err := common.ValidateShipyardTasks(string(decodeString)); err != nil {
+			return fmt.Errorf("provided shipyard file is not valid: %s", err.Error())
+		}
+
+		if err := common.ValidateShipyardFreezeWindows(string(decodeString)); err != nil {
+			return fmt.Errorf("provided shipyard file is not valid: %s", err.Error())
+		}
+
+		if err := common.ValidateShipyardSequenceTimeouts(string(decodeString)); err != nil {
+			return fmt.Errorf("provided shipyard file is not valid: %s", err.Error())
+		}
 	}
 
 	if err := common.ValidateGitRemoteURL(updateProjectParams.GitRemoteURL); err != nil {
@@ -153,6 +178,10 @@ func (p ProjectValidator) validateUpdateProjectParams(updateProjectParams *model
 		return fmt.Errorf("SSH authorization and PEM Certificate be used together")
 	}
 
+	if err := common.ValidateGitPullRequestProvider(updateProjectParams.GitPullRequestProvider, updateProjectParams.GitRemoteURL, updateProjectParams.GitToken); err != nil {
+		return fmt.Errorf("provided gitPullRequestProvider is not valid: %s", err.Error())
+	}
+
 	if updateProjectParams.GitPemCertificate != "" {
 		_, err := base64.StdEncoding.DecodeString(updateProjectParams.GitPemCertificate)
 		if err != nil {
@@ -176,14 +205,16 @@ type ProjectHandler struct {
 	EventSender           common.EventSender
 	Env                   config.EnvConfig
 	RepositoryProvisioner IRepositoryProvisioner
+	Locker                ILocker
 }
 
-func NewProjectHandler(projectManager IProjectManager, eventSender common.EventSender, env config.EnvConfig, repositoryProvisioner IRepositoryProvisioner) *ProjectHandler {
+func NewProjectHandler(projectManager IProjectManager, eventSender common.EventSender, env config.EnvConfig, repositoryProvisioner IRepositoryProvisioner, locker ILocker) *ProjectHandler {
 	return &ProjectHandler{
 		ProjectManager:        projectManager,
 		EventSender:           eventSender,
 		Env:                   env,
 		RepositoryProvisioner: repositoryProvisioner,
+		Locker:                locker,
 	}
 }
 
@@ -214,6 +245,17 @@ func (ph *ProjectHandler) GetAllProjects(c *gin.Context) {
 		return
 	}
 
+	// callers whose API token is limited to a set of projects only see these projects
+	if authorizedProjects, ok := GetAuthorizedProjects(c); ok {
+		filtered := []*apimodels.ExpandedProject{}
+		for _, project := range allProjects {
+			if containsString(authorizedProjects, project.ProjectName) {
+				filtered = append(filtered, project)
+			}
+		}
+		allProjects = filtered
+	}
+
 	sort.Slice(allProjects, func(i, j int) bool {
 		return allProjects[i].ProjectName < allProjects[j].ProjectName
 	})
@@ -289,18 +331,10 @@ func (ph *ProjectHandler) CreateProject(c *gin.Context) {
 		return
 	}
 
-	automaticProvisioningURL := ph.Env.AutomaticProvisioningURL
-	if automaticProvisioningURL != "" && params.GitRemoteURL == "" {
-		provisioningData, err := ph.RepositoryProvisioner.ProvideRepository(*params.Name, common.GetKeptnNamespace())
-		if err != nil {
-			log.Errorf(err.Error())
-			SetFailedDependencyErrorResponse(c, UnableProvisionInstanceGeneric)
-			return
-		}
-
-		params.GitRemoteURL = provisioningData.GitRemoteURL
-		params.GitToken = provisioningData.GitToken
-		params.GitUser = provisioningData.GitUser
+	if err := provisionRepository(ph.RepositoryProvisioner, ph.Env.AutomaticProvisioningURL, params); err != nil {
+		log.Errorf(err.Error())
+		SetFailedDependencyErrorResponse(c, UnableProvisionInstanceGeneric)
+		return
 	}
 
 	projectValidator := ProjectValidator{ProjectNameMaxSize: ph.Env.ProjectNameMaxSize}
@@ -309,8 +343,12 @@ func (ph *ProjectHandler) CreateProject(c *gin.Context) {
 		return
 	}
 
-	common.LockProject(*params.Name)
-	defer common.UnlockProject(*params.Name)
+	unlockProject, err := lockProject(ph.Locker, *params.Name)
+	if err != nil {
+		SetInternalServerErrorResponse(c, err.Error())
+		return
+	}
+	defer unlockProject()
 
 	if err := ph.sendProjectCreateStartedEvent(keptnContext, params); err != nil {
 		log.Errorf("could not send project.create.started event: %s", err.Error())
@@ -369,8 +407,12 @@ func (ph *ProjectHandler) UpdateProject(c *gin.Context) {
 		return
 	}
 
-	common.LockProject(*params.Name)
-	defer common.UnlockProject(*params.Name)
+	unlockProject, err := lockProject(ph.Locker, *params.Name)
+	if err != nil {
+		SetInternalServerErrorResponse(c, err.Error())
+		return
+	}
+	defer unlockProject()
 
 	err, rollback := ph.ProjectManager.Update(params)
 	if err != nil {
@@ -425,8 +467,12 @@ func (ph *ProjectHandler) DeleteProject(c *gin.Context) {
 		}
 	}
 
-	common.LockProject(projectName)
-	defer common.UnlockProject(projectName)
+	unlockProject, err := lockProject(ph.Locker, projectName)
+	if err != nil {
+		SetInternalServerErrorResponse(c, err.Error())
+		return
+	}
+	defer unlockProject()
 	responseMessage, err := ph.ProjectManager.Delete(projectName)
 	if err != nil {
 		log.Errorf("failed to delete project %s: %s", projectName, err.Error())
@@ -448,6 +494,21 @@ func (ph *ProjectHandler) DeleteProject(c *gin.Context) {
 	})
 }
 
+// provisionRepository sets the upstream of the given project to a repository provided by the automatic provisioning, if the provisioning is enabled and no upstream has been set
+func provisionRepository(repositoryProvisioner IRepositoryProvisioner, automaticProvisioningURL string, params *models.CreateProjectParams) error {
+	if automaticProvisioningURL == "" || params.GitRemoteURL != "" {
+		return nil
+	}
+	provisioningData, err := repositoryProvisioner.ProvideRepository(*params.Name, common.GetKeptnNamespace())
+	if err != nil {
+		return err
+	}
+	params.GitRemoteURL = provisioningData.GitRemoteURL
+	params.GitToken = provisioningData.GitToken
+	params.GitUser = provisioningData.GitUser
+	return nil
+}
+
 func (ph *ProjectHandler) sendProjectCreateStartedEvent(keptnContext string, params *models.CreateProjectParams) error {
 	eventPayload := keptnv2.ProjectCreateStartedEventData{
 		EventData: keptnv2.EventData{
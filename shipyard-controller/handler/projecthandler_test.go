@@ -133,7 +133,7 @@ func TestGetAllProjects(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			w, c := createGinTestContext()
 
-			handler := NewProjectHandler(tt.fields.ProjectManager, tt.fields.EventSender, tt.fields.EnvConfig, tt.fields.RepositoryProvisioner)
+			handler := NewProjectHandler(tt.fields.ProjectManager, tt.fields.EventSender, tt.fields.EnvConfig, tt.fields.RepositoryProvisioner, nil)
 			c.Request, _ = http.NewRequest(http.MethodGet, tt.queryParams, bytes.NewBuffer([]byte{}))
 
 			handler.GetAllProjects(c)
@@ -150,6 +150,28 @@ func TestGetAllProjects(t *testing.T) {
 	}
 }
 
+func TestGetAllProjects_AuthorizedProjects(t *testing.T) {
+	p1 := &apimodels.ExpandedProject{ProjectName: "sockshop"}
+	p2 := &apimodels.ExpandedProject{ProjectName: "podtato-head"}
+
+	projectManager := &fake.IProjectManagerMock{
+		GetFunc: func() ([]*apimodels.ExpandedProject, error) {
+			return []*apimodels.ExpandedProject{p1, p2}, nil
+		},
+	}
+	w, c := createGinTestContext()
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", bytes.NewBuffer([]byte{}))
+	c.Set(authorizedProjectsKey, []string{"sockshop"})
+
+	handler := NewProjectHandler(projectManager, &fake.IEventSenderMock{}, config.EnvConfig{ProjectNameMaxSize: 200}, &fake.IRepositoryProvisionerMock{}, nil)
+	handler.GetAllProjects(c)
+
+	response := &apimodels.ExpandedProjects{}
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), response))
+	assert.Equal(t, []*apimodels.ExpandedProject{p1}, response.Projects)
+	assert.Equal(t, float64(1), response.TotalCount)
+}
+
 func TestGetProjectByName(t *testing.T) {
 	s1 := &apimodels.ExpandedStage{StageName: "s1"}
 	s2 := &apimodels.ExpandedStage{StageName: "s2"}
@@ -228,7 +250,7 @@ func TestGetProjectByName(t *testing.T) {
 				gin.Param{Key: "project", Value: "my-project"},
 			}
 
-			handler := NewProjectHandler(tt.fields.ProjectManager, tt.fields.EventSender, tt.fields.EnvConfig, tt.fields.RepositoryProvisioner)
+			handler := NewProjectHandler(tt.fields.ProjectManager, tt.fields.EventSender, tt.fields.EnvConfig, tt.fields.RepositoryProvisioner, nil)
 			c.Request, _ = http.NewRequest(http.MethodGet, "", bytes.NewBuffer([]byte{}))
 
 			handler.GetProjectByName(c)
@@ -443,7 +465,7 @@ func TestCreateProject(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			w, c := createGinTestContext()
 
-			handler := NewProjectHandler(tt.fields.ProjectManager, tt.fields.EventSender, tt.fields.EnvConfig, tt.fields.RepositoryProvisioner)
+			handler := NewProjectHandler(tt.fields.ProjectManager, tt.fields.EventSender, tt.fields.EnvConfig, tt.fields.RepositoryProvisioner, nil)
 			c.Request, _ = http.NewRequest(http.MethodPost, "", bytes.NewBuffer([]byte(tt.jsonPayload)))
 
 			handler.CreateProject(c)
@@ -639,7 +661,7 @@ func TestUpdateProject(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			w, c := createGinTestContext()
 
-			handler := NewProjectHandler(tt.fields.ProjectManager, tt.fields.EventSender, tt.fields.EnvConfig, tt.fields.RepositoryProvisioner)
+			handler := NewProjectHandler(tt.fields.ProjectManager, tt.fields.EventSender, tt.fields.EnvConfig, tt.fields.RepositoryProvisioner, nil)
 			c.Request, _ = http.NewRequest(http.MethodPut, "", bytes.NewBuffer([]byte(tt.jsonPayload)))
 
 			handler.UpdateProject(c)
@@ -776,7 +798,7 @@ func TestDeleteProject(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			w, c := createGinTestContext()
 
-			handler := NewProjectHandler(tt.fields.ProjectManager, tt.fields.EventSender, tt.fields.EnvConfig, tt.fields.RepositoryProvisioner)
+			handler := NewProjectHandler(tt.fields.ProjectManager, tt.fields.EventSender, tt.fields.EnvConfig, tt.fields.RepositoryProvisioner, nil)
 			c.Params = gin.Params{
 				gin.Param{Key: "project", Value: tt.projectPathParam},
 				gin.Param{Key: "namespace", Value: "keptn"},
@@ -908,6 +930,27 @@ func Test_ProjectValidator(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "pull request provider",
+			params: models.CreateProjectParams{
+				Shipyard:               &encodedShipyard,
+				Name:                   &projectName,
+				GitRemoteURL:           "https://some.url",
+				GitToken:               "token",
+				GitPullRequestProvider: "gitlab",
+			},
+			wantErr: false,
+		},
+		{
+			name: "pull request provider with ssh",
+			params: models.CreateProjectParams{
+				Shipyard:               &encodedShipyard,
+				Name:                   &projectName,
+				GitRemoteURL:           "ssh://some.url",
+				GitPullRequestProvider: "gitlab",
+			},
+			wantErr: true,
+		},
 		{
 			name: "Project Name too long",
 			params: models.CreateProjectParams{
@@ -975,6 +1018,16 @@ func Test_ProjectValidator_UpdateParams(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "unsupported pull request provider",
+			params: models.UpdateProjectParams{
+				Name:                   &projectName,
+				GitRemoteURL:           "https://some.url",
+				GitToken:               "token",
+				GitPullRequestProvider: "bitbucket",
+			},
+			wantErr: true,
+		},
 		{
 			name: "PrivateKey and Proxy",
 			params: models.UpdateProjectParams{
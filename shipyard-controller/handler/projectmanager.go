@@ -99,17 +99,18 @@ func (pm *ProjectManager) Create(params *models.CreateProjectParams) (error, com
 	decodedPemCertificate, _ := base64.StdEncoding.DecodeString(params.GitPemCertificate)
 
 	err = pm.updateGITRepositorySecret(*params.Name, &gitCredentials{
-		User:              params.GitUser,
-		Token:             params.GitToken,
-		RemoteURI:         params.GitRemoteURL,
-		GitPrivateKey:     string(decodedPrivateKey),
-		GitPrivateKeyPass: params.GitPrivateKeyPass,
-		GitProxyURL:       params.GitProxyURL,
-		GitProxyScheme:    params.GitProxyScheme,
-		GitProxyUser:      params.GitProxyUser,
-		GitProxyPassword:  params.GitProxyPassword,
-		GitPemCertificate: string(decodedPemCertificate),
-		InsecureSkipTLS:   params.InsecureSkipTLS,
+		User:                params.GitUser,
+		Token:               params.GitToken,
+		RemoteURI:           params.GitRemoteURL,
+		GitPrivateKey:       string(decodedPrivateKey),
+		GitPrivateKeyPass:   params.GitPrivateKeyPass,
+		GitProxyURL:         params.GitProxyURL,
+		GitProxyScheme:      params.GitProxyScheme,
+		GitProxyUser:        params.GitProxyUser,
+		GitProxyPassword:    params.GitProxyPassword,
+		GitPemCertificate:   string(decodedPemCertificate),
+		InsecureSkipTLS:     params.InsecureSkipTLS,
+		PullRequestProvider: params.GitPullRequestProvider,
 	})
 	if err != nil {
 		return err, nilRollback
@@ -190,17 +191,18 @@ func (pm *ProjectManager) Update(params *models.UpdateProjectParams) (error, com
 	}
 
 	rollbackSecretCredentials := &gitCredentials{
-		User:              oldSecret.User,
-		Token:             oldSecret.Token,
-		RemoteURI:         oldSecret.RemoteURI,
-		GitPrivateKey:     oldSecret.GitPrivateKey,
-		GitPrivateKeyPass: oldSecret.GitPrivateKeyPass,
-		GitProxyURL:       oldSecret.GitProxyURL,
-		GitProxyScheme:    oldSecret.GitProxyScheme,
-		GitProxyUser:      oldSecret.GitProxyUser,
-		GitProxyPassword:  oldSecret.GitProxyPassword,
-		GitPemCertificate: oldSecret.GitPemCertificate,
-		InsecureSkipTLS:   oldSecret.InsecureSkipTLS,
+		User:                oldSecret.User,
+		Token:               oldSecret.Token,
+		RemoteURI:           oldSecret.RemoteURI,
+		GitPrivateKey:       oldSecret.GitPrivateKey,
+		GitPrivateKeyPass:   oldSecret.GitPrivateKeyPass,
+		GitProxyURL:         oldSecret.GitProxyURL,
+		GitProxyScheme:      oldSecret.GitProxyScheme,
+		GitProxyUser:        oldSecret.GitProxyUser,
+		GitProxyPassword:    oldSecret.GitProxyPassword,
+		GitPemCertificate:   oldSecret.GitPemCertificate,
+		InsecureSkipTLS:     oldSecret.InsecureSkipTLS,
+		PullRequestProvider: oldSecret.PullRequestProvider,
 	}
 
 	// old project for rollback
@@ -219,17 +221,18 @@ func (pm *ProjectManager) Update(params *models.UpdateProjectParams) (error, com
 	if params.GitRemoteURL != "" {
 		// try to update git repository secret
 		err = pm.updateGITRepositorySecret(*params.Name, &gitCredentials{
-			User:              params.GitUser,
-			Token:             params.GitToken,
-			RemoteURI:         params.GitRemoteURL,
-			GitPrivateKey:     string(decodedPrivateKey),
-			GitPrivateKeyPass: params.GitPrivateKeyPass,
-			GitProxyURL:       params.GitProxyURL,
-			GitProxyScheme:    params.GitProxyScheme,
-			GitProxyUser:      params.GitProxyUser,
-			GitProxyPassword:  params.GitProxyPassword,
-			GitPemCertificate: string(decodedPemCertificate),
-			InsecureSkipTLS:   params.InsecureSkipTLS,
+			User:                params.GitUser,
+			Token:               params.GitToken,
+			RemoteURI:           params.GitRemoteURL,
+			GitPrivateKey:       string(decodedPrivateKey),
+			GitPrivateKeyPass:   params.GitPrivateKeyPass,
+			GitProxyURL:         params.GitProxyURL,
+			GitProxyScheme:      params.GitProxyScheme,
+			GitProxyUser:        params.GitProxyUser,
+			GitProxyPassword:    params.GitProxyPassword,
+			GitPemCertificate:   string(decodedPemCertificate),
+			InsecureSkipTLS:     params.InsecureSkipTLS,
+			PullRequestProvider: params.GitPullRequestProvider,
 		})
 
 		// no roll back needed since updating the git repository secret was the first operation
@@ -602,4 +605,6 @@ type gitCredentials struct {
 	GitProxyPassword  string `json:"gitProxyPassword,omitempty"`
 	GitPemCertificate string `json:"gitPemCertificate,omitempty"`
 	InsecureSkipTLS   bool   `json:"insecureSkipTLS,omitempty"`
+	// PullRequestProvider enables the pull request mode of the resource-service for the project
+	PullRequestProvider string `json:"pullRequestProvider,omitempty"`
 }
@@ -425,17 +425,21 @@ func TestCreate(t *testing.T) {
 
 	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
 	params := &models.CreateProjectParams{
-		GitRemoteURL:    "git-url",
-		GitToken:        "git-token",
-		GitUser:         "git-user",
-		GitProxyURL:     "some-url",
-		GitProxyScheme:  "http",
-		GitProxyUser:    "proxy-user",
-		InsecureSkipTLS: false,
-		Name:            common.Stringp("my-project"),
-		Shipyard:        common.Stringp(encodedShipyard),
+		GitRemoteURL:           "git-url",
+		GitToken:               "git-token",
+		GitUser:                "git-user",
+		GitProxyURL:            "some-url",
+		GitProxyScheme:         "http",
+		GitProxyUser:           "proxy-user",
+		GitPullRequestProvider: "github",
+		InsecureSkipTLS:        false,
+		Name:                   common.Stringp("my-project"),
+		Shipyard:               common.Stringp(encodedShipyard),
 	}
 	instance.Create(params)
+	storedCredentials := &gitCredentials{}
+	assert.Nil(t, json.Unmarshal(secretStore.UpdateSecretCalls()[0].Content["git-credentials"], storedCredentials))
+	assert.Equal(t, "github", storedCredentials.PullRequestProvider)
 	assert.Equal(t, 3, len(configStore.CreateStageCalls()))
 	assert.Equal(t, "my-project", configStore.CreateStageCalls()[0].ProjectName)
 	assert.Equal(t, "dev", configStore.CreateStageCalls()[0].Stage)
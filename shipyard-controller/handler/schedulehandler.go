@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/common"
+	"github.com/keptn/keptn/shipyard-controller/models"
+)
+
+type ScheduleParamsValidator struct{}
+
+func (s ScheduleParamsValidator) Validate(params interface{}) error {
+	switch t := params.(type) {
+	case *models.ScheduleParams:
+		return s.validateScheduleParams(t)
+	default:
+		return nil
+	}
+}
+
+func (s ScheduleParamsValidator) validateScheduleParams(params *models.ScheduleParams) error {
+	if params.Project == "" || params.Stage == "" || params.Service == "" || params.Sequence == "" {
+		return errors.New("project, stage, service and sequence must be specified")
+	}
+	if (params.Cron == "") == (params.RunAt == nil) {
+		return errors.New("either a cron expression or a point in time at which the sequence should be triggered must be specified")
+	}
+	if params.Cron != "" {
+		if _, err := common.ParseCronExpression(params.Cron); err != nil {
+			return err
+		}
+	}
+	if params.RunAt != nil && !params.RunAt.After(time.Now()) {
+		return errors.New("the point in time at which the sequence should be triggered must be in the future")
+	}
+	switch params.OverlapPolicy {
+	case "", models.ScheduleOverlapSkip, models.ScheduleOverlapQueue, models.ScheduleOverlapCancel:
+	default:
+		return fmt.Errorf("invalid overlap policy '%s', expected one of '%s', '%s' or '%s'", params.OverlapPolicy, models.ScheduleOverlapSkip, models.ScheduleOverlapQueue, models.ScheduleOverlapCancel)
+	}
+	return nil
+}
+
+type IScheduleHandler interface {
+	CreateSchedule(context *gin.Context)
+	GetSchedules(context *gin.Context)
+	GetSchedule(context *gin.Context)
+	UpdateSchedule(context *gin.Context)
+	DeleteSchedule(context *gin.Context)
+}
+
+type ScheduleHandler struct {
+	scheduleManager IScheduleManager
+}
+
+func NewScheduleHandler(scheduleManager IScheduleManager) *ScheduleHandler {
+	return &ScheduleHandler{
+		scheduleManager: scheduleManager,
+	}
+}
+
+// CreateSchedule godoc
+// @Summary      Create a schedule
+// @Description  Create a schedule that triggers a sequence for a service, either based on a cron expression, or once at a given point in time
+// @Tags         Schedule
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        schedule  body      models.ScheduleParams          true  "Schedule"
+// @Success      201       {object}  models.CreateScheduleResponse  "ok"
+// @Failure      400       {object}  models.Error                   "Invalid payload"
+// @Failure      404       {object}  models.Error                   "Not found"
+// @Failure      500       {object}  models.Error                   "Internal error"
+// @Router       /schedule [post]
+func (sh *ScheduleHandler) CreateSchedule(c *gin.Context) {
+	params := &models.ScheduleParams{}
+	if err := c.ShouldBindJSON(params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidRequestFormatMsg, err.Error()))
+		return
+	}
+	if err := (ScheduleParamsValidator{}).Validate(params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidPayloadMsg, err.Error()))
+		return
+	}
+
+	schedule, err := sh.scheduleManager.CreateSchedule(*params)
+	if err != nil {
+		setScheduleErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, &models.CreateScheduleResponse{ID: schedule.ID})
+}
+
+// GetSchedules godoc
+// @Summary      Get schedules
+// @Description  Get all schedules, optionally filtered by project, stage and service
+// @Tags         Schedule
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project  query     string                       false  "The name of the project"
+// @Param        stage    query     string                       false  "The name of the stage"
+// @Param        service  query     string                       false  "The name of the service"
+// @Success      200      {object}  models.GetSchedulesResponse  "ok"
+// @Failure      400      {object}  models.Error                 "Invalid payload"
+// @Failure      500      {object}  models.Error                 "Internal error"
+// @Router       /schedule [get]
+func (sh *ScheduleHandler) GetSchedules(c *gin.Context) {
+	params := &models.GetSchedulesParams{}
+	if err := c.ShouldBindQuery(params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidRequestFormatMsg, err.Error()))
+		return
+	}
+
+	schedules, err := sh.scheduleManager.GetSchedules(*params)
+	if err != nil {
+		SetInternalServerErrorResponse(c, fmt.Sprintf(UnableQuerySchedulesMsg, err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, &models.GetSchedulesResponse{Schedules: schedules})
+}
+
+// GetSchedule godoc
+// @Summary      Get a schedule
+// @Description  Get a schedule by its ID
+// @Tags         Schedule
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        scheduleId  path      string           true  "The ID of the schedule"
+// @Success      200         {object}  models.Schedule  "ok"
+// @Failure      404         {object}  models.Error     "Not found"
+// @Failure      500         {object}  models.Error     "Internal error"
+// @Router       /schedule/{scheduleId} [get]
+func (sh *ScheduleHandler) GetSchedule(c *gin.Context) {
+	schedule, err := sh.scheduleManager.GetSchedule(c.Param("scheduleId"))
+	if err != nil {
+		setScheduleErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, schedule)
+}
+
+// UpdateSchedule godoc
+// @Summary      Update a schedule
+// @Description  Update a schedule. The next run of the schedule is calculated based on the updated properties
+// @Tags         Schedule
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        scheduleId  path      string                 true  "The ID of the schedule"
+// @Param        schedule    body      models.ScheduleParams  true  "Schedule"
+// @Success      200         {object}  models.Schedule        "ok"
+// @Failure      400         {object}  models.Error           "Invalid payload"
+// @Failure      404         {object}  models.Error           "Not found"
+// @Failure      500         {object}  models.Error           "Internal error"
+// @Router       /schedule/{scheduleId} [put]
+func (sh *ScheduleHandler) UpdateSchedule(c *gin.Context) {
+	params := &models.ScheduleParams{}
+	if err := c.ShouldBindJSON(params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidRequestFormatMsg, err.Error()))
+		return
+	}
+	if err := (ScheduleParamsValidator{}).Validate(params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidPayloadMsg, err.Error()))
+		return
+	}
+
+	schedule, err := sh.scheduleManager.UpdateSchedule(c.Param("scheduleId"), *params)
+	if err != nil {
+		setScheduleErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, schedule)
+}
+
+// DeleteSchedule godoc
+// @Summary      Delete a schedule
+// @Description  Delete a schedule. Sequences that have already been triggered by the schedule are not affected
+// @Tags         Schedule
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        scheduleId  path      string                         true  "The ID of the schedule"
+// @Success      200         {object}  models.DeleteScheduleResponse  "ok"
+// @Failure      404         {object}  models.Error                   "Not found"
+// @Failure      500         {object}  models.Error                   "Internal error"
+// @Router       /schedule/{scheduleId} [delete]
+func (sh *ScheduleHandler) DeleteSchedule(c *gin.Context) {
+	if err := sh.scheduleManager.DeleteSchedule(c.Param("scheduleId")); err != nil {
+		setScheduleErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, &models.DeleteScheduleResponse{})
+}
+
+func setScheduleErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrScheduleNotFound), errors.Is(err, ErrProjectNotFound), errors.Is(err, ErrStageNotFound), errors.Is(err, ErrServiceNotFound):
+		SetNotFoundErrorResponse(c, err.Error())
+	case errors.Is(err, ErrInvalidSchedule):
+		SetBadRequestErrorResponse(c, err.Error())
+	default:
+		SetInternalServerErrorResponse(c, err.Error())
+	}
+}
@@ -0,0 +1,202 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+	"github.com/keptn/keptn/shipyard-controller/handler/fake"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleParamsValidator(t *testing.T) {
+	inOneHour := time.Now().Add(time.Hour)
+	oneHourAgo := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name    string
+		params  *models.ScheduleParams
+		wantErr bool
+	}{
+		{
+			name:    "valid cron schedule",
+			params:  &models.ScheduleParams{Project: "p", Stage: "s", Service: "svc", Sequence: "evaluation", Cron: "0 2 * * 1-5"},
+			wantErr: false,
+		},
+		{
+			name:    "valid one-time schedule",
+			params:  &models.ScheduleParams{Project: "p", Stage: "s", Service: "svc", Sequence: "evaluation", RunAt: &inOneHour, OverlapPolicy: models.ScheduleOverlapCancel},
+			wantErr: false,
+		},
+		{
+			name:    "missing service",
+			params:  &models.ScheduleParams{Project: "p", Stage: "s", Sequence: "evaluation", Cron: "0 2 * * *"},
+			wantErr: true,
+		},
+		{
+			name:    "neither cron nor runAt",
+			params:  &models.ScheduleParams{Project: "p", Stage: "s", Service: "svc", Sequence: "evaluation"},
+			wantErr: true,
+		},
+		{
+			name:    "both cron and runAt",
+			params:  &models.ScheduleParams{Project: "p", Stage: "s", Service: "svc", Sequence: "evaluation", Cron: "0 2 * * *", RunAt: &inOneHour},
+			wantErr: true,
+		},
+		{
+			name:    "invalid cron expression",
+			params:  &models.ScheduleParams{Project: "p", Stage: "s", Service: "svc", Sequence: "evaluation", Cron: "0 25 * * *"},
+			wantErr: true,
+		},
+		{
+			name:    "runAt in the past",
+			params:  &models.ScheduleParams{Project: "p", Stage: "s", Service: "svc", Sequence: "evaluation", RunAt: &oneHourAgo},
+			wantErr: true,
+		},
+		{
+			name:    "invalid overlap policy",
+			params:  &models.ScheduleParams{Project: "p", Stage: "s", Service: "svc", Sequence: "evaluation", Cron: "@daily", OverlapPolicy: "ignore"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := handler.ScheduleParamsValidator{}.Validate(tt.params)
+			require.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestScheduleHandler_CreateSchedule(t *testing.T) {
+	tests := []struct {
+		name            string
+		scheduleManager *fake.IScheduleManagerMock
+		payload         string
+		wantStatus      int
+	}{
+		{
+			name: "create schedule",
+			scheduleManager: &fake.IScheduleManagerMock{
+				CreateScheduleFunc: func(params models.ScheduleParams) (*models.Schedule, error) {
+					return &models.Schedule{ID: "my-schedule"}, nil
+				},
+			},
+			payload:    `{"project":"my-project","stage":"dev","service":"my-service","sequence":"evaluation","cron":"0 2 * * *"}`,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:            "invalid payload",
+			scheduleManager: &fake.IScheduleManagerMock{},
+			payload:         `{"project":"my-project","stage":"dev","service":"my-service","sequence":"evaluation"}`,
+			wantStatus:      http.StatusBadRequest,
+		},
+		{
+			name: "service not found",
+			scheduleManager: &fake.IScheduleManagerMock{
+				CreateScheduleFunc: func(params models.ScheduleParams) (*models.Schedule, error) {
+					return nil, handler.ErrServiceNotFound
+				},
+			},
+			payload:    `{"project":"my-project","stage":"dev","service":"my-service","sequence":"evaluation","cron":"0 2 * * *"}`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "sequence not available in stage",
+			scheduleManager: &fake.IScheduleManagerMock{
+				CreateScheduleFunc: func(params models.ScheduleParams) (*models.Schedule, error) {
+					return nil, handler.ErrInvalidSchedule
+				},
+			},
+			payload:    `{"project":"my-project","stage":"dev","service":"my-service","sequence":"evaluation","cron":"0 2 * * *"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "internal error",
+			scheduleManager: &fake.IScheduleManagerMock{
+				CreateScheduleFunc: func(params models.ScheduleParams) (*models.Schedule, error) {
+					return nil, errors.New("oops")
+				},
+			},
+			payload:    `{"project":"my-project","stage":"dev","service":"my-service","sequence":"evaluation","cron":"0 2 * * *"}`,
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sh := handler.NewScheduleHandler(tt.scheduleManager)
+
+			router := gin.Default()
+			router.POST("/schedule", sh.CreateSchedule)
+			w := performRequest(router, httptest.NewRequest(http.MethodPost, "/schedule", bytes.NewBufferString(tt.payload)))
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusCreated {
+				response := &models.CreateScheduleResponse{}
+				require.Nil(t, json.Unmarshal(w.Body.Bytes(), response))
+				require.Equal(t, "my-schedule", response.ID)
+				require.Equal(t, "0 2 * * *", tt.scheduleManager.CreateScheduleCalls()[0].Params.Cron)
+			}
+		})
+	}
+}
+
+func TestScheduleHandler_GetSchedules(t *testing.T) {
+	scheduleManager := &fake.IScheduleManagerMock{
+		GetSchedulesFunc: func(params models.GetSchedulesParams) ([]models.Schedule, error) {
+			return []models.Schedule{{ID: "my-schedule", Project: params.Project}}, nil
+		},
+	}
+	sh := handler.NewScheduleHandler(scheduleManager)
+
+	router := gin.Default()
+	router.GET("/schedule", sh.GetSchedules)
+	w := performRequest(router, httptest.NewRequest(http.MethodGet, "/schedule?project=my-project&stage=dev", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, models.GetSchedulesParams{Project: "my-project", Stage: "dev"}, scheduleManager.GetSchedulesCalls()[0].Params)
+
+	response := &models.GetSchedulesResponse{}
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), response))
+	require.Len(t, response.Schedules, 1)
+}
+
+func TestScheduleHandler_DeleteSchedule(t *testing.T) {
+	tests := []struct {
+		name       string
+		deleteErr  error
+		wantStatus int
+	}{
+		{
+			name:       "delete schedule",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "schedule not found",
+			deleteErr:  handler.ErrScheduleNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduleManager := &fake.IScheduleManagerMock{
+				DeleteScheduleFunc: func(id string) error {
+					return tt.deleteErr
+				},
+			}
+			sh := handler.NewScheduleHandler(scheduleManager)
+
+			router := gin.Default()
+			router.DELETE("/schedule/:scheduleId", sh.DeleteSchedule)
+			w := performRequest(router, httptest.NewRequest(http.MethodDelete, "/schedule/my-schedule", nil))
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			require.Equal(t, "my-schedule", scheduleManager.DeleteScheduleCalls()[0].Id)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/keptn/keptn/shipyard-controller/common"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+)
+
+//go:generate moq -pkg fake -skip-ensure -out ./fake/schedulemanager.go . IScheduleManager
+type IScheduleManager interface {
+	CreateSchedule(params models.ScheduleParams) (*models.Schedule, error)
+	GetSchedules(params models.GetSchedulesParams) ([]models.Schedule, error)
+	GetSchedule(id string) (*models.Schedule, error)
+	UpdateSchedule(id string, params models.ScheduleParams) (*models.Schedule, error)
+	DeleteSchedule(id string) error
+}
+
+type ScheduleManager struct {
+	scheduleRepo      db.ScheduleRepo
+	projectMVRepo     db.ProjectMVRepo
+	shipyardRetriever IShipyardRetriever
+}
+
+func NewScheduleManager(scheduleRepo db.ScheduleRepo, projectMVRepo db.ProjectMVRepo, shipyardRetriever IShipyardRetriever) *ScheduleManager {
+	return &ScheduleManager{
+		scheduleRepo:      scheduleRepo,
+		projectMVRepo:     projectMVRepo,
+		shipyardRetriever: shipyardRetriever,
+	}
+}
+
+func (sm *ScheduleManager) CreateSchedule(params models.ScheduleParams) (*models.Schedule, error) {
+	if err := sm.validateTarget(params); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	schedule := &models.Schedule{
+		ID:        uuid.New().String(),
+		CreatedAt: now,
+	}
+	applyScheduleParams(schedule, params)
+	schedule.NextRunAt = getNextScheduleRun(*schedule, now)
+
+	if err := sm.scheduleRepo.CreateSchedule(*schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (sm *ScheduleManager) GetSchedules(params models.GetSchedulesParams) ([]models.Schedule, error) {
+	return sm.scheduleRepo.GetSchedules(params)
+}
+
+func (sm *ScheduleManager) GetSchedule(id string) (*models.Schedule, error) {
+	schedule, err := sm.scheduleRepo.GetSchedule(id)
+	if err != nil {
+		if errors.Is(err, db.ErrScheduleNotFound) {
+			return nil, ErrScheduleNotFound
+		}
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (sm *ScheduleManager) UpdateSchedule(id string, params models.ScheduleParams) (*models.Schedule, error) {
+	schedule, err := sm.GetSchedule(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := sm.validateTarget(params); err != nil {
+		return nil, err
+	}
+
+	applyScheduleParams(schedule, params)
+	schedule.NextRunAt = getNextScheduleRun(*schedule, time.Now().UTC())
+
+	if err := sm.scheduleRepo.UpdateSchedule(*schedule); err != nil {
+		if errors.Is(err, db.ErrScheduleNotFound) {
+			return nil, ErrScheduleNotFound
+		}
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (sm *ScheduleManager) DeleteSchedule(id string) error {
+	if err := sm.scheduleRepo.DeleteSchedule(id); err != nil {
+		if errors.Is(err, db.ErrScheduleNotFound) {
+			return ErrScheduleNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// validateTarget checks whether the service the schedule refers to exists, and whether the sequence is defined in the given stage
+func (sm *ScheduleManager) validateTarget(params models.ScheduleParams) error {
+	if _, err := sm.projectMVRepo.GetService(params.Project, params.Stage, params.Service); err != nil {
+		if errors.Is(err, db.ErrProjectNotFound) {
+			return ErrProjectNotFound
+		} else if errors.Is(err, db.ErrStageNotFound) {
+			return ErrStageNotFound
+		} else if errors.Is(err, db.ErrServiceNotFound) {
+			return ErrServiceNotFound
+		}
+		return err
+	}
+
+	shipyard, err := sm.shipyardRetriever.GetCachedShipyard(params.Project)
+	if err != nil {
+		return err
+	}
+	if _, err := GetTaskSequenceInStage(params.Stage, params.Sequence, shipyard); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidSchedule, err.Error())
+	}
+	return nil
+}
+
+func applyScheduleParams(schedule *models.Schedule, params models.ScheduleParams) {
+	schedule.Project = params.Project
+	schedule.Stage = params.Stage
+	schedule.Service = params.Service
+	schedule.Sequence = params.Sequence
+	schedule.Cron = params.Cron
+	schedule.RunAt = nil
+	if params.RunAt != nil {
+		// the database only stores timestamps with millisecond precision
+		runAt := params.RunAt.UTC().Truncate(time.Millisecond)
+		schedule.RunAt = &runAt
+	}
+	schedule.OverlapPolicy = params.OverlapPolicy
+	if schedule.OverlapPolicy == "" {
+		schedule.OverlapPolicy = models.ScheduleOverlapSkip
+	}
+	schedule.Labels = params.Labels
+}
+
+// getNextScheduleRun returns the next point in time after the given one at which the schedule should be executed.
+// If the schedule should not be executed anymore, nil is returned
+func getNextScheduleRun(schedule models.Schedule, after time.Time) *time.Time {
+	if schedule.Cron != "" {
+		cronSchedule, err := common.ParseCronExpression(schedule.Cron)
+		if err != nil {
+			return nil
+		}
+		next := cronSchedule.Next(after.UTC())
+		if next.IsZero() {
+			return nil
+		}
+		return &next
+	}
+	if schedule.RunAt != nil && schedule.RunAt.After(after) {
+		runAt := *schedule.RunAt
+		return &runAt
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
+	"github.com/keptn/keptn/shipyard-controller/handler/fake"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func newScheduleManagerTestShipyard() *keptnv2.Shipyard {
+	return &keptnv2.Shipyard{
+		Spec: keptnv2.ShipyardSpec{
+			Stages: []keptnv2.Stage{
+				{
+					Name:      "dev",
+					Sequences: []keptnv2.Sequence{{Name: "evaluation", Tasks: []keptnv2.Task{{Name: "evaluation"}}}},
+				},
+			},
+		},
+	}
+}
+
+func TestScheduleManager_CreateSchedule(t *testing.T) {
+	scheduleRepo := &db_mock.ScheduleRepoMock{
+		CreateScheduleFunc: func(schedule models.Schedule) error {
+			return nil
+		},
+	}
+	projectMVRepo := &db_mock.ProjectMVRepoMock{
+		GetServiceFunc: func(projectName string, stageName string, serviceName string) (*apimodels.ExpandedService, error) {
+			return &apimodels.ExpandedService{ServiceName: serviceName}, nil
+		},
+	}
+	shipyardRetriever := &fake.IShipyardRetrieverMock{
+		GetCachedShipyardFunc: func(projectName string) (*keptnv2.Shipyard, error) {
+			return newScheduleManagerTestShipyard(), nil
+		},
+	}
+	sm := NewScheduleManager(scheduleRepo, projectMVRepo, shipyardRetriever)
+
+	schedule, err := sm.CreateSchedule(models.ScheduleParams{Project: "my-project", Stage: "dev", Service: "my-service", Sequence: "evaluation", Cron: "@hourly"})
+	require.Nil(t, err)
+	require.NotEmpty(t, schedule.ID)
+	require.Equal(t, models.ScheduleOverlapSkip, schedule.OverlapPolicy)
+	require.NotNil(t, schedule.NextRunAt)
+	require.True(t, schedule.NextRunAt.After(time.Now()))
+	require.Len(t, scheduleRepo.CreateScheduleCalls(), 1)
+
+	_, err = sm.CreateSchedule(models.ScheduleParams{Project: "my-project", Stage: "dev", Service: "my-service", Sequence: "delivery", Cron: "@hourly"})
+	require.ErrorIs(t, err, ErrInvalidSchedule)
+
+	projectMVRepo.GetServiceFunc = func(projectName string, stageName string, serviceName string) (*apimodels.ExpandedService, error) {
+		return nil, db.ErrServiceNotFound
+	}
+	_, err = sm.CreateSchedule(models.ScheduleParams{Project: "my-project", Stage: "dev", Service: "my-service", Sequence: "evaluation", Cron: "@hourly"})
+	require.ErrorIs(t, err, ErrServiceNotFound)
+	require.Len(t, scheduleRepo.CreateScheduleCalls(), 1)
+}
+
+func TestScheduleManager_DeleteSchedule_NotFound(t *testing.T) {
+	scheduleRepo := &db_mock.ScheduleRepoMock{
+		DeleteScheduleFunc: func(id string) error {
+			return db.ErrScheduleNotFound
+		},
+	}
+	sm := NewScheduleManager(scheduleRepo, nil, nil)
+
+	err := sm.DeleteSchedule("my-schedule")
+	require.ErrorIs(t, err, ErrScheduleNotFound)
+}
+
+func Test_getNextScheduleRun(t *testing.T) {
+	now := time.Date(2022, 3, 1, 10, 30, 0, 0, time.UTC)
+	inOneHour := now.Add(time.Hour)
+	oneHourAgo := now.Add(-time.Hour)
+	tomorrowAtTwo := time.Date(2022, 3, 2, 2, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		schedule models.Schedule
+		want     *time.Time
+	}{
+		{
+			name:     "cron schedule",
+			schedule: models.Schedule{Cron: "0 2 * * *"},
+			want:     &tomorrowAtTwo,
+		},
+		{
+			name:     "one-time schedule in the future",
+			schedule: models.Schedule{RunAt: &inOneHour},
+			want:     &inOneHour,
+		},
+		{
+			name:     "one-time schedule in the past",
+			schedule: models.Schedule{RunAt: &oneHourAgo},
+			want:     nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, getNextScheduleRun(tt.schedule, now))
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/google/uuid"
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/common"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// activeSequenceStates contains the states of sequences that have not been completed yet
+var activeSequenceStates = []string{
+	apimodels.SequenceTriggeredState,
+	apimodels.SequenceStartedState,
+	apimodels.SequenceWaitingState,
+	apimodels.SequenceWaitingForApprovalState,
+	apimodels.SequencePaused,
+}
+
+// Scheduler periodically checks for due schedules, and triggers their sequences.
+// The scheduler can run on every replica of the shipyard-controller, since each run of a schedule is claimed by exactly one of them
+type Scheduler struct {
+	scheduleRepo          db.ScheduleRepo
+	sequenceExecutionRepo db.SequenceExecutionRepo
+	eventSender           common.EventSender
+	shipyardController    IShipyardController
+	syncInterval          time.Duration
+	theClock              clock.Clock
+}
+
+// NewScheduler creates a new Scheduler
+func NewScheduler(scheduleRepo db.ScheduleRepo, sequenceExecutionRepo db.SequenceExecutionRepo, eventSender common.EventSender, shipyardController IShipyardController, syncInterval time.Duration, theClock clock.Clock) *Scheduler {
+	return &Scheduler{
+		scheduleRepo:          scheduleRepo,
+		sequenceExecutionRepo: sequenceExecutionRepo,
+		eventSender:           eventSender,
+		shipyardController:    shipyardController,
+		syncInterval:          syncInterval,
+		theClock:              theClock,
+	}
+}
+
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := s.theClock.Ticker(s.syncInterval)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				log.Info("cancelling Scheduler loop")
+				ticker.Stop()
+				return
+			case <-ticker.C:
+				log.Debugf("%.2f seconds have passed. Looking for due schedules", s.syncInterval.Seconds())
+				s.runDueSchedules()
+			}
+		}
+	}()
+}
+
+func (s *Scheduler) runDueSchedules() {
+	now := s.theClock.Now().UTC()
+	schedules, err := s.scheduleRepo.GetDueSchedules(now)
+	if err != nil {
+		log.WithError(err).Error("could not load due schedules")
+		return
+	}
+
+	for _, schedule := range schedules {
+		if err := s.runSchedule(schedule, now); err != nil {
+			log.WithError(err).Errorf("could not run schedule %s", schedule.ID)
+		}
+	}
+}
+
+func (s *Scheduler) runSchedule(schedule models.Schedule, now time.Time) error {
+	keptnContext := uuid.New().String()
+
+	// claiming the run makes sure that no other replica triggers the sequence for the same run of the schedule.
+	// Runs that have been missed, e.g. because no replica was available, are not caught up, i.e. the sequence is only triggered once
+	if err := s.scheduleRepo.ClaimScheduleRun(schedule, getNextScheduleRun(schedule, now), keptnContext); err != nil {
+		if errors.Is(err, db.ErrScheduleRunClaimed) {
+			log.Debugf("run of schedule %s has already been claimed", schedule.ID)
+			return nil
+		}
+		return err
+	}
+
+	activeSequences, err := s.sequenceExecutionRepo.Get(models.SequenceExecutionFilter{
+		Scope: models.EventScope{
+			EventData: keptnv2.EventData{
+				Project: schedule.Project,
+				Stage:   schedule.Stage,
+				Service: schedule.Service,
+			},
+		},
+		Name:   schedule.Sequence,
+		Status: activeSequenceStates,
+	})
+	if err != nil {
+		return fmt.Errorf("could not check for active sequences: %w", err)
+	}
+
+	if len(activeSequences) > 0 {
+		switch schedule.OverlapPolicy {
+		case models.ScheduleOverlapQueue:
+			// the triggered sequence will be queued by the sequence dispatcher until the active one has been finished
+			log.Infof("sequence %s triggered by schedule %s will be queued, since it is still active in stage %s", schedule.Sequence, schedule.ID, schedule.Stage)
+		case models.ScheduleOverlapCancel:
+			for _, activeSequence := range activeSequences {
+				log.Infof("aborting sequence with keptnContext %s before triggering it again for schedule %s", activeSequence.Scope.KeptnContext, schedule.ID)
+				err := s.shipyardController.ControlSequence(apimodels.SequenceControl{
+					State:        apimodels.AbortSequence,
+					KeptnContext: activeSequence.Scope.KeptnContext,
+					Stage:        activeSequence.Scope.Stage,
+					Project:      activeSequence.Scope.Project,
+				})
+				if err != nil {
+					return fmt.Errorf("could not abort sequence with keptnContext %s: %w", activeSequence.Scope.KeptnContext, err)
+				}
+			}
+		default:
+			log.Infof("skipping run of schedule %s, since sequence %s is still active in stage %s", schedule.ID, schedule.Sequence, schedule.Stage)
+			return nil
+		}
+	}
+
+	eventData := keptnv2.EventData{
+		Project: schedule.Project,
+		Stage:   schedule.Stage,
+		Service: schedule.Service,
+		Labels:  schedule.Labels,
+	}
+	event := common.CreateEventWithPayload(keptnContext, "", keptnv2.GetTriggeredEventType(schedule.Stage+"."+schedule.Sequence), eventData)
+
+	log.Infof("triggering sequence %s in stage %s for schedule %s with keptnContext %s", schedule.Sequence, schedule.Stage, schedule.ID, keptnContext)
+	return s.eventSender.SendEvent(event)
+}
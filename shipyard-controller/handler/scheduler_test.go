@@ -0,0 +1,197 @@
+package handler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/cloudevents/sdk-go/v2/event"
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+	"github.com/keptn/keptn/shipyard-controller/handler/fake"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler(t *testing.T) {
+	tests := []struct {
+		name             string
+		overlapPolicy    string
+		activeSequences  []models.SequenceExecution
+		claimErr         error
+		wantEvent        bool
+		wantAbortedCalls int
+		wantNextRunAtNil bool
+		cron             string
+	}{
+		{
+			name:          "trigger sequence of due cron schedule",
+			overlapPolicy: models.ScheduleOverlapSkip,
+			cron:          "0 2 * * *",
+			wantEvent:     true,
+		},
+		{
+			name:             "trigger sequence of one-time schedule",
+			overlapPolicy:    models.ScheduleOverlapSkip,
+			wantEvent:        true,
+			wantNextRunAtNil: true,
+		},
+		{
+			name:            "skip run if sequence is still active",
+			overlapPolicy:   models.ScheduleOverlapSkip,
+			cron:            "0 2 * * *",
+			activeSequences: []models.SequenceExecution{newActiveSequence("my-active-context")},
+			wantEvent:       false,
+		},
+		{
+			name:            "queue run if sequence is still active",
+			overlapPolicy:   models.ScheduleOverlapQueue,
+			cron:            "0 2 * * *",
+			activeSequences: []models.SequenceExecution{newActiveSequence("my-active-context")},
+			wantEvent:       true,
+		},
+		{
+			name:             "cancel active sequence before triggering it again",
+			overlapPolicy:    models.ScheduleOverlapCancel,
+			cron:             "0 2 * * *",
+			activeSequences:  []models.SequenceExecution{newActiveSequence("my-active-context")},
+			wantEvent:        true,
+			wantAbortedCalls: 1,
+		},
+		{
+			name:          "do not trigger sequence if run has been claimed by another replica",
+			overlapPolicy: models.ScheduleOverlapSkip,
+			cron:          "0 2 * * *",
+			claimErr:      db.ErrScheduleRunClaimed,
+			wantEvent:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theClock := clock.NewMock()
+			theClock.Set(time.Date(2022, 3, 1, 2, 0, 0, 0, time.UTC))
+
+			dueAt := theClock.Now().UTC()
+			schedule := models.Schedule{
+				ID:            "my-schedule",
+				Project:       "my-project",
+				Stage:         "dev",
+				Service:       "my-service",
+				Sequence:      "evaluation",
+				Cron:          tt.cron,
+				OverlapPolicy: tt.overlapPolicy,
+				Labels:        map[string]string{"foo": "bar"},
+				NextRunAt:     &dueAt,
+			}
+			if tt.cron == "" {
+				schedule.RunAt = &dueAt
+			}
+
+			scheduleRepo := &db_mock.ScheduleRepoMock{
+				GetDueSchedulesFunc: func(dueAt time.Time) ([]models.Schedule, error) {
+					return []models.Schedule{schedule}, nil
+				},
+				ClaimScheduleRunFunc: func(schedule models.Schedule, nextRunAt *time.Time, keptnContext string) error {
+					return tt.claimErr
+				},
+			}
+
+			sequenceExecutionRepo := &db_mock.SequenceExecutionRepoMock{
+				GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+					require.Equal(t, "my-project", filter.Scope.Project)
+					require.Equal(t, "dev", filter.Scope.Stage)
+					require.Equal(t, "my-service", filter.Scope.Service)
+					require.Equal(t, "evaluation", filter.Name)
+					return tt.activeSequences, nil
+				},
+			}
+
+			eventSender := &fake.IEventSenderMock{
+				SendEventFunc: func(eventMoqParam event.Event) error {
+					return nil
+				},
+			}
+
+			shipyardController := &fake.IShipyardControllerMock{
+				ControlSequenceFunc: func(controlSequence apimodels.SequenceControl) error {
+					return nil
+				},
+			}
+
+			scheduler := handler.NewScheduler(scheduleRepo, sequenceExecutionRepo, eventSender, shipyardController, 10*time.Second, theClock)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			scheduler.Run(ctx)
+
+			// give the scheduler's goroutine time to start listening on the ticker
+			time.Sleep(100 * time.Millisecond)
+			theClock.Add(10 * time.Second)
+
+			if tt.claimErr != nil {
+				require.Eventually(t, func() bool {
+					return len(scheduleRepo.ClaimScheduleRunCalls()) == 1
+				}, 5*time.Second, 10*time.Millisecond)
+				require.Never(t, func() bool {
+					return len(eventSender.SendEventCalls()) > 0
+				}, 500*time.Millisecond, 10*time.Millisecond)
+				return
+			}
+
+			require.Eventually(t, func() bool {
+				return len(sequenceExecutionRepo.GetCalls()) == 1
+			}, 5*time.Second, 10*time.Millisecond)
+
+			claimCall := scheduleRepo.ClaimScheduleRunCalls()[0]
+			require.Equal(t, tt.wantNextRunAtNil, claimCall.NextRunAt == nil)
+			if !tt.wantNextRunAtNil {
+				require.Equal(t, dueAt.Add(24*time.Hour), *claimCall.NextRunAt)
+			}
+
+			if !tt.wantEvent {
+				require.Never(t, func() bool {
+					return len(eventSender.SendEventCalls()) > 0
+				}, 500*time.Millisecond, 10*time.Millisecond)
+				return
+			}
+
+			require.Eventually(t, func() bool {
+				return len(eventSender.SendEventCalls()) == 1
+			}, 5*time.Second, 10*time.Millisecond)
+
+			sentEvent := eventSender.SendEventCalls()[0].EventMoqParam
+			require.Equal(t, keptnv2.GetTriggeredEventType("dev.evaluation"), sentEvent.Type())
+			require.Equal(t, claimCall.KeptnContext, sentEvent.Extensions()["shkeptncontext"])
+
+			eventData := &keptnv2.EventData{}
+			require.Nil(t, sentEvent.DataAs(eventData))
+			require.Equal(t, "my-project", eventData.Project)
+			require.Equal(t, "dev", eventData.Stage)
+			require.Equal(t, "my-service", eventData.Service)
+			require.Equal(t, map[string]string{"foo": "bar"}, eventData.Labels)
+
+			require.Len(t, shipyardController.ControlSequenceCalls(), tt.wantAbortedCalls)
+			if tt.wantAbortedCalls > 0 {
+				require.Equal(t, apimodels.AbortSequence, shipyardController.ControlSequenceCalls()[0].ControlSequence.State)
+				require.Equal(t, "my-active-context", shipyardController.ControlSequenceCalls()[0].ControlSequence.KeptnContext)
+			}
+		})
+	}
+}
+
+func newActiveSequence(keptnContext string) models.SequenceExecution {
+	return models.SequenceExecution{
+		Scope: models.EventScope{
+			EventData: keptnv2.EventData{
+				Project: "my-project",
+				Stage:   "dev",
+				Service: "my-service",
+			},
+			KeptnContext: keptnContext,
+		},
+	}
+}
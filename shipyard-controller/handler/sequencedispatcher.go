@@ -13,6 +13,7 @@ import (
 	"github.com/benbjohnson/clock"
 	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/metrics"
 	"github.com/keptn/keptn/shipyard-controller/models"
 	log "github.com/sirupsen/logrus"
 )
@@ -30,12 +31,16 @@ type SequenceDispatcher struct {
 	eventRepo             db.EventRepo
 	sequenceQueue         db.SequenceQueueRepo
 	sequenceExecutionRepo db.SequenceExecutionRepo
+	shipyardRetriever     IShipyardRetriever
+	concurrencyLimiter    IConcurrencyLimiter
 	theClock              clock.Clock
 	syncInterval          time.Duration
 	startSequenceFunc     func(event apimodels.KeptnContextExtendedCE) error
 	shipyardController    shipyardController
 	ticker                *clock.Ticker
 	mode                  common.SDMode
+	locker                ILocker
+	claimOptions          common.ClaimOptions
 }
 
 // NewSequenceDispatcher creates a new SequenceDispatcher
@@ -43,34 +48,41 @@ func NewSequenceDispatcher(
 	eventRepo db.EventRepo,
 	sequenceQueueRepo db.SequenceQueueRepo,
 	sequenceExecutionRepo db.SequenceExecutionRepo,
+	shipyardRetriever IShipyardRetriever,
+	concurrencyLimiter IConcurrencyLimiter,
 	syncInterval time.Duration,
 	theClock clock.Clock,
 	mode common.SDMode,
+	locker ILocker,
+	claimOptions common.ClaimOptions,
 ) ISequenceDispatcher {
 	return &SequenceDispatcher{
 		eventRepo:             eventRepo,
 		sequenceQueue:         sequenceQueueRepo,
 		sequenceExecutionRepo: sequenceExecutionRepo,
+		shipyardRetriever:     shipyardRetriever,
+		concurrencyLimiter:    concurrencyLimiter,
 		theClock:              theClock,
 		syncInterval:          syncInterval,
 		mode:                  mode,
+		locker:                locker,
+		claimOptions:          claimOptions,
 	}
 }
 
 func (sd *SequenceDispatcher) Add(queueItem models.QueueItem) error {
-	if sd.mode == common.SDModeRW {
-		//if there is only one shipyard we can both read and write,
-		//so we try to dispatch the sequence immediately
+	if sd.mode == common.SDModeRW || sd.mode == common.SDModePartitioned {
+		//if there is only one shipyard we can both read and write, so we try to dispatch the sequence immediately
 		if err := sd.dispatchSequence(queueItem); err != nil {
 			if errors.Is(err, ErrSequenceBlocked) {
 				//if the sequence is currently blocked, insert it into the queue
 				return sd.add(queueItem)
-			} else if errors.Is(err, ErrSequenceBlockedWaiting) {
+			} else if errors.Is(err, ErrSequenceBlockedWaiting) || errors.Is(err, ErrSequenceFrozen) || errors.Is(err, ErrConcurrencyLimitReached) {
 				//if the sequence is currently blocked and should wait, insert it into the queue
 				if err2 := sd.add(queueItem); err2 != nil {
 					return err2
 				}
-				return ErrSequenceBlockedWaiting
+				return err
 			} else {
 				return err
 			}
@@ -101,21 +113,34 @@ func (sd *SequenceDispatcher) Run(ctx context.Context, mode common.SDMode, start
 	sd.mode = mode
 	sd.ticker = sd.theClock.Ticker(sd.syncInterval)
 	sd.startSequenceFunc = startSequenceFunc
+
+	// in partitioned mode, the claims on the queued sequences need to be renewed regularly.
+	// otherwise, they will expire and be taken over by other replicas
+	heartbeat := newHeartbeat(sd.theClock, mode, sd.claimOptions)
 	go func() {
+		defer heartbeat.Stop()
 		for {
 			select {
 			case <-ctx.Done():
 				log.Info("Cancelling sequence dispatcher loop")
+				sd.releaseClaims()
 				return
+			case <-heartbeat.C:
+				if err := sd.sequenceQueue.RenewSequenceClaims(sd.claimOptions.Owner, sd.claimOptions.TTL); err != nil {
+					log.WithError(err).Error("Could not renew claims on queued sequences")
+				}
 			case <-sd.ticker.C:
 				log.Debugf("%.2f seconds have passed. Dispatching sequences", sd.syncInterval.Seconds())
+				startedAt := time.Now()
 				sd.dispatchSequences()
+				metrics.ObserveDispatcherLoop(metrics.SequenceDispatcherName, startedAt)
 			}
 		}
 	}()
 }
 
 func (sd *SequenceDispatcher) Stop() {
+	sd.releaseClaims()
 	// as soon as a new leader is elected dispatcher should only write
 	sd.mode = common.SDModeW
 	if sd.ticker == nil {
@@ -124,8 +149,25 @@ func (sd *SequenceDispatcher) Stop() {
 	sd.ticker.Stop()
 }
 
+// releaseClaims makes the sequences claimed by this replica available to the other replicas again
+func (sd *SequenceDispatcher) releaseClaims() {
+	if sd.mode != common.SDModePartitioned {
+		return
+	}
+	if err := sd.sequenceQueue.ReleaseClaimedSequences(sd.claimOptions.Owner); err != nil {
+		log.WithError(err).Error("Could not release claims on queued sequences")
+	}
+}
+
+func (sd *SequenceDispatcher) getQueuedSequences() ([]models.QueueItem, error) {
+	if sd.mode == common.SDModePartitioned {
+		return sd.sequenceQueue.ClaimQueuedSequences(sd.claimOptions.Owner, sd.claimOptions.TTL, sd.claimOptions.BatchSize)
+	}
+	return sd.sequenceQueue.GetQueuedSequences()
+}
+
 func (sd *SequenceDispatcher) dispatchSequences() {
-	queuedSequences, err := sd.sequenceQueue.GetQueuedSequences()
+	queuedSequences, err := sd.getQueuedSequences()
 	if err != nil {
 		if errors.Is(err, db.ErrNoEventFound) {
 			// if no sequences are in the queue, we can return here
@@ -137,7 +179,9 @@ func (sd *SequenceDispatcher) dispatchSequences() {
 
 	for _, queuedSequence := range queuedSequences {
 		if err := sd.dispatchSequence(queuedSequence); err != nil {
-			if errors.Is(err, ErrSequenceBlocked) || errors.Is(err, ErrSequenceBlockedWaiting) {
+			if errors.Is(err, ErrSequenceFrozen) || errors.Is(err, ErrConcurrencyLimitReached) {
+				log.Infof("Could not dispatch sequence with keptnContext %s: %v", queuedSequence.Scope.KeptnContext, err)
+			} else if errors.Is(err, ErrSequenceBlocked) || errors.Is(err, ErrSequenceBlockedWaiting) {
 				log.Infof("Could not dispatch sequence with keptnContext %s. Sequence is currently blocked by other sequence", queuedSequence.Scope.KeptnContext)
 			} else {
 				log.WithError(err).Errorf("Could not dispatch sequence with keptnContext %s", queuedSequence.Scope.KeptnContext)
@@ -185,6 +229,9 @@ func (sd *SequenceDispatcher) isSequenceBlocked(queueItem models.QueueItem) (boo
 		return true, err
 	}
 
+	// sequences with a lower priority do not block the sequence, even if they have been triggered before
+	triggeredSequenceExecutions = filterSequencesWithMinPriority(triggeredSequenceExecutions, queueItem.Priority)
+
 	if len(triggeredSequenceExecutions) == 1 {
 		if triggeredSequenceExecutions[0].Scope.KeptnContext != queueItem.Scope.KeptnContext {
 			log.Infof("Sequence with KeptnContext %s is blocked due to triggered sequence with KeptnContext %s in stage %s", queueItem.Scope.KeptnContext, triggeredSequenceExecutions[0].Scope.KeptnContext, queueItem.Scope.Stage)
@@ -200,7 +247,53 @@ func (sd *SequenceDispatcher) isSequenceBlocked(queueItem models.QueueItem) (boo
 	return false, nil
 }
 
+func filterSequencesWithMinPriority(sequenceExecutions []models.SequenceExecution, minPriority int) []models.SequenceExecution {
+	result := []models.SequenceExecution{}
+	for _, sequenceExecution := range sequenceExecutions {
+		if sequenceExecution.Priority >= minPriority {
+			result = append(result, sequenceExecution)
+		}
+	}
+	return result
+}
+
+// checkFreezeWindows returns an error wrapping ErrSequenceFrozen if a freeze window affecting the sequence is currently active in its stage.
+// Sequences whose freeze has been overridden are not affected by freeze windows
+func (sd *SequenceDispatcher) checkFreezeWindows(sequenceExecution models.SequenceExecution) error {
+	if sd.shipyardRetriever == nil || sequenceExecution.Status.FreezeOverridden {
+		return nil
+	}
+	freezeWindow, err := getActiveFreezeWindow(sd.shipyardRetriever, sequenceExecution.Scope.Project, sequenceExecution.Scope.Stage, sequenceExecution.Sequence.Name, sd.theClock.Now())
+	if err != nil {
+		// do not block the sequence if the freeze windows cannot be determined
+		log.WithError(err).Errorf("Could not determine freeze windows of stage %s in project %s", sequenceExecution.Scope.Stage, sequenceExecution.Scope.Project)
+		return nil
+	}
+	if freezeWindow == nil {
+		return nil
+	}
+	// sequences that have already been queued before a rejecting freeze window became active are kept in the queue as well,
+	// since they have been accepted already
+	return fmt.Errorf("%w: %s", ErrSequenceFrozen, getFreezeWindowMessage(*freezeWindow, sequenceExecution.Scope.Stage))
+}
+
 func (sd *SequenceDispatcher) dispatchSequence(queueItem models.QueueItem) error {
+	// make sure that no other replica dispatches a sequence for the same service in the same stage at the same time
+	err := withLock(sd.locker, sequenceDispatchLockKey(queueItem.Scope), func(ctx context.Context) error {
+		return sd.dispatchSequenceUnlocked(ctx, queueItem)
+	})
+	if errors.Is(err, db.ErrLockHeld) {
+		log.Infof("Sequence %s cannot be dispatched right now because another sequence is being dispatched for the same service, or within the same concurrency limits", queueItem.Scope.KeptnContext)
+		return ErrSequenceBlocked
+	}
+	if errors.Is(err, db.ErrLockLost) {
+		log.Infof("Sequence %s has not been dispatched because a lock required for dispatching it has been lost", queueItem.Scope.KeptnContext)
+		return ErrSequenceBlocked
+	}
+	return err
+}
+
+func (sd *SequenceDispatcher) dispatchSequenceUnlocked(ctx context.Context, queueItem models.QueueItem) error {
 	// first, check if the sequence is currently paused
 	sequenceExecution, err := sd.sequenceExecutionRepo.GetByTriggeredID(queueItem.Scope.Project, queueItem.EventID)
 	if err != nil {
@@ -216,6 +309,10 @@ func (sd *SequenceDispatcher) dispatchSequence(queueItem models.QueueItem) error
 		return ErrSequenceBlocked
 	}
 
+	if err := sd.checkFreezeWindows(*sequenceExecution); err != nil {
+		return err
+	}
+
 	sequenceBlocked, err := sd.isSequenceBlocked(queueItem)
 	if err != nil {
 		return err
@@ -239,6 +336,21 @@ func (sd *SequenceDispatcher) dispatchSequence(queueItem models.QueueItem) error
 
 	sequenceTriggeredEvent := events[0]
 
+	if sd.concurrencyLimiter == nil {
+		return sd.startSequence(ctx, queueItem, sequenceTriggeredEvent)
+	}
+	// the sequence is started while holding the locks of its concurrency limits, so that the sequences dispatched for other services
+	// cannot take the same free slots before the sequence has been marked as started
+	return sd.concurrencyLimiter.RunWithinLimits(ctx, *sequenceExecution, func(ctx context.Context) error {
+		return sd.startSequence(ctx, queueItem, sequenceTriggeredEvent)
+	})
+}
+
+func (sd *SequenceDispatcher) startSequence(ctx context.Context, queueItem models.QueueItem, sequenceTriggeredEvent apimodels.KeptnContextExtendedCE) error {
+	if err := checkLockHeld(ctx); err != nil {
+		return err
+	}
+
 	if err := sd.startSequenceFunc(sequenceTriggeredEvent); err != nil {
 		return fmt.Errorf("could not start task sequence %s: %s", queueItem.EventID, err.Error())
 	}
@@ -2,6 +2,7 @@ package handler_test
 
 import (
 	"context"
+	"fmt"
 	"errors"
 	"testing"
 	"time"
@@ -10,8 +11,10 @@ import (
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	"github.com/keptn/keptn/shipyard-controller/common"
+	"github.com/keptn/keptn/shipyard-controller/db"
 	dbmock "github.com/keptn/keptn/shipyard-controller/db/mock"
 	"github.com/keptn/keptn/shipyard-controller/handler"
+	"github.com/keptn/keptn/shipyard-controller/handler/fake"
 	"github.com/keptn/keptn/shipyard-controller/models"
 	"github.com/stretchr/testify/require"
 )
@@ -78,7 +81,7 @@ func TestSequenceDispatcher(t *testing.T) {
 		},
 	}
 
-	sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockSequenceQueueRepo, mockSequenceExecutionRepo, 10*time.Second, theClock, common.SDModeRW)
+	sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockSequenceQueueRepo, mockSequenceExecutionRepo, nil, nil, 10*time.Second, theClock, common.SDModeRW, nil, common.ClaimOptions{})
 
 	sequenceDispatcher.Run(context.Background(), common.SDModeRW, func(event apimodels.KeptnContextExtendedCE) error {
 		startSequenceCalls = append(startSequenceCalls, event)
@@ -236,7 +239,7 @@ func TestSequenceDispatcher_Remove(t *testing.T) {
 		},
 	}
 
-	sequenceDispatcher := handler.NewSequenceDispatcher(nil, mockSequenceQueueRepo, nil, 10*time.Second, nil, common.SDModeRW)
+	sequenceDispatcher := handler.NewSequenceDispatcher(nil, mockSequenceQueueRepo, nil, nil, nil, 10*time.Second, nil, common.SDModeRW, nil, common.ClaimOptions{})
 
 	myScope := models.EventScope{
 		EventData:    keptnv2.EventData{Project: "my-project"},
@@ -326,7 +329,7 @@ func TestSequenceDispatcher_AddError(t *testing.T) {
 		},
 	}
 
-	sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockSequenceQueueRepo, mockSequenceExecutionRepo, 10*time.Second, theClock, common.SDModeRW)
+	sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockSequenceQueueRepo, mockSequenceExecutionRepo, nil, nil, 10*time.Second, theClock, common.SDModeRW, nil, common.ClaimOptions{})
 
 	sequenceDispatcher.Run(context.Background(), common.SDModeRW, func(event apimodels.KeptnContextExtendedCE) error {
 		startSequenceCalls = append(startSequenceCalls, event)
@@ -421,7 +424,7 @@ func TestSequenceDispatcher_QueueIsNotEmpty(t *testing.T) {
 		},
 	}
 
-	sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockSequenceQueueRepo, mockSequenceExecutionRepo, 10*time.Second, theClock, common.SDModeRW)
+	sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockSequenceQueueRepo, mockSequenceExecutionRepo, nil, nil, 10*time.Second, theClock, common.SDModeRW, nil, common.ClaimOptions{})
 
 	sequenceDispatcher.Run(context.Background(), common.SDModeRW, func(event apimodels.KeptnContextExtendedCE) error {
 		startSequenceCalls = append(startSequenceCalls, event)
@@ -617,3 +620,490 @@ func getQueueItem(id string) models.QueueItem {
 		EventID: id,
 	}
 }
+
+func TestSequenceDispatcher_AddWhileLockIsHeldByOtherReplica(t *testing.T) {
+	mockSequenceQueueRepo := &dbmock.SequenceQueueRepoMock{
+		QueueSequenceFunc: func(item models.QueueItem) error {
+			return nil
+		},
+	}
+
+	mockLocker := &fake.ILockerMock{
+		TryLockFunc: func(key string) (*models.Lock, context.Context, error) {
+			return nil, nil, db.ErrLockHeld
+		},
+	}
+
+	sequenceDispatcher := handler.NewSequenceDispatcher(nil, mockSequenceQueueRepo, nil, nil, nil, 10*time.Second, clock.NewMock(), common.SDModeRW, mockLocker, common.ClaimOptions{})
+
+	queueItem := models.QueueItem{
+		Scope: models.EventScope{
+			EventData: keptnv2.EventData{
+				Project: "my-project",
+				Stage:   "my-stage",
+				Service: "my-service",
+			},
+			KeptnContext: "my-context-id",
+		},
+		EventID:   "my-event-id",
+		Timestamp: time.Now().UTC(),
+	}
+	err := sequenceDispatcher.Add(queueItem)
+	require.Nil(t, err)
+
+	// the dispatcher should have tried to dispatch the sequence immediately
+	require.Len(t, mockLocker.TryLockCalls(), 1)
+	require.Equal(t, "sequence-dispatcher.my-project.my-stage.my-service", mockLocker.TryLockCalls()[0].Key)
+
+	// since the lock is held by another replica, the sequence should be queued
+	require.Len(t, mockSequenceQueueRepo.QueueSequenceCalls(), 1)
+	require.Equal(t, queueItem, mockSequenceQueueRepo.QueueSequenceCalls()[0].Item)
+}
+
+func TestSequenceDispatcher_AddInWriteModeWithLocker(t *testing.T) {
+	mockSequenceQueueRepo := &dbmock.SequenceQueueRepoMock{
+		QueueSequenceFunc: func(item models.QueueItem) error {
+			return nil
+		},
+	}
+
+	mockLocker := &fake.ILockerMock{}
+
+	sequenceDispatcher := handler.NewSequenceDispatcher(nil, mockSequenceQueueRepo, nil, nil, nil, 10*time.Second, clock.NewMock(), common.SDModeW, mockLocker, common.ClaimOptions{})
+
+	err := sequenceDispatcher.Add(getQueueItem("my-context-id"))
+	require.Nil(t, err)
+
+	// a replica in write mode should only queue the sequence, even if a locker is configured
+	require.Empty(t, mockLocker.TryLockCalls())
+	require.Len(t, mockSequenceQueueRepo.QueueSequenceCalls(), 1)
+}
+
+func TestSequenceDispatcher_DispatchReleasesLock(t *testing.T) {
+	mockEventRepo := &dbmock.EventRepoMock{
+		GetEventsFunc: func(project string, filter common.EventFilter, status ...common.EventStatus) ([]apimodels.KeptnContextExtendedCE, error) {
+			return []apimodels.KeptnContextExtendedCE{{ID: "my-event-id"}}, nil
+		},
+	}
+	mockSequenceQueueRepo := &dbmock.SequenceQueueRepoMock{
+		DeleteQueuedSequencesFunc: func(itemFilter models.QueueItem) error {
+			return nil
+		},
+	}
+	mockSequenceExecutionRepo := &dbmock.SequenceExecutionRepoMock{
+		GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+			return nil, nil
+		},
+		GetByTriggeredIDFunc: func(project string, triggeredID string) (*models.SequenceExecution, error) {
+			return &models.SequenceExecution{
+				ID: "my-id",
+				Status: models.SequenceExecutionStatus{
+					State: apimodels.SequenceTriggeredState,
+				},
+			}, nil
+		},
+		IsContextPausedFunc: func(eventScope models.EventScope) bool {
+			return false
+		},
+	}
+	mockLocker := &fake.ILockerMock{
+		TryLockFunc: func(key string) (*models.Lock, context.Context, error) {
+			return &models.Lock{Key: key, Token: 1}, context.Background(), nil
+		},
+		UnlockFunc: func(lock models.Lock) error {
+			return nil
+		},
+	}
+
+	sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockSequenceQueueRepo, mockSequenceExecutionRepo, nil, nil, 10*time.Second, clock.NewMock(), common.SDModeRW, mockLocker, common.ClaimOptions{})
+
+	startSequenceCalls := 0
+	sequenceDispatcher.Run(context.Background(), common.SDModeRW, func(event apimodels.KeptnContextExtendedCE) error {
+		startSequenceCalls++
+		return nil
+	})
+
+	err := sequenceDispatcher.Add(models.QueueItem{
+		Scope: models.EventScope{
+			EventData: keptnv2.EventData{
+				Project: "my-project",
+				Stage:   "my-stage",
+				Service: "my-service",
+			},
+			KeptnContext: "my-context-id",
+		},
+		EventID: "my-event-id",
+	})
+	require.Nil(t, err)
+
+	require.Equal(t, 1, startSequenceCalls)
+	require.Len(t, mockLocker.TryLockCalls(), 1)
+	require.Len(t, mockLocker.UnlockCalls(), 1)
+	require.Equal(t, int64(1), mockLocker.UnlockCalls()[0].Lock.Token)
+}
+
+func TestSequenceDispatcher_DoesNotStartSequenceIfLockIsLost(t *testing.T) {
+	mockEventRepo := &dbmock.EventRepoMock{
+		GetEventsFunc: func(project string, filter common.EventFilter, status ...common.EventStatus) ([]apimodels.KeptnContextExtendedCE, error) {
+			return []apimodels.KeptnContextExtendedCE{{ID: "my-event-id"}}, nil
+		},
+	}
+	mockSequenceQueueRepo := &dbmock.SequenceQueueRepoMock{
+		QueueSequenceFunc: func(item models.QueueItem) error {
+			return nil
+		},
+	}
+	mockSequenceExecutionRepo := &dbmock.SequenceExecutionRepoMock{
+		GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+			return nil, nil
+		},
+		GetByTriggeredIDFunc: func(project string, triggeredID string) (*models.SequenceExecution, error) {
+			return &models.SequenceExecution{
+				ID: "my-id",
+				Status: models.SequenceExecutionStatus{
+					State: apimodels.SequenceTriggeredState,
+				},
+			}, nil
+		},
+		IsContextPausedFunc: func(eventScope models.EventScope) bool {
+			return false
+		},
+	}
+	mockLocker := &fake.ILockerMock{
+		TryLockFunc: func(key string) (*models.Lock, context.Context, error) {
+			// the lock is lost before the sequence is started
+			lockCtx, cancel := context.WithCancel(context.Background())
+			cancel()
+			return &models.Lock{Key: key, Token: 1}, lockCtx, nil
+		},
+		UnlockFunc: func(lock models.Lock) error {
+			return db.ErrLockLost
+		},
+	}
+
+	sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockSequenceQueueRepo, mockSequenceExecutionRepo, nil, nil, 10*time.Second, clock.NewMock(), common.SDModeRW, mockLocker, common.ClaimOptions{})
+
+	startSequenceCalls := 0
+	sequenceDispatcher.Run(context.Background(), common.SDModeRW, func(event apimodels.KeptnContextExtendedCE) error {
+		startSequenceCalls++
+		return nil
+	})
+
+	err := sequenceDispatcher.Add(getQueueItem("my-context-id"))
+	require.Nil(t, err)
+
+	// the sequence should be queued instead, so that it can be dispatched by the new holder of the lock
+	require.Equal(t, 0, startSequenceCalls)
+	require.Len(t, mockSequenceQueueRepo.QueueSequenceCalls(), 1)
+}
+
+func TestSequenceDispatcher_PartitionedMode(t *testing.T) {
+	theClock := clock.NewMock()
+
+	mockSequenceQueueRepo := &dbmock.SequenceQueueRepoMock{
+		ClaimQueuedSequencesFunc: func(owner string, ttl time.Duration, limit int) ([]models.QueueItem, error) {
+			return nil, db.ErrNoEventFound
+		},
+		RenewSequenceClaimsFunc: func(owner string, ttl time.Duration) error {
+			return nil
+		},
+		ReleaseClaimedSequencesFunc: func(owner string) error {
+			return nil
+		},
+	}
+
+	claimOptions := common.ClaimOptions{
+		Owner:     "my-replica",
+		TTL:       30 * time.Second,
+		BatchSize: 10,
+	}
+	sequenceDispatcher := handler.NewSequenceDispatcher(nil, mockSequenceQueueRepo, nil, nil, nil, 10*time.Second, theClock, common.SDModeRW, nil, claimOptions)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sequenceDispatcher.Run(ctx, common.SDModePartitioned, func(event apimodels.KeptnContextExtendedCE) error {
+		return nil
+	})
+
+	// after 10 seconds, the dispatcher should claim queued sequences and renew its existing claims
+	theClock.Add(10 * time.Second)
+
+	require.Eventually(t, func() bool {
+		return len(mockSequenceQueueRepo.ClaimQueuedSequencesCalls()) == 1 && len(mockSequenceQueueRepo.RenewSequenceClaimsCalls()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Equal(t, "my-replica", mockSequenceQueueRepo.ClaimQueuedSequencesCalls()[0].Owner)
+	require.Equal(t, 30*time.Second, mockSequenceQueueRepo.ClaimQueuedSequencesCalls()[0].Ttl)
+	require.Equal(t, 10, mockSequenceQueueRepo.ClaimQueuedSequencesCalls()[0].Limit)
+	require.Equal(t, "my-replica", mockSequenceQueueRepo.RenewSequenceClaimsCalls()[0].Owner)
+
+	// the complete queue should never be read in partitioned mode
+	require.Empty(t, mockSequenceQueueRepo.GetQueuedSequencesCalls())
+
+	// when the dispatcher is stopped, the claims should be released, so other replicas can take over
+	cancel()
+
+	require.Eventually(t, func() bool {
+		return len(mockSequenceQueueRepo.ReleaseClaimedSequencesCalls()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, "my-replica", mockSequenceQueueRepo.ReleaseClaimedSequencesCalls()[0].Owner)
+}
+
+func TestSequenceDispatcher_FreezeWindow(t *testing.T) {
+	theClock := clock.NewMock()
+
+	mockQueue := []models.QueueItem{}
+	sequenceExecution := &models.SequenceExecution{
+		ID: "my-id",
+		Sequence: keptnv2.Sequence{
+			Name: "delivery",
+		},
+		Status: models.SequenceExecutionStatus{
+			State: apimodels.SequenceTriggeredState,
+		},
+		Scope: models.EventScope{
+			EventData: keptnv2.EventData{
+				Project: "my-project",
+				Stage:   "production",
+				Service: "my-service",
+			},
+			KeptnContext: "my-context-id",
+		},
+	}
+
+	mockEventRepo := &dbmock.EventRepoMock{
+		GetEventsFunc: func(project string, filter common.EventFilter, status ...common.EventStatus) ([]apimodels.KeptnContextExtendedCE, error) {
+			return []apimodels.KeptnContextExtendedCE{{ID: "my-event-id"}}, nil
+		},
+	}
+	mockSequenceQueueRepo := &dbmock.SequenceQueueRepoMock{
+		QueueSequenceFunc: func(item models.QueueItem) error {
+			mockQueue = append(mockQueue, item)
+			return nil
+		},
+		GetQueuedSequencesFunc: func() ([]models.QueueItem, error) {
+			return mockQueue, nil
+		},
+		DeleteQueuedSequencesFunc: func(itemFilter models.QueueItem) error {
+			mockQueue = []models.QueueItem{}
+			return nil
+		},
+	}
+	mockSequenceExecutionRepo := &dbmock.SequenceExecutionRepoMock{
+		GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+			return nil, nil
+		},
+		GetByTriggeredIDFunc: func(project string, triggeredID string) (*models.SequenceExecution, error) {
+			return sequenceExecution, nil
+		},
+		IsContextPausedFunc: func(eventScope models.EventScope) bool {
+			return false
+		},
+	}
+	mockShipyardRetriever := &fake.IShipyardRetrieverMock{
+		GetCachedFreezeWindowsFunc: func(projectName string) (map[string][]common.FreezeWindow, error) {
+			return map[string][]common.FreezeWindow{
+				"production": {{Name: "always", Sequences: []string{"delivery"}, Message: "no deliveries"}},
+			}, nil
+		},
+	}
+
+	startSequenceCalls := []apimodels.KeptnContextExtendedCE{}
+	sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockSequenceQueueRepo, mockSequenceExecutionRepo, mockShipyardRetriever, nil, 10*time.Second, theClock, common.SDModeRW, nil, common.ClaimOptions{})
+	sequenceDispatcher.Run(context.Background(), common.SDModeRW, func(event apimodels.KeptnContextExtendedCE) error {
+		startSequenceCalls = append(startSequenceCalls, event)
+		return nil
+	})
+
+	queueItem := models.QueueItem{
+		Scope:   sequenceExecution.Scope,
+		EventID: "my-event-id",
+	}
+
+	// the sequence must be queued, since the stage is frozen
+	err := sequenceDispatcher.Add(queueItem)
+	require.ErrorIs(t, err, handler.ErrSequenceFrozen)
+	require.Contains(t, err.Error(), "stage production is frozen by freeze window always: no deliveries")
+	require.Len(t, mockSequenceQueueRepo.QueueSequenceCalls(), 1)
+	require.Empty(t, startSequenceCalls)
+
+	// the sequence stays in the queue while the freeze window is active
+	theClock.Add(11 * time.Second)
+	require.Eventually(t, func() bool {
+		return len(mockSequenceQueueRepo.GetQueuedSequencesCalls()) == 1
+	}, 5*time.Second, 100*time.Millisecond)
+	require.Empty(t, startSequenceCalls)
+	require.Len(t, mockQueue, 1)
+
+	// after overriding the freeze, the sequence is started
+	sequenceExecution.OverrideFreeze()
+	theClock.Add(10 * time.Second)
+
+	require.Eventually(t, func() bool {
+		return len(startSequenceCalls) == 1
+	}, 5*time.Second, 100*time.Millisecond)
+	require.Empty(t, mockQueue)
+}
+
+func TestSequenceDispatcher_ConcurrencyLimit(t *testing.T) {
+	theClock := clock.NewMock()
+
+	mockQueue := []models.QueueItem{}
+	sequenceExecution := &models.SequenceExecution{
+		ID: "my-id",
+		Sequence: keptnv2.Sequence{
+			Name: "delivery",
+		},
+		Status: models.SequenceExecutionStatus{
+			State: apimodels.SequenceTriggeredState,
+		},
+		Scope: models.EventScope{
+			EventData: keptnv2.EventData{
+				Project: "my-project",
+				Stage:   "production",
+				Service: "my-service",
+			},
+			KeptnContext: "my-context-id",
+		},
+	}
+
+	mockEventRepo := &dbmock.EventRepoMock{
+		GetEventsFunc: func(project string, filter common.EventFilter, status ...common.EventStatus) ([]apimodels.KeptnContextExtendedCE, error) {
+			return []apimodels.KeptnContextExtendedCE{{ID: "my-event-id"}}, nil
+		},
+	}
+	mockSequenceQueueRepo := &dbmock.SequenceQueueRepoMock{
+		QueueSequenceFunc: func(item models.QueueItem) error {
+			mockQueue = append(mockQueue, item)
+			return nil
+		},
+		GetQueuedSequencesFunc: func() ([]models.QueueItem, error) {
+			return mockQueue, nil
+		},
+		DeleteQueuedSequencesFunc: func(itemFilter models.QueueItem) error {
+			mockQueue = []models.QueueItem{}
+			return nil
+		},
+	}
+	mockSequenceExecutionRepo := &dbmock.SequenceExecutionRepoMock{
+		GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+			return nil, nil
+		},
+		GetByTriggeredIDFunc: func(project string, triggeredID string) (*models.SequenceExecution, error) {
+			return sequenceExecution, nil
+		},
+		IsContextPausedFunc: func(eventScope models.EventScope) bool {
+			return false
+		},
+	}
+	limitReached := true
+	mockConcurrencyLimiter := &fake.IConcurrencyLimiterMock{
+		RunWithinLimitsFunc: func(ctx context.Context, sequenceExecution models.SequenceExecution, fn func(ctx context.Context) error) error {
+			if limitReached {
+				return fmt.Errorf("%w: 2 sequences are already running in project my-project (limit: 2)", handler.ErrConcurrencyLimitReached)
+			}
+			return fn(ctx)
+		},
+	}
+
+	startSequenceCalls := []apimodels.KeptnContextExtendedCE{}
+	sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockSequenceQueueRepo, mockSequenceExecutionRepo, nil, mockConcurrencyLimiter, 10*time.Second, theClock, common.SDModeRW, nil, common.ClaimOptions{})
+	sequenceDispatcher.Run(context.Background(), common.SDModeRW, func(event apimodels.KeptnContextExtendedCE) error {
+		startSequenceCalls = append(startSequenceCalls, event)
+		return nil
+	})
+
+	queueItem := models.QueueItem{
+		Scope:   sequenceExecution.Scope,
+		EventID: "my-event-id",
+	}
+
+	// the sequence must be queued, since the limit has been reached
+	err := sequenceDispatcher.Add(queueItem)
+	require.ErrorIs(t, err, handler.ErrConcurrencyLimitReached)
+	require.Len(t, mockSequenceQueueRepo.QueueSequenceCalls(), 1)
+	require.Len(t, mockConcurrencyLimiter.RunWithinLimitsCalls(), 1)
+	require.Equal(t, "my-id", mockConcurrencyLimiter.RunWithinLimitsCalls()[0].SequenceExecution.ID)
+	require.Empty(t, startSequenceCalls)
+
+	theClock.Add(11 * time.Second)
+	require.Eventually(t, func() bool {
+		return len(mockConcurrencyLimiter.RunWithinLimitsCalls()) == 2
+	}, 5*time.Second, 100*time.Millisecond)
+	require.Empty(t, startSequenceCalls)
+	require.Len(t, mockQueue, 1)
+
+	// as soon as the number of running sequences is below the limit, the sequence is started
+	limitReached = false
+	theClock.Add(10 * time.Second)
+
+	require.Eventually(t, func() bool {
+		return len(startSequenceCalls) == 1
+	}, 5*time.Second, 100*time.Millisecond)
+	require.Empty(t, mockQueue)
+}
+
+func TestSequenceDispatcher_PriorityOfTriggeredSequences(t *testing.T) {
+	triggeredSequence := models.SequenceExecution{
+		ID: "my-other-id",
+		Status: models.SequenceExecutionStatus{
+			State: apimodels.SequenceTriggeredState,
+		},
+		Scope: models.EventScope{
+			KeptnContext: "my-other-context-id",
+		},
+	}
+
+	mockEventRepo := &dbmock.EventRepoMock{
+		GetEventsFunc: func(project string, filter common.EventFilter, status ...common.EventStatus) ([]apimodels.KeptnContextExtendedCE, error) {
+			return []apimodels.KeptnContextExtendedCE{{ID: *filter.ID}}, nil
+		},
+	}
+	mockSequenceQueueRepo := &dbmock.SequenceQueueRepoMock{
+		QueueSequenceFunc: func(item models.QueueItem) error {
+			return nil
+		},
+		DeleteQueuedSequencesFunc: func(itemFilter models.QueueItem) error {
+			return nil
+		},
+	}
+	mockSequenceExecutionRepo := &dbmock.SequenceExecutionRepoMock{
+		GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+			if filter.Status[0] == apimodels.SequenceTriggeredState {
+				return []models.SequenceExecution{triggeredSequence}, nil
+			}
+			return nil, nil
+		},
+		GetByTriggeredIDFunc: func(project string, triggeredID string) (*models.SequenceExecution, error) {
+			return &models.SequenceExecution{
+				ID: triggeredID,
+				Status: models.SequenceExecutionStatus{
+					State: apimodels.SequenceTriggeredState,
+				},
+			}, nil
+		},
+		IsContextPausedFunc: func(eventScope models.EventScope) bool {
+			return false
+		},
+	}
+
+	startSequenceCalls := []apimodels.KeptnContextExtendedCE{}
+	sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockSequenceQueueRepo, mockSequenceExecutionRepo, nil, nil, 10*time.Second, clock.NewMock(), common.SDModeRW, nil, common.ClaimOptions{})
+	sequenceDispatcher.Run(context.Background(), common.SDModeRW, func(event apimodels.KeptnContextExtendedCE) error {
+		startSequenceCalls = append(startSequenceCalls, event)
+		return nil
+	})
+
+	// a sequence with the same priority has to wait for the sequence that has been triggered before
+	routineItem := getQueueItem("my-routine-id")
+	err := sequenceDispatcher.Add(routineItem)
+	require.ErrorIs(t, err, handler.ErrSequenceBlockedWaiting)
+	require.Empty(t, startSequenceCalls)
+
+	// a sequence with a higher priority jumps ahead of it
+	hotfixItem := getQueueItem("my-hotfix-id")
+	hotfixItem.Priority = 100
+	err = sequenceDispatcher.Add(hotfixItem)
+	require.Nil(t, err)
+	require.Len(t, startSequenceCalls, 1)
+	require.Equal(t, "my-hotfix-id", startSequenceCalls[0].ID)
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/go-utils/pkg/common/timeutils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+)
+
+type ISequenceExecutionHandler interface {
+	GetSequenceExecutions(context *gin.Context)
+}
+
+type SequenceExecutionHandler struct {
+	sequenceExecutionRepo db.SequenceExecutionRepo
+}
+
+func NewSequenceExecutionHandler(sequenceExecutionRepo db.SequenceExecutionRepo) *SequenceExecutionHandler {
+	return &SequenceExecutionHandler{sequenceExecutionRepo: sequenceExecutionRepo}
+}
+
+// GetSequenceExecutions returns the execution history of sequences
+// @Summary      Get the execution history of sequences
+// @Description  Get the execution history of sequences, including the timing, executing integrations and results of their tasks
+// @Tags         Sequence
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project       query     string                               true   "The name of the project"
+// @Param        stage         query     string                               false  "The name of the stage"
+// @Param        service       query     string                               false  "The name of the service"
+// @Param        name          query     string                               false  "The name of the sequence"
+// @Param        state         query     string                               false  "Comma separated list of sequence states, e.g. finished,aborted"
+// @Param        keptnContext  query     string                               false  "The keptn context"
+// @Param        fromTime      query     string                               false  "Only include sequences that have been triggered at or after the given time"
+// @Param        beforeTime    query     string                               false  "Only include sequences that have been triggered before the given time"
+// @Param        pageSize      query     int                                  false  "The number of items to return"
+// @Param        nextPageKey   query     string                               false  "Pointer to the next set of items"
+// @Success      200           {object}  models.GetSequenceExecutionResponse  "ok"
+// @Failure      400           {object}  models.Error                         "Invalid payload"
+// @Failure      500           {object}  models.Error                         "Internal error"
+// @Router       /sequence-execution [get]
+func (sh *SequenceExecutionHandler) GetSequenceExecutions(context *gin.Context) {
+	params := &models.GetSequenceExecutionParams{}
+	if err := context.ShouldBindQuery(params); err != nil {
+		SetBadRequestErrorResponse(context, fmt.Sprintf(InvalidRequestFormatMsg, err.Error()))
+		return
+	}
+
+	if params.Project == "" {
+		SetBadRequestErrorResponse(context, NoProjectNameMsg)
+		return
+	}
+
+	filter := models.SequenceExecutionFilter{
+		Scope: models.EventScope{
+			EventData: keptnv2.EventData{
+				Project: params.Project,
+				Stage:   params.Stage,
+				Service: params.Service,
+			},
+			KeptnContext: params.KeptnContext,
+		},
+		Name: params.Name,
+	}
+
+	if params.State != "" {
+		filter.Status = strings.Split(params.State, ",")
+	}
+
+	if params.FromTime != "" {
+		fromTime, err := timeutils.ParseTimestamp(params.FromTime)
+		if err != nil {
+			SetBadRequestErrorResponse(context, fmt.Sprintf(InvalidRequestFormatMsg, err.Error()))
+			return
+		}
+		filter.TriggeredAfter = *fromTime
+	}
+
+	if params.BeforeTime != "" {
+		beforeTime, err := timeutils.ParseTimestamp(params.BeforeTime)
+		if err != nil {
+			SetBadRequestErrorResponse(context, fmt.Sprintf(InvalidRequestFormatMsg, err.Error()))
+			return
+		}
+		filter.TriggeredAt = *beforeTime
+	}
+
+	sequenceExecutions, totalCount, err := sh.sequenceExecutionRepo.GetPaginated(filter, params.NextPageKey, params.PageSize)
+	if err != nil {
+		SetInternalServerErrorResponse(context, fmt.Sprintf(UnableQuerySequenceExecutionsMsg, err.Error()))
+		return
+	}
+
+	response := models.GetSequenceExecutionResponse{
+		PageSize:           params.PageSize,
+		TotalCount:         totalCount,
+		SequenceExecutions: []models.SequenceExecutionHistory{},
+	}
+	if params.PageSize > 0 && params.PageSize+params.NextPageKey < totalCount {
+		response.NextPageKey = params.PageSize + params.NextPageKey
+	}
+
+	for _, sequenceExecution := range sequenceExecutions {
+		response.SequenceExecutions = append(response.SequenceExecutions, models.NewSequenceExecutionHistory(sequenceExecution))
+	}
+	context.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,126 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequenceExecutionHandler_GetSequenceExecutions(t *testing.T) {
+	sequenceExecutions := []models.SequenceExecution{
+		{
+			ID:       "my-id",
+			Sequence: keptnv2.Sequence{Name: "delivery"},
+			Status: models.SequenceExecutionStatus{
+				State: "finished",
+				PreviousTasks: []models.TaskExecutionResult{
+					{
+						Name:       "deployment",
+						Result:     keptnv2.ResultPass,
+						Status:     keptnv2.StatusSucceeded,
+						StartedAt:  "2021-04-21T17:00:01.000Z",
+						FinishedAt: "2021-04-21T17:00:11.000Z",
+						Executors:  []string{"helm-service"},
+					},
+				},
+			},
+			Scope: models.EventScope{
+				EventData:    keptnv2.EventData{Project: "my-project", Stage: "dev", Service: "my-service"},
+				KeptnContext: "my-context",
+			},
+			TriggeredAt: time.Date(2021, 4, 21, 17, 0, 0, 0, time.UTC),
+		},
+	}
+
+	tests := []struct {
+		name                  string
+		sequenceExecutionRepo *db_mock.SequenceExecutionRepoMock
+		request               *http.Request
+		wantStatus            int
+		wantFilter            *models.SequenceExecutionFilter
+		wantResponse          *models.GetSequenceExecutionResponse
+	}{
+		{
+			name: "get sequence executions",
+			sequenceExecutionRepo: &db_mock.SequenceExecutionRepoMock{
+				GetPaginatedFunc: func(filter models.SequenceExecutionFilter, nextPageKey int64, pageSize int64) ([]models.SequenceExecution, int64, error) {
+					return sequenceExecutions, 3, nil
+				},
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/sequence-execution?project=my-project&stage=dev&service=my-service&name=delivery&state=finished,aborted&keptnContext=my-context&fromTime=2021-04-21T00:00:00.000Z&beforeTime=2021-04-22T00:00:00.000Z&pageSize=1", nil),
+			wantStatus: http.StatusOK,
+			wantFilter: &models.SequenceExecutionFilter{
+				Scope: models.EventScope{
+					EventData:    keptnv2.EventData{Project: "my-project", Stage: "dev", Service: "my-service"},
+					KeptnContext: "my-context",
+				},
+				Name:           "delivery",
+				Status:         []string{"finished", "aborted"},
+				TriggeredAfter: time.Date(2021, 4, 21, 0, 0, 0, 0, time.UTC),
+				TriggeredAt:    time.Date(2021, 4, 22, 0, 0, 0, 0, time.UTC),
+			},
+			wantResponse: &models.GetSequenceExecutionResponse{
+				NextPageKey:        1,
+				PageSize:           1,
+				TotalCount:         3,
+				SequenceExecutions: []models.SequenceExecutionHistory{models.NewSequenceExecutionHistory(sequenceExecutions[0])},
+			},
+		},
+		{
+			name:                  "no project",
+			sequenceExecutionRepo: &db_mock.SequenceExecutionRepoMock{},
+			request:               httptest.NewRequest(http.MethodGet, "/sequence-execution", nil),
+			wantStatus:            http.StatusBadRequest,
+		},
+		{
+			name:                  "invalid time",
+			sequenceExecutionRepo: &db_mock.SequenceExecutionRepoMock{},
+			request:               httptest.NewRequest(http.MethodGet, "/sequence-execution?project=my-project&fromTime=yesterday", nil),
+			wantStatus:            http.StatusBadRequest,
+		},
+		{
+			name: "repo error",
+			sequenceExecutionRepo: &db_mock.SequenceExecutionRepoMock{
+				GetPaginatedFunc: func(filter models.SequenceExecutionFilter, nextPageKey int64, pageSize int64) ([]models.SequenceExecution, int64, error) {
+					return nil, 0, errors.New("oops")
+				},
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/sequence-execution?project=my-project", nil),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sh := handler.NewSequenceExecutionHandler(tt.sequenceExecutionRepo)
+
+			router := gin.Default()
+			router.GET("/sequence-execution", func(c *gin.Context) {
+				sh.GetSequenceExecutions(c)
+			})
+			w := performRequest(router, tt.request)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+
+			if tt.wantFilter != nil {
+				require.Len(t, tt.sequenceExecutionRepo.GetPaginatedCalls(), 1)
+				require.Equal(t, *tt.wantFilter, tt.sequenceExecutionRepo.GetPaginatedCalls()[0].Filter)
+			}
+			if tt.wantResponse != nil {
+				response := &models.GetSequenceExecutionResponse{}
+				err := json.Unmarshal(w.Body.Bytes(), response)
+				require.Nil(t, err)
+				require.Equal(t, tt.wantResponse, response)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ package fake
 
 import (
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/shipyard-controller/models"
 	"sync"
 )
 
@@ -14,7 +15,7 @@ import (
 //
 // 		// make and configure a mocked sequencehooks.ISequenceTimeoutHook
 // 		mockedISequenceTimeoutHook := &ISequenceTimeoutHookMock{
-// 			OnSequenceTimeoutFunc: func(event apimodels.KeptnContextExtendedCE)  {
+// 			OnSequenceTimeoutFunc: func(event apimodels.KeptnContextExtendedCE, reason models.SequenceTimeoutReason)  {
 // 				panic("mock out the OnSequenceTimeout method")
 // 			},
 // 		}
@@ -25,7 +26,7 @@ import (
 // 	}
 type ISequenceTimeoutHookMock struct {
 	// OnSequenceTimeoutFunc mocks the OnSequenceTimeout method.
-	OnSequenceTimeoutFunc func(event apimodels.KeptnContextExtendedCE)
+	OnSequenceTimeoutFunc func(event apimodels.KeptnContextExtendedCE, reason models.SequenceTimeoutReason)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -33,35 +34,41 @@ type ISequenceTimeoutHookMock struct {
 		OnSequenceTimeout []struct {
 			//models.KeptnContextExtendedCEis the event argument value.
 			Event apimodels.KeptnContextExtendedCE
+			// Reason is the reason argument value.
+			Reason models.SequenceTimeoutReason
 		}
 	}
 	lockOnSequenceTimeout sync.RWMutex
 }
 
 // OnSequenceTimeout calls OnSequenceTimeoutFunc.
-func (mock *ISequenceTimeoutHookMock) OnSequenceTimeout(event apimodels.KeptnContextExtendedCE) {
+func (mock *ISequenceTimeoutHookMock) OnSequenceTimeout(event apimodels.KeptnContextExtendedCE, reason models.SequenceTimeoutReason) {
 	if mock.OnSequenceTimeoutFunc == nil {
 		panic("ISequenceTimeoutHookMock.OnSequenceTimeoutFunc: method is nil but ISequenceTimeoutHook.OnSequenceTimeout was just called")
 	}
 	callInfo := struct {
-		Event apimodels.KeptnContextExtendedCE
+		Event  apimodels.KeptnContextExtendedCE
+		Reason models.SequenceTimeoutReason
 	}{
-		Event: event,
+		Event:  event,
+		Reason: reason,
 	}
 	mock.lockOnSequenceTimeout.Lock()
 	mock.calls.OnSequenceTimeout = append(mock.calls.OnSequenceTimeout, callInfo)
 	mock.lockOnSequenceTimeout.Unlock()
-	mock.OnSequenceTimeoutFunc(event)
+	mock.OnSequenceTimeoutFunc(event, reason)
 }
 
 // OnSequenceTimeoutCalls gets all the calls that were made to OnSequenceTimeout.
 // Check the length with:
 //     len(mockedISequenceTimeoutHook.OnSequenceTimeoutCalls())
 func (mock *ISequenceTimeoutHookMock) OnSequenceTimeoutCalls() []struct {
-	Event apimodels.KeptnContextExtendedCE
+	Event  apimodels.KeptnContextExtendedCE
+	Reason models.SequenceTimeoutReason
 } {
 	var calls []struct {
-		Event apimodels.KeptnContextExtendedCE
+		Event  apimodels.KeptnContextExtendedCE
+		Reason models.SequenceTimeoutReason
 	}
 	mock.lockOnSequenceTimeout.RLock()
 	calls = mock.calls.OnSequenceTimeout
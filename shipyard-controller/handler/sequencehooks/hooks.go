@@ -52,7 +52,8 @@ type ISequenceAbortedHook interface {
 
 //go:generate moq -pkg fake -skip-ensure -out ./fake/sequencetimeout.go . ISequenceTimeoutHook
 type ISequenceTimeoutHook interface {
-	OnSequenceTimeout(event apimodels.KeptnContextExtendedCE)
+	// OnSequenceTimeout is called with the .finished event data of the timed out sequence, and the reason of the timeout
+	OnSequenceTimeout(event apimodels.KeptnContextExtendedCE, reason models.SequenceTimeoutReason)
 }
 
 //go:generate moq -pkg fake -skip-ensure -out ./fake/sequencepause.go . ISequencePausedHook
@@ -177,7 +177,7 @@ func (smv *SequenceStateMaterializedView) OnSequenceAborted(eventScope models.Ev
 	smv.updateOverallSequenceState(eventScope, apimodels.SequenceAborted)
 }
 
-func (smv *SequenceStateMaterializedView) OnSequenceTimeout(event apimodels.KeptnContextExtendedCE) {
+func (smv *SequenceStateMaterializedView) OnSequenceTimeout(event apimodels.KeptnContextExtendedCE, reason models.SequenceTimeoutReason) {
 	smv.mutex.Lock()
 	defer smv.mutex.Unlock()
 	eventScope, err := models.NewEventScope(event)
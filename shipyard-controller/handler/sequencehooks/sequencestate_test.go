@@ -193,7 +193,7 @@ func TestSequenceStateMaterializedView_OnSequenceTimeOud(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			smv := sequencehooks.NewSequenceStateMaterializedView(tt.fields.SequenceStateRepo)
-			smv.OnSequenceTimeout(tt.args.event)
+			smv.OnSequenceTimeout(tt.args.event, scmodels.SequenceTimeoutReasonTask)
 
 			if tt.expectUpdateToBeCalled {
 				require.NotEmpty(t, tt.fields.SequenceStateRepo.UpdateSequenceStateCalls())
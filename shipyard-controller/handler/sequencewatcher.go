@@ -11,25 +11,37 @@ import (
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
 	log "github.com/sirupsen/logrus"
 )
 
+// unfinishedSequenceStates contains the states of sequences that have not been completed yet
+var unfinishedSequenceStates = []string{
+	apimodels.SequenceTriggeredState,
+	apimodels.SequenceStartedState,
+	apimodels.SequenceWaitingState,
+	apimodels.SequenceWaitingForApprovalState,
+	apimodels.SequencePaused,
+}
+
 type SequenceWatcher struct {
-	cancelSequenceChannel chan apimodels.SequenceTimeout
+	cancelSequenceChannel chan models.SequenceTimeout
 	eventRepo             db.EventRepo
 	eventQueueRepo        db.EventQueueRepo
 	projectRepo           db.ProjectRepo
+	sequenceExecutionRepo db.SequenceExecutionRepo
 	eventTimeout          time.Duration
 	syncInterval          time.Duration
 	theClock              clock.Clock
 }
 
-func NewSequenceWatcher(cancelSequenceChannel chan apimodels.SequenceTimeout, eventRepo db.EventRepo, eventQueueRepo db.EventQueueRepo, projectRepo db.ProjectRepo, eventTimeout time.Duration, syncInterval time.Duration, theClock clock.Clock) *SequenceWatcher {
+func NewSequenceWatcher(cancelSequenceChannel chan models.SequenceTimeout, eventRepo db.EventRepo, eventQueueRepo db.EventQueueRepo, projectRepo db.ProjectRepo, sequenceExecutionRepo db.SequenceExecutionRepo, eventTimeout time.Duration, syncInterval time.Duration, theClock clock.Clock) *SequenceWatcher {
 	return &SequenceWatcher{
 		cancelSequenceChannel: cancelSequenceChannel,
 		eventRepo:             eventRepo,
 		eventQueueRepo:        eventQueueRepo,
 		projectRepo:           projectRepo,
+		sequenceExecutionRepo: sequenceExecutionRepo,
 		eventTimeout:          eventTimeout,
 		syncInterval:          syncInterval,
 		theClock:              theClock,
@@ -64,7 +76,59 @@ func (sw *SequenceWatcher) cleanUpOrphanedTasks() {
 		if err := sw.cleanUpOrphanedTasksOfProject(projects[index].ProjectName); err != nil {
 			log.WithError(err).Errorf("could not clean up orphaned tasks of project %s", projects[index].ProjectName)
 		}
+		if err := sw.timeOutSequencesOfProject(projects[index].ProjectName); err != nil {
+			log.WithError(err).Errorf("could not check timeouts of sequences in project %s", projects[index].ProjectName)
+		}
+	}
+}
+
+// timeOutSequencesOfProject cancels the unfinished sequences of the project whose timeout, or the timeout of one of their current tasks, has expired.
+// The timeout of a sequence also expires while it is paused, or in between two of its tasks
+func (sw *SequenceWatcher) timeOutSequencesOfProject(project string) error {
+	sequenceExecutions, err := sw.sequenceExecutionRepo.Get(models.SequenceExecutionFilter{
+		Scope: models.EventScope{
+			EventData: keptnv2.EventData{
+				Project: project,
+			},
+		},
+		Status: unfinishedSequenceStates,
+	})
+	if err != nil {
+		return fmt.Errorf("could not retrieve running sequences: %w", err)
+	}
+
+	now := sw.theClock.Now().UTC()
+	for _, sequenceExecution := range sequenceExecutions {
+		reason, msg := sequenceExecution.GetExpiredTimeout(now)
+		if reason == "" {
+			continue
+		}
+		// if no task is active, the sequence itself is referenced by the timeout, and it is identified by its scope
+		lastEventType := keptnv2.GetTriggeredEventType(sequenceExecution.Scope.Stage + "." + sequenceExecution.Sequence.Name)
+		currentTask := sequenceExecution.Status.CurrentTask
+		if currentTask.TriggeredID != "" {
+			lastEventType = keptnv2.GetTriggeredEventType(currentTask.Name)
+		}
+		log.Infof("%s. Cancelling sequence with keptnContext %s", msg, sequenceExecution.Scope.KeptnContext)
+		sw.cancelSequenceChannel <- models.SequenceTimeout{
+			SequenceTimeout: apimodels.SequenceTimeout{
+				KeptnContext: sequenceExecution.Scope.KeptnContext,
+				LastEvent: apimodels.KeptnContextExtendedCE{
+					ID:             currentTask.TriggeredID,
+					Shkeptncontext: sequenceExecution.Scope.KeptnContext,
+					Type:           common.Stringp(lastEventType),
+					Data: keptnv2.EventData{
+						Project: sequenceExecution.Scope.Project,
+						Stage:   sequenceExecution.Scope.Stage,
+						Service: sequenceExecution.Scope.Service,
+					},
+				},
+			},
+			Reason:  reason,
+			Message: msg,
+		}
 	}
+	return nil
 }
 
 func (sw *SequenceWatcher) cleanUpOrphanedTasksOfProject(project string) error {
@@ -116,9 +180,12 @@ func (sw *SequenceWatcher) cleanUpOrphanedTasksOfProject(project string) error {
 			}
 			if len(responseEvents) == 0 {
 				// time out -> tell shipyard controller to complete the task sequence
-				sequenceCancellation := apimodels.SequenceTimeout{
-					KeptnContext: event.Shkeptncontext,
-					LastEvent:    event,
+				sequenceCancellation := models.SequenceTimeout{
+					SequenceTimeout: apimodels.SequenceTimeout{
+						KeptnContext: event.Shkeptncontext,
+						LastEvent:    event,
+					},
+					Reason: models.SequenceTimeoutReasonTaskNotStarted,
 				}
 
 				sw.cancelSequenceChannel <- sequenceCancellation
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/benbjohnson/clock"
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/go-utils/pkg/common/timeutils"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/keptn/keptn/shipyard-controller/db"
@@ -109,13 +110,18 @@ func TestSequenceWatcher(t *testing.T) {
 		},
 	}
 
-	cancelSequenceChannel := make(chan apimodels.SequenceTimeout)
+	cancelSequenceChannel := make(chan models.SequenceTimeout)
 
 	watcher := handler.NewSequenceWatcher(
 		cancelSequenceChannel,
 		eventRepoMock,
 		eventQueueMock,
 		projectRepoMock,
+		&db_mock.SequenceExecutionRepoMock{
+			GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+				return nil, nil
+			},
+		},
 		10*time.Minute,
 		1*time.Minute,
 		theClock,
@@ -135,6 +141,7 @@ func TestSequenceWatcher(t *testing.T) {
 	select {
 	case cancelCall := <-cancelSequenceChannel:
 		require.Equal(t, "my-keptn-context-2", cancelCall.KeptnContext)
+		require.Equal(t, models.SequenceTimeoutReasonTaskNotStarted, cancelCall.Reason)
 
 		require.Eventually(t, func() bool {
 			return len(eventRepoMock.DeleteEventCalls()) == 1
@@ -146,3 +153,133 @@ func TestSequenceWatcher(t *testing.T) {
 	}
 	cancel()
 }
+
+func TestSequenceWatcher_TaskAndSequenceTimeouts(t *testing.T) {
+	theClock := clock.NewMock()
+	startedAt := theClock.Now().UTC()
+
+	newSequenceExecution := func(keptnContext, sequenceTimeout, taskTimeout string) models.SequenceExecution {
+		return models.SequenceExecution{
+			ID: keptnContext,
+			Sequence: keptnv2.Sequence{
+				Name: "delivery",
+				Tasks: []keptnv2.Task{
+					{Name: "deployment"},
+				},
+			},
+			TaskAttributes: []common.TaskAttributes{{Timeout: taskTimeout}},
+			Status: models.SequenceExecutionStatus{
+				State:     apimodels.SequenceStartedState,
+				StartedAt: startedAt,
+				CurrentTask: models.TaskExecutionState{
+					Name:        "deployment",
+					TriggeredID: keptnContext + "-triggered-id",
+					Events: []models.TaskEvent{
+						{EventType: keptnv2.GetStartedEventType("deployment"), Source: "helm-service", Time: timeutils.GetKeptnTimeStamp(startedAt)},
+					},
+				},
+			},
+			Scope: models.EventScope{
+				KeptnContext: keptnContext,
+				EventData: keptnv2.EventData{
+					Project: "my-project",
+					Stage:   "my-stage",
+					Service: "my-service",
+				},
+			},
+			Timeout: sequenceTimeout,
+		}
+	}
+
+	betweenTasks := newSequenceExecution("sequence-timeout-between-tasks", "15m", "5m")
+	betweenTasks.Status.CurrentTask = models.TaskExecutionState{}
+
+	paused := newSequenceExecution("paused-sequence-timeout", "15m", "")
+	paused.Status.State = apimodels.SequencePaused
+
+	sequenceExecutionRepoMock := &db_mock.SequenceExecutionRepoMock{
+		GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+			return []models.SequenceExecution{
+				newSequenceExecution("no-timeout", "", ""),
+				newSequenceExecution("sequence-timeout", "15m", ""),
+				newSequenceExecution("task-timeout", "", "5m"),
+				betweenTasks,
+				paused,
+			}, nil
+		},
+	}
+
+	eventRepoMock := &db_mock.EventRepoMock{
+		GetEventsFunc: func(project string, filter common.EventFilter, status ...common.EventStatus) ([]apimodels.KeptnContextExtendedCE, error) {
+			return nil, db.ErrNoEventFound
+		},
+	}
+
+	projectRepoMock := &db_mock.ProjectRepoMock{
+		GetProjectsFunc: func() ([]*apimodels.ExpandedProject, error) {
+			return []*apimodels.ExpandedProject{{ProjectName: "my-project"}}, nil
+		},
+	}
+
+	cancelSequenceChannel := make(chan models.SequenceTimeout, 100)
+
+	watcher := handler.NewSequenceWatcher(
+		cancelSequenceChannel,
+		eventRepoMock,
+		&db_mock.EventQueueRepoMock{},
+		projectRepoMock,
+		sequenceExecutionRepoMock,
+		10*time.Minute,
+		1*time.Minute,
+		theClock,
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watcher.Run(ctx)
+
+	// after 6 minutes, the timeout of the task has expired
+	theClock.Add(6 * time.Minute)
+
+	select {
+	case timeout := <-cancelSequenceChannel:
+		require.Equal(t, "task-timeout", timeout.KeptnContext)
+		require.Equal(t, "task-timeout-triggered-id", timeout.LastEvent.ID)
+		require.Equal(t, keptnv2.GetTriggeredEventType("deployment"), *timeout.LastEvent.Type)
+		require.Equal(t, "task deployment of sequence delivery timed out after 5m0s", timeout.Message)
+		require.Equal(t, models.SequenceTimeoutReasonTask, timeout.Reason)
+	case <-time.After(5 * time.Second):
+		t.Error("did not receive expected task timeout")
+	}
+
+	// after 16 minutes, the timeout of the sequence has expired as well
+	theClock.Add(10 * time.Minute)
+
+	timedOut := map[string]string{}
+	reasons := map[string]models.SequenceTimeoutReason{}
+	lastEvents := map[string]apimodels.KeptnContextExtendedCE{}
+	require.Eventually(t, func() bool {
+		for {
+			select {
+			case timeout := <-cancelSequenceChannel:
+				timedOut[timeout.KeptnContext] = timeout.Message
+				reasons[timeout.KeptnContext] = timeout.Reason
+				lastEvents[timeout.KeptnContext] = timeout.LastEvent
+			default:
+				return timedOut["sequence-timeout"] != "" && timedOut["sequence-timeout-between-tasks"] != "" && timedOut["paused-sequence-timeout"] != ""
+			}
+		}
+	}, 5*time.Second, 100*time.Millisecond)
+	require.Equal(t, "sequence delivery timed out after 15m", timedOut["sequence-timeout"])
+	require.Equal(t, "sequence delivery timed out after 15m", timedOut["sequence-timeout-between-tasks"])
+	require.Equal(t, "sequence delivery timed out after 15m", timedOut["paused-sequence-timeout"])
+	require.Equal(t, models.SequenceTimeoutReasonSequence, reasons["sequence-timeout"])
+	require.NotContains(t, timedOut, "no-timeout")
+
+	// without an active task, the timeout refers to the sequence
+	require.Empty(t, lastEvents["sequence-timeout-between-tasks"].ID)
+	require.Equal(t, keptnv2.GetTriggeredEventType("my-stage.delivery"), *lastEvents["sequence-timeout-between-tasks"].Type)
+
+	// the paused sequences are checked as well
+	require.Contains(t, sequenceExecutionRepoMock.GetCalls()[0].Filter.Status, apimodels.SequencePaused)
+}
@@ -25,6 +25,8 @@ func (s ServiceParamsValidator) Validate(params interface{}) error {
 	switch t := params.(type) {
 	case *models.CreateServiceParams:
 		return s.validateCreateServiceParams(t)
+	case *models.UpdateServiceParams:
+		return s.validateUpdateServiceParams(t)
 	default:
 		return nil
 	}
@@ -45,24 +47,35 @@ func (s ServiceParamsValidator) validateCreateServiceParams(params *models.Creat
 	return nil
 }
 
+func (s ServiceParamsValidator) validateUpdateServiceParams(params *models.UpdateServiceParams) error {
+	if params.ServiceName == nil {
+		return nil
+	}
+	return s.validateCreateServiceParams(&models.CreateServiceParams{ServiceName: params.ServiceName})
+}
+
 type IServiceHandler interface {
 	CreateService(context *gin.Context)
 	DeleteService(context *gin.Context)
 	GetService(context *gin.Context)
 	GetServices(context *gin.Context)
+	GetServiceMetadata(context *gin.Context)
+	UpdateService(context *gin.Context)
 }
 
 type ServiceHandler struct {
 	serviceManager IServiceManager
 	EventSender    common.EventSender
 	Env            config.EnvConfig
+	locker         ILocker
 }
 
-func NewServiceHandler(serviceManager IServiceManager, eventSender common.EventSender, env config.EnvConfig) IServiceHandler {
+func NewServiceHandler(serviceManager IServiceManager, eventSender common.EventSender, env config.EnvConfig, locker ILocker) IServiceHandler {
 	return &ServiceHandler{
 		serviceManager: serviceManager,
 		EventSender:    eventSender,
 		Env:            env,
+		locker:         locker,
 	}
 }
 
@@ -101,8 +114,12 @@ func (sh *ServiceHandler) CreateService(c *gin.Context) {
 		return
 	}
 
-	common.LockProject(projectName)
-	defer common.UnlockProject(projectName)
+	unlockProject, err := lockProject(sh.locker, projectName)
+	if err != nil {
+		SetInternalServerErrorResponse(c, err.Error())
+		return
+	}
+	defer unlockProject()
 
 	if err := sh.sendServiceCreateStartedEvent(keptnContext, projectName, params); err != nil {
 		log.Errorf("could not send service.create.started event: %s", err.Error())
@@ -153,8 +170,12 @@ func (sh *ServiceHandler) DeleteService(c *gin.Context) {
 		SetBadRequestErrorResponse(c, NoServiceNameMsg)
 	}
 
-	common.LockProject(projectName)
-	defer common.UnlockProject(projectName)
+	unlockProject, err := lockProject(sh.locker, projectName)
+	if err != nil {
+		SetInternalServerErrorResponse(c, err.Error())
+		return
+	}
+	defer unlockProject()
 
 	if err := sh.sendServiceDeleteStartedEvent(keptnContext, projectName, serviceName); err != nil {
 		log.Errorf("could not send service.delete.started event: %s", err.Error())
@@ -264,6 +285,92 @@ func (sh *ServiceHandler) GetServices(c *gin.Context) {
 	c.JSON(http.StatusOK, payload)
 }
 
+// GetServiceMetadata godoc
+// @Summary      Gets the metadata of a service
+// @Description  Gets the description, owners and labels of a service
+// @Tags         Services
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project  path      string                  true  "Project"
+// @Param        service  path      string                  true  "Service"
+// @Success      200      {object}  models.ServiceMetadata  "ok"
+// @Failure      404      {object}  models.Error            "Not found"
+// @Failure      500      {object}  models.Error            "Internal error"
+// @Router       /project/{project}/service/{service} [get]
+func (sh *ServiceHandler) GetServiceMetadata(c *gin.Context) {
+	projectName := c.Param("project")
+	serviceName := c.Param("service")
+
+	metadata, err := sh.serviceManager.GetServiceMetadata(projectName, serviceName)
+	if err != nil {
+		setServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, metadata)
+}
+
+// UpdateService godoc
+// @Summary      Update a service
+// @Description  Update the description, owners and labels of a service, and/or rename the service.
+// @Description  When a service is renamed, its resources are moved to the new service in every stage, while its state, e.g. the last deployment, is retained.
+// @Description  Subscriptions referring to the service are updated accordingly. A service cannot be renamed while sequences are active for the service
+// @Tags         Services
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project  path      string                      true  "Project"
+// @Param        service  path      string                      true  "Service"
+// @Param        update   body      models.UpdateServiceParams  true  "The changes to the service"
+// @Success      200      {object}  models.ServiceMetadata      "ok"
+// @Failure      400      {object}  models.Error                "Invalid payload"
+// @Failure      404      {object}  models.Error                "Not found"
+// @Failure      409      {object}  models.Error                "Conflict"
+// @Failure      500      {object}  models.Error                "Internal error"
+// @Router       /project/{project}/service/{service} [put]
+func (sh *ServiceHandler) UpdateService(c *gin.Context) {
+	projectName := c.Param("project")
+	serviceName := c.Param("service")
+
+	params := &models.UpdateServiceParams{}
+	if err := c.ShouldBindJSON(params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidRequestFormatMsg, err.Error()))
+		return
+	}
+	serviceValidator := ServiceParamsValidator{
+		ServiceNameMaxSize: sh.Env.ServiceNameMaxSize,
+	}
+	if err := serviceValidator.Validate(params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidPayloadMsg, err.Error()))
+		return
+	}
+
+	unlockProject, err := lockProject(sh.locker, projectName)
+	if err != nil {
+		SetInternalServerErrorResponse(c, err.Error())
+		return
+	}
+	defer unlockProject()
+
+	metadata, err := sh.serviceManager.UpdateService(projectName, serviceName, params)
+	if err != nil {
+		setServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, metadata)
+}
+
+func setServiceErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrProjectNotFound), errors.Is(err, ErrServiceNotFound):
+		SetNotFoundErrorResponse(c, err.Error())
+	case errors.Is(err, ErrServiceAlreadyExists), errors.Is(err, ErrServiceHasActiveSequences):
+		SetConflictErrorResponse(c, err.Error())
+	default:
+		SetInternalServerErrorResponse(c, fmt.Sprintf(UnableUpdateServiceMsg, err.Error()))
+	}
+}
+
 func (sh *ServiceHandler) sendServiceCreateStartedEvent(keptnContext string, projectName string, params *models.CreateServiceParams) error {
 	eventPayload := keptnv2.ServiceCreateStartedEventData{
 		EventData: keptnv2.EventData{
@@ -456,7 +456,7 @@ func TestServiceHandler_GetService(t *testing.T) {
 
 			c.Request, _ = http.NewRequest(http.MethodPost, "", bytes.NewBuffer([]byte{}))
 
-			sh := NewServiceHandler(tt.fields.serviceManager, tt.fields.EventSender, tt.fields.EnvConfig)
+			sh := NewServiceHandler(tt.fields.serviceManager, tt.fields.EventSender, tt.fields.EnvConfig, nil)
 
 			sh.GetService(c)
 
@@ -600,7 +600,7 @@ func TestServiceHandler_GetServices(t *testing.T) {
 
 			c.Request, _ = http.NewRequest(http.MethodPost, "", bytes.NewBuffer([]byte{}))
 
-			sh := NewServiceHandler(tt.fields.serviceManager, tt.fields.EventSender, tt.fields.EnvConfig)
+			sh := NewServiceHandler(tt.fields.serviceManager, tt.fields.EventSender, tt.fields.EnvConfig, nil)
 
 			sh.GetServices(c)
 
@@ -692,3 +692,131 @@ func TestServiceParamsValidator(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceHandler_UpdateService(t *testing.T) {
+	tests := []struct {
+		name             string
+		payload          string
+		updateErr        error
+		expectHttpStatus int
+		expectUpdate     bool
+	}{
+		{
+			name:             "update metadata",
+			payload:          `{"description": "my service", "owners": ["team-a"], "labels": {"tier": "backend"}}`,
+			expectHttpStatus: http.StatusOK,
+			expectUpdate:     true,
+		},
+		{
+			name:             "rename service",
+			payload:          `{"serviceName": "my-new-service"}`,
+			expectHttpStatus: http.StatusOK,
+			expectUpdate:     true,
+		},
+		{
+			name:             "invalid payload",
+			payload:          `invalid`,
+			expectHttpStatus: http.StatusBadRequest,
+		},
+		{
+			name:             "invalid service name",
+			payload:          `{"serviceName": "my/service"}`,
+			expectHttpStatus: http.StatusBadRequest,
+		},
+		{
+			name:             "service not found",
+			payload:          `{"description": "my service"}`,
+			updateErr:        ErrServiceNotFound,
+			expectHttpStatus: http.StatusNotFound,
+			expectUpdate:     true,
+		},
+		{
+			name:             "new service name already exists",
+			payload:          `{"serviceName": "my-new-service"}`,
+			updateErr:        ErrServiceAlreadyExists,
+			expectHttpStatus: http.StatusConflict,
+			expectUpdate:     true,
+		},
+		{
+			name:             "service has active sequences",
+			payload:          `{"serviceName": "my-new-service"}`,
+			updateErr:        ErrServiceHasActiveSequences,
+			expectHttpStatus: http.StatusConflict,
+			expectUpdate:     true,
+		},
+		{
+			name:             "internal error",
+			payload:          `{"description": "my service"}`,
+			updateErr:        errors.New("whoops"),
+			expectHttpStatus: http.StatusInternalServerError,
+			expectUpdate:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceManager := &fake.IServiceManagerMock{
+				UpdateServiceFunc: func(projectName string, serviceName string, params *models.UpdateServiceParams) (*models.ServiceMetadata, error) {
+					if tt.updateErr != nil {
+						return nil, tt.updateErr
+					}
+					return &models.ServiceMetadata{Project: projectName, Service: serviceName}, nil
+				},
+			}
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPut, "", bytes.NewBufferString(tt.payload))
+			c.Params = gin.Params{
+				gin.Param{Key: "project", Value: "my-project"},
+				gin.Param{Key: "service", Value: "my-service"},
+			}
+
+			handler := NewServiceHandler(serviceManager, &fake.IEventSenderMock{}, config.EnvConfig{ServiceNameMaxSize: 43}, nil)
+			handler.UpdateService(c)
+
+			assert.Equal(t, tt.expectHttpStatus, c.Writer.Status())
+			if !tt.expectUpdate {
+				assert.Empty(t, serviceManager.UpdateServiceCalls())
+				return
+			}
+			assert.Len(t, serviceManager.UpdateServiceCalls(), 1)
+			assert.Equal(t, "my-project", serviceManager.UpdateServiceCalls()[0].ProjectName)
+			assert.Equal(t, "my-service", serviceManager.UpdateServiceCalls()[0].ServiceName)
+		})
+	}
+}
+
+func TestServiceHandler_GetServiceMetadata(t *testing.T) {
+	serviceManager := &fake.IServiceManagerMock{
+		GetServiceMetadataFunc: func(projectName string, serviceName string) (*models.ServiceMetadata, error) {
+			if serviceName != "my-service" {
+				return nil, ErrServiceNotFound
+			}
+			return &models.ServiceMetadata{Project: projectName, Service: serviceName, Owners: []string{"team-a"}}, nil
+		},
+	}
+	handler := NewServiceHandler(serviceManager, &fake.IEventSenderMock{}, config.EnvConfig{}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{
+		gin.Param{Key: "project", Value: "my-project"},
+		gin.Param{Key: "service", Value: "my-service"},
+	}
+	handler.GetServiceMetadata(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	metadata := &models.ServiceMetadata{}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), metadata))
+	assert.Equal(t, []string{"team-a"}, metadata.Owners)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Params = gin.Params{
+		gin.Param{Key: "project", Value: "my-project"},
+		gin.Param{Key: "service", Value: "unknown-service"},
+	}
+	handler.GetServiceMetadata(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -3,7 +3,10 @@ package handler
 import (
 	"errors"
 	"fmt"
+	"time"
+
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/keptn/keptn/shipyard-controller/db"
 	"github.com/keptn/keptn/shipyard-controller/models"
@@ -20,19 +23,27 @@ type IServiceManager interface {
 	DeleteService(projectName, serviceName string) error
 	GetService(projectName, stageName, serviceName string) (*apimodels.ExpandedService, error)
 	GetAllServices(projectName, stageName string) ([]*apimodels.ExpandedService, error)
+	// GetServiceMetadata returns the description, owners and labels of a service
+	GetServiceMetadata(projectName, serviceName string) (*models.ServiceMetadata, error)
+	// UpdateService updates the description, owners and labels of a service, and renames it in all stages if a new name is given
+	UpdateService(projectName, serviceName string, params *models.UpdateServiceParams) (*models.ServiceMetadata, error)
 }
 
 type serviceManager struct {
-	projectMVRepo      db.ProjectMVRepo
-	configurationStore common.ConfigurationStore
-	uniformRepo        db.UniformRepo
+	projectMVRepo         db.ProjectMVRepo
+	configurationStore    common.ConfigurationStore
+	uniformRepo           db.UniformRepo
+	sequenceExecutionRepo db.SequenceExecutionRepo
+	serviceMetadataRepo   db.ServiceMetadataRepo
 }
 
-func NewServiceManager(servicesDBOperations db.ProjectMVRepo, configurationStore common.ConfigurationStore, uniformRepo db.UniformRepo) *serviceManager {
+func NewServiceManager(servicesDBOperations db.ProjectMVRepo, configurationStore common.ConfigurationStore, uniformRepo db.UniformRepo, sequenceExecutionRepo db.SequenceExecutionRepo, serviceMetadataRepo db.ServiceMetadataRepo) *serviceManager {
 	return &serviceManager{
-		projectMVRepo:      servicesDBOperations,
-		configurationStore: configurationStore,
-		uniformRepo:        uniformRepo,
+		projectMVRepo:         servicesDBOperations,
+		configurationStore:    configurationStore,
+		uniformRepo:           uniformRepo,
+		sequenceExecutionRepo: sequenceExecutionRepo,
+		serviceMetadataRepo:   serviceMetadataRepo,
 	}
 }
 
@@ -148,11 +159,181 @@ func (sm *serviceManager) DeleteService(projectName, serviceName string) error {
 			return sm.logAndReturnError(fmt.Sprintf("could not delete service %s from stage %s: %s", serviceName, stage.StageName, err.Error()))
 		}
 	}
+	if err := sm.serviceMetadataRepo.DeleteServiceMetadata(projectName, serviceName); err != nil {
+		return sm.logAndReturnError(fmt.Sprintf("could not delete metadata of service %s: %s", serviceName, err.Error()))
+	}
 	log.Infof("deleted service %s from project %s", serviceName, projectName)
 
 	return nil
 }
 
+func (sm *serviceManager) GetServiceMetadata(projectName, serviceName string) (*models.ServiceMetadata, error) {
+	project, err := sm.projectMVRepo.GetProject(projectName)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+	if len(getServiceStages(project, serviceName)) == 0 {
+		return nil, ErrServiceNotFound
+	}
+	return sm.getServiceMetadata(projectName, serviceName)
+}
+
+func (sm *serviceManager) UpdateService(projectName, serviceName string, params *models.UpdateServiceParams) (*models.ServiceMetadata, error) {
+	project, err := sm.projectMVRepo.GetProject(projectName)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+	if len(getServiceStages(project, serviceName)) == 0 {
+		return nil, ErrServiceNotFound
+	}
+
+	metadata, err := sm.getServiceMetadata(projectName, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	rename := params.ServiceName != nil && *params.ServiceName != serviceName
+	if rename {
+		newServiceName := *params.ServiceName
+		if len(getServiceStages(project, newServiceName)) > 0 {
+			return nil, ErrServiceAlreadyExists
+		}
+		if err := sm.checkNoActiveSequences(projectName, serviceName); err != nil {
+			return nil, err
+		}
+		if err := sm.renameService(project, serviceName, newServiceName); err != nil {
+			return nil, err
+		}
+		metadata.Service = newServiceName
+	}
+
+	if params.Description != nil {
+		metadata.Description = *params.Description
+	}
+	if params.Owners != nil {
+		metadata.Owners = params.Owners
+	}
+	if params.Labels != nil {
+		metadata.Labels = params.Labels
+	}
+	updatedAt := time.Now().UTC()
+	metadata.UpdatedAt = &updatedAt
+
+	if err := sm.serviceMetadataRepo.UpsertServiceMetadata(*metadata); err != nil {
+		return nil, fmt.Errorf("could not store metadata of service %s: %w", metadata.Service, err)
+	}
+	if rename {
+		if err := sm.serviceMetadataRepo.DeleteServiceMetadata(projectName, serviceName); err != nil {
+			log.Errorf("could not delete metadata of renamed service %s: %s", serviceName, err.Error())
+		}
+	}
+	return metadata, nil
+}
+
+// renameService moves the resources of the service to a service with the new name in every stage of the project,
+// and renames the service in the materialized view and in the filters of subscriptions. The state of the service in the materialized view,
+// e.g. the last deployment and open remediations, is retained
+func (sm *serviceManager) renameService(project *apimodels.ExpandedProject, serviceName, newServiceName string) error {
+	projectName := project.ProjectName
+	stages := getServiceStages(project, serviceName)
+	log.Infof("Renaming service %s of project %s to %s", serviceName, projectName, newServiceName)
+
+	for i, stage := range stages {
+		if err := sm.copyService(projectName, stage.StageName, serviceName, newServiceName); err != nil {
+			for _, copiedStage := range stages[:i+1] {
+				if err := sm.configurationStore.DeleteService(projectName, copiedStage.StageName, newServiceName); err != nil && !errors.Is(err, common.ErrServiceNotFound) {
+					log.Errorf("Rollback failed: could not delete service %s from stage %s: %s", newServiceName, copiedStage.StageName, err.Error())
+				}
+			}
+			return err
+		}
+	}
+	for _, stage := range stages {
+		if err := sm.configurationStore.DeleteService(projectName, stage.StageName, serviceName); err != nil && !errors.Is(err, common.ErrServiceNotFound) {
+			return fmt.Errorf("could not delete service %s from stage %s: %w", serviceName, stage.StageName, err)
+		}
+	}
+
+	for _, stage := range stages {
+		for _, service := range stage.Services {
+			if service.ServiceName == serviceName {
+				service.ServiceName = newServiceName
+			}
+		}
+	}
+	if err := sm.projectMVRepo.UpdateProject(project); err != nil {
+		return fmt.Errorf("could not rename service %s in project %s: %w", serviceName, projectName, err)
+	}
+
+	if err := sm.uniformRepo.RenameServiceInSubscriptions(projectName, serviceName, newServiceName); err != nil {
+		return fmt.Errorf("could not rename service %s in subscriptions: %w", serviceName, err)
+	}
+	return nil
+}
+
+func (sm *serviceManager) copyService(projectName, stageName, serviceName, newServiceName string) error {
+	if err := sm.configurationStore.CreateService(projectName, stageName, newServiceName); err != nil {
+		return fmt.Errorf("could not create service %s in stage %s: %w", newServiceName, stageName, err)
+	}
+	resources, err := sm.configurationStore.GetServiceResources(projectName, stageName, serviceName)
+	if err != nil {
+		return fmt.Errorf("could not load resources of service %s in stage %s: %w", serviceName, stageName, err)
+	}
+	if len(resources) == 0 {
+		return nil
+	}
+	if err := sm.configurationStore.CreateServiceResources(projectName, stageName, newServiceName, toAPIResources(toProjectArchiveResources(resources, nil))); err != nil {
+		return fmt.Errorf("could not copy resources of service %s in stage %s: %w", serviceName, stageName, err)
+	}
+	return nil
+}
+
+func (sm *serviceManager) checkNoActiveSequences(projectName, serviceName string) error {
+	sequenceExecutions, err := sm.sequenceExecutionRepo.Get(models.SequenceExecutionFilter{
+		Scope:  models.EventScope{EventData: keptnv2.EventData{Project: projectName, Service: serviceName}},
+		Status: activeSequenceStates,
+	})
+	if err != nil {
+		return fmt.Errorf("could not load sequence executions of service %s: %w", serviceName, err)
+	}
+	if len(sequenceExecutions) > 0 {
+		return ErrServiceHasActiveSequences
+	}
+	return nil
+}
+
+// getServiceMetadata returns the stored metadata of a service, or empty metadata if none has been stored yet
+func (sm *serviceManager) getServiceMetadata(projectName, serviceName string) (*models.ServiceMetadata, error) {
+	metadata, err := sm.serviceMetadataRepo.GetServiceMetadata(projectName, serviceName)
+	if err != nil {
+		if errors.Is(err, db.ErrServiceMetadataNotFound) {
+			return &models.ServiceMetadata{Project: projectName, Service: serviceName}, nil
+		}
+		return nil, fmt.Errorf("could not load metadata of service %s: %w", serviceName, err)
+	}
+	return metadata, nil
+}
+
+// getServiceStages returns the stages of the project containing the given service
+func getServiceStages(project *apimodels.ExpandedProject, serviceName string) []*apimodels.ExpandedStage {
+	stages := []*apimodels.ExpandedStage{}
+	for _, stage := range project.Stages {
+		for _, service := range stage.Services {
+			if service.ServiceName == serviceName {
+				stages = append(stages, stage)
+				break
+			}
+		}
+	}
+	return stages
+}
+
 func (sm *serviceManager) logAndReturnError(msg string) error {
 	log.Error(msg)
 	return errors.New(msg)
@@ -5,6 +5,7 @@ import (
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
 	"github.com/keptn/keptn/shipyard-controller/common"
 	common_mock "github.com/keptn/keptn/shipyard-controller/common/fake"
+	"github.com/keptn/keptn/shipyard-controller/db"
 	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
 	"github.com/keptn/keptn/shipyard-controller/models"
 	"github.com/stretchr/testify/assert"
@@ -15,7 +16,7 @@ func TestCreateService_GettingStagesFails(t *testing.T) {
 	projectMVRepo := &db_mock.ProjectMVRepoMock{}
 	configurationStore := &common_mock.ConfigurationStoreMock{}
 	uniformRepo := &db_mock.UniformRepoMock{}
-	instance := NewServiceManager(projectMVRepo, configurationStore, uniformRepo)
+	instance := NewServiceManager(projectMVRepo, configurationStore, uniformRepo, &db_mock.SequenceExecutionRepoMock{}, &db_mock.ServiceMetadataRepoMock{})
 
 	params := &models.CreateServiceParams{
 		ServiceName: common.Stringp("service-name"),
@@ -33,7 +34,7 @@ func TestCreateService_ServiceAlreadyExists(t *testing.T) {
 	projectMVRepo := &db_mock.ProjectMVRepoMock{}
 	configurationStore := &common_mock.ConfigurationStoreMock{}
 	uniformRepo := &db_mock.UniformRepoMock{}
-	instance := NewServiceManager(projectMVRepo, configurationStore, uniformRepo)
+	instance := NewServiceManager(projectMVRepo, configurationStore, uniformRepo, &db_mock.SequenceExecutionRepoMock{}, &db_mock.ServiceMetadataRepoMock{})
 
 	params := &models.CreateServiceParams{
 		ServiceName: common.Stringp("service-name"),
@@ -67,7 +68,7 @@ func TestCreatService_CreatingServiceInConfigurationServiceFails(t *testing.T) {
 	projectMVRepo := &db_mock.ProjectMVRepoMock{}
 	configurationStore := &common_mock.ConfigurationStoreMock{}
 	uniformRepo := &db_mock.UniformRepoMock{}
-	instance := NewServiceManager(projectMVRepo, configurationStore, uniformRepo)
+	instance := NewServiceManager(projectMVRepo, configurationStore, uniformRepo, &db_mock.SequenceExecutionRepoMock{}, &db_mock.ServiceMetadataRepoMock{})
 
 	params := &models.CreateServiceParams{
 		ServiceName: common.Stringp("service-name"),
@@ -105,7 +106,7 @@ func TestCreatService_CreatingServiceInDBFails(t *testing.T) {
 	projectMVRepo := &db_mock.ProjectMVRepoMock{}
 	configurationStore := &common_mock.ConfigurationStoreMock{}
 	uniformRepo := &db_mock.UniformRepoMock{}
-	instance := NewServiceManager(projectMVRepo, configurationStore, uniformRepo)
+	instance := NewServiceManager(projectMVRepo, configurationStore, uniformRepo, &db_mock.SequenceExecutionRepoMock{}, &db_mock.ServiceMetadataRepoMock{})
 
 	params := &models.CreateServiceParams{
 		ServiceName: common.Stringp("service-name"),
@@ -147,7 +148,7 @@ func TestCreateService(t *testing.T) {
 	projectMVRepo := &db_mock.ProjectMVRepoMock{}
 	configurationStore := &common_mock.ConfigurationStoreMock{}
 	uniformRepo := &db_mock.UniformRepoMock{}
-	instance := NewServiceManager(projectMVRepo, configurationStore, uniformRepo)
+	instance := NewServiceManager(projectMVRepo, configurationStore, uniformRepo, &db_mock.SequenceExecutionRepoMock{}, &db_mock.ServiceMetadataRepoMock{})
 	params := &models.CreateServiceParams{
 		ServiceName: common.Stringp("service-name"),
 	}
@@ -203,7 +204,7 @@ func TestDeleteService_GettingAllStagesFails(t *testing.T) {
 	projectMVRepo := &db_mock.ProjectMVRepoMock{}
 	configurationStore := &common_mock.ConfigurationStoreMock{}
 	uniformRepo := &db_mock.UniformRepoMock{}
-	instance := NewServiceManager(projectMVRepo, configurationStore, uniformRepo)
+	instance := NewServiceManager(projectMVRepo, configurationStore, uniformRepo, &db_mock.SequenceExecutionRepoMock{}, &db_mock.ServiceMetadataRepoMock{})
 	projectMVRepo.GetProjectFunc = func(projectName string) (*apimodels.ExpandedProject, error) {
 		return nil, errors.New("whoops")
 	}
@@ -217,7 +218,7 @@ func TestDeleteService_DeleteServiceInConfigurationServiceFails(t *testing.T) {
 	projectMVRepo := &db_mock.ProjectMVRepoMock{}
 	configurationStore := &common_mock.ConfigurationStoreMock{}
 	uniformRepo := &db_mock.UniformRepoMock{}
-	instance := NewServiceManager(projectMVRepo, configurationStore, uniformRepo)
+	instance := NewServiceManager(projectMVRepo, configurationStore, uniformRepo, &db_mock.SequenceExecutionRepoMock{}, &db_mock.ServiceMetadataRepoMock{})
 	projectMVRepo.GetProjectFunc = func(projectName string) (*apimodels.ExpandedProject, error) {
 		service := &apimodels.ExpandedService{
 			ServiceName: "service-name",
@@ -252,7 +253,12 @@ func TestDeleteService_DeleteServiceInConfigurationServiceReturnsServiceNotFound
 	projectMVRepo := &db_mock.ProjectMVRepoMock{}
 	configurationStore := &common_mock.ConfigurationStoreMock{}
 	uniformRepo := &db_mock.UniformRepoMock{}
-	instance := NewServiceManager(projectMVRepo, configurationStore, uniformRepo)
+	serviceMetadataRepo := &db_mock.ServiceMetadataRepoMock{
+		DeleteServiceMetadataFunc: func(projectName string, serviceName string) error {
+			return nil
+		},
+	}
+	instance := NewServiceManager(projectMVRepo, configurationStore, uniformRepo, &db_mock.SequenceExecutionRepoMock{}, serviceMetadataRepo)
 	projectMVRepo.GetProjectFunc = func(projectName string) (*apimodels.ExpandedProject, error) {
 		service := &apimodels.ExpandedService{
 			ServiceName: "service-name",
@@ -296,7 +302,7 @@ func TestDeleteService_DeleteServiceInDBFails(t *testing.T) {
 	projectMVRepo := &db_mock.ProjectMVRepoMock{}
 	configurationStore := &common_mock.ConfigurationStoreMock{}
 	uniformRepo := &db_mock.UniformRepoMock{}
-	instance := NewServiceManager(projectMVRepo, configurationStore, uniformRepo)
+	instance := NewServiceManager(projectMVRepo, configurationStore, uniformRepo, &db_mock.SequenceExecutionRepoMock{}, &db_mock.ServiceMetadataRepoMock{})
 	projectMVRepo.GetProjectFunc = func(projectName string) (*apimodels.ExpandedProject, error) {
 		service := &apimodels.ExpandedService{
 			ServiceName: "service-name",
@@ -335,7 +341,12 @@ func TestDeleteService(t *testing.T) {
 	projectMVRepo := &db_mock.ProjectMVRepoMock{}
 	configurationStore := &common_mock.ConfigurationStoreMock{}
 	uniformRepo := &db_mock.UniformRepoMock{}
-	instance := NewServiceManager(projectMVRepo, configurationStore, uniformRepo)
+	serviceMetadataRepo := &db_mock.ServiceMetadataRepoMock{
+		DeleteServiceMetadataFunc: func(projectName string, serviceName string) error {
+			return nil
+		},
+	}
+	instance := NewServiceManager(projectMVRepo, configurationStore, uniformRepo, &db_mock.SequenceExecutionRepoMock{}, serviceMetadataRepo)
 	projectMVRepo.GetProjectFunc = func(projectName string) (*apimodels.ExpandedProject, error) {
 		service := &apimodels.ExpandedService{
 			ServiceName: "service-name",
@@ -387,4 +398,187 @@ func TestDeleteService(t *testing.T) {
 	assert.Equal(t, "my-project", projectMVRepo.DeleteServiceCalls()[1].Project)
 	assert.Equal(t, "prod", projectMVRepo.DeleteServiceCalls()[1].Stage)
 	assert.Equal(t, "my-service", projectMVRepo.DeleteServiceCalls()[1].Service)
+
+	assert.Len(t, serviceMetadataRepo.DeleteServiceMetadataCalls(), 1)
+}
+
+func newServiceUpdateTestProject() *apimodels.ExpandedProject {
+	return &apimodels.ExpandedProject{
+		ProjectName: "my-project",
+		Stages: []*apimodels.ExpandedStage{
+			{
+				StageName: "dev",
+				Services: []*apimodels.ExpandedService{
+					{ServiceName: "my-service", DeployedImage: "my-image:1.0"},
+					{ServiceName: "other-service"},
+				},
+			},
+			{
+				StageName: "prod",
+				Services:  []*apimodels.ExpandedService{{ServiceName: "my-service"}},
+			},
+		},
+	}
+}
+
+func TestUpdateService_Metadata(t *testing.T) {
+	projectMVRepo := &db_mock.ProjectMVRepoMock{
+		GetProjectFunc: func(projectName string) (*apimodels.ExpandedProject, error) {
+			return newServiceUpdateTestProject(), nil
+		},
+	}
+	serviceMetadataRepo := &db_mock.ServiceMetadataRepoMock{
+		GetServiceMetadataFunc: func(projectName string, serviceName string) (*models.ServiceMetadata, error) {
+			return &models.ServiceMetadata{Project: projectName, Service: serviceName, Description: "my service", Owners: []string{"team-a"}}, nil
+		},
+		UpsertServiceMetadataFunc: func(metadata models.ServiceMetadata) error {
+			return nil
+		},
+	}
+	instance := NewServiceManager(projectMVRepo, &common_mock.ConfigurationStoreMock{}, &db_mock.UniformRepoMock{}, &db_mock.SequenceExecutionRepoMock{}, serviceMetadataRepo)
+
+	metadata, err := instance.UpdateService("my-project", "my-service", &models.UpdateServiceParams{
+		Labels: map[string]string{"tier": "backend"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "my-service", metadata.Service)
+	assert.Equal(t, "my service", metadata.Description)
+	assert.Equal(t, []string{"team-a"}, metadata.Owners)
+	assert.Equal(t, map[string]string{"tier": "backend"}, metadata.Labels)
+	assert.NotNil(t, metadata.UpdatedAt)
+
+	assert.Len(t, serviceMetadataRepo.UpsertServiceMetadataCalls(), 1)
+	assert.Equal(t, *metadata, serviceMetadataRepo.UpsertServiceMetadataCalls()[0].Metadata)
+}
+
+func TestUpdateService_ServiceNotFound(t *testing.T) {
+	projectMVRepo := &db_mock.ProjectMVRepoMock{
+		GetProjectFunc: func(projectName string) (*apimodels.ExpandedProject, error) {
+			return newServiceUpdateTestProject(), nil
+		},
+	}
+	instance := NewServiceManager(projectMVRepo, &common_mock.ConfigurationStoreMock{}, &db_mock.UniformRepoMock{}, &db_mock.SequenceExecutionRepoMock{}, &db_mock.ServiceMetadataRepoMock{})
+
+	_, err := instance.UpdateService("my-project", "unknown-service", &models.UpdateServiceParams{Description: common.Stringp("my service")})
+	assert.ErrorIs(t, err, ErrServiceNotFound)
+}
+
+func TestUpdateService_RenameToExistingService(t *testing.T) {
+	projectMVRepo := &db_mock.ProjectMVRepoMock{
+		GetProjectFunc: func(projectName string) (*apimodels.ExpandedProject, error) {
+			return newServiceUpdateTestProject(), nil
+		},
+	}
+	serviceMetadataRepo := &db_mock.ServiceMetadataRepoMock{
+		GetServiceMetadataFunc: func(projectName string, serviceName string) (*models.ServiceMetadata, error) {
+			return nil, db.ErrServiceMetadataNotFound
+		},
+	}
+	configurationStore := &common_mock.ConfigurationStoreMock{}
+	instance := NewServiceManager(projectMVRepo, configurationStore, &db_mock.UniformRepoMock{}, &db_mock.SequenceExecutionRepoMock{}, serviceMetadataRepo)
+
+	_, err := instance.UpdateService("my-project", "my-service", &models.UpdateServiceParams{ServiceName: common.Stringp("other-service")})
+	assert.ErrorIs(t, err, ErrServiceAlreadyExists)
+	assert.Empty(t, configurationStore.CreateServiceCalls())
+}
+
+func TestUpdateService_RenameWithActiveSequences(t *testing.T) {
+	projectMVRepo := &db_mock.ProjectMVRepoMock{
+		GetProjectFunc: func(projectName string) (*apimodels.ExpandedProject, error) {
+			return newServiceUpdateTestProject(), nil
+		},
+	}
+	serviceMetadataRepo := &db_mock.ServiceMetadataRepoMock{
+		GetServiceMetadataFunc: func(projectName string, serviceName string) (*models.ServiceMetadata, error) {
+			return nil, db.ErrServiceMetadataNotFound
+		},
+	}
+	sequenceExecutionRepo := &db_mock.SequenceExecutionRepoMock{
+		GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+			return []models.SequenceExecution{{ID: "my-sequence"}}, nil
+		},
+	}
+	configurationStore := &common_mock.ConfigurationStoreMock{}
+	instance := NewServiceManager(projectMVRepo, configurationStore, &db_mock.UniformRepoMock{}, sequenceExecutionRepo, serviceMetadataRepo)
+
+	_, err := instance.UpdateService("my-project", "my-service", &models.UpdateServiceParams{ServiceName: common.Stringp("my-new-service")})
+	assert.ErrorIs(t, err, ErrServiceHasActiveSequences)
+	assert.Equal(t, "my-service", sequenceExecutionRepo.GetCalls()[0].Filter.Scope.Service)
+	assert.Empty(t, configurationStore.CreateServiceCalls())
+}
+
+func TestUpdateService_Rename(t *testing.T) {
+	var updatedProject *apimodels.ExpandedProject
+	projectMVRepo := &db_mock.ProjectMVRepoMock{
+		GetProjectFunc: func(projectName string) (*apimodels.ExpandedProject, error) {
+			return newServiceUpdateTestProject(), nil
+		},
+		UpdateProjectFunc: func(prj *apimodels.ExpandedProject) error {
+			updatedProject = prj
+			return nil
+		},
+	}
+	configurationStore := &common_mock.ConfigurationStoreMock{
+		CreateServiceFunc: func(projectName string, stageName string, serviceName string) error {
+			return nil
+		},
+		GetServiceResourcesFunc: func(projectName string, stageName string, serviceName string) ([]*apimodels.Resource, error) {
+			return []*apimodels.Resource{{ResourceURI: common.Stringp("helm/chart.tgz"), ResourceContent: "chart"}}, nil
+		},
+		CreateServiceResourcesFunc: func(projectName string, stageName string, serviceName string, resources []*apimodels.Resource) error {
+			return nil
+		},
+		DeleteServiceFunc: func(projectName string, stageName string, serviceName string) error {
+			return nil
+		},
+	}
+	uniformRepo := &db_mock.UniformRepoMock{
+		RenameServiceInSubscriptionsFunc: func(projectName string, serviceName string, newServiceName string) error {
+			return nil
+		},
+	}
+	sequenceExecutionRepo := &db_mock.SequenceExecutionRepoMock{
+		GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+			return nil, nil
+		},
+	}
+	serviceMetadataRepo := &db_mock.ServiceMetadataRepoMock{
+		GetServiceMetadataFunc: func(projectName string, serviceName string) (*models.ServiceMetadata, error) {
+			return &models.ServiceMetadata{Project: projectName, Service: serviceName, Owners: []string{"team-a"}}, nil
+		},
+		UpsertServiceMetadataFunc: func(metadata models.ServiceMetadata) error {
+			return nil
+		},
+		DeleteServiceMetadataFunc: func(projectName string, serviceName string) error {
+			return nil
+		},
+	}
+	instance := NewServiceManager(projectMVRepo, configurationStore, uniformRepo, sequenceExecutionRepo, serviceMetadataRepo)
+
+	metadata, err := instance.UpdateService("my-project", "my-service", &models.UpdateServiceParams{ServiceName: common.Stringp("my-new-service")})
+	assert.Nil(t, err)
+	assert.Equal(t, "my-new-service", metadata.Service)
+	assert.Equal(t, []string{"team-a"}, metadata.Owners)
+
+	// the service is created with its resources in every stage, and the old service is removed
+	assert.Len(t, configurationStore.CreateServiceCalls(), 2)
+	assert.Equal(t, "my-new-service", configurationStore.CreateServiceCalls()[0].ServiceName)
+	assert.Len(t, configurationStore.CreateServiceResourcesCalls(), 2)
+	assert.Equal(t, "helm/chart.tgz", *configurationStore.CreateServiceResourcesCalls()[0].Resources[0].ResourceURI)
+	assert.Len(t, configurationStore.DeleteServiceCalls(), 2)
+	assert.Equal(t, "my-service", configurationStore.DeleteServiceCalls()[0].ServiceName)
+
+	// the state of the service is retained in the materialized view
+	assert.NotNil(t, updatedProject)
+	assert.Equal(t, "my-new-service", updatedProject.Stages[0].Services[0].ServiceName)
+	assert.Equal(t, "my-image:1.0", updatedProject.Stages[0].Services[0].DeployedImage)
+	assert.Equal(t, "other-service", updatedProject.Stages[0].Services[1].ServiceName)
+	assert.Equal(t, "my-new-service", updatedProject.Stages[1].Services[0].ServiceName)
+
+	assert.Len(t, uniformRepo.RenameServiceInSubscriptionsCalls(), 1)
+	assert.Equal(t, "my-new-service", uniformRepo.RenameServiceInSubscriptionsCalls()[0].NewServiceName)
+
+	assert.Equal(t, "my-new-service", serviceMetadataRepo.UpsertServiceMetadataCalls()[0].Metadata.Service)
+	assert.Len(t, serviceMetadataRepo.DeleteServiceMetadataCalls(), 1)
+	assert.Equal(t, "my-service", serviceMetadataRepo.DeleteServiceMetadataCalls()[0].ServiceName)
 }
@@ -11,15 +11,20 @@ import (
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
 	"github.com/keptn/go-utils/pkg/common/timeutils"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	commontracing "github.com/keptn/keptn/cp-common/tracing"
 	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/keptn/keptn/shipyard-controller/db"
 	"github.com/keptn/keptn/shipyard-controller/handler/sequencehooks"
 	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/keptn/keptn/shipyard-controller/tracing"
 	log "github.com/sirupsen/logrus"
 )
 
 const maxRepoReadRetries = 5
 
+// sequenceLockTimeout is the maximum duration to wait for another replica to finish processing an event of the same sequence
+const sequenceLockTimeout = 1 * time.Minute
+
 const couldNotGetActiveSequencesErrMsg = "unable to get active task executions for project %s in stage %s for Keptn context %s: %w"
 const noActiveSequencesErrMsg = "no active task executions for project %s in stage %s for Keptn context %s found"
 
@@ -31,6 +36,7 @@ type IShipyardController interface {
 	GetTriggeredEventsOfProject(project string, filter common.EventFilter) ([]apimodels.KeptnContextExtendedCE, error)
 	HandleIncomingEvent(event apimodels.KeptnContextExtendedCE, waitForCompletion bool) error
 	ControlSequence(controlSequence apimodels.SequenceControl) error
+	RetrySequence(controlSequence apimodels.SequenceControl, fromTask string) error
 	StartTaskSequence(event apimodels.KeptnContextExtendedCE) error
 	StartDispatchers(ctx context.Context, mode common.SDMode)
 	StopDispatchers()
@@ -42,7 +48,7 @@ type shipyardController struct {
 	projectMvRepo              db.ProjectMVRepo
 	eventDispatcher            IEventDispatcher
 	sequenceDispatcher         ISequenceDispatcher
-	sequenceTimeoutChan        chan apimodels.SequenceTimeout
+	sequenceTimeoutChan        chan models.SequenceTimeout
 	sequenceTriggeredHooks     []sequencehooks.ISequenceTriggeredHook
 	sequenceStartedHooks       []sequencehooks.ISequenceStartedHook
 	sequenceWaitingHooks       []sequencehooks.ISequenceWaitingHook
@@ -56,14 +62,18 @@ type shipyardController struct {
 	sequencePausedHooks        []sequencehooks.ISequencePausedHook
 	sequenceResumedHooks       []sequencehooks.ISequenceResumedHook
 	shipyardRetriever          IShipyardRetriever
+	locker                     ILocker
+	priorityClasses            common.PriorityClasses
 }
 
 func GetShipyardControllerInstance(
 	ctx context.Context,
 	eventDispatcher IEventDispatcher,
 	sequenceDispatcher ISequenceDispatcher,
-	sequenceTimeoutChannel chan apimodels.SequenceTimeout,
+	sequenceTimeoutChannel chan models.SequenceTimeout,
 	shipyardRetriever IShipyardRetriever,
+	locker ILocker,
+	priorityClasses common.PriorityClasses,
 ) *shipyardController {
 	if shipyardControllerInstance == nil {
 		cbConnectionInstance := db.GetMongoDBConnectionInstance()
@@ -77,6 +87,8 @@ func GetShipyardControllerInstance(
 			sequenceDispatcher:  sequenceDispatcher,
 			sequenceTimeoutChan: sequenceTimeoutChannel,
 			shipyardRetriever:   shipyardRetriever,
+			locker:              locker,
+			priorityClasses:     priorityClasses,
 		}
 		shipyardControllerInstance.run(ctx)
 	}
@@ -90,7 +102,9 @@ func (sc *shipyardController) run(ctx context.Context) {
 			case <-ctx.Done():
 				return
 			case timeoutSequence := <-sc.sequenceTimeoutChan:
-				err := sc.timeoutSequence(timeoutSequence)
+				err := sc.handleWithLock(timeoutSequence.LastEvent, func(ctx context.Context, event apimodels.KeptnContextExtendedCE) error {
+					return sc.timeoutSequence(ctx, timeoutSequence)
+				})
 				if err != nil {
 					log.WithError(err).Error("Unable to cancel sequence")
 					return
@@ -126,12 +140,118 @@ func (sc *shipyardController) ControlSequence(controlSequence apimodels.Sequence
 			KeptnContext: controlSequence.KeptnContext,
 		})
 		return sc.resumeSequence(controlSequence)
+	case models.OverrideFreezeSequence:
+		log.Info("Processing OVERRIDE FREEZE sequence control")
+		return sc.overrideFreeze(controlSequence)
+	case models.RetrySequence:
+		return sc.RetrySequence(controlSequence, "")
 	}
 	return nil
 }
 
+// RetrySequence triggers the failed sequence executions of the given keptnContext again. The results of the tasks that have been completed before the given task are kept.
+// If no task is given, the sequences are retried from their first failed task
+func (sc *shipyardController) RetrySequence(controlSequence apimodels.SequenceControl, fromTask string) error {
+	log.Info("Processing RETRY sequence control")
+	sequenceExecutions, err := sc.sequenceExecutionRepo.Get(models.SequenceExecutionFilter{Scope: models.EventScope{
+		KeptnContext: controlSequence.KeptnContext,
+		EventData: keptnv2.EventData{
+			Project: controlSequence.Project,
+			Stage:   controlSequence.Stage,
+		},
+	}})
+	if err != nil {
+		return fmt.Errorf(couldNotGetActiveSequencesErrMsg, controlSequence.Project, controlSequence.Stage, controlSequence.KeptnContext, err)
+	}
+
+	if len(sequenceExecutions) == 0 {
+		return ErrSequenceNotFound
+	}
+
+	nrRetriedSequences := 0
+	for _, sequenceExecution := range getLatestSequenceExecutionPerStage(sequenceExecutions) {
+		if !sequenceExecution.CanBeRetried() {
+			continue
+		}
+		if err := sc.retrySequenceExecution(sequenceExecution, fromTask); err != nil {
+			return err
+		}
+		nrRetriedSequences++
+	}
+
+	if nrRetriedSequences == 0 {
+		return fmt.Errorf("%w: no failed sequence found for Keptn context %s", ErrSequenceNotRetryable, controlSequence.KeptnContext)
+	}
+	return nil
+}
+
+// retrySequenceExecution creates a copy of the given sequence execution that continues under the same keptnContext, starting at the given task
+func (sc *shipyardController) retrySequenceExecution(sequenceExecution models.SequenceExecution, fromTask string) error {
+	previousTasks, err := sequenceExecution.GetPreviousTasksForRetry(fromTask)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSequenceNotRetryable, err)
+	}
+
+	// the retried sequence is started by a new '.triggered' event containing the same input as the original one
+	payload := common.CopyMap(sequenceExecution.InputProperties)
+	payload["project"] = sequenceExecution.Scope.Project
+	payload["stage"] = sequenceExecution.Scope.Stage
+	payload["service"] = sequenceExecution.Scope.Service
+
+	eventType := keptnv2.GetTriggeredEventType(sequenceExecution.Scope.Stage + "." + sequenceExecution.Sequence.Name)
+	triggeredEvent, err := models.ConvertToEvent(common.CreateEventWithPayload(sequenceExecution.Scope.KeptnContext, "", eventType, payload))
+	if err != nil {
+		return fmt.Errorf("could not create event for retrying sequence %s: %w", sequenceExecution.Sequence.Name, err)
+	}
+	eventScope, err := models.NewEventScope(*triggeredEvent)
+	if err != nil {
+		return fmt.Errorf("unable to create event scope: %w", err)
+	}
+
+	sc.appendLatestCommitIDToEvent(*eventScope, &eventScope.WrappedEvent)
+	if err := sc.eventRepo.InsertEvent(eventScope.Project, eventScope.WrappedEvent, common.TriggeredEvent); err != nil {
+		return fmt.Errorf("could not store event that triggered task sequence: %w", err)
+	}
+
+	retriedSequenceExecution := models.SequenceExecution{
+		ID:       uuid.New().String(),
+		Sequence: sequenceExecution.Sequence,
+		Status: models.SequenceExecutionStatus{
+			State:         apimodels.SequenceTriggeredState,
+			PreviousTasks: previousTasks,
+		},
+		InputProperties: sequenceExecution.InputProperties,
+		Scope:           *eventScope,
+		TriggeredAt:     time.Now().UTC(),
+		Priority:        sequenceExecution.Priority,
+		Timeout:         sequenceExecution.Timeout,
+		TaskAttributes:  sequenceExecution.TaskAttributes,
+	}
+	retriedSequenceExecution.Scope.TriggeredID = eventScope.WrappedEvent.ID
+	retriedSequenceExecution.Scope.GitCommitID = eventScope.WrappedEvent.GitCommitID
+
+	log.Infof("Retrying sequence %s with keptnContext %s in stage %s after %d completed tasks", sequenceExecution.Sequence.Name, eventScope.KeptnContext, eventScope.Stage, len(previousTasks))
+	return sc.queueSequenceExecution(retriedSequenceExecution, *eventScope)
+}
+
+// getLatestSequenceExecutionPerStage returns the most recently triggered sequence execution of each stage
+func getLatestSequenceExecutionPerStage(sequenceExecutions []models.SequenceExecution) []models.SequenceExecution {
+	result := []models.SequenceExecution{}
+	stageIndices := map[string]int{}
+	for _, sequenceExecution := range sequenceExecutions {
+		index, ok := stageIndices[sequenceExecution.Scope.Stage]
+		if !ok {
+			stageIndices[sequenceExecution.Scope.Stage] = len(result)
+			result = append(result, sequenceExecution)
+		} else if sequenceExecution.TriggeredAt.After(result[index].TriggeredAt) {
+			result[index] = sequenceExecution
+		}
+	}
+	return result
+}
+
 func (sc shipyardController) StartDispatchers(ctx context.Context, mode common.SDMode) {
-	sc.eventDispatcher.Run(ctx)
+	sc.eventDispatcher.Run(ctx, mode)
 	sc.sequenceDispatcher.Run(ctx, mode, sc.StartTaskSequence)
 }
 
@@ -156,12 +276,20 @@ func (sc *shipyardController) HandleIncomingEvent(event apimodels.KeptnContextEx
 
 	log.Infof("Received event of type %s from %s for project %s", *event.Type, *event.Source, eventData.Project)
 	log.Debugf("Context of event %s, sent by %s: %s", *event.Type, *event.Source, ObjToJSON(event))
-	cb := getCompletionCallback(waitForCompletion, done)
+
+	// the events created while handling the incoming event continue the trace via the span created here
+	ctx, span := tracing.StartEventSpan(event, "shipyard-controller.HandleIncomingEvent")
+	commontracing.InjectIntoEvent(ctx, &event)
+	completionCallback := getCompletionCallback(waitForCompletion, done)
+	cb := func(err error) {
+		tracing.EndSpan(span, err)
+		completionCallback(err)
+	}
 
 	switch statusType {
 	case string(common.TriggeredEvent):
 		go func() {
-			err := sc.handleSequenceTriggered(event)
+			err := sc.handleWithLock(event, sc.handleSequenceTriggered)
 			if err != nil {
 				log.WithError(err).Error("Unable to handle sequence '.triggered' event")
 			}
@@ -169,7 +297,7 @@ func (sc *shipyardController) HandleIncomingEvent(event apimodels.KeptnContextEx
 		}()
 	case string(common.StartedEvent), string(common.FinishedEvent):
 		go func() {
-			err := sc.handleTaskEvent(event)
+			err := sc.handleWithLock(event, sc.handleTaskEvent)
 			if err != nil {
 				if errors.Is(err, ErrSequenceNotFound) || errors.Is(err, models.ErrInvalidEventScope) {
 					log.Infof("Unable to handle task event: %v", err)
@@ -180,11 +308,25 @@ func (sc *shipyardController) HandleIncomingEvent(event apimodels.KeptnContextEx
 			cb(err)
 		}()
 	default:
+		span.End()
 		return nil
 	}
 	return completeEventHandler(waitForCompletion, done)
 }
 
+// handleWithLock ensures that events belonging to the same sequence in the same stage are processed one after another,
+// even if they are received by different replicas
+func (sc *shipyardController) handleWithLock(event apimodels.KeptnContextExtendedCE, handle func(ctx context.Context, event apimodels.KeptnContextExtendedCE) error) error {
+	eventScope, err := models.NewEventScope(event)
+	if err != nil {
+		// the handler will take care of reporting the invalid event scope
+		return handle(context.Background(), event)
+	}
+	return withBlockingLock(sc.locker, sequenceContextLockKey(*eventScope), sequenceLockTimeout, func(ctx context.Context) error {
+		return handle(ctx, event)
+	})
+}
+
 func completeEventHandler(waitForCompletion bool, done chan error) error {
 	if waitForCompletion {
 		return <-done
@@ -200,7 +342,9 @@ func getCompletionCallback(waitForCompletion bool, done chan error) func(err err
 	}
 }
 
-func (sc *shipyardController) handleSequenceTriggered(event apimodels.KeptnContextExtendedCE) error {
+// handleSequenceTriggered creates a sequence execution for a sequence '.triggered' event.
+// The given context belongs to the lock on the sequence, which must still be held when the sequence execution is stored
+func (sc *shipyardController) handleSequenceTriggered(ctx context.Context, event apimodels.KeptnContextExtendedCE) error {
 	eventScope, err := models.NewEventScope(event)
 	if err != nil {
 		return fmt.Errorf("unable to create event scope: %w", err)
@@ -233,6 +377,17 @@ func (sc *shipyardController) handleSequenceTriggered(event apimodels.KeptnConte
 		return sc.triggerSequenceFailed(*eventScope, msg, taskSequenceName)
 	}
 
+	// reject the sequence if a freeze window that rejects sequences is currently active in the stage
+	freezeWindow, err := getActiveFreezeWindow(sc.shipyardRetriever, eventScope.Project, eventScope.Stage, taskSequenceName, time.Now())
+	if err != nil {
+		// log the error but continue
+		log.WithError(err).Errorf("Unable to determine freeze windows of stage %s", eventScope.Stage)
+	} else if freezeWindow != nil && freezeWindow.GetAction() == common.FreezeActionReject {
+		msg := fmt.Sprintf("Unable to start sequence %s: %s", taskSequenceName, getFreezeWindowMessage(*freezeWindow, eventScope.Stage))
+		log.Info(msg)
+		return sc.triggerSequenceFailed(*eventScope, msg, taskSequenceName)
+	}
+
 	sc.appendLatestCommitIDToEvent(*eventScope, &eventScope.WrappedEvent)
 	if err := sc.eventRepo.InsertEvent(eventScope.Project, eventScope.WrappedEvent, common.TriggeredEvent); err != nil {
 		log.Infof("could not store event that triggered task sequence: %s", err.Error())
@@ -254,11 +409,45 @@ func (sc *shipyardController) handleSequenceTriggered(event apimodels.KeptnConte
 		InputProperties: inputProperties,
 		Scope:           *eventScope,
 		TriggeredAt:     time.Now().UTC(),
+		Priority:        sc.priorityClasses.GetPriority(taskSequenceName, eventScope.Labels),
+		Timeout:         sc.getSequenceTimeout(eventScope.Project, eventScope.Stage, taskSequenceName),
+		TaskAttributes:  sc.getTaskAttributes(eventScope.Project, eventScope.Stage, taskSequenceName),
+		FencingToken:    fencingTokenFromContext(ctx),
 	}
 	sequenceExecution.Scope.TriggeredID = event.ID
 	sequenceExecution.Scope.GitCommitID = eventScope.WrappedEvent.GitCommitID
 
-	if sc.sequenceExecutionRepo.IsContextPaused(*eventScope) {
+	if err := checkLockHeld(ctx); err != nil {
+		return fmt.Errorf("could not store sequence execution of sequence %s: %w", taskSequenceName, err)
+	}
+	return sc.queueSequenceExecution(sequenceExecution, *eventScope)
+}
+
+// getSequenceTimeout returns the timeout of the sequence as defined in the shipyard, or an empty string if the sequence has no timeout
+func (sc *shipyardController) getSequenceTimeout(projectName, stageName, sequenceName string) string {
+	timeouts, err := sc.shipyardRetriever.GetCachedSequenceTimeouts(projectName)
+	if err != nil {
+		// log the error but continue
+		log.WithError(err).Errorf("Unable to determine timeout of sequence %s in stage %s", sequenceName, stageName)
+		return ""
+	}
+	return timeouts[stageName][sequenceName]
+}
+
+// getTaskAttributes returns the attributes of the tasks of the sequence as defined in the shipyard, or nil if they could not be determined
+func (sc *shipyardController) getTaskAttributes(projectName, stageName, sequenceName string) []common.TaskAttributes {
+	taskAttributes, err := sc.shipyardRetriever.GetCachedTaskAttributes(projectName)
+	if err != nil {
+		// log the error but continue
+		log.WithError(err).Errorf("Unable to determine task attributes of sequence %s in stage %s", sequenceName, stageName)
+		return nil
+	}
+	return taskAttributes[stageName][sequenceName]
+}
+
+// queueSequenceExecution stores the given sequence execution and adds it to the queue of the sequence dispatcher
+func (sc *shipyardController) queueSequenceExecution(sequenceExecution models.SequenceExecution, eventScope models.EventScope) error {
+	if sc.sequenceExecutionRepo.IsContextPaused(eventScope) {
 		sequenceExecution.Pause()
 	}
 
@@ -268,11 +457,17 @@ func (sc *shipyardController) handleSequenceTriggered(event apimodels.KeptnConte
 	}
 
 	sc.onSequenceTriggered(eventScope.WrappedEvent)
-	err = sc.sequenceDispatcher.Add(models.QueueItem{
-		Scope:     *eventScope,
+	err := sc.sequenceDispatcher.Add(models.QueueItem{
+		Scope:     eventScope,
 		EventID:   eventScope.WrappedEvent.ID,
 		Timestamp: eventScope.WrappedEvent.Time,
+		Priority:  sequenceExecution.Priority,
 	})
+	if errors.Is(err, ErrSequenceFrozen) || errors.Is(err, ErrConcurrencyLimitReached) {
+		log.Infof("Sequence %s with keptnContext %s has been queued: %v", sequenceExecution.Sequence.Name, eventScope.KeptnContext, err)
+		sc.onSequenceWaiting(eventScope.WrappedEvent)
+		return nil
+	}
 	if errors.Is(err, ErrSequenceBlockedWaiting) {
 		sc.onSequenceWaiting(eventScope.WrappedEvent)
 		return nil
@@ -293,7 +488,9 @@ func (sc *shipyardController) appendLatestCommitIDToEvent(eventScope models.Even
 	}
 }
 
-func (sc *shipyardController) handleTaskEvent(event apimodels.KeptnContextExtendedCE) error {
+// handleTaskEvent updates the sequence execution a task event belongs to.
+// The given context belongs to the lock on the sequence, which must still be held when the sequence execution is updated
+func (sc *shipyardController) handleTaskEvent(ctx context.Context, event apimodels.KeptnContextExtendedCE) error {
 	eventScope, err := models.NewEventScope(event)
 	if err != nil {
 		return fmt.Errorf("unable to handle 'task.finished' event: %w", err)
@@ -318,16 +515,21 @@ func (sc *shipyardController) handleTaskEvent(event apimodels.KeptnContextExtend
 		sc.onSequenceTaskStarted(eventScope.WrappedEvent)
 	}
 
+	if err := checkLockHeld(ctx); err != nil {
+		return fmt.Errorf("could not update sequence execution of %s event: %w", eventScope.EventType, err)
+	}
+	sequenceExecution.FencingToken = fencingTokenFromContext(ctx)
 	return sc.onTaskProgress(event, *sequenceExecution, eventScope)
 }
 
 func (sc *shipyardController) onTaskProgress(event apimodels.KeptnContextExtendedCE, sequenceExecution models.SequenceExecution, eventScope *models.EventScope) error {
 	taskEvent := models.TaskEvent{
-		EventType: *event.Type,
-		Source:    *event.Source,
-		Result:    eventScope.Result,
-		Status:    eventScope.Status,
-		Time:      timeutils.GetKeptnTimeStamp(event.Time),
+		TriggeredID: eventScope.TriggeredID,
+		EventType:   *event.Type,
+		Source:      *event.Source,
+		Result:      eventScope.Result,
+		Status:      eventScope.Status,
+		Time:        timeutils.GetKeptnTimeStamp(event.Time),
 	}
 	if keptnv2.IsFinishedEventType(taskEvent.EventType) {
 		eventData := map[string]interface{}{}
@@ -341,14 +543,26 @@ func (sc *shipyardController) onTaskProgress(event apimodels.KeptnContextExtende
 	if err != nil {
 		return err
 	}
+	// the following updates of the sequence execution are only accepted as long as no other replica has taken over the lock on the sequence
+	updatedSequenceExecution.FencingToken = sequenceExecution.FencingToken
 
 	// now check if the number of .started events matches the number of finished events - if yes, that means were done
 	// note: this should also work with multiple replicas because the `AppendTaskEvent` updates the list of events and returns the resulting state
 	// atomically, so ONLY the thread that appended the last event to reach the completion state of the task will get the state required for further proceeding with the task sequence
-	if !updatedSequenceExecution.Status.CurrentTask.IsFinished() {
+	if !updatedSequenceExecution.Status.AreCurrentTasksFinished() {
+		// if the task is part of a parallel group, the sequence can only proceed once all tasks of the group are finished
+		if keptnv2.IsFinishedEventType(taskEvent.EventType) && isTaskFinished(*updatedSequenceExecution, eventScope.TriggeredID) {
+			sc.onSequenceTaskFinished(eventScope.WrappedEvent)
+		}
 		return nil
 	}
 
+	if retries := updatedSequenceExecution.GetTasksToRetry(); len(retries) > 0 {
+		sc.onSequenceTaskFinished(eventScope.WrappedEvent)
+		return sc.retryTasks(*eventScope, *updatedSequenceExecution, retries)
+	}
+
+	completedTasks := updatedSequenceExecution.Status.GetCurrentTasks()
 	result, status := updatedSequenceExecution.CompleteCurrentTask()
 
 	eventScope.Result = result
@@ -374,10 +588,30 @@ func (sc *shipyardController) onTaskProgress(event apimodels.KeptnContextExtende
 		return fmt.Errorf("unable to delete associated task '.triggered' event with ID %s: %w", eventScope.TriggeredID, err)
 	}
 
+	// also remove the '.triggered' events of the tasks that have been running in parallel
+	for _, completedTask := range completedTasks {
+		if completedTask.TriggeredID == eventScope.TriggeredID {
+			continue
+		}
+		if err := sc.eventRepo.DeleteEvent(eventScope.Project, completedTask.TriggeredID, common.TriggeredEvent); err != nil {
+			log.WithError(err).Errorf("could not delete '.triggered' event of task %s with ID %s", completedTask.Name, completedTask.TriggeredID)
+		}
+	}
+
 	sc.onSequenceTaskFinished(eventScope.WrappedEvent)
 	return sc.proceedTaskSequence(*eventScope, *updatedSequenceExecution)
 }
 
+// isTaskFinished checks whether the task with the given triggeredID is finished
+func isTaskFinished(sequenceExecution models.SequenceExecution, triggeredID string) bool {
+	for _, task := range sequenceExecution.Status.GetCurrentTasks() {
+		if task.TriggeredID == triggeredID {
+			return task.IsFinished()
+		}
+	}
+	return false
+}
+
 func (sc *shipyardController) wasTaskTriggered(eventScope models.EventScope) (bool, error) {
 	taskContext, err := sc.getOpenSequenceExecution(eventScope)
 	if err != nil {
@@ -427,10 +661,12 @@ func (sc *shipyardController) cancelSequence(cancel apimodels.SequenceControl) e
 
 	// delete all open .triggered events for the task sequence
 	for _, sequenceExecution := range sequenceExecutions {
-		err := sc.eventRepo.DeleteEvent(cancel.Project, sequenceExecution.Status.CurrentTask.TriggeredID, common.TriggeredEvent)
-		if err != nil {
-			// log the error, but continue
-			log.WithError(err).Error("could not delete event")
+		for _, currentTask := range sequenceExecution.Status.GetCurrentTasks() {
+			err := sc.eventRepo.DeleteEvent(cancel.Project, currentTask.TriggeredID, common.TriggeredEvent)
+			if err != nil {
+				// log the error, but continue
+				log.WithError(err).Error("could not delete event")
+			}
 		}
 
 		if err := sc.forceTaskSequenceCompletion(sequenceExecution); err != nil {
@@ -508,6 +744,36 @@ func (sc *shipyardController) resumeSequence(resume apimodels.SequenceControl) e
 	return nil
 }
 
+// overrideFreeze allows the sequence executions of the given context to be started despite an active freeze window (break-glass)
+func (sc *shipyardController) overrideFreeze(override apimodels.SequenceControl) error {
+	sequenceExecutions, err := sc.sequenceExecutionRepo.Get(models.SequenceExecutionFilter{Scope: models.EventScope{
+		KeptnContext: override.KeptnContext,
+		EventData: keptnv2.EventData{
+			Project: override.Project,
+			Stage:   override.Stage,
+		},
+	}})
+	if err != nil {
+		return fmt.Errorf(couldNotGetActiveSequencesErrMsg, override.Project, override.Stage, override.KeptnContext, err)
+	}
+
+	if len(sequenceExecutions) == 0 {
+		log.Infof(noActiveSequencesErrMsg, override.Project, override.Stage, override.KeptnContext)
+		return nil
+	}
+
+	for _, sequenceExecution := range sequenceExecutions {
+		if !sequenceExecution.OverrideFreeze() {
+			continue
+		}
+		log.Infof("Overriding freeze windows for sequence %s with keptnContext %s in stage %s", sequenceExecution.Sequence.Name, override.KeptnContext, sequenceExecution.Scope.Stage)
+		if _, err := sc.sequenceExecutionRepo.UpdateStatus(sequenceExecution); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (sc *shipyardController) forceTaskSequenceCompletion(sequenceExecution models.SequenceExecution) error {
 	scope := sequenceExecution.Scope
 
@@ -517,7 +783,9 @@ func (sc *shipyardController) forceTaskSequenceCompletion(sequenceExecution mode
 	return sc.completeTaskSequence(scope, sequenceExecution, apimodels.SequenceFinished)
 }
 
-func (sc *shipyardController) timeoutSequence(timeout apimodels.SequenceTimeout) error {
+// timeoutSequence completes a sequence that has timed out. The given context belongs to the lock on the sequence, which must still be held
+// when the sequence execution is updated
+func (sc *shipyardController) timeoutSequence(ctx context.Context, timeout models.SequenceTimeout) error {
 	log.Infof("sequence %s has been timed out", timeout.KeptnContext)
 	eventScope, err := models.NewEventScope(timeout.LastEvent)
 	if err != nil {
@@ -526,12 +794,24 @@ func (sc *shipyardController) timeoutSequence(timeout apimodels.SequenceTimeout)
 
 	eventScope.Status = keptnv2.StatusErrored
 	eventScope.Result = keptnv2.ResultFailed
-	eventScope.Message = fmt.Sprintf("sequence timed out while waiting for task %s to receive a correlating .started or .finished event", *timeout.LastEvent.Type)
+	eventScope.Message = timeout.Message
+	reason := timeout.Reason
+	if reason == "" {
+		reason = models.SequenceTimeoutReasonTaskNotStarted
+	}
+	if eventScope.Message == "" {
+		eventScope.Message = fmt.Sprintf("sequence timed out while waiting for task %s to receive a correlating .started or .finished event", *timeout.LastEvent.Type)
+	}
 
-	sequenceExecutions, err := sc.sequenceExecutionRepo.Get(models.SequenceExecutionFilter{
+	filter := models.SequenceExecutionFilter{
 		CurrentTriggeredID: timeout.LastEvent.ID,
 		Scope:              *eventScope,
-	})
+	}
+	if timeout.LastEvent.ID == "" {
+		// the sequence timed out while none of its tasks was active, so it is only identified by its scope
+		filter.Status = unfinishedSequenceStates
+	}
+	sequenceExecutions, err := sc.sequenceExecutionRepo.Get(filter)
 
 	if err != nil {
 		return fmt.Errorf("could not sequence executions associated to eventID %s: %w", timeout.LastEvent.ID, err)
@@ -542,10 +822,35 @@ func (sc *shipyardController) timeoutSequence(timeout apimodels.SequenceTimeout)
 		return nil
 	}
 
+	if err := checkLockHeld(ctx); err != nil {
+		return err
+	}
 	sequenceExecution := sequenceExecutions[0]
-	sc.onSequenceTimeout(timeout.LastEvent)
+	sequenceExecution.FencingToken = fencingTokenFromContext(ctx)
 
-	if err := sc.completeTaskSequence(*eventScope, sequenceExecution, apimodels.TimedOut); err != nil {
+	// the tasks that are still active are completed as errored, so that late responses of their executors are not correlated to the sequence anymore
+	for _, currentTask := range sequenceExecution.Status.GetCurrentTasks() {
+		if err := sc.eventRepo.DeleteEvent(eventScope.Project, currentTask.TriggeredID, common.TriggeredEvent); err != nil {
+			log.WithError(err).Errorf("could not delete '.triggered' event of task %s with ID %s", currentTask.Name, currentTask.TriggeredID)
+		}
+	}
+	sequenceExecution.CompleteTimedOutTasks()
+	if err := sc.sequenceExecutionRepo.Upsert(sequenceExecution, nil); err != nil {
+		return fmt.Errorf("could not update sequence execution %s: %w", sequenceExecution.ID, err)
+	}
+
+	finishedEventData := models.SequenceTimedOutEventData{
+		EventData: eventScope.EventData,
+		TimedOut:  reason,
+	}
+	timeoutEvent := timeout.LastEvent
+	timeoutEvent.Data = finishedEventData
+	sc.onSequenceTimeout(timeoutEvent, reason)
+
+	if err := checkLockHeld(ctx); err != nil {
+		return err
+	}
+	if err := sc.completeTaskSequenceWithEventData(*eventScope, sequenceExecution, apimodels.TimedOut, finishedEventData); err != nil {
 		return err
 	}
 	return nil
@@ -570,7 +875,7 @@ func (sc *shipyardController) triggerSequenceFailed(eventScope models.EventScope
 	return sc.sendTaskSequenceFinishedEvent(models.EventScope{
 		EventData:    finishedEventData,
 		KeptnContext: event.Shkeptncontext,
-	}, taskSequenceName, event.ID)
+	}, taskSequenceName, event.ID, finishedEventData)
 }
 
 func (sc *shipyardController) StartTaskSequence(event apimodels.KeptnContextExtendedCE) error {
@@ -603,6 +908,7 @@ func (sc *shipyardController) StartTaskSequence(event apimodels.KeptnContextExte
 	}
 	sequenceExecution := sequenceExecutions[0]
 	sequenceExecution.Status.State = apimodels.SequenceStartedState
+	sequenceExecution.Status.StartedAt = time.Now().UTC()
 	updatedSequenceExecution, err := sc.sequenceExecutionRepo.UpdateStatus(sequenceExecution)
 	if err != nil {
 		msg := fmt.Sprintf("could not update sequence execution state %s: %s", taskSequenceName, err.Error())
@@ -693,9 +999,12 @@ func (sc *shipyardController) proceedTaskSequence(eventScope models.EventScope,
 		return err
 	}
 
-	task := sequenceExecution.GetNextTaskOfSequence()
-	if task == nil {
+	tasks := sequenceExecution.GetNextTasksOfSequence()
+	if len(tasks) == 0 {
 		// task sequence completed -> send .finished event and check if a new task sequence should be triggered by the completion
+		if len(sequenceExecution.Status.PreviousTasks) > 0 {
+			eventScope.Result, eventScope.Status = sequenceExecution.GetSequenceResult()
+		}
 		err = sc.completeTaskSequence(eventScope, sequenceExecution, apimodels.SequenceFinished)
 		if err != nil {
 			log.Errorf("Could not complete task sequence %s.%s with KeptnContext %s: %s", eventScope.Stage, sequenceExecution.Sequence.Name, eventScope.KeptnContext, err.Error())
@@ -704,7 +1013,7 @@ func (sc *shipyardController) proceedTaskSequence(eventScope models.EventScope,
 		return sc.triggerNextTaskSequences(eventScope, inputEvent, sequenceExecution)
 	}
 
-	return sc.triggerTask(eventScope, sequenceExecution, *task)
+	return sc.triggerTasks(eventScope, sequenceExecution, tasks)
 }
 
 // this function retrieves the .triggered event for the task sequence and appends its properties to the existing .finished events
@@ -753,6 +1062,11 @@ func (sc *shipyardController) triggerNextTaskSequences(eventScope models.EventSc
 }
 
 func (sc *shipyardController) completeTaskSequence(eventScope models.EventScope, sequenceExecution models.SequenceExecution, reason string) error {
+	return sc.completeTaskSequenceWithEventData(eventScope, sequenceExecution, reason, eventScope.EventData)
+}
+
+// completeTaskSequenceWithEventData completes the sequence like completeTaskSequence, but sends its .finished event with the given event data
+func (sc *shipyardController) completeTaskSequenceWithEventData(eventScope models.EventScope, sequenceExecution models.SequenceExecution, reason string, eventData interface{}) error {
 	sequenceExecution.Status.State = reason
 	_, err := sc.sequenceExecutionRepo.UpdateStatus(sequenceExecution)
 
@@ -764,46 +1078,99 @@ func (sc *shipyardController) completeTaskSequence(eventScope models.EventScope,
 	if err := sc.eventRepo.DeleteAllFinishedEvents(eventScope); err != nil {
 		return err
 	}
-	return sc.sendTaskSequenceFinishedEvent(eventScope, sequenceExecution.Sequence.Name, sequenceExecution.Scope.TriggeredID)
+	return sc.sendTaskSequenceFinishedEvent(eventScope, sequenceExecution.Sequence.Name, sequenceExecution.Scope.TriggeredID, eventData)
+}
+
+// triggerTasks sends the .triggered events for the given tasks. If more than one task is passed, the tasks are part of a parallel group and are executed at the same time
+func (sc *shipyardController) triggerTasks(eventScope models.EventScope, sequenceExecution models.SequenceExecution, tasks []keptnv2.Task) error {
+	dispatcherEvents := []models.DispatcherEvent{}
+	for index := range tasks {
+		dispatcherEvent, err := sc.storeTaskTriggeredEvent(eventScope, sequenceExecution, tasks[index], 0)
+		if err != nil {
+			return err
+		}
+
+		if index == 0 {
+			sequenceExecution.SetNextCurrentTask(tasks[index].Name, dispatcherEvent.Event.ID())
+		} else {
+			sequenceExecution.AddParallelTask(tasks[index].Name, dispatcherEvent.Event.ID())
+		}
+		dispatcherEvents = append(dispatcherEvents, *dispatcherEvent)
+	}
+
+	// the sequence execution needs to contain all tasks of a parallel group before any of their events are sent
+	return sc.updateSequenceAndDispatch(sequenceExecution, dispatcherEvents)
+}
+
+// retryTasks sends new .triggered events for tasks that should be retried according to their retry policy
+func (sc *shipyardController) retryTasks(eventScope models.EventScope, sequenceExecution models.SequenceExecution, retries []models.TaskRetry) error {
+	dispatcherEvents := []models.DispatcherEvent{}
+	for _, retry := range retries {
+		// the '.triggered' event of the previous attempt is not needed anymore
+		if err := sc.eventRepo.DeleteEvent(eventScope.Project, retry.TriggeredID, common.TriggeredEvent); err != nil {
+			log.WithError(err).Errorf("could not delete '.triggered' event of task %s with ID %s", retry.Task.Name, retry.TriggeredID)
+		}
+
+		dispatcherEvent, err := sc.storeTaskTriggeredEvent(eventScope, sequenceExecution, retry.Task, retry.Backoff)
+		if err != nil {
+			return err
+		}
+		log.Infof("retrying task %s of sequence %s with KeptnContext %s", retry.Task.Name, sequenceExecution.Sequence.Name, sequenceExecution.Scope.KeptnContext)
+		sequenceExecution.RetryTask(retry.TriggeredID, dispatcherEvent.Event.ID())
+		dispatcherEvents = append(dispatcherEvents, *dispatcherEvent)
+	}
+	return sc.updateSequenceAndDispatch(sequenceExecution, dispatcherEvents)
 }
 
-func (sc *shipyardController) triggerTask(eventScope models.EventScope, sequenceExecution models.SequenceExecution, task keptnv2.Task) error {
-	eventPayload := sequenceExecution.GetNextTriggeredEventData()
+// storeTaskTriggeredEvent creates and stores the .triggered event for the given task. The event will be sent after the given delay, or after the delay
+// defined in the task's triggeredAfter property
+func (sc *shipyardController) storeTaskTriggeredEvent(eventScope models.EventScope, sequenceExecution models.SequenceExecution, task keptnv2.Task, delay time.Duration) (*models.DispatcherEvent, error) {
+	eventPayload := sequenceExecution.GetTriggeredEventDataForTask(&task)
 
 	event := common.CreateEventWithPayload(eventScope.KeptnContext, "", keptnv2.GetTriggeredEventType(task.Name), eventPayload)
 	event.SetExtension("gitcommitid", sequenceExecution.Scope.GitCommitID)
 
+	traceContext := commontracing.ContextFromEvent(context.Background(), eventScope.WrappedEvent)
+	commontracing.InjectIntoCloudEvent(traceContext, &event)
+
 	storeEvent := &apimodels.KeptnContextExtendedCE{}
 	if err := keptnv2.Decode(event, storeEvent); err != nil {
 		log.Errorf("could not transform CloudEvent for storage in mongodb: %s", err.Error())
-		return err
+		return nil, err
 	}
+	// the trace context needs to be stored as well since the event might be sent by the EventDispatcher at a later point
+	commontracing.InjectIntoEvent(traceContext, storeEvent)
 
-	sendTaskTimestamp := time.Now().UTC()
+	sendTaskTimestamp := time.Now().UTC().Add(delay)
 	if task.TriggeredAfter != "" {
 		if duration, err := time.ParseDuration(task.TriggeredAfter); err == nil {
 			sendTaskTimestamp = sendTaskTimestamp.Add(duration)
 		} else {
 			log.Errorf("could not parse triggeredAfter property: %s", err.Error())
 		}
+	}
+	if task.TriggeredAfter != "" || delay > 0 {
 		log.Infof("queueing %s event with ID %s to be sent at %s", event.Type(), event.ID(), sendTaskTimestamp.String())
 	}
 	storeEvent.Time = sendTaskTimestamp
 
 	if err := sc.eventRepo.InsertEvent(eventScope.Project, *storeEvent, common.TriggeredEvent); err != nil {
 		log.Errorf("Could not store event: %s", err.Error())
-		return err
+		return nil, err
 	}
 
 	sc.onSequenceTaskTriggered(*storeEvent)
+	return &models.DispatcherEvent{TimeStamp: sendTaskTimestamp, Event: event}, nil
+}
 
-	sequenceExecution.SetNextCurrentTask(task.Name, storeEvent.ID)
-
+func (sc *shipyardController) updateSequenceAndDispatch(sequenceExecution models.SequenceExecution, dispatcherEvents []models.DispatcherEvent) error {
 	if err := sc.sequenceExecutionRepo.Upsert(sequenceExecution, nil); err != nil {
 		return err
 	}
-	if err := sc.eventDispatcher.Add(models.DispatcherEvent{TimeStamp: sendTaskTimestamp, Event: event}, false); err != nil {
-		return err
+	for _, dispatcherEvent := range dispatcherEvents {
+		if err := sc.eventDispatcher.Add(dispatcherEvent, false); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -817,12 +1184,15 @@ func (sc *shipyardController) sendTaskSequenceTriggeredEvent(eventScope *models.
 	eventType := eventScope.Stage + "." + taskSequenceName
 
 	event := common.CreateEventWithPayload(eventScope.KeptnContext, "", keptnv2.GetTriggeredEventType(eventType), mergedPayload)
+	traceContext := commontracing.ContextFromEvent(context.Background(), eventScope.WrappedEvent)
+	commontracing.InjectIntoCloudEvent(traceContext, &event)
 
 	toEvent, err := models.ConvertToEvent(event)
 	if err != nil {
 		return fmt.Errorf("could not store event that triggered task sequence: " + err.Error())
 	}
 	sc.appendLatestCommitIDToEvent(*eventScope, toEvent)
+	commontracing.InjectIntoEvent(traceContext, toEvent)
 	if err := sc.eventRepo.InsertEvent(eventScope.Project, *toEvent, common.TriggeredEvent); err != nil {
 		return fmt.Errorf("could not store event that triggered task sequence: " + err.Error())
 	}
@@ -830,10 +1200,11 @@ func (sc *shipyardController) sendTaskSequenceTriggeredEvent(eventScope *models.
 	return sc.eventDispatcher.Add(models.DispatcherEvent{TimeStamp: time.Now().UTC(), Event: event}, true)
 }
 
-func (sc *shipyardController) sendTaskSequenceFinishedEvent(eventScope models.EventScope, taskSequenceName, triggeredID string) error {
+func (sc *shipyardController) sendTaskSequenceFinishedEvent(eventScope models.EventScope, taskSequenceName, triggeredID string, eventData interface{}) error {
 	eventType := eventScope.Stage + "." + taskSequenceName
 
-	event := common.CreateEventWithPayload(eventScope.KeptnContext, triggeredID, keptnv2.GetFinishedEventType(eventType), eventScope.EventData)
+	event := common.CreateEventWithPayload(eventScope.KeptnContext, triggeredID, keptnv2.GetFinishedEventType(eventType), eventData)
+	commontracing.InjectIntoCloudEvent(commontracing.ContextFromEvent(context.Background(), eventScope.WrappedEvent), &event)
 
 	if toEvent, err := models.ConvertToEvent(event); err == nil {
 		sc.onSubSequenceFinished(*toEvent)
@@ -856,7 +856,7 @@ func Test_shipyardController_DuplicateTask(t *testing.T) {
 func Test_shipyardController_TimeoutSequence(t *testing.T) {
 	sc, cancel := getTestShipyardController("")
 	defer cancel()
-	fakeTimeoutHook := &fakehooks.ISequenceTimeoutHookMock{OnSequenceTimeoutFunc: func(event apimodels.KeptnContextExtendedCE) {}}
+	fakeTimeoutHook := &fakehooks.ISequenceTimeoutHookMock{OnSequenceTimeoutFunc: func(event apimodels.KeptnContextExtendedCE, reason models.SequenceTimeoutReason) {}}
 	sc.AddSequenceTimeoutHook(fakeTimeoutHook)
 
 	// insert the test data
@@ -907,17 +907,19 @@ func Test_shipyardController_TimeoutSequence(t *testing.T) {
 	require.Nil(t, err)
 
 	// invoke the CancelSequence function
-	err = sc.timeoutSequence(apimodels.SequenceTimeout{
-		KeptnContext: "my-keptn-context-id",
-		LastEvent: apimodels.KeptnContextExtendedCE{
-			Data: keptnv2.EventData{
-				Project: "my-project",
-				Stage:   "my-stage",
-				Service: "my-service",
+	err = sc.timeoutSequence(context.Background(), models.SequenceTimeout{
+		SequenceTimeout: apimodels.SequenceTimeout{
+			KeptnContext: "my-keptn-context-id",
+			LastEvent: apimodels.KeptnContextExtendedCE{
+				Data: keptnv2.EventData{
+					Project: "my-project",
+					Stage:   "my-stage",
+					Service: "my-service",
+				},
+				Type:           common.Stringp(keptnv2.GetTriggeredEventType("my-task")),
+				ID:             "my-deployment-triggered-id",
+				Shkeptncontext: "my-keptn-context-id",
 			},
-			Type:           common.Stringp(keptnv2.GetTriggeredEventType("my-task")),
-			ID:             "my-deployment-triggered-id",
-			Shkeptncontext: "my-keptn-context-id",
 		},
 	})
 
@@ -938,10 +940,97 @@ func Test_shipyardController_TimeoutSequence(t *testing.T) {
 	require.Equal(t, keptnv2.StatusErrored, eventData.Status)
 }
 
+func Test_shipyardController_TimeoutSequence_TaskTimeout(t *testing.T) {
+	sc, cancel := getTestShipyardController("")
+	defer cancel()
+	fakeTimeoutHook := &fakehooks.ISequenceTimeoutHookMock{OnSequenceTimeoutFunc: func(event apimodels.KeptnContextExtendedCE, reason models.SequenceTimeoutReason) {}}
+	sc.AddSequenceTimeoutHook(fakeTimeoutHook)
+
+	_ = sc.eventRepo.InsertEvent("my-project", apimodels.KeptnContextExtendedCE{
+		Data: keptnv2.EventData{
+			Project: "my-project",
+			Stage:   "my-stage",
+			Service: "my-service",
+		},
+		ID:             "my-deployment-triggered-id",
+		Shkeptncontext: "my-keptn-context-id",
+		Type:           common.Stringp(keptnv2.GetTriggeredEventType(keptnv2.DeploymentTaskName)),
+	}, common.TriggeredEvent)
+
+	err := sc.sequenceExecutionRepo.Upsert(models.SequenceExecution{
+		ID: "sequence-execution-id",
+		Sequence: keptnv2.Sequence{
+			Name: "delivery",
+		},
+		Status: models.SequenceExecutionStatus{
+			State: apimodels.SequenceStartedState,
+			CurrentTask: models.TaskExecutionState{
+				Name:        "deployment",
+				TriggeredID: "my-deployment-triggered-id",
+			},
+		},
+		Scope: models.EventScope{
+			KeptnContext: "my-keptn-context-id",
+			EventData: keptnv2.EventData{
+				Project: "my-project",
+				Stage:   "my-stage",
+				Service: "my-service",
+			},
+		},
+	}, nil)
+	require.Nil(t, err)
+
+	err = sc.timeoutSequence(context.Background(), models.SequenceTimeout{
+		SequenceTimeout: apimodels.SequenceTimeout{
+			KeptnContext: "my-keptn-context-id",
+			LastEvent: apimodels.KeptnContextExtendedCE{
+				Data: keptnv2.EventData{
+					Project: "my-project",
+					Stage:   "my-stage",
+					Service: "my-service",
+				},
+				Type:           common.Stringp(keptnv2.GetTriggeredEventType(keptnv2.DeploymentTaskName)),
+				ID:             "my-deployment-triggered-id",
+				Shkeptncontext: "my-keptn-context-id",
+			},
+		},
+		Reason:  models.SequenceTimeoutReasonTask,
+		Message: "task deployment of sequence delivery timed out after 30m0s",
+	})
+	require.Nil(t, err)
+
+	// the hook receives the reason of the timeout
+	require.Len(t, fakeTimeoutHook.OnSequenceTimeoutCalls(), 1)
+	require.Equal(t, models.SequenceTimeoutReasonTask, fakeTimeoutHook.OnSequenceTimeoutCalls()[0].Reason)
+	hookEventData := &keptnv2.EventData{}
+	err = keptnv2.Decode(fakeTimeoutHook.OnSequenceTimeoutCalls()[0].Event.Data, hookEventData)
+	require.Nil(t, err)
+	require.Equal(t, "task deployment of sequence delivery timed out after 30m0s", hookEventData.Message)
+	require.Equal(t, keptnv2.StatusErrored, hookEventData.Status)
+
+	eventDispatcherMock := sc.eventDispatcher.(*fake.IEventDispatcherMock)
+	require.Len(t, eventDispatcherMock.AddCalls(), 1)
+	eventData := &models.SequenceTimedOutEventData{}
+	err = eventDispatcherMock.AddCalls()[0].Event.Event.DataAs(eventData)
+	require.Nil(t, err)
+	require.Equal(t, keptnv2.StatusErrored, eventData.Status)
+	require.Equal(t, "task deployment of sequence delivery timed out after 30m0s", eventData.Message)
+	require.Equal(t, models.SequenceTimeoutReasonTask, eventData.TimedOut)
+
+	// the timed out task is recorded as errored
+	sequenceExecutions, err := sc.sequenceExecutionRepo.Get(models.SequenceExecutionFilter{Scope: models.EventScope{EventData: keptnv2.EventData{Project: "my-project"}}})
+	require.Nil(t, err)
+	require.Len(t, sequenceExecutions, 1)
+	require.Equal(t, apimodels.TimedOut, sequenceExecutions[0].Status.State)
+	require.Len(t, sequenceExecutions[0].Status.PreviousTasks, 1)
+	require.Equal(t, keptnv2.StatusErrored, sequenceExecutions[0].Status.PreviousTasks[0].Status)
+	require.Empty(t, sequenceExecutions[0].Status.CurrentTask.TriggeredID)
+}
+
 func Test_shipyardController_TimeoutSequence_ErrorWhenSendingEvent(t *testing.T) {
 	sc, cancel := getTestShipyardController("")
 	defer cancel()
-	fakeTimeoutHook := &fakehooks.ISequenceTimeoutHookMock{OnSequenceTimeoutFunc: func(event apimodels.KeptnContextExtendedCE) {}}
+	fakeTimeoutHook := &fakehooks.ISequenceTimeoutHookMock{OnSequenceTimeoutFunc: func(event apimodels.KeptnContextExtendedCE, reason models.SequenceTimeoutReason) {}}
 	sc.AddSequenceTimeoutHook(fakeTimeoutHook)
 
 	// insert the test data
@@ -997,17 +1086,19 @@ func Test_shipyardController_TimeoutSequence_ErrorWhenSendingEvent(t *testing.T)
 	}
 
 	// invoke the CancelSequence function
-	err = sc.timeoutSequence(apimodels.SequenceTimeout{
-		KeptnContext: "my-keptn-context-id",
-		LastEvent: apimodels.KeptnContextExtendedCE{
-			Data: keptnv2.EventData{
-				Project: "my-project",
-				Stage:   "my-stage",
-				Service: "my-service",
+	err = sc.timeoutSequence(context.Background(), models.SequenceTimeout{
+		SequenceTimeout: apimodels.SequenceTimeout{
+			KeptnContext: "my-keptn-context-id",
+			LastEvent: apimodels.KeptnContextExtendedCE{
+				Data: keptnv2.EventData{
+					Project: "my-project",
+					Stage:   "my-stage",
+					Service: "my-service",
+				},
+				Type:           common.Stringp(keptnv2.GetTriggeredEventType("my-task")),
+				ID:             "my-deployment-triggered-id",
+				Shkeptncontext: "my-keptn-context-id",
 			},
-			Type:           common.Stringp(keptnv2.GetTriggeredEventType("my-task")),
-			ID:             "my-deployment-triggered-id",
-			Shkeptncontext: "my-keptn-context-id",
 		},
 	})
 
@@ -1319,9 +1410,13 @@ func getTestShipyardController(shipyardContent string) (*shipyardController, con
 		eventRepo,
 		sequenceQueueRepo,
 		sequenceExecutionRepo,
+		nil,
+		nil,
 		time.Second,
 		clock.New(),
 		common.SDModeRW,
+		nil,
+		common.ClaimOptions{},
 	)
 	sc := &shipyardController{
 		projectMvRepo: db.NewProjectMVRepo(db.NewMongoDBKeyEncodingProjectsRepo(db.GetMongoDBConnectionInstance()), db.NewMongoDBEventsRepo(db.GetMongoDBConnectionInstance())),
@@ -1330,7 +1425,7 @@ func getTestShipyardController(shipyardContent string) (*shipyardController, con
 			AddFunc: func(event models.DispatcherEvent, skipQueue bool) error {
 				return nil
 			},
-			RunFunc: func(ctx context.Context) {
+			RunFunc: func(ctx context.Context, mode common.SDMode) {
 
 			},
 			StopFunc: func() {},
@@ -1346,6 +1441,15 @@ func getTestShipyardController(shipyardContent string) (*shipyardController, con
 			GetLatestCommitIDFunc: func(projectName string, stageName string) (string, error) {
 				return "latest-commit-id", nil
 			},
+			GetCachedFreezeWindowsFunc: func(projectName string) (map[string][]common.FreezeWindow, error) {
+				return common.GetShipyardFreezeWindows(shipyardContent)
+			},
+			GetCachedSequenceTimeoutsFunc: func(projectName string) (map[string]map[string]string, error) {
+				return common.GetShipyardSequenceTimeouts(shipyardContent)
+			},
+			GetCachedTaskAttributesFunc: func(projectName string) (map[string]map[string][]common.TaskAttributes, error) {
+				return common.GetShipyardTaskAttributes(shipyardContent)
+			},
 		},
 		sequenceExecutionRepo: sequenceExecutionRepo,
 	}
@@ -108,9 +108,9 @@ func (sc *shipyardController) onSequenceAborted(eventScope scmodels.EventScope)
 	}
 }
 
-func (sc *shipyardController) onSequenceTimeout(event models.KeptnContextExtendedCE) {
+func (sc *shipyardController) onSequenceTimeout(event models.KeptnContextExtendedCE, reason scmodels.SequenceTimeoutReason) {
 	for _, hook := range sc.sequenceTimoutHooks {
-		hook.OnSequenceTimeout(event)
+		hook.OnSequenceTimeout(event, reason)
 	}
 }
 
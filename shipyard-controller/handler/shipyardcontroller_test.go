@@ -1,8 +1,10 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/keptn/keptn/shipyard-controller/db"
 	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
@@ -12,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func Test_GetAllTriggeredEvents(t *testing.T) {
@@ -193,7 +196,7 @@ func TestHandleTaskEvent(t *testing.T) {
 			}
 
 			em.AddSequenceTaskFinishedHook(tt.fields.taskFinishedHook)
-			if err := em.handleTaskEvent(tt.args.event); (err != nil) != tt.wantErr {
+			if err := em.handleTaskEvent(context.Background(), tt.args.event); (err != nil) != tt.wantErr {
 				t.Errorf("handleTaskFinished() error = %v, wantErr %v", err, tt.wantErr)
 			}
 
@@ -205,3 +208,759 @@ func TestHandleTaskEvent(t *testing.T) {
 		})
 	}
 }
+
+func Test_shipyardController_HandleTaskEventWithLock(t *testing.T) {
+	startedEvent := apimodels.KeptnContextExtendedCE{
+		Data:           keptnv2.EventData{Project: "my-project", Stage: "dev", Service: "my-service"},
+		ID:             "test-started-id",
+		Shkeptncontext: "my-context",
+		Source:         common.Stringp("test-service"),
+		Triggeredid:    "test-triggered-id",
+		Type:           common.Stringp(keptnv2.GetStartedEventType("test")),
+	}
+	newSequenceExecutionRepo := func() *db_mock.SequenceExecutionRepoMock {
+		return &db_mock.SequenceExecutionRepoMock{
+			GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+				return []models.SequenceExecution{
+					{
+						ID:       "id",
+						Sequence: keptnv2.Sequence{Name: "delivery", Tasks: []keptnv2.Task{{Name: "test"}}},
+						Status: models.SequenceExecutionStatus{
+							State:       apimodels.SequenceStartedState,
+							CurrentTask: models.TaskExecutionState{Name: "test", TriggeredID: "test-triggered-id"},
+						},
+						Scope: models.EventScope{
+							EventData:    keptnv2.EventData{Project: "my-project", Stage: "dev", Service: "my-service"},
+							KeptnContext: "my-context",
+						},
+					},
+				}, nil
+			},
+			AppendTaskEventFunc: func(taskSequence models.SequenceExecution, event models.TaskEvent) (*models.SequenceExecution, error) {
+				updated := taskSequence
+				updated.Status.CurrentTask.Events = append(updated.Status.CurrentTask.Events, event)
+				return &updated, nil
+			},
+		}
+	}
+
+	t.Run("updates the sequence execution with the fencing token of the lock", func(t *testing.T) {
+		sequenceExecutionRepo := newSequenceExecutionRepo()
+		sc := &shipyardController{sequenceExecutionRepo: sequenceExecutionRepo}
+
+		ctx := withFencingToken(context.Background(), models.Lock{Key: "sequence.my-project.dev.my-context", Token: 42})
+		err := sc.handleTaskEvent(ctx, startedEvent)
+		require.Nil(t, err)
+
+		require.Len(t, sequenceExecutionRepo.AppendTaskEventCalls(), 1)
+		require.Equal(t, int64(42), sequenceExecutionRepo.AppendTaskEventCalls()[0].TaskSequence.FencingToken)
+	})
+
+	t.Run("does not update the sequence execution if the lock has been lost", func(t *testing.T) {
+		sequenceExecutionRepo := newSequenceExecutionRepo()
+		sc := &shipyardController{sequenceExecutionRepo: sequenceExecutionRepo}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := sc.handleTaskEvent(ctx, startedEvent)
+		require.ErrorIs(t, err, db.ErrLockLost)
+
+		require.Empty(t, sequenceExecutionRepo.AppendTaskEventCalls())
+	})
+}
+
+func Test_shipyardController_TimeoutSequenceWithLock(t *testing.T) {
+	timeout := models.SequenceTimeout{
+		SequenceTimeout: apimodels.SequenceTimeout{
+			KeptnContext: "my-context",
+			LastEvent: apimodels.KeptnContextExtendedCE{
+				Data:           keptnv2.EventData{Project: "my-project", Stage: "dev", Service: "my-service"},
+				ID:             "test-triggered-id",
+				Shkeptncontext: "my-context",
+				Type:           common.Stringp(keptnv2.GetTriggeredEventType("test")),
+			},
+		},
+		Reason:  models.SequenceTimeoutReasonTask,
+		Message: "task test of sequence delivery timed out after 5m0s",
+	}
+	newSequenceExecutionRepo := func() *db_mock.SequenceExecutionRepoMock {
+		return &db_mock.SequenceExecutionRepoMock{
+			GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+				return []models.SequenceExecution{
+					{
+						ID:       "id",
+						Sequence: keptnv2.Sequence{Name: "delivery", Tasks: []keptnv2.Task{{Name: "test"}}},
+						Status: models.SequenceExecutionStatus{
+							State:       apimodels.SequenceStartedState,
+							CurrentTask: models.TaskExecutionState{Name: "test", TriggeredID: "test-triggered-id"},
+						},
+						Scope: models.EventScope{
+							EventData:    keptnv2.EventData{Project: "my-project", Stage: "dev", Service: "my-service"},
+							KeptnContext: "my-context",
+						},
+					},
+				}, nil
+			},
+			UpsertFunc: func(item models.SequenceExecution, options *models.SequenceExecutionUpsertOptions) error {
+				return nil
+			},
+			UpdateStatusFunc: func(taskSequence models.SequenceExecution) (*models.SequenceExecution, error) {
+				return &taskSequence, nil
+			},
+		}
+	}
+	eventRepo := &db_mock.EventRepoMock{
+		DeleteEventFunc: func(project string, eventID string, status common.EventStatus) error {
+			return nil
+		},
+		DeleteAllFinishedEventsFunc: func(eventScope models.EventScope) error {
+			return nil
+		},
+	}
+
+	t.Run("times out the sequence while holding the lock of the sequence", func(t *testing.T) {
+		sequenceExecutionRepo := newSequenceExecutionRepo()
+		eventDispatcher := &fake.IEventDispatcherMock{
+			AddFunc: func(event models.DispatcherEvent, skipQueue bool) error {
+				return nil
+			},
+		}
+		locker := &fake.ILockerMock{
+			LockFunc: func(ctx context.Context, key string) (*models.Lock, context.Context, error) {
+				return &models.Lock{Key: key, Token: 42}, context.Background(), nil
+			},
+			UnlockFunc: func(lock models.Lock) error {
+				return nil
+			},
+		}
+		timeoutHook := &fakehooks.ISequenceTimeoutHookMock{OnSequenceTimeoutFunc: func(event apimodels.KeptnContextExtendedCE, reason models.SequenceTimeoutReason) {}}
+		sequenceTimeoutChan := make(chan models.SequenceTimeout)
+		sc := &shipyardController{
+			eventRepo:             eventRepo,
+			sequenceExecutionRepo: sequenceExecutionRepo,
+			eventDispatcher:       eventDispatcher,
+			locker:                locker,
+			sequenceTimeoutChan:   sequenceTimeoutChan,
+		}
+		sc.AddSequenceTimeoutHook(timeoutHook)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		sc.run(ctx)
+		sequenceTimeoutChan <- timeout
+
+		require.Eventually(t, func() bool {
+			return len(eventDispatcher.AddCalls()) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+
+		require.Len(t, locker.LockCalls(), 1)
+		require.Equal(t, "sequence.my-project.dev.my-context", locker.LockCalls()[0].Key)
+		require.Equal(t, int64(42), sequenceExecutionRepo.UpsertCalls()[0].Item.FencingToken)
+		require.Equal(t, int64(42), sequenceExecutionRepo.UpdateStatusCalls()[0].TaskSequence.FencingToken)
+
+		require.Len(t, timeoutHook.OnSequenceTimeoutCalls(), 1)
+		require.Equal(t, models.SequenceTimeoutReasonTask, timeoutHook.OnSequenceTimeoutCalls()[0].Reason)
+
+		finishedEventData := &models.SequenceTimedOutEventData{}
+		require.Nil(t, eventDispatcher.AddCalls()[0].Event.Event.DataAs(finishedEventData))
+		require.Equal(t, models.SequenceTimeoutReasonTask, finishedEventData.TimedOut)
+		require.Equal(t, keptnv2.StatusErrored, finishedEventData.Status)
+		require.Equal(t, "task test of sequence delivery timed out after 5m0s", finishedEventData.Message)
+	})
+
+	t.Run("does not update the sequence execution if the lock has been lost", func(t *testing.T) {
+		sequenceExecutionRepo := newSequenceExecutionRepo()
+		sc := &shipyardController{eventRepo: eventRepo, sequenceExecutionRepo: sequenceExecutionRepo}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := sc.timeoutSequence(ctx, timeout)
+		require.ErrorIs(t, err, db.ErrLockLost)
+
+		require.Empty(t, sequenceExecutionRepo.UpsertCalls())
+		require.Empty(t, sequenceExecutionRepo.UpdateStatusCalls())
+	})
+}
+
+func Test_shipyardController_TriggersTasksOfParallelGroup(t *testing.T) {
+	eventRepo := &db_mock.EventRepoMock{
+		GetTaskSequenceTriggeredEventFunc: func(eventScope models.EventScope, taskSequenceName string) (*apimodels.KeptnContextExtendedCE, error) {
+			return &apimodels.KeptnContextExtendedCE{}, nil
+		},
+		InsertEventFunc: func(project string, event apimodels.KeptnContextExtendedCE, status common.EventStatus) error {
+			return nil
+		},
+	}
+	var upsertedSequenceExecution models.SequenceExecution
+	sequenceExecutionRepo := &db_mock.SequenceExecutionRepoMock{
+		UpsertFunc: func(item models.SequenceExecution, options *models.SequenceExecutionUpsertOptions) error {
+			upsertedSequenceExecution = item
+			return nil
+		},
+	}
+	eventDispatcher := &fake.IEventDispatcherMock{
+		AddFunc: func(event models.DispatcherEvent, skipQueue bool) error {
+			return nil
+		},
+	}
+	sc := &shipyardController{
+		eventRepo:             eventRepo,
+		sequenceExecutionRepo: sequenceExecutionRepo,
+		eventDispatcher:       eventDispatcher,
+	}
+
+	sequenceExecution := models.SequenceExecution{
+		ID: "id",
+		Sequence: keptnv2.Sequence{
+			Name: "delivery",
+			Tasks: []keptnv2.Task{
+				{Name: "deployment"},
+				{Name: "test"},
+				{Name: "security-scan"},
+				{Name: "release"},
+			},
+		},
+		TaskAttributes: []common.TaskAttributes{{}, {Parallel: "verification"}, {Parallel: "verification"}, {}},
+		Status: models.SequenceExecutionStatus{
+			State: apimodels.SequenceStartedState,
+			PreviousTasks: []models.TaskExecutionResult{
+				{Name: "deployment", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded},
+			},
+		},
+		Scope: models.EventScope{
+			EventData:    keptnv2.EventData{Project: "my-project", Stage: "dev", Service: "my-service"},
+			KeptnContext: "my-context",
+		},
+	}
+
+	err := sc.proceedTaskSequence(sequenceExecution.Scope, sequenceExecution)
+	require.Nil(t, err)
+
+	// both tasks of the group are triggered, and the sequence execution is stored before any event is sent
+	require.Len(t, eventRepo.InsertEventCalls(), 2)
+	require.Equal(t, keptnv2.GetTriggeredEventType("test"), *eventRepo.InsertEventCalls()[0].Event.Type)
+	require.Equal(t, keptnv2.GetTriggeredEventType("security-scan"), *eventRepo.InsertEventCalls()[1].Event.Type)
+	require.Len(t, sequenceExecutionRepo.UpsertCalls(), 1)
+	require.Len(t, eventDispatcher.AddCalls(), 2)
+
+	require.Equal(t, "test", upsertedSequenceExecution.Status.CurrentTask.Name)
+	require.Equal(t, eventRepo.InsertEventCalls()[0].Event.ID, upsertedSequenceExecution.Status.CurrentTask.TriggeredID)
+	require.Len(t, upsertedSequenceExecution.Status.ParallelTasks, 1)
+	require.Equal(t, "security-scan", upsertedSequenceExecution.Status.ParallelTasks[0].Name)
+	require.Equal(t, eventRepo.InsertEventCalls()[1].Event.ID, upsertedSequenceExecution.Status.ParallelTasks[0].TriggeredID)
+}
+
+func Test_shipyardController_WaitsForAllTasksOfParallelGroup(t *testing.T) {
+	sequenceExecution := models.SequenceExecution{
+		ID: "id",
+		Sequence: keptnv2.Sequence{
+			Name: "delivery",
+			Tasks: []keptnv2.Task{
+				{Name: "test"},
+				{Name: "security-scan"},
+			},
+		},
+		TaskAttributes: []common.TaskAttributes{{Parallel: "verification"}, {Parallel: "verification"}},
+		Status: models.SequenceExecutionStatus{
+			State: apimodels.SequenceStartedState,
+			CurrentTask: models.TaskExecutionState{
+				Name:        "test",
+				TriggeredID: "test-triggered-id",
+				Events: []models.TaskEvent{
+					{EventType: keptnv2.GetStartedEventType("test")},
+				},
+			},
+			ParallelTasks: []models.TaskExecutionState{
+				{
+					Name:        "security-scan",
+					TriggeredID: "scan-triggered-id",
+					Events: []models.TaskEvent{
+						{EventType: keptnv2.GetStartedEventType("security-scan")},
+					},
+				},
+			},
+		},
+		Scope: models.EventScope{
+			EventData:    keptnv2.EventData{Project: "my-project", Stage: "dev", Service: "my-service"},
+			KeptnContext: "my-context",
+		},
+	}
+
+	sequenceExecutionRepo := &db_mock.SequenceExecutionRepoMock{
+		AppendTaskEventFunc: func(taskSequence models.SequenceExecution, event models.TaskEvent) (*models.SequenceExecution, error) {
+			updated := taskSequence
+			updated.Status.CurrentTask.Events = append(updated.Status.CurrentTask.Events, event)
+			return &updated, nil
+		},
+	}
+	taskFinishedHook := &fakehooks.ISequenceTaskFinishedHookMock{OnSequenceTaskFinishedFunc: func(event apimodels.KeptnContextExtendedCE) {}}
+	sc := &shipyardController{
+		sequenceExecutionRepo: sequenceExecutionRepo,
+	}
+	sc.AddSequenceTaskFinishedHook(taskFinishedHook)
+
+	finishedEvent := apimodels.KeptnContextExtendedCE{
+		Data:           keptnv2.EventData{Project: "my-project", Stage: "dev", Service: "my-service", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded},
+		ID:             "test-finished-id",
+		Shkeptncontext: "my-context",
+		Source:         common.Stringp("test-service"),
+		Triggeredid:    "test-triggered-id",
+		Type:           common.Stringp(keptnv2.GetFinishedEventType("test")),
+	}
+	eventScope, err := models.NewEventScope(finishedEvent)
+	require.Nil(t, err)
+
+	err = sc.onTaskProgress(finishedEvent, sequenceExecution, eventScope)
+	require.Nil(t, err)
+
+	// the event has been assigned to the correct task
+	require.Len(t, sequenceExecutionRepo.AppendTaskEventCalls(), 1)
+	require.Equal(t, "test-triggered-id", sequenceExecutionRepo.AppendTaskEventCalls()[0].Event.TriggeredID)
+
+	// the finished task is reported, but the sequence does not proceed until the security-scan task is finished as well
+	require.Len(t, taskFinishedHook.OnSequenceTaskFinishedCalls(), 1)
+}
+
+func Test_shipyardController_RetriesErroredTask(t *testing.T) {
+	sequenceExecution := models.SequenceExecution{
+		ID: "id",
+		Sequence: keptnv2.Sequence{
+			Name: "delivery",
+			Tasks: []keptnv2.Task{
+				{Name: "deployment"},
+				{Name: "release"},
+			},
+		},
+		TaskAttributes: []common.TaskAttributes{{Retry: &common.TaskRetryPolicy{MaxRetries: 1, Backoff: "1m"}}, {}},
+		Status: models.SequenceExecutionStatus{
+			State: apimodels.SequenceStartedState,
+			CurrentTask: models.TaskExecutionState{
+				Name:        "deployment",
+				TriggeredID: "deployment-triggered-id",
+				Events: []models.TaskEvent{
+					{EventType: keptnv2.GetStartedEventType("deployment")},
+				},
+			},
+		},
+		Scope: models.EventScope{
+			EventData:    keptnv2.EventData{Project: "my-project", Stage: "dev", Service: "my-service"},
+			KeptnContext: "my-context",
+		},
+	}
+
+	eventRepo := &db_mock.EventRepoMock{
+		InsertEventFunc: func(project string, event apimodels.KeptnContextExtendedCE, status common.EventStatus) error {
+			return nil
+		},
+		DeleteEventFunc: func(project string, eventID string, status common.EventStatus) error {
+			return nil
+		},
+	}
+	var upsertedSequenceExecution models.SequenceExecution
+	sequenceExecutionRepo := &db_mock.SequenceExecutionRepoMock{
+		AppendTaskEventFunc: func(taskSequence models.SequenceExecution, event models.TaskEvent) (*models.SequenceExecution, error) {
+			updated := taskSequence
+			updated.Status.CurrentTask.Events = append(updated.Status.CurrentTask.Events, event)
+			return &updated, nil
+		},
+		UpsertFunc: func(item models.SequenceExecution, options *models.SequenceExecutionUpsertOptions) error {
+			upsertedSequenceExecution = item
+			return nil
+		},
+	}
+	eventDispatcher := &fake.IEventDispatcherMock{
+		AddFunc: func(event models.DispatcherEvent, skipQueue bool) error {
+			return nil
+		},
+	}
+	taskFinishedHook := &fakehooks.ISequenceTaskFinishedHookMock{OnSequenceTaskFinishedFunc: func(event apimodels.KeptnContextExtendedCE) {}}
+	sc := &shipyardController{
+		eventRepo:             eventRepo,
+		sequenceExecutionRepo: sequenceExecutionRepo,
+		eventDispatcher:       eventDispatcher,
+	}
+	sc.AddSequenceTaskFinishedHook(taskFinishedHook)
+
+	finishedEvent := apimodels.KeptnContextExtendedCE{
+		Data:           keptnv2.EventData{Project: "my-project", Stage: "dev", Service: "my-service", Result: keptnv2.ResultFailed, Status: keptnv2.StatusErrored},
+		ID:             "deployment-finished-id",
+		Shkeptncontext: "my-context",
+		Source:         common.Stringp("helm-service"),
+		Triggeredid:    "deployment-triggered-id",
+		Type:           common.Stringp(keptnv2.GetFinishedEventType("deployment")),
+	}
+	eventScope, err := models.NewEventScope(finishedEvent)
+	require.Nil(t, err)
+
+	err = sc.onTaskProgress(finishedEvent, sequenceExecution, eventScope)
+	require.Nil(t, err)
+
+	require.Len(t, taskFinishedHook.OnSequenceTaskFinishedCalls(), 1)
+
+	// the .triggered event of the failed attempt is replaced by a new one, which is sent after the backoff
+	require.Len(t, eventRepo.DeleteEventCalls(), 1)
+	require.Equal(t, "deployment-triggered-id", eventRepo.DeleteEventCalls()[0].EventID)
+	require.Len(t, eventRepo.InsertEventCalls(), 1)
+	newTriggeredEvent := eventRepo.InsertEventCalls()[0].Event
+	require.Equal(t, keptnv2.GetTriggeredEventType("deployment"), *newTriggeredEvent.Type)
+	require.True(t, newTriggeredEvent.Time.After(time.Now().UTC().Add(50*time.Second)))
+
+	require.Len(t, eventDispatcher.AddCalls(), 1)
+
+	// the sequence stays at the deployment task, and the failed attempt is recorded
+	require.Len(t, sequenceExecutionRepo.UpsertCalls(), 1)
+	require.Empty(t, upsertedSequenceExecution.Status.PreviousTasks)
+	require.Equal(t, "deployment", upsertedSequenceExecution.Status.CurrentTask.Name)
+	require.Equal(t, newTriggeredEvent.ID, upsertedSequenceExecution.Status.CurrentTask.TriggeredID)
+	require.Empty(t, upsertedSequenceExecution.Status.CurrentTask.Events)
+	require.Len(t, upsertedSequenceExecution.Status.CurrentTask.Attempts, 1)
+	require.Equal(t, "deployment-triggered-id", upsertedSequenceExecution.Status.CurrentTask.Attempts[0].TriggeredID)
+	require.Equal(t, keptnv2.StatusErrored, upsertedSequenceExecution.Status.CurrentTask.Attempts[0].Status)
+}
+
+func Test_shipyardController_RejectsSequenceDuringFreezeWindow(t *testing.T) {
+	shipyard := &keptnv2.Shipyard{
+		Spec: keptnv2.ShipyardSpec{
+			Stages: []keptnv2.Stage{
+				{
+					Name:      "production",
+					Sequences: []keptnv2.Sequence{{Name: "delivery", Tasks: []keptnv2.Task{{Name: "deployment"}}}},
+				},
+			},
+		},
+	}
+	eventDispatcher := &fake.IEventDispatcherMock{
+		AddFunc: func(event models.DispatcherEvent, skipQueue bool) error {
+			return nil
+		},
+	}
+	sequenceExecutionRepo := &db_mock.SequenceExecutionRepoMock{}
+	sc := &shipyardController{
+		eventDispatcher:       eventDispatcher,
+		sequenceExecutionRepo: sequenceExecutionRepo,
+		shipyardRetriever: &fake.IShipyardRetrieverMock{
+			GetShipyardFunc: func(projectName string) (*keptnv2.Shipyard, error) {
+				return shipyard, nil
+			},
+			GetCachedFreezeWindowsFunc: func(projectName string) (map[string][]common.FreezeWindow, error) {
+				return map[string][]common.FreezeWindow{
+					"production": {{Name: "release-freeze", Action: common.FreezeActionReject}},
+				}, nil
+			},
+		},
+	}
+
+	err := sc.handleSequenceTriggered(context.Background(), apimodels.KeptnContextExtendedCE{
+		Data:           keptnv2.EventData{Project: "my-project", Stage: "production", Service: "my-service"},
+		ID:             "my-triggered-id",
+		Shkeptncontext: "my-context",
+		Source:         common.Stringp("shipyard-controller"),
+		Type:           common.Stringp(keptnv2.GetTriggeredEventType("production.delivery")),
+	})
+	require.Nil(t, err)
+
+	// the sequence must not be stored, but finished immediately
+	require.Empty(t, sequenceExecutionRepo.UpsertCalls())
+	require.Len(t, eventDispatcher.AddCalls(), 1)
+
+	finishedEvent := eventDispatcher.AddCalls()[0].Event.Event
+	require.Equal(t, keptnv2.GetFinishedEventType("production.delivery"), finishedEvent.Type())
+
+	eventData := &keptnv2.EventData{}
+	require.Nil(t, finishedEvent.DataAs(eventData))
+	require.Equal(t, keptnv2.StatusErrored, eventData.Status)
+	require.Equal(t, keptnv2.ResultFailed, eventData.Result)
+	require.Contains(t, eventData.Message, "stage production is frozen by freeze window release-freeze")
+}
+
+func Test_shipyardController_OverrideFreeze(t *testing.T) {
+	sequenceExecutionRepo := &db_mock.SequenceExecutionRepoMock{
+		GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+			require.Equal(t, "my-context", filter.Scope.KeptnContext)
+			require.Equal(t, "production", filter.Scope.Stage)
+			return []models.SequenceExecution{
+				{
+					ID:       "my-id",
+					Sequence: keptnv2.Sequence{Name: "delivery"},
+					Status:   models.SequenceExecutionStatus{State: apimodels.SequenceWaitingState},
+					Scope: models.EventScope{
+						EventData:    keptnv2.EventData{Project: "my-project", Stage: "production", Service: "my-service"},
+						KeptnContext: "my-context",
+					},
+				},
+			}, nil
+		},
+		UpdateStatusFunc: func(taskSequence models.SequenceExecution) (*models.SequenceExecution, error) {
+			return &taskSequence, nil
+		},
+	}
+	sc := &shipyardController{
+		sequenceExecutionRepo: sequenceExecutionRepo,
+	}
+
+	err := sc.ControlSequence(apimodels.SequenceControl{
+		State:        models.OverrideFreezeSequence,
+		KeptnContext: "my-context",
+		Stage:        "production",
+		Project:      "my-project",
+	})
+	require.Nil(t, err)
+
+	require.Len(t, sequenceExecutionRepo.UpdateStatusCalls(), 1)
+	require.True(t, sequenceExecutionRepo.UpdateStatusCalls()[0].TaskSequence.Status.FreezeOverridden)
+}
+
+func Test_shipyardController_SetsPriorityOfTriggeredSequence(t *testing.T) {
+	shipyard := &keptnv2.Shipyard{
+		Spec: keptnv2.ShipyardSpec{
+			Stages: []keptnv2.Stage{
+				{
+					Name: "production",
+					Sequences: []keptnv2.Sequence{
+						{Name: "delivery", Tasks: []keptnv2.Task{{Name: "deployment"}}},
+						{Name: "remediation", Tasks: []keptnv2.Task{{Name: "action"}}},
+					},
+				},
+			},
+		},
+	}
+	priorityClasses, err := common.NewPriorityClasses(map[string]int{"high": 100, "urgent": 1000}, map[string]string{"remediation": "high"})
+	require.Nil(t, err)
+
+	sequenceExecutionRepo := &db_mock.SequenceExecutionRepoMock{
+		IsContextPausedFunc: func(eventScope models.EventScope) bool {
+			return false
+		},
+		UpsertFunc: func(item models.SequenceExecution, upsertOptions *models.SequenceExecutionUpsertOptions) error {
+			return nil
+		},
+	}
+	sequenceDispatcher := &fake.ISequenceDispatcherMock{
+		AddFunc: func(queueItem models.QueueItem) error {
+			return nil
+		},
+	}
+	sc := &shipyardController{
+		eventRepo: &db_mock.EventRepoMock{
+			InsertEventFunc: func(project string, event apimodels.KeptnContextExtendedCE, status common.EventStatus) error {
+				return nil
+			},
+		},
+		sequenceExecutionRepo: sequenceExecutionRepo,
+		sequenceDispatcher:    sequenceDispatcher,
+		shipyardRetriever: &fake.IShipyardRetrieverMock{
+			GetShipyardFunc: func(projectName string) (*keptnv2.Shipyard, error) {
+				return shipyard, nil
+			},
+			GetCachedFreezeWindowsFunc: func(projectName string) (map[string][]common.FreezeWindow, error) {
+				return nil, nil
+			},
+			GetCachedSequenceTimeoutsFunc: func(projectName string) (map[string]map[string]string, error) {
+				return nil, nil
+			},
+			GetCachedTaskAttributesFunc: func(projectName string) (map[string]map[string][]common.TaskAttributes, error) {
+				return nil, nil
+			},
+			GetLatestCommitIDFunc: func(projectName string, stageName string) (string, error) {
+				return "", nil
+			},
+		},
+		priorityClasses: priorityClasses,
+	}
+
+	triggerSequence := func(id, sequence string, labels map[string]string) {
+		err := sc.handleSequenceTriggered(context.Background(), apimodels.KeptnContextExtendedCE{
+			Data:           keptnv2.EventData{Project: "my-project", Stage: "production", Service: "my-service", Labels: labels},
+			ID:             id,
+			Shkeptncontext: "my-context-" + id,
+			Source:         common.Stringp("shipyard-controller"),
+			Type:           common.Stringp(keptnv2.GetTriggeredEventType("production." + sequence)),
+		})
+		require.Nil(t, err)
+	}
+
+	triggerSequence("delivery", "delivery", nil)
+	triggerSequence("remediation", "remediation", nil)
+	triggerSequence("hotfix", "delivery", map[string]string{common.PriorityClassLabel: "urgent"})
+
+	wantPriorities := []int{0, 100, 1000}
+	require.Len(t, sequenceDispatcher.AddCalls(), len(wantPriorities))
+	for i, want := range wantPriorities {
+		require.Equal(t, want, sequenceExecutionRepo.UpsertCalls()[i].Item.Priority)
+		require.Equal(t, want, sequenceDispatcher.AddCalls()[i].QueueItem.Priority)
+	}
+}
+
+func Test_shipyardController_SetsTimeoutOfTriggeredSequence(t *testing.T) {
+	shipyardContent := `apiVersion: "spec.keptn.sh/0.2.3"
+kind: "Shipyard"
+metadata:
+  name: "shipyard-sockshop"
+spec:
+  stages:
+    - name: "production"
+      sequences:
+        - name: "delivery"
+          timeout: "2h"
+          tasks:
+            - name: "deployment"
+        - name: "evaluation"
+          tasks:
+            - name: "evaluation"`
+
+	sequenceExecutionRepo := &db_mock.SequenceExecutionRepoMock{
+		IsContextPausedFunc: func(eventScope models.EventScope) bool {
+			return false
+		},
+		UpsertFunc: func(item models.SequenceExecution, upsertOptions *models.SequenceExecutionUpsertOptions) error {
+			return nil
+		},
+	}
+	sc := &shipyardController{
+		eventRepo: &db_mock.EventRepoMock{
+			InsertEventFunc: func(project string, event apimodels.KeptnContextExtendedCE, status common.EventStatus) error {
+				return nil
+			},
+		},
+		sequenceExecutionRepo: sequenceExecutionRepo,
+		sequenceDispatcher: &fake.ISequenceDispatcherMock{
+			AddFunc: func(queueItem models.QueueItem) error {
+				return nil
+			},
+		},
+		shipyardRetriever: &fake.IShipyardRetrieverMock{
+			GetShipyardFunc: func(projectName string) (*keptnv2.Shipyard, error) {
+				return common.UnmarshalShipyard(shipyardContent)
+			},
+			GetCachedFreezeWindowsFunc: func(projectName string) (map[string][]common.FreezeWindow, error) {
+				return nil, nil
+			},
+			GetCachedSequenceTimeoutsFunc: func(projectName string) (map[string]map[string]string, error) {
+				return common.GetShipyardSequenceTimeouts(shipyardContent)
+			},
+			GetCachedTaskAttributesFunc: func(projectName string) (map[string]map[string][]common.TaskAttributes, error) {
+				return common.GetShipyardTaskAttributes(shipyardContent)
+			},
+			GetLatestCommitIDFunc: func(projectName string, stageName string) (string, error) {
+				return "", nil
+			},
+		},
+	}
+
+	for _, sequence := range []string{"delivery", "evaluation"} {
+		err := sc.handleSequenceTriggered(context.Background(), apimodels.KeptnContextExtendedCE{
+			Data:           keptnv2.EventData{Project: "my-project", Stage: "production", Service: "my-service"},
+			ID:             sequence,
+			Shkeptncontext: "my-context-" + sequence,
+			Source:         common.Stringp("shipyard-controller"),
+			Type:           common.Stringp(keptnv2.GetTriggeredEventType("production." + sequence)),
+		})
+		require.Nil(t, err)
+	}
+
+	require.Len(t, sequenceExecutionRepo.UpsertCalls(), 2)
+	require.Equal(t, "2h", sequenceExecutionRepo.UpsertCalls()[0].Item.Timeout)
+	require.Empty(t, sequenceExecutionRepo.UpsertCalls()[1].Item.Timeout)
+}
+
+func Test_shipyardController_RetrySequence(t *testing.T) {
+	sequence := keptnv2.Sequence{
+		Name:  "delivery",
+		Tasks: []keptnv2.Task{{Name: "deployment"}, {Name: "test"}, {Name: "release"}},
+	}
+	scope := models.EventScope{
+		EventData:    keptnv2.EventData{Project: "my-project", Stage: "dev", Service: "my-service"},
+		KeptnContext: "my-context",
+		TriggeredID:  "original-triggered-id",
+	}
+	failedSequence := models.SequenceExecution{
+		ID:       "failed-id",
+		Sequence: sequence,
+		Status: models.SequenceExecutionStatus{
+			State: apimodels.SequenceFinished,
+			PreviousTasks: []models.TaskExecutionResult{
+				{Name: "deployment", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded, Properties: map[string]interface{}{"deploymentURI": "my-url"}},
+				{Name: "test", Result: keptnv2.ResultFailed, Status: keptnv2.StatusSucceeded},
+			},
+		},
+		InputProperties: map[string]interface{}{"project": "my-project", "stage": "dev", "service": "my-service", "configurationChange": map[string]interface{}{"image": "my-image"}},
+		Scope:           scope,
+		TriggeredAt:     time.Now().UTC().Add(-time.Hour),
+		Priority:        100,
+	}
+
+	sequenceExecutions := []models.SequenceExecution{failedSequence}
+	sequenceExecutionRepo := &db_mock.SequenceExecutionRepoMock{
+		GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+			return sequenceExecutions, nil
+		},
+		IsContextPausedFunc: func(eventScope models.EventScope) bool {
+			return false
+		},
+		UpsertFunc: func(item models.SequenceExecution, upsertOptions *models.SequenceExecutionUpsertOptions) error {
+			return nil
+		},
+	}
+	eventRepo := &db_mock.EventRepoMock{
+		InsertEventFunc: func(project string, event apimodels.KeptnContextExtendedCE, status common.EventStatus) error {
+			return nil
+		},
+	}
+	sequenceDispatcher := &fake.ISequenceDispatcherMock{
+		AddFunc: func(queueItem models.QueueItem) error {
+			return nil
+		},
+	}
+	sc := &shipyardController{
+		eventRepo:             eventRepo,
+		sequenceExecutionRepo: sequenceExecutionRepo,
+		sequenceDispatcher:    sequenceDispatcher,
+		shipyardRetriever: &fake.IShipyardRetrieverMock{
+			GetLatestCommitIDFunc: func(projectName string, stageName string) (string, error) {
+				return "latest-commit", nil
+			},
+		},
+	}
+
+	controlSequence := apimodels.SequenceControl{State: models.RetrySequence, Project: "my-project", KeptnContext: "my-context"}
+
+	err := sc.RetrySequence(controlSequence, "")
+	require.Nil(t, err)
+
+	require.Len(t, eventRepo.InsertEventCalls(), 1)
+	triggeredEvent := eventRepo.InsertEventCalls()[0].Event
+	require.Equal(t, common.TriggeredEvent, eventRepo.InsertEventCalls()[0].Status)
+	require.Equal(t, keptnv2.GetTriggeredEventType("dev.delivery"), *triggeredEvent.Type)
+	require.Equal(t, "my-context", triggeredEvent.Shkeptncontext)
+	require.Equal(t, "latest-commit", triggeredEvent.GitCommitID)
+
+	require.Len(t, sequenceExecutionRepo.UpsertCalls(), 1)
+	retried := sequenceExecutionRepo.UpsertCalls()[0].Item
+	require.NotEqual(t, failedSequence.ID, retried.ID)
+	require.Equal(t, apimodels.SequenceTriggeredState, retried.Status.State)
+	require.Equal(t, failedSequence.Status.PreviousTasks[:1], retried.Status.PreviousTasks)
+	require.Equal(t, "my-context", retried.Scope.KeptnContext)
+	require.Equal(t, triggeredEvent.ID, retried.Scope.TriggeredID)
+	require.Equal(t, failedSequence.Priority, retried.Priority)
+	require.Equal(t, "test", retried.GetNextTaskOfSequence().Name)
+
+	require.Len(t, sequenceDispatcher.AddCalls(), 1)
+	require.Equal(t, triggeredEvent.ID, sequenceDispatcher.AddCalls()[0].QueueItem.EventID)
+
+	// the original sequence is only retried once, because the retried one is the most recent one of the stage
+	retried.Status.State = apimodels.SequenceStartedState
+	retried.TriggeredAt = time.Now().UTC()
+	sequenceExecutions = append(sequenceExecutions, retried)
+
+	err = sc.RetrySequence(controlSequence, "")
+	require.ErrorIs(t, err, ErrSequenceNotRetryable)
+
+	// unknown tasks are rejected
+	sequenceExecutions = []models.SequenceExecution{failedSequence}
+	err = sc.RetrySequence(controlSequence, "unknown")
+	require.ErrorIs(t, err, ErrSequenceNotRetryable)
+
+	sequenceExecutions = nil
+	err = sc.RetrySequence(controlSequence, "")
+	require.ErrorIs(t, err, ErrSequenceNotFound)
+}
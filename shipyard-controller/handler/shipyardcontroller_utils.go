@@ -3,8 +3,11 @@ package handler
 import (
 	"encoding/json"
 	"fmt"
+	"time"
+
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/keptn/keptn/shipyard-controller/models"
 	log "github.com/sirupsen/logrus"
 )
@@ -49,6 +52,23 @@ func GetStageFromShipyard(stageName string, shipyard *keptnv2.Shipyard) *keptnv2
 	return nil
 }
 
+// getActiveFreezeWindow returns the freeze window of the given stage that currently affects the given sequence, or nil if there is none
+func getActiveFreezeWindow(shipyardRetriever IShipyardRetriever, projectName, stageName, sequenceName string, now time.Time) (*common.FreezeWindow, error) {
+	freezeWindows, err := shipyardRetriever.GetCachedFreezeWindows(projectName)
+	if err != nil {
+		return nil, err
+	}
+	return common.GetActiveFreezeWindow(freezeWindows[stageName], sequenceName, now), nil
+}
+
+func getFreezeWindowMessage(freezeWindow common.FreezeWindow, stageName string) string {
+	msg := fmt.Sprintf("stage %s is frozen by freeze window %s", stageName, freezeWindow.Name)
+	if freezeWindow.Message != "" {
+		msg += ": " + freezeWindow.Message
+	}
+	return msg
+}
+
 func GetTaskSequencesByTrigger(eventScope models.EventScope, completedTaskSequence string, shipyard *keptnv2.Shipyard, previousTask string) []NextTaskSequence {
 	var result []NextTaskSequence
 
@@ -0,0 +1,318 @@
+package handler
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/common"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+)
+
+type IShipyardHandler interface {
+	ValidateShipyard(context *gin.Context)
+	PlanSequence(context *gin.Context)
+}
+
+type ShipyardHandler struct {
+	projectMVRepo db.ProjectMVRepo
+	uniformRepo   db.UniformRepo
+}
+
+// NewShipyardHandler creates a new ShipyardHandler
+func NewShipyardHandler(projectMVRepo db.ProjectMVRepo, uniformRepo db.UniformRepo) *ShipyardHandler {
+	return &ShipyardHandler{
+		projectMVRepo: projectMVRepo,
+		uniformRepo:   uniformRepo,
+	}
+}
+
+// ValidateShipyard godoc
+// @Summary      Validate a shipyard
+// @Description  Validate the given shipyard, or the current shipyard of the project, without applying it. Besides the schema, the stage names, the references of triggers and cycles between sequences are checked.
+// @Description  Tasks that no integration is subscribed to are reported as warnings
+// @Tags         Project
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project   path      string                           true   "The name of the project"
+// @Param        shipyard  body      models.ValidateShipyardParams    false  "Shipyard"
+// @Success      200       {object}  models.ShipyardValidationResult  "ok"
+// @Failure      400       {object}  models.Error                     "Invalid payload"
+// @Failure      404       {object}  models.Error                     "Not found"
+// @Failure      500       {object}  models.Error                     "Internal error"
+// @Router       /project/{project}/shipyard/validate [post]
+func (sh *ShipyardHandler) ValidateShipyard(c *gin.Context) {
+	params := &models.ValidateShipyardParams{}
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(params); err != nil {
+			SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidRequestFormatMsg, err.Error()))
+			return
+		}
+	}
+
+	project, shipyardContent, err := sh.getShipyardContent(c.Param("project"), params.Shipyard)
+	if err != nil {
+		setShipyardErrorResponse(c, err)
+		return
+	}
+
+	integrations, err := sh.uniformRepo.GetUniformIntegrations(models.GetUniformIntegrationsParams{})
+	if err != nil {
+		SetInternalServerErrorResponse(c, fmt.Sprintf(UnableQueryIntegrationsMsg, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, validateShipyardContent(project, shipyardContent, integrations))
+}
+
+// PlanSequence godoc
+// @Summary      Preview the execution of a sequence
+// @Description  Get the sequences and tasks that would be executed if the given sequence was triggered, without emitting any event
+// @Tags         Sequence
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project  path      string                     true  "The name of the project"
+// @Param        plan     body      models.PlanSequenceParams  true  "Trigger of the sequence"
+// @Success      200      {object}  models.SequencePlan        "ok"
+// @Failure      400      {object}  models.Error               "Invalid payload"
+// @Failure      404      {object}  models.Error               "Not found"
+// @Failure      500      {object}  models.Error               "Internal error"
+// @Router       /project/{project}/sequence/plan [post]
+func (sh *ShipyardHandler) PlanSequence(c *gin.Context) {
+	params := &models.PlanSequenceParams{}
+	if err := c.ShouldBindJSON(params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidRequestFormatMsg, err.Error()))
+		return
+	}
+	result := keptnv2.ResultPass
+	if params.Result != "" {
+		result = keptnv2.ResultType(params.Result)
+	}
+	if result != keptnv2.ResultPass && result != keptnv2.ResultWarning && result != keptnv2.ResultFailed {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidPayloadMsg, "result must be one of 'pass', 'warning' or 'fail'"))
+		return
+	}
+
+	_, shipyardContent, err := sh.getShipyardContent(c.Param("project"), params.Shipyard)
+	if err != nil {
+		setShipyardErrorResponse(c, err)
+		return
+	}
+	shipyard, err := common.UnmarshalShipyard(shipyardContent)
+	if err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidPayloadMsg, err.Error()))
+		return
+	}
+
+	integrations, err := sh.uniformRepo.GetUniformIntegrations(models.GetUniformIntegrationsParams{})
+	if err != nil {
+		SetInternalServerErrorResponse(c, fmt.Sprintf(UnableQueryIntegrationsMsg, err.Error()))
+		return
+	}
+
+	plan, err := planSequence(c.Param("project"), shipyard, shipyardContent, params.Stage, params.Sequence, result, integrations)
+	if err != nil {
+		SetNotFoundErrorResponse(c, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, plan)
+}
+
+// getShipyardContent returns the project and the given base64 encoded shipyard. If no shipyard is given, the current shipyard of the project is returned
+func (sh *ShipyardHandler) getShipyardContent(projectName, encodedShipyard string) (*apimodels.ExpandedProject, string, error) {
+	project, err := sh.projectMVRepo.GetProject(projectName)
+	if err != nil {
+		return nil, "", err
+	}
+	if project == nil {
+		return nil, "", ErrProjectNotFound
+	}
+	if encodedShipyard == "" {
+		return project, project.Shipyard, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encodedShipyard)
+	if err != nil {
+		return nil, "", ErrInvalidShipyardEncoding
+	}
+	return project, string(decoded), nil
+}
+
+func setShipyardErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrProjectNotFound):
+		SetNotFoundErrorResponse(c, fmt.Sprintf(ProjectNotFoundMsg, c.Param("project")))
+	case errors.Is(err, ErrInvalidShipyardEncoding):
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidPayloadMsg, err.Error()))
+	default:
+		SetInternalServerErrorResponse(c, err.Error())
+	}
+}
+
+// validateShipyardContent checks the given shipyard content. Problems that prevent the shipyard from being used are reported as errors,
+// while tasks that are not handled by any of the given integrations are reported as warnings
+func validateShipyardContent(project *apimodels.ExpandedProject, shipyardContent string, integrations []apimodels.Integration) models.ShipyardValidationResult {
+	result := models.ShipyardValidationResult{
+		Errors:   []string{},
+		Warnings: []string{},
+	}
+
+	shipyard, err := common.UnmarshalShipyard(shipyardContent)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("could not unmarshal shipyard content: %s", err.Error()))
+		return result
+	}
+
+	validators := []func() error{
+		func() error { return common.ValidateShipyardVersion(shipyard) },
+		func() error { return common.ValidateShipyardStages(shipyard) },
+		func() error { return common.ValidateShipyardTasks(shipyardContent) },
+		func() error { return common.ValidateShipyardFreezeWindows(shipyardContent) },
+		func() error { return common.ValidateShipyardSequenceTimeouts(shipyardContent) },
+		func() error { return common.ValidateShipyardTriggers(shipyard) },
+		func() error { return validateStagesOfProject(project, shipyard) },
+	}
+	for _, validate := range validators {
+		if err := validate(); err != nil {
+			result.Errors = append(result.Errors, err.Error())
+		}
+	}
+	if cycle := common.GetShipyardSequenceCycle(shipyard); cycle != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("sequences trigger each other in a cycle: %s", strings.Join(cycle, " -> ")))
+	}
+
+	for _, stage := range shipyard.Spec.Stages {
+		for _, sequence := range stage.Sequences {
+			for _, task := range sequence.Tasks {
+				if len(getSubscribedIntegrations(integrations, project.ProjectName, stage.Name, task.Name)) == 0 {
+					result.Warnings = append(result.Warnings, fmt.Sprintf("no integration is subscribed to task %s of sequence %s in stage %s", task.Name, sequence.Name, stage.Name))
+				}
+			}
+		}
+	}
+
+	result.Valid = len(result.Errors) == 0
+	return result
+}
+
+// validateStagesOfProject checks whether the shipyard contains all stages of the project, since stages cannot be removed or renamed when the shipyard of a project is updated
+func validateStagesOfProject(project *apimodels.ExpandedProject, shipyard *keptnv2.Shipyard) error {
+	for _, projectStage := range project.Stages {
+		if GetStageFromShipyard(projectStage.StageName, shipyard) == nil {
+			return fmt.Errorf("stage %s of project %s is missing: %w", projectStage.StageName, project.ProjectName, ErrInvalidStageChange)
+		}
+	}
+	return nil
+}
+
+// planSequence determines the sequences and tasks that are executed if the given sequence is triggered, assuming that each sequence finishes with the given result
+func planSequence(projectName string, shipyard *keptnv2.Shipyard, shipyardContent, stageName, sequenceName string, result keptnv2.ResultType, integrations []apimodels.Integration) (*models.SequencePlan, error) {
+	sequence, err := GetTaskSequenceInStage(stageName, sequenceName, shipyard)
+	if err != nil {
+		return nil, err
+	}
+	sequenceTimeouts, err := common.GetShipyardSequenceTimeouts(shipyardContent)
+	if err != nil {
+		return nil, err
+	}
+	taskAttributes, err := common.GetShipyardTaskAttributes(shipyardContent)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &models.SequencePlan{Steps: []models.SequencePlanStep{}}
+	queue := []NextTaskSequence{{Sequence: *sequence, StageName: stageName}}
+	triggeredBy := map[string]string{}
+	visited := map[string]bool{}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		sequenceKey := next.StageName + "." + next.Sequence.Name
+		// sequences that trigger each other in a cycle are only planned once
+		if visited[sequenceKey] {
+			continue
+		}
+		visited[sequenceKey] = true
+
+		step := models.SequencePlanStep{
+			Stage:       next.StageName,
+			Sequence:    next.Sequence.Name,
+			TriggeredBy: triggeredBy[sequenceKey],
+			Timeout:     sequenceTimeouts[next.StageName][next.Sequence.Name],
+			Tasks:       []models.PlannedTask{},
+		}
+		sequenceTaskAttributes := taskAttributes[next.StageName][next.Sequence.Name]
+		for taskIndex, task := range next.Sequence.Tasks {
+			attributes := common.TaskAttributes{}
+			if taskIndex < len(sequenceTaskAttributes) {
+				attributes = sequenceTaskAttributes[taskIndex]
+			}
+			plannedTask := models.PlannedTask{
+				Name:         task.Name,
+				Parallel:     attributes.GetParallelGroup(),
+				When:         attributes.GetCondition(),
+				Integrations: getSubscribedIntegrations(integrations, projectName, next.StageName, task.Name),
+			}
+			if timeout, err := attributes.GetTimeout(); err == nil && timeout > 0 {
+				plannedTask.Timeout = timeout.String()
+			}
+			step.Tasks = append(step.Tasks, plannedTask)
+		}
+		plan.Steps = append(plan.Steps, step)
+
+		lastTask := ""
+		if len(next.Sequence.Tasks) > 0 {
+			lastTask = next.Sequence.Tasks[len(next.Sequence.Tasks)-1].Name
+		}
+		eventScope := models.EventScope{EventData: keptnv2.EventData{Stage: next.StageName, Result: result}}
+		for _, triggeredSequence := range GetTaskSequencesByTrigger(eventScope, next.Sequence.Name, shipyard, lastTask) {
+			triggeredKey := triggeredSequence.StageName + "." + triggeredSequence.Sequence.Name
+			if _, ok := triggeredBy[triggeredKey]; !ok {
+				triggeredBy[triggeredKey] = sequenceKey + "." + string(common.FinishedEvent)
+			}
+			queue = append(queue, triggeredSequence)
+		}
+	}
+	return plan, nil
+}
+
+// getSubscribedIntegrations returns the names of the integrations that are subscribed to the '.triggered' event of the given task in the given stage of the project
+func getSubscribedIntegrations(integrations []apimodels.Integration, projectName, stageName, taskName string) []string {
+	eventType := keptnv2.GetTriggeredEventType(taskName)
+	result := []string{}
+	for _, integration := range integrations {
+		for _, subscription := range integration.Subscriptions {
+			if !common.MatchesEventSubject(eventType, subscription.Event) {
+				continue
+			}
+			if !matchesSubscriptionFilter(subscription.Filter.Projects, projectName) || !matchesSubscriptionFilter(subscription.Filter.Stages, stageName) {
+				continue
+			}
+			result = append(result, integration.Name)
+			break
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// matchesSubscriptionFilter determines whether a value is matched by the filter of a subscription. An empty filter matches all values
+func matchesSubscriptionFilter(filter []string, value string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, item := range filter {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,275 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+const shipyardHandlerTestShipyard = `apiVersion: "spec.keptn.sh/0.2.3"
+kind: "Shipyard"
+metadata:
+  name: "shipyard-sockshop"
+spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          timeout: "1h"
+          tasks:
+            - name: "deployment"
+              timeout: "30m"
+            - name: "test"
+              parallel: "verification"
+            - name: "security-scan"
+              parallel: "verification"
+    - name: "production"
+      sequences:
+        - name: "delivery"
+          triggeredOn:
+            - event: "dev.delivery.finished"
+          tasks:
+            - name: "deployment"
+            - name: "release"
+              when: "deployment.result == pass"
+        - name: "rollback"
+          triggeredOn:
+            - event: "production.delivery.finished"
+              selector:
+                match:
+                  result: "fail"
+          tasks:
+            - name: "rollback"`
+
+const shipyardHandlerTestShipyardWithCycle = `apiVersion: "spec.keptn.sh/0.2.3"
+kind: "Shipyard"
+metadata:
+  name: "shipyard-sockshop"
+spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          triggeredOn:
+            - event: "production.delivery.finished"
+          tasks:
+            - name: "deployment"
+    - name: "production"
+      sequences:
+        - name: "delivery"
+          triggeredOn:
+            - event: "dev.delivery.finished"
+            - event: "staging.delivery.finished"
+          tasks:
+            - name: "deployment"`
+
+func newShipyardHandlerTestRepos() (*db_mock.ProjectMVRepoMock, *db_mock.UniformRepoMock) {
+	projectMVRepo := &db_mock.ProjectMVRepoMock{
+		GetProjectFunc: func(projectName string) (*apimodels.ExpandedProject, error) {
+			if projectName != "my-project" {
+				return nil, nil
+			}
+			return &apimodels.ExpandedProject{
+				ProjectName: "my-project",
+				Shipyard:    shipyardHandlerTestShipyard,
+				Stages:      []*apimodels.ExpandedStage{{StageName: "dev"}, {StageName: "production"}},
+			}, nil
+		},
+	}
+	uniformRepo := &db_mock.UniformRepoMock{
+		GetUniformIntegrationsFunc: func(filter models.GetUniformIntegrationsParams) ([]apimodels.Integration, error) {
+			return []apimodels.Integration{
+				{
+					Name:          "helm-service",
+					Subscriptions: []apimodels.EventSubscription{{Event: "sh.keptn.event.deployment.triggered"}, {Event: "sh.keptn.event.release.triggered"}, {Event: "sh.keptn.event.rollback.triggered"}},
+				},
+				{
+					Name:          "jmeter-service",
+					Subscriptions: []apimodels.EventSubscription{{Event: "sh.keptn.event.test.triggered", Filter: apimodels.EventSubscriptionFilter{Stages: []string{"dev"}}}},
+				},
+				{
+					Name:          "webhook-service",
+					Subscriptions: []apimodels.EventSubscription{{Event: "sh.keptn.event.>", Filter: apimodels.EventSubscriptionFilter{Projects: []string{"other-project"}}}},
+				},
+			}, nil
+		},
+	}
+	return projectMVRepo, uniformRepo
+}
+
+func TestShipyardHandler_ValidateShipyard(t *testing.T) {
+	tests := []struct {
+		name         string
+		request      *http.Request
+		wantStatus   int
+		wantResponse *models.ShipyardValidationResult
+	}{
+		{
+			name:       "validate current shipyard",
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/shipyard/validate", nil),
+			wantStatus: http.StatusOK,
+			wantResponse: &models.ShipyardValidationResult{
+				Valid:    true,
+				Errors:   []string{},
+				Warnings: []string{"no integration is subscribed to task security-scan of sequence delivery in stage dev"},
+			},
+		},
+		{
+			name:       "validate given shipyard",
+			request:    newShipyardHandlerTestRequest("/project/my-project/shipyard/validate", models.ValidateShipyardParams{Shipyard: base64.StdEncoding.EncodeToString([]byte(shipyardHandlerTestShipyardWithCycle))}),
+			wantStatus: http.StatusOK,
+			wantResponse: &models.ShipyardValidationResult{
+				Valid: false,
+				Errors: []string{
+					"sequence delivery in stage production: trigger 'staging.delivery.finished' refers to sequence delivery in stage staging, which does not exist",
+					"sequences trigger each other in a cycle: dev.delivery -> production.delivery -> dev.delivery",
+				},
+				Warnings: []string{},
+			},
+		},
+		{
+			name:       "invalid shipyard encoding",
+			request:    newShipyardHandlerTestRequest("/project/my-project/shipyard/validate", models.ValidateShipyardParams{Shipyard: "not base64"}),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "project not found",
+			request:    httptest.NewRequest(http.MethodPost, "/project/unknown/shipyard/validate", nil),
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sh := handler.NewShipyardHandler(newShipyardHandlerTestRepos())
+
+			router := gin.Default()
+			router.POST("/project/:project/shipyard/validate", func(c *gin.Context) {
+				sh.ValidateShipyard(c)
+			})
+			w := performRequest(router, tt.request)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantResponse != nil {
+				response := &models.ShipyardValidationResult{}
+				require.Nil(t, json.Unmarshal(w.Body.Bytes(), response))
+				require.Equal(t, tt.wantResponse, response)
+			}
+		})
+	}
+}
+
+func TestShipyardHandler_PlanSequence(t *testing.T) {
+	tests := []struct {
+		name         string
+		request      *http.Request
+		wantStatus   int
+		wantResponse *models.SequencePlan
+	}{
+		{
+			name:       "plan successful sequence",
+			request:    newShipyardHandlerTestRequest("/project/my-project/sequence/plan", models.PlanSequenceParams{Stage: "dev", Sequence: "delivery"}),
+			wantStatus: http.StatusOK,
+			wantResponse: &models.SequencePlan{
+				Steps: []models.SequencePlanStep{
+					{
+						Stage:    "dev",
+						Sequence: "delivery",
+						Timeout:  "1h",
+						Tasks: []models.PlannedTask{
+							{Name: "deployment", Timeout: "30m0s", Integrations: []string{"helm-service"}},
+							{Name: "test", Parallel: "verification", Integrations: []string{"jmeter-service"}},
+							{Name: "security-scan", Parallel: "verification", Integrations: []string{}},
+						},
+					},
+					{
+						Stage:       "production",
+						Sequence:    "delivery",
+						TriggeredBy: "dev.delivery.finished",
+						Tasks: []models.PlannedTask{
+							{Name: "deployment", Integrations: []string{"helm-service"}},
+							{Name: "release", When: "deployment.result == pass", Integrations: []string{"helm-service"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:       "plan failing sequence",
+			request:    newShipyardHandlerTestRequest("/project/my-project/sequence/plan", models.PlanSequenceParams{Stage: "production", Sequence: "delivery", Result: "fail"}),
+			wantStatus: http.StatusOK,
+			wantResponse: &models.SequencePlan{
+				Steps: []models.SequencePlanStep{
+					{
+						Stage:    "production",
+						Sequence: "delivery",
+						Tasks: []models.PlannedTask{
+							{Name: "deployment", Integrations: []string{"helm-service"}},
+							{Name: "release", When: "deployment.result == pass", Integrations: []string{"helm-service"}},
+						},
+					},
+					{
+						Stage:       "production",
+						Sequence:    "rollback",
+						TriggeredBy: "production.delivery.finished",
+						Tasks: []models.PlannedTask{
+							{Name: "rollback", Integrations: []string{"helm-service"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:       "unknown sequence",
+			request:    newShipyardHandlerTestRequest("/project/my-project/sequence/plan", models.PlanSequenceParams{Stage: "dev", Sequence: "remediation"}),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "invalid result",
+			request:    newShipyardHandlerTestRequest("/project/my-project/sequence/plan", models.PlanSequenceParams{Stage: "dev", Sequence: "delivery", Result: "unknown"}),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing sequence",
+			request:    newShipyardHandlerTestRequest("/project/my-project/sequence/plan", models.PlanSequenceParams{Stage: "dev"}),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "project not found",
+			request:    newShipyardHandlerTestRequest("/project/unknown/sequence/plan", models.PlanSequenceParams{Stage: "dev", Sequence: "delivery"}),
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sh := handler.NewShipyardHandler(newShipyardHandlerTestRepos())
+
+			router := gin.Default()
+			router.POST("/project/:project/sequence/plan", func(c *gin.Context) {
+				sh.PlanSequence(c)
+			})
+			w := performRequest(router, tt.request)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantResponse != nil {
+				response := &models.SequencePlan{}
+				require.Nil(t, json.Unmarshal(w.Body.Bytes(), response))
+				require.Equal(t, tt.wantResponse, response)
+			}
+		})
+	}
+}
+
+func newShipyardHandlerTestRequest(path string, payload interface{}) *http.Request {
+	body, _ := json.Marshal(payload)
+	return httptest.NewRequest(http.MethodPost, path, bytes.NewBuffer(body))
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/keptn/keptn/shipyard-controller/db"
 	log "github.com/sirupsen/logrus"
-	"gopkg.in/yaml.v3"
 )
 
 // IShipyardRetriever godoc
@@ -15,6 +14,9 @@ type IShipyardRetriever interface {
 	GetShipyard(projectName string) (*keptnv2.Shipyard, error)
 	GetCachedShipyard(projectName string) (*keptnv2.Shipyard, error)
 	GetLatestCommitID(projectName, stageName string) (string, error)
+	GetCachedFreezeWindows(projectName string) (map[string][]common.FreezeWindow, error)
+	GetCachedSequenceTimeouts(projectName string) (map[string]map[string]string, error)
+	GetCachedTaskAttributes(projectName string) (map[string]map[string][]common.TaskAttributes, error)
 }
 
 type ShipyardRetriever struct {
@@ -40,13 +42,8 @@ func (sr *ShipyardRetriever) GetShipyard(projectName string) (*keptnv2.Shipyard,
 		return nil, fmt.Errorf("could not unmarshal shipyard.yaml of project %s: %w", projectName, err)
 	}
 
-	// update the shipyard content of the project
-	shipyardContent, err := yaml.Marshal(shipyard)
-	if err != nil {
-		// log the error but continue
-		log.Errorf("could not encode shipyard file of project %s: %v", projectName, err)
-	}
-	if err := sr.projectRepo.UpdateShipyard(projectName, string(shipyardContent)); err != nil {
+	// update the shipyard content of the project. The original content is stored, since it contains attributes that are not part of keptnv2.Shipyard, e.g. the freeze windows of stages
+	if err := sr.projectRepo.UpdateShipyard(projectName, resource.ResourceContent); err != nil {
 		// log the error but continue
 		log.Errorf("could not update shipyard content of project %s: %v", projectName, err)
 	}
@@ -75,6 +72,42 @@ func (sr *ShipyardRetriever) GetCachedShipyard(projectName string) (*keptnv2.Shi
 	return shipyard, nil
 }
 
+// GetCachedFreezeWindows returns the freeze windows defined in the shipyard that is stored for the project in the materialized view, grouped by the names of their stages
+func (sr *ShipyardRetriever) GetCachedFreezeWindows(projectName string) (map[string][]common.FreezeWindow, error) {
+	project, err := sr.projectRepo.GetProject(projectName)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, db.ErrProjectNotFound
+	}
+	return common.GetShipyardFreezeWindows(project.Shipyard)
+}
+
+// GetCachedSequenceTimeouts returns the timeouts of the sequences defined in the shipyard that is stored for the project in the materialized view, grouped by the names of their stages and sequences
+func (sr *ShipyardRetriever) GetCachedSequenceTimeouts(projectName string) (map[string]map[string]string, error) {
+	project, err := sr.projectRepo.GetProject(projectName)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, db.ErrProjectNotFound
+	}
+	return common.GetShipyardSequenceTimeouts(project.Shipyard)
+}
+
+// GetCachedTaskAttributes returns the attributes of the tasks defined in the shipyard that is stored for the project in the materialized view, grouped by the names of their stages and sequences
+func (sr *ShipyardRetriever) GetCachedTaskAttributes(projectName string) (map[string]map[string][]common.TaskAttributes, error) {
+	project, err := sr.projectRepo.GetProject(projectName)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, db.ErrProjectNotFound
+	}
+	return common.GetShipyardTaskAttributes(project.Shipyard)
+}
+
 func (sr *ShipyardRetriever) GetLatestCommitID(projectName, stageName string) (string, error) {
 	stageMetadata, err := sr.configurationStore.GetStageResource(projectName, stageName, "metadata.yaml")
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptncommon "github.com/keptn/go-utils/pkg/lib/keptn"
 	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/keptn/keptn/shipyard-controller/models"
 )
@@ -15,15 +16,20 @@ import (
 type IStageHandler interface {
 	GetAllStages(context *gin.Context)
 	GetStage(context *gin.Context)
+	CreateStage(context *gin.Context)
+	UpdateStage(context *gin.Context)
+	DeleteStage(context *gin.Context)
 }
 
 type StageHandler struct {
 	StageManager IStageManager
+	Locker       ILocker
 }
 
-func NewStageHandler(stageManager IStageManager) *StageHandler {
+func NewStageHandler(stageManager IStageManager, locker ILocker) *StageHandler {
 	return &StageHandler{
 		StageManager: stageManager,
+		Locker:       locker,
 	}
 }
 
@@ -119,3 +125,148 @@ func (sh *StageHandler) GetStage(c *gin.Context) {
 	c.JSON(http.StatusOK, stage)
 
 }
+
+// CreateStage godoc
+// @Summary      Create a stage
+// @Description  Add a stage to a project. The stage is added to the shipyard of the project, and the services of the project are created in the new stage
+// @Tags         Stage
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project  path  string                    true  "The name of the project"
+// @Param        stage    body  models.CreateStageParams  true  "The stage to create"
+// @Success      201      "ok"
+// @Failure      400      {object}  models.Error  "Invalid payload"
+// @Failure      404      {object}  models.Error  "Not found"
+// @Failure      409      {object}  models.Error  "Conflict"
+// @Failure      500      {object}  models.Error  "Internal error"
+// @Router       /project/{project}/stage [post]
+func (sh *StageHandler) CreateStage(c *gin.Context) {
+	projectName := c.Param("project")
+
+	params := models.CreateStageParams{}
+	if err := c.ShouldBindJSON(&params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidRequestFormatMsg, err.Error()))
+		return
+	}
+	if err := validateStageName(params.StageName); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidPayloadMsg, err.Error()))
+		return
+	}
+
+	unlockProject, err := lockProject(sh.Locker, projectName)
+	if err != nil {
+		SetInternalServerErrorResponse(c, err.Error())
+		return
+	}
+	defer unlockProject()
+
+	if err := sh.StageManager.CreateStage(projectName, params); err != nil {
+		setStageErrorResponse(c, err)
+		return
+	}
+	c.Status(http.StatusCreated)
+}
+
+// UpdateStage godoc
+// @Summary      Update a stage
+// @Description  Rename a stage and/or change its position within the stages of the shipyard.
+// @Description  When a stage is renamed, its resources are moved to the new stage, and the triggers of sequences referring to the stage are updated. A stage cannot be renamed while sequences are active in the stage
+// @Tags         Stage
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project  path  string                    true  "The name of the project"
+// @Param        stage    path  string                    true  "The name of the stage"
+// @Param        update   body  models.UpdateStageParams  true  "The changes to the stage"
+// @Success      200      "ok"
+// @Failure      400      {object}  models.Error  "Invalid payload"
+// @Failure      404      {object}  models.Error  "Not found"
+// @Failure      409      {object}  models.Error  "Conflict"
+// @Failure      500      {object}  models.Error  "Internal error"
+// @Router       /project/{project}/stage/{stage} [put]
+func (sh *StageHandler) UpdateStage(c *gin.Context) {
+	projectName := c.Param("project")
+	stageName := c.Param("stage")
+
+	params := models.UpdateStageParams{}
+	if err := c.ShouldBindJSON(&params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidRequestFormatMsg, err.Error()))
+		return
+	}
+	if params.StageName == "" && params.Position == nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidPayloadMsg, "must provide a new name or position of the stage"))
+		return
+	}
+	if params.StageName != "" {
+		if err := validateStageName(params.StageName); err != nil {
+			SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidPayloadMsg, err.Error()))
+			return
+		}
+	}
+
+	unlockProject, err := lockProject(sh.Locker, projectName)
+	if err != nil {
+		SetInternalServerErrorResponse(c, err.Error())
+		return
+	}
+	defer unlockProject()
+
+	if err := sh.StageManager.UpdateStage(projectName, stageName, params); err != nil {
+		setStageErrorResponse(c, err)
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// DeleteStage godoc
+// @Summary      Delete a stage
+// @Description  Remove a stage from a project, including its branch or directory in the repository of the project.
+// @Description  A stage cannot be deleted while sequences are active in the stage, or if sequences of other stages are triggered by it
+// @Tags         Stage
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        project  path  string  true  "The name of the project"
+// @Param        stage    path  string  true  "The name of the stage"
+// @Success      200      "ok"
+// @Failure      404      {object}  models.Error  "Not found"
+// @Failure      409      {object}  models.Error  "Conflict"
+// @Failure      500      {object}  models.Error  "Internal error"
+// @Router       /project/{project}/stage/{stage} [delete]
+func (sh *StageHandler) DeleteStage(c *gin.Context) {
+	projectName := c.Param("project")
+	stageName := c.Param("stage")
+
+	unlockProject, err := lockProject(sh.Locker, projectName)
+	if err != nil {
+		SetInternalServerErrorResponse(c, err.Error())
+		return
+	}
+	defer unlockProject()
+
+	if err := sh.StageManager.DeleteStage(projectName, stageName); err != nil {
+		setStageErrorResponse(c, err)
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func validateStageName(stageName string) error {
+	if !keptncommon.ValidateKeptnEntityName(stageName) {
+		return errors.New("stage name must start with a lower case letter, followed by lower case letters, numbers, and hyphens")
+	}
+	return nil
+}
+
+func setStageErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrProjectNotFound), errors.Is(err, ErrStageNotFound):
+		SetNotFoundErrorResponse(c, err.Error())
+	case errors.Is(err, ErrInvalidStagePosition), errors.Is(err, ErrLastStage):
+		SetBadRequestErrorResponse(c, err.Error())
+	case errors.Is(err, ErrStageAlreadyExists), errors.Is(err, ErrStageHasActiveSequences), errors.Is(err, ErrStageReferenced):
+		SetConflictErrorResponse(c, err.Error())
+	default:
+		SetInternalServerErrorResponse(c, fmt.Sprintf(UnableUpdateStageMsg, err.Error()))
+	}
+}
@@ -2,12 +2,16 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
 	"github.com/keptn/keptn/shipyard-controller/handler/fake"
+	"github.com/keptn/keptn/shipyard-controller/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -69,7 +73,7 @@ func TestGetStage(t *testing.T) {
 				gin.Param{Key: "project", Value: "my-project"},
 				gin.Param{Key: "stageName", Value: "my-stage"},
 			}
-			handler := NewStageHandler(tt.fields.StageManager)
+			handler := NewStageHandler(tt.fields.StageManager, nil)
 			handler.GetStage(c)
 			assert.Equal(t, tt.expectHttpStatus, w.Code)
 
@@ -151,7 +155,7 @@ func TestGetStages(t *testing.T) {
 				gin.Param{Key: "projectName", Value: "my-project"},
 			}
 
-			handler := NewStageHandler(tt.fields.StageManager)
+			handler := NewStageHandler(tt.fields.StageManager, nil)
 			handler.GetAllStages(c)
 
 			if tt.expectJSONResponse != nil {
@@ -254,7 +258,7 @@ func TestGetStagesWithPagination(t *testing.T) {
 				gin.Param{Key: "projectName", Value: "my-project"},
 			}
 
-			handler := NewStageHandler(tt.fields.StageManager)
+			handler := NewStageHandler(tt.fields.StageManager, nil)
 			handler.GetAllStages(c)
 
 			if tt.expectJSONResponse != nil {
@@ -268,6 +272,277 @@ func TestGetStagesWithPagination(t *testing.T) {
 	}
 }
 
+func TestCreateStage(t *testing.T) {
+	tests := []struct {
+		name             string
+		payload          string
+		createErr        error
+		expectHttpStatus int
+		expectCreate     bool
+	}{
+		{
+			name:             "create stage",
+			payload:          `{"stageName": "hardening", "position": 1}`,
+			expectHttpStatus: http.StatusCreated,
+			expectCreate:     true,
+		},
+		{
+			name:             "stage name missing",
+			payload:          `{"position": 1}`,
+			expectHttpStatus: http.StatusBadRequest,
+		},
+		{
+			name:             "invalid stage name",
+			payload:          `{"stageName": "Hardening"}`,
+			expectHttpStatus: http.StatusBadRequest,
+		},
+		{
+			name:             "stage already exists",
+			payload:          `{"stageName": "hardening"}`,
+			createErr:        ErrStageAlreadyExists,
+			expectHttpStatus: http.StatusConflict,
+			expectCreate:     true,
+		},
+		{
+			name:             "project not found",
+			payload:          `{"stageName": "hardening"}`,
+			createErr:        ErrProjectNotFound,
+			expectHttpStatus: http.StatusNotFound,
+			expectCreate:     true,
+		},
+		{
+			name:             "internal error",
+			payload:          `{"stageName": "hardening"}`,
+			createErr:        errors.New("whoops"),
+			expectHttpStatus: http.StatusInternalServerError,
+			expectCreate:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stageManager := &fake.IStageManagerMock{
+				CreateStageFunc: func(projectName string, params models.CreateStageParams) error {
+					return tt.createErr
+				},
+			}
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "", bytes.NewBufferString(tt.payload))
+			c.Params = gin.Params{
+				gin.Param{Key: "project", Value: "my-project"},
+			}
+
+			handler := NewStageHandler(stageManager, nil)
+			handler.CreateStage(c)
+
+			assert.Equal(t, tt.expectHttpStatus, c.Writer.Status())
+			if !tt.expectCreate {
+				assert.Empty(t, stageManager.CreateStageCalls())
+				return
+			}
+			assert.Len(t, stageManager.CreateStageCalls(), 1)
+			assert.Equal(t, "my-project", stageManager.CreateStageCalls()[0].ProjectName)
+			assert.Equal(t, "hardening", stageManager.CreateStageCalls()[0].Params.StageName)
+		})
+	}
+}
+
+func TestUpdateStage(t *testing.T) {
+	tests := []struct {
+		name             string
+		payload          string
+		updateErr        error
+		expectHttpStatus int
+		expectUpdate     bool
+	}{
+		{
+			name:             "rename stage",
+			payload:          `{"stageName": "development"}`,
+			expectHttpStatus: http.StatusOK,
+			expectUpdate:     true,
+		},
+		{
+			name:             "move stage",
+			payload:          `{"position": 0}`,
+			expectHttpStatus: http.StatusOK,
+			expectUpdate:     true,
+		},
+		{
+			name:             "no changes",
+			payload:          `{}`,
+			expectHttpStatus: http.StatusBadRequest,
+		},
+		{
+			name:             "invalid stage name",
+			payload:          `{"stageName": "dev_1"}`,
+			expectHttpStatus: http.StatusBadRequest,
+		},
+		{
+			name:             "stage not found",
+			payload:          `{"stageName": "development"}`,
+			updateErr:        ErrStageNotFound,
+			expectHttpStatus: http.StatusNotFound,
+			expectUpdate:     true,
+		},
+		{
+			name:             "invalid position",
+			payload:          `{"position": 5}`,
+			updateErr:        ErrInvalidStagePosition,
+			expectHttpStatus: http.StatusBadRequest,
+			expectUpdate:     true,
+		},
+		{
+			name:             "active sequences",
+			payload:          `{"stageName": "development"}`,
+			updateErr:        ErrStageHasActiveSequences,
+			expectHttpStatus: http.StatusConflict,
+			expectUpdate:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stageManager := &fake.IStageManagerMock{
+				UpdateStageFunc: func(projectName string, stageName string, params models.UpdateStageParams) error {
+					return tt.updateErr
+				},
+			}
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPut, "", bytes.NewBufferString(tt.payload))
+			c.Params = gin.Params{
+				gin.Param{Key: "project", Value: "my-project"},
+				gin.Param{Key: "stage", Value: "dev"},
+			}
+
+			handler := NewStageHandler(stageManager, nil)
+			handler.UpdateStage(c)
+
+			assert.Equal(t, tt.expectHttpStatus, c.Writer.Status())
+			if !tt.expectUpdate {
+				assert.Empty(t, stageManager.UpdateStageCalls())
+				return
+			}
+			assert.Len(t, stageManager.UpdateStageCalls(), 1)
+			assert.Equal(t, "my-project", stageManager.UpdateStageCalls()[0].ProjectName)
+			assert.Equal(t, "dev", stageManager.UpdateStageCalls()[0].StageName)
+		})
+	}
+}
+
+func TestDeleteStage(t *testing.T) {
+	tests := []struct {
+		name             string
+		deleteErr        error
+		expectHttpStatus int
+	}{
+		{
+			name:             "delete stage",
+			expectHttpStatus: http.StatusOK,
+		},
+		{
+			name:             "stage not found",
+			deleteErr:        ErrStageNotFound,
+			expectHttpStatus: http.StatusNotFound,
+		},
+		{
+			name:             "stage referenced by other stages",
+			deleteErr:        fmt.Errorf("%w: production.delivery", ErrStageReferenced),
+			expectHttpStatus: http.StatusConflict,
+		},
+		{
+			name:             "last stage",
+			deleteErr:        ErrLastStage,
+			expectHttpStatus: http.StatusBadRequest,
+		},
+		{
+			name:             "internal error",
+			deleteErr:        errors.New("whoops"),
+			expectHttpStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stageManager := &fake.IStageManagerMock{
+				DeleteStageFunc: func(projectName string, stageName string) error {
+					return tt.deleteErr
+				},
+			}
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodDelete, "", nil)
+			c.Params = gin.Params{
+				gin.Param{Key: "project", Value: "my-project"},
+				gin.Param{Key: "stage", Value: "dev"},
+			}
+
+			handler := NewStageHandler(stageManager, nil)
+			handler.DeleteStage(c)
+
+			assert.Equal(t, tt.expectHttpStatus, c.Writer.Status())
+			assert.Len(t, stageManager.DeleteStageCalls(), 1)
+			assert.Equal(t, "dev", stageManager.DeleteStageCalls()[0].StageName)
+		})
+	}
+}
+
+func TestDeleteStage_LocksProject(t *testing.T) {
+	stageManager := &fake.IStageManagerMock{
+		DeleteStageFunc: func(projectName string, stageName string) error {
+			return nil
+		},
+	}
+	locker := &fake.ILockerMock{
+		LockFunc: func(ctx context.Context, key string) (*models.Lock, context.Context, error) {
+			return &models.Lock{Key: key, Token: 1}, context.Background(), nil
+		},
+		UnlockFunc: func(lock models.Lock) error {
+			require.Len(t, stageManager.DeleteStageCalls(), 1)
+			return nil
+		},
+	}
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodDelete, "", nil)
+	c.Params = gin.Params{
+		gin.Param{Key: "project", Value: "my-project"},
+		gin.Param{Key: "stage", Value: "dev"},
+	}
+
+	handler := NewStageHandler(stageManager, locker)
+	handler.DeleteStage(c)
+
+	assert.Equal(t, http.StatusOK, c.Writer.Status())
+	require.Len(t, locker.LockCalls(), 1)
+	assert.Equal(t, "project.my-project", locker.LockCalls()[0].Key)
+	require.Len(t, locker.UnlockCalls(), 1)
+	assert.Equal(t, "project.my-project", locker.UnlockCalls()[0].Lock.Key)
+}
+
+func TestDeleteStage_ProjectLockNotAcquired(t *testing.T) {
+	stageManager := &fake.IStageManagerMock{}
+	locker := &fake.ILockerMock{
+		LockFunc: func(ctx context.Context, key string) (*models.Lock, context.Context, error) {
+			return nil, nil, context.DeadlineExceeded
+		},
+	}
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodDelete, "", nil)
+	c.Params = gin.Params{
+		gin.Param{Key: "project", Value: "my-project"},
+		gin.Param{Key: "stage", Value: "dev"},
+	}
+
+	handler := NewStageHandler(stageManager, locker)
+	handler.DeleteStage(c)
+
+	assert.Equal(t, http.StatusInternalServerError, c.Writer.Status())
+	assert.Empty(t, stageManager.DeleteStageCalls())
+}
+
 func createExpandedStages() []*apimodels.ExpandedStage {
 	s1 := &apimodels.ExpandedStage{
 		StageName: "s1",
@@ -1,23 +1,38 @@
 package handler
 
 import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	log "github.com/sirupsen/logrus"
 )
 
 //go:generate moq -pkg fake -skip-ensure -out ./fake/stagemanager.go . IStageManager
 type IStageManager interface {
 	GetAllStages(projectName string) ([]*apimodels.ExpandedStage, error)
 	GetStage(projectName, stageName string) (*apimodels.ExpandedStage, error)
+	CreateStage(projectName string, params models.CreateStageParams) error
+	UpdateStage(projectName, stageName string, params models.UpdateStageParams) error
+	DeleteStage(projectName, stageName string) error
 }
 
 type StageManager struct {
-	projectMVRepo db.ProjectMVRepo
+	projectMVRepo         db.ProjectMVRepo
+	configurationStore    common.ConfigurationStore
+	sequenceExecutionRepo db.SequenceExecutionRepo
 }
 
-func NewStageManager(projectMVRepo db.ProjectMVRepo) *StageManager {
+func NewStageManager(projectMVRepo db.ProjectMVRepo, configurationStore common.ConfigurationStore, sequenceExecutionRepo db.SequenceExecutionRepo) *StageManager {
 	return &StageManager{
-		projectMVRepo: projectMVRepo,
+		projectMVRepo:         projectMVRepo,
+		configurationStore:    configurationStore,
+		sequenceExecutionRepo: sequenceExecutionRepo,
 	}
 }
 
@@ -50,3 +65,281 @@ func (sm *StageManager) GetStage(projectName, stageName string) (*apimodels.Expa
 	return nil, ErrStageNotFound
 
 }
+
+// CreateStage adds a stage to the shipyard of the project, and creates it in the configuration store and the materialized view.
+// The services of the project are created in the new stage as well
+func (sm *StageManager) CreateStage(projectName string, params models.CreateStageParams) error {
+	project, err := sm.getProject(projectName)
+	if err != nil {
+		return err
+	}
+	if getStage(project, params.StageName) != nil {
+		return ErrStageAlreadyExists
+	}
+
+	position := -1
+	if params.Position != nil {
+		if *params.Position < 0 || *params.Position > len(project.Stages) {
+			return ErrInvalidStagePosition
+		}
+		position = *params.Position
+	}
+
+	shipyardContent, err := sm.getShipyardContent(projectName)
+	if err != nil {
+		return err
+	}
+	updatedShipyard, err := common.AddShipyardStage(shipyardContent, params.StageName, position)
+	if err != nil {
+		return fmt.Errorf("could not add stage %s to shipyard: %w", params.StageName, err)
+	}
+
+	log.Infof("Creating stage %s in project %s", params.StageName, projectName)
+	if err := sm.configurationStore.CreateStage(projectName, params.StageName); err != nil {
+		return fmt.Errorf("could not create stage %s in configuration store: %w", params.StageName, err)
+	}
+	if err := sm.projectMVRepo.CreateStage(projectName, params.StageName); err != nil {
+		return fmt.Errorf("could not create stage %s in materialized view: %w", params.StageName, err)
+	}
+
+	for _, serviceName := range getServiceNames(project) {
+		if err := sm.configurationStore.CreateService(projectName, params.StageName, serviceName); err != nil {
+			return fmt.Errorf("could not create service %s in stage %s: %w", serviceName, params.StageName, err)
+		}
+		if err := sm.projectMVRepo.CreateService(projectName, params.StageName, serviceName); err != nil {
+			return fmt.Errorf("could not create service %s in stage %s in materialized view: %w", serviceName, params.StageName, err)
+		}
+	}
+
+	return sm.updateShipyard(projectName, updatedShipyard, nil)
+}
+
+// UpdateStage renames a stage and/or moves it to another position within the stages of the shipyard.
+// A stage can only be renamed if no sequences are active in the stage
+func (sm *StageManager) UpdateStage(projectName, stageName string, params models.UpdateStageParams) error {
+	project, err := sm.getProject(projectName)
+	if err != nil {
+		return err
+	}
+	if getStage(project, stageName) == nil {
+		return ErrStageNotFound
+	}
+	if params.Position != nil && (*params.Position < 0 || *params.Position >= len(project.Stages)) {
+		return ErrInvalidStagePosition
+	}
+
+	rename := params.StageName != "" && params.StageName != stageName
+	if rename {
+		if getStage(project, params.StageName) != nil {
+			return ErrStageAlreadyExists
+		}
+		if err := sm.checkNoActiveSequences(projectName, stageName); err != nil {
+			return err
+		}
+	}
+
+	updatedShipyard, err := sm.getShipyardContent(projectName)
+	if err != nil {
+		return err
+	}
+	if params.Position != nil {
+		if updatedShipyard, err = common.MoveShipyardStage(updatedShipyard, stageName, *params.Position); err != nil {
+			return fmt.Errorf("could not move stage %s within shipyard: %w", stageName, err)
+		}
+	}
+	if !rename {
+		return sm.updateShipyard(projectName, updatedShipyard, nil)
+	}
+
+	if updatedShipyard, err = common.RenameShipyardStage(updatedShipyard, stageName, params.StageName); err != nil {
+		return fmt.Errorf("could not rename stage %s within shipyard: %w", stageName, err)
+	}
+
+	log.Infof("Renaming stage %s of project %s to %s", stageName, projectName, params.StageName)
+	if err := sm.copyStage(project, stageName, params.StageName); err != nil {
+		if err := sm.configurationStore.DeleteStage(projectName, params.StageName); err != nil {
+			log.Errorf("Rollback failed: could not delete stage %s of project %s: %s", params.StageName, projectName, err.Error())
+		}
+		return err
+	}
+	if err := sm.configurationStore.DeleteStage(projectName, stageName); err != nil {
+		return fmt.Errorf("could not delete stage %s in configuration store: %w", stageName, err)
+	}
+
+	return sm.updateShipyard(projectName, updatedShipyard, map[string]string{stageName: params.StageName})
+}
+
+// DeleteStage removes a stage from the shipyard of the project, as well as from the configuration store and the materialized view.
+// A stage can only be deleted if no sequences are active in the stage, and no sequences of other stages are triggered by it
+func (sm *StageManager) DeleteStage(projectName, stageName string) error {
+	project, err := sm.getProject(projectName)
+	if err != nil {
+		return err
+	}
+	if getStage(project, stageName) == nil {
+		return ErrStageNotFound
+	}
+	if len(project.Stages) == 1 {
+		return ErrLastStage
+	}
+
+	shipyardContent, err := sm.getShipyardContent(projectName)
+	if err != nil {
+		return err
+	}
+	shipyard, err := common.UnmarshalShipyard(shipyardContent)
+	if err != nil {
+		return fmt.Errorf("could not decode shipyard of project %s: %w", projectName, err)
+	}
+	if references := common.GetShipyardStageReferences(shipyard, stageName); len(references) > 0 {
+		return fmt.Errorf("%w: %s", ErrStageReferenced, strings.Join(references, ", "))
+	}
+	if err := sm.checkNoActiveSequences(projectName, stageName); err != nil {
+		return err
+	}
+
+	updatedShipyard, err := common.RemoveShipyardStage(shipyardContent, stageName)
+	if err != nil {
+		return fmt.Errorf("could not remove stage %s from shipyard: %w", stageName, err)
+	}
+
+	log.Infof("Deleting stage %s of project %s", stageName, projectName)
+	if err := sm.configurationStore.DeleteStage(projectName, stageName); err != nil {
+		return fmt.Errorf("could not delete stage %s in configuration store: %w", stageName, err)
+	}
+	if err := sm.projectMVRepo.DeleteStage(projectName, stageName); err != nil {
+		return fmt.Errorf("could not delete stage %s in materialized view: %w", stageName, err)
+	}
+
+	return sm.updateShipyard(projectName, updatedShipyard, nil)
+}
+
+func (sm *StageManager) getProject(projectName string) (*apimodels.ExpandedProject, error) {
+	project, err := sm.projectMVRepo.GetProject(projectName)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+	return project, nil
+}
+
+func (sm *StageManager) getShipyardContent(projectName string) (string, error) {
+	shipyard, err := sm.configurationStore.GetProjectResource(projectName, shipyardResourceURI)
+	if err != nil {
+		return "", fmt.Errorf("could not load shipyard of project %s: %w", projectName, err)
+	}
+	return shipyard.ResourceContent, nil
+}
+
+func (sm *StageManager) checkNoActiveSequences(projectName, stageName string) error {
+	sequenceExecutions, err := sm.sequenceExecutionRepo.Get(models.SequenceExecutionFilter{
+		Scope:  models.EventScope{EventData: keptnv2.EventData{Project: projectName, Stage: stageName}},
+		Status: activeSequenceStates,
+	})
+	if err != nil {
+		return fmt.Errorf("could not load sequence executions of stage %s: %w", stageName, err)
+	}
+	if len(sequenceExecutions) > 0 {
+		return ErrStageHasActiveSequences
+	}
+	return nil
+}
+
+// copyStage creates a new stage containing the services and resources of an existing stage in the configuration store
+func (sm *StageManager) copyStage(project *apimodels.ExpandedProject, stageName, newStageName string) error {
+	projectName := project.ProjectName
+	if err := sm.configurationStore.CreateStage(projectName, newStageName); err != nil {
+		return fmt.Errorf("could not create stage %s in configuration store: %w", newStageName, err)
+	}
+
+	serviceNames := []string{}
+	for _, service := range getStage(project, stageName).Services {
+		serviceNames = append(serviceNames, service.ServiceName)
+		if err := sm.configurationStore.CreateService(projectName, newStageName, service.ServiceName); err != nil {
+			return fmt.Errorf("could not create service %s in stage %s: %w", service.ServiceName, newStageName, err)
+		}
+		resources, err := sm.configurationStore.GetServiceResources(projectName, stageName, service.ServiceName)
+		if err != nil {
+			return fmt.Errorf("could not load resources of service %s in stage %s: %w", service.ServiceName, stageName, err)
+		}
+		if len(resources) == 0 {
+			continue
+		}
+		if err := sm.configurationStore.CreateServiceResources(projectName, newStageName, service.ServiceName, toAPIResources(toProjectArchiveResources(resources, nil))); err != nil {
+			return fmt.Errorf("could not copy resources of service %s to stage %s: %w", service.ServiceName, newStageName, err)
+		}
+	}
+
+	resources, err := sm.configurationStore.GetStageResources(projectName, stageName)
+	if err != nil {
+		return fmt.Errorf("could not load resources of stage %s: %w", stageName, err)
+	}
+	// the resources of the services are part of the stage resources, but they have already been copied above
+	stageResources := toAPIResources(toProjectArchiveResources(resources, serviceNames))
+	if len(stageResources) == 0 {
+		return nil
+	}
+	if err := sm.configurationStore.CreateStageResources(projectName, newStageName, stageResources); err != nil {
+		return fmt.Errorf("could not copy resources to stage %s: %w", newStageName, err)
+	}
+	return nil
+}
+
+// updateShipyard stores the updated shipyard in the configuration store and the materialized view.
+// The stages of the materialized view are renamed according to the given mapping, and sorted in the order of the shipyard
+func (sm *StageManager) updateShipyard(projectName, shipyardContent string, renamedStages map[string]string) error {
+	shipyard, err := common.UnmarshalShipyard(shipyardContent)
+	if err != nil {
+		return fmt.Errorf("could not decode updated shipyard of project %s: %w", projectName, err)
+	}
+
+	if err := sm.configurationStore.UpdateProjectResource(projectName, &apimodels.Resource{
+		ResourceURI:     common.Stringp(shipyardResourceURI),
+		ResourceContent: base64.StdEncoding.EncodeToString([]byte(shipyardContent)),
+	}); err != nil {
+		return fmt.Errorf("could not store updated shipyard of project %s: %w", projectName, err)
+	}
+
+	project, err := sm.getProject(projectName)
+	if err != nil {
+		return err
+	}
+	for _, stage := range project.Stages {
+		if newName, ok := renamedStages[stage.StageName]; ok {
+			stage.StageName = newName
+		}
+	}
+	stages := []*apimodels.ExpandedStage{}
+	for _, shipyardStage := range shipyard.Spec.Stages {
+		if stage := getStage(project, shipyardStage.Name); stage != nil {
+			stages = append(stages, stage)
+		}
+	}
+	project.Stages = stages
+
+	if err := sm.projectMVRepo.UpdateProject(project); err != nil {
+		return fmt.Errorf("could not update stages of project %s in materialized view: %w", projectName, err)
+	}
+	return sm.projectMVRepo.UpdateShipyard(projectName, shipyardContent)
+}
+
+func getStage(project *apimodels.ExpandedProject, stageName string) *apimodels.ExpandedStage {
+	for _, stage := range project.Stages {
+		if stage.StageName == stageName {
+			return stage
+		}
+	}
+	return nil
+}
+
+func getServiceNames(project *apimodels.ExpandedProject) []string {
+	services := map[string]bool{}
+	for _, stage := range project.Stages {
+		for _, service := range stage.Services {
+			services[service.ServiceName] = true
+		}
+	}
+	return sortedKeys(services)
+}
@@ -1,17 +1,23 @@
 package handler
 
 import (
+	"encoding/base64"
 	"errors"
+	"testing"
+
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/shipyard-controller/common"
+	common_mock "github.com/keptn/keptn/shipyard-controller/common/fake"
 	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
+	"github.com/keptn/keptn/shipyard-controller/models"
 	"github.com/stretchr/testify/assert"
-	"testing"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetAllStages_GettingProjectFromDBFails(t *testing.T) {
 
 	projectMVRepo := &db_mock.ProjectMVRepoMock{}
-	instance := NewStageManager(projectMVRepo)
+	instance := NewStageManager(projectMVRepo, &common_mock.ConfigurationStoreMock{}, &db_mock.SequenceExecutionRepoMock{})
 
 	projectMVRepo.GetProjectFunc = func(projectName string) (*apimodels.ExpandedProject, error) {
 		return nil, errors.New("whoops")
@@ -24,7 +30,7 @@ func TestGetAllStages_GettingProjectFromDBFails(t *testing.T) {
 
 func TestGetAllStages_ProjectNotFound(t *testing.T) {
 	projectMVRepo := &db_mock.ProjectMVRepoMock{}
-	instance := NewStageManager(projectMVRepo)
+	instance := NewStageManager(projectMVRepo, &common_mock.ConfigurationStoreMock{}, &db_mock.SequenceExecutionRepoMock{})
 
 	projectMVRepo.GetProjectFunc = func(projectName string) (*apimodels.ExpandedProject, error) {
 		return nil, nil
@@ -38,7 +44,7 @@ func TestGetAllStages_ProjectNotFound(t *testing.T) {
 
 func TestGetAllStages(t *testing.T) {
 	projectMVRepo := &db_mock.ProjectMVRepoMock{}
-	instance := NewStageManager(projectMVRepo)
+	instance := NewStageManager(projectMVRepo, &common_mock.ConfigurationStoreMock{}, &db_mock.SequenceExecutionRepoMock{})
 
 	projectMVRepo.GetProjectFunc = func(projectName string) (*apimodels.ExpandedProject, error) {
 
@@ -63,7 +69,7 @@ func TestGetAllStages(t *testing.T) {
 
 func TestGetStage_GettingProjectFromDBFails(t *testing.T) {
 	projectMVRepo := &db_mock.ProjectMVRepoMock{}
-	instance := NewStageManager(projectMVRepo)
+	instance := NewStageManager(projectMVRepo, &common_mock.ConfigurationStoreMock{}, &db_mock.SequenceExecutionRepoMock{})
 
 	projectMVRepo.GetProjectFunc = func(projectName string) (*apimodels.ExpandedProject, error) {
 		return nil, errors.New("whoops")
@@ -76,7 +82,7 @@ func TestGetStage_GettingProjectFromDBFails(t *testing.T) {
 
 func TestGetStage_ProjectNotFound(t *testing.T) {
 	projectMVRepo := &db_mock.ProjectMVRepoMock{}
-	instance := NewStageManager(projectMVRepo)
+	instance := NewStageManager(projectMVRepo, &common_mock.ConfigurationStoreMock{}, &db_mock.SequenceExecutionRepoMock{})
 
 	projectMVRepo.GetProjectFunc = func(projectName string) (*apimodels.ExpandedProject, error) {
 		return nil, nil
@@ -90,7 +96,7 @@ func TestGetStage_ProjectNotFound(t *testing.T) {
 
 func TestGetStage_StageNotFound(t *testing.T) {
 	projectMVRepo := &db_mock.ProjectMVRepoMock{}
-	instance := NewStageManager(projectMVRepo)
+	instance := NewStageManager(projectMVRepo, &common_mock.ConfigurationStoreMock{}, &db_mock.SequenceExecutionRepoMock{})
 
 	projectMVRepo.GetProjectFunc = func(projectName string) (*apimodels.ExpandedProject, error) {
 
@@ -110,3 +116,303 @@ func TestGetStage_StageNotFound(t *testing.T) {
 	assert.Nil(t, stage)
 	assert.Equal(t, ErrStageNotFound, err)
 }
+
+const stageManagerTestShipyard = `apiVersion: "spec.keptn.sh/0.2.3"
+kind: "Shipyard"
+metadata:
+  name: "shipyard-sockshop"
+spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "deployment"
+    - name: "production"
+      sequences:
+        - name: "delivery"
+          triggeredOn:
+            - event: "dev.delivery.finished"
+          tasks:
+            - name: "deployment"
+`
+
+type stageManagerTestFields struct {
+	projectMVRepo         *db_mock.ProjectMVRepoMock
+	configurationStore    *common_mock.ConfigurationStoreMock
+	sequenceExecutionRepo *db_mock.SequenceExecutionRepoMock
+}
+
+func newStageManagerTestFields() stageManagerTestFields {
+	return stageManagerTestFields{
+		projectMVRepo: &db_mock.ProjectMVRepoMock{
+			GetProjectFunc: func(projectName string) (*apimodels.ExpandedProject, error) {
+				if projectName != "my-project" {
+					return nil, nil
+				}
+				return &apimodels.ExpandedProject{
+					ProjectName: "my-project",
+					Stages: []*apimodels.ExpandedStage{
+						{StageName: "dev", Services: []*apimodels.ExpandedService{{ServiceName: "carts"}}},
+						{StageName: "production", Services: []*apimodels.ExpandedService{{ServiceName: "carts"}}},
+					},
+				}, nil
+			},
+			CreateStageFunc:    func(project string, stage string) error { return nil },
+			DeleteStageFunc:    func(project string, stage string) error { return nil },
+			CreateServiceFunc:  func(project string, stage string, service string) error { return nil },
+			UpdateProjectFunc:  func(prj *apimodels.ExpandedProject) error { return nil },
+			UpdateShipyardFunc: func(projectName string, shipyardContent string) error { return nil },
+		},
+		configurationStore: &common_mock.ConfigurationStoreMock{
+			GetProjectResourceFunc: func(projectName string, resourceURI string) (*apimodels.Resource, error) {
+				return newTestResource(resourceURI, stageManagerTestShipyard), nil
+			},
+			UpdateProjectResourceFunc: func(projectName string, resource *apimodels.Resource) error { return nil },
+			CreateStageFunc:           func(projectName string, stage string) error { return nil },
+			DeleteStageFunc:           func(projectName string, stage string) error { return nil },
+			CreateServiceFunc:         func(projectName string, stageName string, serviceName string) error { return nil },
+			GetStageResourcesFunc: func(projectName string, stageName string) ([]*apimodels.Resource, error) {
+				return []*apimodels.Resource{newTestResource("/slo.yaml", "slo"), newTestResource("/carts/helm/carts.tgz", "chart")}, nil
+			},
+			GetServiceResourcesFunc: func(projectName string, stageName string, serviceName string) ([]*apimodels.Resource, error) {
+				return []*apimodels.Resource{newTestResource("/helm/carts.tgz", "chart")}, nil
+			},
+			CreateStageResourcesFunc: func(projectName string, stageName string, resources []*apimodels.Resource) error {
+				return nil
+			},
+			CreateServiceResourcesFunc: func(projectName string, stageName string, serviceName string, resources []*apimodels.Resource) error {
+				return nil
+			},
+		},
+		sequenceExecutionRepo: &db_mock.SequenceExecutionRepoMock{
+			GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+				return []models.SequenceExecution{}, nil
+			},
+		},
+	}
+}
+
+func (f stageManagerTestFields) newStageManager() *StageManager {
+	return NewStageManager(f.projectMVRepo, f.configurationStore, f.sequenceExecutionRepo)
+}
+
+func (f stageManagerTestFields) getUpdatedShipyardStages(t *testing.T) []string {
+	require.Len(t, f.configurationStore.UpdateProjectResourceCalls(), 1)
+	resource := f.configurationStore.UpdateProjectResourceCalls()[0].Resource
+	require.Equal(t, "shipyard.yaml", *resource.ResourceURI)
+	content, err := base64.StdEncoding.DecodeString(resource.ResourceContent)
+	require.Nil(t, err)
+
+	require.Len(t, f.projectMVRepo.UpdateShipyardCalls(), 1)
+	require.Equal(t, string(content), f.projectMVRepo.UpdateShipyardCalls()[0].ShipyardContent)
+
+	shipyard, err := common.UnmarshalShipyard(string(content))
+	require.Nil(t, err)
+	stages := []string{}
+	for _, stage := range shipyard.Spec.Stages {
+		stages = append(stages, stage.Name)
+	}
+	return stages
+}
+
+func getUpdatedProjectStages(f stageManagerTestFields) []string {
+	stages := []string{}
+	for _, stage := range f.projectMVRepo.UpdateProjectCalls()[0].Prj.Stages {
+		stages = append(stages, stage.StageName)
+	}
+	return stages
+}
+
+func intp(i int) *int {
+	return &i
+}
+
+func TestStageManager_CreateStage(t *testing.T) {
+	fields := newStageManagerTestFields()
+
+	err := fields.newStageManager().CreateStage("my-project", models.CreateStageParams{StageName: "hardening", Position: intp(1)})
+	require.Nil(t, err)
+
+	require.Len(t, fields.configurationStore.CreateStageCalls(), 1)
+	require.Equal(t, "hardening", fields.configurationStore.CreateStageCalls()[0].Stage)
+	require.Len(t, fields.projectMVRepo.CreateStageCalls(), 1)
+	require.Equal(t, "hardening", fields.projectMVRepo.CreateStageCalls()[0].Stage)
+
+	// the services of the project are created in the new stage
+	require.Len(t, fields.configurationStore.CreateServiceCalls(), 1)
+	require.Equal(t, "hardening", fields.configurationStore.CreateServiceCalls()[0].StageName)
+	require.Equal(t, "carts", fields.configurationStore.CreateServiceCalls()[0].ServiceName)
+	require.Len(t, fields.projectMVRepo.CreateServiceCalls(), 1)
+
+	require.Equal(t, []string{"dev", "hardening", "production"}, fields.getUpdatedShipyardStages(t))
+}
+
+func TestStageManager_CreateStage_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		projectName string
+		params      models.CreateStageParams
+		wantErr     error
+	}{
+		{name: "project not found", projectName: "unknown-project", params: models.CreateStageParams{StageName: "hardening"}, wantErr: ErrProjectNotFound},
+		{name: "stage already exists", projectName: "my-project", params: models.CreateStageParams{StageName: "dev"}, wantErr: ErrStageAlreadyExists},
+		{name: "invalid position", projectName: "my-project", params: models.CreateStageParams{StageName: "hardening", Position: intp(3)}, wantErr: ErrInvalidStagePosition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := newStageManagerTestFields()
+
+			err := fields.newStageManager().CreateStage(tt.projectName, tt.params)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Empty(t, fields.configurationStore.CreateStageCalls())
+			require.Empty(t, fields.configurationStore.UpdateProjectResourceCalls())
+		})
+	}
+}
+
+func TestStageManager_UpdateStage_Rename(t *testing.T) {
+	fields := newStageManagerTestFields()
+
+	err := fields.newStageManager().UpdateStage("my-project", "dev", models.UpdateStageParams{StageName: "development"})
+	require.Nil(t, err)
+
+	// the services and resources are copied to the new stage
+	require.Len(t, fields.configurationStore.CreateStageCalls(), 1)
+	require.Equal(t, "development", fields.configurationStore.CreateStageCalls()[0].Stage)
+	require.Len(t, fields.configurationStore.CreateServiceCalls(), 1)
+	require.Equal(t, "development", fields.configurationStore.CreateServiceCalls()[0].StageName)
+	require.Len(t, fields.configurationStore.CreateServiceResourcesCalls(), 1)
+	require.Equal(t, "helm/carts.tgz", *fields.configurationStore.CreateServiceResourcesCalls()[0].Resources[0].ResourceURI)
+	require.Len(t, fields.configurationStore.CreateStageResourcesCalls(), 1)
+	require.Len(t, fields.configurationStore.CreateStageResourcesCalls()[0].Resources, 1)
+	require.Equal(t, "slo.yaml", *fields.configurationStore.CreateStageResourcesCalls()[0].Resources[0].ResourceURI)
+
+	// the previous stage is removed
+	require.Len(t, fields.configurationStore.DeleteStageCalls(), 1)
+	require.Equal(t, "dev", fields.configurationStore.DeleteStageCalls()[0].Stage)
+
+	require.Equal(t, []string{"development", "production"}, fields.getUpdatedShipyardStages(t))
+	require.Contains(t, fields.projectMVRepo.UpdateShipyardCalls()[0].ShipyardContent, "development.delivery.finished")
+
+	// the data of the stage is migrated in the materialized view
+	require.Equal(t, []string{"development", "production"}, getUpdatedProjectStages(fields))
+	require.Equal(t, "carts", fields.projectMVRepo.UpdateProjectCalls()[0].Prj.Stages[0].Services[0].ServiceName)
+	require.Empty(t, fields.projectMVRepo.DeleteStageCalls())
+}
+
+func TestStageManager_UpdateStage_Move(t *testing.T) {
+	fields := newStageManagerTestFields()
+
+	err := fields.newStageManager().UpdateStage("my-project", "production", models.UpdateStageParams{Position: intp(0)})
+	require.Nil(t, err)
+
+	require.Empty(t, fields.configurationStore.CreateStageCalls())
+	require.Empty(t, fields.configurationStore.DeleteStageCalls())
+	require.Empty(t, fields.sequenceExecutionRepo.GetCalls())
+
+	require.Equal(t, []string{"production", "dev"}, fields.getUpdatedShipyardStages(t))
+	require.Equal(t, []string{"production", "dev"}, getUpdatedProjectStages(fields))
+}
+
+func TestStageManager_UpdateStage_ActiveSequences(t *testing.T) {
+	fields := newStageManagerTestFields()
+	fields.sequenceExecutionRepo.GetFunc = func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+		return []models.SequenceExecution{{ID: "my-sequence"}}, nil
+	}
+
+	err := fields.newStageManager().UpdateStage("my-project", "dev", models.UpdateStageParams{StageName: "development"})
+	require.ErrorIs(t, err, ErrStageHasActiveSequences)
+
+	filter := fields.sequenceExecutionRepo.GetCalls()[0].Filter
+	require.Equal(t, "my-project", filter.Scope.Project)
+	require.Equal(t, "dev", filter.Scope.Stage)
+	require.Contains(t, filter.Status, apimodels.SequenceStartedState)
+	require.Empty(t, fields.configurationStore.CreateStageCalls())
+}
+
+func TestStageManager_UpdateStage_CopyFails(t *testing.T) {
+	fields := newStageManagerTestFields()
+	fields.configurationStore.CreateServiceResourcesFunc = func(projectName string, stageName string, serviceName string, resources []*apimodels.Resource) error {
+		return errors.New("oops")
+	}
+
+	err := fields.newStageManager().UpdateStage("my-project", "dev", models.UpdateStageParams{StageName: "development"})
+	require.NotNil(t, err)
+
+	// the partially created stage is removed again
+	require.Len(t, fields.configurationStore.DeleteStageCalls(), 1)
+	require.Equal(t, "development", fields.configurationStore.DeleteStageCalls()[0].Stage)
+	require.Empty(t, fields.configurationStore.UpdateProjectResourceCalls())
+}
+
+func TestStageManager_UpdateStage_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		stageName string
+		params    models.UpdateStageParams
+		wantErr   error
+	}{
+		{name: "stage not found", stageName: "staging", params: models.UpdateStageParams{StageName: "hardening"}, wantErr: ErrStageNotFound},
+		{name: "stage already exists", stageName: "dev", params: models.UpdateStageParams{StageName: "production"}, wantErr: ErrStageAlreadyExists},
+		{name: "invalid position", stageName: "dev", params: models.UpdateStageParams{Position: intp(2)}, wantErr: ErrInvalidStagePosition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := newStageManagerTestFields()
+
+			err := fields.newStageManager().UpdateStage("my-project", tt.stageName, tt.params)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Empty(t, fields.configurationStore.UpdateProjectResourceCalls())
+		})
+	}
+}
+
+func TestStageManager_DeleteStage(t *testing.T) {
+	fields := newStageManagerTestFields()
+
+	err := fields.newStageManager().DeleteStage("my-project", "production")
+	require.Nil(t, err)
+
+	require.Len(t, fields.configurationStore.DeleteStageCalls(), 1)
+	require.Equal(t, "production", fields.configurationStore.DeleteStageCalls()[0].Stage)
+	require.Len(t, fields.projectMVRepo.DeleteStageCalls(), 1)
+	require.Equal(t, "production", fields.projectMVRepo.DeleteStageCalls()[0].Stage)
+
+	require.Equal(t, []string{"dev"}, fields.getUpdatedShipyardStages(t))
+}
+
+func TestStageManager_DeleteStage_Invalid(t *testing.T) {
+	tests := []struct {
+		name            string
+		stageName       string
+		activeSequences bool
+		singleStage     bool
+		wantErr         error
+	}{
+		{name: "stage not found", stageName: "staging", wantErr: ErrStageNotFound},
+		{name: "stage triggers other stages", stageName: "dev", wantErr: ErrStageReferenced},
+		{name: "active sequences", stageName: "production", activeSequences: true, wantErr: ErrStageHasActiveSequences},
+		{name: "last stage", stageName: "dev", singleStage: true, wantErr: ErrLastStage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := newStageManagerTestFields()
+			if tt.activeSequences {
+				fields.sequenceExecutionRepo.GetFunc = func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+					return []models.SequenceExecution{{ID: "my-sequence"}}, nil
+				}
+			}
+			if tt.singleStage {
+				fields.projectMVRepo.GetProjectFunc = func(projectName string) (*apimodels.ExpandedProject, error) {
+					return &apimodels.ExpandedProject{ProjectName: projectName, Stages: []*apimodels.ExpandedStage{{StageName: "dev"}}}, nil
+				}
+			}
+
+			err := fields.newStageManager().DeleteStage("my-project", tt.stageName)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Empty(t, fields.configurationStore.DeleteStageCalls())
+			require.Empty(t, fields.projectMVRepo.DeleteStageCalls())
+		})
+	}
+}
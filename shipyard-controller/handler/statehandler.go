@@ -4,48 +4,57 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	"github.com/keptn/keptn/shipyard-controller/db"
-	_ "github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	log "github.com/sirupsen/logrus"
 )
 
 type IStateHandler interface {
 	GetSequenceState(context *gin.Context)
 	ControlSequenceState(context *gin.Context)
+	GetTaskAttempts(context *gin.Context)
 }
 
 type StateHandler struct {
-	StateRepo          db.SequenceStateRepo
-	shipyardController IShipyardController
+	StateRepo             db.SequenceStateRepo
+	SequenceExecutionRepo db.SequenceExecutionRepo
+	shipyardController    IShipyardController
+	shipyardRetriever     IShipyardRetriever
 }
 
-func NewStateHandler(stateRepo db.SequenceStateRepo, shipyardController IShipyardController) *StateHandler {
+func NewStateHandler(stateRepo db.SequenceStateRepo, sequenceExecutionRepo db.SequenceExecutionRepo, shipyardController IShipyardController, shipyardRetriever IShipyardRetriever) *StateHandler {
 	return &StateHandler{
-		StateRepo:          stateRepo,
-		shipyardController: shipyardController,
+		StateRepo:             stateRepo,
+		SequenceExecutionRepo: sequenceExecutionRepo,
+		shipyardController:    shipyardController,
+		shipyardRetriever:     shipyardRetriever,
 	}
 }
 
 // GetSequenceState godoc
 // @Summary      Get task sequence execution states
-// @Description  Get task sequence execution states
+// @Description  Get task sequence execution states, as well as the freeze windows of the stages of the project
 // @Tags         Sequence
 // @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
-// @Param        project       path      string                    false  "The project name"
-// @Param        name          query     string                    false  "The name of the sequence"
-// @Param        state         query     string                    false  "The state of the sequence (e.g., triggered, finished,...)"
-// @Param        fromTime      query     string                    false  "The from time stamp for fetching sequence states (in ISO8601 time format, e.g.: 2021-05-10T09:51:00.000Z)"
-// @Param        beforeTime    query     string                    false  "The before time stamp for fetching sequence states (in ISO8601 time format, e.g.: 2021-05-10T09:51:00.000Z)"
-// @Param        pageSize      query     int                       false  "The number of items to return"
-// @Param        nextPageKey   query     string                    false  "Pointer to the next set of items"
-// @Param        keptnContext  query     string                    false  "Comma separated list of keptnContext IDs"
-// @Success      200           {object}  apimodels.SequenceStates  "ok"
-// @Failure      400           {object}  models.Error              "Invalid payload"
-// @Failure      500           {object}  models.Error              "Internal error"
+// @Param        project       path      string                           false  "The project name"
+// @Param        name          query     string                           false  "The name of the sequence"
+// @Param        state         query     string                           false  "The state of the sequence (e.g., triggered, finished,...)"
+// @Param        fromTime      query     string                           false  "The from time stamp for fetching sequence states (in ISO8601 time format, e.g.: 2021-05-10T09:51:00.000Z)"
+// @Param        beforeTime    query     string                           false  "The before time stamp for fetching sequence states (in ISO8601 time format, e.g.: 2021-05-10T09:51:00.000Z)"
+// @Param        pageSize      query     int                              false  "The number of items to return"
+// @Param        nextPageKey   query     string                           false  "Pointer to the next set of items"
+// @Param        keptnContext  query     string                           false  "Comma separated list of keptnContext IDs"
+// @Success      200           {object}  models.GetSequenceStateResponse  "ok"
+// @Failure      400           {object}  models.Error                     "Invalid payload"
+// @Failure      500           {object}  models.Error                     "Internal error"
 // @Router       /sequence/{project} [get]
 func (sh *StateHandler) GetSequenceState(c *gin.Context) {
 	projectName := c.Param("project")
@@ -64,19 +73,57 @@ func (sh *StateHandler) GetSequenceState(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, states)
+	response := models.GetSequenceStateResponse{
+		FreezeWindows: sh.getFreezeWindows(projectName),
+	}
+	if states != nil {
+		response.SequenceStates = *states
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// getFreezeWindows returns the freeze windows of all stages of the project, ordered by the names of the stages
+func (sh *StateHandler) getFreezeWindows(projectName string) []models.StageFreezeWindow {
+	if sh.shipyardRetriever == nil {
+		return nil
+	}
+	freezeWindows, err := sh.shipyardRetriever.GetCachedFreezeWindows(projectName)
+	if err != nil {
+		// the sequence states can be returned without the freeze windows
+		log.WithError(err).Errorf("Could not retrieve freeze windows of project %s", projectName)
+		return nil
+	}
+
+	stageNames := []string{}
+	for stageName := range freezeWindows {
+		stageNames = append(stageNames, stageName)
+	}
+	sort.Strings(stageNames)
+
+	now := time.Now()
+	result := []models.StageFreezeWindow{}
+	for _, stageName := range stageNames {
+		for _, freezeWindow := range freezeWindows[stageName] {
+			result = append(result, models.StageFreezeWindow{
+				Stage:        stageName,
+				FreezeWindow: freezeWindow,
+				Active:       freezeWindow.IsActive(now),
+			})
+		}
+	}
+	return result
 }
 
 // ControlSequenceState godoc
-// @Summary      Pause/Resume/Abort a task sequence
-// @Description  Pause/Resume/Abort a task sequence, either for a specific stage, or for all stages involved in the sequence
+// @Summary      Pause/Resume/Abort/Retry a task sequence
+// @Description  Pause/Resume/Abort/Retry a task sequence, either for a specific stage, or for all stages involved in the sequence. The state 'overrideFreeze' allows a sequence that is waiting for a freeze window to end to be started immediately. The state 'retry' triggers a failed sequence again under the same keptnContext, starting at the task given in 'fromTask', or at its first failed task
 // @Tags         Sequence
 // @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param        project          path      string                             true  "The project name"
 // @Param        keptnContext     path      string                             true  "The keptnContext ID of the sequence"
-// @Param        sequenceControl  body      apimodels.SequenceControlCommand   true  "Sequence Control Command"
+// @Param        sequenceControl  body      models.SequenceControlCommand      true  "Sequence Control Command"
 // @Success      200              {object}  apimodels.SequenceControlResponse  "ok"
 // @Failure      400              {object}  models.Error                       "Invalid payload"
 // @Failure      404              {object}  models.Error                       "Not found"
@@ -86,21 +133,33 @@ func (sh *StateHandler) ControlSequenceState(c *gin.Context) {
 	keptnContext := c.Param("keptnContext")
 	project := c.Param("project")
 
-	params := &apimodels.SequenceControlCommand{}
+	params := &models.SequenceControlCommand{}
 	if err := c.ShouldBindJSON(params); err != nil {
 		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidRequestFormatMsg, err.Error()))
 		return
 	}
 
-	err := sh.shipyardController.ControlSequence(apimodels.SequenceControl{
+	controlSequence := apimodels.SequenceControl{
 		State:        params.State,
 		KeptnContext: keptnContext,
 		Stage:        params.Stage,
 		Project:      project,
-	})
+	}
+
+	var err error
+	if params.State == models.RetrySequence {
+		err = sh.shipyardController.RetrySequence(controlSequence, params.FromTask)
+	} else {
+		err = sh.shipyardController.ControlSequence(controlSequence)
+	}
 	if err != nil {
 		if errors.Is(err, ErrSequenceNotFound) {
 			SetNotFoundErrorResponse(c, fmt.Sprintf(UnableFindSequenceMsg, err.Error()))
+			return
+		}
+		if errors.Is(err, ErrSequenceNotRetryable) {
+			SetBadRequestErrorResponse(c, fmt.Sprintf(UnableControleSequenceMsg, err.Error()))
+			return
 		}
 		SetInternalServerErrorResponse(c, fmt.Sprintf(UnableControleSequenceMsg, err.Error()))
 		return
@@ -108,3 +167,43 @@ func (sh *StateHandler) ControlSequenceState(c *gin.Context) {
 
 	c.JSON(http.StatusOK, apimodels.SequenceControlResponse{})
 }
+
+// GetTaskAttempts godoc
+// @Summary      Get the attempt history of the tasks of a sequence
+// @Description  Get the outcome of the tasks of a sequence, including the previous attempts of tasks that have been retried according to their retry policy
+// @Tags         Sequence
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project       path      string                          true   "The project name"
+// @Param        keptnContext  path      string                          true   "The keptnContext ID of the sequence"
+// @Param        stage         query     string                          false  "The stage of the sequence"
+// @Success      200           {object}  models.GetTaskAttemptsResponse  "ok"
+// @Failure      404           {object}  models.Error                    "Not found"
+// @Failure      500           {object}  models.Error                    "Internal error"
+// @Router       /sequence/{project}/{keptnContext}/attempts [get]
+func (sh *StateHandler) GetTaskAttempts(c *gin.Context) {
+	sequenceExecutions, err := sh.SequenceExecutionRepo.Get(models.SequenceExecutionFilter{
+		Scope: models.EventScope{
+			EventData: keptnv2.EventData{
+				Project: c.Param("project"),
+				Stage:   c.Query("stage"),
+			},
+			KeptnContext: c.Param("keptnContext"),
+		},
+	})
+	if err != nil {
+		SetInternalServerErrorResponse(c, fmt.Sprintf(UnableQueryTaskAttemptsMsg, err.Error()))
+		return
+	}
+	if len(sequenceExecutions) == 0 {
+		SetNotFoundErrorResponse(c, fmt.Sprintf(UnableQueryTaskAttemptsMsg, ErrSequenceNotFound.Error()))
+		return
+	}
+
+	response := models.GetTaskAttemptsResponse{Tasks: []models.TaskAttemptHistory{}}
+	for index := range sequenceExecutions {
+		response.Tasks = append(response.Tasks, sequenceExecutions[index].GetTaskAttemptHistory()...)
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -1,15 +1,22 @@
 package handler_test
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/keptn/go-utils/pkg/api/models"
 	"github.com/keptn/go-utils/pkg/common/timeutils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/common"
 	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
 	"github.com/keptn/keptn/shipyard-controller/handler"
+	"github.com/keptn/keptn/shipyard-controller/handler/fake"
+	scmodels "github.com/keptn/keptn/shipyard-controller/models"
 	"github.com/stretchr/testify/require"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -71,7 +78,7 @@ func TestStateHandler_GetState(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			sh := handler.NewStateHandler(tt.fields.StateRepo, nil)
+			sh := handler.NewStateHandler(tt.fields.StateRepo, nil, nil, nil)
 
 			router := gin.Default()
 			router.GET("/state/:project", func(c *gin.Context) {
@@ -87,8 +94,202 @@ func TestStateHandler_GetState(t *testing.T) {
 	}
 }
 
+func TestStateHandler_GetSequenceState_FreezeWindows(t *testing.T) {
+	stateRepo := &db_mock.SequenceStateRepoMock{
+		FindSequenceStatesFunc: func(filter models.StateFilter) (*models.SequenceStates, error) {
+			return &models.SequenceStates{States: []models.SequenceState{{Name: "delivery", Project: "my-project"}}}, nil
+		},
+	}
+	shipyardRetriever := &fake.IShipyardRetrieverMock{
+		GetCachedFreezeWindowsFunc: func(projectName string) (map[string][]common.FreezeWindow, error) {
+			return map[string][]common.FreezeWindow{
+				"production": {{Name: "always", Sequences: []string{"delivery"}}},
+				"dev":        {{Name: "christmas", Start: "2000-12-24T00:00:00Z", End: "2000-12-27T00:00:00Z"}},
+			}, nil
+		},
+	}
+	sh := handler.NewStateHandler(stateRepo, nil, nil, shipyardRetriever)
+
+	router := gin.Default()
+	router.GET("/state/:project", sh.GetSequenceState)
+	w := performRequest(router, httptest.NewRequest("GET", "/state/my-project", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	response := &scmodels.GetSequenceStateResponse{}
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), response))
+	require.Len(t, response.States, 1)
+	require.Equal(t, []scmodels.StageFreezeWindow{
+		{
+			Stage:        "dev",
+			FreezeWindow: common.FreezeWindow{Name: "christmas", Start: "2000-12-24T00:00:00Z", End: "2000-12-27T00:00:00Z"},
+			Active:       false,
+		},
+		{
+			Stage:        "production",
+			FreezeWindow: common.FreezeWindow{Name: "always", Sequences: []string{"delivery"}},
+			Active:       true,
+		},
+	}, response.FreezeWindows)
+}
+
+func TestStateHandler_GetTaskAttempts(t *testing.T) {
+	tests := []struct {
+		name                  string
+		sequenceExecutionRepo *db_mock.SequenceExecutionRepoMock
+		wantStatus            int
+		wantTasks             []scmodels.TaskAttemptHistory
+	}{
+		{
+			name: "returns attempt history",
+			sequenceExecutionRepo: &db_mock.SequenceExecutionRepoMock{
+				GetFunc: func(filter scmodels.SequenceExecutionFilter) ([]scmodels.SequenceExecution, error) {
+					return []scmodels.SequenceExecution{
+						{
+							Scope: scmodels.EventScope{EventData: keptnv2.EventData{Stage: "dev"}},
+							Status: scmodels.SequenceExecutionStatus{
+								PreviousTasks: []scmodels.TaskExecutionResult{
+									{
+										Name:        "deployment",
+										TriggeredID: "id-2",
+										Result:      keptnv2.ResultPass,
+										Status:      keptnv2.StatusSucceeded,
+										Attempts: []scmodels.TaskExecutionAttempt{
+											{TriggeredID: "id-1", Result: keptnv2.ResultFailed, Status: keptnv2.StatusErrored},
+										},
+									},
+								},
+								CurrentTask: scmodels.TaskExecutionState{Name: "test", TriggeredID: "id-3"},
+							},
+						},
+					}, nil
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantTasks: []scmodels.TaskAttemptHistory{
+				{
+					Stage:       "dev",
+					Task:        "deployment",
+					TriggeredID: "id-2",
+					Result:      keptnv2.ResultPass,
+					Status:      keptnv2.StatusSucceeded,
+					Attempts: []scmodels.TaskExecutionAttempt{
+						{TriggeredID: "id-1", Result: keptnv2.ResultFailed, Status: keptnv2.StatusErrored},
+					},
+				},
+				{
+					Stage:       "dev",
+					Task:        "test",
+					TriggeredID: "id-3",
+				},
+			},
+		},
+		{
+			name: "sequence not found",
+			sequenceExecutionRepo: &db_mock.SequenceExecutionRepoMock{
+				GetFunc: func(filter scmodels.SequenceExecutionFilter) ([]scmodels.SequenceExecution, error) {
+					return []scmodels.SequenceExecution{}, nil
+				},
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "repo returns error",
+			sequenceExecutionRepo: &db_mock.SequenceExecutionRepoMock{
+				GetFunc: func(filter scmodels.SequenceExecutionFilter) ([]scmodels.SequenceExecution, error) {
+					return nil, errors.New("oops")
+				},
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sh := handler.NewStateHandler(nil, tt.sequenceExecutionRepo, nil, nil)
+
+			router := gin.Default()
+			router.GET("/sequence/:project/:keptnContext/attempts", func(c *gin.Context) {
+				sh.GetTaskAttempts(c)
+			})
+			w := performRequest(router, httptest.NewRequest("GET", "/sequence/my-project/my-context/attempts?stage=dev", nil))
+
+			require.Equal(t, tt.wantStatus, w.Code)
+
+			require.Len(t, tt.sequenceExecutionRepo.GetCalls(), 1)
+			filter := tt.sequenceExecutionRepo.GetCalls()[0].Filter
+			require.Equal(t, "my-project", filter.Scope.Project)
+			require.Equal(t, "dev", filter.Scope.Stage)
+			require.Equal(t, "my-context", filter.Scope.KeptnContext)
+
+			if tt.wantTasks != nil {
+				response := &scmodels.GetTaskAttemptsResponse{}
+				require.Nil(t, json.Unmarshal(w.Body.Bytes(), response))
+				require.Equal(t, tt.wantTasks, response.Tasks)
+			}
+		})
+	}
+}
+
 func performRequest(r http.Handler, request *http.Request) *httptest.ResponseRecorder {
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, request)
 	return w
 }
+
+func TestStateHandler_ControlSequenceState_Retry(t *testing.T) {
+	tests := []struct {
+		name         string
+		retryErr     error
+		body         string
+		wantStatus   int
+		wantFromTask string
+	}{
+		{
+			name:         "retry from task",
+			body:         `{"state": "retry", "stage": "dev", "fromTask": "test"}`,
+			wantStatus:   http.StatusOK,
+			wantFromTask: "test",
+		},
+		{
+			name:       "retry from first failed task",
+			body:       `{"state": "retry"}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:         "sequence cannot be retried",
+			retryErr:     fmt.Errorf("%w: oops", handler.ErrSequenceNotRetryable),
+			body:         `{"state": "retry", "fromTask": "unknown"}`,
+			wantStatus:   http.StatusBadRequest,
+			wantFromTask: "unknown",
+		},
+		{
+			name:       "sequence not found",
+			retryErr:   handler.ErrSequenceNotFound,
+			body:       `{"state": "retry"}`,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shipyardController := &fake.IShipyardControllerMock{
+				RetrySequenceFunc: func(controlSequence models.SequenceControl, fromTask string) error {
+					return tt.retryErr
+				},
+			}
+			sh := handler.NewStateHandler(nil, nil, shipyardController, nil)
+
+			router := gin.Default()
+			router.POST("/sequence/:project/:keptnContext/control", func(c *gin.Context) {
+				sh.ControlSequenceState(c)
+			})
+			w := performRequest(router, httptest.NewRequest(http.MethodPost, "/sequence/my-project/my-context/control", strings.NewReader(tt.body)))
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			require.Len(t, shipyardController.RetrySequenceCalls(), 1)
+			require.Empty(t, shipyardController.ControlSequenceCalls())
+			require.Equal(t, "my-project", shipyardController.RetrySequenceCalls()[0].ControlSequence.Project)
+			require.Equal(t, "my-context", shipyardController.RetrySequenceCalls()[0].ControlSequence.KeptnContext)
+			require.Equal(t, scmodels.RetrySequence, shipyardController.RetrySequenceCalls()[0].ControlSequence.State)
+			require.Equal(t, tt.wantFromTask, shipyardController.RetrySequenceCalls()[0].FromTask)
+		})
+	}
+}
@@ -15,7 +15,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/kelseyhightower/envconfig"
-	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnapi "github.com/keptn/go-utils/pkg/api/utils"
 	"github.com/keptn/go-utils/pkg/common/osutils"
 	keptncommon "github.com/keptn/go-utils/pkg/lib/keptn"
 	"github.com/keptn/keptn/shipyard-controller/common"
@@ -26,9 +26,13 @@ import (
 	_ "github.com/keptn/keptn/shipyard-controller/docs"
 	"github.com/keptn/keptn/shipyard-controller/handler"
 	"github.com/keptn/keptn/shipyard-controller/handler/sequencehooks"
-	_ "github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/keptn/keptn/shipyard-controller/metrics"
+	"github.com/keptn/keptn/shipyard-controller/models"
 	"github.com/keptn/keptn/shipyard-controller/nats"
+	"github.com/keptn/keptn/shipyard-controller/tracing"
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	v1 "k8s.io/client-go/kubernetes/typed/coordination/v1"
@@ -90,12 +94,26 @@ func main() {
 		gin.DefaultWriter = ioutil.Discard
 	}
 
+	// the monitor needs to be set before the first connection to MongoDB is established
+	db.GetMongoDBConnectionInstance().Monitor = metrics.NewMongoDBCommandMonitor()
+
 	// TODO: refactor shippy to use envconfig
 	var env config.EnvConfig
 	if err := envconfig.Process("", &env); err != nil {
 		log.Fatalf("Failed to process env var: %v", err)
 	}
 
+	tracerProvider, err := tracing.NewTracerProvider(ctx, "shipyard-controller", env.OTLPEndpoint)
+	if err != nil {
+		log.Fatalf("could not create tracer provider: %v", err)
+	}
+	otel.SetTracerProvider(tracerProvider)
+	defer func() {
+		if err := tracerProvider.Shutdown(context.Background()); err != nil {
+			log.WithError(err).Error("could not shut down tracer provider")
+		}
+	}()
+
 	eventDispatcherSyncInterval, err := strconv.Atoi(osutils.GetOSEnvOrDefault(envVarEventDispatchIntervalSec, envVarEventDispatchIntervalSecDefault))
 	if err != nil {
 		log.Fatalf("Unexpected value of EVENT_DISPATCH_INTERVAL_SEC environment variable. Need to be a number")
@@ -145,32 +163,63 @@ func main() {
 		projectMVRepo,
 		common.NewGitConfigurationStore(csEndpoint.String()),
 		uniformRepo,
+		sequenceExecutionRepo,
+		createServiceMetadataRepo(),
+	)
+
+	stageManager := handler.NewStageManager(
+		projectMVRepo,
+		common.NewGitConfigurationStore(csEndpoint.String()),
+		sequenceExecutionRepo,
 	)
 
-	stageManager := handler.NewStageManager(projectMVRepo)
+	replicaID := uuid.New().String()
+	locker := handler.NewDistributedLocker(createLockRepo(), replicaID, env.LockTTL, clock.New())
+	claimOptions := common.ClaimOptions{
+		Owner:     replicaID,
+		TTL:       env.QueueClaimTTL,
+		BatchSize: env.QueueClaimBatchSize,
+	}
+
+	shipyardRetriever := handler.NewShipyardRetriever(
+		common.NewGitConfigurationStore(csEndpoint.String()),
+		projectMVRepo,
+	)
 
-	eventDispatcher := handler.NewEventDispatcher(createEventsRepo(), createEventQueueRepo(), sequenceExecutionRepo, eventSender, time.Duration(eventDispatcherSyncInterval)*time.Second)
+	priorityClasses, err := common.NewPriorityClasses(env.PriorityClasses, env.SequencePriorityClasses)
+	if err != nil {
+		log.Fatalf("Invalid priority classes: %v", err)
+	}
+	concurrencyLimiter := handler.NewConcurrencyLimiter(common.ConcurrencyLimits{
+		MaxPerProject:  env.MaxSequencesPerProject,
+		MaxPerStage:    env.MaxSequencesPerStage,
+		MaxPerSequence: env.MaxSequencesPerName,
+	}, sequenceExecutionRepo, projectMVRepo, locker)
+
+	eventDispatcher := handler.NewEventDispatcher(createEventsRepo(), createEventQueueRepo(), sequenceExecutionRepo, concurrencyLimiter, eventSender, time.Duration(eventDispatcherSyncInterval)*time.Second, locker, claimOptions)
 	sequenceDispatcher := handler.NewSequenceDispatcher(
 		createEventsRepo(),
 		createSequenceQueueRepo(),
 		sequenceExecutionRepo,
+		shipyardRetriever,
+		concurrencyLimiter,
 		getDurationFromEnvVar(envVarSequenceDispatchIntervalSec, envVarSequenceDispatchIntervalSecDefault),
 		clock.New(),
 		common.SDModeRW,
+		locker,
+		claimOptions,
 	)
 
-	sequenceTimeoutChannel := make(chan apimodels.SequenceTimeout)
+	sequenceTimeoutChannel := make(chan models.SequenceTimeout)
 
-	shipyardRetriever := handler.NewShipyardRetriever(
-		common.NewGitConfigurationStore(csEndpoint.String()),
-		projectMVRepo,
-	)
 	shipyardController := handler.GetShipyardControllerInstance(
 		ctx,
 		eventDispatcher,
 		sequenceDispatcher,
 		sequenceTimeoutChannel,
 		shipyardRetriever,
+		locker,
+		priorityClasses,
 	)
 
 	engine := gin.Default()
@@ -179,15 +228,37 @@ func main() {
 	wg := &sync.WaitGroup{}
 	engine.Use(handler.GracefulShutdownMiddleware(wg))
 
+	auditRepo := createAuditRepo()
+	if err := auditRepo.SetupTTLIndex(env.AuditRecordTTL); err != nil {
+		log.WithError(err).Error("could not setup TTL index for audit records")
+	}
+	auditManager := handler.NewAuditManager(auditRepo, clock.New())
+	engine.Use(handler.AuditMiddleware(auditManager, handler.NewAuditActions(projectMVRepo)))
+	engine.Use(handler.AuthorizationMiddleware(env.InternalAPIToken))
+
 	apiV1 := engine.Group("/v1")
 	apiHealth := engine.Group("")
 
-	projectService := handler.NewProjectHandler(projectManager, eventSender, env, repositoryProvisioner)
+	projectService := handler.NewProjectHandler(projectManager, eventSender, env, repositoryProvisioner, locker)
 
 	projectController := controller.NewProjectController(projectService)
 	projectController.Inject(apiV1)
 
-	serviceHandler := handler.NewServiceHandler(serviceManager, eventSender, env)
+	projectArchiveManager := handler.NewProjectArchiveManager(
+		projectManager,
+		serviceManager,
+		common.NewGitConfigurationStore(csEndpoint.String()),
+		projectMVRepo,
+		uniformRepo,
+		sequenceExecutionRepo,
+		keptnapi.NewSecretHandler(env.SecretServiceURL),
+		clock.New(),
+	)
+	projectArchiveHandler := handler.NewProjectArchiveHandler(projectArchiveManager, env, repositoryProvisioner, locker)
+	projectArchiveController := controller.NewProjectArchiveController(projectArchiveHandler)
+	projectArchiveController.Inject(apiV1)
+
+	serviceHandler := handler.NewServiceHandler(serviceManager, eventSender, env, locker)
 	serviceController := controller.NewServiceController(serviceHandler)
 	serviceController.Inject(apiV1)
 
@@ -195,7 +266,7 @@ func main() {
 	eventController := controller.NewEventController(eventHandler)
 	eventController.Inject(apiV1)
 
-	stageHandler := handler.NewStageHandler(stageManager)
+	stageHandler := handler.NewStageHandler(stageManager, locker)
 	stageController := controller.NewStageController(stageHandler)
 	stageController.Inject(apiV1)
 
@@ -207,10 +278,14 @@ func main() {
 	evaluationController := controller.NewEvaluationController(evaluationHandler)
 	evaluationController.Inject(apiV1)
 
-	stateHandler := handler.NewStateHandler(db.NewMongoDBStateRepo(db.GetMongoDBConnectionInstance()), shipyardController)
+	stateHandler := handler.NewStateHandler(db.NewMongoDBStateRepo(db.GetMongoDBConnectionInstance()), sequenceExecutionRepo, shipyardController, shipyardRetriever)
 	stateController := controller.NewStateController(stateHandler)
 	stateController.Inject(apiV1)
 
+	sequenceExecutionHandler := handler.NewSequenceExecutionHandler(sequenceExecutionRepo)
+	sequenceExecutionController := controller.NewSequenceExecutionController(sequenceExecutionHandler)
+	sequenceExecutionController.Inject(apiV1)
+
 	sequenceStateMaterializedView := sequencehooks.NewSequenceStateMaterializedView(createStateRepo())
 	shipyardController.AddSequenceTriggeredHook(sequenceStateMaterializedView)
 	shipyardController.AddSequenceStartedHook(sequenceStateMaterializedView)
@@ -229,6 +304,54 @@ func main() {
 	shipyardController.AddSequencePausedHook(sequenceStateMaterializedView)
 	shipyardController.AddSequenceResumedHook(sequenceStateMaterializedView)
 
+	notificationDeliveryRepo := createNotificationDeliveryRepo()
+	if err := notificationDeliveryRepo.SetupTTLIndex(env.NotificationDeliveryTTL); err != nil {
+		log.WithError(err).Error("could not setup TTL index for notification deliveries")
+	}
+	notificationChannelRepo := createNotificationChannelRepo()
+	notificationManager := handler.NewNotificationManager(notificationChannelRepo, notificationDeliveryRepo, projectMVRepo, sequenceExecutionRepo, clock.New())
+	shipyardController.AddSequenceTriggeredHook(notificationManager)
+	shipyardController.AddSequenceStartedHook(notificationManager)
+	shipyardController.AddSequenceWaitingHook(notificationManager)
+	shipyardController.AddSequenceFinishedHook(notificationManager)
+	shipyardController.AddSequenceAbortedHook(notificationManager)
+	shipyardController.AddSequenceTimeoutHook(notificationManager)
+	shipyardController.AddSequencePausedHook(notificationManager)
+	shipyardController.AddSequenceResumedHook(notificationManager)
+
+	auditHandler := handler.NewAuditHandler(auditManager)
+	auditController := controller.NewAuditController(auditHandler)
+	auditController.Inject(apiV1)
+
+	apiTokenManager := handler.NewAPITokenManager(createAPITokenRepo(), clock.New())
+	apiTokenHandler := handler.NewAPITokenHandler(apiTokenManager)
+	tokenController := controller.NewTokenController(apiTokenHandler)
+	tokenController.Inject(apiV1)
+
+	notificationHandler := handler.NewNotificationHandler(notificationManager)
+	notificationController := controller.NewNotificationController(notificationHandler)
+	notificationController.Inject(apiV1)
+
+	notificationDispatcher := handler.NewNotificationDispatcher(
+		notificationDeliveryRepo,
+		notificationChannelRepo,
+		handler.NewNotificationSenders(),
+		handler.NotificationRetryOptions{
+			MaxAttempts: env.NotificationMaxAttempts,
+			Backoff:     env.NotificationRetryBackoff,
+		},
+		env.NotificationDispatchInterval,
+		clock.New(),
+	)
+	notificationDispatcher.Run(ctx)
+
+	taskDurationHook := metrics.NewTaskDurationHook()
+	shipyardController.AddSequenceTaskTriggeredHook(taskDurationHook)
+	shipyardController.AddSequenceTaskFinishedHook(taskDurationHook)
+	shipyardController.AddSequenceFinishedHook(taskDurationHook)
+	shipyardController.AddSequenceAbortedHook(taskDurationHook)
+	shipyardController.AddSequenceTimeoutHook(taskDurationHook)
+
 	taskStartedWaitDuration := getDurationFromEnvVar(envVarTaskStartedWaitDuration, envVarTaskStartedWaitDurationDefault)
 
 	watcher := handler.NewSequenceWatcher(
@@ -236,6 +359,7 @@ func main() {
 		createEventsRepo(),
 		createEventQueueRepo(),
 		createProjectRepo(),
+		sequenceExecutionRepo,
 		taskStartedWaitDuration,
 		1*time.Minute,
 		clock.New(),
@@ -247,6 +371,18 @@ func main() {
 	uniformController := controller.NewUniformIntegrationController(uniformHandler)
 	uniformController.Inject(apiV1)
 
+	shipyardHandler := handler.NewShipyardHandler(projectMVRepo, uniformRepo)
+	shipyardValidationController := controller.NewShipyardController(shipyardHandler)
+	shipyardValidationController.Inject(apiV1)
+
+	scheduleRepo := createScheduleRepo()
+	scheduleHandler := handler.NewScheduleHandler(handler.NewScheduleManager(scheduleRepo, projectMVRepo, shipyardRetriever))
+	scheduleController := controller.NewScheduleController(scheduleHandler)
+	scheduleController.Inject(apiV1)
+
+	scheduler := handler.NewScheduler(scheduleRepo, sequenceExecutionRepo, eventSender, shipyardController, env.ScheduleSyncInterval, clock.New())
+	scheduler.Run(ctx)
+
 	logRepo := createLogRepo()
 	err = logRepo.SetupTTLIndex(getDurationFromEnvVar(envVarLogTTL, envVarLogsTTLDefault))
 	if err != nil {
@@ -276,6 +412,21 @@ func main() {
 	healthController := controller.NewHealthController(healthHandler)
 	healthController.Inject(apiHealth)
 
+	repositoryCollector := metrics.NewRepositoryCollector(
+		createSequenceQueueRepo(),
+		createEventQueueRepo(),
+		createProjectRepo(),
+		sequenceExecutionRepo,
+		uniformRepo,
+		clock.New(),
+	)
+	if err := metrics.Register(prometheus.DefaultRegisterer, repositoryCollector); err != nil {
+		log.Fatalf("Could not register metrics: %v", err)
+	}
+	metricsHandler := handler.NewMetricsHandler(prometheus.DefaultGatherer)
+	metricsController := controller.NewMetricsController(metricsHandler)
+	metricsController.Inject(apiHealth)
+
 	engine.Static("/swagger-ui", "./swagger-ui")
 	srv := &http.Server{
 		Addr:    ":8080",
@@ -294,7 +445,11 @@ func main() {
 		}
 	}()
 
-	if os.Getenv(envVarDisableLeaderElection) == "true" {
+	if env.DispatchMode == config.DispatchModePartitioned {
+		// multiple shipyards, each dispatching the queued items it has claimed
+		log.Infof("Starting dispatchers in partitioned mode with replica ID %s", replicaID)
+		shipyardController.StartDispatchers(ctx, common.SDModePartitioned)
+	} else if os.Getenv(envVarDisableLeaderElection) == "true" {
 		// single shipyard
 		shipyardController.StartDispatchers(ctx, common.SDModeRW)
 	} else {
@@ -438,6 +593,34 @@ func createSecretStore(kubeAPI *kubernetes.Clientset) *common.K8sSecretStore {
 	return common.NewK8sSecretStore(kubeAPI)
 }
 
+func createLockRepo() *db.MongoDBLockRepo {
+	return db.NewMongoDBLockRepo(db.GetMongoDBConnectionInstance())
+}
+
+func createScheduleRepo() *db.MongoDBScheduleRepo {
+	return db.NewMongoDBScheduleRepo(db.GetMongoDBConnectionInstance())
+}
+
+func createAuditRepo() *db.MongoDBAuditRepo {
+	return db.NewMongoDBAuditRepo(db.GetMongoDBConnectionInstance())
+}
+
+func createAPITokenRepo() *db.MongoDBAPITokenRepo {
+	return db.NewMongoDBAPITokenRepo(db.GetMongoDBConnectionInstance())
+}
+
+func createNotificationChannelRepo() *db.MongoDBNotificationChannelRepo {
+	return db.NewMongoDBNotificationChannelRepo(db.GetMongoDBConnectionInstance())
+}
+
+func createNotificationDeliveryRepo() *db.MongoDBNotificationDeliveryRepo {
+	return db.NewMongoDBNotificationDeliveryRepo(db.GetMongoDBConnectionInstance())
+}
+
+func createServiceMetadataRepo() *db.MongoDBServiceMetadataRepo {
+	return db.NewMongoDBServiceMetadataRepo(db.GetMongoDBConnectionInstance())
+}
+
 func createLogRepo() *db.MongoDBLogRepo {
 	return db.NewMongoDBLogRepo(db.GetMongoDBConnectionInstance())
 }
@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "shipyard_controller"
+
+// SequenceDispatcherName is the label value for metrics of the sequence dispatcher loop
+const SequenceDispatcherName = "sequence"
+
+// EventDispatcherName is the label value for metrics of the event dispatcher loop
+const EventDispatcherName = "event"
+
+var (
+	// TaskDuration measures the time between the .triggered event of a task and the .finished event sent by an integration
+	TaskDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "task_duration_seconds",
+		Help:      "Time between the .triggered event of a task and its .finished event, by task and integration",
+		Buckets:   []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800, 3600},
+	}, []string{"task", "integration"})
+
+	// DispatcherLoopDuration measures the duration of a single iteration of a dispatcher loop
+	DispatcherLoopDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "dispatcher_loop_duration_seconds",
+		Help:      "Duration of a single iteration of the sequence and event dispatcher loops",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"dispatcher"})
+
+	// DispatcherLastRun contains the time at which a dispatcher loop has completed its last iteration
+	DispatcherLastRun = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "dispatcher_last_run_timestamp_seconds",
+		Help:      "Unix time at which the sequence and event dispatcher loops have completed their last iteration",
+	}, []string{"dispatcher"})
+
+	// MongoDBOperationDuration measures the duration of the commands sent to MongoDB
+	MongoDBOperationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "mongodb_operation_duration_seconds",
+		Help:      "Duration of the commands sent to MongoDB, by command",
+		Buckets:   []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	}, []string{"command"})
+
+	// MongoDBOperationErrors counts the commands sent to MongoDB that have failed
+	MongoDBOperationErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "mongodb_operation_errors_total",
+		Help:      "Number of failed commands sent to MongoDB, by command",
+	}, []string{"command"})
+
+	// NATSPublishFailures counts the events that could not be published to NATS
+	NATSPublishFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "nats_publish_failures_total",
+		Help:      "Number of events that could not be published to NATS, by event type",
+	}, []string{"type"})
+)
+
+// Register registers the metrics of the shipyard-controller, as well as the given collectors, at the given registerer
+func Register(registerer prometheus.Registerer, collectors ...prometheus.Collector) error {
+	collectors = append(collectors,
+		TaskDuration,
+		DispatcherLoopDuration,
+		DispatcherLastRun,
+		MongoDBOperationDuration,
+		MongoDBOperationErrors,
+		NATSPublishFailures,
+	)
+	for _, collector := range collectors {
+		if err := registerer.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ObserveDispatcherLoop records the duration of an iteration of the given dispatcher that has been started at the given time
+func ObserveDispatcherLoop(dispatcher string, startedAt time.Time) {
+	now := time.Now()
+	DispatcherLoopDuration.WithLabelValues(dispatcher).Observe(now.Sub(startedAt).Seconds())
+	DispatcherLastRun.WithLabelValues(dispatcher).Set(float64(now.Unix()))
+}
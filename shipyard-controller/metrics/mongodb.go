@@ -0,0 +1,20 @@
+package metrics
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// NewMongoDBCommandMonitor returns a monitor for the MongoDB client that records the duration and failures of the commands sent to MongoDB
+func NewMongoDBCommandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(ctx context.Context, evt *event.CommandSucceededEvent) {
+			MongoDBOperationDuration.WithLabelValues(evt.CommandName).Observe(float64(evt.DurationNanos) / 1e9)
+		},
+		Failed: func(ctx context.Context, evt *event.CommandFailedEvent) {
+			MongoDBOperationDuration.WithLabelValues(evt.CommandName).Observe(float64(evt.DurationNanos) / 1e9)
+			MongoDBOperationErrors.WithLabelValues(evt.CommandName).Inc()
+		},
+	}
+}
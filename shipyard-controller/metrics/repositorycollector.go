@@ -0,0 +1,131 @@
+package metrics
+
+import (
+	"errors"
+
+	"github.com/benbjohnson/clock"
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+// activeSequenceStates contains the states of sequences that are reported by the RepositoryCollector. Completed sequences are not reported
+var activeSequenceStates = []string{
+	apimodels.SequenceTriggeredState,
+	apimodels.SequenceStartedState,
+	apimodels.SequenceWaitingState,
+	apimodels.SequenceWaitingForApprovalState,
+	apimodels.SequencePaused,
+}
+
+var (
+	queueDepthDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "queue_depth"),
+		"Number of items in the sequence queue, and number of events in the event queue that are due to be sent",
+		[]string{"queue"}, nil,
+	)
+	sequencesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "sequences"),
+		"Number of sequences that have not been completed yet, by state",
+		[]string{"state"}, nil,
+	)
+	integrationLastSeenDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "integration_last_seen_seconds"),
+		"Time since the last heartbeat of an integration",
+		[]string{"integration", "id"}, nil,
+	)
+)
+
+// RepositoryCollector is a prometheus.Collector that retrieves the queue depths, the states of the sequences,
+// and the heartbeats of the integrations from the database whenever the metrics are collected
+type RepositoryCollector struct {
+	sequenceQueueRepo     db.SequenceQueueRepo
+	eventQueueRepo        db.EventQueueRepo
+	projectRepo           db.ProjectRepo
+	sequenceExecutionRepo db.SequenceExecutionRepo
+	uniformRepo           db.UniformRepo
+	theClock              clock.Clock
+}
+
+// NewRepositoryCollector creates a new RepositoryCollector
+func NewRepositoryCollector(sequenceQueueRepo db.SequenceQueueRepo, eventQueueRepo db.EventQueueRepo, projectRepo db.ProjectRepo, sequenceExecutionRepo db.SequenceExecutionRepo, uniformRepo db.UniformRepo, theClock clock.Clock) *RepositoryCollector {
+	return &RepositoryCollector{
+		sequenceQueueRepo:     sequenceQueueRepo,
+		eventQueueRepo:        eventQueueRepo,
+		projectRepo:           projectRepo,
+		sequenceExecutionRepo: sequenceExecutionRepo,
+		uniformRepo:           uniformRepo,
+		theClock:              theClock,
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *RepositoryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- queueDepthDesc
+	ch <- sequencesDesc
+	ch <- integrationLastSeenDesc
+}
+
+// Collect implements prometheus.Collector. Metrics that cannot be retrieved from the database are omitted
+func (c *RepositoryCollector) Collect(ch chan<- prometheus.Metric) {
+	c.collectQueueDepths(ch)
+	c.collectSequences(ch)
+	c.collectIntegrations(ch)
+}
+
+func (c *RepositoryCollector) collectQueueDepths(ch chan<- prometheus.Metric) {
+	sequences, err := c.sequenceQueueRepo.GetQueuedSequences()
+	if err != nil && !errors.Is(err, db.ErrNoEventFound) {
+		log.WithError(err).Error("Could not collect depth of sequence queue")
+	} else {
+		ch <- prometheus.MustNewConstMetric(queueDepthDesc, prometheus.GaugeValue, float64(len(sequences)), SequenceDispatcherName)
+	}
+
+	events, err := c.eventQueueRepo.GetQueuedEvents(c.theClock.Now().UTC())
+	if err != nil && !errors.Is(err, db.ErrNoEventFound) {
+		log.WithError(err).Error("Could not collect depth of event queue")
+	} else {
+		ch <- prometheus.MustNewConstMetric(queueDepthDesc, prometheus.GaugeValue, float64(len(events)), EventDispatcherName)
+	}
+}
+
+func (c *RepositoryCollector) collectSequences(ch chan<- prometheus.Metric) {
+	projects, err := c.projectRepo.GetProjects()
+	if err != nil {
+		log.WithError(err).Error("Could not collect states of sequences")
+		return
+	}
+
+	counts := map[string]int{}
+	for _, project := range projects {
+		sequenceExecutions, err := c.sequenceExecutionRepo.Get(models.SequenceExecutionFilter{
+			Scope:  models.EventScope{EventData: keptnv2.EventData{Project: project.ProjectName}},
+			Status: activeSequenceStates,
+		})
+		if err != nil {
+			log.WithError(err).Errorf("Could not collect states of sequences in project %s", project.ProjectName)
+			return
+		}
+		for _, sequenceExecution := range sequenceExecutions {
+			counts[sequenceExecution.Status.State]++
+		}
+	}
+	for _, state := range activeSequenceStates {
+		ch <- prometheus.MustNewConstMetric(sequencesDesc, prometheus.GaugeValue, float64(counts[state]), state)
+	}
+}
+
+func (c *RepositoryCollector) collectIntegrations(ch chan<- prometheus.Metric) {
+	integrations, err := c.uniformRepo.GetUniformIntegrations(models.GetUniformIntegrationsParams{})
+	if err != nil {
+		log.WithError(err).Error("Could not collect heartbeats of integrations")
+		return
+	}
+	now := c.theClock.Now().UTC()
+	for _, integration := range integrations {
+		ch <- prometheus.MustNewConstMetric(integrationLastSeenDesc, prometheus.GaugeValue, now.Sub(integration.MetaData.LastSeen).Seconds(), integration.Name, integration.ID)
+	}
+}
@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepositoryCollector(t *testing.T) {
+	theClock := clock.NewMock()
+	theClock.Set(time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC))
+
+	sequenceQueueRepo := &db_mock.SequenceQueueRepoMock{
+		GetQueuedSequencesFunc: func() ([]models.QueueItem, error) {
+			return []models.QueueItem{{EventID: "1"}, {EventID: "2"}}, nil
+		},
+	}
+	eventQueueRepo := &db_mock.EventQueueRepoMock{
+		GetQueuedEventsFunc: func(timestamp time.Time) ([]models.QueueItem, error) {
+			return nil, errors.New("oops")
+		},
+	}
+	projectRepo := &db_mock.ProjectRepoMock{
+		GetProjectsFunc: func() ([]*apimodels.ExpandedProject, error) {
+			return []*apimodels.ExpandedProject{{ProjectName: "project-a"}, {ProjectName: "project-b"}}, nil
+		},
+	}
+	sequenceExecutionRepo := &db_mock.SequenceExecutionRepoMock{
+		GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+			if filter.Scope.Project == "project-a" {
+				return []models.SequenceExecution{
+					{Status: models.SequenceExecutionStatus{State: apimodels.SequenceStartedState}},
+					{Status: models.SequenceExecutionStatus{State: apimodels.SequenceStartedState}},
+				}, nil
+			}
+			return []models.SequenceExecution{
+				{Status: models.SequenceExecutionStatus{State: apimodels.SequenceWaitingState}},
+			}, nil
+		},
+	}
+	uniformRepo := &db_mock.UniformRepoMock{
+		GetUniformIntegrationsFunc: func(filter models.GetUniformIntegrationsParams) ([]apimodels.Integration, error) {
+			return []apimodels.Integration{
+				{ID: "i1", Name: "helm-service", MetaData: apimodels.MetaData{LastSeen: theClock.Now().Add(-90 * time.Second)}},
+			}, nil
+		},
+	}
+
+	collector := NewRepositoryCollector(sequenceQueueRepo, eventQueueRepo, projectRepo, sequenceExecutionRepo, uniformRepo, theClock)
+
+	expected := `
+# HELP shipyard_controller_integration_last_seen_seconds Time since the last heartbeat of an integration
+# TYPE shipyard_controller_integration_last_seen_seconds gauge
+shipyard_controller_integration_last_seen_seconds{id="i1",integration="helm-service"} 90
+# HELP shipyard_controller_queue_depth Number of items in the sequence queue, and number of events in the event queue that are due to be sent
+# TYPE shipyard_controller_queue_depth gauge
+shipyard_controller_queue_depth{queue="sequence"} 2
+# HELP shipyard_controller_sequences Number of sequences that have not been completed yet, by state
+# TYPE shipyard_controller_sequences gauge
+shipyard_controller_sequences{state="paused"} 0
+shipyard_controller_sequences{state="started"} 2
+shipyard_controller_sequences{state="triggered"} 0
+shipyard_controller_sequences{state="waiting"} 1
+shipyard_controller_sequences{state="waitingForApproval"} 0
+`
+	require.Nil(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+	require.Len(t, sequenceExecutionRepo.GetCalls(), 2)
+	require.Equal(t, activeSequenceStates, sequenceExecutionRepo.GetCalls()[0].Filter.Status)
+}
@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/models"
+)
+
+type triggeredTask struct {
+	keptnContext string
+	triggeredAt  time.Time
+}
+
+// TaskDurationHook records the duration of tasks using the sequence hooks of the shipyard controller.
+// The time of a .triggered event is kept in memory until the sequence is completed. Tasks that have been triggered by another replica,
+// or before the last restart of the shipyard-controller, are therefore not recorded
+type TaskDurationHook struct {
+	triggeredTasks map[string]triggeredTask
+	mutex          sync.Mutex
+}
+
+// NewTaskDurationHook creates a new TaskDurationHook
+func NewTaskDurationHook() *TaskDurationHook {
+	return &TaskDurationHook{triggeredTasks: map[string]triggeredTask{}}
+}
+
+// OnSequenceTaskTriggered stores the time at which the task has been triggered
+func (h *TaskDurationHook) OnSequenceTaskTriggered(event apimodels.KeptnContextExtendedCE) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.triggeredTasks[event.ID] = triggeredTask{keptnContext: event.Shkeptncontext, triggeredAt: event.Time}
+}
+
+// OnSequenceTaskFinished records the time between the .triggered event of the task and the given .finished event.
+// Since multiple integrations can respond to the same .triggered event, the time of the .triggered event is retained
+func (h *TaskDurationHook) OnSequenceTaskFinished(event apimodels.KeptnContextExtendedCE) {
+	if event.Type == nil {
+		return
+	}
+	taskName, _, err := keptnv2.ParseTaskEventType(*event.Type)
+	if err != nil {
+		return
+	}
+
+	h.mutex.Lock()
+	task, ok := h.triggeredTasks[event.Triggeredid]
+	h.mutex.Unlock()
+	if !ok {
+		return
+	}
+
+	finishedAt := event.Time
+	if finishedAt.IsZero() {
+		finishedAt = time.Now().UTC()
+	}
+	integration := ""
+	if event.Source != nil {
+		integration = *event.Source
+	}
+	TaskDuration.WithLabelValues(taskName, integration).Observe(finishedAt.Sub(task.triggeredAt).Seconds())
+}
+
+// OnSequenceFinished removes the tasks of the finished sequence
+func (h *TaskDurationHook) OnSequenceFinished(event apimodels.KeptnContextExtendedCE) {
+	h.removeTasksOfContext(event.Shkeptncontext)
+}
+
+// OnSequenceAborted removes the tasks of the aborted sequence
+func (h *TaskDurationHook) OnSequenceAborted(eventScope models.EventScope) {
+	h.removeTasksOfContext(eventScope.KeptnContext)
+}
+
+// OnSequenceTimeout removes the tasks of the timed out sequence
+func (h *TaskDurationHook) OnSequenceTimeout(event apimodels.KeptnContextExtendedCE, reason models.SequenceTimeoutReason) {
+	h.removeTasksOfContext(event.Shkeptncontext)
+}
+
+func (h *TaskDurationHook) removeTasksOfContext(keptnContext string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for id, task := range h.triggeredTasks {
+		if task.keptnContext == keptnContext {
+			delete(h.triggeredTasks, id)
+		}
+	}
+}
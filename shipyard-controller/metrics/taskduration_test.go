@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/common"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskDurationHook(t *testing.T) {
+	TaskDuration.Reset()
+	hook := NewTaskDurationHook()
+
+	triggeredAt := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+	hook.OnSequenceTaskTriggered(apimodels.KeptnContextExtendedCE{
+		ID:             "triggered-id",
+		Shkeptncontext: "my-context",
+		Type:           common.Stringp(keptnv2.GetTriggeredEventType(keptnv2.DeploymentTaskName)),
+		Time:           triggeredAt,
+	})
+
+	// a .finished event for an unknown task is ignored
+	hook.OnSequenceTaskFinished(apimodels.KeptnContextExtendedCE{
+		Triggeredid: "unknown-id",
+		Source:      common.Stringp("helm-service"),
+		Type:        common.Stringp(keptnv2.GetFinishedEventType(keptnv2.DeploymentTaskName)),
+		Time:        triggeredAt.Add(time.Minute),
+	})
+	require.Equal(t, 0, testutil.CollectAndCount(TaskDuration))
+
+	hook.OnSequenceTaskFinished(apimodels.KeptnContextExtendedCE{
+		Triggeredid: "triggered-id",
+		Source:      common.Stringp("helm-service"),
+		Type:        common.Stringp(keptnv2.GetFinishedEventType(keptnv2.DeploymentTaskName)),
+		Time:        triggeredAt.Add(time.Minute),
+	})
+	hook.OnSequenceTaskFinished(apimodels.KeptnContextExtendedCE{
+		Triggeredid: "triggered-id",
+		Source:      common.Stringp("my-deployment-service"),
+		Type:        common.Stringp(keptnv2.GetFinishedEventType(keptnv2.DeploymentTaskName)),
+		Time:        triggeredAt.Add(2 * time.Minute),
+	})
+	require.Equal(t, 2, testutil.CollectAndCount(TaskDuration))
+
+	// the task is removed once the sequence is completed
+	hook.OnSequenceAborted(models.EventScope{KeptnContext: "my-context"})
+	require.Empty(t, hook.triggeredTasks)
+}
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+const (
+	// TokenRoleViewer allows read access
+	TokenRoleViewer = "viewer"
+	// TokenRoleApprover allows read access and sending the results of approvals
+	TokenRoleApprover = "approver"
+	// TokenRoleOperator allows read and write access, except for the creation and deletion of projects and the management of API tokens
+	TokenRoleOperator = "operator"
+	// TokenRoleAdmin allows full access
+	TokenRoleAdmin = "admin"
+)
+
+// TokenRoles contains all roles that can be assigned to an API token
+var TokenRoles = []string{TokenRoleViewer, TokenRoleApprover, TokenRoleOperator, TokenRoleAdmin}
+
+// APIToken is a named token that grants access to the Keptn API. Only the hash of the token is stored
+type APIToken struct {
+	Name string `json:"name" bson:"_id"`
+	// Hash is the SHA-256 hash of the token
+	Hash string `json:"-" bson:"hash"`
+	// Roles are the roles that have been granted to the token
+	Roles []string `json:"roles" bson:"roles"`
+	// Projects limit the access of the token to the given projects. If empty, the token grants access to all projects
+	Projects  []string   `json:"projects,omitempty" bson:"projects,omitempty"`
+	CreatedAt time.Time  `json:"createdAt" bson:"createdAt"`
+	RotatedAt *time.Time `json:"rotatedAt,omitempty" bson:"rotatedAt,omitempty"`
+}
+
+type CreateAPITokenParams struct {
+	Name     string   `json:"name"`
+	Roles    []string `json:"roles"`
+	Projects []string `json:"projects,omitempty"`
+}
+
+// CreateAPITokenResponse contains the token in plain text. It is only returned when a token is created or rotated
+type CreateAPITokenResponse struct {
+	APIToken
+	Token string `json:"token"`
+}
+
+type GetAPITokensResponse struct {
+	Tokens []APIToken `json:"tokens"`
+}
+
+type DeleteAPITokenResponse struct{}
+
+type ValidateAPITokenParams struct {
+	Token string `json:"token"`
+}
@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+// AuditPrincipalInternal is the principal of requests that have not been passed through the API gateway, e.g. requests sent by other Keptn services
+const AuditPrincipalInternal = "internal"
+
+// AuditRecord records a state-changing call to the API of a Keptn service
+type AuditRecord struct {
+	ID   string    `json:"id" bson:"_id"`
+	Time time.Time `json:"time" bson:"time"`
+	// Service is the name of the Keptn service that received the call
+	Service string `json:"service" bson:"service"`
+	// Principal identifies who made the call
+	Principal string `json:"principal" bson:"principal"`
+	// ClientIP is the IP address of the client that made the call
+	ClientIP string `json:"clientIP,omitempty" bson:"clientIP,omitempty"`
+	// Action describes what has been done, e.g. 'project.delete'
+	Action string `json:"action" bson:"action"`
+	// TargetType is the type of the object the action has been applied to, e.g. 'project'
+	TargetType string `json:"targetType" bson:"targetType"`
+	// Target identifies the object the action has been applied to
+	Target  string `json:"target,omitempty" bson:"target,omitempty"`
+	Project string `json:"project,omitempty" bson:"project,omitempty"`
+	// Before summarizes the state of the target before the call, if known
+	Before string `json:"before,omitempty" bson:"before,omitempty"`
+	// After summarizes the change requested by the call. Credentials are never included
+	After string `json:"after,omitempty" bson:"after,omitempty"`
+	// RequestID correlates the record with the logs of the API gateway and of the services involved in the call
+	RequestID string `json:"requestID,omitempty" bson:"requestID,omitempty"`
+	// StatusCode is the HTTP status code of the response to the call
+	StatusCode int `json:"statusCode" bson:"statusCode"`
+}
+
+type GetAuditRecordsParams struct {
+	Service    string `form:"service" json:"service"`
+	Principal  string `form:"principal" json:"principal"`
+	Action     string `form:"action" json:"action"`
+	TargetType string `form:"targetType" json:"targetType"`
+	Target     string `form:"target" json:"target"`
+	Project    string `form:"project" json:"project"`
+	RequestID  string `form:"requestID" json:"requestID"`
+	// From only includes records created at or after the given point in time (RFC3339)
+	From string `form:"from" json:"from"`
+	// To only includes records created before the given point in time (RFC3339)
+	To string `form:"to" json:"to"`
+
+	NextPageKey int64 `form:"nextPageKey" json:"nextPageKey"`
+	PageSize    int64 `form:"pageSize" json:"pageSize"`
+}
+
+type GetAuditRecordsResponse struct {
+	// Pointer to next page
+	NextPageKey int64 `json:"nextPageKey,omitempty"`
+
+	// Size of returned page
+	PageSize int64 `json:"pageSize,omitempty"`
+
+	// Total number of records
+	TotalCount int64 `json:"totalCount,omitempty"`
+
+	Records []AuditRecord `json:"records"`
+}
+
+type CreateAuditRecordResponse struct {
+	ID string `json:"id"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/shipyard-controller/common"
+)
+
+// OverrideFreezeSequence is the sequence control state that allows a sequence to be started despite an active freeze window of its stage
+const OverrideFreezeSequence apimodels.SequenceControlState = "overrideFreeze"
+
+// StageFreezeWindow represents a freeze window defined for a stage
+type StageFreezeWindow struct {
+	// Stage is the name of the stage the freeze window is defined for
+	Stage string `json:"stage"`
+	common.FreezeWindow
+	// Active indicates whether the freeze window is currently active
+	Active bool `json:"active"`
+}
+
+// GetSequenceStateResponse contains the states of sequences, as well as the freeze windows defined for the stages of the project
+type GetSequenceStateResponse struct {
+	apimodels.SequenceStates
+	// FreezeWindows contains the freeze windows of the stages of the project
+	FreezeWindows []StageFreezeWindow `json:"freezeWindows,omitempty"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+// Lock is a lease on a named resource that is shared between all replicas of the shipyard-controller
+type Lock struct {
+	// Key identifies the locked resource
+	Key string `json:"key" bson:"_id"`
+	// Owner is the ID of the replica currently holding the lock
+	Owner string `json:"owner" bson:"owner"`
+	// Token is a fencing token which is incremented every time the lock is acquired
+	Token int64 `json:"token" bson:"token"`
+	// AcquiredAt is the time when the lock has been acquired by the current owner
+	AcquiredAt time.Time `json:"acquiredAt" bson:"acquiredAt"`
+	// ExpiresAt is the time after which the lock can be taken over by another owner
+	ExpiresAt time.Time `json:"expiresAt" bson:"expiresAt"`
+}
+
+// IsExpired returns true if the lease of the lock has expired at the given point in time
+func (l Lock) IsExpired(now time.Time) bool {
+	return !now.Before(l.ExpiresAt)
+}
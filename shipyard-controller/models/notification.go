@@ -0,0 +1,205 @@
+package models
+
+import "time"
+
+const (
+	// NotificationChannelWebhook sends the notification as JSON payload to an HTTP endpoint
+	NotificationChannelWebhook = "webhook"
+	// NotificationChannelSlack sends the notification as message to a Slack-compatible incoming webhook
+	NotificationChannelSlack = "slack"
+	// NotificationChannelSMTP sends the notification as email via an SMTP server
+	NotificationChannelSMTP = "smtp"
+)
+
+const (
+	NotificationSequenceTriggered = "sequence.triggered"
+	NotificationSequenceStarted   = "sequence.started"
+	NotificationSequenceWaiting   = "sequence.waiting"
+	NotificationSequenceFinished  = "sequence.finished"
+	NotificationSequenceAborted   = "sequence.aborted"
+	NotificationSequenceTimedOut  = "sequence.timedout"
+	NotificationSequencePaused    = "sequence.paused"
+	NotificationSequenceResumed   = "sequence.resumed"
+)
+
+// NotificationEvents contains the events of a sequence a notification channel can be subscribed to
+var NotificationEvents = []string{
+	NotificationSequenceTriggered,
+	NotificationSequenceStarted,
+	NotificationSequenceWaiting,
+	NotificationSequenceFinished,
+	NotificationSequenceAborted,
+	NotificationSequenceTimedOut,
+	NotificationSequencePaused,
+	NotificationSequenceResumed,
+}
+
+// DefaultNotificationEvents contains the events a notification channel is subscribed to if its filter does not specify any events
+var DefaultNotificationEvents = []string{
+	NotificationSequenceFinished,
+	NotificationSequenceAborted,
+	NotificationSequenceTimedOut,
+}
+
+const (
+	// NotificationDeliveryPending indicates that a notification has not been delivered yet, but will be (re-)tried
+	NotificationDeliveryPending = "pending"
+	// NotificationDeliveryDelivered indicates that a notification has been delivered successfully
+	NotificationDeliveryDelivered = "delivered"
+	// NotificationDeliveryFailed indicates that a notification could not be delivered within the maximum number of attempts
+	NotificationDeliveryFailed = "failed"
+)
+
+// NotificationChannel defines where notifications about the sequences of a project are sent to
+type NotificationChannel struct {
+	ID      string `json:"id" bson:"_id"`
+	Project string `json:"project" bson:"project"`
+	Name    string `json:"name" bson:"name"`
+	// Type is the type of the channel ('webhook', 'slack' or 'smtp')
+	Type string `json:"type" bson:"type"`
+	// URL is the endpoint the notifications are sent to. Only used by 'webhook' and 'slack' channels
+	URL string `json:"url,omitempty" bson:"url,omitempty"`
+	// Headers are added to the requests sent to the URL of the channel. Only used by 'webhook' channels
+	Headers map[string]string `json:"headers,omitempty" bson:"headers,omitempty"`
+	// SMTP contains the settings of the mail server. Only used by 'smtp' channels
+	SMTP      *SMTPSettings      `json:"smtp,omitempty" bson:"smtp,omitempty"`
+	Filter    NotificationFilter `json:"filter" bson:"filter"`
+	CreatedAt time.Time          `json:"createdAt" bson:"createdAt"`
+}
+
+// Redacted returns a copy of the channel that does not contain the password of the mail server
+func (c NotificationChannel) Redacted() NotificationChannel {
+	if c.SMTP != nil {
+		smtpSettings := *c.SMTP
+		smtpSettings.Password = ""
+		c.SMTP = &smtpSettings
+	}
+	return c
+}
+
+// SMTPSettings contains the mail server and the recipients of the notifications sent via an 'smtp' channel
+type SMTPSettings struct {
+	Host     string `json:"host" bson:"host"`
+	Port     int    `json:"port" bson:"port"`
+	Username string `json:"username,omitempty" bson:"username,omitempty"`
+	// Password is never included in API responses. If it is omitted when updating a channel, the stored password is kept
+	Password string   `json:"password,omitempty" bson:"password,omitempty"`
+	From     string   `json:"from" bson:"from"`
+	To       []string `json:"to" bson:"to"`
+}
+
+// NotificationFilter restricts the notifications that are sent via a channel. Empty properties match all notifications
+type NotificationFilter struct {
+	// Events contains the events the channel is subscribed to. If empty, the channel is subscribed to finished, aborted and timed out sequences
+	Events []string `json:"events,omitempty" bson:"events,omitempty"`
+	// Sequences contains the names of the sequences the channel is subscribed to
+	Sequences []string `json:"sequences,omitempty" bson:"sequences,omitempty"`
+	// Stages contains the names of the stages the channel is subscribed to
+	Stages []string `json:"stages,omitempty" bson:"stages,omitempty"`
+	// Results contains the results ('pass', 'warning' or 'fail') the channel is subscribed to. Notifications without a result are not sent if this is set
+	Results []string `json:"results,omitempty" bson:"results,omitempty"`
+}
+
+// Matches checks whether the given notification should be sent via a channel with this filter
+func (f NotificationFilter) Matches(notification Notification) bool {
+	events := f.Events
+	if len(events) == 0 {
+		events = DefaultNotificationEvents
+	}
+	return containsOrEmpty(events, notification.Event) &&
+		containsOrEmpty(f.Sequences, notification.Sequence) &&
+		containsOrEmpty(f.Stages, notification.Stage) &&
+		containsOrEmpty(f.Results, notification.Result)
+}
+
+func containsOrEmpty(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Notification describes an event of a sequence that is sent to the notification channels of its project
+type Notification struct {
+	Event        string    `json:"event" bson:"event"`
+	Project      string    `json:"project" bson:"project"`
+	Stage        string    `json:"stage,omitempty" bson:"stage,omitempty"`
+	Service      string    `json:"service,omitempty" bson:"service,omitempty"`
+	Sequence     string    `json:"sequence,omitempty" bson:"sequence,omitempty"`
+	KeptnContext string    `json:"keptnContext" bson:"keptnContext"`
+	Result       string    `json:"result,omitempty" bson:"result,omitempty"`
+	Status       string    `json:"status,omitempty" bson:"status,omitempty"`
+	Message      string    `json:"message,omitempty" bson:"message,omitempty"`
+	Time         time.Time `json:"time" bson:"time"`
+}
+
+// NotificationDelivery tracks the delivery of a notification via a channel
+type NotificationDelivery struct {
+	ID           string       `json:"id" bson:"_id"`
+	ChannelID    string       `json:"channelId" bson:"channelId"`
+	Project      string       `json:"project" bson:"project"`
+	Notification Notification `json:"notification" bson:"notification"`
+	// Status is the status of the delivery ('pending', 'delivered' or 'failed')
+	Status string `json:"status" bson:"status"`
+	// Attempts is the number of attempts that have been made to deliver the notification
+	Attempts int `json:"attempts" bson:"attempts"`
+	// LastError is the error that occurred during the last failed attempt
+	LastError string `json:"lastError,omitempty" bson:"lastError,omitempty"`
+	// NextAttemptAt is the point in time at which the next attempt is made. It is only set for pending deliveries
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty" bson:"nextAttemptAt,omitempty"`
+	DeliveredAt   *time.Time `json:"deliveredAt,omitempty" bson:"deliveredAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt" bson:"createdAt"`
+}
+
+// NotificationChannelParams contains the properties of a notification channel that can be set when creating or updating it
+type NotificationChannelParams struct {
+	Name    string             `json:"name"`
+	Type    string             `json:"type"`
+	URL     string             `json:"url,omitempty"`
+	Headers map[string]string  `json:"headers,omitempty"`
+	SMTP    *SMTPSettings      `json:"smtp,omitempty"`
+	Filter  NotificationFilter `json:"filter"`
+}
+
+type GetNotificationChannelsResponse struct {
+	Channels []NotificationChannel `json:"channels"`
+}
+
+type CreateNotificationChannelResponse struct {
+	ID string `json:"id"`
+}
+
+type DeleteNotificationChannelResponse struct{}
+
+type GetNotificationDeliveriesParams struct {
+	// Project is the name of the project the notifications belong to
+	Project string `form:"-" json:"-"`
+	// ChannelID is the ID of the channel the notifications have been sent to
+	ChannelID string `form:"channelId" json:"channelId"`
+	// KeptnContext is the keptn context of the sequence the notifications belong to
+	KeptnContext string `form:"keptnContext" json:"keptnContext"`
+	// Status is the status of the deliveries ('pending', 'delivered' or 'failed')
+	Status string `form:"status" json:"status"`
+
+	NextPageKey int64 `form:"nextPageKey" json:"nextPageKey"`
+	PageSize    int64 `form:"pageSize" json:"pageSize"`
+}
+
+type GetNotificationDeliveriesResponse struct {
+	// Pointer to next page
+	NextPageKey int64 `json:"nextPageKey,omitempty"`
+
+	// Size of returned page
+	PageSize int64 `json:"pageSize,omitempty"`
+
+	// Total number of deliveries
+	TotalCount int64 `json:"totalCount,omitempty"`
+
+	// Deliveries contains the matching deliveries, ordered from newest to oldest
+	Deliveries []NotificationDelivery `json:"deliveries"`
+}
@@ -41,6 +41,9 @@ type UpdateProjectParams struct {
 	//Git PEM Certificate
 	GitPemCertificate string `json:"gitPemCertificate,omitempty"`
 
+	// Git provider used to propose changes via pull requests instead of pushing them to the upstream: github, gitlab or gitea
+	GitPullRequestProvider string `json:"gitPullRequestProvider,omitempty"`
+
 	// name
 	Name *string `json:"name"`
 
@@ -86,6 +89,9 @@ type CreateProjectParams struct {
 	//Git PEM Certificate
 	GitPemCertificate string `json:"gitPemCertificate,omitempty"`
 
+	// Git provider used to propose changes via pull requests instead of pushing them to the upstream: github, gitlab or gitea
+	GitPullRequestProvider string `json:"gitPullRequestProvider,omitempty"`
+
 	// name
 	Name *string `json:"name"`
 
@@ -0,0 +1,306 @@
+package models
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+	"time"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+)
+
+// ProjectArchiveVersion is the version of the format of project archives created by the export of a project
+const ProjectArchiveVersion = 1
+
+type ExportProjectParams struct {
+	// IncludeSequences determines whether the execution history of the sequences of the project is included in the archive
+	IncludeSequences bool `form:"includeSequences" json:"includeSequences"`
+}
+
+type ImportProjectParams struct {
+	// Archive is the base64 encoded tar.gz archive created by the export of a project
+	Archive string `json:"archive" binding:"required"`
+
+	// Git remote URL
+	GitRemoteURL string `json:"gitRemoteURL,omitempty"`
+
+	// Git token
+	GitToken string `json:"gitToken,omitempty"`
+
+	// Git user
+	GitUser string `json:"gitUser,omitempty"`
+
+	// Git private key
+	GitPrivateKey string `json:"gitPrivateKey,omitempty"`
+
+	// Git private key passphrase
+	GitPrivateKeyPass string `json:"gitPrivateKeyPass,omitempty"`
+
+	// Git proxy URL
+	GitProxyURL string `json:"gitProxyUrl,omitempty"`
+
+	// Git proxy scheme
+	GitProxyScheme string `json:"gitProxyScheme,omitempty"`
+
+	// Git proxy user
+	GitProxyUser string `json:"gitProxyUser,omitempty"`
+
+	// Git proxy password
+	GitProxyPassword string `json:"gitProxyPassword,omitempty"`
+
+	// Git PEM Certificate
+	GitPemCertificate string `json:"gitPemCertificate,omitempty"`
+
+	// insecure skip tls
+	InsecureSkipTLS bool `json:"insecureSkipTLS"`
+}
+
+type ImportProjectResponse struct {
+	// Warnings contains the parts of the archive that could not be imported, e.g. subscriptions of integrations that are not registered
+	Warnings []string `json:"warnings"`
+	// MissingSecrets contains the secrets of the archive that do not exist in this installation and need to be created manually
+	MissingSecrets []ProjectArchiveSecret `json:"missingSecrets"`
+}
+
+// ProjectArchive is the content of the archive created by the export of a project
+type ProjectArchive struct {
+	Metadata ProjectArchiveMetadata
+	Shipyard []byte
+	// StageResources maps the names of the stages to their resources, excluding the ones of the services
+	StageResources map[string][]ProjectArchiveResource
+	// ServiceResources maps the names of the stages to the names of their services and their resources
+	ServiceResources map[string]map[string][]ProjectArchiveResource
+	Subscriptions    []ProjectArchiveSubscription
+	Secrets          []ProjectArchiveSecret
+	// Sequences contains the execution history of the sequences of the project. It is only set if the sequences have been included in the export
+	Sequences []SequenceExecutionHistory
+}
+
+// ProjectArchiveMetadata describes the project contained in an archive
+type ProjectArchiveMetadata struct {
+	Version    int                   `json:"version"`
+	Project    string                `json:"project"`
+	ExportedAt time.Time             `json:"exportedAt"`
+	Stages     []ProjectArchiveStage `json:"stages"`
+}
+
+type ProjectArchiveStage struct {
+	Name     string   `json:"name"`
+	Services []string `json:"services"`
+}
+
+type ProjectArchiveResource struct {
+	URI     string
+	Content []byte
+}
+
+// ProjectArchiveSubscription is a subscription of an integration to the events of the exported project
+type ProjectArchiveSubscription struct {
+	// Integration is the name of the integration the subscription belongs to
+	Integration string `json:"integration"`
+	Event       string `json:"event"`
+	// Filter contains the stages and services the subscription is restricted to. The project filter is set to the imported project
+	Filter apimodels.EventSubscriptionFilter `json:"filter"`
+}
+
+// ProjectArchiveSecret describes a secret without its values
+type ProjectArchiveSecret struct {
+	Name  string   `json:"name"`
+	Scope string   `json:"scope"`
+	Keys  []string `json:"keys"`
+}
+
+const (
+	projectArchiveMetadataFile      = "metadata.json"
+	projectArchiveShipyardFile      = "shipyard.yaml"
+	projectArchiveSubscriptionsFile = "subscriptions.json"
+	projectArchiveSecretsFile       = "secrets.json"
+	projectArchiveSequencesFile     = "sequences.json"
+	projectArchiveStagesDir         = "stages"
+	projectArchiveServicesDir       = "services"
+	projectArchiveResourcesDir      = "resources"
+)
+
+// ErrInvalidProjectArchive indicates that an archive could not be read, or that it has not been created by the export of a project
+var ErrInvalidProjectArchive = errors.New("invalid project archive")
+
+// WriteProjectArchive creates a tar.gz archive containing the given project. The archive has the following structure:
+//
+//	metadata.json
+//	shipyard.yaml
+//	subscriptions.json
+//	secrets.json
+//	sequences.json (only if the sequences of the project have been exported)
+//	stages/<stage>/resources/<resourceURI>
+//	stages/<stage>/services/<service>/resources/<resourceURI>
+func WriteProjectArchive(archive ProjectArchive) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	modTime := archive.Metadata.ExportedAt
+	writeFile := func(name string, content []byte) error {
+		header := &tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    int64(len(content)),
+			ModTime: modTime,
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return fmt.Errorf("could not write %s to archive: %w", name, err)
+		}
+		if _, err := tarWriter.Write(content); err != nil {
+			return fmt.Errorf("could not write %s to archive: %w", name, err)
+		}
+		return nil
+	}
+	writeJSONFile := func(name string, content interface{}) error {
+		marshalledContent, err := json.MarshalIndent(content, "", "  ")
+		if err != nil {
+			return fmt.Errorf("could not encode %s: %w", name, err)
+		}
+		return writeFile(name, marshalledContent)
+	}
+
+	if err := writeJSONFile(projectArchiveMetadataFile, archive.Metadata); err != nil {
+		return nil, err
+	}
+	if err := writeFile(projectArchiveShipyardFile, archive.Shipyard); err != nil {
+		return nil, err
+	}
+	if err := writeJSONFile(projectArchiveSubscriptionsFile, archive.Subscriptions); err != nil {
+		return nil, err
+	}
+	if err := writeJSONFile(projectArchiveSecretsFile, archive.Secrets); err != nil {
+		return nil, err
+	}
+	if archive.Sequences != nil {
+		if err := writeJSONFile(projectArchiveSequencesFile, archive.Sequences); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, stage := range archive.Metadata.Stages {
+		for _, resource := range archive.StageResources[stage.Name] {
+			if err := writeFile(path.Join(projectArchiveStagesDir, stage.Name, projectArchiveResourcesDir, resource.URI), resource.Content); err != nil {
+				return nil, err
+			}
+		}
+		for _, service := range stage.Services {
+			for _, resource := range archive.ServiceResources[stage.Name][service] {
+				if err := writeFile(path.Join(projectArchiveStagesDir, stage.Name, projectArchiveServicesDir, service, projectArchiveResourcesDir, resource.URI), resource.Content); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return nil, err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ReadProjectArchive reads a tar.gz archive that has been created by WriteProjectArchive
+func ReadProjectArchive(content []byte) (*ProjectArchive, error) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidProjectArchive, err.Error())
+	}
+	defer gzipReader.Close()
+
+	archive := &ProjectArchive{
+		StageResources:   map[string][]ProjectArchiveResource{},
+		ServiceResources: map[string]map[string][]ProjectArchiveResource{},
+		Subscriptions:    []ProjectArchiveSubscription{},
+		Secrets:          []ProjectArchiveSecret{},
+	}
+	hasMetadata := false
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidProjectArchive, err.Error())
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(header.Name)
+		if strings.HasPrefix(name, "/") || strings.Contains(name, "..") {
+			return nil, fmt.Errorf("%w: invalid file name %s", ErrInvalidProjectArchive, header.Name)
+		}
+		fileContent, err := ioutil.ReadAll(tarReader)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidProjectArchive, err.Error())
+		}
+
+		switch name {
+		case projectArchiveMetadataFile:
+			err = json.Unmarshal(fileContent, &archive.Metadata)
+			hasMetadata = true
+		case projectArchiveShipyardFile:
+			archive.Shipyard = fileContent
+		case projectArchiveSubscriptionsFile:
+			err = json.Unmarshal(fileContent, &archive.Subscriptions)
+		case projectArchiveSecretsFile:
+			err = json.Unmarshal(fileContent, &archive.Secrets)
+		case projectArchiveSequencesFile:
+			err = json.Unmarshal(fileContent, &archive.Sequences)
+		default:
+			err = addProjectArchiveResource(archive, name, fileContent)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: could not read %s: %s", ErrInvalidProjectArchive, name, err.Error())
+		}
+	}
+
+	if !hasMetadata {
+		return nil, fmt.Errorf("%w: %s is missing", ErrInvalidProjectArchive, projectArchiveMetadataFile)
+	}
+	if archive.Metadata.Version != ProjectArchiveVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidProjectArchive, archive.Metadata.Version)
+	}
+	if len(archive.Shipyard) == 0 {
+		return nil, fmt.Errorf("%w: %s is missing", ErrInvalidProjectArchive, projectArchiveShipyardFile)
+	}
+	return archive, nil
+}
+
+// addProjectArchiveResource adds the given file of a project archive to the resources of its stage or service
+func addProjectArchiveResource(archive *ProjectArchive, name string, content []byte) error {
+	segments := strings.Split(name, "/")
+	switch {
+	case len(segments) > 3 && segments[0] == projectArchiveStagesDir && segments[2] == projectArchiveResourcesDir:
+		stage := segments[1]
+		archive.StageResources[stage] = append(archive.StageResources[stage], ProjectArchiveResource{
+			URI:     strings.Join(segments[3:], "/"),
+			Content: content,
+		})
+	case len(segments) > 5 && segments[0] == projectArchiveStagesDir && segments[2] == projectArchiveServicesDir && segments[4] == projectArchiveResourcesDir:
+		stage, service := segments[1], segments[3]
+		if archive.ServiceResources[stage] == nil {
+			archive.ServiceResources[stage] = map[string][]ProjectArchiveResource{}
+		}
+		archive.ServiceResources[stage][service] = append(archive.ServiceResources[stage][service], ProjectArchiveResource{
+			URI:     strings.Join(segments[5:], "/"),
+			Content: content,
+		})
+	default:
+		return errors.New("unexpected file")
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+	"time"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectArchive_WriteAndRead(t *testing.T) {
+	archive := ProjectArchive{
+		Metadata: ProjectArchiveMetadata{
+			Version:    ProjectArchiveVersion,
+			Project:    "my-project",
+			ExportedAt: time.Date(2022, 5, 10, 9, 0, 0, 0, time.UTC),
+			Stages: []ProjectArchiveStage{
+				{Name: "dev", Services: []string{"carts"}},
+				{Name: "prod", Services: []string{"carts"}},
+			},
+		},
+		Shipyard: []byte("apiVersion: spec.keptn.sh/0.2.2"),
+		StageResources: map[string][]ProjectArchiveResource{
+			"dev": {{URI: "slo.yaml", Content: []byte("slo")}},
+		},
+		ServiceResources: map[string]map[string][]ProjectArchiveResource{
+			"dev":  {"carts": {{URI: "helm/carts.tgz", Content: []byte{0x1f, 0x8b, 0x00}}}},
+			"prod": {"carts": {{URI: "helm/carts.tgz", Content: []byte{0x1f, 0x8b, 0x01}}, {URI: "sli.yaml", Content: []byte("sli")}}},
+		},
+		Subscriptions: []ProjectArchiveSubscription{
+			{Integration: "helm-service", Event: "sh.keptn.event.deployment.triggered", Filter: apimodels.EventSubscriptionFilter{Projects: []string{"my-project"}, Stages: []string{"dev"}}},
+		},
+		Secrets: []ProjectArchiveSecret{
+			{Name: "dynatrace", Scope: "dynatrace-service", Keys: []string{"DT_API_TOKEN"}},
+		},
+	}
+
+	content, err := WriteProjectArchive(archive)
+	require.Nil(t, err)
+
+	readArchive, err := ReadProjectArchive(content)
+	require.Nil(t, err)
+	require.Equal(t, archive.Metadata, readArchive.Metadata)
+	require.Equal(t, archive.Shipyard, readArchive.Shipyard)
+	require.Equal(t, archive.StageResources, readArchive.StageResources)
+	require.Equal(t, archive.ServiceResources, readArchive.ServiceResources)
+	require.Equal(t, archive.Subscriptions, readArchive.Subscriptions)
+	require.Equal(t, archive.Secrets, readArchive.Secrets)
+	require.Nil(t, readArchive.Sequences)
+
+	// sequences are only part of the archive if they have been exported
+	archive.Sequences = []SequenceExecutionHistory{{Name: "delivery", KeptnContext: "my-context", Tasks: []TaskExecutionHistory{}}}
+	content, err = WriteProjectArchive(archive)
+	require.Nil(t, err)
+
+	readArchive, err = ReadProjectArchive(content)
+	require.Nil(t, err)
+	require.Equal(t, archive.Sequences, readArchive.Sequences)
+}
+
+func TestReadProjectArchive_Invalid(t *testing.T) {
+	createArchive := func(files map[string]string) []byte {
+		buf := &bytes.Buffer{}
+		gzipWriter := gzip.NewWriter(buf)
+		tarWriter := tar.NewWriter(gzipWriter)
+		for name, content := range files {
+			require.Nil(t, tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}))
+			_, err := tarWriter.Write([]byte(content))
+			require.Nil(t, err)
+		}
+		require.Nil(t, tarWriter.Close())
+		require.Nil(t, gzipWriter.Close())
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name    string
+		content []byte
+	}{
+		{
+			name:    "not a tar.gz archive",
+			content: []byte("my-archive"),
+		},
+		{
+			name:    "metadata missing",
+			content: createArchive(map[string]string{"shipyard.yaml": "apiVersion: spec.keptn.sh/0.2.2"}),
+		},
+		{
+			name:    "shipyard missing",
+			content: createArchive(map[string]string{"metadata.json": `{"version": 1, "project": "my-project"}`}),
+		},
+		{
+			name:    "unsupported version",
+			content: createArchive(map[string]string{"metadata.json": `{"version": 2, "project": "my-project"}`, "shipyard.yaml": "apiVersion: spec.keptn.sh/0.2.2"}),
+		},
+		{
+			name:    "unexpected file",
+			content: createArchive(map[string]string{"metadata.json": `{"version": 1, "project": "my-project"}`, "shipyard.yaml": "apiVersion: spec.keptn.sh/0.2.2", "other/file.yaml": ""}),
+		},
+		{
+			name:    "file outside of the archive",
+			content: createArchive(map[string]string{"metadata.json": `{"version": 1, "project": "my-project"}`, "shipyard.yaml": "apiVersion: spec.keptn.sh/0.2.2", "stages/dev/resources/../../../file.yaml": ""}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadProjectArchive(tt.content)
+			require.ErrorIs(t, err, ErrInvalidProjectArchive)
+		})
+	}
+}
@@ -9,6 +9,8 @@ type QueueItem struct {
 	Scope     EventScope `json:"scope" bson:"scope"`
 	EventID   string     `json:"eventID" bson:"eventID"`
 	Timestamp time.Time  `json:"timestamp" bson:"timestamp"`
+	// Priority determines the order in which queued items are dispatched. Items with a higher priority are dispatched first
+	Priority int `json:"priority" bson:"priority"`
 }
 
 type EventQueueSequenceState struct {
@@ -0,0 +1,65 @@
+package models
+
+import "time"
+
+const (
+	// ScheduleOverlapSkip specifies that a scheduled run is skipped if the sequence is still running from a previous run
+	ScheduleOverlapSkip = "skip"
+	// ScheduleOverlapQueue specifies that a scheduled run is queued until the sequence triggered by a previous run has been finished
+	ScheduleOverlapQueue = "queue"
+	// ScheduleOverlapCancel specifies that a running sequence is aborted before the sequence is triggered again
+	ScheduleOverlapCancel = "cancel"
+)
+
+// Schedule triggers a sequence for a service in a stage of a project, either periodically based on a cron expression,
+// or once at a given point in time
+type Schedule struct {
+	ID       string `json:"id" bson:"_id"`
+	Project  string `json:"project" bson:"project"`
+	Stage    string `json:"stage" bson:"stage"`
+	Service  string `json:"service" bson:"service"`
+	Sequence string `json:"sequence" bson:"sequence"`
+	// Cron is the cron expression (evaluated in UTC) defining when the sequence should be triggered
+	Cron string `json:"cron,omitempty" bson:"cron,omitempty"`
+	// RunAt is the point in time at which the sequence should be triggered, if the schedule should only be executed once
+	RunAt *time.Time `json:"runAt,omitempty" bson:"runAt,omitempty"`
+	// OverlapPolicy defines what happens if the sequence is still running when the schedule is due ('skip', 'queue' or 'cancel')
+	OverlapPolicy string `json:"overlapPolicy" bson:"overlapPolicy"`
+	// Labels are added to the events triggering the sequence
+	Labels map[string]string `json:"labels,omitempty" bson:"labels,omitempty"`
+	// NextRunAt is the next point in time at which the sequence will be triggered. If it is not set, the schedule will not be executed anymore
+	NextRunAt *time.Time `json:"nextRunAt,omitempty" bson:"nextRunAt,omitempty"`
+	// LastRunAt is the last point in time at which the schedule has been executed
+	LastRunAt *time.Time `json:"lastRunAt,omitempty" bson:"lastRunAt,omitempty"`
+	// LastKeptnContext is the keptnContext of the sequence triggered by the last execution of the schedule
+	LastKeptnContext string    `json:"lastKeptnContext,omitempty" bson:"lastKeptnContext,omitempty"`
+	CreatedAt        time.Time `json:"createdAt" bson:"createdAt"`
+}
+
+// ScheduleParams contains the properties of a schedule that can be set when creating or updating it
+type ScheduleParams struct {
+	Project       string            `json:"project"`
+	Stage         string            `json:"stage"`
+	Service       string            `json:"service"`
+	Sequence      string            `json:"sequence"`
+	Cron          string            `json:"cron,omitempty"`
+	RunAt         *time.Time        `json:"runAt,omitempty"`
+	OverlapPolicy string            `json:"overlapPolicy,omitempty"`
+	Labels        map[string]string `json:"labels,omitempty"`
+}
+
+type GetSchedulesParams struct {
+	Project string `form:"project" json:"project"`
+	Stage   string `form:"stage" json:"stage"`
+	Service string `form:"service" json:"service"`
+}
+
+type GetSchedulesResponse struct {
+	Schedules []Schedule `json:"schedules"`
+}
+
+type CreateScheduleResponse struct {
+	ID string `json:"id"`
+}
+
+type DeleteScheduleResponse struct{}
@@ -0,0 +1,13 @@
+package models
+
+import apimodels "github.com/keptn/go-utils/pkg/api/models"
+
+// RetrySequence is the sequence control state that triggers a failed sequence again, either from its first failed task, or from a given task
+const RetrySequence apimodels.SequenceControlState = "retry"
+
+// SequenceControlCommand is used to pause, resume, abort or retry a sequence
+type SequenceControlCommand struct {
+	apimodels.SequenceControlCommand
+	// FromTask is the name of the task from which a sequence should be retried. Only used for the 'retry' state
+	FromTask string `json:"fromTask,omitempty"`
+}
@@ -1,9 +1,11 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/go-utils/pkg/common/timeutils"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	"github.com/keptn/keptn/shipyard-controller/common"
 )
@@ -23,6 +25,16 @@ type SequenceExecution struct {
 	// InputProperties contains properties of the event which triggered the task sequence
 	InputProperties map[string]interface{} `json:"inputProperties" bson:"inputProperties"`
 	TriggeredAt     time.Time              `json:"triggeredAt" bson:"triggeredAt"`
+	// Priority determines the order in which queued sequences are dispatched. Sequences with a higher priority are dispatched first
+	Priority int `json:"priority,omitempty" bson:"priority,omitempty"`
+	// Timeout is the maximum duration of the sequence, starting when the sequence has been started, e.g. '2h'
+	Timeout string `json:"timeout,omitempty" bson:"timeout,omitempty"`
+	// TaskAttributes contains the shipyard attributes of the tasks of the sequence, such as their parallel groups, conditions, retry policies and timeouts.
+	// The attributes of a task are located at the same index as the task in Sequence.Tasks
+	TaskAttributes []common.TaskAttributes `json:"taskAttributes,omitempty" bson:"taskAttributes,omitempty"`
+	// FencingToken is the token of the lock on the sequence that is held by the current replica while processing the sequence.
+	// It is not stored as part of the sequence execution, but is used to reject writes of replicas that have lost the lock in the meantime
+	FencingToken int64 `json:"-" bson:"-"`
 }
 
 type SequenceExecutionStatus struct {
@@ -33,6 +45,44 @@ type SequenceExecutionStatus struct {
 	PreviousTasks []TaskExecutionResult `json:"previousTasks" bson:"previousTasks"`
 	// CurrentTask represents the state of the currently active task
 	CurrentTask TaskExecutionState `json:"currentTask" bson:"currentTask"`
+	// ParallelTasks represents the states of the tasks that are active in parallel to the CurrentTask, if the current task is part of a parallel group
+	ParallelTasks []TaskExecutionState `json:"parallelTasks,omitempty" bson:"parallelTasks,omitempty"`
+	// FreezeOverridden indicates that the sequence may be started even if a freeze window of its stage is currently active
+	FreezeOverridden bool `json:"freezeOverridden,omitempty" bson:"freezeOverridden,omitempty"`
+	// StartedAt is the time at which the sequence has been started, i.e. dispatched from the queue
+	StartedAt time.Time `json:"startedAt,omitempty" bson:"startedAt,omitempty"`
+}
+
+// GetCurrentTasks returns the states of all currently active tasks
+func (s SequenceExecutionStatus) GetCurrentTasks() []TaskExecutionState {
+	if s.CurrentTask.Name == "" && s.CurrentTask.TriggeredID == "" {
+		return s.ParallelTasks
+	}
+	return append([]TaskExecutionState{s.CurrentTask}, s.ParallelTasks...)
+}
+
+// IsCurrentTask determines whether the task with the given triggeredID is currently active
+func (s SequenceExecutionStatus) IsCurrentTask(triggeredID string) bool {
+	for _, task := range s.GetCurrentTasks() {
+		if task.TriggeredID == triggeredID {
+			return true
+		}
+	}
+	return false
+}
+
+// AreCurrentTasksFinished indicates whether all currently active tasks are finished
+func (s SequenceExecutionStatus) AreCurrentTasksFinished() bool {
+	currentTasks := s.GetCurrentTasks()
+	if len(currentTasks) == 0 {
+		return false
+	}
+	for _, task := range currentTasks {
+		if !task.IsFinished() {
+			return false
+		}
+	}
+	return true
 }
 
 type TaskExecutionResult struct {
@@ -42,6 +92,32 @@ type TaskExecutionResult struct {
 	Status      keptnv2.StatusType `json:"status" bson:"status"`
 	// Properties contains the aggregated results of the task's executors
 	Properties map[string]interface{} `json:"properties" bson:"properties"`
+	// Attempts contains the unsuccessful attempts of the task that have been retried before reaching this result
+	Attempts []TaskExecutionAttempt `json:"attempts,omitempty" bson:"attempts,omitempty"`
+	// StartedAt is the time of the first .started event of the task
+	StartedAt string `json:"startedAt,omitempty" bson:"startedAt,omitempty"`
+	// FinishedAt is the time of the last .finished event of the task
+	FinishedAt string `json:"finishedAt,omitempty" bson:"finishedAt,omitempty"`
+	// Executors contains the sources of the .started events of the task, i.e. the integrations that executed the task
+	Executors []string `json:"executors,omitempty" bson:"executors,omitempty"`
+}
+
+// TaskExecutionAttempt represents an attempt to execute a task that has been retried
+type TaskExecutionAttempt struct {
+	TriggeredID string             `json:"triggeredID" bson:"triggeredID"`
+	Result      keptnv2.ResultType `json:"result" bson:"result"`
+	Status      keptnv2.StatusType `json:"status" bson:"status"`
+	// FinishedAt is the time of the last .finished event of the attempt
+	FinishedAt string `json:"finishedAt" bson:"finishedAt"`
+}
+
+// TaskRetry contains the information needed to retry a task of a sequence
+type TaskRetry struct {
+	Task keptnv2.Task
+	// TriggeredID is the ID of the .triggered event of the attempt that should be retried
+	TriggeredID string
+	// Backoff is the duration to wait before the task is triggered again
+	Backoff time.Duration
 }
 
 func (r TaskExecutionResult) IsFailed() bool {
@@ -56,25 +132,111 @@ type TaskExecutionState struct {
 	Name        string      `json:"name" bson:"name"`
 	TriggeredID string      `json:"triggeredID" bson:"triggeredID"`
 	Events      []TaskEvent `json:"events" bson:"events"`
+	// Attempts contains the previous attempts of the task, if it has been retried
+	Attempts []TaskExecutionAttempt `json:"attempts,omitempty" bson:"attempts,omitempty"`
 }
 
 // GetNextTaskOfSequence returns the next task of a sequence, based on its current execution state. If no task is remaining, or if a previous task
-// could not be completed successfully, it will return nil.
+// could not be completed successfully, it will return nil. If the next tasks are part of a parallel group, the first task of the group is returned.
 func (e *SequenceExecution) GetNextTaskOfSequence() *keptnv2.Task {
-	if e.GetLastTaskExecutionResult().IsFailed() || e.GetLastTaskExecutionResult().IsErrored() {
+	nextTasks := e.GetNextTasksOfSequence()
+	if len(nextTasks) == 0 {
+		return nil
+	}
+	return &nextTasks[0]
+}
+
+// GetNextTasksOfSequence returns the tasks that should be executed next, based on the current execution state of the sequence.
+// This is either a single task, or all tasks of a parallel group whose conditions are met. Tasks whose conditions are not met are skipped.
+// Once a task has failed or errored, only the remaining tasks that have a condition are considered. If no task is remaining, nil is returned.
+func (e *SequenceExecution) GetNextTasksOfSequence() []keptnv2.Task {
+	taskIndices := e.getNextTaskIndices()
+	if len(taskIndices) == 0 {
 		return nil
 	}
-	nextTaskIndex := 0
-	if e.Status.PreviousTasks != nil && len(e.Status.PreviousTasks) > 0 {
-		nextTaskIndex = len(e.Status.PreviousTasks)
+	tasks := []keptnv2.Task{}
+	for _, taskIndex := range taskIndices {
+		tasks = append(tasks, e.Sequence.Tasks[taskIndex])
 	}
+	return tasks
+}
 
-	if len(e.Sequence.Tasks) > nextTaskIndex {
-		return &e.Sequence.Tasks[nextTaskIndex]
+// getNextTaskIndices returns the indices of the tasks within the sequence that should be executed next, as described in GetNextTasksOfSequence
+func (e *SequenceExecution) getNextTaskIndices() []int {
+	nrCompletedTasks := 0
+	for taskIndex := 0; taskIndex < len(e.Sequence.Tasks); {
+		groupEndIndex := e.getTaskGroupEndIndex(taskIndex)
+
+		// the conditions of a group only depend on the results of the tasks preceding the group
+		previousResults := e.Status.PreviousTasks[:nrCompletedTasks]
+		tasksToExecute := []int{}
+		for ; taskIndex < groupEndIndex; taskIndex++ {
+			if isTaskExecutable(e.GetTaskAttributes(taskIndex), previousResults) {
+				tasksToExecute = append(tasksToExecute, taskIndex)
+			}
+		}
+		if len(tasksToExecute) == 0 {
+			continue
+		}
+		if nrCompletedTasks+len(tasksToExecute) > len(e.Status.PreviousTasks) {
+			return tasksToExecute
+		}
+		nrCompletedTasks += len(tasksToExecute)
 	}
 	return nil
 }
 
+// GetTaskAttributes returns the shipyard attributes of the task at the given index of the sequence
+func (e *SequenceExecution) GetTaskAttributes(taskIndex int) common.TaskAttributes {
+	if taskIndex < 0 || taskIndex >= len(e.TaskAttributes) {
+		return common.TaskAttributes{}
+	}
+	return e.TaskAttributes[taskIndex]
+}
+
+// getTaskGroupEndIndex returns the index following the tasks starting at the given index that are executed together,
+// i.e. either a single task, or consecutive tasks of the same parallel group
+func (e *SequenceExecution) getTaskGroupEndIndex(startIndex int) int {
+	groupName := e.GetTaskAttributes(startIndex).GetParallelGroup()
+	endIndex := startIndex + 1
+	if groupName != "" {
+		for endIndex < len(e.Sequence.Tasks) && e.GetTaskAttributes(endIndex).GetParallelGroup() == groupName {
+			endIndex++
+		}
+	}
+	return endIndex
+}
+
+func isTaskExecutable(taskAttributes common.TaskAttributes, previousResults []TaskExecutionResult) bool {
+	conditionString := taskAttributes.GetCondition()
+	if conditionString == "" {
+		for _, previousResult := range previousResults {
+			if previousResult.IsFailed() || previousResult.IsErrored() {
+				return false
+			}
+		}
+		return true
+	}
+	condition, err := common.ParseTaskCondition(conditionString)
+	if err != nil {
+		// invalid conditions are rejected when the shipyard is validated - if there is one anyway, the task is not executed
+		return false
+	}
+	return condition.Evaluate(func(taskName, property string) string {
+		// if a task has been executed multiple times, the most recent result is used
+		for i := len(previousResults) - 1; i >= 0; i-- {
+			if previousResults[i].Name != taskName {
+				continue
+			}
+			if property == "status" {
+				return string(previousResults[i].Status)
+			}
+			return string(previousResults[i].Result)
+		}
+		return ""
+	})
+}
+
 func (e *SequenceExecution) GetLastTaskExecutionResult() TaskExecutionResult {
 	if len(e.Status.PreviousTasks) == 0 {
 		return TaskExecutionResult{}
@@ -82,18 +244,185 @@ func (e *SequenceExecution) GetLastTaskExecutionResult() TaskExecutionResult {
 	return e.Status.PreviousTasks[len(e.Status.PreviousTasks)-1]
 }
 
-// CompleteCurrentTask completes the current task and appends the aggregated result of the current task to the list of already completed tasks.
+// GetSequenceResult returns the overall result of the sequence, based on the results of its completed tasks.
+// If one of the tasks has failed or errored, this is reflected in the overall result, even if subsequent tasks, e.g. a rollback, were successful.
+// Otherwise, the result of the last task is returned.
+func (e *SequenceExecution) GetSequenceResult() (keptnv2.ResultType, keptnv2.StatusType) {
+	lastResult := e.GetLastTaskExecutionResult()
+	result := lastResult.Result
+	status := lastResult.Status
+	for _, previousTask := range e.Status.PreviousTasks {
+		if previousTask.IsFailed() {
+			result = keptnv2.ResultFailed
+		}
+		if previousTask.IsErrored() {
+			status = keptnv2.StatusErrored
+		}
+	}
+	return result, status
+}
+
+// CanBeRetried determines whether the sequence can be retried. This is the case if the sequence is not active anymore, and has either failed or has been ended before all of its tasks were executed, e.g. because it has been aborted or has timed out
+func (e *SequenceExecution) CanBeRetried() bool {
+	if e.CanBePaused() || e.IsPaused() {
+		return false
+	}
+	result, status := e.GetSequenceResult()
+	return result == keptnv2.ResultFailed || status == keptnv2.StatusErrored || len(e.GetNextTasksOfSequence()) > 0
+}
+
+// GetPreviousTasksForRetry returns the results of the completed tasks that are kept if the sequence is retried from the given task.
+// If no task is given, the sequence is retried from its first failed task, or, if no task has failed, from the first task that has not been executed yet.
+// If the task is part of a parallel group, the whole group is executed again
+func (e *SequenceExecution) GetPreviousTasksForRetry(fromTask string) ([]TaskExecutionResult, error) {
+	retryIndex := len(e.Status.PreviousTasks)
+	for index, previousTask := range e.Status.PreviousTasks {
+		if (fromTask == "" && (previousTask.IsFailed() || previousTask.IsErrored())) || (fromTask != "" && previousTask.Name == fromTask) {
+			retryIndex = index
+			break
+		}
+	}
+
+	if retryIndex == len(e.Status.PreviousTasks) && fromTask != "" && !containsTask(e.GetNextTasksOfSequence(), fromTask) {
+		return nil, fmt.Errorf("task %s has not been executed in sequence %s", fromTask, e.Sequence.Name)
+	}
+
+	keepUntil := retryIndex
+	if retryIndex < len(e.Status.PreviousTasks) {
+		// the results of a parallel group are either kept or discarded as a whole
+		for _, groupStartIndex := range e.getCompletedTaskGroupStartIndices() {
+			if groupStartIndex <= retryIndex {
+				keepUntil = groupStartIndex
+			}
+		}
+	}
+
+	previousTasks := make([]TaskExecutionResult, keepUntil)
+	copy(previousTasks, e.Status.PreviousTasks[:keepUntil])
+	return previousTasks, nil
+}
+
+// getCompletedTaskGroupStartIndices returns the indices of the completed tasks at which the results of a task group (i.e. either a single task, or the tasks of a parallel group) start
+func (e *SequenceExecution) getCompletedTaskGroupStartIndices() []int {
+	startIndices := []int{}
+	nrCompletedTasks := 0
+	for taskIndex := 0; taskIndex < len(e.Sequence.Tasks); {
+		groupEndIndex := e.getTaskGroupEndIndex(taskIndex)
+
+		previousResults := e.Status.PreviousTasks[:nrCompletedTasks]
+		nrExecutedTasks := 0
+		for ; taskIndex < groupEndIndex; taskIndex++ {
+			if isTaskExecutable(e.GetTaskAttributes(taskIndex), previousResults) {
+				nrExecutedTasks++
+			}
+		}
+		if nrExecutedTasks == 0 {
+			continue
+		}
+		if nrCompletedTasks+nrExecutedTasks > len(e.Status.PreviousTasks) {
+			break
+		}
+		startIndices = append(startIndices, nrCompletedTasks)
+		nrCompletedTasks += nrExecutedTasks
+	}
+	return startIndices
+}
+
+func containsTask(tasks []keptnv2.Task, taskName string) bool {
+	for _, task := range tasks {
+		if task.Name == taskName {
+			return true
+		}
+	}
+	return false
+}
+
+// CompleteCurrentTask completes the currently active tasks and appends their aggregated results to the list of already completed tasks.
+// The returned result and status are the combined result of all tasks that have been completed, i.e. if one task of a parallel group has failed, the result is 'fail'
 func (e *SequenceExecution) CompleteCurrentTask() (keptnv2.ResultType, keptnv2.StatusType) {
+	result := keptnv2.ResultPass
+	status := keptnv2.StatusSucceeded
+
+	for _, currentTask := range e.Status.GetCurrentTasks() {
+		executionResult := currentTask.getExecutionResult()
+		if executionResult.IsFailed() {
+			result = keptnv2.ResultFailed
+		} else if executionResult.Result == keptnv2.ResultWarning && result != keptnv2.ResultFailed {
+			result = keptnv2.ResultWarning
+		}
+		if executionResult.IsErrored() {
+			status = keptnv2.StatusErrored
+		}
+		e.Status.PreviousTasks = append(
+			e.Status.PreviousTasks,
+			executionResult,
+		)
+	}
+	e.Status.CurrentTask = TaskExecutionState{}
+	e.Status.ParallelTasks = nil
+	return result, status
+}
+
+// CompleteTimedOutTasks completes the currently active tasks after a timeout has expired. Tasks that have not been finished yet are completed with result 'fail' and status 'errored'
+func (e *SequenceExecution) CompleteTimedOutTasks() {
+	for _, currentTask := range e.Status.GetCurrentTasks() {
+		executionResult := currentTask.getExecutionResult()
+		if !currentTask.IsFinished() {
+			executionResult.Result = keptnv2.ResultFailed
+			executionResult.Status = keptnv2.StatusErrored
+		}
+		e.Status.PreviousTasks = append(e.Status.PreviousTasks, executionResult)
+	}
+	e.Status.CurrentTask = TaskExecutionState{}
+	e.Status.ParallelTasks = nil
+}
+
+// GetExpiredTimeout checks whether the timeout of the sequence, or the timeout of one of its currently active tasks has expired at the given time.
+// If this is the case, the reason of the timeout and a message describing the expired timeout are returned. Otherwise, an empty reason is returned
+func (e *SequenceExecution) GetExpiredTimeout(now time.Time) (SequenceTimeoutReason, string) {
+	if e.Timeout != "" && !e.Status.StartedAt.IsZero() {
+		if timeout, err := common.ParseTimeout(e.Timeout); err == nil && now.After(e.Status.StartedAt.Add(timeout)) {
+			return SequenceTimeoutReasonSequence, fmt.Sprintf("sequence %s timed out after %s", e.Sequence.Name, e.Timeout)
+		}
+	}
+
+	currentTaskIndices := e.getNextTaskIndices()
+	for _, currentTask := range e.Status.GetCurrentTasks() {
+		if currentTask.IsFinished() {
+			continue
+		}
+		taskIndex := e.getTaskIndex(currentTaskIndices, currentTask.Name)
+		if taskIndex < 0 {
+			continue
+		}
+		timeout, err := e.GetTaskAttributes(taskIndex).GetTimeout()
+		if err != nil || timeout == 0 {
+			continue
+		}
+		startedAt, err := timeutils.ParseTimestamp(currentTask.GetStartedAt())
+		if err != nil {
+			// the timeout of a task starts with its first .started event. Tasks that have not been started are covered by the TASK_STARTED_WAIT_DURATION
+			continue
+		}
+		if now.After(startedAt.Add(timeout)) {
+			return SequenceTimeoutReasonTask, fmt.Sprintf("task %s of sequence %s timed out after %s", currentTask.Name, e.Sequence.Name, timeout)
+		}
+	}
+	return "", ""
+}
+
+// getExecutionResult aggregates the events of the task into a TaskExecutionResult
+func (e TaskExecutionState) getExecutionResult() TaskExecutionResult {
 	var result keptnv2.ResultType
 	var status keptnv2.StatusType
-	if e.Status.CurrentTask.IsFailed() {
+	if e.IsFailed() {
 		result = keptnv2.ResultFailed
-	} else if e.Status.CurrentTask.IsWarning() {
+	} else if e.IsWarning() {
 		result = keptnv2.ResultWarning
 	} else {
 		result = keptnv2.ResultPass
 	}
-	if e.Status.CurrentTask.IsErrored() {
+	if e.IsErrored() {
 		status = keptnv2.StatusErrored
 	} else {
 		status = keptnv2.StatusSucceeded
@@ -101,27 +430,131 @@ func (e *SequenceExecution) CompleteCurrentTask() (keptnv2.ResultType, keptnv2.S
 
 	var mergedProperties interface{}
 
-	for _, taskEvent := range e.Status.CurrentTask.Events {
+	for _, taskEvent := range e.Events {
 		if keptnv2.IsFinishedEventType(taskEvent.EventType) && taskEvent.Properties != nil {
 			mergedProperties = common.Merge(mergedProperties, taskEvent.Properties)
 		}
 	}
 
 	executionResult := TaskExecutionResult{
-		Name:        e.Status.CurrentTask.Name,
-		TriggeredID: e.Status.CurrentTask.TriggeredID,
+		Name:        e.Name,
+		TriggeredID: e.TriggeredID,
 		Result:      result,
 		Status:      status,
+		Attempts:    e.Attempts,
+		StartedAt:   e.GetStartedAt(),
+		FinishedAt:  e.GetFinishedAt(),
+		Executors:   e.GetExecutors(),
 	}
 	if mergedPropertiesMap, ok := mergedProperties.(map[string]interface{}); ok {
 		executionResult.Properties = mergedPropertiesMap
 	}
-	e.Status.PreviousTasks = append(
-		e.Status.PreviousTasks,
-		executionResult,
-	)
-	e.Status.CurrentTask = TaskExecutionState{}
-	return result, status
+	return executionResult
+}
+
+// GetTasksToRetry returns the currently active tasks that are finished, but should be retried according to their retry policy
+func (e *SequenceExecution) GetTasksToRetry() []TaskRetry {
+	result := []TaskRetry{}
+	currentTaskIndices := e.getNextTaskIndices()
+	for _, currentTask := range e.Status.GetCurrentTasks() {
+		if !currentTask.IsFinished() {
+			continue
+		}
+		taskIndex := e.getTaskIndex(currentTaskIndices, currentTask.Name)
+		if taskIndex < 0 {
+			continue
+		}
+		retryPolicy := e.GetTaskAttributes(taskIndex).Retry
+		if retryPolicy == nil || len(currentTask.Attempts) >= retryPolicy.MaxRetries {
+			continue
+		}
+		if (currentTask.IsErrored() && retryPolicy.ShouldRetryOn(common.RetryOnErrored)) ||
+			(currentTask.IsFailed() && retryPolicy.ShouldRetryOn(common.RetryOnFailed)) {
+			result = append(result, TaskRetry{
+				Task:        e.Sequence.Tasks[taskIndex],
+				TriggeredID: currentTask.TriggeredID,
+				Backoff:     retryPolicy.GetBackoff(len(currentTask.Attempts) + 1),
+			})
+		}
+	}
+	return result
+}
+
+// getTaskIndex returns the index of the task with the given name among the given task indices, or -1 if none of them has this name
+func (e *SequenceExecution) getTaskIndex(taskIndices []int, name string) int {
+	for _, taskIndex := range taskIndices {
+		if e.Sequence.Tasks[taskIndex].Name == name {
+			return taskIndex
+		}
+	}
+	return -1
+}
+
+// RetryTask records the outcome of the current attempt of the task with the given triggeredID, and resets the state of the task
+// so that it can be executed again using the new triggeredID
+func (e *SequenceExecution) RetryTask(triggeredID, newTriggeredID string) bool {
+	taskState := e.getCurrentTaskState(triggeredID)
+	if taskState == nil {
+		return false
+	}
+	executionResult := taskState.getExecutionResult()
+	attempt := TaskExecutionAttempt{
+		TriggeredID: taskState.TriggeredID,
+		Result:      executionResult.Result,
+		Status:      executionResult.Status,
+	}
+	for _, event := range taskState.Events {
+		if keptnv2.IsFinishedEventType(event.EventType) {
+			attempt.FinishedAt = event.Time
+		}
+	}
+	taskState.Attempts = append(taskState.Attempts, attempt)
+	taskState.TriggeredID = newTriggeredID
+	taskState.Events = []TaskEvent{}
+	return true
+}
+
+// GetTaskAttemptHistory returns the attempt history of all completed and currently active tasks of the sequence execution
+func (e *SequenceExecution) GetTaskAttemptHistory() []TaskAttemptHistory {
+	result := []TaskAttemptHistory{}
+	for _, previousTask := range e.Status.PreviousTasks {
+		result = append(result, TaskAttemptHistory{
+			Stage:       e.Scope.Stage,
+			Task:        previousTask.Name,
+			TriggeredID: previousTask.TriggeredID,
+			Result:      previousTask.Result,
+			Status:      previousTask.Status,
+			Attempts:    previousTask.Attempts,
+		})
+	}
+	for _, currentTask := range e.Status.GetCurrentTasks() {
+		taskHistory := TaskAttemptHistory{
+			Stage:       e.Scope.Stage,
+			Task:        currentTask.Name,
+			TriggeredID: currentTask.TriggeredID,
+			Attempts:    currentTask.Attempts,
+		}
+		// the outcome of a task that is still running is not known yet
+		if currentTask.IsFinished() {
+			executionResult := currentTask.getExecutionResult()
+			taskHistory.Result = executionResult.Result
+			taskHistory.Status = executionResult.Status
+		}
+		result = append(result, taskHistory)
+	}
+	return result
+}
+
+func (e *SequenceExecution) getCurrentTaskState(triggeredID string) *TaskExecutionState {
+	if e.Status.CurrentTask.TriggeredID == triggeredID {
+		return &e.Status.CurrentTask
+	}
+	for index := range e.Status.ParallelTasks {
+		if e.Status.ParallelTasks[index].TriggeredID == triggeredID {
+			return &e.Status.ParallelTasks[index]
+		}
+	}
+	return nil
 }
 
 // GetNextTriggeredEventData generates a map representing the event payload for the next task.triggered event. For this, it will merge the following properties:
@@ -129,6 +562,12 @@ func (e *SequenceExecution) CompleteCurrentTask() (keptnv2.ResultType, keptnv2.S
 // - The properties of the task, defined in the sequence definition
 // - The results of the already completed tasks of the sequence
 func (e *SequenceExecution) GetNextTriggeredEventData() map[string]interface{} {
+	return e.GetTriggeredEventDataForTask(e.GetNextTaskOfSequence())
+}
+
+// GetTriggeredEventDataForTask generates a map representing the event payload for the task.triggered event of the given task.
+// This is needed for tasks of a parallel group, since each one of them has its own properties
+func (e *SequenceExecution) GetTriggeredEventDataForTask(nextTask *keptnv2.Task) map[string]interface{} {
 	eventPayload := map[string]interface{}{}
 
 	if e.InputProperties != nil {
@@ -149,7 +588,6 @@ func (e *SequenceExecution) GetNextTriggeredEventData() map[string]interface{} {
 		eventPayload["status"] = e.Status.PreviousTasks[lastTaskIndex].Status
 	}
 
-	nextTask := e.GetNextTaskOfSequence()
 	if nextTask != nil && nextTask.Properties != nil {
 		eventPayload[nextTask.Name] = common.Merge(eventPayload[nextTask.Name], nextTask.Properties)
 	}
@@ -190,6 +628,16 @@ func (e *SequenceExecution) Resume() bool {
 	return true
 }
 
+// OverrideFreeze allows the sequence execution to be started despite an active freeze window. If the override has been applied, true is returned.
+// If the sequence is not active anymore, or the freeze has already been overridden, false is returned
+func (e *SequenceExecution) OverrideFreeze() bool {
+	if e.Status.FreezeOverridden || !(e.CanBePaused() || e.IsPaused()) {
+		return false
+	}
+	e.Status.FreezeOverridden = true
+	return true
+}
+
 // SetNextCurrentTask updates the Current task of the sequence and sets the current state appropriately, considering the special logic that should be applied for approval tasks
 func (e *SequenceExecution) SetNextCurrentTask(taskName, triggeredEventID string) {
 	e.Status.CurrentTask = TaskExecutionState{
@@ -197,11 +645,28 @@ func (e *SequenceExecution) SetNextCurrentTask(taskName, triggeredEventID string
 		TriggeredID: triggeredEventID,
 		Events:      []TaskEvent{},
 	}
+	e.Status.ParallelTasks = nil
+	e.updateStateForTask(taskName)
+}
 
+// AddParallelTask adds a task that is executed in parallel to the current task of the sequence
+func (e *SequenceExecution) AddParallelTask(taskName, triggeredEventID string) {
+	e.Status.ParallelTasks = append(e.Status.ParallelTasks, TaskExecutionState{
+		Name:        taskName,
+		TriggeredID: triggeredEventID,
+		Events:      []TaskEvent{},
+	})
+	e.updateStateForTask(taskName)
+}
+
+func (e *SequenceExecution) updateStateForTask(taskName string) {
 	// special handling for approval events
 	nextState := models.SequenceStartedState
 	if taskName == keptnv2.ApprovalTaskName {
 		nextState = models.SequenceWaitingForApprovalState
+	} else if len(e.Status.ParallelTasks) > 0 && e.getCurrentState() == models.SequenceWaitingForApprovalState {
+		// if an approval task is part of the current parallel group, the sequence keeps waiting for the approval
+		return
 	}
 
 	if e.IsPaused() {
@@ -211,6 +676,13 @@ func (e *SequenceExecution) SetNextCurrentTask(taskName, triggeredEventID string
 	}
 }
 
+func (e *SequenceExecution) getCurrentState() string {
+	if e.IsPaused() {
+		return e.Status.StateBeforePause
+	}
+	return e.Status.State
+}
+
 // IsFinished indicates if a task is finished, i.e. the number of task.started and task.finished events line up
 func (e *TaskExecutionState) IsFinished() bool {
 	if len(e.Events) == 0 {
@@ -276,13 +748,74 @@ func (e *TaskExecutionState) IsErrored() bool {
 	return false
 }
 
+// GetStartedAt returns the time of the first .started event of the task
+func (e *TaskExecutionState) GetStartedAt() string {
+	for _, event := range e.Events {
+		if keptnv2.IsStartedEventType(event.EventType) {
+			return event.Time
+		}
+	}
+	return ""
+}
+
+// GetFinishedAt returns the time of the last .finished event of the task, or an empty string if the task is not finished yet
+func (e *TaskExecutionState) GetFinishedAt() string {
+	if !e.IsFinished() {
+		return ""
+	}
+	for i := len(e.Events) - 1; i >= 0; i-- {
+		if keptnv2.IsFinishedEventType(e.Events[i].EventType) {
+			return e.Events[i].Time
+		}
+	}
+	return ""
+}
+
+// GetExecutors returns the sources of the .started events of the task
+func (e *TaskExecutionState) GetExecutors() []string {
+	var executors []string
+	for _, event := range e.Events {
+		if keptnv2.IsStartedEventType(event.EventType) && event.Source != "" && !containsString(executors, event.Source) {
+			executors = append(executors, event.Source)
+		}
+	}
+	return executors
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 type TaskEvent struct {
-	EventType  string                 `json:"eventType" bson:"eventType"`
-	Source     string                 `json:"source" bson:"source"`
-	Result     keptnv2.ResultType     `json:"result" bson:"result"`
-	Status     keptnv2.StatusType     `json:"status" bson:"status"`
-	Time       string                 `json:"time" bson:"time"`
-	Properties map[string]interface{} `json:"properties" bson:"properties"`
+	// TriggeredID is the ID of the task.triggered event the event is related to
+	TriggeredID string                 `json:"triggeredID,omitempty" bson:"triggeredID,omitempty"`
+	EventType   string                 `json:"eventType" bson:"eventType"`
+	Source      string                 `json:"source" bson:"source"`
+	Result      keptnv2.ResultType     `json:"result" bson:"result"`
+	Status      keptnv2.StatusType     `json:"status" bson:"status"`
+	Time        string                 `json:"time" bson:"time"`
+	Properties  map[string]interface{} `json:"properties" bson:"properties"`
+}
+
+// TaskAttemptHistory contains the outcome of a task of a sequence, as well as its previous attempts if the task has been retried
+type TaskAttemptHistory struct {
+	Stage       string             `json:"stage"`
+	Task        string             `json:"task"`
+	TriggeredID string             `json:"triggeredID"`
+	Result      keptnv2.ResultType `json:"result"`
+	Status      keptnv2.StatusType `json:"status"`
+	// Attempts contains the previous, unsuccessful attempts of the task
+	Attempts []TaskExecutionAttempt `json:"attempts,omitempty"`
+}
+
+// GetTaskAttemptsResponse is the response of the endpoint for retrieving the attempt history of the tasks of a sequence
+type GetTaskAttemptsResponse struct {
+	Tasks []TaskAttemptHistory `json:"tasks"`
 }
 
 type SequenceExecutionFilter struct {
@@ -290,7 +823,10 @@ type SequenceExecutionFilter struct {
 	Status             []string
 	Name               string
 	CurrentTriggeredID string
-	TriggeredAt        time.Time
+	// TriggeredAt only matches sequence executions that have been triggered before the given time
+	TriggeredAt time.Time
+	// TriggeredAfter only matches sequence executions that have been triggered at or after the given time
+	TriggeredAfter time.Time
 }
 
 type SequenceExecutionUpsertOptions struct {
@@ -0,0 +1,151 @@
+package models
+
+import (
+	"time"
+
+	"github.com/keptn/go-utils/pkg/common/timeutils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+type GetSequenceExecutionParams struct {
+	// Project is the name of the project the sequence executions belong to
+	Project string `form:"project" json:"project"`
+	// Stage is the name of the stage the sequence executions have been executed in
+	Stage string `form:"stage" json:"stage"`
+	// Service is the name of the service the sequence executions have been executed for
+	Service string `form:"service" json:"service"`
+	// Name is the name of the sequence
+	Name string `form:"name" json:"name"`
+	// State is a comma separated list of the states of the sequence executions, e.g. "finished,aborted"
+	State string `form:"state" json:"state"`
+	// KeptnContext is the keptn context of the sequence executions
+	KeptnContext string `form:"keptnContext" json:"keptnContext"`
+	// FromTime only includes sequence executions that have been triggered at or after the given time
+	FromTime string `form:"fromTime" json:"fromTime"`
+	// BeforeTime only includes sequence executions that have been triggered before the given time
+	BeforeTime string `form:"beforeTime" json:"beforeTime"`
+
+	NextPageKey int64 `form:"nextPageKey" json:"nextPageKey"`
+	PageSize    int64 `form:"pageSize" json:"pageSize"`
+}
+
+type GetSequenceExecutionResponse struct {
+	// Pointer to next page
+	NextPageKey int64 `json:"nextPageKey,omitempty"`
+
+	// Size of returned page
+	PageSize int64 `json:"pageSize,omitempty"`
+
+	// Total number of sequence executions
+	TotalCount int64 `json:"totalCount,omitempty"`
+
+	// SequenceExecutions contains the matching sequence executions, ordered from newest to oldest
+	SequenceExecutions []SequenceExecutionHistory `json:"sequenceExecutions"`
+}
+
+// SequenceExecutionHistory describes the execution of a sequence, including the timing and results of its tasks
+type SequenceExecutionHistory struct {
+	ID           string             `json:"id"`
+	Name         string             `json:"name"`
+	Project      string             `json:"project"`
+	Stage        string             `json:"stage"`
+	Service      string             `json:"service"`
+	KeptnContext string             `json:"keptnContext"`
+	State        string             `json:"state"`
+	Result       keptnv2.ResultType `json:"result,omitempty"`
+	Status       keptnv2.StatusType `json:"status,omitempty"`
+	TriggeredAt  time.Time          `json:"triggeredAt"`
+	// FinishedAt is the time at which the last task of the sequence has been finished. It is only set for sequences that are not active anymore
+	FinishedAt string `json:"finishedAt,omitempty"`
+	// DurationSeconds is the time between the triggering of the sequence and its last finished task. It is only set for sequences that are not active anymore
+	DurationSeconds float64 `json:"durationSeconds,omitempty"`
+	// Tasks contains the completed tasks of the sequence, followed by the currently active ones
+	Tasks []TaskExecutionHistory `json:"tasks"`
+}
+
+// TaskExecutionHistory describes the execution of a task of a sequence
+type TaskExecutionHistory struct {
+	Name        string             `json:"name"`
+	TriggeredID string             `json:"triggeredID"`
+	Result      keptnv2.ResultType `json:"result,omitempty"`
+	Status      keptnv2.StatusType `json:"status,omitempty"`
+	// Active indicates that the task has not been completed yet
+	Active     bool   `json:"active,omitempty"`
+	StartedAt  string `json:"startedAt,omitempty"`
+	FinishedAt string `json:"finishedAt,omitempty"`
+	// DurationSeconds is the time between the first .started event and the last .finished event of the task
+	DurationSeconds float64 `json:"durationSeconds,omitempty"`
+	// Executors contains the names of the integrations that executed the task
+	Executors []string `json:"executors,omitempty"`
+	// Properties contains the merged properties of the .finished events of the task
+	Properties map[string]interface{} `json:"properties,omitempty"`
+	// Attempts contains the unsuccessful attempts of the task that have been retried
+	Attempts []TaskExecutionAttempt `json:"attempts,omitempty"`
+}
+
+// NewSequenceExecutionHistory creates the history of the given sequence execution
+func NewSequenceExecutionHistory(e SequenceExecution) SequenceExecutionHistory {
+	history := SequenceExecutionHistory{
+		ID:           e.ID,
+		Name:         e.Sequence.Name,
+		Project:      e.Scope.Project,
+		Stage:        e.Scope.Stage,
+		Service:      e.Scope.Service,
+		KeptnContext: e.Scope.KeptnContext,
+		State:        e.Status.State,
+		TriggeredAt:  e.TriggeredAt,
+		Tasks:        []TaskExecutionHistory{},
+	}
+
+	for _, previousTask := range e.Status.PreviousTasks {
+		history.Tasks = append(history.Tasks, TaskExecutionHistory{
+			Name:            previousTask.Name,
+			TriggeredID:     previousTask.TriggeredID,
+			Result:          previousTask.Result,
+			Status:          previousTask.Status,
+			StartedAt:       previousTask.StartedAt,
+			FinishedAt:      previousTask.FinishedAt,
+			DurationSeconds: getDurationSeconds(previousTask.StartedAt, previousTask.FinishedAt),
+			Executors:       previousTask.Executors,
+			Properties:      previousTask.Properties,
+			Attempts:        previousTask.Attempts,
+		})
+	}
+
+	for _, currentTask := range e.Status.GetCurrentTasks() {
+		history.Tasks = append(history.Tasks, TaskExecutionHistory{
+			Name:        currentTask.Name,
+			TriggeredID: currentTask.TriggeredID,
+			Active:      true,
+			StartedAt:   currentTask.GetStartedAt(),
+			Executors:   currentTask.GetExecutors(),
+			Attempts:    currentTask.Attempts,
+		})
+	}
+
+	if len(e.Status.PreviousTasks) > 0 {
+		history.Result, history.Status = e.GetSequenceResult()
+	}
+
+	// the sequence is not active anymore if it can neither be paused nor resumed
+	if !e.CanBePaused() && !e.IsPaused() && len(e.Status.GetCurrentTasks()) == 0 {
+		history.FinishedAt = e.GetLastTaskExecutionResult().FinishedAt
+		if finishedAt, err := timeutils.ParseTimestamp(history.FinishedAt); err == nil && !e.TriggeredAt.IsZero() {
+			history.DurationSeconds = finishedAt.Sub(e.TriggeredAt).Seconds()
+		}
+	}
+	return history
+}
+
+// getDurationSeconds returns the number of seconds between the given timestamps, or 0 if one of them cannot be parsed
+func getDurationSeconds(from, to string) float64 {
+	fromTime, err := timeutils.ParseTimestamp(from)
+	if err != nil {
+		return 0
+	}
+	toTime, err := timeutils.ParseTimestamp(to)
+	if err != nil {
+		return 0
+	}
+	return toTime.Sub(*fromTime).Seconds()
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskExecutionState_Timing(t *testing.T) {
+	task := TaskExecutionState{
+		Name: "deployment",
+		Events: []TaskEvent{
+			{EventType: keptnv2.GetStartedEventType("deployment"), Source: "helm-service", Time: "2021-04-21T17:00:01.000Z"},
+			{EventType: keptnv2.GetStartedEventType("deployment"), Source: "job-executor-service", Time: "2021-04-21T17:00:02.000Z"},
+			{EventType: keptnv2.GetFinishedEventType("deployment"), Source: "helm-service", Time: "2021-04-21T17:00:10.000Z"},
+		},
+	}
+
+	require.Equal(t, "2021-04-21T17:00:01.000Z", task.GetStartedAt())
+	require.Equal(t, []string{"helm-service", "job-executor-service"}, task.GetExecutors())
+	// not all executors have finished the task yet
+	require.Empty(t, task.GetFinishedAt())
+
+	task.Events = append(task.Events, TaskEvent{EventType: keptnv2.GetFinishedEventType("deployment"), Source: "job-executor-service", Time: "2021-04-21T17:00:20.000Z"})
+	require.Equal(t, "2021-04-21T17:00:20.000Z", task.GetFinishedAt())
+}
+
+func TestNewSequenceExecutionHistory(t *testing.T) {
+	triggeredAt := time.Date(2021, 4, 21, 17, 0, 0, 0, time.UTC)
+	sequenceExecution := SequenceExecution{
+		ID:       "my-id",
+		Sequence: keptnv2.Sequence{Name: "delivery"},
+		Status: SequenceExecutionStatus{
+			State: models.SequenceFinished,
+			PreviousTasks: []TaskExecutionResult{
+				{
+					Name:        "deployment",
+					TriggeredID: "deployment-id",
+					Result:      keptnv2.ResultPass,
+					Status:      keptnv2.StatusSucceeded,
+					StartedAt:   "2021-04-21T17:00:01.000Z",
+					FinishedAt:  "2021-04-21T17:00:11.000Z",
+					Executors:   []string{"helm-service"},
+					Properties:  map[string]interface{}{"deploymentURI": "my-url"},
+				},
+				{
+					Name:        "evaluation",
+					TriggeredID: "evaluation-id",
+					Result:      keptnv2.ResultWarning,
+					Status:      keptnv2.StatusSucceeded,
+					StartedAt:   "2021-04-21T17:00:12.000Z",
+					FinishedAt:  "2021-04-21T17:00:30.000Z",
+					Executors:   []string{"lighthouse-service"},
+				},
+			},
+		},
+		Scope: EventScope{
+			EventData:    keptnv2.EventData{Project: "my-project", Stage: "dev", Service: "my-service"},
+			KeptnContext: "my-context",
+		},
+		TriggeredAt: triggeredAt,
+	}
+
+	history := NewSequenceExecutionHistory(sequenceExecution)
+
+	require.Equal(t, "delivery", history.Name)
+	require.Equal(t, "my-project", history.Project)
+	require.Equal(t, "dev", history.Stage)
+	require.Equal(t, "my-service", history.Service)
+	require.Equal(t, "my-context", history.KeptnContext)
+	require.Equal(t, keptnv2.ResultWarning, history.Result)
+	require.Equal(t, "2021-04-21T17:00:30.000Z", history.FinishedAt)
+	require.Equal(t, float64(30), history.DurationSeconds)
+
+	require.Len(t, history.Tasks, 2)
+	require.Equal(t, float64(10), history.Tasks[0].DurationSeconds)
+	require.Equal(t, []string{"helm-service"}, history.Tasks[0].Executors)
+	require.Equal(t, "my-url", history.Tasks[0].Properties["deploymentURI"])
+	require.Equal(t, float64(18), history.Tasks[1].DurationSeconds)
+
+	// a running sequence has no finish time yet, and contains its active task
+	sequenceExecution.Status.State = models.SequenceStartedState
+	sequenceExecution.Status.CurrentTask = TaskExecutionState{
+		Name:        "release",
+		TriggeredID: "release-id",
+		Events: []TaskEvent{
+			{EventType: keptnv2.GetStartedEventType("release"), Source: "helm-service", Time: "2021-04-21T17:00:31.000Z"},
+		},
+	}
+
+	history = NewSequenceExecutionHistory(sequenceExecution)
+
+	require.Empty(t, history.FinishedAt)
+	require.Zero(t, history.DurationSeconds)
+	require.Len(t, history.Tasks, 3)
+	require.True(t, history.Tasks[2].Active)
+	require.Equal(t, "2021-04-21T17:00:31.000Z", history.Tasks[2].StartedAt)
+	require.Equal(t, []string{"helm-service"}, history.Tasks[2].Executors)
+}
@@ -3,9 +3,11 @@ package models
 import (
 	"github.com/keptn/go-utils/pkg/api/models"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/stretchr/testify/require"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestSequenceExecution_GetNextTriggeredEventData(t *testing.T) {
@@ -336,6 +338,7 @@ func TestSequenceExecution_CompleteCurrentTask(t *testing.T) {
 					TriggeredID: "my-triggered-id",
 					Result:      keptnv2.ResultPass,
 					Status:      keptnv2.StatusSucceeded,
+					Executors:   []string{"my-service"},
 					Properties: map[string]interface{}{
 						"deploymentURI": "my-deployment-uri",
 					},
@@ -392,6 +395,7 @@ func TestSequenceExecution_CompleteCurrentTask(t *testing.T) {
 					TriggeredID: "my-triggered-id",
 					Result:      keptnv2.ResultPass,
 					Status:      keptnv2.StatusSucceeded,
+					Executors:   []string{"my-service", "my-second-service"},
 					Properties: map[string]interface{}{
 						"deploymentURI": "my-deployment-uri",
 						"otherProperty": "otherValue",
@@ -449,6 +453,7 @@ func TestSequenceExecution_CompleteCurrentTask(t *testing.T) {
 					TriggeredID: "my-triggered-id",
 					Result:      keptnv2.ResultFailed,
 					Status:      keptnv2.StatusSucceeded,
+					Executors:   []string{"my-service", "my-second-service"},
 					Properties: map[string]interface{}{
 						"deploymentURI": "my-deployment-uri",
 						"otherProperty": "otherValue",
@@ -506,6 +511,7 @@ func TestSequenceExecution_CompleteCurrentTask(t *testing.T) {
 					TriggeredID: "my-triggered-id",
 					Result:      keptnv2.ResultWarning,
 					Status:      keptnv2.StatusSucceeded,
+					Executors:   []string{"my-service", "my-second-service"},
 					Properties: map[string]interface{}{
 						"deploymentURI": "my-deployment-uri",
 						"otherProperty": "otherValue",
@@ -563,6 +569,7 @@ func TestSequenceExecution_CompleteCurrentTask(t *testing.T) {
 					TriggeredID: "my-triggered-id",
 					Result:      keptnv2.ResultFailed,
 					Status:      keptnv2.StatusErrored,
+					Executors:   []string{"my-service", "my-second-service"},
 					Properties: map[string]interface{}{
 						"deploymentURI": "my-deployment-uri",
 						"otherProperty": "otherValue",
@@ -616,6 +623,7 @@ func TestSequenceExecution_CompleteCurrentTask(t *testing.T) {
 					TriggeredID: "my-triggered-id",
 					Result:      keptnv2.ResultFailed,
 					Status:      keptnv2.StatusErrored,
+					Executors:   []string{"my-service", "my-second-service"},
 					Properties:  nil,
 				},
 			},
@@ -979,6 +987,42 @@ func TestSequenceExecution_Resume(t *testing.T) {
 	}
 }
 
+func TestSequenceExecution_OverrideFreeze(t *testing.T) {
+	tests := []struct {
+		name   string
+		status SequenceExecutionStatus
+		want   bool
+	}{
+		{
+			name:   "override freeze of waiting sequence",
+			status: SequenceExecutionStatus{State: models.SequenceWaitingState},
+			want:   true,
+		},
+		{
+			name:   "override freeze of paused sequence",
+			status: SequenceExecutionStatus{State: models.SequencePaused, StateBeforePause: models.SequenceWaitingState},
+			want:   true,
+		},
+		{
+			name:   "freeze has already been overridden",
+			status: SequenceExecutionStatus{State: models.SequenceWaitingState, FreezeOverridden: true},
+			want:   false,
+		},
+		{
+			name:   "sequence has already been finished",
+			status: SequenceExecutionStatus{State: models.SequenceFinished},
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &SequenceExecution{Status: tt.status}
+			require.Equal(t, tt.want, e.OverrideFreeze())
+			require.Equal(t, tt.want || tt.status.FreezeOverridden, e.Status.FreezeOverridden)
+		})
+	}
+}
+
 func TestTaskExecutionState_IsFinished(t *testing.T) {
 	type fields struct {
 		Name        string
@@ -1236,3 +1280,672 @@ func TestSequenceExecution_SetNextCurrentTask(t *testing.T) {
 		})
 	}
 }
+
+// shipyardTask is a task of a sequence, together with its shipyard attributes
+type shipyardTask struct {
+	keptnv2.Task
+	common.TaskAttributes
+}
+
+// newShipyardTaskSequenceExecution creates a sequence execution for a sequence consisting of the given tasks
+func newShipyardTaskSequenceExecution(sequenceName string, tasks ...shipyardTask) *SequenceExecution {
+	e := &SequenceExecution{Sequence: keptnv2.Sequence{Name: sequenceName, Tasks: []keptnv2.Task{}}}
+	for _, task := range tasks {
+		e.Sequence.Tasks = append(e.Sequence.Tasks, task.Task)
+		e.TaskAttributes = append(e.TaskAttributes, task.TaskAttributes)
+	}
+	return e
+}
+
+func TestSequenceExecution_GetNextTasksOfSequence(t *testing.T) {
+	task := func(name string) shipyardTask {
+		return shipyardTask{Task: keptnv2.Task{Name: name}}
+	}
+	parallelTask := func(name, group string) shipyardTask {
+		return shipyardTask{Task: keptnv2.Task{Name: name}, TaskAttributes: common.TaskAttributes{Parallel: group}}
+	}
+	conditionalTask := func(name, condition string) shipyardTask {
+		return shipyardTask{Task: keptnv2.Task{Name: name}, TaskAttributes: common.TaskAttributes{When: condition}}
+	}
+	result := func(name string, result keptnv2.ResultType, status keptnv2.StatusType) TaskExecutionResult {
+		return TaskExecutionResult{Name: name, Result: result, Status: status}
+	}
+
+	tests := []struct {
+		name          string
+		tasks         []shipyardTask
+		previousTasks []TaskExecutionResult
+		want          []string
+	}{
+		{
+			name: "tasks of a parallel group are returned together",
+			tasks: []shipyardTask{
+				task("deployment"),
+				parallelTask("test", "verification"),
+				parallelTask("security-scan", "verification"),
+				task("evaluation"),
+			},
+			previousTasks: []TaskExecutionResult{
+				result("deployment", keptnv2.ResultPass, keptnv2.StatusSucceeded),
+			},
+			want: []string{"test", "security-scan"},
+		},
+		{
+			name: "task after parallel group is returned once all tasks of the group are completed",
+			tasks: []shipyardTask{
+				task("deployment"),
+				parallelTask("test", "verification"),
+				parallelTask("security-scan", "verification"),
+				task("evaluation"),
+			},
+			previousTasks: []TaskExecutionResult{
+				result("deployment", keptnv2.ResultPass, keptnv2.StatusSucceeded),
+				result("test", keptnv2.ResultPass, keptnv2.StatusSucceeded),
+				result("security-scan", keptnv2.ResultPass, keptnv2.StatusSucceeded),
+			},
+			want: []string{"evaluation"},
+		},
+		{
+			name: "different groups are executed one after another",
+			tasks: []shipyardTask{
+				parallelTask("test", "verification"),
+				parallelTask("security-scan", "verification"),
+				parallelTask("release", "release"),
+				parallelTask("notify", "release"),
+			},
+			previousTasks: []TaskExecutionResult{
+				result("test", keptnv2.ResultPass, keptnv2.StatusSucceeded),
+				result("security-scan", keptnv2.ResultPass, keptnv2.StatusSucceeded),
+			},
+			want: []string{"release", "notify"},
+		},
+		{
+			name: "failed task of a parallel group stops the sequence",
+			tasks: []shipyardTask{
+				parallelTask("test", "verification"),
+				parallelTask("security-scan", "verification"),
+				task("release"),
+			},
+			previousTasks: []TaskExecutionResult{
+				result("test", keptnv2.ResultPass, keptnv2.StatusSucceeded),
+				result("security-scan", keptnv2.ResultFailed, keptnv2.StatusSucceeded),
+			},
+			want: nil,
+		},
+		{
+			name: "condition is met",
+			tasks: []shipyardTask{
+				task("evaluation"),
+				conditionalTask("approval", "evaluation.result == warning"),
+				task("release"),
+			},
+			previousTasks: []TaskExecutionResult{
+				result("evaluation", keptnv2.ResultWarning, keptnv2.StatusSucceeded),
+			},
+			want: []string{"approval"},
+		},
+		{
+			name: "condition is not met - task is skipped",
+			tasks: []shipyardTask{
+				task("evaluation"),
+				conditionalTask("approval", "evaluation.result == warning"),
+				task("release"),
+			},
+			previousTasks: []TaskExecutionResult{
+				result("evaluation", keptnv2.ResultPass, keptnv2.StatusSucceeded),
+			},
+			want: []string{"release"},
+		},
+		{
+			name: "skipped task is not counted as completed task",
+			tasks: []shipyardTask{
+				task("evaluation"),
+				conditionalTask("approval", "evaluation.result == warning"),
+				task("release"),
+			},
+			previousTasks: []TaskExecutionResult{
+				result("evaluation", keptnv2.ResultPass, keptnv2.StatusSucceeded),
+				result("release", keptnv2.ResultPass, keptnv2.StatusSucceeded),
+			},
+			want: nil,
+		},
+		{
+			name: "conditional task is executed after a failed task",
+			tasks: []shipyardTask{
+				task("evaluation"),
+				task("release"),
+				conditionalTask("rollback", "evaluation.result == fail"),
+			},
+			previousTasks: []TaskExecutionResult{
+				result("evaluation", keptnv2.ResultFailed, keptnv2.StatusSucceeded),
+			},
+			want: []string{"rollback"},
+		},
+		{
+			name: "tasks without condition are not executed after a failed task, even if a conditional task succeeded",
+			tasks: []shipyardTask{
+				task("evaluation"),
+				conditionalTask("rollback", "evaluation.result == fail"),
+				task("release"),
+			},
+			previousTasks: []TaskExecutionResult{
+				result("evaluation", keptnv2.ResultFailed, keptnv2.StatusSucceeded),
+				result("rollback", keptnv2.ResultPass, keptnv2.StatusSucceeded),
+			},
+			want: nil,
+		},
+		{
+			name: "conditions can be combined",
+			tasks: []shipyardTask{
+				task("test"),
+				task("evaluation"),
+				conditionalTask("approval", "evaluation.result == warning || test.status == errored && evaluation.result != fail"),
+			},
+			previousTasks: []TaskExecutionResult{
+				result("test", keptnv2.ResultPass, keptnv2.StatusSucceeded),
+				result("evaluation", keptnv2.ResultPass, keptnv2.StatusSucceeded),
+			},
+			want: nil,
+		},
+		{
+			name: "conditional tasks within a parallel group",
+			tasks: []shipyardTask{
+				task("evaluation"),
+				{Task: keptnv2.Task{Name: "release"}, TaskAttributes: common.TaskAttributes{Parallel: "post-evaluation", When: "evaluation.result == pass"}},
+				parallelTask("notify", "post-evaluation"),
+			},
+			previousTasks: []TaskExecutionResult{
+				result("evaluation", keptnv2.ResultPass, keptnv2.StatusSucceeded),
+			},
+			want: []string{"release", "notify"},
+		},
+		{
+			name: "invalid condition - task is skipped",
+			tasks: []shipyardTask{
+				task("evaluation"),
+				conditionalTask("approval", "evaluation is warning"),
+			},
+			previousTasks: []TaskExecutionResult{
+				result("evaluation", keptnv2.ResultWarning, keptnv2.StatusSucceeded),
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newShipyardTaskSequenceExecution("delivery", tt.tasks...)
+			e.Status.PreviousTasks = tt.previousTasks
+			var got []string
+			for _, task := range e.GetNextTasksOfSequence() {
+				got = append(got, task.Name)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSequenceExecution_CompleteCurrentTask_ParallelTasks(t *testing.T) {
+	e := newShipyardTaskSequenceExecution("delivery",
+		shipyardTask{Task: keptnv2.Task{Name: "test"}, TaskAttributes: common.TaskAttributes{Parallel: "verification"}},
+		shipyardTask{Task: keptnv2.Task{Name: "security-scan"}, TaskAttributes: common.TaskAttributes{Parallel: "verification"}},
+	)
+	e.SetNextCurrentTask("test", "tr1")
+	e.AddParallelTask("security-scan", "tr2")
+
+	require.True(t, e.Status.IsCurrentTask("tr1"))
+	require.True(t, e.Status.IsCurrentTask("tr2"))
+	require.False(t, e.Status.IsCurrentTask("tr3"))
+
+	e.Status.CurrentTask.Events = []TaskEvent{
+		{EventType: keptnv2.GetStartedEventType("test")},
+		{EventType: keptnv2.GetFinishedEventType("test"), Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded},
+	}
+	require.False(t, e.Status.AreCurrentTasksFinished())
+
+	e.Status.ParallelTasks[0].Events = []TaskEvent{
+		{EventType: keptnv2.GetStartedEventType("security-scan")},
+		{
+			EventType:  keptnv2.GetFinishedEventType("security-scan"),
+			Result:     keptnv2.ResultWarning,
+			Status:     keptnv2.StatusSucceeded,
+			Properties: map[string]interface{}{"security-scan": map[string]interface{}{"findings": 2.0}},
+		},
+	}
+	require.True(t, e.Status.AreCurrentTasksFinished())
+
+	result, status := e.CompleteCurrentTask()
+	require.Equal(t, keptnv2.ResultWarning, result)
+	require.Equal(t, keptnv2.StatusSucceeded, status)
+
+	require.Equal(t, []TaskExecutionResult{
+		{Name: "test", TriggeredID: "tr1", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded},
+		{
+			Name:        "security-scan",
+			TriggeredID: "tr2",
+			Result:      keptnv2.ResultWarning,
+			Status:      keptnv2.StatusSucceeded,
+			Properties:  map[string]interface{}{"security-scan": map[string]interface{}{"findings": 2.0}},
+		},
+	}, e.Status.PreviousTasks)
+	require.Empty(t, e.Status.GetCurrentTasks())
+	require.Nil(t, e.GetNextTaskOfSequence())
+}
+
+func TestSequenceExecution_AddParallelTask_Approval(t *testing.T) {
+	e := &SequenceExecution{}
+	e.SetNextCurrentTask(keptnv2.ApprovalTaskName, "tr1")
+	e.AddParallelTask("notify", "tr2")
+
+	require.Equal(t, models.SequenceWaitingForApprovalState, e.Status.State)
+
+	e = &SequenceExecution{}
+	e.SetNextCurrentTask("notify", "tr1")
+	e.AddParallelTask(keptnv2.ApprovalTaskName, "tr2")
+
+	require.Equal(t, models.SequenceWaitingForApprovalState, e.Status.State)
+}
+
+func TestSequenceExecution_GetSequenceResult(t *testing.T) {
+	e := &SequenceExecution{
+		Status: SequenceExecutionStatus{
+			PreviousTasks: []TaskExecutionResult{
+				{Name: "evaluation", Result: keptnv2.ResultFailed, Status: keptnv2.StatusSucceeded},
+				{Name: "rollback", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded},
+			},
+		},
+	}
+	result, status := e.GetSequenceResult()
+	require.Equal(t, keptnv2.ResultFailed, result)
+	require.Equal(t, keptnv2.StatusSucceeded, status)
+
+	e.Status.PreviousTasks = []TaskExecutionResult{
+		{Name: "evaluation", Result: keptnv2.ResultWarning, Status: keptnv2.StatusSucceeded},
+		{Name: "release", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded},
+	}
+	result, status = e.GetSequenceResult()
+	require.Equal(t, keptnv2.ResultPass, result)
+	require.Equal(t, keptnv2.StatusSucceeded, status)
+}
+
+func TestSequenceExecution_GetTriggeredEventDataForTask(t *testing.T) {
+	e := &SequenceExecution{
+		Scope: EventScope{
+			EventData: keptnv2.EventData{Project: "my-project", Stage: "my-stage", Service: "my-service"},
+		},
+	}
+
+	got := e.GetTriggeredEventDataForTask(&keptnv2.Task{
+		Name: "test",
+		Properties: map[string]interface{}{
+			"timeout":      "5m",
+			"teststrategy": "performance",
+		},
+	})
+
+	// the properties of the task are passed to the task executors as they are, even if they use the name of a task attribute
+	require.Equal(t, map[string]interface{}{
+		"project": "my-project",
+		"stage":   "my-stage",
+		"service": "my-service",
+		"test": map[string]interface{}{
+			"timeout":      "5m",
+			"teststrategy": "performance",
+		},
+	}, got)
+}
+
+func TestSequenceExecution_RetryTask(t *testing.T) {
+	retryPolicy := &common.TaskRetryPolicy{MaxRetries: 2, Backoff: "10s", RetryOn: []string{common.RetryOnErrored, common.RetryOnFailed}}
+	e := newShipyardTaskSequenceExecution("delivery",
+		shipyardTask{Task: keptnv2.Task{Name: "deployment"}, TaskAttributes: common.TaskAttributes{Retry: retryPolicy}},
+		shipyardTask{Task: keptnv2.Task{Name: "test"}},
+	)
+	e.SetNextCurrentTask("deployment", "tr1")
+
+	finishTask := func(result keptnv2.ResultType, status keptnv2.StatusType) {
+		e.Status.CurrentTask.Events = []TaskEvent{
+			{EventType: keptnv2.GetStartedEventType("deployment")},
+			{EventType: keptnv2.GetFinishedEventType("deployment"), Result: result, Status: status, Time: "2022-01-01T00:00:00.000Z"},
+		}
+	}
+
+	// a running task is not retried
+	require.Empty(t, e.GetTasksToRetry())
+
+	finishTask(keptnv2.ResultFailed, keptnv2.StatusErrored)
+	retries := e.GetTasksToRetry()
+	require.Len(t, retries, 1)
+	require.Equal(t, "deployment", retries[0].Task.Name)
+	require.Equal(t, "tr1", retries[0].TriggeredID)
+	require.Equal(t, 10*time.Second, retries[0].Backoff)
+
+	require.True(t, e.RetryTask("tr1", "tr2"))
+	require.Equal(t, "tr2", e.Status.CurrentTask.TriggeredID)
+	require.Empty(t, e.Status.CurrentTask.Events)
+	require.Equal(t, []TaskExecutionAttempt{
+		{TriggeredID: "tr1", Result: keptnv2.ResultFailed, Status: keptnv2.StatusErrored, FinishedAt: "2022-01-01T00:00:00.000Z"},
+	}, e.Status.CurrentTask.Attempts)
+
+	// the backoff is doubled with each retry
+	finishTask(keptnv2.ResultFailed, keptnv2.StatusSucceeded)
+	retries = e.GetTasksToRetry()
+	require.Len(t, retries, 1)
+	require.Equal(t, 20*time.Second, retries[0].Backoff)
+	require.True(t, e.RetryTask("tr2", "tr3"))
+
+	// the maximum number of retries has been reached
+	finishTask(keptnv2.ResultFailed, keptnv2.StatusErrored)
+	require.Empty(t, e.GetTasksToRetry())
+
+	e.CompleteCurrentTask()
+	require.Len(t, e.Status.PreviousTasks, 1)
+	require.Len(t, e.Status.PreviousTasks[0].Attempts, 2)
+	require.False(t, e.RetryTask("tr3", "tr4"))
+}
+
+func TestSequenceExecution_GetTasksToRetry_RetryOn(t *testing.T) {
+	e := newShipyardTaskSequenceExecution("delivery",
+		shipyardTask{Task: keptnv2.Task{Name: "deployment"}, TaskAttributes: common.TaskAttributes{Retry: &common.TaskRetryPolicy{MaxRetries: 1}}},
+	)
+	e.SetNextCurrentTask("deployment", "tr1")
+
+	// by default, only errored tasks are retried
+	e.Status.CurrentTask.Events = []TaskEvent{
+		{EventType: keptnv2.GetStartedEventType("deployment")},
+		{EventType: keptnv2.GetFinishedEventType("deployment"), Result: keptnv2.ResultFailed, Status: keptnv2.StatusSucceeded},
+	}
+	require.Empty(t, e.GetTasksToRetry())
+
+	e.Status.CurrentTask.Events = []TaskEvent{
+		{EventType: keptnv2.GetStartedEventType("deployment")},
+		{EventType: keptnv2.GetFinishedEventType("deployment"), Result: keptnv2.ResultFailed, Status: keptnv2.StatusErrored},
+	}
+	require.Len(t, e.GetTasksToRetry(), 1)
+}
+
+func TestSequenceExecution_GetTaskAttemptHistory(t *testing.T) {
+	e := &SequenceExecution{
+		Scope: EventScope{EventData: keptnv2.EventData{Stage: "dev"}},
+		Status: SequenceExecutionStatus{
+			PreviousTasks: []TaskExecutionResult{
+				{
+					Name:        "deployment",
+					TriggeredID: "tr2",
+					Result:      keptnv2.ResultPass,
+					Status:      keptnv2.StatusSucceeded,
+					Attempts:    []TaskExecutionAttempt{{TriggeredID: "tr1", Result: keptnv2.ResultFailed, Status: keptnv2.StatusErrored}},
+				},
+			},
+			CurrentTask: TaskExecutionState{
+				Name:        "test",
+				TriggeredID: "tr4",
+				Events:      []TaskEvent{{EventType: keptnv2.GetStartedEventType("test")}},
+				Attempts:    []TaskExecutionAttempt{{TriggeredID: "tr3", Result: keptnv2.ResultFailed, Status: keptnv2.StatusErrored}},
+			},
+		},
+	}
+
+	require.Equal(t, []TaskAttemptHistory{
+		{
+			Stage:       "dev",
+			Task:        "deployment",
+			TriggeredID: "tr2",
+			Result:      keptnv2.ResultPass,
+			Status:      keptnv2.StatusSucceeded,
+			Attempts:    []TaskExecutionAttempt{{TriggeredID: "tr1", Result: keptnv2.ResultFailed, Status: keptnv2.StatusErrored}},
+		},
+		{
+			Stage:       "dev",
+			Task:        "test",
+			TriggeredID: "tr4",
+			Attempts:    []TaskExecutionAttempt{{TriggeredID: "tr3", Result: keptnv2.ResultFailed, Status: keptnv2.StatusErrored}},
+		},
+	}, e.GetTaskAttemptHistory())
+}
+
+func TestSequenceExecution_GetPreviousTasksForRetry(t *testing.T) {
+	sequence := keptnv2.Sequence{
+		Name: "delivery",
+		Tasks: []keptnv2.Task{
+			{Name: "deployment"},
+			{Name: "test"},
+			{Name: "security-scan"},
+			{Name: "evaluation"},
+			{Name: "release"},
+		},
+	}
+	taskAttributes := []common.TaskAttributes{{}, {Parallel: "checks"}, {Parallel: "checks"}, {}, {}}
+	previousTasks := []TaskExecutionResult{
+		{Name: "deployment", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded, Properties: map[string]interface{}{"deploymentURI": "my-url"}},
+		{Name: "test", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded},
+		{Name: "security-scan", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded},
+		{Name: "evaluation", Result: keptnv2.ResultFailed, Status: keptnv2.StatusSucceeded},
+	}
+
+	tests := []struct {
+		name          string
+		previousTasks []TaskExecutionResult
+		fromTask      string
+		want          []TaskExecutionResult
+		wantErr       bool
+	}{
+		{
+			name:          "retry from first failed task",
+			previousTasks: previousTasks,
+			want:          previousTasks[:3],
+		},
+		{
+			name:          "retry from given task",
+			previousTasks: previousTasks,
+			fromTask:      "deployment",
+			want:          []TaskExecutionResult{},
+		},
+		{
+			name:          "retry from task of parallel group",
+			previousTasks: previousTasks,
+			fromTask:      "security-scan",
+			want:          previousTasks[:1],
+		},
+		{
+			name:          "retry from next task of aborted sequence",
+			previousTasks: previousTasks[:1],
+			fromTask:      "test",
+			want:          previousTasks[:1],
+		},
+		{
+			name:          "retry aborted sequence",
+			previousTasks: previousTasks[:3],
+			want:          previousTasks[:3],
+		},
+		{
+			name:          "task has not been executed",
+			previousTasks: previousTasks,
+			fromTask:      "release",
+			wantErr:       true,
+		},
+		{
+			name:          "unknown task",
+			previousTasks: previousTasks,
+			fromTask:      "unknown",
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &SequenceExecution{
+				Sequence: sequence,
+				Status: SequenceExecutionStatus{
+					State:         models.SequenceFinished,
+					PreviousTasks: tt.previousTasks,
+				},
+				TaskAttributes: taskAttributes,
+			}
+			got, err := e.GetPreviousTasksForRetry(tt.fromTask)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSequenceExecution_CanBeRetried(t *testing.T) {
+	sequence := keptnv2.Sequence{
+		Name:  "delivery",
+		Tasks: []keptnv2.Task{{Name: "deployment"}, {Name: "release"}},
+	}
+	tests := []struct {
+		name   string
+		status SequenceExecutionStatus
+		want   bool
+	}{
+		{
+			name: "failed sequence",
+			status: SequenceExecutionStatus{
+				State:         models.SequenceFinished,
+				PreviousTasks: []TaskExecutionResult{{Name: "deployment", Result: keptnv2.ResultFailed, Status: keptnv2.StatusSucceeded}},
+			},
+			want: true,
+		},
+		{
+			name: "aborted sequence",
+			status: SequenceExecutionStatus{
+				State:         models.SequenceAborted,
+				PreviousTasks: []TaskExecutionResult{{Name: "deployment", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded}},
+			},
+			want: true,
+		},
+		{
+			name: "successful sequence",
+			status: SequenceExecutionStatus{
+				State: models.SequenceFinished,
+				PreviousTasks: []TaskExecutionResult{
+					{Name: "deployment", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded},
+					{Name: "release", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded},
+				},
+			},
+			want: false,
+		},
+		{
+			name:   "running sequence",
+			status: SequenceExecutionStatus{State: models.SequenceStartedState},
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &SequenceExecution{Sequence: sequence, Status: tt.status}
+			require.Equal(t, tt.want, e.CanBeRetried())
+		})
+	}
+}
+
+func TestSequenceExecution_GetExpiredTimeout(t *testing.T) {
+	startedAt := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	sequence := keptnv2.Sequence{
+		Name: "delivery",
+		Tasks: []keptnv2.Task{
+			{Name: "deployment"},
+			{Name: "release"},
+		},
+	}
+	taskAttributes := []common.TaskAttributes{{Timeout: "10m"}, {}}
+	startedTask := TaskExecutionState{
+		Name:        "deployment",
+		TriggeredID: "deployment-id",
+		Events: []TaskEvent{
+			{EventType: keptnv2.GetStartedEventType("deployment"), Source: "helm-service", Time: "2022-03-01T10:05:00.000Z"},
+		},
+	}
+	tests := []struct {
+		name       string
+		timeout    string
+		task       TaskExecutionState
+		now        time.Time
+		wantReason SequenceTimeoutReason
+		want       string
+	}{
+		{
+			name: "no timeout expired",
+			task: startedTask,
+			now:  startedAt.Add(10 * time.Minute),
+			want: "",
+		},
+		{
+			name:       "task timeout expired",
+			task:       startedTask,
+			now:        startedAt.Add(16 * time.Minute),
+			wantReason: SequenceTimeoutReasonTask,
+			want:       "task deployment of sequence delivery timed out after 10m0s",
+		},
+		{
+			name: "task has not been started",
+			task: TaskExecutionState{Name: "deployment", TriggeredID: "deployment-id"},
+			now:  startedAt.Add(time.Hour),
+			want: "",
+		},
+		{
+			name:       "sequence timeout expired",
+			timeout:    "30m",
+			task:       TaskExecutionState{Name: "deployment", TriggeredID: "deployment-id"},
+			now:        startedAt.Add(31 * time.Minute),
+			wantReason: SequenceTimeoutReasonSequence,
+			want:       "sequence delivery timed out after 30m",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &SequenceExecution{
+				Sequence: sequence,
+				Status: SequenceExecutionStatus{
+					State:       models.SequenceStartedState,
+					StartedAt:   startedAt,
+					CurrentTask: tt.task,
+				},
+				Timeout:        tt.timeout,
+				TaskAttributes: taskAttributes,
+			}
+			reason, msg := e.GetExpiredTimeout(tt.now)
+			require.Equal(t, tt.wantReason, reason)
+			require.Equal(t, tt.want, msg)
+		})
+	}
+}
+
+func TestSequenceExecution_CompleteTimedOutTasks(t *testing.T) {
+	e := &SequenceExecution{
+		Sequence: keptnv2.Sequence{Name: "delivery"},
+		Status: SequenceExecutionStatus{
+			CurrentTask: TaskExecutionState{
+				Name:        "test",
+				TriggeredID: "test-id",
+				Events: []TaskEvent{
+					{EventType: keptnv2.GetStartedEventType("test"), Source: "jmeter-service"},
+				},
+			},
+			ParallelTasks: []TaskExecutionState{
+				{
+					Name:        "security-scan",
+					TriggeredID: "security-scan-id",
+					Events: []TaskEvent{
+						{EventType: keptnv2.GetStartedEventType("security-scan"), Source: "scanner"},
+						{EventType: keptnv2.GetFinishedEventType("security-scan"), Source: "scanner", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded},
+					},
+				},
+			},
+		},
+	}
+
+	e.CompleteTimedOutTasks()
+
+	require.Empty(t, e.Status.GetCurrentTasks())
+	require.Len(t, e.Status.PreviousTasks, 2)
+	require.Equal(t, "test", e.Status.PreviousTasks[0].Name)
+	require.Equal(t, keptnv2.ResultFailed, e.Status.PreviousTasks[0].Result)
+	require.Equal(t, keptnv2.StatusErrored, e.Status.PreviousTasks[0].Status)
+	require.Equal(t, "security-scan", e.Status.PreviousTasks[1].Name)
+	require.Equal(t, keptnv2.ResultPass, e.Status.PreviousTasks[1].Result)
+	require.Equal(t, keptnv2.StatusSucceeded, e.Status.PreviousTasks[1].Status)
+}
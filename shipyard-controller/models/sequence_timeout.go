@@ -0,0 +1,34 @@
+package models
+
+import (
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+// SequenceTimeoutReason states which timeout has expired for a sequence that has timed out
+type SequenceTimeoutReason string
+
+const (
+	// SequenceTimeoutReasonTaskNotStarted is used if a task has not been started within the TASK_STARTED_WAIT_DURATION
+	SequenceTimeoutReasonTaskNotStarted SequenceTimeoutReason = "taskNotStarted"
+	// SequenceTimeoutReasonTask is used if the timeout of a task, as defined in the shipyard, has expired
+	SequenceTimeoutReasonTask SequenceTimeoutReason = "task"
+	// SequenceTimeoutReasonSequence is used if the timeout of the sequence, as defined in the shipyard, has expired
+	SequenceTimeoutReasonSequence SequenceTimeoutReason = "sequence"
+)
+
+// SequenceTimeout is used to tell the shipyard controller that a sequence has timed out
+type SequenceTimeout struct {
+	apimodels.SequenceTimeout
+	// Reason states which timeout has expired. If it is empty, the sequence has timed out while waiting for a task to be started
+	Reason SequenceTimeoutReason
+	// Message describes the timeout that has expired
+	Message string
+}
+
+// SequenceTimedOutEventData is the data of the .finished event of a sequence that has timed out
+type SequenceTimedOutEventData struct {
+	keptnv2.EventData
+	// TimedOut states which timeout has expired
+	TimedOut SequenceTimeoutReason `json:"timedOut"`
+}
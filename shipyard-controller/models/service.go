@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type CreateServiceParams struct {
 	// name
 	ServiceName *string `json:"serviceName"`
@@ -20,3 +22,25 @@ type GetServiceParams struct {
 	//The number of items to return
 	PageSize *int64 `form:"pageSize"`
 }
+
+// UpdateServiceParams contains the properties of a service that can be updated. Properties that are not set remain unchanged
+type UpdateServiceParams struct {
+	// ServiceName is the new name of the service. Renaming a service moves its resources, its state and the subscriptions referring to it
+	ServiceName *string `json:"serviceName,omitempty"`
+	// Description is a human-readable description of the service
+	Description *string `json:"description,omitempty"`
+	// Owners are the teams or persons responsible for the service
+	Owners []string `json:"owners,omitempty"`
+	// Labels are arbitrary key-value pairs attached to the service
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ServiceMetadata contains the descriptive properties of a service, which apply to all stages of the project
+type ServiceMetadata struct {
+	Project     string            `json:"project" bson:"project"`
+	Service     string            `json:"service" bson:"service"`
+	Description string            `json:"description,omitempty" bson:"description,omitempty"`
+	Owners      []string          `json:"owners,omitempty" bson:"owners,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty" bson:"labels,omitempty"`
+	UpdatedAt   *time.Time        `json:"updatedAt,omitempty" bson:"updatedAt,omitempty"`
+}
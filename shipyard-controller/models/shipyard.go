@@ -0,0 +1,59 @@
+package models
+
+// ValidateShipyardParams contains the shipyard that should be validated
+type ValidateShipyardParams struct {
+	// Shipyard is the base64 encoded shipyard. If it is empty, the current shipyard of the project is validated
+	Shipyard string `json:"shipyard,omitempty"`
+}
+
+// ShipyardValidationResult contains the result of the validation of a shipyard
+type ShipyardValidationResult struct {
+	// Valid indicates that the shipyard does not contain any errors
+	Valid bool `json:"valid"`
+	// Errors contains the problems that prevent the shipyard from being used
+	Errors []string `json:"errors"`
+	// Warnings contains potential problems of the shipyard, e.g. tasks that no integration is subscribed to
+	Warnings []string `json:"warnings"`
+}
+
+// PlanSequenceParams describes the trigger of a sequence whose execution plan should be determined
+type PlanSequenceParams struct {
+	// Stage is the name of the stage in which the sequence is triggered
+	Stage string `json:"stage" binding:"required"`
+	// Sequence is the name of the sequence that is triggered
+	Sequence string `json:"sequence" binding:"required"`
+	// Result is the assumed result of each sequence, which determines the subsequent sequences that are triggered. Defaults to 'pass'
+	Result string `json:"result,omitempty"`
+	// Shipyard is the base64 encoded shipyard. If it is empty, the current shipyard of the project is used
+	Shipyard string `json:"shipyard,omitempty"`
+}
+
+// SequencePlan describes the sequences and tasks that are executed if a sequence is triggered
+type SequencePlan struct {
+	// Steps contains the sequences that are executed, in the order in which they are triggered
+	Steps []SequencePlanStep `json:"steps"`
+}
+
+// SequencePlanStep describes the execution of a sequence in a stage
+type SequencePlanStep struct {
+	Stage    string `json:"stage"`
+	Sequence string `json:"sequence"`
+	// TriggeredBy is the event that triggers the sequence, e.g. 'dev.delivery.finished'. It is empty for the sequence that is triggered initially
+	TriggeredBy string `json:"triggeredBy,omitempty"`
+	// Timeout is the maximum duration of the sequence
+	Timeout string        `json:"timeout,omitempty"`
+	Tasks   []PlannedTask `json:"tasks"`
+}
+
+// PlannedTask describes a task that is executed as part of a sequence
+type PlannedTask struct {
+	Name string `json:"name"`
+	// Parallel is the name of the parallel group the task belongs to
+	Parallel string `json:"parallel,omitempty"`
+	// When is the condition that needs to be met for the task to be executed
+	When string `json:"when,omitempty"`
+	// Timeout is the maximum duration of the task
+	Timeout string `json:"timeout,omitempty"`
+	// Integrations contains the names of the integrations that are subscribed to the task
+	Integrations []string `json:"integrations"`
+}
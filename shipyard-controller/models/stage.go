@@ -11,3 +11,19 @@ type GetStagesParams struct {
 	//Name of the project
 	ProjectName string `form:"-"`
 }
+
+// CreateStageParams contains the stage that should be added to a project
+type CreateStageParams struct {
+	// StageName is the name of the stage
+	StageName string `json:"stageName" binding:"required"`
+	// Position is the zero-based index of the stage within the stages of the shipyard. If not set, the stage is added as the last stage
+	Position *int `json:"position,omitempty"`
+}
+
+// UpdateStageParams contains the changes to a stage of a project. At least one of the fields must be set
+type UpdateStageParams struct {
+	// StageName is the new name of the stage
+	StageName string `json:"stageName,omitempty"`
+	// Position is the new zero-based index of the stage within the stages of the shipyard
+	Position *int `json:"position,omitempty"`
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/keptn/keptn/shipyard-controller/metrics"
 	"github.com/nats-io/nats.go"
 )
 
@@ -25,5 +26,9 @@ func (p *Publisher) Send(ctx context.Context, event cloudevents.Event) error {
 	if err != nil {
 		return err
 	}
-	return p.natsConnection.Publish(event.Type(), marshal)
+	if err := p.natsConnection.Publish(event.Type(), marshal); err != nil {
+		metrics.NATSPublishFailures.WithLabelValues(event.Type()).Inc()
+		return err
+	}
+	return nil
 }
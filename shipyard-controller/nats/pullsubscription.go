@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/cp-common/tracing"
 	"github.com/nats-io/nats.go"
 	logger "github.com/sirupsen/logrus"
 )
@@ -86,6 +87,7 @@ func (ps *PullSubscription) processMessage(msg *nats.Msg) {
 		}
 		return
 	}
+	tracing.ApplyCloudEventAttributes(msg.Data, event)
 	if err := ps.messageHandler(*event, false); err != nil {
 		logger.WithError(err).Error("could not process message")
 	}
@@ -0,0 +1,69 @@
+package tracing
+
+import (
+	"context"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/types"
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	commontracing "github.com/keptn/keptn/cp-common/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/keptn/keptn/shipyard-controller"
+
+// NewTracerProvider creates a TracerProvider that exports spans to an OTLP collector via HTTP. The exporter is configured via the OTEL_EXPORTER_OTLP_* environment variables,
+// and is only created if the given endpoint, i.e. the value of OTEL_EXPORTER_OTLP_ENDPOINT, is not empty.
+// Without an exporter, no spans are exported, but the trace context of the events is still propagated
+func NewTracerProvider(ctx context.Context, serviceName, endpoint string) (*sdktrace.TracerProvider, error) {
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName))),
+	}
+	if endpoint != "" {
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+	return sdktrace.NewTracerProvider(options...), nil
+}
+
+// StartEventSpan starts a span that continues the trace of the given event
+func StartEventSpan(event apimodels.KeptnContextExtendedCE, spanName string) (context.Context, trace.Span) {
+	eventType := ""
+	if event.Type != nil {
+		eventType = *event.Type
+	}
+	return startSpan(commontracing.ContextFromEvent(context.Background(), event), spanName, event.Shkeptncontext, event.ID, eventType)
+}
+
+// StartCloudEventSpan starts a span that continues the trace of the given CloudEvent
+func StartCloudEventSpan(event cloudevents.Event, spanName string) (context.Context, trace.Span) {
+	keptnContext, _ := types.ToString(event.Extensions()["shkeptncontext"])
+	return startSpan(commontracing.ContextFromCloudEvent(context.Background(), event), spanName, keptnContext, event.ID(), event.Type())
+}
+
+func startSpan(ctx context.Context, spanName, keptnContext, eventID, eventType string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, spanName, trace.WithAttributes(
+		attribute.String("keptn.context", keptnContext),
+		attribute.String("keptn.event.id", eventID),
+		attribute.String("keptn.event.type", eventType),
+	))
+}
+
+// EndSpan ends the given span and marks it as failed if an error is passed
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
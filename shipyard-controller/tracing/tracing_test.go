@@ -0,0 +1,46 @@
+package tracing
+
+import (
+	"testing"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	commontracing "github.com/keptn/keptn/cp-common/tracing"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func setupSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+	})
+	return recorder
+}
+
+func TestStartEventSpan(t *testing.T) {
+	recorder := setupSpanRecorder(t)
+	eventType := "sh.keptn.event.deployment.triggered"
+	event := apimodels.KeptnContextExtendedCE{
+		ID:             "my-id",
+		Shkeptncontext: "my-context",
+		Type:           &eventType,
+		Extensions:     map[string]interface{}{commontracing.TraceParentExtension: testTraceParent},
+	}
+
+	ctx, span := StartEventSpan(event, "my-span")
+	commontracing.InjectIntoEvent(ctx, &event)
+	EndSpan(span, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "my-span", spans[0].Name())
+	require.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	require.Contains(t, event.Extensions.(map[string]interface{})[commontracing.TraceParentExtension], spans[0].SpanContext().SpanID().String())
+}